@@ -25,9 +25,16 @@ const (
 	// ApplicationV1_4_2 is the capabilties string for standard new non-backwards compatible fabric v1.4.2 application capabilities.
 	ApplicationV1_4_2 = "V1_4_2"
 
+	// ApplicationV1_4_3 is the capabilties string for standard new non-backwards compatible fabric v1.4.3 application capabilities.
+	ApplicationV1_4_3 = "V1_4_3"
+
 	// ApplicationPvtDataExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationPvtDataExperimental = "V1_1_PVTDATA_EXPERIMENTAL"
 
+	// ApplicationCrossChannelWritesExperimental is the capabilties string for the experimental writes of
+	// the chaincodes invoked by the chaincodes of another channel.
+	ApplicationCrossChannelWritesExperimental = "V1_4_3_CROSSCHANNEL_EXPERIMENTAL"
+
 	// ApplicationResourcesTreeExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
 )
@@ -39,7 +46,9 @@ type ApplicationProvider struct {
 	v12                    bool
 	v13                    bool
 	v142                   bool
+	v143                   bool
 	v11PvtDataExperimental bool
+	crossChannelWrites     bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -50,7 +59,9 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v12 = capabilities[ApplicationV1_2]
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v142 = capabilities[ApplicationV1_4_2]
+	_, ap.v143 = capabilities[ApplicationV1_4_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.crossChannelWrites = capabilities[ApplicationCrossChannelWritesExperimental]
 	return ap
 }
 
@@ -61,44 +72,44 @@ func (ap *ApplicationProvider) Type() string {
 
 // ACLs returns whether ACLs may be specified in the channel application config
 func (ap *ApplicationProvider) ACLs() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
 // in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
 func (ap *ApplicationProvider) ForbidDuplicateTXIdInBlock() bool {
-	return ap.v11 || ap.v12 || ap.v13 || ap.v142
+	return ap.v11 || ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // PrivateChannelData returns true if support for private channel data (a.k.a. collections) is enabled.
 // In v1.1, the private channel data is experimental and has to be enabled explicitly.
 // In v1.2, the private channel data is enabled by default.
 func (ap *ApplicationProvider) PrivateChannelData() bool {
-	return ap.v11PvtDataExperimental || ap.v12 || ap.v13 || ap.v142
+	return ap.v11PvtDataExperimental || ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // CollectionUpgrade returns true if this channel is configured to allow updates to
 // existing collection or add new collections through chaincode upgrade (as introduced in v1.2)
 func (ap ApplicationProvider) CollectionUpgrade() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // V1_1Validation returns true is this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.1).
 func (ap *ApplicationProvider) V1_1Validation() bool {
-	return ap.v11 || ap.v12 || ap.v13 || ap.v142
+	return ap.v11 || ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // V1_2Validation returns true if this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.2).
 func (ap *ApplicationProvider) V1_2Validation() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143
 }
 
 // V1_3Validation returns true if this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.3).
 func (ap *ApplicationProvider) V1_3Validation() bool {
-	return ap.v13 || ap.v142
+	return ap.v13 || ap.v142 || ap.v143
 }
 
 // MetadataLifecycle indicates whether the peer should use the deprecated and problematic
@@ -111,7 +122,7 @@ func (ap *ApplicationProvider) MetadataLifecycle() bool {
 // KeyLevelEndorsement returns true if this channel supports endorsement
 // policies expressible at a ledger key granularity, as described in FAB-8812
 func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
-	return ap.v13 || ap.v142
+	return ap.v13 || ap.v142 || ap.v143
 }
 
 // There is no fabtoken support in v1.4, so always return false
@@ -122,7 +133,21 @@ func (ap *ApplicationProvider) FabToken() bool {
 // StorePvtDataOfInvalidTx returns true if the peer needs to store
 // the pvtData of invalid transactions.
 func (ap *ApplicationProvider) StorePvtDataOfInvalidTx() bool {
-	return ap.v142
+	return ap.v142 || ap.v143
+}
+
+// MultipleChaincodeEvents returns true if the chaincodes of this channel may
+// emit more than one event per transaction.
+func (ap *ApplicationProvider) MultipleChaincodeEvents() bool {
+	return ap.v143
+}
+
+// CrossChannelWrites returns true if the chaincodes of this channel may write
+// to the chaincodes of another channel they invoke, and vice versa, through
+// cross-channel write intents. It is an experimental feature which has to be
+// enabled explicitly on both channels.
+func (ap *ApplicationProvider) CrossChannelWrites() bool {
+	return ap.crossChannelWrites
 }
 
 // HasCapability returns true if the capability is supported by this binary.
@@ -137,10 +162,14 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationV1_4_2:
 		return true
+	case ApplicationV1_4_3:
+		return true
 	case ApplicationPvtDataExperimental:
 		return true
 	case ApplicationResourcesTreeExperimental:
 		return true
+	case ApplicationCrossChannelWritesExperimental:
+		return true
 	default:
 		return false
 	}
@@ -71,6 +71,28 @@ func TestApplicationV142(t *testing.T) {
 	assert.True(t, ap.PrivateChannelData())
 }
 
+func TestApplicationV143(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_4_3: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.MultipleChaincodeEvents())
+	assert.True(t, ap.StorePvtDataOfInvalidTx())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.True(t, ap.V1_1Validation())
+	assert.True(t, ap.V1_2Validation())
+	assert.True(t, ap.V1_3Validation())
+	assert.True(t, ap.KeyLevelEndorsement())
+	assert.True(t, ap.ACLs())
+	assert.True(t, ap.CollectionUpgrade())
+	assert.True(t, ap.PrivateChannelData())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_4_2: {},
+	})
+	assert.False(t, ap.MultipleChaincodeEvents())
+}
+
 func TestApplicationPvtDataExperimental(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{
 		ApplicationPvtDataExperimental: {},
@@ -78,6 +100,20 @@ func TestApplicationPvtDataExperimental(t *testing.T) {
 	assert.True(t, ap.PrivateChannelData())
 }
 
+func TestApplicationCrossChannelWritesExperimental(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCrossChannelWritesExperimental: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.CrossChannelWrites())
+
+	// the experimental feature isn't enabled by the versions
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_4_3: {},
+	})
+	assert.False(t, ap.CrossChannelWrites())
+}
+
 func TestFabToken(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.False(t, ap.FabToken())
@@ -88,7 +124,10 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_1))
 	assert.True(t, ap.HasCapability(ApplicationV1_2))
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
+	assert.True(t, ap.HasCapability(ApplicationV1_4_2))
+	assert.True(t, ap.HasCapability(ApplicationV1_4_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationCrossChannelWritesExperimental))
 	assert.False(t, ap.HasCapability("default"))
 }
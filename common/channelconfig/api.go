@@ -184,6 +184,14 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// MultipleChaincodeEvents returns true if the chaincodes of this channel may
+	// emit more than one event per transaction.
+	MultipleChaincodeEvents() bool
+
+	// CrossChannelWrites returns true if the chaincodes of this channel may write
+	// to the chaincodes of another channel they invoke, and vice versa.
+	CrossChannelWrites() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
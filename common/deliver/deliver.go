@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
@@ -52,6 +53,17 @@ type Chain interface {
 	Errored() <-chan struct{}
 }
 
+//go:generate counterfeiter -o mock/chaincode_event_index.go -fake-name ChaincodeEventIndex . ChaincodeEventIndex
+
+// ChaincodeEventIndex is implemented by the chains whose ledger indexes the chaincode events,
+// so that the blocks matching a chaincode event filter are found without reading the others.
+type ChaincodeEventIndex interface {
+	// ChaincodeEventBlocks returns the numbers, in increasing order, of the blocks of the given
+	// range in which a valid transaction set the given event of the given chaincode. It returns
+	// an error if the chaincode events of the range are not indexed.
+	ChaincodeEventBlocks(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) ([]uint64, error)
+}
+
 //go:generate counterfeiter -o mock/policy_checker.go -fake-name PolicyChecker . PolicyChecker
 
 // PolicyChecker checks the envelope against the policy logic supplied by the
@@ -272,7 +284,7 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
 	cursor, number := chain.Reader().Iterator(seekInfo.Start)
-	defer cursor.Close()
+	defer func() { cursor.Close() }()
 	var stopNum uint64
 	switch stop := seekInfo.Stop.Type.(type) {
 	case *ab.SeekPosition_Oldest:
@@ -295,6 +307,41 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		}
 	}
 
+	if _, notFound := cursor.(*blockledger.NotFoundErrorIterator); !notFound && seekInfo.ChaincodeEventFilter != nil {
+		// the committed blocks matching the filter are looked up in the index, if any, and
+		// the blocks that are not committed yet are read as they are committed
+		if blockNums, lastNum, ok := lookupChaincodeEventBlocks(chain, seekInfo.ChaincodeEventFilter, number, stopNum); ok {
+			for _, blockNum := range blockNums {
+				block, status := readBlock(chain.Reader(), blockNum)
+				if status != cb.Status_SUCCESS {
+					logger.Errorf("[channel: %s] Error reading block [%d] from channel, cause was: %v", chdr.ChannelId, blockNum, status)
+					return status, nil
+				}
+
+				if err := accessControl.Evaluate(); err != nil {
+					logger.Warningf("[channel: %s] Client authorization revoked for deliver request from %s: %s", chdr.ChannelId, addr, err)
+					return cb.Status_FORBIDDEN, nil
+				}
+
+				logger.Debugf("[channel: %s] Delivering block [%d] matching the chaincode event filter of (%p) for %s", chdr.ChannelId, blockNum, seekInfo, addr)
+
+				if err := srv.SendBlockResponse(block); err != nil {
+					logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
+					return cb.Status_INTERNAL_SERVER_ERROR, err
+				}
+
+				h.Metrics.BlocksSent.With(labels...).Add(1)
+			}
+
+			if lastNum == stopNum {
+				logger.Debugf("[channel: %s] Done delivering to %s for (%p)", chdr.ChannelId, addr, seekInfo)
+				return cb.Status_SUCCESS, nil
+			}
+			cursor.Close()
+			cursor, number = chain.Reader().Iterator(seekPositionOf(lastNum + 1))
+		}
+	}
+
 	for {
 		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
 			if number > chain.Reader().Height()-1 {
@@ -337,6 +384,14 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			return cb.Status_FORBIDDEN, nil
 		}
 
+		if !matchesChaincodeEventFilter(block, seekInfo.ChaincodeEventFilter) {
+			logger.Debugf("[channel: %s] Skipping block [%d] not matching the chaincode event filter of (%p) for %s", chdr.ChannelId, block.Header.Number, seekInfo, addr)
+			if stopNum == block.Header.Number {
+				break
+			}
+			continue
+		}
+
 		logger.Debugf("[channel: %s] Delivering block [%d] for (%p) for %s", chdr.ChannelId, block.Header.Number, seekInfo, addr)
 
 		if err := srv.SendBlockResponse(block); err != nil {
@@ -356,6 +411,74 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return cb.Status_SUCCESS, nil
 }
 
+// matchesChaincodeEventFilter returns whether a valid transaction of the block set the
+// chaincode event of the filter. The blocks that were not validated, such as the ones
+// of the ordering service, carry no transaction validation flags and always match.
+func matchesChaincodeEventFilter(block *cb.Block, filter *ab.ChaincodeEventFilter) bool {
+	if filter == nil || block.Data == nil {
+		return true
+	}
+	var txsFilter []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+	if len(txsFilter) == 0 {
+		return true
+	}
+
+	for txNum, envBytes := range block.Data.Data {
+		if txNum >= len(txsFilter) || txsFilter[txNum] != uint8(pb.TxValidationCode_VALID) {
+			continue
+		}
+		events, err := utils.GetChaincodeEventsFromEnvelope(envBytes)
+		if err != nil {
+			logger.Warningf("Failed to extract the chaincode events of transaction [%d] of block [%d]: %s", txNum, block.Header.Number, err)
+			continue
+		}
+		for _, event := range events {
+			if event.ChaincodeId == filter.ChaincodeId && event.EventName == filter.EventName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupChaincodeEventBlocks returns the numbers of the committed blocks from startNum to stopNum
+// matching the chaincode event filter, and the number of the last block looked up, if the chain
+// indexes the chaincode events of these blocks.
+func lookupChaincodeEventBlocks(chain Chain, filter *ab.ChaincodeEventFilter, startNum, stopNum uint64) ([]uint64, uint64, bool) {
+	index, ok := chain.(ChaincodeEventIndex)
+	if !ok {
+		return nil, 0, false
+	}
+	height := chain.Reader().Height()
+	if height == 0 || startNum > height-1 {
+		return nil, 0, false
+	}
+	lastNum := stopNum
+	if lastNum > height-1 {
+		lastNum = height - 1
+	}
+	blockNums, err := index.ChaincodeEventBlocks(filter.ChaincodeId, filter.EventName, startNum, lastNum)
+	if err != nil {
+		logger.Debugf("Reading blocks [%d] to [%d] to match the chaincode event filter, as they cannot be looked up in the index: %s", startNum, lastNum, err)
+		return nil, 0, false
+	}
+	return blockNums, lastNum, true
+}
+
+// readBlock reads a committed block of the chain.
+func readBlock(reader blockledger.Reader, blockNum uint64) (*cb.Block, cb.Status) {
+	cursor, _ := reader.Iterator(seekPositionOf(blockNum))
+	defer cursor.Close()
+	return cursor.Next()
+}
+
+func seekPositionOf(blockNum uint64) *ab.SeekPosition {
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: blockNum}}}
+}
+
 func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
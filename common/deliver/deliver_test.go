@@ -20,12 +20,14 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -42,6 +44,12 @@ var (
 	}
 )
 
+// indexedChain is a chain that indexes the chaincode events
+type indexedChain struct {
+	*mock.Chain
+	*mock.ChaincodeEventIndex
+}
+
 var _ = Describe("Deliver", func() {
 	Describe("NewHandler", func() {
 		var fakeChainManager *mock.ChainManager
@@ -568,6 +576,176 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when a chaincode event filter is set", func() {
+			var blocks map[uint64]*cb.Block
+
+			BeforeEach(func() {
+				blocks = map[uint64]*cb.Block{}
+				for blockNum, event := range map[uint64]*pb.ChaincodeEvent{
+					995: nil,
+					996: {ChaincodeId: "cc1", EventName: "transfer"},
+					997: {ChaincodeId: "cc1", EventName: "transfer"},
+					998: {ChaincodeId: "cc1", EventName: "mint"},
+					999: nil,
+				} {
+					env, _, err := testutil.ConstructTransactionFromTxDetails(&testutil.TxDetails{
+						TxID:              "txid",
+						ChaincodeName:     "cc1",
+						ChaincodeVersion:  "v1",
+						SimulationResults: []byte("results"),
+						ChaincodeEvent:    event,
+					}, false)
+					Expect(err).NotTo(HaveOccurred())
+					txsFilter := []byte{uint8(pb.TxValidationCode_VALID)}
+					// the transaction of block 997 is invalid
+					if blockNum == 997 {
+						txsFilter[0] = uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)
+					}
+					blocks[blockNum] = &cb.Block{
+						Header:   &cb.BlockHeader{Number: blockNum},
+						Data:     &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(env)}},
+						Metadata: &cb.BlockMetadata{Metadata: [][]byte{{}, {}, txsFilter, {}}},
+					}
+				}
+				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
+					return blocks[994+uint64(fakeBlockIterator.NextCallCount())], cb.Status_SUCCESS
+				}
+				seekInfo = &ab.SeekInfo{
+					Start: &ab.SeekPosition{
+						Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 995}},
+					},
+					Stop:                 seekNewest,
+					ChaincodeEventFilter: &ab.ChaincodeEventFilter{ChaincodeId: "cc1", EventName: "transfer"},
+				}
+			})
+
+			It("sends only the blocks with a valid transaction which set the event", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBlockIterator.NextCallCount()).To(Equal(5))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SendBlockResponseArgsForCall(0)).To(Equal(blocks[996]))
+				Expect(fakeBlocksSent.AddCallCount()).To(Equal(1))
+
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+			})
+
+			Context("when the blocks carry no transaction validation flags", func() {
+				BeforeEach(func() {
+					for _, block := range blocks {
+						block.Metadata = nil
+					}
+				})
+
+				It("sends all requested blocks", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+				})
+			})
+
+			Context("when the chain indexes the chaincode events", func() {
+				var fakeChaincodeEventIndex *mock.ChaincodeEventIndex
+
+				BeforeEach(func() {
+					fakeChaincodeEventIndex = &mock.ChaincodeEventIndex{}
+					fakeChaincodeEventIndex.ChaincodeEventBlocksReturns([]uint64{996}, nil)
+					fakeChainManager.GetChainReturns(&indexedChain{Chain: fakeChain, ChaincodeEventIndex: fakeChaincodeEventIndex})
+
+					fakeBlockReader.IteratorStub = func(startPosition *ab.SeekPosition) (blockledger.Iterator, uint64) {
+						startNum := startPosition.GetSpecified().GetNumber()
+						iterator := &mock.BlockIterator{}
+						iterator.NextStub = func() (*cb.Block, cb.Status) {
+							return blocks[startNum+uint64(iterator.NextCallCount())-1], cb.Status_SUCCESS
+						}
+						return iterator, startNum
+					}
+				})
+
+				It("reads and sends only the committed blocks found in the index", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeChaincodeEventIndex.ChaincodeEventBlocksCallCount()).To(Equal(1))
+					chaincodeID, eventName, startNum, endNum := fakeChaincodeEventIndex.ChaincodeEventBlocksArgsForCall(0)
+					Expect(chaincodeID).To(Equal("cc1"))
+					Expect(eventName).To(Equal("transfer"))
+					Expect(startNum).To(Equal(uint64(995)))
+					Expect(endNum).To(Equal(uint64(999)))
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(2))
+					Expect(fakeBlockReader.IteratorArgsForCall(1).GetSpecified().GetNumber()).To(Equal(uint64(996)))
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendBlockResponseArgsForCall(0)).To(Equal(blocks[996]))
+					Expect(fakeBlocksSent.AddCallCount()).To(Equal(1))
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+				})
+
+				Context("when the stop block is not committed yet", func() {
+					BeforeEach(func() {
+						fakeBlockReader.HeightReturns(998)
+						seekInfo.Stop = &ab.SeekPosition{
+							Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 999}},
+						}
+					})
+
+					It("reads the blocks committed after the lookup", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, _, startNum, endNum := fakeChaincodeEventIndex.ChaincodeEventBlocksArgsForCall(0)
+						Expect(startNum).To(Equal(uint64(995)))
+						Expect(endNum).To(Equal(uint64(997)))
+
+						Expect(fakeBlockReader.IteratorCallCount()).To(Equal(3))
+						Expect(fakeBlockReader.IteratorArgsForCall(2).GetSpecified().GetNumber()).To(Equal(uint64(998)))
+						Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+						Expect(fakeResponseSender.SendBlockResponseArgsForCall(0)).To(Equal(blocks[996]))
+						Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+					})
+				})
+
+				Context("when the blocks are not indexed", func() {
+					BeforeEach(func() {
+						fakeChaincodeEventIndex.ChaincodeEventBlocksReturns(nil, errors.New("not indexed"))
+					})
+
+					It("reads each block of the range", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBlockReader.IteratorCallCount()).To(Equal(1))
+						Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+						Expect(fakeResponseSender.SendBlockResponseArgsForCall(0)).To(Equal(blocks[996]))
+						Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+					})
+				})
+
+				Context("when reading a block of the index fails", func() {
+					BeforeEach(func() {
+						fakeBlockReader.IteratorStub = func(*ab.SeekPosition) (blockledger.Iterator, uint64) {
+							iterator := &mock.BlockIterator{}
+							iterator.NextReturns(nil, cb.Status_SERVICE_UNAVAILABLE)
+							return iterator, 995
+						}
+					})
+
+					It("sends the status of the failure", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+						Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+					})
+				})
+			})
+		})
+
 		Context("when sending the block fails", func() {
 			BeforeEach(func() {
 				fakeResponseSender.SendBlockResponseReturns(errors.New("send-fails"))
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package httputil holds the helpers shared by the HTTP handlers which the
+// peer serves on its operations endpoint.
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+var logger = flogging.MustGetLogger("httputil")
+
+// ErrorResponse is the body of the response to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SendJSONResponse writes the payload encoded in JSON, with the given status code, to the
+// response. A payload which is an error is sent as an ErrorResponse.
+func SendJSONResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+	js, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("failed to encode payload: %s", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	resp.Write(js)
+}
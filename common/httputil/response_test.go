@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httputil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendJSONResponse(t *testing.T) {
+	resp := httptest.NewRecorder()
+	SendJSONResponse(resp, http.StatusOK, map[string]int{"count": 1})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"count": 1}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	SendJSONResponse(resp, http.StatusNotFound, errors.New("not found"))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error": "not found"}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	SendJSONResponse(resp, http.StatusOK, func() {})
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Empty(t, resp.Body.String())
+}
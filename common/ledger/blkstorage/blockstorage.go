@@ -25,6 +25,7 @@ const (
 	IndexableAttrBlockNumTranNum  = IndexableAttr("BlockNumTranNum")
 	IndexableAttrBlockTxID        = IndexableAttr("BlockTxID")
 	IndexableAttrTxValidationCode = IndexableAttr("TxValidationCode")
+	IndexableAttrChaincodeEvent   = IndexableAttr("ChaincodeEvent")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -46,6 +47,9 @@ type BlockStoreProvider interface {
 	OpenBlockStore(ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// DropLedger deletes the blocks and the index of the BlockStore with given id,
+	// which must not be open
+	DropLedger(ledgerid string) error
 	Close()
 }
 
@@ -62,5 +66,10 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveChaincodeEvents returns an iterator over the events with the given name set by the given
+	// chaincode in the valid transactions of the given range of blocks, in the order of the transactions.
+	// The results of the iterator are of type *peer.ChaincodeEventInfo. An error is returned if the chaincode events of the
+	// start block are not indexed, as only the blocks committed since the chaincode event index is enabled are indexed
+	RetrieveChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error)
 	Shutdown()
 }
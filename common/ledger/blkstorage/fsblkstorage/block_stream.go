@@ -22,12 +22,15 @@ import (
 var ErrUnexpectedEndOfBlockfile = errors.New("unexpected end of blockfile")
 
 // blockfileStream reads blocks sequentially from a single file.
-// It starts from the given offset and can traverse till the end of the file
+// It starts from the given offset and can traverse till the end of the file.
+// The blocks of a compressed file are returned decompressed
 type blockfileStream struct {
 	fileNum       int
 	file          *os.File
 	reader        *bufio.Reader
 	currentOffset int64
+	compression   blockfileCompression
+	partialHeader bool
 }
 
 // blockStream reads blocks sequentially from multiple files.
@@ -46,6 +49,7 @@ type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	compressed       bool
 }
 
 ///////////////////////////////////
@@ -59,6 +63,16 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
 		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
 	}
+	compression, headerLen, err := readBlockfileHeader(file)
+	partialHeader := err == errPartialBlockfileHeader
+	if err != nil && !partialHeader {
+		file.Close()
+		return nil, err
+	}
+	// the blocks of a compressed file start after its header
+	if startOffset < int64(headerLen) {
+		startOffset = int64(headerLen)
+	}
 	var newPosition int64
 	if newPosition, err = file.Seek(startOffset, 0); err != nil {
 		return nil, errors.Wrapf(err, "error seeking block file [%s] to startOffset [%d]", filePath, startOffset)
@@ -67,7 +81,7 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 		panic(fmt.Sprintf("Could not seek block file [%s] to startOffset [%d]. New position = [%d]",
 			filePath, startOffset, newPosition))
 	}
-	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
+	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset, compression, partialHeader}
 	return s, nil
 }
 
@@ -93,6 +107,9 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		logger.Debugf("Finished reading file number [%d]", s.fileNum)
 		return nil, nil, nil
 	}
+	if s.partialHeader {
+		return nil, nil, ErrUnexpectedEndOfBlockfile
+	}
 	remainingBytes := fileInfo.Size() - s.currentOffset
 	// Peek 8 or smaller number of bytes (if remaining bytes are less than 8)
 	// Assumption is that a block size would be small enough to be represented in 8 bytes varint
@@ -129,10 +146,14 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	if blockBytes, err = s.compression.decompress(blockBytes); err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("error reading block at offset [%d] of file number [%d]", s.currentOffset, s.fileNum))
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
-		blockBytesOffset: s.currentOffset + int64(n)}
+		blockBytesOffset: s.currentOffset + int64(n),
+		compressed:       s.compression != compressionNone}
 	s.currentOffset += int64(n) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
 	return blockBytes, blockPlacementInfo, nil
@@ -194,6 +215,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], compressed=[%t]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.compressed)
 }
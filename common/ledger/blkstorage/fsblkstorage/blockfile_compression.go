@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// The algorithms with which the blocks appended to new block files can be compressed
+const (
+	// NoCompression leaves the blocks uncompressed, as in the legacy block files
+	NoCompression = ""
+	// SnappyCompression compresses the blocks with snappy
+	SnappyCompression = "snappy"
+)
+
+// blockfileCompression is the compression of the blocks of a block file, which is recorded in its header
+type blockfileCompression byte
+
+const (
+	compressionNone blockfileCompression = iota
+	compressionSnappy
+)
+
+// A compressed block file starts with a header, made of a magic prefix, a version and the
+// compression of its blocks. Each block is compressed individually, so that the locations
+// of the blocks in the index keep enabling random access to them. The magic prefix starts
+// with a zero byte, which cannot start a legacy block file as it would encode an empty block.
+var blockfileHeaderMagic = []byte{0x00, 'f', 'b', 'c'}
+
+const blockfileHeaderVersion = 1
+
+var blockfileHeaderLen = len(blockfileHeaderMagic) + 2
+
+// errPartialBlockfileHeader is returned if a block file only contains part of a header,
+// which can happen if a crash occurs while appending the first block to a compressed file
+var errPartialBlockfileHeader = errors.New("partial block file header")
+
+func compressionByName(name string) (blockfileCompression, error) {
+	switch name {
+	case NoCompression:
+		return compressionNone, nil
+	case SnappyCompression:
+		return compressionSnappy, nil
+	default:
+		return compressionNone, errors.Errorf("unsupported block file compression [%s], the supported ones are [%s]", name, SnappyCompression)
+	}
+}
+
+func (c blockfileCompression) String() string {
+	switch c {
+	case compressionNone:
+		return "none"
+	case compressionSnappy:
+		return SnappyCompression
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(c))
+	}
+}
+
+func (c blockfileCompression) compress(blockBytes []byte) []byte {
+	if c == compressionSnappy {
+		return snappy.Encode(nil, blockBytes)
+	}
+	return blockBytes
+}
+
+func (c blockfileCompression) decompress(b []byte) ([]byte, error) {
+	if c != compressionSnappy {
+		return b, nil
+	}
+	blockBytes, err := snappy.Decode(nil, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decompressing block bytes")
+	}
+	return blockBytes, nil
+}
+
+// header returns the header of a block file whose blocks are compressed, or nil for uncompressed blocks
+func (c blockfileCompression) header() []byte {
+	if c == compressionNone {
+		return nil
+	}
+	header := append([]byte{}, blockfileHeaderMagic...)
+	return append(header, blockfileHeaderVersion, byte(c))
+}
+
+// readBlockfileHeader returns the compression of the blocks of the given block file, along with the
+// length of its header, which is zero for a legacy block file. An empty file is reported as legacy.
+func readBlockfileHeader(file *os.File) (blockfileCompression, int, error) {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return compressionNone, 0, errors.Wrapf(err, "error getting block file stat")
+	}
+	headerLen := blockfileHeaderLen
+	if fileInfo.Size() < int64(headerLen) {
+		headerLen = int(fileInfo.Size())
+	}
+	header := make([]byte, headerLen)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return compressionNone, 0, errors.Wrapf(err, "error reading the header of block file %s", file.Name())
+	}
+	if len(header) == 0 || header[0] != blockfileHeaderMagic[0] {
+		return compressionNone, 0, nil
+	}
+	magicLen := len(blockfileHeaderMagic)
+	if len(header) < magicLen {
+		magicLen = len(header)
+	}
+	if !bytes.Equal(header[:magicLen], blockfileHeaderMagic[:magicLen]) {
+		return compressionNone, 0, errors.Errorf("invalid header of block file %s", file.Name())
+	}
+	if len(header) < blockfileHeaderLen {
+		return compressionNone, 0, errPartialBlockfileHeader
+	}
+	if version := header[len(blockfileHeaderMagic)]; version != blockfileHeaderVersion {
+		return compressionNone, 0, errors.Errorf("unsupported version [%d] of block file %s", version, file.Name())
+	}
+	compression := blockfileCompression(header[len(blockfileHeaderMagic)+1])
+	if compression != compressionSnappy {
+		return compressionNone, 0, errors.Errorf("unsupported compression [%s] of block file %s", compression, file.Name())
+	}
+	return compression, blockfileHeaderLen, nil
+}
+
+// retrieveBlockfileCompression returns the compression of the blocks of the given block file
+func retrieveBlockfileCompression(filePath string) (blockfileCompression, error) {
+	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+	if err != nil {
+		return compressionNone, errors.Wrapf(err, "error opening block file %s", filePath)
+	}
+	defer file.Close()
+	compression, _, err := readBlockfileHeader(file)
+	return compression, err
+}
@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockfileCompressionReadWrite(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConfWithCompression(path, 0, SnappyCompression))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	ledgerDir := env.provider.conf.getLedgerBlockDir("testLedger")
+	for fileNum := 0; fileNum < 3; fileNum++ {
+		compression, err := retrieveBlockfileCompression(deriveBlockfilePath(ledgerDir, fileNum))
+		assert.NoError(t, err)
+		assert.Equal(t, compressionSnappy, compression)
+	}
+	// the blocks are actually stored compressed
+	placementInfo, _ := locateBlock(t, ledgerDir, 0)
+	assert.True(t, placementInfo.compressed)
+	assert.Equal(t, int64(blockfileHeaderLen), placementInfo.blockStartOffset)
+
+	env = newTestEnv(t, NewConfWithCompression(path, 0, SnappyCompression))
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+
+	itr, err := blkfileMgrWrapper.blockfileMgr.retrieveBlocks(0)
+	assert.NoError(t, err)
+	defer itr.Close()
+	for _, block := range blocks {
+		b, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, block, b)
+	}
+}
+
+func TestBlockfileCompressionMixedLedger(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks[:10], 10)
+
+	// enabling the compression leaves the current file uncompressed
+	env = newTestEnv(t, NewConfWithCompression(path, 0, SnappyCompression))
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[10:15])
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(blocks[15:20])
+	env.provider.Close()
+	blkfileMgrWrapper.close()
+
+	// disabling the compression leaves the current file compressed
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[20:25])
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(blocks[25:])
+
+	ledgerDir := env.provider.conf.getLedgerBlockDir("testLedger")
+	for fileNum, expectedCompression := range []blockfileCompression{compressionNone, compressionSnappy, compressionNone} {
+		compression, err := retrieveBlockfileCompression(deriveBlockfilePath(ledgerDir, fileNum))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCompression, compression)
+	}
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+	env.provider.Close()
+	blkfileMgrWrapper.close()
+
+	// the block index is verified and rebuilt across the files
+	conf := NewConf(path, 0)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	indexDB := dbProvider.GetDBHandle("testLedger")
+	assert.NoError(t, indexDB.Delete(constructBlockNumTranNumKey(17, 0), true))
+	dbProvider.Close()
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, true, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, result.BlockFileIssues)
+	assert.Equal(t, []string{"entry of transaction [0] of block [17] is missing"}, result.IndexIssues)
+	assert.True(t, result.IndexRebuilt)
+
+	result, err = VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockStoreVerification{Height: 30}, result)
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+}
+
+func TestBlockfileCompressionCrashDuringFirstBlock(t *testing.T) {
+	header := compressionSnappy.header()
+	testBlockfileCompressionCrashDuringFirstBlock(t, header[:2])
+	testBlockfileCompressionCrashDuringFirstBlock(t, header)
+	testBlockfileCompressionCrashDuringFirstBlock(t, append(header, proto.EncodeVarint(1000)...))
+}
+
+func testBlockfileCompressionCrashDuringFirstBlock(t *testing.T, partialBytes []byte) {
+	env := newTestEnv(t, NewConfWithCompression(testPath(), 0, SnappyCompression))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.close()
+
+	filePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir("testLedger"), 1)
+	assert.NoError(t, ioutil.WriteFile(filePath, partialBytes, 0600))
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	assert.Equal(t, uint64(5), blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height)
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	compression, err := retrieveBlockfileCompression(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, compressionSnappy, compression)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	testGetTransactions(t, blkfileMgrWrapper, blocks)
+}
+
+func TestReadBlockfileHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsblkstorage-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	header := compressionSnappy.header()
+	testCases := []struct {
+		name                string
+		content             []byte
+		expectedCompression blockfileCompression
+		expectedHeaderLen   int
+		expectedErr         string
+	}{
+		{name: "empty", content: nil, expectedCompression: compressionNone},
+		{name: "legacy", content: append(proto.EncodeVarint(3), 1, 2, 3), expectedCompression: compressionNone},
+		{name: "snappy", content: append(header, 1, 2), expectedCompression: compressionSnappy, expectedHeaderLen: blockfileHeaderLen},
+		{name: "partial", content: header[:3], expectedErr: errPartialBlockfileHeader.Error()},
+		{name: "invalid", content: []byte{0, 'x', 'y', 'z', 1, 1}, expectedErr: "invalid header of block file"},
+		{name: "version", content: append(append([]byte{}, blockfileHeaderMagic...), 2, 1), expectedErr: "unsupported version [2]"},
+		{name: "compression", content: append(append([]byte{}, blockfileHeaderMagic...), 1, 9), expectedErr: "unsupported compression [unknown(9)]"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filePath := filepath.Join(dir, testCase.name)
+			assert.NoError(t, ioutil.WriteFile(filePath, testCase.content, 0600))
+			file, err := os.Open(filePath)
+			assert.NoError(t, err)
+			defer file.Close()
+			compression, headerLen, err := readBlockfileHeader(file)
+			if testCase.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCompression, compression)
+			assert.Equal(t, testCase.expectedHeaderLen, headerLen)
+		})
+	}
+
+	_, err = compressionByName("zip")
+	assert.EqualError(t, err, "unsupported block file compression [zip], the supported ones are [snappy]")
+}
+
+func TestFileLocPointerCompressedBlockOffset(t *testing.T) {
+	legacyLoc := &fileLocPointer{fileSuffixNum: 1, locPointer: locPointer{offset: 300, bytesLength: 20}}
+	compressedLoc := &fileLocPointer{fileSuffixNum: 1, locPointer: locPointer{offset: 30, bytesLength: 20}, compressedBlockOffset: 300}
+	for _, loc := range []*fileLocPointer{legacyLoc, compressedLoc} {
+		b, err := loc.marshal()
+		assert.NoError(t, err)
+		unmarshaledLoc := &fileLocPointer{}
+		assert.NoError(t, unmarshaledLoc.unmarshal(b))
+		assert.Equal(t, loc, unmarshaledLoc)
+	}
+
+	// the encoding of the locations in legacy block files is unchanged
+	b, err := legacyLoc.marshal()
+	assert.NoError(t, err)
+	legacyBytes := append(proto.EncodeVarint(1), proto.EncodeVarint(300)...)
+	legacyBytes = append(legacyBytes, proto.EncodeVarint(20)...)
+	assert.Equal(t, legacyBytes, b)
+
+	assert.True(t, isLocBefore(&fileLocPointer{fileSuffixNum: 1, locPointer: locPointer{offset: 90}, compressedBlockOffset: 10}, compressedLoc))
+	assert.False(t, isSameLoc(legacyLoc, compressedLoc))
+}
+
+func testGetTransactions(t *testing.T, w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	for _, block := range blocks {
+		for tranNum, txEnvelopeBytes := range block.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txID, err := putil.GetOrComputeTxIDFromEnvelope(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txEnvelopeFromFileMgr, err := w.blockfileMgr.retrieveTransactionByID(txID)
+			assert.NoError(t, err, "Error while retrieving tx [%s]", txID)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+			txEnvelopeFromFileMgr, err = w.blockfileMgr.retrieveTransactionByBlockNumTranNum(block.Header.Number, uint64(tranNum))
+			assert.NoError(t, err, "Error while retrieving tx [%d] of block [%d]", tranNum, block.Header.Number)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+		}
+	}
+}
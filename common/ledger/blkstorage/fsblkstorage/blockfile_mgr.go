@@ -16,6 +16,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -36,14 +37,16 @@ var (
 )
 
 type blockfileMgr struct {
-	rootDir           string
-	conf              *Conf
-	db                *leveldbhelper.DBHandle
-	index             index
-	cpInfo            *checkpointInfo
-	cpInfoCond        *sync.Cond
-	currentFileWriter *blockfileWriter
-	bcInfo            atomic.Value
+	rootDir                string
+	conf                   *Conf
+	db                     *leveldbhelper.DBHandle
+	index                  index
+	cpInfo                 *checkpointInfo
+	cpInfoCond             *sync.Cond
+	currentFileWriter      *blockfileWriter
+	currentFileCompression blockfileCompression
+	compression            blockfileCompression
+	bcInfo                 atomic.Value
 }
 
 /*
@@ -68,6 +71,13 @@ bytes in that transaction
 Each block is stored with the total encoded length of that block as well as the
 tx location offsets.
 
+If the blocks are configured to be compressed, each new block file starts with a
+header that records the compression, and each block is compressed individually, so
+that it can be still read randomly from its location in the index. The location of
+a transaction in a compressed block file records the location of its block along with
+the location of the transaction in the decompressed block bytes. The existing block
+files keep their compression (or lack thereof) until the next file is started.
+
 Remember that these steps are only done once at start-up of the system.
 At start up a new manager:
   *) Checks if the directory for storing files exists, if not creates the dir
@@ -95,8 +105,12 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
+	compression, err := compressionByName(conf.compression)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid block file compression: %s", err))
+	}
 	// Instantiate the manager, i.e. blockFileMgr structure
-	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore, compression: compression}
 
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
@@ -131,6 +145,13 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Could not truncate current file to known size in db: %s", err))
 	}
+	//The blocks appended to the current file keep its compression, unless it is empty
+	mgr.currentFileCompression = compression
+	if cpInfo.latestFileChunksize > 0 {
+		if mgr.currentFileCompression, err = retrieveBlockfileCompression(currentFileWriter.filePath); err != nil {
+			panic(fmt.Sprintf("Could not retrieve the compression of the current file: %s", err))
+		}
+	}
 
 	// Create a new KeyValue store database handler for the blocks index in the keyvalue database
 	if mgr.index, err = newBlockIndex(indexConfig, indexStore); err != nil {
@@ -234,6 +255,7 @@ func (mgr *blockfileMgr) moveToNextFile() {
 		panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 	}
 	mgr.currentFileWriter = nextFileWriter
+	mgr.currentFileCompression = mgr.compression
 	mgr.updateCheckpoint(cpInfo)
 }
 
@@ -265,21 +287,24 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	txOffsets := info.txOffsets
 	currentOffset := mgr.cpInfo.latestFileChunksize
 
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	fileHeader, storedBlockBytes := mgr.encodeBlockForCurrentFile(blockBytes, currentOffset)
+	blockBytesEncodedLen := proto.EncodeVarint(uint64(len(storedBlockBytes)))
+	totalBytesToAppend := len(fileHeader) + len(blockBytesEncodedLen) + len(storedBlockBytes)
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
 		currentOffset = 0
+		fileHeader, storedBlockBytes = mgr.encodeBlockForCurrentFile(blockBytes, currentOffset)
+		blockBytesEncodedLen = proto.EncodeVarint(uint64(len(storedBlockBytes)))
+		totalBytesToAppend = len(fileHeader) + len(blockBytesEncodedLen) + len(storedBlockBytes)
 	}
-	//append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+	//append the file header, if any, and blockBytesEncodedLen to the file
+	err = mgr.currentFileWriter.append(append(fileHeader, blockBytesEncodedLen...), false)
 	if err == nil {
-		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		//append the actual (possibly compressed) block bytes to the file
+		err = mgr.currentFileWriter.append(storedBlockBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.cpInfo.latestFileChunksize)
@@ -307,15 +332,24 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 
 	//Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newCPInfo.latestFileChunkSuffixNum}
-	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	blockFLP.offset = currentOffset + len(fileHeader)
+	compressed := mgr.currentFileCompression != compressionNone
+	// shift the txoffset because we prepend length of bytes before block bytes,
+	// unless the txoffset is relative to the decompressed block bytes
+	if !compressed {
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(blockBytesEncodedLen)
+		}
+	}
+	var chaincodeEvents []*chaincodeEventIdxInfo
+	if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrChaincodeEvent) {
+		chaincodeEvents = chaincodeEventsOf(block)
 	}
 	//save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata}); err != nil {
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata, compressed: compressed,
+		chaincodeEvents: chaincodeEvents}); err != nil {
 		return err
 	}
 
@@ -325,6 +359,16 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	return nil
 }
 
+// encodeBlockForCurrentFile returns the bytes with which the given serialized block is stored in the current
+// file at the given offset, along with the header of the file if the block is the first in a compressed file
+func (mgr *blockfileMgr) encodeBlockForCurrentFile(blockBytes []byte, offset int) ([]byte, []byte) {
+	var fileHeader []byte
+	if offset == 0 {
+		fileHeader = mgr.currentFileCompression.header()
+	}
+	return fileHeader, mgr.currentFileCompression.compress(blockBytes)
+}
+
 func (mgr *blockfileMgr) syncIndex() error {
 	var lastBlockIndexed uint64
 	var indexEmpty bool
@@ -401,10 +445,13 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		//therefore just shift by the difference between blockBytesOffset and blockStartOffset,
+		//unless the txOffsets are relative to the decompressed block bytes
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
@@ -414,6 +461,15 @@ func (mgr *blockfileMgr) syncIndex() error {
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
+		blockIdxInfo.chaincodeEvents = nil
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrChaincodeEvent) {
+			block, err := deserializeBlock(blockBytes)
+			if err != nil {
+				return err
+			}
+			blockIdxInfo.chaincodeEvents = chaincodeEventsOf(block)
+		}
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -489,6 +545,11 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 	return mgr.index.getTxValidationCodeByTxID(txID)
 }
 
+func (mgr *blockfileMgr) retrieveChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error) {
+	logger.Debugf("retrieveChaincodeEvents() - chaincodeID = [%s], eventName = [%s], blocks = [%d, %d]", chaincodeID, eventName, startBlockNum, endBlockNum)
+	return mgr.index.getChaincodeEvents(chaincodeID, eventName, startBlockNum, endBlockNum)
+}
+
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
@@ -544,7 +605,12 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	logger.Debugf("Entering fetchTransactionEnvelope() %v\n", lp)
 	var err error
 	var txEnvelopeBytes []byte
-	if txEnvelopeBytes, err = mgr.fetchRawBytes(lp); err != nil {
+	if lp.compressedBlockOffset > 0 {
+		txEnvelopeBytes, err = mgr.fetchBytesOfCompressedBlock(lp)
+	} else {
+		txEnvelopeBytes, err = mgr.fetchRawBytes(lp)
+	}
+	if err != nil {
 		return nil, err
 	}
 	_, n := proto.DecodeVarint(txEnvelopeBytes)
@@ -564,6 +630,22 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// fetchBytesOfCompressedBlock returns the bytes at the given location in the
+// decompressed bytes of a block of a compressed block file
+func (mgr *blockfileMgr) fetchBytesOfCompressedBlock(lp *fileLocPointer) ([]byte, error) {
+	blockBytes, err := mgr.fetchBlockBytes(&fileLocPointer{
+		fileSuffixNum: lp.fileSuffixNum,
+		locPointer:    locPointer{offset: lp.compressedBlockOffset},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lp.offset+lp.bytesLength > len(blockBytes) {
+		return nil, errors.Errorf("location {%s} is out of the bounds of the block, whose length is [%d]", lp, len(blockBytes))
+	}
+	return blockBytes[lp.offset : lp.offset+lp.bytesLength], nil
+}
+
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
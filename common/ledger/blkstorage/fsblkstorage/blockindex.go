@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -27,10 +28,17 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	chaincodeEventIdxKeyPrefix     = 'e'
+	chaincodeEventKeySeparator     = 0x00
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	chaincodeEventIdxStartKeyStr   = "chaincodeEventIdxStartKey"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
+
+// chaincodeEventIdxStartKey records the number of the first block whose chaincode events are indexed,
+// as the index may be enabled on a ledger with committed blocks
+var chaincodeEventIdxStartKey = []byte(chaincodeEventIdxStartKeyStr)
 var errIndexEmpty = errors.New("NoBlockIndexed")
 
 type index interface {
@@ -42,6 +50,7 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	getChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error)
 	isAttributeIndexed(attribute blkstorage.IndexableAttr) bool
 }
 
@@ -51,6 +60,12 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	// compressed is set for a block of a compressed block file, whose
+	// txOffsets are relative to the decompressed block bytes
+	compressed bool
+	// chaincodeEvents are the chaincode events set by the valid transactions,
+	// which are only extracted if the chaincode events are indexed
+	chaincodeEvents []*chaincodeEventIdxInfo
 }
 
 type blockIndex struct {
@@ -73,6 +88,13 @@ func newBlockIndex(indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHand
 		return nil, errors.Errorf("dependent index [%s] is not enabled for [%s] or [%s]",
 			blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrTxValidationCode, blkstorage.IndexableAttrBlockTxID)
 	}
+	// the blocks committed while the chaincode events are not indexed leave a gap in the index,
+	// so the indexing restarts from the next block committed once the index is enabled again
+	if !indexItemsMap[blkstorage.IndexableAttrChaincodeEvent] {
+		if err := db.Delete(chaincodeEventIdxStartKey, true); err != nil {
+			return nil, err
+		}
+	}
 	return &blockIndex{indexItemsMap, db}, nil
 }
 
@@ -126,7 +148,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 				continue
 			}
 
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to txid-index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -139,7 +161,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	//Index4 - Store BlockNumTranNum will be used to query history data
 	if index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
 		for txIterator, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := newTxLocationPointer(flp, blockIdxInfo.compressed, txoffset.loc)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, txIterator, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -169,6 +191,25 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Index7 - Store the chaincode events by chaincode id, event name and position of the transaction
+	if index.isAttributeIndexed(blkstorage.IndexableAttrChaincodeEvent) {
+		startBytes, err := index.db.Get(chaincodeEventIdxStartKey)
+		if err != nil {
+			return err
+		}
+		if startBytes == nil {
+			batch.Put(chaincodeEventIdxStartKey, encodeBlockNum(blockIdxInfo.blockNum))
+		}
+		for _, eventIdxInfo := range blockIdxInfo.chaincodeEvents {
+			eventBytes, marshalErr := proto.Marshal(eventIdxInfo.event)
+			if marshalErr != nil {
+				return errors.Wrap(marshalErr, "error marshaling chaincode event")
+			}
+			event := eventIdxInfo.event
+			batch.Put(constructChaincodeEventKey(event.ChaincodeId, event.EventName, blockIdxInfo.blockNum, eventIdxInfo.txNum, eventIdxInfo.eventNum), eventBytes)
+		}
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -355,6 +396,11 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// compressedBlockOffset is the offset of the block containing the transaction located by
+	// locPointer in its decompressed bytes, if the block file is compressed. It is never zero
+	// for a compressed block file, which starts with a header, and it is not marshaled
+	// otherwise, so that the locations in legacy block files keep their encoding
+	compressedBlockOffset int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -364,6 +410,20 @@ func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *
 	return flp
 }
 
+// newTxLocationPointer returns the location of a transaction of the block at the given location.
+// The transaction of a block of a compressed block file cannot be read directly from the file,
+// hence its location is relative to the decompressed block bytes and records the block location
+func newTxLocationPointer(blockFLP *fileLocPointer, compressed bool, relativeLP *locPointer) *fileLocPointer {
+	if !compressed {
+		return newFileLocationPointer(blockFLP.fileSuffixNum, blockFLP.offset, relativeLP)
+	}
+	return &fileLocPointer{
+		fileSuffixNum:         blockFLP.fileSuffixNum,
+		locPointer:            *relativeLP,
+		compressedBlockOffset: blockFLP.offset,
+	}
+}
+
 func (flp *fileLocPointer) marshal() ([]byte, error) {
 	buffer := proto.NewBuffer([]byte{})
 	e := buffer.EncodeVarint(uint64(flp.fileSuffixNum))
@@ -378,6 +438,12 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, e
 	}
+	if flp.compressedBlockOffset > 0 {
+		e = buffer.EncodeVarint(uint64(flp.compressedBlockOffset))
+		if e != nil {
+			return nil, e
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -399,10 +465,22 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return e
 	}
 	flp.bytesLength = int(i)
+	// the locations in uncompressed block files have no compressed block offset
+	if len(b) == proto.SizeVarint(uint64(flp.fileSuffixNum))+proto.SizeVarint(uint64(flp.offset))+proto.SizeVarint(uint64(flp.bytesLength)) {
+		return nil
+	}
+	i, e = buffer.DecodeVarint()
+	if e != nil {
+		return e
+	}
+	flp.compressedBlockOffset = int(i)
 	return nil
 }
 
 func (flp *fileLocPointer) String() string {
+	if flp.compressedBlockOffset > 0 {
+		return fmt.Sprintf("fileSuffixNum=%d, compressedBlockOffset=%d, %s", flp.fileSuffixNum, flp.compressedBlockOffset, flp.locPointer.String())
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -60,6 +61,10 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) getChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error) {
+	return nil, nil
+}
+
 func (i *noopIndex) isAttributeIndexed(attribute blkstorage.IndexableAttr) bool {
 	return true
 }
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// chaincodeEventIdxInfo is a chaincode event set by a valid transaction of the block being indexed,
+// along with its position among the events of the transaction
+type chaincodeEventIdxInfo struct {
+	txNum    uint64
+	eventNum uint64
+	event    *peer.ChaincodeEvent
+}
+
+// chaincodeEventsOf returns the chaincode events set by the valid transactions of a block.
+// The transactions whose chaincode events cannot be extracted are skipped with a warning,
+// as the index must not prevent a validated block from being committed
+func chaincodeEventsOf(block *common.Block) []*chaincodeEventIdxInfo {
+	var txsfltr ledgerUtil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsfltr = ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	var chaincodeEvents []*chaincodeEventIdxInfo
+	for txNum, envBytes := range block.Data.Data {
+		if txNum < len(txsfltr) && txsfltr.IsInvalid(txNum) {
+			continue
+		}
+		events, err := utils.GetChaincodeEventsFromEnvelope(envBytes)
+		if err != nil {
+			logger.Warningf("Not indexing the chaincode events of transaction [%d] of block [%d]: %s", txNum, block.Header.Number, err)
+			continue
+		}
+		for eventNum, event := range events {
+			chaincodeEvents = append(chaincodeEvents, &chaincodeEventIdxInfo{txNum: uint64(txNum), eventNum: uint64(eventNum), event: event})
+		}
+	}
+	return chaincodeEvents
+}
+
+func (index *blockIndex) getChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error) {
+	if !index.isAttributeIndexed(blkstorage.IndexableAttrChaincodeEvent) {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	if chaincodeID == "" || eventName == "" {
+		return nil, errors.New("chaincode id and event name must be specified")
+	}
+	if startBlockNum > endBlockNum {
+		return nil, errors.Errorf("start block number [%d] is greater than end block number [%d]", startBlockNum, endBlockNum)
+	}
+	startBytes, err := index.db.Get(chaincodeEventIdxStartKey)
+	if err != nil {
+		return nil, err
+	}
+	if startBytes == nil {
+		return nil, errors.New("no block is indexed for the chaincode events")
+	}
+	if indexStart := decodeBlockNum(startBytes); startBlockNum < indexStart {
+		return nil, errors.Errorf("the chaincode events are indexed from block [%d], which is after start block number [%d]", indexStart, startBlockNum)
+	}
+	keyPrefix := constructChaincodeEventKeyPrefix(chaincodeID, eventName)
+	startKey := append(constructChaincodeEventKeyPrefix(chaincodeID, eventName), util.EncodeOrderPreservingVarUint64(startBlockNum)...)
+	endKey := constructChaincodeEventKeyPrefix(chaincodeID, eventName)
+	if endBlockNum == math.MaxUint64 {
+		// the key prefix ends with a separator, which is followed by a greater byte in no key
+		endKey[len(endKey)-1] = chaincodeEventKeySeparator + 1
+	} else {
+		endKey = append(endKey, util.EncodeOrderPreservingVarUint64(endBlockNum+1)...)
+	}
+	return &chaincodeEventsItr{
+		dbItr:        index.db.GetIterator(startKey, endKey),
+		keyPrefixLen: len(keyPrefix),
+	}, nil
+}
+
+// chaincodeEventsItr iterates over the entries of a range of the chaincode event index
+type chaincodeEventsItr struct {
+	dbItr        *leveldbhelper.Iterator
+	keyPrefixLen int
+}
+
+// Next returns the next *peer.ChaincodeEventInfo, or nil if the iterator is exhausted
+func (itr *chaincodeEventsItr) Next() (ledger.QueryResult, error) {
+	if !itr.dbItr.Next() {
+		return nil, errors.WithStack(itr.dbItr.Error())
+	}
+	key := itr.dbItr.Key()[itr.keyPrefixLen:]
+	blockNum, n, err := util.DecodeOrderPreservingVarUint64(key)
+	if err != nil {
+		return nil, err
+	}
+	key = key[n:]
+	txNum, n, err := util.DecodeOrderPreservingVarUint64(key)
+	if err != nil {
+		return nil, err
+	}
+	eventNum, _, err := util.DecodeOrderPreservingVarUint64(key[n:])
+	if err != nil {
+		return nil, err
+	}
+	event := &peer.ChaincodeEvent{}
+	if err := proto.Unmarshal(itr.dbItr.Value(), event); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the chaincode event of transaction [%d] of block [%d]", txNum, blockNum)
+	}
+	return &peer.ChaincodeEventInfo{BlockNumber: blockNum, TxNumber: txNum, EventNumber: eventNum, ChaincodeEvent: event}, nil
+}
+
+// Close releases the underlying iterator of the index
+func (itr *chaincodeEventsItr) Close() {
+	itr.dbItr.Release()
+}
+
+// constructChaincodeEventKey returns the key of an event, which includes the position of the
+// event among the events of its transaction, since a transaction may set several events of
+// the same name
+func constructChaincodeEventKey(chaincodeID, eventName string, blockNum, txNum, eventNum uint64) []byte {
+	key := constructChaincodeEventKeyPrefix(chaincodeID, eventName)
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	key = append(key, util.EncodeOrderPreservingVarUint64(txNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(eventNum)...)
+}
+
+func constructChaincodeEventKeyPrefix(chaincodeID, eventName string) []byte {
+	key := append([]byte{chaincodeEventIdxKeyPrefix}, chaincodeID...)
+	key = append(key, chaincodeEventKeySeparator)
+	key = append(key, eventName...)
+	return append(key, chaincodeEventKeySeparator)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var attrsToIndexWithChaincodeEvents = append(append([]blkstorage.IndexableAttr{}, attrsToIndex...), blkstorage.IndexableAttrChaincodeEvent)
+
+func TestChaincodeEventIndex(t *testing.T) {
+	path := testPath()
+	env := newTestEnvSelectiveIndexing(t, NewConf(path, 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blocks := constructBlocksWithChaincodeEvents(t, 6)
+	blkfileMgrWrapper.addBlocks(blocks[:4])
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(blocks[4:])
+	store := &fsBlockStore{fileMgr: blkfileMgrWrapper.blockfileMgr}
+
+	// the second transaction of block 3 is invalid
+	assertChaincodeEvents(t, store, "cc1", "transfer", 0, math.MaxUint64, blocks, []uint64{1, 1, 2, 2, 3, 4, 4, 5, 5}, []uint64{0, 1, 0, 1, 0, 0, 1, 0, 1})
+	assertChaincodeEvents(t, store, "cc1", "transfer", 2, 4, blocks, []uint64{2, 2, 3, 4, 4}, []uint64{0, 1, 0, 0, 1})
+	assertChaincodeEvents(t, store, "cc1", "transfer", 5, 5, blocks, []uint64{5, 5}, []uint64{0, 1})
+	assertChaincodeEvents(t, store, "cc2", "mint", 0, 3, blocks, []uint64{1, 2, 3}, []uint64{2, 2, 2})
+	assertChaincodeEvents(t, store, "cc1", "mint", 0, math.MaxUint64, blocks, nil, nil)
+	assertChaincodeEvents(t, store, "cc1", "transfer", 6, 10, blocks, nil, nil)
+
+	_, err := store.RetrieveChaincodeEvents("cc1", "transfer", 3, 2)
+	assert.EqualError(t, err, "start block number [3] is greater than end block number [2]")
+	_, err = store.RetrieveChaincodeEvents("cc1", "", 0, 2)
+	assert.EqualError(t, err, "chaincode id and event name must be specified")
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the index is rebuilt from the block files
+	assert.NoError(t, DropBlockIndex(path, "testLedger"))
+	env = newTestEnvSelectiveIndexing(t, NewConf(path, 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	store = &fsBlockStore{fileMgr: blkfileMgrWrapper.blockfileMgr}
+	assertChaincodeEvents(t, store, "cc1", "transfer", 0, math.MaxUint64, blocks, []uint64{1, 1, 2, 2, 3, 4, 4, 5, 5}, []uint64{0, 1, 0, 1, 0, 0, 1, 0, 1})
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the entries of the blocks that are rolled back are deleted
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndexWithChaincodeEvents}
+	assert.NoError(t, Rollback(path, "testLedger", 3, indexConfig))
+	env = newTestEnvSelectiveIndexing(t, NewConf(path, 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	store = &fsBlockStore{fileMgr: blkfileMgrWrapper.blockfileMgr}
+	assertChaincodeEvents(t, store, "cc1", "transfer", 0, math.MaxUint64, blocks, []uint64{1, 1, 2, 2, 3}, []uint64{0, 1, 0, 1, 0})
+	assertChaincodeEvents(t, store, "cc2", "mint", 0, math.MaxUint64, blocks, []uint64{1, 2, 3}, []uint64{2, 2, 2})
+}
+
+func TestChaincodeEventIndexMultipleEvents(t *testing.T) {
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+
+	// the first transaction sets the "transfer" event twice
+	var envs []*common.Envelope
+	for txNum, events := range [][]*peer.ChaincodeEvent{
+		{
+			{ChaincodeId: "cc1", EventName: "transfer", Payload: []byte("first")},
+			{ChaincodeId: "cc1", EventName: "mint", Payload: []byte("second")},
+			{ChaincodeId: "cc1", EventName: "transfer", Payload: []byte("third")},
+		},
+		{
+			{ChaincodeId: "cc1", EventName: "transfer", Payload: []byte("fourth")},
+		},
+	} {
+		txEnv, _, err := testutil.ConstructTransactionFromTxDetails(&testutil.TxDetails{
+			TxID:              fmt.Sprintf("tx-%d", txNum),
+			ChaincodeName:     "cc1",
+			ChaincodeVersion:  "v1",
+			SimulationResults: []byte("results"),
+		}, false)
+		assert.NoError(t, err)
+		envs = append(envs, withChaincodeEvents(t, txEnv, events))
+	}
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	blocks = append(blocks, testutil.NewBlock(envs, 1, blocks[0].Header.Hash()))
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	itr, err := blkfileMgrWrapper.blockfileMgr.retrieveChaincodeEvents("cc1", "transfer", 0, math.MaxUint64)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var positions [][]uint64
+	var payloads []string
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			break
+		}
+		eventInfo := result.(*peer.ChaincodeEventInfo)
+		positions = append(positions, []uint64{eventInfo.BlockNumber, eventInfo.TxNumber, eventInfo.EventNumber})
+		payloads = append(payloads, string(eventInfo.ChaincodeEvent.Payload))
+	}
+	assert.Equal(t, [][]uint64{{1, 0, 0}, {1, 0, 2}, {1, 1, 0}}, positions)
+	assert.Equal(t, []string{"first", "third", "fourth"}, payloads)
+}
+
+// withChaincodeEvents returns the given transaction with its chaincode action carrying
+// the given list of chaincode events
+func withChaincodeEvents(t *testing.T, env *common.Envelope, events []*peer.ChaincodeEvent) *common.Envelope {
+	payload, err := utils.GetPayload(env)
+	assert.NoError(t, err)
+	tx, err := utils.GetTransaction(payload.Data)
+	assert.NoError(t, err)
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	assert.NoError(t, err)
+	prp, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	assert.NoError(t, err)
+	cAct, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+
+	cAct.ChaincodeEvents = events
+	prp.Extension = utils.MarshalOrPanic(cAct)
+	cap.Action.ProposalResponsePayload = utils.MarshalOrPanic(prp)
+	tx.Actions[0].Payload = utils.MarshalOrPanic(cap)
+	payload.Data = utils.MarshalOrPanic(tx)
+	return &common.Envelope{Payload: utils.MarshalOrPanic(payload)}
+}
+
+func TestChaincodeEventIndexNotEnabled(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(constructBlocksWithChaincodeEvents(t, 2))
+
+	_, err := blkfileMgrWrapper.blockfileMgr.retrieveChaincodeEvents("cc1", "transfer", 0, math.MaxUint64)
+	assert.Equal(t, blkstorage.ErrAttrNotIndexed, err)
+}
+
+func TestChaincodeEventIndexEnabledLater(t *testing.T) {
+	path := testPath()
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blocks := constructBlocksWithChaincodeEvents(t, 8)
+	blkfileMgrWrapper.addBlocks(blocks[:3])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the blocks committed before the index is enabled are not indexed
+	env = newTestEnvSelectiveIndexing(t, NewConf(path, 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[3:5])
+	store := &fsBlockStore{fileMgr: blkfileMgrWrapper.blockfileMgr}
+	_, err := store.RetrieveChaincodeEvents("cc1", "transfer", 2, math.MaxUint64)
+	assert.EqualError(t, err, "the chaincode events are indexed from block [3], which is after start block number [2]")
+	assertChaincodeEvents(t, store, "cc2", "mint", 3, math.MaxUint64, blocks, []uint64{3, 4}, []uint64{2, 2})
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the index restarts from the first block committed after the index is enabled again
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[5:6])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+	env = newTestEnvSelectiveIndexing(t, NewConf(path, 0), attrsToIndexWithChaincodeEvents, &disabled.Provider{})
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks[6:])
+	store = &fsBlockStore{fileMgr: blkfileMgrWrapper.blockfileMgr}
+	_, err = store.RetrieveChaincodeEvents("cc2", "mint", 3, math.MaxUint64)
+	assert.EqualError(t, err, "the chaincode events are indexed from block [6], which is after start block number [3]")
+	assertChaincodeEvents(t, store, "cc2", "mint", 6, math.MaxUint64, blocks, []uint64{6, 7}, []uint64{2, 2})
+}
+
+// constructBlocksWithChaincodeEvents returns a genesis block followed by blocks with four transactions,
+// the first two of which set the "transfer" event of "cc1", the third the "mint" event of "cc2" and
+// the last one no event. The second transaction of block 3 is invalid
+func constructBlocksWithChaincodeEvents(t *testing.T, numBlocks int) []*common.Block {
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	for blockNum := uint64(1); blockNum < uint64(numBlocks); blockNum++ {
+		var txs []*testutil.TxDetails
+		for txNum, ccEvent := range []*peer.ChaincodeEvent{
+			{ChaincodeId: "cc1", EventName: "transfer"},
+			{ChaincodeId: "cc1", EventName: "transfer"},
+			{ChaincodeId: "cc2", EventName: "mint"},
+			nil,
+		} {
+			txID := fmt.Sprintf("tx-%d-%d", blockNum, txNum)
+			if ccEvent != nil {
+				ccEvent.TxId = txID
+				ccEvent.Payload = []byte(txID)
+			}
+			txs = append(txs, &testutil.TxDetails{
+				TxID:              txID,
+				ChaincodeName:     "cc1",
+				ChaincodeVersion:  "v1",
+				SimulationResults: []byte("results"),
+				ChaincodeEvent:    ccEvent,
+			})
+		}
+		block := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+			BlockNum:     blockNum,
+			PreviousHash: blocks[blockNum-1].Header.Hash(),
+			Txs:          txs,
+		}, false)
+		if blockNum == 3 {
+			block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][1] = byte(peer.TxValidationCode_MVCC_READ_CONFLICT)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func assertChaincodeEvents(t *testing.T, store *fsBlockStore, chaincodeID, eventName string, startBlockNum, endBlockNum uint64,
+	blocks []*common.Block, expectedBlockNums, expectedTxNums []uint64) {
+	itr, err := store.RetrieveChaincodeEvents(chaincodeID, eventName, startBlockNum, endBlockNum)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var blockNums, txNums []uint64
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			break
+		}
+		eventInfo := result.(*peer.ChaincodeEventInfo)
+		blockNums = append(blockNums, eventInfo.BlockNumber)
+		txNums = append(txNums, eventInfo.TxNumber)
+		txID := fmt.Sprintf("tx-%d-%d", eventInfo.BlockNumber, eventInfo.TxNumber)
+		assert.True(t, proto.Equal(&peer.ChaincodeEvent{
+			ChaincodeId: chaincodeID,
+			EventName:   eventName,
+			TxId:        txID,
+			Payload:     []byte(txID),
+		}, eventInfo.ChaincodeEvent))
+	}
+	assert.Equal(t, expectedBlockNums, blockNums)
+	assert.Equal(t, expectedTxNums, txNums)
+}
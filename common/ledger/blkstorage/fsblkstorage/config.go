@@ -30,15 +30,22 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	compression      string
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `FsBlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithCompression(blockStorageDir, maxBlockfileSize, NoCompression)
+}
+
+// NewConfWithCompression constructs new `Conf` with which the blocks appended to new block files
+// are compressed with the given algorithm. The existing block files keep their compression, if any
+func NewConfWithCompression(blockStorageDir string, maxBlockfileSize int, compression string) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, compression}
 }
 
 func (conf *Conf) getIndexDir() string {
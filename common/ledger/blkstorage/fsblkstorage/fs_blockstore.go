@@ -102,6 +102,12 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveChaincodeEvents returns an iterator over the indexed events with the given name set by the given chaincode
+// in the valid transactions of the given range of blocks
+func (store *fsBlockStore) RetrieveChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger.ResultsIterator, error) {
+	return store.fileMgr.retrieveChaincodeEvents(chaincodeID, eventName, startBlockNum, endBlockNum)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -17,10 +17,14 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
@@ -63,6 +67,16 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// DropLedger deletes the index entries and the block files of the BlockStore with given id.
+// The index is deleted first so that an interrupted drop leaves block files that are
+// indexed again when the BlockStore is opened
+func (p *FsBlockstoreProvider) DropLedger(ledgerid string) error {
+	if err := p.leveldbProvider.GetDBHandle(ledgerid).DeleteAll(); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error deleting the block index of ledger [%s]", ledgerid))
+	}
+	return errors.Wrapf(os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)), "error removing the block files of ledger [%s]", ledgerid)
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
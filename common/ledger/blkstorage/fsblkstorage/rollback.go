@@ -129,6 +129,16 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 			return err
 		}
 		addIndexEntriesToBeDeleted(batch, blockInfo, r.indexStore)
+		if r.indexStore.isAttributeIndexed(blkstorage.IndexableAttrChaincodeEvent) {
+			block, err := deserializeBlock(blockBytes)
+			if err != nil {
+				return err
+			}
+			for _, eventIdxInfo := range chaincodeEventsOf(block) {
+				event := eventIdxInfo.event
+				batch.Delete(constructChaincodeEventKey(event.ChaincodeId, event.EventName, block.Header.Number, eventIdxInfo.txNum, eventIdxInfo.eventNum))
+			}
+		}
 		numberOfBlocksToRetrieve--
 	}
 
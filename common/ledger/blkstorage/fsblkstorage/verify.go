@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// BlockStoreVerification is the outcome of the verification of the block files
+// and of the block index of a ledger
+type BlockStoreVerification struct {
+	// Height is the number of blocks found in the block files
+	Height uint64
+	// BlockFileIssues lists the problems found in the block files
+	BlockFileIssues []string
+	// IndexIssues lists the problems found in the block index
+	IndexIssues []string
+	// IndexRebuilt is set if the block index has been rebuilt from the block files
+	IndexRebuilt bool
+}
+
+// VerifyBlockStore walks the block files of a ledger in order, checking that the blocks form
+// a hash chain and that the header of each block matches its data, and cross-checks the entries
+// of the block index against the placement of the blocks and transactions in the block files.
+// The function verifyBlock, if not nil, is invoked on each block in order, and the problems it
+// returns are reported along with the problems found in the block files.
+// If rebuildIndex is true and problems are found in the block index, the index is dropped and
+// rebuilt from the block files. The block store must not be in use while it is verified.
+func VerifyBlockStore(blockStorageDir, ledgerID string, indexConfig *blkstorage.IndexConfig,
+	rebuildIndex bool, verifyBlock func(block *common.Block) []string) (*BlockStoreVerification, error) {
+	conf := NewConf(blockStorageDir, 0)
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	if err := validateLedgerID(ledgerDir, ledgerID); err != nil {
+		return nil, err
+	}
+
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer dbProvider.Close()
+	indexDB := dbProvider.GetDBHandle(ledgerID)
+	index, err := newBlockIndex(indexConfig, indexDB)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &blockStoreVerifier{
+		ledgerDir:   ledgerDir,
+		index:       index,
+		verifyBlock: verifyBlock,
+		result:      &BlockStoreVerification{},
+	}
+	logger.Infof("Verifying the block files and the block index of ledger [%s]", ledgerID)
+	if err := v.verify(); err != nil {
+		return nil, err
+	}
+
+	if rebuildIndex && len(v.result.IndexIssues) > 0 {
+		logger.Infof("Rebuilding the block index of ledger [%s]", ledgerID)
+		if err := rebuildBlockIndex(ledgerID, conf, indexConfig, indexDB, v.result.Height); err != nil {
+			return nil, err
+		}
+		v.result.IndexRebuilt = true
+	}
+	return v.result, nil
+}
+
+type blockStoreVerifier struct {
+	ledgerDir   string
+	index       *blockIndex
+	verifyBlock func(block *common.Block) []string
+	result      *BlockStoreVerification
+}
+
+func (v *blockStoreVerifier) verify() error {
+	lastFileNum, err := retrieveLastFileSuffix(v.ledgerDir)
+	if err != nil {
+		return err
+	}
+	if lastFileNum < 0 {
+		return nil
+	}
+
+	indexEmpty := false
+	lastBlockIndexed, err := v.index.getLastBlockIndexed()
+	if err == errIndexEmpty {
+		indexEmpty = true
+	} else if err != nil {
+		return err
+	}
+
+	stream, err := newBlockStream(v.ledgerDir, 0, 0, lastFileNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	var prevHeader *common.BlockHeader
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err == ErrUnexpectedEndOfBlockfile {
+			v.blockFileIssue("incomplete block found at the end of block file [%d]", stream.currentFileNum)
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		block, err := deserializeBlock(blockBytes)
+		if err != nil {
+			v.blockFileIssue("block at {%s} cannot be decoded: %s", placementInfo, err)
+			break
+		}
+		blockInfo, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+
+		v.verifyBlockChain(block, prevHeader)
+		if v.verifyBlock != nil {
+			v.result.BlockFileIssues = append(v.result.BlockFileIssues, v.verifyBlock(block)...)
+		}
+		if !indexEmpty && block.Header.Number <= lastBlockIndexed {
+			if err := v.verifyIndexEntries(block, blockInfo, placementInfo); err != nil {
+				return err
+			}
+		}
+		prevHeader = block.Header
+		v.result.Height = block.Header.Number + 1
+	}
+
+	if len(v.index.indexItemsMap) == 0 {
+		return nil
+	}
+	switch {
+	case indexEmpty && v.result.Height > 0:
+		v.indexIssue("block index is empty while the block files contain [%d] blocks", v.result.Height)
+	case !indexEmpty && lastBlockIndexed >= v.result.Height:
+		v.indexIssue("block index contains entries up to block [%d] while the block files contain [%d] blocks",
+			lastBlockIndexed, v.result.Height)
+	case !indexEmpty && lastBlockIndexed+1 < v.result.Height:
+		v.indexIssue("block index contains entries up to block [%d] only while the block files contain [%d] blocks",
+			lastBlockIndexed, v.result.Height)
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) verifyBlockChain(block *common.Block, prevHeader *common.BlockHeader) {
+	blockNum := block.Header.Number
+	if prevHeader == nil {
+		if blockNum != 0 {
+			v.blockFileIssue("block [%d] found where block [0] was expected", blockNum)
+		}
+	} else {
+		if blockNum != prevHeader.Number+1 {
+			v.blockFileIssue("block [%d] found where block [%d] was expected", blockNum, prevHeader.Number+1)
+		}
+		if !bytes.Equal(block.Header.PreviousHash, prevHeader.Hash()) {
+			v.blockFileIssue("previous hash of block [%d] does not match the hash of the header of block [%d]",
+				blockNum, prevHeader.Number)
+		}
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		v.blockFileIssue("data hash of block [%d] does not match the hash of its data", blockNum)
+	}
+	txsFilter := txsFilterOf(block.Metadata)
+	if len(txsFilter) != len(block.Data.Data) {
+		v.blockFileIssue("block [%d] contains [%d] transactions but [%d] validation codes",
+			blockNum, len(block.Data.Data), len(txsFilter))
+	}
+}
+
+func (v *blockStoreVerifier) verifyIndexEntries(block *common.Block, blockInfo *serializedBlockInfo, placementInfo *blockPlacementInfo) error {
+	blockNum := block.Header.Number
+	blockLoc := &fileLocPointer{
+		fileSuffixNum: placementInfo.fileNum,
+		locPointer:    locPointer{offset: int(placementInfo.blockStartOffset)},
+	}
+
+	if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNum) {
+		loc, err := v.index.getBlockLocByBlockNum(blockNum)
+		if err := v.checkLoc(loc, err, blockLoc, "block [%d] by number", blockNum); err != nil {
+			return err
+		}
+	}
+	if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockHash) {
+		loc, err := v.index.getBlockLocByHash(block.Header.Hash())
+		if err := v.checkLoc(loc, err, blockLoc, "block [%d] by hash", blockNum); err != nil {
+			return err
+		}
+	}
+
+	txsFilter := txsFilterOf(blockInfo.metadata)
+	txsBaseLoc := blockLoc
+	if !placementInfo.compressed {
+		txsBaseLoc = &fileLocPointer{
+			fileSuffixNum: placementInfo.fileNum,
+			locPointer:    locPointer{offset: int(placementInfo.blockBytesOffset)},
+		}
+	}
+	for txNum, txOffset := range blockInfo.txOffsets {
+		txLoc := newTxLocationPointer(txsBaseLoc, placementInfo.compressed, txOffset.loc)
+
+		if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
+			loc, err := v.index.getTXLocByBlockNumTranNum(blockNum, uint64(txNum))
+			if err := v.checkLoc(loc, err, txLoc, "transaction [%d] of block [%d]", txNum, blockNum); err != nil {
+				return err
+			}
+		}
+
+		if txOffset.txID == "" || !v.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+			continue
+		}
+		loc, err := v.index.getTxLoc(txOffset.txID)
+		if err == blkstorage.ErrNotFoundInIndex {
+			v.indexIssue("entry of transaction [%s] of block [%d] is missing", txOffset.txID, blockNum)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !isSameLoc(loc, txLoc) {
+			// the index points at the first occurrence of a duplicate txid,
+			// hence an entry pointing at an earlier transaction is expected
+			if !isLocBefore(loc, txLoc) {
+				v.indexIssue("entry of transaction [%s] of block [%d] points at {%s} instead of {%s}",
+					txOffset.txID, blockNum, loc, txLoc)
+			}
+			continue
+		}
+
+		if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockTxID) {
+			loc, err := v.index.getBlockLocByTxID(txOffset.txID)
+			if err := v.checkLoc(loc, err, blockLoc, "block of transaction [%s]", txOffset.txID); err != nil {
+				return err
+			}
+		}
+		if v.index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+			code, err := v.index.getTxValidationCodeByTxID(txOffset.txID)
+			switch {
+			case err == blkstorage.ErrNotFoundInIndex:
+				v.indexIssue("validation code of transaction [%s] of block [%d] is missing", txOffset.txID, blockNum)
+			case err != nil:
+				return err
+			case txNum < len(txsFilter) && code != txsFilter.Flag(txNum):
+				v.indexIssue("validation code of transaction [%s] of block [%d] is [%s] instead of [%s]",
+					txOffset.txID, blockNum, code, txsFilter.Flag(txNum))
+			}
+		}
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) checkLoc(loc *fileLocPointer, err error, expectedLoc *fileLocPointer, format string, args ...interface{}) error {
+	entry := fmt.Sprintf(format, args...)
+	switch {
+	case err == blkstorage.ErrNotFoundInIndex:
+		v.indexIssue("entry of %s is missing", entry)
+	case err != nil:
+		return err
+	case !isSameLoc(loc, expectedLoc):
+		v.indexIssue("entry of %s points at {%s} instead of {%s}", entry, loc, expectedLoc)
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) blockFileIssue(format string, args ...interface{}) {
+	v.result.BlockFileIssues = append(v.result.BlockFileIssues, fmt.Sprintf(format, args...))
+}
+
+func (v *blockStoreVerifier) indexIssue(format string, args ...interface{}) {
+	v.result.IndexIssues = append(v.result.IndexIssues, fmt.Sprintf(format, args...))
+}
+
+func txsFilterOf(metadata *common.BlockMetadata) ledgerUtil.TxValidationFlags {
+	if metadata == nil || len(metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil
+	}
+	return ledgerUtil.TxValidationFlags(metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+}
+
+func isSameLoc(loc, expectedLoc *fileLocPointer) bool {
+	return loc.fileSuffixNum == expectedLoc.fileSuffixNum &&
+		loc.compressedBlockOffset == expectedLoc.compressedBlockOffset &&
+		loc.offset == expectedLoc.offset
+}
+
+func isLocBefore(loc, otherLoc *fileLocPointer) bool {
+	if loc.fileSuffixNum != otherLoc.fileSuffixNum {
+		return loc.fileSuffixNum < otherLoc.fileSuffixNum
+	}
+	// the locations of the transactions of a compressed block are relative to the block
+	if loc.compressedBlockOffset != otherLoc.compressedBlockOffset {
+		return loc.compressedBlockOffset < otherLoc.compressedBlockOffset
+	}
+	return loc.offset < otherLoc.offset
+}
+
+// rebuildBlockIndex drops all the entries of the block index of a ledger, including the checkpoint
+// info of the block files, and lets the block file manager reconstruct them from the block files
+func rebuildBlockIndex(ledgerID string, conf *Conf, indexConfig *blkstorage.IndexConfig, indexDB *leveldbhelper.DBHandle, height uint64) error {
+	if err := indexDB.DeleteAll(); err != nil {
+		return err
+	}
+
+	mgr := newBlockfileMgr(ledgerID, conf, indexConfig, indexDB)
+	defer mgr.close()
+	if height == 0 {
+		return nil
+	}
+	lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
+	if err != nil && err != errIndexEmpty {
+		return err
+	}
+	if err == errIndexEmpty || lastBlockIndexed != height-1 {
+		return errors.Errorf("block index of ledger [%s] could not be rebuilt up to block [%d]", ledgerID, height-1)
+	}
+	return nil
+}
+
+// ValidateLedgerExists returns an error if the block store holds no ledger with the given ID
+func ValidateLedgerExists(blockStorageDir, ledgerID string) error {
+	conf := NewConf(blockStorageDir, 0)
+	return validateLedgerID(conf.getLedgerBlockDir(ledgerID), ledgerID)
+}
+
+// DropBlockIndex drops all the entries of the block index of a ledger, including the checkpoint
+// info of the block files. The block file manager rebuilds them from the block files when the
+// block store of the ledger is opened next. The block store must not be in use
+func DropBlockIndex(blockStorageDir, ledgerID string) error {
+	conf := NewConf(blockStorageDir, 0)
+	if err := validateLedgerID(conf.getLedgerBlockDir(ledgerID), ledgerID); err != nil {
+		return err
+	}
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer dbProvider.Close()
+	return dbProvider.GetDBHandle(ledgerID).DeleteAll()
+}
@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBlockStore(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	var verifiedBlocks []uint64
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, false, func(block *common.Block) []string {
+		verifiedBlocks = append(verifiedBlocks, block.Header.Number)
+		if block.Header.Number == 7 {
+			return []string{"invalid signature"}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockStoreVerification{
+		Height:          30,
+		BlockFileIssues: []string{"invalid signature"},
+	}, result)
+	assert.Len(t, verifiedBlocks, 30)
+	for i, blockNum := range verifiedBlocks {
+		assert.Equal(t, uint64(i), blockNum)
+	}
+
+	_, err = VerifyBlockStore(path, "nonExistingLedger", indexConfig, false, nil)
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+}
+
+func TestVerifyBlockStoreCorruptedBlockFiles(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	ledgerDir := NewConf(path, 0).getLedgerBlockDir("testLedger")
+	// the serialized header starts with the block number, followed by the data hash
+	// and by the previous hash, each of them prefixed by its length
+	placementInfo, _ := locateBlock(t, ledgerDir, 10)
+	flipByte(t, deriveBlockfilePath(ledgerDir, placementInfo.fileNum), placementInfo.blockBytesOffset+1+1+32+1)
+	placementInfo, blockInfo := locateBlock(t, ledgerDir, 20)
+	txLoc := blockInfo.txOffsets[0].loc
+	flipByte(t, deriveBlockfilePath(ledgerDir, placementInfo.fileNum),
+		placementInfo.blockBytesOffset+int64(txLoc.offset+txLoc.bytesLength-1))
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), result.Height)
+	assert.Equal(t, []string{
+		"previous hash of block [10] does not match the hash of the header of block [9]",
+		"previous hash of block [11] does not match the hash of the header of block [10]",
+		"data hash of block [20] does not match the hash of its data",
+	}, result.BlockFileIssues)
+	assert.Equal(t, []string{"entry of block [10] by hash is missing"}, result.IndexIssues)
+}
+
+func TestVerifyBlockStoreCorruptedIndex(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	conf := NewConf(path, 0)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	indexDB := dbProvider.GetDBHandle("testLedger")
+	assert.NoError(t, indexDB.Delete(constructBlockNumKey(5), true))
+	assert.NoError(t, indexDB.Delete(constructBlockNumTranNumKey(15, 0), true))
+	flpBytes, err := (&fileLocPointer{fileSuffixNum: 2, locPointer: locPointer{offset: 0}}).marshal()
+	assert.NoError(t, err)
+	assert.NoError(t, indexDB.Put(constructBlockHashKey(blocks[12].Header.Hash()), flpBytes, true))
+	dbProvider.Close()
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, result.BlockFileIssues)
+	assert.Len(t, result.IndexIssues, 3)
+	assert.Equal(t, "entry of block [5] by number is missing", result.IndexIssues[0])
+	assert.Contains(t, result.IndexIssues[1], "entry of block [12] by hash points at {fileSuffixNum=2, offset=0, bytesLength=0}")
+	assert.Equal(t, "entry of transaction [0] of block [15] is missing", result.IndexIssues[2])
+	assert.False(t, result.IndexRebuilt)
+
+	// rebuilding the index fixes all the issues
+	result, err = VerifyBlockStore(path, "testLedger", indexConfig, true, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.IndexIssues, 3)
+	assert.True(t, result.IndexRebuilt)
+
+	result, err = VerifyBlockStore(path, "testLedger", indexConfig, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockStoreVerification{Height: 30}, result)
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+}
+
+func TestVerifyBlockStoreIndexBehind(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	conf := NewConf(path, 0)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	indexDB := dbProvider.GetDBHandle("testLedger")
+	assert.NoError(t, indexDB.Put(indexCheckpointKey, encodeBlockNum(6), true))
+	dbProvider.Close()
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"block index contains entries up to block [6] only while the block files contain [10] blocks",
+	}, result.IndexIssues)
+}
+
+func storeBlocksInFiles(t *testing.T, env *testEnv, ledgerID string, blocks []*common.Block, blocksPerFile int) {
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerID)
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		assert.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%blocksPerFile == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+	env.provider.Close()
+	blkfileMgrWrapper.close()
+}
+
+func locateBlock(t *testing.T, ledgerDir string, blockNum uint64) (*blockPlacementInfo, *serializedBlockInfo) {
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	assert.NoError(t, err)
+	stream, err := newBlockStream(ledgerDir, 0, 0, lastFileNum)
+	assert.NoError(t, err)
+	defer stream.close()
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		assert.NoError(t, err)
+		assert.NotNil(t, blockBytes)
+		blockInfo, err := extractSerializedBlockInfo(blockBytes)
+		assert.NoError(t, err)
+		if blockInfo.blockHeader.Number == blockNum {
+			return placementInfo, blockInfo
+		}
+	}
+}
+
+func flipByte(t *testing.T, filePath string, offset int64) {
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0600)
+	assert.NoError(t, err)
+	defer file.Close()
+	b := make([]byte, 1)
+	_, err = file.ReadAt(b, offset)
+	assert.NoError(t, err)
+	b[0] ^= 0xff
+	_, err = file.WriteAt(b, offset)
+	assert.NoError(t, err)
+}
+
+func TestDropBlockIndex(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	storeBlocksInFiles(t, env, "testLedger", blocks, 10)
+
+	assert.EqualError(t, DropBlockIndex(path, "noLedger"), "ledgerID [noLedger] does not exist")
+	assert.NoError(t, DropBlockIndex(path, "testLedger"))
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	result, err := VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"block index is empty while the block files contain [20] blocks"}, result.IndexIssues)
+
+	// the index is rebuilt when the block store is opened
+	reopenedEnv := newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper := newTestBlockfileWrapper(reopenedEnv, "testLedger")
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+	blkfileMgrWrapper.close()
+	reopenedEnv.provider.Close()
+
+	result, err = VerifyBlockStore(path, "testLedger", indexConfig, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockStoreVerification{Height: 20}, result)
+}
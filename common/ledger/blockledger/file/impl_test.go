@@ -109,6 +109,10 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) RetrieveChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (cl.ResultsIterator, error) {
+	return nil, mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
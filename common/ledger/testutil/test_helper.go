@@ -32,6 +32,7 @@ type TxDetails struct {
 	TxID                            string
 	ChaincodeName, ChaincodeVersion string
 	SimulationResults               []byte
+	ChaincodeEvent                  *pb.ChaincodeEvent
 }
 
 type BlockDetails struct {
@@ -110,10 +111,16 @@ func ConstructTransactionFromTxDetails(txDetails *TxDetails, sign bool) (*common
 	var txEnv *common.Envelope
 	var err error
 	var txID string
+	var events []byte
+	if txDetails.ChaincodeEvent != nil {
+		if events, err = proto.Marshal(txDetails.ChaincodeEvent); err != nil {
+			return nil, "", err
+		}
+	}
 	if sign {
-		txEnv, txID, err = ptestutils.ConstructSignedTxEnvWithDefaultSigner(util.GetTestChainID(), ccid, nil, txDetails.SimulationResults, txDetails.TxID, nil, nil)
+		txEnv, txID, err = ptestutils.ConstructSignedTxEnvWithDefaultSigner(util.GetTestChainID(), ccid, nil, txDetails.SimulationResults, txDetails.TxID, events, nil)
 	} else {
-		txEnv, txID, err = ptestutils.ConstructUnsignedTxEnv(util.GetTestChainID(), ccid, nil, txDetails.SimulationResults, txDetails.TxID, nil, nil)
+		txEnv, txID, err = ptestutils.ConstructUnsignedTxEnv(util.GetTestChainID(), ccid, nil, txDetails.SimulationResults, txDetails.TxID, events, nil)
 	}
 	return txEnv, txID, err
 }
@@ -26,6 +26,7 @@ import (
 
 var dbNameKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
+var deleteAllBatchSize = 10000
 
 // Provider enables to use a single leveldb as multiple logical leveldbs
 type Provider struct {
@@ -99,6 +100,25 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	return nil
 }
 
+// DeleteAll deletes all the keys of the named db. The keys are deleted in batches to bound
+// the memory used, hence the deletion is not atomic
+func (h *DBHandle) DeleteAll() error {
+	for {
+		batch := NewUpdateBatch()
+		itr := h.GetIterator(nil, nil)
+		for len(batch.KVs) < deleteAllBatchSize && itr.Next() {
+			batch.Delete(append([]byte{}, itr.Key()...))
+		}
+		itr.Release()
+		if len(batch.KVs) == 0 {
+			return nil
+		}
+		if err := h.WriteBatch(batch, true); err != nil {
+			return err
+		}
+	}
+}
+
 // GetIterator gets an handle to iterator. The iterator should be released after the use.
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
@@ -172,3 +172,27 @@ func createTestValues(dbname string, start int, end int) []string {
 	}
 	return values
 }
+
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+	defer func(batchSize int) { deleteAllBatchSize = batchSize }(deleteAllBatchSize)
+	deleteAllBatchSize = 3
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < 10; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	assert.NoError(t, db1.DeleteAll())
+	itr1 := db1.GetIterator(nil, nil)
+	defer itr1.Release()
+	assert.False(t, itr1.Next())
+
+	itr2 := db2.GetIterator(nil, nil)
+	defer itr2.Release()
+	checkItrResults(t, itr2, createTestKeys(0, 9), createTestValues("db2", 0, 9))
+}
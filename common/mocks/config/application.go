@@ -48,6 +48,8 @@ type MockApplicationCapabilities struct {
 	V1_3ValidationRv             bool
 	FabTokenRv                   bool
 	StorePvtDataOfInvalidTxRv    bool
+	MultipleChaincodeEventsRv    bool
+	CrossChannelWritesRv         bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -97,3 +99,11 @@ func (mac *MockApplicationCapabilities) FabToken() bool {
 func (mac *MockApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	return mac.StorePvtDataOfInvalidTxRv
 }
+
+func (mac *MockApplicationCapabilities) MultipleChaincodeEvents() bool {
+	return mac.MultipleChaincodeEventsRv
+}
+
+func (mac *MockApplicationCapabilities) CrossChannelWrites() bool {
+	return mac.CrossChannelWritesRv
+}
@@ -75,6 +75,9 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateAtHeight] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateFingerprint] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetChaincodeEvents] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -89,6 +92,7 @@ func (d *defaultACLProvider) initialize() {
 	//---------------- non-scc resources ------------
 	//Peer resources
 	d.cResourcePolicyMap[resources.Peer_Propose] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Peer_Evaluate] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Peer_ChaincodeToChaincode] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
@@ -23,11 +23,14 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo        = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber    = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash      = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID  = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID      = "qscc/GetBlockByTxID"
+	Qscc_GetStateAtHeight    = "qscc/GetStateAtHeight"
+	Qscc_GetStateFingerprint = "qscc/GetStateFingerprint"
+	Qscc_GetChaincodeEvents  = "qscc/GetChaincodeEvents"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -38,6 +41,7 @@ const (
 
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
+	Peer_Evaluate             = "peer/Evaluate"
 	Peer_ChaincodeToChaincode = "peer/ChaincodeToChaincode"
 
 	//Events
@@ -34,13 +34,29 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// ChannelUnjoiner removes channels from the peer
+type ChannelUnjoiner interface {
+	// UnjoinChannel stops serving the given channel and removes it, along
+	// with all its data, from the peer
+	UnjoinChannel(channelID string) error
+}
+
+// UnjoinChannelFunc is an adapter that allows functions to be used as a ChannelUnjoiner
+type UnjoinChannelFunc func(channelID string) error
+
+// UnjoinChannel calls f(channelID)
+func (f UnjoinChannelFunc) UnjoinChannel(channelID string) error {
+	return f(channelID)
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, cu ChannelUnjoiner) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
-		specAtStartup: flogging.Global.Spec(),
+		specAtStartup:   flogging.Global.Spec(),
+		channelUnjoiner: cu,
 	}
 	return s
 }
@@ -49,7 +65,8 @@ func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
 type ServerAdmin struct {
 	v requestValidator
 
-	specAtStartup string
+	specAtStartup   string
+	channelUnjoiner ChannelUnjoiner
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -140,3 +157,22 @@ func (s *ServerAdmin) SetLogSpec(ctx context.Context, env *common.Envelope) (*pb
 	}
 	return logResponse, nil
 }
+
+func (s *ServerAdmin) UnjoinChannel(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetUnjoinChannelReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if request.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel ID must be specified")
+	}
+	logger.Infof("Unjoining channel [%s]", request.ChannelId)
+	if err := s.channelUnjoiner.UnjoinChannel(request.ChannelId); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
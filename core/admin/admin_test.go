@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/core/testutil"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,7 +37,7 @@ func (v *mockValidator) validate(ctx context.Context, env *common.Envelope) (*pb
 }
 
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -46,7 +47,7 @@ func TestGetStatus(t *testing.T) {
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -56,10 +57,10 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
-	mv.On("validate").Return(nil, accessDenied).Times(7)
+	mv.On("validate").Return(nil, accessDenied).Times(8)
 
 	ctx := context.Background()
 	status, err := adminServer.GetStatus(ctx, nil)
@@ -85,10 +86,13 @@ func TestForbidden(t *testing.T) {
 
 	_, err = adminServer.StartServer(ctx, nil)
 	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.UnjoinChannel(ctx, nil)
+	assert.Equal(t, accessDenied, err)
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -219,3 +223,46 @@ func TestLoggingCalls(t *testing.T) {
 		}
 	}
 }
+
+func TestUnjoinChannel(t *testing.T) {
+	var unjoinedChannels []string
+	adminServer := NewAdminServer(nil, UnjoinChannelFunc(func(channelID string) error {
+		if channelID == "bogus" {
+			return errors.New("channel [bogus] does not exist")
+		}
+		unjoinedChannels = append(unjoinedChannels, channelID)
+		return nil
+	}))
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapUnjoinChannelRequest := func(r *pb.UnjoinChannelRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_UnjoinChannelReq{
+				UnjoinChannelReq: r,
+			},
+		}
+	}
+
+	testCases := []struct {
+		req         *pb.UnjoinChannelRequest
+		expectedErr string
+	}{
+		{req: nil, expectedErr: "request is nil"},
+		{req: &pb.UnjoinChannelRequest{}, expectedErr: "rpc error: code = InvalidArgument desc = channel ID must be specified"},
+		{req: &pb.UnjoinChannelRequest{ChannelId: "bogus"}, expectedErr: "channel [bogus] does not exist"},
+		{req: &pb.UnjoinChannelRequest{ChannelId: "mychannel"}},
+	}
+	for _, tc := range testCases {
+		mv.On("validate").Return(wrapUnjoinChannelRequest(tc.req), nil).Once()
+		resp, err := adminServer.UnjoinChannel(context.Background(), nil)
+		if tc.expectedErr != "" {
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+	}
+	assert.Equal(t, []string{"mychannel"}, unjoinedChannels)
+}
@@ -204,6 +204,15 @@ func (lc *Lifecycle) NewChannelSubscription(channel string, queryCreator QueryCr
 	return sub, nil
 }
 
+// RemoveChannel removes the metadata of the chaincodes of the given channel,
+// which is expected to be invoked when the peer is removed from the channel
+func (lc *Lifecycle) RemoveChannel(channel string) {
+	lc.Lock()
+	defer lc.Unlock()
+	delete(lc.deployedCCsByChannel, channel)
+	delete(lc.queryCreatorsByChannel, channel)
+}
+
 // AddListener registers the given listener to be triggered upon a lifecycle change
 func (lc *Lifecycle) AddListener(listener LifeCycleChangeListener) {
 	lc.Lock()
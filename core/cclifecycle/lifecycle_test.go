@@ -466,6 +466,12 @@ func TestMetadata(t *testing.T) {
 	md = lc.Metadata("mychannel", "cc1", true)
 	assert.Nil(t, md)
 	assertLogged(t, recorder, "Failed querying lscc namespace for cc1~collection: foo")
+
+	// Scenario IX: The channel is removed from the lifecycle, hence its metadata is no longer available
+	lc.RemoveChannel("mychannel")
+	md = lc.Metadata("mychannel", "cc1", false)
+	assert.Nil(t, md)
+	assertLogged(t, recorder, "Requested Metadata for non-existent channel mychannel")
 }
 
 func newLogRecorder(t *testing.T) (*floggingtest.Recorder, func()) {
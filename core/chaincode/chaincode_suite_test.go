@@ -110,6 +110,21 @@ type peerLedger interface {
 	ledger.PeerLedger
 }
 
+//go:generate counterfeiter -o mock/launcher.go --fake-name Launcher . launcher
+type launcher interface {
+	chaincode.Launcher
+}
+
+//go:generate counterfeiter -o mock/container_info_provider.go --fake-name ContainerInfoProvider . containerInfoProvider
+type containerInfoProvider interface {
+	chaincode.ContainerInfoProvider
+}
+
+//go:generate counterfeiter -o mock/channel_ledgers.go --fake-name ChannelLedgers . channelLedgers
+type channelLedgers interface {
+	chaincode.ChannelLedgers
+}
+
 // NOTE: These are getting generated into the "fake" package to avoid import cycles. We need to revisit this.
 
 //go:generate counterfeiter -o fake/launch_registry.go --fake-name LaunchRegistry . launchRegistry
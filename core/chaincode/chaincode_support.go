@@ -58,6 +58,8 @@ type ChaincodeSupport struct {
 	appConfig        ApplicationConfigRetriever
 	HandlerMetrics   *HandlerMetrics
 	LaunchMetrics    *LaunchMetrics
+	AdditionalParams *pb.ChaincodeAdditionalParams
+	Upgrader         *Upgrader
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -87,6 +89,12 @@ func NewChaincodeSupport(
 		appConfig:        appConfig,
 		HandlerMetrics:   NewHandlerMetrics(metricsProvider),
 		LaunchMetrics:    NewLaunchMetrics(metricsProvider),
+		AdditionalParams: &pb.ChaincodeAdditionalParams{
+			UseWriteBatch:          config.UseWriteBatch,
+			MaxSizeWriteBatch:      config.MaxSizeWriteBatch,
+			UseGetMultipleKeys:     config.UseGetMultipleKeys,
+			MaxSizeGetMultipleKeys: config.MaxSizeGetMultipleKeys,
+		},
 	}
 
 	// Keep TestQueries working
@@ -115,6 +123,17 @@ func NewChaincodeSupport(
 		Metrics:         cs.LaunchMetrics,
 	}
 
+	cs.Upgrader = &Upgrader{
+		Launcher:         cs.Launcher,
+		Runtime:          cs.Runtime,
+		Registry:         cs.HandlerRegistry,
+		ContainerInfo:    ContainerInfoFunc(InstalledContainerInfo),
+		DefinitionGetter: lifecycle,
+		Channels:         peer.Default,
+		GracePeriod:      config.UpgradeGracePeriod,
+		Metrics:          NewUpgradeMetrics(metricsProvider),
+	}
+
 	return cs
 }
 
@@ -186,6 +205,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		LedgerGetter:               peer.Default,
 		AppConfig:                  cs.appConfig,
 		Metrics:                    cs.HandlerMetrics,
+		AdditionalParams:           cs.AdditionalParams,
 	}
 
 	return handler.ProcessStream(stream)
@@ -215,7 +235,7 @@ func createCCMessage(messageType pb.ChaincodeMessage_Type, cid string, txid stri
 // is entirely deprecated.  Ideally one release after the introduction of the new lifecycle.
 // It does not attempt to start the chaincode based on the information from lifecycle, but instead
 // accepts the container information directly in the form of a ChaincodeDeploymentSpec.
-func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	ccci := ccprovider.DeploymentSpecToChaincodeContainerInfo(spec)
 	ccci.Version = cccid.Version
 
@@ -235,12 +255,12 @@ func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionPa
 }
 
 // Execute invokes chaincode and returns the original response.
-func (cs *ChaincodeSupport) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (cs *ChaincodeSupport) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	resp, err := cs.Invoke(txParams, cccid, input)
 	return processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
 }
 
-func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMessage, err error) (*pb.Response, *pb.ChaincodeEvent, error) {
+func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMessage, err error) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to execute transaction %s", txid)
 	}
@@ -248,9 +268,13 @@ func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMess
 		return nil, nil, errors.Errorf("nil response from transaction %s", txid)
 	}
 
-	if resp.ChaincodeEvent != nil {
-		resp.ChaincodeEvent.ChaincodeId = ccName
-		resp.ChaincodeEvent.TxId = txid
+	events := resp.ChaincodeEvents
+	if len(events) == 0 && resp.ChaincodeEvent != nil {
+		events = []*pb.ChaincodeEvent{resp.ChaincodeEvent}
+	}
+	for _, event := range events {
+		event.ChaincodeId = ccName
+		event.TxId = txid
 	}
 
 	switch resp.Type {
@@ -260,10 +284,10 @@ func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMess
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "failed to unmarshal response for transaction %s", txid)
 		}
-		return res, resp.ChaincodeEvent, nil
+		return res, events, nil
 
 	case pb.ChaincodeMessage_ERROR:
-		return nil, resp.ChaincodeEvent, errors.Errorf("transaction returned with failure: %s", resp.Payload)
+		return nil, events, errors.Errorf("transaction returned with failure: %s", resp.Payload)
 
 	default:
 		return nil, nil, errors.Errorf("unexpected response type %d for transaction %s", resp.Type, txid)
@@ -1440,3 +1440,39 @@ func TestCCFramework(t *testing.T) {
 
 	ccSide.Quit()
 }
+
+func TestProcessChaincodeExecutionResultEvents(t *testing.T) {
+	resBytes := putils.MarshalOrPanic(&pb.Response{Status: shim.OK})
+
+	// a chaincode emitting a single event only sets the legacy field
+	res, events, err := processChaincodeExecutionResult("txid", "mycc", &pb.ChaincodeMessage{
+		Type:           pb.ChaincodeMessage_COMPLETED,
+		Payload:        resBytes,
+		ChaincodeEvent: &pb.ChaincodeEvent{EventName: "event1"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []*pb.ChaincodeEvent{{ChaincodeId: "mycc", TxId: "txid", EventName: "event1"}}, events)
+
+	res, events, err = processChaincodeExecutionResult("txid", "mycc", &pb.ChaincodeMessage{
+		Type:           pb.ChaincodeMessage_COMPLETED,
+		Payload:        resBytes,
+		ChaincodeEvent: &pb.ChaincodeEvent{EventName: "event1"},
+		ChaincodeEvents: []*pb.ChaincodeEvent{
+			{EventName: "event1"},
+			{EventName: "event2"},
+		},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.ChaincodeEvent{
+		{ChaincodeId: "mycc", TxId: "txid", EventName: "event1"},
+		{ChaincodeId: "mycc", TxId: "txid", EventName: "event2"},
+	}, events)
+
+	_, events, err = processChaincodeExecutionResult("txid", "mycc", &pb.ChaincodeMessage{
+		Type:    pb.ChaincodeMessage_COMPLETED,
+		Payload: resBytes,
+	}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+}
@@ -19,6 +19,7 @@ import (
 const (
 	defaultExecutionTimeout = 30 * time.Second
 	minimumStartupTimeout   = 5 * time.Second
+	defaultMaxSizeBatch     = 1000
 )
 
 type Config struct {
@@ -29,6 +30,18 @@ type Config struct {
 	LogFormat      string
 	LogLevel       string
 	ShimLogLevel   string
+
+	// UpgradeGracePeriod is how long the previous version of an upgraded
+	// chaincode may finish its transactions before it is stopped
+	UpgradeGracePeriod time.Duration
+
+	// UseWriteBatch and UseGetMultipleKeys allow the chaincodes to batch their
+	// writes and their reads of multiple keys, which carry at most
+	// MaxSizeWriteBatch writes and MaxSizeGetMultipleKeys keys per message
+	UseWriteBatch          bool
+	MaxSizeWriteBatch      uint32
+	UseGetMultipleKeys     bool
+	MaxSizeGetMultipleKeys uint32
 }
 
 func GlobalConfig() *Config {
@@ -54,10 +67,37 @@ func (c *Config) load() {
 	if c.StartupTimeout < minimumStartupTimeout {
 		c.StartupTimeout = minimumStartupTimeout
 	}
+	c.UpgradeGracePeriod = viper.GetDuration("chaincode.upgradeGracePeriod")
+	if c.UpgradeGracePeriod <= 0 {
+		// no transaction outlasts the execute timeout
+		c.UpgradeGracePeriod = c.ExecuteTimeout
+	}
 
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
+
+	c.UseWriteBatch = getBoolOrDefault("chaincode.runtimeParams.useWriteBatch", true)
+	c.MaxSizeWriteBatch = getMaxSizeBatch("chaincode.runtimeParams.maxSizeWriteBatch")
+	c.UseGetMultipleKeys = getBoolOrDefault("chaincode.runtimeParams.useGetMultipleKeys", true)
+	c.MaxSizeGetMultipleKeys = getMaxSizeBatch("chaincode.runtimeParams.maxSizeGetMultipleKeys")
+}
+
+func getBoolOrDefault(key string, def bool) bool {
+	if !viper.IsSet(key) {
+		return def
+	}
+	return viper.GetBool(key)
+}
+
+// getMaxSizeBatch gets a batch size from viper, which defaults to
+// defaultMaxSizeBatch when it is not positive
+func getMaxSizeBatch(key string) uint32 {
+	size := viper.GetInt(key)
+	if size <= 0 {
+		return defaultMaxSizeBatch
+	}
+	return uint32(size)
 }
 
 func toSeconds(s string, def int) time.Duration {
@@ -32,18 +32,41 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.keepalive", "50")
 			viper.Set("chaincode.executetimeout", "20h")
 			viper.Set("chaincode.startuptimeout", "30h")
+			viper.Set("chaincode.upgradeGracePeriod", "40h")
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "WARNING")
 			viper.Set("chaincode.logging.shim", "WARNING")
+			viper.Set("chaincode.runtimeParams.useWriteBatch", false)
+			viper.Set("chaincode.runtimeParams.maxSizeWriteBatch", 10)
+			viper.Set("chaincode.runtimeParams.useGetMultipleKeys", true)
+			viper.Set("chaincode.runtimeParams.maxSizeGetMultipleKeys", 20)
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
 			Expect(config.Keepalive).To(Equal(50 * time.Second))
 			Expect(config.ExecuteTimeout).To(Equal(20 * time.Hour))
 			Expect(config.StartupTimeout).To(Equal(30 * time.Hour))
+			Expect(config.UpgradeGracePeriod).To(Equal(40 * time.Hour))
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("WARNING"))
 			Expect(config.ShimLogLevel).To(Equal("WARNING"))
+			Expect(config.UseWriteBatch).To(BeFalse())
+			Expect(config.MaxSizeWriteBatch).To(Equal(uint32(10)))
+			Expect(config.UseGetMultipleKeys).To(BeTrue())
+			Expect(config.MaxSizeGetMultipleKeys).To(Equal(uint32(20)))
+		})
+
+		Context("when the maximum sizes of the batches are not positive", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.runtimeParams.maxSizeWriteBatch", 0)
+				viper.Set("chaincode.runtimeParams.maxSizeGetMultipleKeys", -1)
+			})
+
+			It("falls back to the default sizes", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.MaxSizeWriteBatch).To(Equal(uint32(1000)))
+				Expect(config.MaxSizeGetMultipleKeys).To(Equal(uint32(1000)))
+			})
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -79,6 +102,18 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("when the upgrade grace period is not set", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.executetimeout", "20h")
+				viper.Set("chaincode.upgradeGracePeriod", "")
+			})
+
+			It("falls back to the execute timeout", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.UpgradeGracePeriod).To(Equal(20 * time.Hour))
+			})
+		})
+
 		Context("when an invalid log level is configured", func() {
 			BeforeEach(func() {
 				viper.Set("chaincode.logging.level", "foo")
@@ -112,13 +147,17 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":                               viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":                            viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":                       viper.GetString("chaincode.executetimeout"),
+		"chaincode.startuptimeout":                       viper.GetString("chaincode.startuptimeout"),
+		"chaincode.logging.format":                       viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":                        viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":                         viper.GetString("chaincode.logging.shim"),
+		"chaincode.runtimeParams.useWriteBatch":          viper.GetString("chaincode.runtimeParams.useWriteBatch"),
+		"chaincode.runtimeParams.maxSizeWriteBatch":      viper.GetString("chaincode.runtimeParams.maxSizeWriteBatch"),
+		"chaincode.runtimeParams.useGetMultipleKeys":     viper.GetString("chaincode.runtimeParams.useGetMultipleKeys"),
+		"chaincode.runtimeParams.maxSizeGetMultipleKeys": viper.GetString("chaincode.runtimeParams.maxSizeGetMultipleKeys"),
 	}
 
 	return func() {
@@ -17,6 +17,8 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalcontroller"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -47,6 +49,13 @@ type ContainerRuntime struct {
 func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
 	cname := ccci.Name + ":" + ccci.Version
 
+	switch ccci.ContainerType {
+	case externalcontroller.ContainerType:
+		return c.connect(ccci, codePackage)
+	case wasmcontroller.ContainerType:
+		return c.startInPeer(cname, ccci, codePackage)
+	}
+
 	lc, err := c.LaunchConfig(cname, ccci.Type)
 	if err != nil {
 		return err
@@ -81,6 +90,47 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 	return nil
 }
 
+// connect connects to chaincode running as an external service, which needs neither
+// launch arguments nor TLS certificates, as the peer is the one connecting.
+func (c *ContainerRuntime) connect(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	chaincodeLogger.Debugf("connect to chaincode: %s:%s", ccci.Name, ccci.Version)
+
+	scr := container.StartContainerReq{
+		Builder: &container.CodePackageBuilder{CodePackage: codePackage},
+		CCID: ccintf.CCID{
+			Name:    ccci.Name,
+			Version: ccci.Version,
+		},
+	}
+
+	if err := c.Processor.Process(ccci.ContainerType, scr); err != nil {
+		return errors.WithMessage(err, "error connecting to chaincode")
+	}
+
+	return nil
+}
+
+// startInPeer starts WebAssembly chaincode, which runs in the peer from its code package,
+// and only needs the name it registers with.
+func (c *ContainerRuntime) startInPeer(cname string, ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	chaincodeLogger.Debugf("start chaincode in peer: %s", cname)
+
+	scr := container.StartContainerReq{
+		Builder: &container.CodePackageBuilder{CodePackage: codePackage},
+		Env:     []string{"CORE_CHAINCODE_ID_NAME=" + cname},
+		CCID: ccintf.CCID{
+			Name:    ccci.Name,
+			Version: ccci.Version,
+		},
+	}
+
+	if err := c.Processor.Process(ccci.ContainerType, scr); err != nil {
+		return errors.WithMessage(err, "error starting chaincode in peer")
+	}
+
+	return nil
+}
+
 // Stop terminates chaincode and its container runtime environment.
 func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
 	scr := container.StopContainerReq{
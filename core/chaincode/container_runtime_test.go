@@ -189,6 +189,80 @@ func TestContainerRuntimeStart(t *testing.T) {
 	})
 }
 
+func TestContainerRuntimeStartExternal(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		PeerAddress: "peer.example.com",
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Name:          "chaincode-name",
+		Version:       "chaincode-version",
+		ContainerType: "EXTERNAL",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	vmType, req := fakeProcessor.ProcessArgsForCall(0)
+	assert.Equal(t, vmType, "EXTERNAL")
+	startReq, ok := req.(container.StartContainerReq)
+	assert.True(t, ok)
+
+	assert.Equal(t, startReq.Builder, &container.CodePackageBuilder{CodePackage: []byte("code-package")})
+	assert.Nil(t, startReq.Args)
+	assert.Nil(t, startReq.Env)
+	assert.Nil(t, startReq.FilesToUpload)
+	assert.Equal(t, startReq.CCID, ccintf.CCID{
+		Name:    "chaincode-name",
+		Version: "chaincode-version",
+	})
+
+	fakeProcessor.ProcessReturns(errors.New("process-failed"))
+	err = cr.Start(ccci, nil)
+	assert.EqualError(t, err, "error connecting to chaincode: process-failed")
+}
+
+func TestContainerRuntimeStartWasm(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		PeerAddress: "peer.example.com",
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_WASM.String(),
+		Name:          "chaincode-name",
+		Version:       "chaincode-version",
+		ContainerType: "WASM",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	vmType, req := fakeProcessor.ProcessArgsForCall(0)
+	assert.Equal(t, vmType, "WASM")
+	startReq, ok := req.(container.StartContainerReq)
+	assert.True(t, ok)
+
+	assert.Equal(t, startReq.Builder, &container.CodePackageBuilder{CodePackage: []byte("code-package")})
+	assert.Nil(t, startReq.Args)
+	assert.Equal(t, []string{"CORE_CHAINCODE_ID_NAME=chaincode-name:chaincode-version"}, startReq.Env)
+	assert.Nil(t, startReq.FilesToUpload)
+	assert.Equal(t, startReq.CCID, ccintf.CCID{
+		Name:    "chaincode-name",
+		Version: "chaincode-version",
+	})
+
+	fakeProcessor.ProcessReturns(errors.New("process-failed"))
+	err = cr.Start(ccci, nil)
+	assert.EqualError(t, err, "error starting chaincode in peer: process-failed")
+}
+
 func TestContainerRuntimeStartErrors(t *testing.T) {
 	tests := []struct {
 		chaincodeType string
@@ -44,6 +44,16 @@ type ContextRegistry struct {
 	getReturnsOnCall map[int]struct {
 		result1 *chaincode.TransactionContext
 	}
+	LenStub        func() int
+	lenMutex       sync.RWMutex
+	lenArgsForCall []struct {
+	}
+	lenReturns struct {
+		result1 int
+	}
+	lenReturnsOnCall map[int]struct {
+		result1 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -227,6 +237,58 @@ func (fake *ContextRegistry) GetReturnsOnCall(i int, result1 *chaincode.Transact
 	}{result1}
 }
 
+func (fake *ContextRegistry) Len() int {
+	fake.lenMutex.Lock()
+	ret, specificReturn := fake.lenReturnsOnCall[len(fake.lenArgsForCall)]
+	fake.lenArgsForCall = append(fake.lenArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Len", []interface{}{})
+	fake.lenMutex.Unlock()
+	if fake.LenStub != nil {
+		return fake.LenStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lenReturns
+	return fakeReturns.result1
+}
+
+func (fake *ContextRegistry) LenCallCount() int {
+	fake.lenMutex.RLock()
+	defer fake.lenMutex.RUnlock()
+	return len(fake.lenArgsForCall)
+}
+
+func (fake *ContextRegistry) LenCalls(stub func() int) {
+	fake.lenMutex.Lock()
+	defer fake.lenMutex.Unlock()
+	fake.LenStub = stub
+}
+
+func (fake *ContextRegistry) LenReturns(result1 int) {
+	fake.lenMutex.Lock()
+	defer fake.lenMutex.Unlock()
+	fake.LenStub = nil
+	fake.lenReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *ContextRegistry) LenReturnsOnCall(i int, result1 int) {
+	fake.lenMutex.Lock()
+	defer fake.lenMutex.Unlock()
+	fake.LenStub = nil
+	if fake.lenReturnsOnCall == nil {
+		fake.lenReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.lenReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *ContextRegistry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +300,8 @@ func (fake *ContextRegistry) Invocations() map[string][][]interface{} {
 	defer fake.deleteMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.lenMutex.RLock()
+	defer fake.lenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -20,6 +20,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
@@ -69,6 +70,7 @@ type ContextRegistry interface {
 	Create(txParams *ccprovider.TransactionParams) (*TransactionContext, error)
 	Get(chainID, txID string) *TransactionContext
 	Delete(chainID, txID string)
+	Len() int
 	Close()
 }
 
@@ -151,6 +153,9 @@ type Handler struct {
 	AppConfig ApplicationConfigRetriever
 	// Metrics holds chaincode handler metrics
 	Metrics *HandlerMetrics
+	// AdditionalParams tells the chaincode, on registration, whether it may
+	// batch its reads and writes. The chaincode batches neither when it is nil
+	AdditionalParams *pb.ChaincodeAdditionalParams
 
 	// state holds the current handler state. It will be created, established, or
 	// ready.
@@ -221,6 +226,10 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetQueryResult)
 	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		go h.HandleTransaction(msg, h.HandleGetHistoryForKey)
+	case pb.ChaincodeMessage_GET_STATE_AT_HEIGHT:
+		go h.HandleTransaction(msg, h.HandleGetStateAtHeight)
+	case pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT:
+		go h.HandleTransaction(msg, h.HandleGetStateByRangeAtHeight)
 	case pb.ChaincodeMessage_QUERY_STATE_NEXT:
 		go h.HandleTransaction(msg, h.HandleQueryStateNext)
 	case pb.ChaincodeMessage_QUERY_STATE_CLOSE:
@@ -231,6 +240,10 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandlePutStateMetadata)
+	case pb.ChaincodeMessage_GET_STATE_MULTIPLE:
+		go h.HandleTransaction(msg, h.HandleGetStateMultiple)
+	case pb.ChaincodeMessage_PUT_STATE_BATCH:
+		go h.HandleTransaction(msg, h.HandlePutStateBatch)
 	default:
 		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, msg.Type)
 	}
@@ -526,8 +539,18 @@ func (h *Handler) HandleRegister(msg *pb.ChaincodeMessage) {
 	// name in keys
 	h.ccInstance = ParseName(h.chaincodeID.Name)
 
+	var payload []byte
+	if h.AdditionalParams != nil {
+		payload, err = proto.Marshal(h.AdditionalParams)
+		if err != nil {
+			chaincodeLogger.Errorf("Error marshaling the additional params of %s: %s", pb.ChaincodeMessage_REGISTERED, err)
+			h.notifyRegistry(err)
+			return
+		}
+	}
+
 	chaincodeLogger.Debugf("Got %s for chaincodeID = %s, sending back %s", pb.ChaincodeMessage_REGISTER, chaincodeID, pb.ChaincodeMessage_REGISTERED)
-	if err := h.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}); err != nil {
+	if err := h.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: payload}); err != nil {
 		chaincodeLogger.Errorf("error sending %s: %s", pb.ChaincodeMessage_REGISTERED, err)
 		h.notifyRegistry(err)
 		return
@@ -580,6 +603,50 @@ func (h *Handler) registerTxid(msg *pb.ChaincodeMessage) bool {
 	return false
 }
 
+// recordCrossChannelIntent records with the simulator of the calling chaincode
+// the pending write intent of the writes of a chaincode invoked on another
+// channel, if both channels support cross-channel writes.
+func (h *Handler) recordCrossChannelIntent(txid string, txContext *TransactionContext, targetChannel string, sim ledger.TxSimulator) error {
+	for _, channelID := range []string{txContext.ChainID, targetChannel} {
+		ac, exists := h.AppConfig.GetApplicationConfig(channelID)
+		if !exists || !ac.Capabilities().CrossChannelWrites() {
+			return nil
+		}
+	}
+
+	simRes, err := sim.GetTxSimulationResults()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if simRes.ContainsPvtWrites() {
+		return errors.Errorf("private data writes on channel %s are not supported by cross-channel writes", targetChannel)
+	}
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	writes, err := crosschannel.Writes(pubSimResBytes)
+	if err != nil {
+		return err
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	intent, err := proto.Marshal(&pb.CrossChannelIntent{
+		TxId:          txid,
+		SourceChannel: txContext.ChainID,
+		TargetChannel: targetChannel,
+		TargetResults: pubSimResBytes,
+		Status:        pb.CrossChannelIntent_PREPARED,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	chaincodeLogger.Debugf("[%s] recording cross-channel write intent on channel %s", shorttxid(txid), targetChannel)
+	return txContext.TXSimulator.SetState(crosschannel.Namespace, crosschannel.PendingIntentKey(txid, intent), intent)
+}
+
 func (h *Handler) checkMetadataCap(msg *pb.ChaincodeMessage) error {
 	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
 	if !exists {
@@ -654,6 +721,7 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordStateReads(res)
 	if res == nil {
 		chaincodeLogger.Debugf("[%s] No state associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
 	}
@@ -662,6 +730,50 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to ledger to get the state of multiple keys
+func (h *Handler) HandleGetStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	if h.AdditionalParams == nil || !h.AdditionalParams.UseGetMultipleKeys {
+		return nil, errors.Errorf("%s is not enabled", pb.ChaincodeMessage_GET_STATE_MULTIPLE)
+	}
+
+	getStateMultiple := &pb.GetStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, getStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if maxSize := h.AdditionalParams.MaxSizeGetMultipleKeys; maxSize != 0 && len(getStateMultiple.Keys) > int(maxSize) {
+		return nil, errors.Errorf("number of keys %d exceeds the maximum of %d", len(getStateMultiple.Keys), maxSize)
+	}
+
+	var values [][]byte
+	chaincodeName := h.ChaincodeName()
+	collection := getStateMultiple.Collection
+	chaincodeLogger.Debugf("[%s] getting state for chaincode %s, %d keys, channel %s", shorttxid(msg.Txid), chaincodeName, len(getStateMultiple.Keys), txContext.ChainID)
+
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if err := errorIfCreatorHasNoReadAccess(chaincodeName, collection, txContext); err != nil {
+			return nil, err
+		}
+		values, err = txContext.TXSimulator.GetPrivateDataMultipleKeys(chaincodeName, collection, getStateMultiple.Keys)
+	} else {
+		values, err = txContext.TXSimulator.GetStateMultipleKeys(chaincodeName, getStateMultiple.Keys)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	txContext.RecordStateReads(values...)
+
+	res, err := proto.Marshal(&pb.GetStateMultipleResult{Values: values})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	getState := &pb.GetState{}
 	err := proto.Unmarshal(msg.Payload, getState)
@@ -680,6 +792,7 @@ func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordStateReads(res)
 	if res == nil {
 		chaincodeLogger.Debugf("[%s] No state associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
 	}
@@ -795,6 +908,7 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordQueryResults(payload.GetResults())
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -826,6 +940,7 @@ func (h *Handler) HandleQueryStateNext(msg *pb.ChaincodeMessage, txContext *Tran
 		txContext.CleanupQueryContext(queryStateNext.Id)
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordQueryResults(payload.GetResults())
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -912,6 +1027,7 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordQueryResults(payload.GetResults())
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -934,19 +1050,107 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKey.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+
+	var historyIter commonledger.ResultsIterator
+	isPaginated := false
+
+	options := getHistoryForKey.Options
+	if options != nil || isMetadataSetForPagination(metadata) {
+		historyOptions := &ledger.HistoryQueryOptions{}
+		if options != nil {
+			historyOptions.StartBlock = options.StartBlock
+			historyOptions.EndBlock = options.EndBlock
+			historyOptions.Descending = options.Descending
+		}
+		if isMetadataSetForPagination(metadata) {
+			isPaginated = true
+			historyOptions.PageSize = totalReturnLimit
+			historyOptions.Bookmark = metadata.Bookmark
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithOptions(chaincodeName, getHistoryForKey.Key, historyOptions)
+	} else {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	txContext.InitializeQueryContext(iterID, historyIter)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.WithStack(err)
+	}
+	txContext.RecordQueryResults(payload.GetResults())
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	chaincodeLogger.Debugf("Got keys and values. Sending %s", pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles query to ledger history db to get the state of a key at a given height.
+// The read isn't recorded in the read set of the transaction, since it doesn't
+// depend on the current state.
+func (h *Handler) HandleGetStateAtHeight(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateAtHeight := &pb.GetStateAtHeight{}
+	err := proto.Unmarshal(msg.Payload, getStateAtHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	keyModification, err := txContext.HistoryQueryExecutor.GetStateAtHeight(chaincodeName, getStateAtHeight.Key, getStateAtHeight.BlockNum)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Send response msg back to chaincode. A key that didn't exist
+	// at the height is returned as a nil value, like GetState does.
+	var res []byte
+	if keyModification != nil && !keyModification.IsDelete {
+		res = keyModification.Value
+	}
+	txContext.RecordStateReads(res)
+	chaincodeLogger.Debugf("[%s] Got state at height %d. Sending %s", shorttxid(msg.Txid), getStateAtHeight.BlockNum, pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles range query to ledger history db to get the state of the keys in a range at a given height
+func (h *Handler) HandleGetStateByRangeAtHeight(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateByRangeAtHeight := &pb.GetStateByRangeAtHeight{}
+	err := proto.Unmarshal(msg.Payload, getStateByRangeAtHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	iterID := h.UUIDGenerator.New()
+	chaincodeName := h.ChaincodeName()
+	rangeIter, err := txContext.HistoryQueryExecutor.GetStateRangeScanIteratorAtHeight(chaincodeName,
+		getStateByRangeAtHeight.StartKey, getStateByRangeAtHeight.EndKey, getStateByRangeAtHeight.BlockNum)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	totalReturnLimit := calculateTotalReturnLimit(nil)
 
-	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	txContext.InitializeQueryContext(iterID, rangeIter)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, rangeIter, iterID, false, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordQueryResults(payload.GetResults())
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -1068,6 +1272,50 @@ func (h *Handler) HandlePutState(msg *pb.ChaincodeMessage, txContext *Transactio
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordStateWrites(putState.Value)
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles the writes the chaincode buffered during the transaction
+func (h *Handler) HandlePutStateBatch(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	if h.AdditionalParams == nil || !h.AdditionalParams.UseWriteBatch {
+		return nil, errors.Errorf("%s is not enabled", pb.ChaincodeMessage_PUT_STATE_BATCH)
+	}
+
+	putStateBatch := &pb.PutStateBatch{}
+	err := proto.Unmarshal(msg.Payload, putStateBatch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if maxSize := h.AdditionalParams.MaxSizeWriteBatch; maxSize != 0 && len(putStateBatch.Records) > int(maxSize) {
+		return nil, errors.Errorf("number of writes %d exceeds the maximum of %d", len(putStateBatch.Records), maxSize)
+	}
+
+	chaincodeName := h.ChaincodeName()
+	for _, record := range putStateBatch.Records {
+		collection := record.Collection
+		if isCollectionSet(collection) {
+			if txContext.IsInitTransaction {
+				return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+			}
+			if record.IsDelete {
+				err = txContext.TXSimulator.DeletePrivateData(chaincodeName, collection, record.Key)
+			} else {
+				err = txContext.TXSimulator.SetPrivateData(chaincodeName, collection, record.Key, record.Value)
+			}
+		} else {
+			if record.IsDelete {
+				err = txContext.TXSimulator.DeleteState(chaincodeName, record.Key)
+			} else {
+				err = txContext.TXSimulator.SetState(chaincodeName, record.Key, record.Value)
+			}
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		txContext.RecordStateWrites(record.Value)
+	}
 
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
@@ -1124,6 +1372,7 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	txContext.RecordStateWrites(nil)
 
 	// Send response msg back to chaincode.
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
@@ -1227,6 +1476,15 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.Wrap(err, "execute failed")
 	}
 
+	// The writes of a chaincode of another channel are discarded, unless both
+	// channels support cross-channel writes
+	if targetInstance.ChainID != txContext.ChainID && responseMessage.Type == pb.ChaincodeMessage_COMPLETED {
+		err = h.recordCrossChannelIntent(msg.Txid, txContext, targetInstance.ChainID, txParams.TXSimulator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// payload is marshalled and sent to the calling chaincode's shim which unmarshals and
 	// sends it to chaincode
 	res, err := proto.Marshal(responseMessage)
@@ -1268,10 +1526,22 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	case <-h.streamDone():
 		err = errors.New("chaincode stream terminated")
 	}
+	h.recordResourceUsage(cccid.Name+":"+cccid.Version, msg.ChannelId, txctx)
 
 	return ccresp, err
 }
 
+// recordResourceUsage exports the resources used by a chaincode execution as metrics
+func (h *Handler) recordResourceUsage(ccName, channelID string, txctx *TransactionContext) {
+	usage := txctx.ResourceUsage()
+	labels := []string{"chaincode", ccName, "channel", channelID}
+	h.Metrics.StateReads.With(labels...).Add(float64(usage.StateReads))
+	h.Metrics.StateWrites.With(labels...).Add(float64(usage.StateWrites))
+	h.Metrics.StateBytesRead.With(labels...).Add(float64(usage.BytesRead))
+	h.Metrics.StateBytesWritten.With(labels...).Add(float64(usage.BytesWritten))
+	h.Metrics.QueryResults.With(labels...).Add(float64(usage.QueryResults))
+}
+
 func (h *Handler) setChaincodeProposal(signedProp *pb.SignedProposal, prop *pb.Proposal, msg *pb.ChaincodeMessage) error {
 	if prop != nil && signedProp == nil {
 		return errors.New("failed getting proposal context. Signed proposal is nil")
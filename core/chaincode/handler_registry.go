@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -121,6 +123,21 @@ func (r *HandlerRegistry) Handler(cname string) *Handler {
 	return h
 }
 
+// Versions returns the versions of a chaincode which have registered handlers.
+func (r *HandlerRegistry) Versions(name string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var versions []string
+	for cname := range r.handlers {
+		if strings.HasPrefix(cname, name+":") {
+			versions = append(versions, strings.TrimPrefix(cname, name+":"))
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
 // Register adds a chaincode handler to the registry.
 // An error will be returned if a handler is already registered for the
 // chaincode. An error will also be returned if the chaincode has not already
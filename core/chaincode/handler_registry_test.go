@@ -147,6 +147,23 @@ var _ = Describe("HandlerRegistry", func() {
 		})
 	})
 
+	Describe("Versions", func() {
+		BeforeEach(func() {
+			for _, cname := range []string{"chaincode-name:2.0", "chaincode-name:1.0", "chaincode-name-other:1.0"} {
+				h := &chaincode.Handler{}
+				chaincode.SetHandlerChaincodeID(h, &pb.ChaincodeID{Name: cname})
+				err := hr.Register(h)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the versions of the chaincode with registered handlers", func() {
+			Expect(hr.Versions("chaincode-name")).To(Equal([]string{"1.0", "2.0"}))
+			Expect(hr.Versions("chaincode-name-other")).To(Equal([]string{"1.0"}))
+			Expect(hr.Versions("unregistered-name")).To(BeEmpty())
+		})
+	})
+
 	Describe("Register", func() {
 		Context("when unsolicited registration is disallowed", func() {
 			BeforeEach(func() {
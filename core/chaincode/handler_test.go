@@ -19,7 +19,12 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/fake"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -48,6 +53,11 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeStateReads                 *metricsfakes.Counter
+		fakeStateWrites                *metricsfakes.Counter
+		fakeStateBytesRead             *metricsfakes.Counter
+		fakeStateBytesWritten          *metricsfakes.Counter
+		fakeQueryResults               *metricsfakes.Counter
 
 		responseNotifier chan *pb.ChaincodeMessage
 		txContext        *chaincode.TransactionContext
@@ -102,12 +112,27 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeStateReads = &metricsfakes.Counter{}
+		fakeStateReads.WithReturns(fakeStateReads)
+		fakeStateWrites = &metricsfakes.Counter{}
+		fakeStateWrites.WithReturns(fakeStateWrites)
+		fakeStateBytesRead = &metricsfakes.Counter{}
+		fakeStateBytesRead.WithReturns(fakeStateBytesRead)
+		fakeStateBytesWritten = &metricsfakes.Counter{}
+		fakeStateBytesWritten.WithReturns(fakeStateBytesWritten)
+		fakeQueryResults = &metricsfakes.Counter{}
+		fakeQueryResults.WithReturns(fakeQueryResults)
 
 		chaincodeMetrics := &chaincode.HandlerMetrics{
 			ShimRequestsReceived:  fakeShimRequestsReceived,
 			ShimRequestsCompleted: fakeShimRequestsCompleted,
 			ShimRequestDuration:   fakeShimRequestDuration,
 			ExecuteTimeouts:       fakeExecuteTimeouts,
+			StateReads:            fakeStateReads,
+			StateWrites:           fakeStateWrites,
+			StateBytesRead:        fakeStateBytesRead,
+			StateBytesWritten:     fakeStateBytesWritten,
+			QueryResults:          fakeQueryResults,
 		}
 
 		handler = &chaincode.Handler{
@@ -540,6 +565,15 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		It("records the write in the transaction context", func() {
+			_, err := handler.HandlePutState(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txContext.ResourceUsage()).To(Equal(chaincode.ResourceUsage{
+				StateWrites:  1,
+				BytesWritten: len("put-state-value"),
+			}))
+		})
+
 		Context("when unmarshaling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -619,6 +653,134 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePutStateBatch", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.PutStateBatch
+
+		BeforeEach(func() {
+			handler.AdditionalParams = &pb.ChaincodeAdditionalParams{UseWriteBatch: true, MaxSizeWriteBatch: 3}
+			request = &pb.PutStateBatch{
+				Records: []*pb.WriteRecord{
+					{Key: "put-key", Value: []byte("put-value")},
+					{Key: "del-key", IsDelete: true},
+				},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PUT_STATE_BATCH,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("records the writes in the transaction simulator and returns a response message", func() {
+			resp, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+			ccname, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("put-key"))
+			Expect(value).To(Equal([]byte("put-value")))
+			Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(1))
+			ccname, key = fakeTxSimulator.DeleteStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("del-key"))
+		})
+
+		Context("when the writes are private", func() {
+			BeforeEach(func() {
+				for _, record := range request.Records {
+					record.Collection = "collection-name"
+				}
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("records the writes in the private write set", func() {
+				_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.SetPrivateDataCallCount()).To(Equal(1))
+				ccname, collection, key, value := fakeTxSimulator.SetPrivateDataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(key).To(Equal("put-key"))
+				Expect(value).To(Equal([]byte("put-value")))
+				Expect(fakeTxSimulator.DeletePrivateDataCallCount()).To(Equal(1))
+				ccname, collection, key = fakeTxSimulator.DeletePrivateDataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(key).To(Equal("del-key"))
+			})
+
+			Context("and the transaction is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+		})
+
+		Context("when a write fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.DeleteStateReturns(errors.New("mothra"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+				Expect(err).To(MatchError("mothra"))
+			})
+		})
+
+		Context("when the batch exceeds the maximum size", func() {
+			BeforeEach(func() {
+				handler.AdditionalParams.MaxSizeWriteBatch = 1
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+				Expect(err).To(MatchError("number of writes 2 exceeds the maximum of 1"))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when write batches are not enabled", func() {
+			BeforeEach(func() {
+				handler.AdditionalParams = nil
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+				Expect(err).To(MatchError("PUT_STATE_BATCH is not enabled"))
+			})
+		})
+
+		Context("when unmarshaling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateBatch(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+	})
+
 	Describe("HandlePutStateMetadata", func() {
 		var incomingMessage *pb.ChaincodeMessage
 		var request *pb.PutStateMetadata
@@ -864,6 +1026,137 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateMultiple", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.GetStateMultiple
+
+		BeforeEach(func() {
+			handler.AdditionalParams = &pb.ChaincodeAdditionalParams{UseGetMultipleKeys: true, MaxSizeGetMultipleKeys: 2}
+			request = &pb.GetStateMultiple{Keys: []string{"key1", "key2"}}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_MULTIPLE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			fakeTxSimulator.GetStateMultipleKeysReturns([][]byte{[]byte("value1"), nil}, nil)
+			fakeTxSimulator.GetPrivateDataMultipleKeysReturns([][]byte{nil, []byte("private-value2")}, nil)
+		})
+
+		It("returns the values from GetStateMultipleKeys", func() {
+			resp, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Txid).To(Equal("tx-id"))
+			Expect(resp.ChannelId).To(Equal("channel-id"))
+			result := &pb.GetStateMultipleResult{}
+			err = proto.Unmarshal(resp.Payload, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Values).To(Equal([][]byte{[]byte("value1"), {}}))
+
+			Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(1))
+			ccname, keys := fakeTxSimulator.GetStateMultipleKeysArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(keys).To(Equal([]string{"key1", "key2"}))
+		})
+
+		Context("when GetStateMultipleKeys fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateMultipleKeysReturns(nil, errors.New("rodan"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("rodan"))
+			})
+		})
+
+		Context("when the collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+				fakeCollectionStore.HasReadAccessReturns(true, nil)
+			})
+
+			It("returns the values from GetPrivateDataMultipleKeys", func() {
+				resp, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				result := &pb.GetStateMultipleResult{}
+				err = proto.Unmarshal(resp.Payload, result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Values).To(Equal([][]byte{{}, []byte("private-value2")}))
+
+				Expect(fakeTxSimulator.GetPrivateDataMultipleKeysCallCount()).To(Equal(1))
+				ccname, collection, keys := fakeTxSimulator.GetPrivateDataMultipleKeysArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(keys).To(Equal([]string{"key1", "key2"}))
+			})
+
+			Context("and the creator has no read access", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.HasReadAccessReturns(false, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have read access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+				})
+			})
+
+			Context("and the transaction is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+		})
+
+		Context("when the request exceeds the maximum number of keys", func() {
+			BeforeEach(func() {
+				handler.AdditionalParams.MaxSizeGetMultipleKeys = 1
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("number of keys 2 exceeds the maximum of 1"))
+			})
+		})
+
+		Context("when the reads of multiple keys are not enabled", func() {
+			BeforeEach(func() {
+				handler.AdditionalParams.UseGetMultipleKeys = false
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("GET_STATE_MULTIPLE is not enabled"))
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -1019,6 +1312,15 @@ var _ = Describe("Handler", func() {
 				Expect(key).To(Equal("get-state-key"))
 			})
 
+			It("records the read in the transaction context", func() {
+				_, err := handler.HandleGetState(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txContext.ResourceUsage()).To(Equal(chaincode.ResourceUsage{
+					StateReads: 1,
+					BytesRead:  len("get-state-response"),
+				}))
+			})
+
 			Context("and GetState fails", func() {
 				BeforeEach(func() {
 					fakeTxSimulator.GetStateReturns(nil, errors.New("tomato"))
@@ -2000,6 +2302,95 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when options are provided", func() {
+			BeforeEach(func() {
+				request.Options = &pb.HistoryQueryOptions{StartBlock: 2, EndBlock: 5, Descending: true}
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithOptions on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsCallCount()).To(Equal(1))
+				ccname, key, options := fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(options).To(Equal(&ledger.HistoryQueryOptions{StartBlock: 2, EndBlock: 5, Descending: true}))
+			})
+
+			It("builds a query response that isn't paginated", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeFalse())
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(nil, errors.New("calzone"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("calzone"))
+				})
+			})
+		})
+
+		Context("when pagination metadata is provided", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 10, Bookmark: "3:0"})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(fakeIterator, nil)
+			})
+
+			It("pages through the history in ascending order", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsCallCount()).To(Equal(1))
+				_, _, options := fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsArgsForCall(0)
+				Expect(options).To(Equal(&ledger.HistoryQueryOptions{PageSize: 10, Bookmark: "3:0"}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(10)))
+			})
+		})
+
+		Context("when the metadata can't be unmarshaled", func() {
+			BeforeEach(func() {
+				request.Metadata = []byte("this-is-a-bogus-metadata")
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("unmarshal failed")))
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -2067,6 +2458,181 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateAtHeight", func() {
+		var incomingMessage *pb.ChaincodeMessage
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetStateAtHeight{
+				Key:      "key",
+				BlockNum: 7,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_AT_HEIGHT,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			fakeHistoryQueryExecutor.GetStateAtHeightReturns(&queryresult.KeyModification{Value: []byte("old-value")}, nil)
+		})
+
+		It("returns the value of the key at the height", func() {
+			resp, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("old-value"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeHistoryQueryExecutor.GetStateAtHeightCallCount()).To(Equal(1))
+			ccname, key, blockNum := fakeHistoryQueryExecutor.GetStateAtHeightArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("key"))
+			Expect(blockNum).To(Equal(uint64(7)))
+		})
+
+		It("does not read from the transaction simulator", func() {
+			_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(0))
+		})
+
+		Context("when the key was deleted at the height", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateAtHeightReturns(&queryresult.KeyModification{IsDelete: true}, nil)
+			})
+
+			It("returns a nil value", func() {
+				resp, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Payload).To(BeNil())
+			})
+		})
+
+		Context("when the key did not exist at the height", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateAtHeightReturns(nil, nil)
+			})
+
+			It("returns a nil value", func() {
+				resp, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Payload).To(BeNil())
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateAtHeightReturns(nil, errors.New("calzone"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("calzone"))
+			})
+		})
+	})
+
+	Describe("HandleGetStateByRangeAtHeight", func() {
+		var (
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetStateByRangeAtHeight{
+				StartKey: "start-key",
+				EndKey:   "end-key",
+				BlockNum: 7,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightReturns(fakeIterator, nil)
+		})
+
+		It("calls GetStateRangeScanIteratorAtHeight on the history query executor", func() {
+			_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightCallCount()).To(Equal(1))
+			ccname, startKey, endKey, blockNum := fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+			Expect(blockNum).To(Equal(uint64(7)))
+		})
+
+		It("builds a query response from a new query context", func() {
+			resp, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			iter := txContext.GetQueryIterator("generated-query-id")
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, _, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+
+			payload, err := proto.Marshal(expectedQueryResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Payload).To(Equal(payload))
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateRangeScanIteratorAtHeightReturns(nil, errors.New("stromboli"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("stromboli"))
+			})
+		})
+
+		Context("when building the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("mushrooms"))
+			})
+
+			It("cleans up the query context", func() {
+				_, err := handler.HandleGetStateByRangeAtHeight(incomingMessage, txContext)
+				Expect(err).To(MatchError("mushrooms"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+			})
+		})
+	})
+
 	Describe("HandleInvokeChaincode", func() {
 		var (
 			expectedSignedProp      *pb.SignedProposal
@@ -2263,6 +2829,99 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("razzies"))
 				})
 			})
+
+			Context("when the target chaincode writes", func() {
+				var targetResults []byte
+
+				BeforeEach(func() {
+					responseMessage.Type = pb.ChaincodeMessage_COMPLETED
+
+					kvRWSet, err := proto.Marshal(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "key", Value: []byte("value")}}})
+					Expect(err).NotTo(HaveOccurred())
+					pubSimResults := &rwset.TxReadWriteSet{
+						NsRwset: []*rwset.NsReadWriteSet{{Namespace: "target-chaincode-name", Rwset: kvRWSet}},
+					}
+					targetResults, err = proto.Marshal(pubSimResults)
+					Expect(err).NotTo(HaveOccurred())
+					newTxSimulator.GetTxSimulationResultsReturns(&ledger.TxSimulationResults{PubSimulationResults: pubSimResults}, nil)
+				})
+
+				It("discards the writes", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(newTxSimulator.GetTxSimulationResultsCallCount()).To(Equal(0))
+					Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+				})
+
+				Context("when both channels support cross-channel writes", func() {
+					BeforeEach(func() {
+						applicationCapability := &config.MockApplication{
+							CapabilitiesRv: &config.MockApplicationCapabilities{CrossChannelWritesRv: true},
+						}
+						fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
+					})
+
+					It("records a write intent with the simulator of the caller", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeApplicationConfigRetriever.GetApplicationConfigCallCount()).To(Equal(2))
+						Expect(fakeApplicationConfigRetriever.GetApplicationConfigArgsForCall(0)).To(Equal("channel-id"))
+						Expect(fakeApplicationConfigRetriever.GetApplicationConfigArgsForCall(1)).To(Equal("target-channel-id"))
+
+						Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+						namespace, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+						Expect(namespace).To(Equal(crosschannel.Namespace))
+						Expect(key).To(Equal(crosschannel.PendingIntentKey("tx-id", value)))
+
+						intent := &pb.CrossChannelIntent{}
+						Expect(proto.Unmarshal(value, intent)).To(Succeed())
+						Expect(intent.TxId).To(Equal("tx-id"))
+						Expect(intent.SourceChannel).To(Equal("channel-id"))
+						Expect(intent.TargetChannel).To(Equal("target-channel-id"))
+						Expect(intent.TargetResults).To(Equal(targetResults))
+						Expect(intent.Status).To(Equal(pb.CrossChannelIntent_PREPARED))
+					})
+
+					Context("when the target chaincode doesn't write", func() {
+						BeforeEach(func() {
+							newTxSimulator.GetTxSimulationResultsReturns(&ledger.TxSimulationResults{PubSimulationResults: &rwset.TxReadWriteSet{}}, nil)
+						})
+
+						It("doesn't record a write intent", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the target chaincode writes private data", func() {
+						BeforeEach(func() {
+							newTxSimulator.GetTxSimulationResultsReturns(&ledger.TxSimulationResults{
+								PubSimulationResults: &rwset.TxReadWriteSet{},
+								PvtSimulationResults: &rwset.TxPvtReadWriteSet{NsPvtRwset: []*rwset.NsPvtReadWriteSet{{Namespace: "target-chaincode-name"}}},
+							}, nil)
+						})
+
+						It("returns an error", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).To(MatchError("private data writes on channel target-channel-id are not supported by cross-channel writes"))
+						})
+					})
+
+					Context("when getting the simulation results fails", func() {
+						BeforeEach(func() {
+							newTxSimulator.GetTxSimulationResultsReturns(nil, errors.New("candy"))
+						})
+
+						It("returns an error", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).To(MatchError("candy"))
+						})
+					})
+				})
+			})
 		})
 
 		Context("when the target is a system chaincode", func() {
@@ -2493,6 +3152,28 @@ var _ = Describe("Handler", func() {
 			Expect(resp).To(Equal(&pb.ChaincodeMessage{Txid: "a-transaction-id"}))
 		})
 
+		It("records the resources used by the chaincode", func() {
+			txContext.RecordStateReads([]byte("value"), nil)
+			txContext.RecordStateWrites([]byte("new-value"))
+			txContext.RecordQueryResults([]*pb.QueryResultBytes{{ResultBytes: []byte("result")}})
+			close(responseNotifier)
+			handler.Execute(txParams, cccid, incomingMessage, time.Second)
+
+			labelValues := []string{"chaincode", "chaincode-name:chaincode-version", "channel", "channel-id"}
+			for counter, expected := range map[*metricsfakes.Counter]float64{
+				fakeStateReads:        2,
+				fakeStateWrites:       1,
+				fakeStateBytesRead:    11,
+				fakeStateBytesWritten: 9,
+				fakeQueryResults:      1,
+			} {
+				Expect(counter.WithCallCount()).To(Equal(1))
+				Expect(counter.WithArgsForCall(0)).To(Equal(labelValues))
+				Expect(counter.AddCallCount()).To(Equal(1))
+				Expect(counter.AddArgsForCall(0)).To(BeNumerically("~", expected))
+			}
+		})
+
 		It("deletes the transaction context", func() {
 			close(responseNotifier)
 			handler.Execute(txParams, cccid, incomingMessage, time.Second)
@@ -2703,6 +3384,24 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		Context("when the handler has additional params", func() {
+			BeforeEach(func() {
+				handler.AdditionalParams = &pb.ChaincodeAdditionalParams{UseWriteBatch: true, MaxSizeWriteBatch: 100}
+			})
+
+			It("sends them in the registered message", func() {
+				handler.HandleRegister(incomingMessage)
+
+				Eventually(fakeChatStream.SendCallCount).Should(Equal(2))
+				registeredMessage := fakeChatStream.SendArgsForCall(0)
+				Expect(registeredMessage.Type).To(Equal(pb.ChaincodeMessage_REGISTERED))
+				params := &pb.ChaincodeAdditionalParams{}
+				err := proto.Unmarshal(registeredMessage.Payload, params)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(params, handler.AdditionalParams)).To(BeTrue())
+			})
+		})
+
 		Context("when sending the ready message fails", func() {
 			BeforeEach(func() {
 				fakeChatStream.SendReturnsOnCall(1, errors.New("carrot"))
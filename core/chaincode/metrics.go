@@ -31,6 +31,21 @@ var (
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
 
+	drainDuration = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "drain_duration",
+		Help:         "The time to drain the transactions of a chaincode version replaced by an upgrade.",
+		LabelNames:   []string{"chaincode", "success"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{success}",
+	}
+	drainTimeouts = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "drain_timeouts",
+		Help:         "The number of replaced chaincode versions stopped with transactions still in flight.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+
 	shimRequestsReceived = metrics.CounterOpts{
 		Namespace:    "chaincode",
 		Name:         "shim_requests_received",
@@ -52,6 +67,41 @@ var (
 		LabelNames:   []string{"type", "channel", "chaincode", "success"},
 		StatsdFormat: "%{#fqname}.%{type}.%{channel}.%{chaincode}.%{success}",
 	}
+	stateReads = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "state_reads",
+		Help:         "The number of keys read by chaincode executions.",
+		LabelNames:   []string{"chaincode", "channel"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{channel}",
+	}
+	stateWrites = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "state_writes",
+		Help:         "The number of keys written or deleted by chaincode executions.",
+		LabelNames:   []string{"chaincode", "channel"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{channel}",
+	}
+	stateBytesRead = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "state_bytes_read",
+		Help:         "The number of bytes of the values and query results read by chaincode executions.",
+		LabelNames:   []string{"chaincode", "channel"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{channel}",
+	}
+	stateBytesWritten = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "state_bytes_written",
+		Help:         "The number of bytes of the values written by chaincode executions.",
+		LabelNames:   []string{"chaincode", "channel"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{channel}",
+	}
+	queryResults = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "query_results",
+		Help:         "The number of query results returned to chaincode executions.",
+		LabelNames:   []string{"chaincode", "channel"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{channel}",
+	}
 	executeTimeouts = metrics.CounterOpts{
 		Namespace:    "chaincode",
 		Name:         "execute_timeouts",
@@ -66,6 +116,11 @@ type HandlerMetrics struct {
 	ShimRequestsCompleted metrics.Counter
 	ShimRequestDuration   metrics.Histogram
 	ExecuteTimeouts       metrics.Counter
+	StateReads            metrics.Counter
+	StateWrites           metrics.Counter
+	StateBytesRead        metrics.Counter
+	StateBytesWritten     metrics.Counter
+	QueryResults          metrics.Counter
 }
 
 func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
@@ -74,6 +129,11 @@ func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
 		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
 		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		StateReads:            p.NewCounter(stateReads),
+		StateWrites:           p.NewCounter(stateWrites),
+		StateBytesRead:        p.NewCounter(stateBytesRead),
+		StateBytesWritten:     p.NewCounter(stateBytesWritten),
+		QueryResults:          p.NewCounter(queryResults),
 	}
 }
 
@@ -90,3 +150,15 @@ func NewLaunchMetrics(p metrics.Provider) *LaunchMetrics {
 		LaunchTimeouts: p.NewCounter(launchTimeouts),
 	}
 }
+
+type UpgradeMetrics struct {
+	DrainDuration metrics.Histogram
+	DrainTimeouts metrics.Counter
+}
+
+func NewUpgradeMetrics(p metrics.Provider) *UpgradeMetrics {
+	return &UpgradeMetrics{
+		DrainDuration: p.NewHistogram(drainDuration),
+		DrainTimeouts: p.NewCounter(drainTimeouts),
+	}
+}
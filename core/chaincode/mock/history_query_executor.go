@@ -5,6 +5,8 @@ import (
 	sync "sync"
 
 	ledger "github.com/hyperledger/fabric/common/ledger"
+	ledgera "github.com/hyperledger/fabric/core/ledger"
+	queryresult "github.com/hyperledger/fabric/protos/ledger/queryresult"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +24,52 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithOptionsStub        func(string, string, *ledgera.HistoryQueryOptions) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyWithOptionsMutex       sync.RWMutex
+	getHistoryForKeyWithOptionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *ledgera.HistoryQueryOptions
+	}
+	getHistoryForKeyWithOptionsReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithOptionsReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	GetStateAtHeightStub        func(string, string, uint64) (*queryresult.KeyModification, error)
+	getStateAtHeightMutex       sync.RWMutex
+	getStateAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateAtHeightReturns struct {
+		result1 *queryresult.KeyModification
+		result2 error
+	}
+	getStateAtHeightReturnsOnCall map[int]struct {
+		result1 *queryresult.KeyModification
+		result2 error
+	}
+	GetStateRangeScanIteratorAtHeightStub        func(string, string, string, uint64) (ledger.ResultsIterator, error)
+	getStateRangeScanIteratorAtHeightMutex       sync.RWMutex
+	getStateRangeScanIteratorAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}
+	getStateRangeScanIteratorAtHeightReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorAtHeightReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +138,213 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptions(arg1 string, arg2 string, arg3 *ledgera.HistoryQueryOptions) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithOptionsReturnsOnCall[len(fake.getHistoryForKeyWithOptionsArgsForCall)]
+	fake.getHistoryForKeyWithOptionsArgsForCall = append(fake.getHistoryForKeyWithOptionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *ledgera.HistoryQueryOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyWithOptions", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	if fake.GetHistoryForKeyWithOptionsStub != nil {
+		return fake.GetHistoryForKeyWithOptionsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithOptionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptionsCallCount() int {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithOptionsArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptionsCalls(stub func(string, string, *ledgera.HistoryQueryOptions) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptionsArgsForCall(i int) (string, string, *ledgera.HistoryQueryOptions) {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptionsReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	fake.getHistoryForKeyWithOptionsReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithOptionsReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	if fake.getHistoryForKeyWithOptionsReturnsOnCall == nil {
+		fake.getHistoryForKeyWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithOptionsReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeight(arg1 string, arg2 string, arg3 uint64) (*queryresult.KeyModification, error) {
+	fake.getStateAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateAtHeightReturnsOnCall[len(fake.getStateAtHeightArgsForCall)]
+	fake.getStateAtHeightArgsForCall = append(fake.getStateAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateAtHeight", []interface{}{arg1, arg2, arg3})
+	fake.getStateAtHeightMutex.Unlock()
+	if fake.GetStateAtHeightStub != nil {
+		return fake.GetStateAtHeightStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightCallCount() int {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	return len(fake.getStateAtHeightArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightCalls(stub func(string, string, uint64) (*queryresult.KeyModification, error)) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightArgsForCall(i int) (string, string, uint64) {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightReturns(result1 *queryresult.KeyModification, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	fake.getStateAtHeightReturns = struct {
+		result1 *queryresult.KeyModification
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtHeightReturnsOnCall(i int, result1 *queryresult.KeyModification, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	if fake.getStateAtHeightReturnsOnCall == nil {
+		fake.getStateAtHeightReturnsOnCall = make(map[int]struct {
+			result1 *queryresult.KeyModification
+			result2 error
+		})
+	}
+	fake.getStateAtHeightReturnsOnCall[i] = struct {
+		result1 *queryresult.KeyModification
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeight(arg1 string, arg2 string, arg3 string, arg4 uint64) (ledger.ResultsIterator, error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorAtHeightReturnsOnCall[len(fake.getStateRangeScanIteratorAtHeightArgsForCall)]
+	fake.getStateRangeScanIteratorAtHeightArgsForCall = append(fake.getStateRangeScanIteratorAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateRangeScanIteratorAtHeight", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	if fake.GetStateRangeScanIteratorAtHeightStub != nil {
+		return fake.GetStateRangeScanIteratorAtHeightStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateRangeScanIteratorAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightCallCount() int {
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorAtHeightArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightCalls(stub func(string, string, string, uint64) (ledger.ResultsIterator, error)) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightArgsForCall(i int) (string, string, string, uint64) {
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateRangeScanIteratorAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = nil
+	fake.getStateRangeScanIteratorAtHeightReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateRangeScanIteratorAtHeightReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorAtHeightMutex.Lock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.Unlock()
+	fake.GetStateRangeScanIteratorAtHeightStub = nil
+	if fake.getStateRangeScanIteratorAtHeightReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorAtHeightReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorAtHeightReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	fake.getStateRangeScanIteratorAtHeightMutex.RLock()
+	defer fake.getStateRangeScanIteratorAtHeightMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
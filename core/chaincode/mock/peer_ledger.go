@@ -117,6 +117,22 @@ type PeerLedger struct {
 		result1 ledgera.ResultsIterator
 		result2 error
 	}
+	GetChaincodeEventsStub        func(string, string, uint64, uint64) (ledgera.ResultsIterator, error)
+	getChaincodeEventsMutex       sync.RWMutex
+	getChaincodeEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+		arg4 uint64
+	}
+	getChaincodeEventsReturns struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}
+	getChaincodeEventsReturnsOnCall map[int]struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}
 	GetConfigHistoryRetrieverStub        func() (ledger.ConfigHistoryRetriever, error)
 	getConfigHistoryRetrieverMutex       sync.RWMutex
 	getConfigHistoryRetrieverArgsForCall []struct {
@@ -169,6 +185,19 @@ type PeerLedger struct {
 		result1 []*ledger.TxPvtData
 		result2 error
 	}
+	GetStateFingerprintStub        func(uint64) (*common.StateFingerprint, error)
+	getStateFingerprintMutex       sync.RWMutex
+	getStateFingerprintArgsForCall []struct {
+		arg1 uint64
+	}
+	getStateFingerprintReturns struct {
+		result1 *common.StateFingerprint
+		result2 error
+	}
+	getStateFingerprintReturnsOnCall map[int]struct {
+		result1 *common.StateFingerprint
+		result2 error
+	}
 	GetTransactionByIDStub        func(string) (*peer.ProcessedTransaction, error)
 	getTransactionByIDMutex       sync.RWMutex
 	getTransactionByIDArgsForCall []struct {
@@ -266,6 +295,19 @@ type PeerLedger struct {
 	purgePrivateDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RepairPvtDataOfOldBlocksStub        func([]*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)
+	repairPvtDataOfOldBlocksMutex       sync.RWMutex
+	repairPvtDataOfOldBlocksArgsForCall []struct {
+		arg1 []*ledger.BlockPvtData
+	}
+	repairPvtDataOfOldBlocksReturns struct {
+		result1 []*ledger.PvtdataHashMismatch
+		result2 error
+	}
+	repairPvtDataOfOldBlocksReturnsOnCall map[int]struct {
+		result1 []*ledger.PvtdataHashMismatch
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -797,6 +839,72 @@ func (fake *PeerLedger) GetBlocksIteratorReturnsOnCall(i int, result1 ledgera.Re
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetChaincodeEvents(arg1 string, arg2 string, arg3 uint64, arg4 uint64) (ledgera.ResultsIterator, error) {
+	fake.getChaincodeEventsMutex.Lock()
+	ret, specificReturn := fake.getChaincodeEventsReturnsOnCall[len(fake.getChaincodeEventsArgsForCall)]
+	fake.getChaincodeEventsArgsForCall = append(fake.getChaincodeEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetChaincodeEvents", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getChaincodeEventsMutex.Unlock()
+	if fake.GetChaincodeEventsStub != nil {
+		return fake.GetChaincodeEventsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getChaincodeEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetChaincodeEventsCallCount() int {
+	fake.getChaincodeEventsMutex.RLock()
+	defer fake.getChaincodeEventsMutex.RUnlock()
+	return len(fake.getChaincodeEventsArgsForCall)
+}
+
+func (fake *PeerLedger) GetChaincodeEventsCalls(stub func(string, string, uint64, uint64) (ledgera.ResultsIterator, error)) {
+	fake.getChaincodeEventsMutex.Lock()
+	defer fake.getChaincodeEventsMutex.Unlock()
+	fake.GetChaincodeEventsStub = stub
+}
+
+func (fake *PeerLedger) GetChaincodeEventsArgsForCall(i int) (string, string, uint64, uint64) {
+	fake.getChaincodeEventsMutex.RLock()
+	defer fake.getChaincodeEventsMutex.RUnlock()
+	argsForCall := fake.getChaincodeEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PeerLedger) GetChaincodeEventsReturns(result1 ledgera.ResultsIterator, result2 error) {
+	fake.getChaincodeEventsMutex.Lock()
+	defer fake.getChaincodeEventsMutex.Unlock()
+	fake.GetChaincodeEventsStub = nil
+	fake.getChaincodeEventsReturns = struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetChaincodeEventsReturnsOnCall(i int, result1 ledgera.ResultsIterator, result2 error) {
+	fake.getChaincodeEventsMutex.Lock()
+	defer fake.getChaincodeEventsMutex.Unlock()
+	fake.GetChaincodeEventsStub = nil
+	if fake.getChaincodeEventsReturnsOnCall == nil {
+		fake.getChaincodeEventsReturnsOnCall = make(map[int]struct {
+			result1 ledgera.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getChaincodeEventsReturnsOnCall[i] = struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
 	fake.getConfigHistoryRetrieverMutex.Lock()
 	ret, specificReturn := fake.getConfigHistoryRetrieverReturnsOnCall[len(fake.getConfigHistoryRetrieverArgsForCall)]
@@ -1035,6 +1143,69 @@ func (fake *PeerLedger) GetPvtDataByNumReturnsOnCall(i int, result1 []*ledger.Tx
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetStateFingerprint(arg1 uint64) (*common.StateFingerprint, error) {
+	fake.getStateFingerprintMutex.Lock()
+	ret, specificReturn := fake.getStateFingerprintReturnsOnCall[len(fake.getStateFingerprintArgsForCall)]
+	fake.getStateFingerprintArgsForCall = append(fake.getStateFingerprintArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("GetStateFingerprint", []interface{}{arg1})
+	fake.getStateFingerprintMutex.Unlock()
+	if fake.GetStateFingerprintStub != nil {
+		return fake.GetStateFingerprintStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateFingerprintReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetStateFingerprintCallCount() int {
+	fake.getStateFingerprintMutex.RLock()
+	defer fake.getStateFingerprintMutex.RUnlock()
+	return len(fake.getStateFingerprintArgsForCall)
+}
+
+func (fake *PeerLedger) GetStateFingerprintCalls(stub func(uint64) (*common.StateFingerprint, error)) {
+	fake.getStateFingerprintMutex.Lock()
+	defer fake.getStateFingerprintMutex.Unlock()
+	fake.GetStateFingerprintStub = stub
+}
+
+func (fake *PeerLedger) GetStateFingerprintArgsForCall(i int) uint64 {
+	fake.getStateFingerprintMutex.RLock()
+	defer fake.getStateFingerprintMutex.RUnlock()
+	argsForCall := fake.getStateFingerprintArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) GetStateFingerprintReturns(result1 *common.StateFingerprint, result2 error) {
+	fake.getStateFingerprintMutex.Lock()
+	defer fake.getStateFingerprintMutex.Unlock()
+	fake.GetStateFingerprintStub = nil
+	fake.getStateFingerprintReturns = struct {
+		result1 *common.StateFingerprint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetStateFingerprintReturnsOnCall(i int, result1 *common.StateFingerprint, result2 error) {
+	fake.getStateFingerprintMutex.Lock()
+	defer fake.getStateFingerprintMutex.Unlock()
+	fake.GetStateFingerprintStub = nil
+	if fake.getStateFingerprintReturnsOnCall == nil {
+		fake.getStateFingerprintReturnsOnCall = make(map[int]struct {
+			result1 *common.StateFingerprint
+			result2 error
+		})
+	}
+	fake.getStateFingerprintReturnsOnCall[i] = struct {
+		result1 *common.StateFingerprint
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetTransactionByID(arg1 string) (*peer.ProcessedTransaction, error) {
 	fake.getTransactionByIDMutex.Lock()
 	ret, specificReturn := fake.getTransactionByIDReturnsOnCall[len(fake.getTransactionByIDArgsForCall)]
@@ -1509,6 +1680,74 @@ func (fake *PeerLedger) PurgePrivateDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PeerLedger) RepairPvtDataOfOldBlocks(arg1 []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	var arg1Copy []*ledger.BlockPvtData
+	if arg1 != nil {
+		arg1Copy = make([]*ledger.BlockPvtData, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.repairPvtDataOfOldBlocksMutex.Lock()
+	ret, specificReturn := fake.repairPvtDataOfOldBlocksReturnsOnCall[len(fake.repairPvtDataOfOldBlocksArgsForCall)]
+	fake.repairPvtDataOfOldBlocksArgsForCall = append(fake.repairPvtDataOfOldBlocksArgsForCall, struct {
+		arg1 []*ledger.BlockPvtData
+	}{arg1Copy})
+	fake.recordInvocation("RepairPvtDataOfOldBlocks", []interface{}{arg1Copy})
+	fake.repairPvtDataOfOldBlocksMutex.Unlock()
+	if fake.RepairPvtDataOfOldBlocksStub != nil {
+		return fake.RepairPvtDataOfOldBlocksStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.repairPvtDataOfOldBlocksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) RepairPvtDataOfOldBlocksCallCount() int {
+	fake.repairPvtDataOfOldBlocksMutex.RLock()
+	defer fake.repairPvtDataOfOldBlocksMutex.RUnlock()
+	return len(fake.repairPvtDataOfOldBlocksArgsForCall)
+}
+
+func (fake *PeerLedger) RepairPvtDataOfOldBlocksCalls(stub func([]*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)) {
+	fake.repairPvtDataOfOldBlocksMutex.Lock()
+	defer fake.repairPvtDataOfOldBlocksMutex.Unlock()
+	fake.RepairPvtDataOfOldBlocksStub = stub
+}
+
+func (fake *PeerLedger) RepairPvtDataOfOldBlocksArgsForCall(i int) []*ledger.BlockPvtData {
+	fake.repairPvtDataOfOldBlocksMutex.RLock()
+	defer fake.repairPvtDataOfOldBlocksMutex.RUnlock()
+	argsForCall := fake.repairPvtDataOfOldBlocksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) RepairPvtDataOfOldBlocksReturns(result1 []*ledger.PvtdataHashMismatch, result2 error) {
+	fake.repairPvtDataOfOldBlocksMutex.Lock()
+	defer fake.repairPvtDataOfOldBlocksMutex.Unlock()
+	fake.RepairPvtDataOfOldBlocksStub = nil
+	fake.repairPvtDataOfOldBlocksReturns = struct {
+		result1 []*ledger.PvtdataHashMismatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) RepairPvtDataOfOldBlocksReturnsOnCall(i int, result1 []*ledger.PvtdataHashMismatch, result2 error) {
+	fake.repairPvtDataOfOldBlocksMutex.Lock()
+	defer fake.repairPvtDataOfOldBlocksMutex.Unlock()
+	fake.RepairPvtDataOfOldBlocksStub = nil
+	if fake.repairPvtDataOfOldBlocksReturnsOnCall == nil {
+		fake.repairPvtDataOfOldBlocksReturnsOnCall = make(map[int]struct {
+			result1 []*ledger.PvtdataHashMismatch
+			result2 error
+		})
+	}
+	fake.repairPvtDataOfOldBlocksReturnsOnCall[i] = struct {
+		result1 []*ledger.PvtdataHashMismatch
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1530,6 +1769,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getBlockchainInfoMutex.RUnlock()
 	fake.getBlocksIteratorMutex.RLock()
 	defer fake.getBlocksIteratorMutex.RUnlock()
+	fake.getChaincodeEventsMutex.RLock()
+	defer fake.getChaincodeEventsMutex.RUnlock()
 	fake.getConfigHistoryRetrieverMutex.RLock()
 	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
 	fake.getMissingPvtDataTrackerMutex.RLock()
@@ -1538,6 +1779,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getPvtDataAndBlockByNumMutex.RUnlock()
 	fake.getPvtDataByNumMutex.RLock()
 	defer fake.getPvtDataByNumMutex.RUnlock()
+	fake.getStateFingerprintMutex.RLock()
+	defer fake.getStateFingerprintMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
@@ -1554,6 +1797,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.purgePrivateDataMutex.RLock()
 	defer fake.purgePrivateDataMutex.RUnlock()
+	fake.repairPvtDataOfOldBlocksMutex.RLock()
+	defer fake.repairPvtDataOfOldBlocksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.platform.wasm")
+
+// metadataDir is the directory of the metadata of the chaincode, which is
+// packaged from the directory of the module
+const metadataDir = "META-INF"
+
+// Platform for WebAssembly chaincode, whose module runs in the peer
+type Platform struct {
+}
+
+// Name returns the name of this platform
+func (wasmPlatform *Platform) Name() string {
+	return pb.ChaincodeSpec_WASM.String()
+}
+
+// ValidatePath validates that the path is the one of a WebAssembly module
+func (wasmPlatform *Platform) ValidatePath(path string) error {
+	if filepath.Ext(path) != ".wasm" {
+		return errors.Errorf("invalid path %s: the path of WebAssembly chaincode must be a .wasm file", path)
+	}
+	return nil
+}
+
+// ValidateCodePackage validates that the code package only holds a valid
+// module and metadata
+func (wasmPlatform *Platform) ValidateCodePackage(code []byte) error {
+	if len(code) == 0 {
+		// Nothing to validate if no CodePackage was included
+		return nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return errors.Wrap(err, "failure opening codepackage gzip stream")
+	}
+	tr := tar.NewReader(gr)
+
+	foundModule := false
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failure reading codepackage")
+		}
+
+		if header.Name != wasmcontroller.ModuleFile && !strings.HasPrefix(header.Name, metadataDir+"/") {
+			return errors.Errorf("illegal file detected in payload: \"%s\"", header.Name)
+		}
+		// Only regular files, which are readable and writable, are accepted
+		if header.Mode&^0100666 != 0 {
+			return errors.Errorf("illegal file mode detected for file %s: %o", header.Name, header.Mode)
+		}
+		if header.Name != wasmcontroller.ModuleFile {
+			continue
+		}
+
+		module, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return errors.Wrapf(err, "failure reading %s", header.Name)
+		}
+		if err := wasmcontroller.ValidateModule(module); err != nil {
+			return errors.WithMessage(err, "invalid "+header.Name)
+		}
+		foundModule = true
+	}
+
+	if !foundModule {
+		return errors.Errorf("the code package does not contain %s", wasmcontroller.ModuleFile)
+	}
+	return nil
+}
+
+// GetDeploymentPayload packages the module at the given path, along with the
+// META-INF directory next to it, if any
+func (wasmPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
+	logger.Debugf("Packaging WebAssembly module %s", path)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	if err := cutil.WriteFileToPackage(path, wasmcontroller.ModuleFile, tw); err != nil {
+		return nil, errors.Errorf("Error writing chaincode package contents: %s", err)
+	}
+
+	metadataPath := filepath.Join(filepath.Dir(path), metadataDir)
+	if _, err := os.Stat(metadataPath); err == nil {
+		err := filepath.Walk(metadataPath, func(localpath string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(filepath.Dir(path), localpath)
+			if err != nil {
+				return err
+			}
+			return cutil.WriteFileToPackage(localpath, filepath.ToSlash(rel), tw)
+		})
+		if err != nil {
+			return nil, errors.Errorf("Error writing chaincode metadata: %s", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+// GenerateDockerfile returns an error, as WebAssembly chaincode runs in the peer
+func (wasmPlatform *Platform) GenerateDockerfile() (string, error) {
+	return "", errors.New("WebAssembly chaincode runs in the peer, not in a container")
+}
+
+// GenerateDockerBuild returns an error, as WebAssembly chaincode runs in the peer
+func (wasmPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+	return errors.New("WebAssembly chaincode runs in the peer, not in a container")
+}
+
+//GetMetadataProvider fetches metadata provider given deployment spec
+func (wasmPlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
+	return &ccmetadata.TargzMetadataProvider{Code: code}
+}
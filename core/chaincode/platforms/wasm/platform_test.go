@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = platforms.Platform(&wasm.Platform{})
+
+// moduleFixture is the module of the tests of the WebAssembly VM, whose source
+// is chaincode.wat next to it
+const moduleFixture = "../../../container/wasmcontroller/testdata/chaincode.wasm"
+
+// newChaincodeDir creates a directory holding kvs.wasm, a copy of the module
+// fixture, and the META-INF directory of testdata/kvs, and returns the path of
+// the module
+func newChaincodeDir(t *testing.T) (modulePath string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "wasm-platform")
+	require.NoError(t, err)
+	cleanup = func() { os.RemoveAll(dir) }
+
+	copyFile := func(src, dst string) {
+		contents, err := ioutil.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(dst), 0755))
+		require.NoError(t, ioutil.WriteFile(dst, contents, 0644))
+	}
+	modulePath = filepath.Join(dir, "kvs.wasm")
+	copyFile(moduleFixture, modulePath)
+	index := filepath.Join("META-INF", "statedb", "couchdb", "indexes", "indexOwner.json")
+	copyFile(filepath.Join("testdata", "kvs", index), filepath.Join(dir, index))
+	return modulePath, cleanup
+}
+
+func makeCodePackage(t *testing.T, files map[string][]byte, mode int64) []byte {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(contents)), Mode: mode}))
+		_, err := tw.Write(contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return payload.Bytes()
+}
+
+func packageContents(t *testing.T, code []byte) map[string][]byte {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	contents := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return contents
+		}
+		require.NoError(t, err)
+		contents[header.Name], err = ioutil.ReadAll(tr)
+		require.NoError(t, err)
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	platform := &wasm.Platform{}
+	assert.NoError(t, platform.ValidatePath(moduleFixture))
+
+	err := platform.ValidatePath("testdata/kvs")
+	assert.EqualError(t, err, "invalid path testdata/kvs: the path of WebAssembly chaincode must be a .wasm file")
+}
+
+func TestGetDeploymentPayload(t *testing.T) {
+	modulePath, cleanup := newChaincodeDir(t)
+	defer cleanup()
+	platform := &wasm.Platform{}
+	module, err := ioutil.ReadFile(modulePath)
+	require.NoError(t, err)
+
+	code, err := platform.GetDeploymentPayload(modulePath)
+	require.NoError(t, err)
+	contents := packageContents(t, code)
+	assert.Len(t, contents, 2)
+	assert.Equal(t, module, contents["chaincode.wasm"])
+	assert.Contains(t, contents, "META-INF/statedb/couchdb/indexes/indexOwner.json")
+	assert.NoError(t, platform.ValidateCodePackage(code))
+
+	metadata, err := platform.GetMetadataProvider(code).GetMetadataAsTarEntries()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, metadata)
+
+	_, err = platform.GetDeploymentPayload("testdata/missing.wasm")
+	assert.Contains(t, err.Error(), "Error writing chaincode package contents")
+}
+
+func TestValidateCodePackage(t *testing.T) {
+	platform := &wasm.Platform{}
+	module, err := ioutil.ReadFile(moduleFixture)
+	require.NoError(t, err)
+
+	assert.NoError(t, platform.ValidateCodePackage(nil))
+
+	err = platform.ValidateCodePackage([]byte("garbage"))
+	assert.Contains(t, err.Error(), "failure opening codepackage gzip stream")
+
+	tests := []struct {
+		files       map[string][]byte
+		mode        int64
+		expectedErr string
+	}{
+		{map[string][]byte{"chaincode.wasm": module, "main.go": nil}, 0100644, "illegal file detected in payload: \"main.go\""},
+		{map[string][]byte{"chaincode.wasm": module}, 0100755, "illegal file mode detected for file chaincode.wasm: 100755"},
+		{map[string][]byte{"chaincode.wasm": []byte("garbage")}, 0100644, "invalid chaincode.wasm: invalid WebAssembly module"},
+		{map[string][]byte{"META-INF/statedb/couchdb/indexes/index.json": nil}, 0100644, "the code package does not contain chaincode.wasm"},
+	}
+	for _, test := range tests {
+		err := platform.ValidateCodePackage(makeCodePackage(t, test.files, test.mode))
+		assert.Contains(t, err.Error(), test.expectedErr)
+	}
+}
+
+func TestGenerateDocker(t *testing.T) {
+	platform := &wasm.Platform{}
+
+	_, err := platform.GenerateDockerfile()
+	assert.EqualError(t, err, "WebAssembly chaincode runs in the peer, not in a container")
+
+	err = platform.GenerateDockerBuild(moduleFixture, nil, nil)
+	assert.EqualError(t, err, "WebAssembly chaincode runs in the peer, not in a container")
+}
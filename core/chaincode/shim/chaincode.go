@@ -54,6 +54,7 @@ type ChaincodeStub struct {
 	TxID                       string
 	ChannelId                  string
 	chaincodeEvent             *pb.ChaincodeEvent
+	addedEvents                []*pb.ChaincodeEvent
 	args                       [][]byte
 	handler                    *Handler
 	signedProposal             *pb.SignedProposal
@@ -66,6 +67,10 @@ type ChaincodeStub struct {
 	binding   []byte
 
 	decorations map[string][]byte
+
+	// writeBatch buffers the writes of the transaction when the peer
+	// accepts PUT_STATE_BATCH messages
+	writeBatch *writeBatch
 }
 
 // Peer address derived from command line or env var
@@ -397,6 +402,9 @@ func (stub *ChaincodeStub) init(handler *Handler, channelId string, txid string,
 	stub.signedProposal = signedProposal
 	stub.decorations = input.Decorations
 	stub.validationParameterMetakey = pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	if handler.additionalParams.UseWriteBatch {
+		stub.writeBatch = newWriteBatch()
+	}
 
 	// TODO: sanity check: verify that every call to init with a nil
 	// signedProposal is a legitimate one, meaning it is an internal call
@@ -458,6 +466,16 @@ func (stub *ChaincodeStub) GetState(key string) ([]byte, error) {
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultipleStates documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	// Access public data by setting the collection to empty string
+	collection := ""
+	return stub.handler.handleGetMultipleStates(collection, keys, stub.ChannelId, stub.TxID)
+}
+
 // SetStateValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) SetStateValidationParameter(key string, ep []byte) error {
 	return stub.handler.handlePutStateMetadataEntry("", key, stub.validationParameterMetakey, ep, stub.ChannelId, stub.TxID)
@@ -482,7 +500,7 @@ func (stub *ChaincodeStub) PutState(key string, value []byte) error {
 	}
 	// Access public data by setting the collection to empty string
 	collection := ""
-	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	return stub.putState(collection, key, value)
 }
 
 func (stub *ChaincodeStub) createStateQueryIterator(response *pb.QueryResponse) *StateQueryIterator {
@@ -508,9 +526,61 @@ func (stub *ChaincodeStub) GetQueryResult(query string) (StateQueryIteratorInter
 func (stub *ChaincodeStub) DelState(key string) error {
 	// Access public data by setting the collection to empty string
 	collection := ""
+	return stub.delState(collection, key)
+}
+
+// putState buffers the write in the write batch, if any, or sends it to the peer
+func (stub *ChaincodeStub) putState(collection, key string, value []byte) error {
+	if stub.writeBatch != nil {
+		stub.writeBatch.add(&pb.WriteRecord{Key: key, Value: value, Collection: collection})
+		return nil
+	}
+	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+}
+
+// delState buffers the delete in the write batch, if any, or sends it to the peer
+func (stub *ChaincodeStub) delState(collection, key string) error {
+	if stub.writeBatch != nil {
+		stub.writeBatch.add(&pb.WriteRecord{Key: key, Collection: collection, IsDelete: true})
+		return nil
+	}
 	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// flushWriteBatch sends the buffered writes to the peer. It is called once the
+// chaincode function returns
+func (stub *ChaincodeStub) flushWriteBatch() error {
+	if stub.writeBatch == nil || len(stub.writeBatch.records) == 0 {
+		return nil
+	}
+	records := stub.writeBatch.records
+	stub.writeBatch = newWriteBatch()
+	return stub.handler.handlePutStateBatch(records, stub.ChannelId, stub.TxID)
+}
+
+// writeBatch holds the writes of a transaction in the order they were made. A
+// key written several times is only kept with its last write, which takes the
+// place of the first
+type writeBatch struct {
+	records []*pb.WriteRecord
+	index   map[string]int
+}
+
+func newWriteBatch() *writeBatch {
+	return &writeBatch{index: make(map[string]int)}
+}
+
+func (b *writeBatch) add(record *pb.WriteRecord) {
+	// collections can't contain a null character, unlike composite keys
+	k := record.Collection + "\x00" + record.Key
+	if i, ok := b.index[k]; ok {
+		b.records[i] = record
+		return
+	}
+	b.index[k] = len(b.records)
+	b.records = append(b.records, record)
+}
+
 //  ---------  private state functions  ---------
 
 // GetPrivateData documentation can be found in interfaces.go
@@ -521,6 +591,17 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultiplePrivateData documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return stub.handler.handleGetMultipleStates(collection, keys, stub.ChannelId, stub.TxID)
+}
+
 // GetPrivateDataHash documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
 	if collection == "" {
@@ -537,7 +618,7 @@ func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value [
 	if key == "" {
 		return fmt.Errorf("key must not be an empty string")
 	}
-	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	return stub.putState(collection, key, value)
 }
 
 // DelPrivateData documentation can be found in interfaces.go
@@ -545,7 +626,7 @@ func (stub *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	if collection == "" {
 		return fmt.Errorf("collection must not be an empty string")
 	}
-	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	return stub.delState(collection, key)
 }
 
 // GetPrivateDataByRange documentation can be found in interfaces.go
@@ -708,13 +789,56 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetHistoryForKey(key, nil, nil, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := stub.handler.handleGetHistoryForKey(key, options, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
+}
+
+// GetStateAtHeight documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return stub.handler.handleGetStateAtHeight(key, blockNum, stub.ChannelId, stub.TxID)
+}
+
+// GetStateByRangeAtHeight documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	response, err := stub.handler.handleGetStateByRangeAtHeight(startKey, endKey, blockNum, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return stub.createStateQueryIterator(response), nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
@@ -1032,6 +1156,29 @@ func (stub *ChaincodeStub) SetEvent(name string, payload []byte) error {
 	return nil
 }
 
+// AddEvent documentation can be found in interfaces.go
+func (stub *ChaincodeStub) AddEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be nil string")
+	}
+	stub.addedEvents = append(stub.addedEvents, &pb.ChaincodeEvent{EventName: name, Payload: payload})
+	return nil
+}
+
+// events returns all the events of the transaction, the one set with SetEvent
+// first, or nil when no event was added with AddEvent, in which case the
+// peer only needs the event which was set
+func (stub *ChaincodeStub) events() []*pb.ChaincodeEvent {
+	if len(stub.addedEvents) == 0 {
+		return nil
+	}
+	var events []*pb.ChaincodeEvent
+	if stub.chaincodeEvent != nil {
+		events = append(events, stub.chaincodeEvent)
+	}
+	return append(events, stub.addedEvents...)
+}
+
 // ------------- Logging Control and Chaincode Loggers ---------------
 
 // As independent programs, Go language chaincodes can use any logging
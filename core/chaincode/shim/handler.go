@@ -52,6 +52,9 @@ type Handler struct {
 	// Multiple queries (and one transaction) with different txids can be executing in parallel for this chaincode
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
+	// additionalParams are sent by the peer on registration and tell whether
+	// the reads and writes of multiple keys may be batched
+	additionalParams *pb.ChaincodeAdditionalParams
 }
 
 func shorttxid(txid string) string {
@@ -168,6 +171,7 @@ func newChaincodeHandler(peerChatStream PeerChaincodeStream, chaincode Chaincode
 		ChatStream: peerChatStream,
 		cc:         chaincode,
 	}
+	v.additionalParams = &pb.ChaincodeAdditionalParams{}
 	v.responseChannel = make(map[string]chan pb.ChaincodeMessage)
 	v.state = created
 	return v
@@ -220,6 +224,11 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 			}
 		}
 
+		err = stub.flushWriteBatch()
+		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s] Init failed to write the state. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		resBytes, err := proto.Marshal(&res)
 		if err != nil {
 			payload := []byte(err.Error())
@@ -229,7 +238,7 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 		}
 
 		// Send COMPLETED message to chaincode support and change state
-		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChannelId: stub.ChannelId}
+		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChaincodeEvents: stub.events(), ChannelId: stub.ChannelId}
 		chaincodeLogger.Debugf("[%s] Init succeeded. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_COMPLETED)
 	}()
 }
@@ -272,6 +281,11 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan er
 		}
 		res := handler.cc.Invoke(stub)
 
+		err = stub.flushWriteBatch()
+		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s] Transaction failed to write the state. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		// Endorser will handle error contained in Response.
 		resBytes, err := proto.Marshal(&res)
 		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s] Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
@@ -280,7 +294,7 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan er
 
 		// Send COMPLETED message to chaincode support and change state
 		chaincodeLogger.Debugf("[%s] Transaction completed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_COMPLETED)
-		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChannelId: stub.ChannelId}
+		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChaincodeEvents: stub.events(), ChannelId: stub.ChannelId}
 	}()
 }
 
@@ -299,7 +313,6 @@ func (handler *Handler) callPeerWithChaincodeMsg(msg *pb.ChaincodeMessage, chann
 	return handler.sendReceive(msg, respChan)
 }
 
-// TODO: Implement a method to get multiple keys at a time [FAB-1244]
 // handleGetState communicates with the peer to fetch the requested state information from the ledger.
 func (handler *Handler) handleGetState(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_STATE
@@ -329,6 +342,80 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetMultipleStates fetches the state of multiple keys from the ledger, in as
+// few round trips as the peer accepts. The values are in the order of the keys, nil
+// denoting a key which does not exist. The keys are fetched one by one from the
+// peers which do not accept GET_STATE_MULTIPLE messages.
+func (handler *Handler) handleGetMultipleStates(collection string, keys []string, channelId string, txid string) ([][]byte, error) {
+	if !handler.additionalParams.UseGetMultipleKeys {
+		values := make([][]byte, 0, len(keys))
+		for _, key := range keys {
+			value, err := handler.handleGetState(collection, key, channelId, txid)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	values := make([][]byte, 0, len(keys))
+	for len(keys) > 0 {
+		n := len(keys)
+		if maxSize := int(handler.additionalParams.MaxSizeGetMultipleKeys); maxSize != 0 && n > maxSize {
+			n = maxSize
+		}
+		chunk, err := handler.handleGetStateMultiple(collection, keys[:n], channelId, txid)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, chunk...)
+		keys = keys[n:]
+	}
+	return values, nil
+}
+
+func (handler *Handler) handleGetStateMultiple(collection string, keys []string, channelId string, txid string) ([][]byte, error) {
+	// Construct payload for GET_STATE_MULTIPLE
+	payloadBytes, _ := proto.Marshal(&pb.GetStateMultiple{Collection: collection, Keys: keys})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_MULTIPLE)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_STATE_MULTIPLE", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetStateMultiple received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		result := &pb.GetStateMultipleResult{}
+		if err := proto.Unmarshal(responseMsg.Payload, result); err != nil {
+			chaincodeLogger.Errorf("[%s] GetStateMultiple could not unmarshal result", shorttxid(responseMsg.Txid))
+			return nil, errors.Wrap(err, "could not unmarshal the values")
+		}
+		if len(result.Values) != len(keys) {
+			return nil, errors.Errorf("[%s] received %d values for %d keys", shorttxid(responseMsg.Txid), len(result.Values), len(keys))
+		}
+		for i, value := range result.Values {
+			if len(value) == 0 {
+				result.Values[i] = nil
+			}
+		}
+		return result.Values, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetStateMultiple received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetPrivateDataHash(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_PRIVATE_DATA_HASH
 	payloadBytes, _ := proto.Marshal(&pb.GetState{Collection: collection, Key: key})
@@ -396,7 +483,6 @@ func (handler *Handler) handleGetStateMetadata(collection string, key string, ch
 	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-// TODO: Implement a method to set multiple keys at a time [FAB-1244]
 // handlePutState communicates with the peer to put state information into the ledger.
 func (handler *Handler) handlePutState(collection string, key string, value []byte, channelId string, txid string) error {
 	// Construct payload for PUT_STATE
@@ -428,6 +514,52 @@ func (handler *Handler) handlePutState(collection string, key string, value []by
 	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handlePutStateBatch sends the writes of a transaction to the peer, in batches
+// of at most the size the peer accepts.
+func (handler *Handler) handlePutStateBatch(records []*pb.WriteRecord, channelId string, txid string) error {
+	for len(records) > 0 {
+		n := len(records)
+		if maxSize := int(handler.additionalParams.MaxSizeWriteBatch); maxSize != 0 && n > maxSize {
+			n = maxSize
+		}
+		if err := handler.sendPutStateBatch(records[:n], channelId, txid); err != nil {
+			return err
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (handler *Handler) sendPutStateBatch(records []*pb.WriteRecord, channelId string, txid string) error {
+	// Construct payload for PUT_STATE_BATCH
+	payloadBytes, _ := proto.Marshal(&pb.PutStateBatch{Records: records})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_BATCH, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_PUT_STATE_BATCH)
+
+	// Execute the request and get response
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("[%s] error sending PUT_STATE_BATCH", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully updated state", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handlePutStateMetadataEntry(collection string, key string, metakey string, metadata []byte, channelID string, txID string) error {
 	// Construct payload for PUT_STATE_METADATA
 	md := &pb.StateMetadata{Metakey: metakey, Value: metadata}
@@ -660,7 +792,8 @@ func (handler *Handler) handleGetQueryResult(collection string, query string, me
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txid string) (*pb.QueryResponse, error) {
+func (handler *Handler) handleGetHistoryForKey(key string, options *pb.HistoryQueryOptions, metadata []byte,
+	channelId string, txid string) (*pb.QueryResponse, error) {
 	// Create the channel on which to communicate the response from validating peer
 	var respChan chan pb.ChaincodeMessage
 	var err error
@@ -673,7 +806,7 @@ func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txi
 
 	// Send GET_HISTORY_FOR_KEY message to peer chaincode support
 	//we constructed a valid object. No need to check for error
-	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKey{Key: key})
+	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKey{Key: key, Options: options, Metadata: metadata})
 
 	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
 	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
@@ -708,6 +841,72 @@ func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txi
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetStateAtHeight communicates with the peer to fetch the state of a key at a given height
+func (handler *Handler) handleGetStateAtHeight(key string, blockNum uint64, channelId string, txid string) ([]byte, error) {
+	// Construct payload for GET_STATE_AT_HEIGHT
+	payloadBytes, _ := proto.Marshal(&pb.GetStateAtHeight{Key: key, BlockNum: blockNum})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_AT_HEIGHT)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_STATE_AT_HEIGHT", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetStateAtHeight received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return responseMsg.Payload, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetStateAtHeight received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handleGetStateByRangeAtHeight communicates with the peer to run a range query at a given height
+func (handler *Handler) handleGetStateByRangeAtHeight(startKey, endKey string, blockNum uint64, channelId string, txid string) (*pb.QueryResponse, error) {
+	// Send GET_STATE_BY_RANGE_AT_HEIGHT message to peer chaincode support
+	//we constructed a valid object. No need to check for error
+	payloadBytes, _ := proto.Marshal(&pb.GetStateByRangeAtHeight{StartKey: startKey, EndKey: endKey, BlockNum: blockNum})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.Errorf("[%s] error sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT)
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully got range", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+
+		rangeQueryResponse := &pb.QueryResponse{}
+		if err = proto.Unmarshal(responseMsg.Payload, rangeQueryResponse); err != nil {
+			chaincodeLogger.Errorf("[%s] unmarshal error", shorttxid(responseMsg.Txid))
+			return nil, errors.Errorf("[%s] GetStateByRangeAtHeightResponse unmarshall error", shorttxid(responseMsg.Txid))
+		}
+
+		return rangeQueryResponse, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("Incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) createResponse(status int32, payload []byte) pb.Response {
 	return pb.Response{Status: status, Payload: payload}
 }
@@ -819,6 +1018,12 @@ func (handler *Handler) handleEstablished(msg *pb.ChaincodeMessage, errc chan er
 //handle created state
 func (handler *Handler) handleCreated(msg *pb.ChaincodeMessage, errc chan error) error {
 	if msg.Type == pb.ChaincodeMessage_REGISTERED {
+		// older peers send no additional params, and accept no batches
+		additionalParams := &pb.ChaincodeAdditionalParams{}
+		if err := proto.Unmarshal(msg.Payload, additionalParams); err != nil {
+			return errors.Wrapf(err, "[%s] error unmarshaling the additional params of %s", msg.Txid, msg.Type)
+		}
+		handler.additionalParams = additionalParams
 		handler.state = established
 		return nil
 	}
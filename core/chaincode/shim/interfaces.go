@@ -82,6 +82,13 @@ type ChaincodeStubInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetMultipleStates returns the values of the specified `keys` from the
+	// ledger, in the order of the keys, fetching them in as few requests to
+	// the peer as it allows. Like GetState, it doesn't consider data modified
+	// by PutState that has not been committed. The value of a key which does
+	// not exist in the state database is nil.
+	GetMultipleStates(keys ...string) ([][]byte, error)
+
 	// PutState puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal. PutState doesn't effect the ledger
 	// until the transaction is validated and successfully committed.
@@ -90,6 +97,10 @@ type ChaincodeStubInterface interface {
 	// composite keys, which internally get prefixed with 0x00 as composite
 	// key namespace. In addition, if using CouchDB, keys can only contain
 	// valid UTF-8 strings and cannot begin with an underscore ("_").
+	// When the peer allows it, the writes are buffered by the shim and sent
+	// to the peer in batches once the transaction function returns, so that
+	// errors such as an invalid key may only surface then, failing the
+	// transaction.
 	PutState(key string, value []byte) error
 
 	// DelState records the specified `key` to be deleted in the writeset of
@@ -233,6 +244,42 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns a history of key values across time,
+	// restricted to the updates made in the blocks between the start and end
+	// blocks of the options, both included, an end block of zero meaning no
+	// upper bound. The updates are returned from the oldest to the newest,
+	// or from the newest to the oldest if the options are descending.
+	// When an empty string is passed as a value to the bookmark argument, the
+	// returned iterator can be used to fetch the first `pageSize` updates.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` updates starting at the bookmark (inclusive).
+	// Note that only the bookmark present in a prior page of query results
+	// (ResponseMetadata) can be used as a value to the bookmark argument.
+	// A pageSize of zero along with an empty bookmark disables pagination.
+	// The same caveats as for GetHistoryForKey apply.
+	GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetStateAtHeight returns the value of the specified `key` as it was right
+	// after the block of the given number was committed, or nil if the key
+	// didn't exist at that height.
+	// GetStateAtHeight requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// The key isn't added to the readset of the transaction, since the
+	// value at a committed height never changes.
+	GetStateAtHeight(key string, blockNum uint64) ([]byte, error)
+
+	// GetStateByRangeAtHeight returns a range iterator over a set of keys in the
+	// ledger as they were right after the block of the given number was committed.
+	// The iterator can be used to iterate over all keys between the startKey
+	// (inclusive) and endKey (exclusive), that existed at that height.
+	// Empty strings as startKey and endKey refer to the first and the last
+	// key of the range respectively, like in GetStateByRange.
+	// GetStateByRangeAtHeight requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// The keys aren't added to the readset of the transaction.
+	GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -240,6 +287,11 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetMultiplePrivateData returns the values of the specified `keys` from the
+	// specified `collection`, in the order of the keys, like GetMultipleStates
+	// does for the public state.
+	GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error)
+
 	// GetPrivateDataHash returns the hash of the value of the specified `key` from the specified
 	// `collection`
 	GetPrivateDataHash(collection, key string) ([]byte, error)
@@ -348,6 +400,13 @@ type ChaincodeStubInterface interface {
 	// available within the transaction in the committed block regardless of the
 	// validity of the transaction.
 	SetEvent(name string, payload []byte) error
+
+	// AddEvent allows the chaincode to add an event to the response to the
+	// proposal, in addition to the one set with SetEvent and the ones added
+	// before. The events are recorded in the transaction in the order they
+	// were added, after the one which was set. More than one event per
+	// transaction requires the V1_4_3 application capability of the channel.
+	AddEvent(name string, payload []byte) error
 }
 
 // CommonIteratorInterface allows a chaincode to check whether any more result
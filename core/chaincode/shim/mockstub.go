@@ -165,6 +165,22 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 	return m[key], nil
 }
 
+// GetMultiplePrivateData retrieves the values of the specified `keys` from the collection.
+func (stub *MockStub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	var values [][]byte
+	for _, key := range keys {
+		value, err := stub.GetPrivateData(collection, key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
 func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
 	return nil, errors.New("Not Implemented")
 }
@@ -207,6 +223,19 @@ func (stub *MockStub) GetState(key string) ([]byte, error) {
 	return value, nil
 }
 
+// GetMultipleStates retrieves the values of the specified `keys` from the ledger.
+func (stub *MockStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	var values [][]byte
+	for _, key := range keys {
+		value, err := stub.GetState(key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
 // PutState writes the specified `value` and `key` into the ledger.
 func (stub *MockStub) PutState(key string, value []byte) error {
 	if stub.TxID == "" {
@@ -296,6 +325,25 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyWithOptions function can be invoked by a chaincode to return a bounded,
+// ordered and paginated history of key values across time.
+func (stub *MockStub) GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetStateAtHeight function can be invoked by a chaincode to get the value of a key
+// as it was right after the block of the given number was committed.
+func (stub *MockStub) GetStateAtHeight(key string, blockNum uint64) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetStateByRangeAtHeight function can be invoked by a chaincode to query the state
+// of a range of keys as it was right after the block of the given number was committed.
+func (stub *MockStub) GetStateByRangeAtHeight(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
@@ -400,6 +448,11 @@ func (stub *MockStub) SetEvent(name string, payload []byte) error {
 	return nil
 }
 
+func (stub *MockStub) AddEvent(name string, payload []byte) error {
+	stub.ChaincodeEventsChannel <- &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	return nil
+}
+
 func (stub *MockStub) SetStateValidationParameter(key string, ep []byte) error {
 	return stub.SetPrivateDataValidationParameter("", key, ep)
 }
@@ -284,6 +284,29 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+func TestMockStubGetMultiple(t *testing.T) {
+	stub := NewMockStub("multiple", nil)
+
+	stub.MockTransactionStart("1")
+	assert.NoError(t, stub.PutState("A", []byte("a")))
+	assert.NoError(t, stub.PutState("C", []byte("c")))
+	assert.NoError(t, stub.PutPrivateData("coll", "A", []byte("pa")))
+	stub.MockTransactionEnd("1")
+
+	values, err := stub.GetMultipleStates("A", "B", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), nil, []byte("c")}, values)
+
+	values, err = stub.GetMultiplePrivateData("coll", "A", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("pa"), nil}, values)
+	values, err = stub.GetMultiplePrivateData("missing", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{nil}, values)
+	_, err = stub.GetMultiplePrivateData("", "A")
+	assert.EqualError(t, err, "collection must not be an empty string")
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -313,6 +336,7 @@ func TestMockMock(t *testing.T) {
 	stub.GetSignedProposal()
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
+	stub.AddEvent("e2", nil)
 	stub.GetHistoryForKey("k")
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a ChaincodeServer. The key and the
+// certificates are PEM encoded
+type TLSProperties struct {
+	// Disabled disables TLS, which is only meant for development
+	Disabled bool
+	// Key and Cert are the key pair of the server
+	Key  []byte
+	Cert []byte
+	// ClientCACerts, when set, requires the peers connecting to the server
+	// to present a certificate issued by one of them
+	ClientCACerts []byte
+}
+
+// ChaincodeServer serves a chaincode running as an external service, to which
+// the peers connect instead of the chaincode connecting to a peer
+type ChaincodeServer struct {
+	// CCID is the name the chaincode registers with, that is name:version
+	CCID string
+	// Address is the address the server listens on
+	Address string
+	// CC is the chaincode which is served
+	CC Chaincode
+	// TLSProps are the TLS settings of the server
+	TLSProps TLSProperties
+}
+
+// serverStream is the server side of a stream established by a peer. Unlike
+// the client side, it cannot be half closed, which happens on return of Connect
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+func (s *serverStream) CloseSend() error {
+	return nil
+}
+
+// Connect is called by the peers connecting to the chaincode, over whose
+// stream the chaincode registers and runs the usual protocol
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(cs.CCID, &serverStream{stream}, cs.CC)
+}
+
+// Start starts the server and blocks until it stops
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+
+	SetupChaincodeLogging()
+
+	err := factory.InitFactories(factory.GetDefaultOpts())
+	if err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	secOpts := &comm.SecureOptions{UseTLS: !cs.TLSProps.Disabled}
+	if secOpts.UseTLS {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return errors.New("key and cert must be specified when TLS is enabled")
+		}
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		if cs.TLSProps.ClientCACerts != nil {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		KaOpts:  comm.DefaultKeepaliveOptions,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the chaincode server")
+	}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	chaincodeLogger.Infof("Chaincode %s listening on %s", cs.CCID, server.Address())
+	return server.Start()
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type mockConnectServer struct {
+	grpc.ServerStream
+	sent []*pb.ChaincodeMessage
+}
+
+func (m *mockConnectServer) Send(msg *pb.ChaincodeMessage) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *mockConnectServer) Recv() (*pb.ChaincodeMessage, error) {
+	return nil, io.EOF
+}
+
+func TestChaincodeServerConnect(t *testing.T) {
+	cs := &ChaincodeServer{CCID: "mycc:1.0", CC: &shimTestCC{}}
+	stream := &mockConnectServer{}
+
+	err := cs.Connect(stream)
+	assert.EqualError(t, err, "received EOF, ending chaincode stream: EOF")
+
+	// the chaincode registers over the stream established by the peer
+	assert.Len(t, stream.sent, 1)
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, stream.sent[0].Type)
+	chaincodeID := &pb.ChaincodeID{}
+	assert.NoError(t, proto.Unmarshal(stream.sent[0].Payload, chaincodeID))
+	assert.Equal(t, "mycc:1.0", chaincodeID.Name)
+}
+
+func TestChaincodeServerStartErrors(t *testing.T) {
+	tests := []struct {
+		server      *ChaincodeServer
+		expectedErr string
+	}{
+		{&ChaincodeServer{Address: "127.0.0.1:0", CC: &shimTestCC{}}, "ccid must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", CC: &shimTestCC{}}, "address must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "127.0.0.1:0"}, "chaincode must be specified"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "127.0.0.1:0", CC: &shimTestCC{}}, "key and cert must be specified when TLS is enabled"},
+		{&ChaincodeServer{CCID: "mycc:1.0", Address: "256.0.0.1:0", CC: &shimTestCC{}, TLSProps: TLSProperties{Disabled: true}}, "failed to create the chaincode server"},
+	}
+	for _, test := range tests {
+		err := test.server.Start()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), test.expectedErr)
+	}
+}
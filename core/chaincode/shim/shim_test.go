@@ -11,8 +11,10 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	mockpeer "github.com/hyperledger/fabric/common/mocks/peer"
 	"github.com/hyperledger/fabric/common/util"
@@ -22,6 +24,7 @@ import (
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // shimTestCC example simple Chaincode implementation
@@ -81,6 +84,10 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "historyqpaged" {
+		return t.historyqpaged(stub, args)
+	} else if function == "heightq" {
+		return t.heightq(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	} else if function == "putep" {
@@ -290,6 +297,23 @@ func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Respon
 	return Success(buffer.Bytes())
 }
 
+// heightq gets the value of a key at a height
+func (t *shimTestCC) heightq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) < 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	blockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error(err.Error())
+	}
+	value, err := stub.GetStateAtHeight(args[0], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+	return Success(value)
+}
+
 // rangeq calls range query
 func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) < 1 {
@@ -342,6 +366,32 @@ func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Res
 	return Success(buffer.Bytes())
 }
 
+// historyqpaged gets a page of the history of a key, from the newest update to the oldest
+func (t *shimTestCC) historyqpaged(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) < 3 {
+		return Error("Incorrect number of arguments. Expecting 3")
+	}
+
+	pageSize, err := strconv.ParseInt(args[1], 10, 32)
+	if err != nil {
+		return Error(err.Error())
+	}
+
+	resultsIterator, metadata, err := stub.GetHistoryForKeyWithOptions(args[0], &pb.HistoryQueryOptions{}, int32(pageSize), args[2])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			return Error(err.Error())
+		}
+	}
+
+	return Success([]byte(metadata.Bookmark))
+}
+
 func (t *shimTestCC) putEP(stub ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	err := stub.SetStateValidationParameter(string(args[1]), args[2])
@@ -480,6 +530,27 @@ func TestNilEventName(t *testing.T) {
 
 }
 
+func TestAddEvent(t *testing.T) {
+	stub := &ChaincodeStub{}
+	assert.EqualError(t, stub.AddEvent("", []byte("event payload")), "event name can not be nil string")
+
+	// a single event set is only sent as the legacy event
+	assert.NoError(t, stub.SetEvent("set", []byte("set payload")))
+	assert.Nil(t, stub.events())
+
+	assert.NoError(t, stub.AddEvent("added1", []byte("payload1")))
+	assert.NoError(t, stub.AddEvent("added2", nil))
+	assert.Equal(t, []*pb.ChaincodeEvent{
+		{EventName: "set", Payload: []byte("set payload")},
+		{EventName: "added1", Payload: []byte("payload1")},
+		{EventName: "added2"},
+	}, stub.events())
+
+	stub = &ChaincodeStub{}
+	assert.NoError(t, stub.AddEvent("added", nil))
+	assert.Equal(t, []*pb.ChaincodeEvent{{EventName: "added"}}, stub.events())
+}
+
 type testCase struct {
 	name         string
 	ccLogLevel   string
@@ -876,6 +947,70 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//paginated history query
+
+	//create the response
+	historyQueryResponse = &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "6", Value: []byte("100")})}},
+		Metadata: utils.MarshalOrPanic(&pb.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: "3:0"})}
+	payload = utils.MarshalOrPanic(historyQueryResponse)
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Txid: "7d", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7d", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7d", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7d", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7d", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyqpaged"), []byte("A"), []byte("1"), []byte("")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7d", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//query at height
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("100"), Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("heightq"), []byte("A"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error query at height
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AT_HEIGHT, Txid: "7c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("history database not enabled"), Txid: "7c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("heightq"), []byte("A"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//query result
 
 	//create the response
@@ -1187,3 +1322,169 @@ func TestSend(t *testing.T) {
 	err := stream.Send(msg)
 	assert.NotNil(t, err, "should have errored on panic")
 }
+
+// batchPeerStream records the messages sent by the shim and answers them with
+// the response of respond
+type batchPeerStream struct {
+	handler *Handler
+	respond func(*pb.ChaincodeMessage) *pb.ChaincodeMessage
+
+	mutex sync.Mutex
+	sent  []*pb.ChaincodeMessage
+}
+
+func (s *batchPeerStream) Send(msg *pb.ChaincodeMessage) error {
+	s.mutex.Lock()
+	s.sent = append(s.sent, msg)
+	s.mutex.Unlock()
+	resp := s.respond(msg)
+	resp.Txid, resp.ChannelId = msg.Txid, msg.ChannelId
+	go s.handler.sendChannel(resp)
+	return nil
+}
+
+func (s *batchPeerStream) Recv() (*pb.ChaincodeMessage, error) { select {} }
+func (s *batchPeerStream) CloseSend() error                    { return nil }
+
+func (s *batchPeerStream) sentMessages() []*pb.ChaincodeMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sent
+}
+
+func newBatchStub(t *testing.T, params *pb.ChaincodeAdditionalParams, respond func(*pb.ChaincodeMessage) *pb.ChaincodeMessage) (*ChaincodeStub, *batchPeerStream) {
+	stream := &batchPeerStream{respond: respond}
+	handler := newChaincodeHandler(stream, nil)
+	stream.handler = handler
+
+	var payload []byte
+	if params != nil {
+		payload = utils.MarshalOrPanic(params)
+	}
+	err := handler.handleCreated(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: payload}, nil)
+	require.NoError(t, err)
+
+	stub := &ChaincodeStub{}
+	err = stub.init(handler, "testchannel", "txid", &pb.ChaincodeInput{}, nil)
+	require.NoError(t, err)
+	return stub, stream
+}
+
+func unmarshalOrPanic(buf []byte, msg proto.Message) {
+	if err := proto.Unmarshal(buf, msg); err != nil {
+		panic(err)
+	}
+}
+
+func respondToBatches(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+	switch msg.Type {
+	case pb.ChaincodeMessage_GET_STATE_MULTIPLE:
+		getStateMultiple := &pb.GetStateMultiple{}
+		unmarshalOrPanic(msg.Payload, getStateMultiple)
+		result := &pb.GetStateMultipleResult{}
+		for _, key := range getStateMultiple.Keys {
+			if key == "missing" {
+				result.Values = append(result.Values, nil)
+				continue
+			}
+			result.Values = append(result.Values, []byte(getStateMultiple.Collection+"value-"+key))
+		}
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(result)}
+	case pb.ChaincodeMessage_GET_STATE:
+		getState := &pb.GetState{}
+		unmarshalOrPanic(msg.Payload, getState)
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("value-" + getState.Key)}
+	default:
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE}
+	}
+}
+
+func TestGetMultipleStates(t *testing.T) {
+	stub, stream := newBatchStub(t, &pb.ChaincodeAdditionalParams{UseGetMultipleKeys: true, MaxSizeGetMultipleKeys: 2}, respondToBatches)
+
+	values, err := stub.GetMultipleStates("A", "missing", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value-A"), nil, []byte("value-C")}, values)
+
+	// the keys are fetched in as many messages as the maximum size requires
+	sent := stream.sentMessages()
+	require.Len(t, sent, 2)
+	for i, keys := range [][]string{{"A", "missing"}, {"C"}} {
+		assert.Equal(t, pb.ChaincodeMessage_GET_STATE_MULTIPLE, sent[i].Type)
+		getStateMultiple := &pb.GetStateMultiple{}
+		unmarshalOrPanic(sent[i].Payload, getStateMultiple)
+		assert.Equal(t, keys, getStateMultiple.Keys)
+	}
+
+	values, err = stub.GetMultiplePrivateData("coll", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("collvalue-A")}, values)
+
+	_, err = stub.GetMultiplePrivateData("", "A")
+	assert.EqualError(t, err, "collection must not be an empty string")
+
+	values, err = stub.GetMultipleStates()
+	assert.NoError(t, err)
+	assert.Nil(t, values)
+	assert.Len(t, stream.sentMessages(), 3)
+
+	stub, _ = newBatchStub(t, &pb.ChaincodeAdditionalParams{UseGetMultipleKeys: true}, func(*pb.ChaincodeMessage) *pb.ChaincodeMessage {
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("no ledger context")}
+	})
+	_, err = stub.GetMultipleStates("A")
+	assert.EqualError(t, err, "no ledger context")
+}
+
+func TestWriteBatch(t *testing.T) {
+	stub, stream := newBatchStub(t, &pb.ChaincodeAdditionalParams{UseWriteBatch: true, MaxSizeWriteBatch: 2}, respondToBatches)
+
+	// the writes are buffered until the batch is flushed, and only the last
+	// write of a key is sent
+	assert.NoError(t, stub.PutState("A", []byte("1")))
+	assert.NoError(t, stub.PutState("B", []byte("2")))
+	assert.NoError(t, stub.DelState("A"))
+	assert.NoError(t, stub.PutPrivateData("coll", "A", []byte("3")))
+	assert.NoError(t, stub.PutState("C", []byte("4")))
+	assert.Empty(t, stream.sentMessages())
+
+	assert.NoError(t, stub.flushWriteBatch())
+	sent := stream.sentMessages()
+	require.Len(t, sent, 2)
+	expected := [][]*pb.WriteRecord{
+		{{Key: "A", IsDelete: true}, {Key: "B", Value: []byte("2")}},
+		{{Key: "A", Value: []byte("3"), Collection: "coll"}, {Key: "C", Value: []byte("4")}},
+	}
+	for i, records := range expected {
+		assert.Equal(t, pb.ChaincodeMessage_PUT_STATE_BATCH, sent[i].Type)
+		putStateBatch := &pb.PutStateBatch{}
+		unmarshalOrPanic(sent[i].Payload, putStateBatch)
+		assert.True(t, proto.Equal(&pb.PutStateBatch{Records: records}, putStateBatch))
+	}
+
+	// a flushed batch is emptied
+	assert.NoError(t, stub.flushWriteBatch())
+	assert.Len(t, stream.sentMessages(), 2)
+
+	stub, _ = newBatchStub(t, &pb.ChaincodeAdditionalParams{UseWriteBatch: true}, func(*pb.ChaincodeMessage) *pb.ChaincodeMessage {
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("private data APIs are not allowed in chaincode Init()")}
+	})
+	assert.NoError(t, stub.PutPrivateData("coll", "A", []byte("1")))
+	assert.EqualError(t, stub.flushWriteBatch(), "private data APIs are not allowed in chaincode Init()")
+}
+
+func TestBatchesNotSupported(t *testing.T) {
+	// the peers which send no additional params get the keys one by one
+	stub, stream := newBatchStub(t, nil, respondToBatches)
+
+	values, err := stub.GetMultipleStates("A", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value-A"), []byte("value-B")}, values)
+	assert.NoError(t, stub.PutState("A", []byte("1")))
+	assert.NoError(t, stub.flushWriteBatch())
+
+	var types []pb.ChaincodeMessage_Type
+	for _, msg := range stream.sentMessages() {
+		types = append(types, msg.Type)
+	}
+	assert.Equal(t, []pb.ChaincodeMessage_Type{pb.ChaincodeMessage_GET_STATE, pb.ChaincodeMessage_GET_STATE, pb.ChaincodeMessage_PUT_STATE}, types)
+}
@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shimtest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// runningChaincode is a chaincode which runs in the harness, connected to
+// its handler through an in-process stream
+type runningChaincode struct {
+	version  string
+	handler  *chaincode.Handler
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// Stop stops the chaincode and waits until it quits
+func (rc *runningChaincode) Stop() {
+	rc.stopOnce.Do(func() { close(rc.stop) })
+	<-rc.done
+}
+
+// launch starts the chaincode and waits until it is registered with its handler
+func (h *Harness) launch(name, version string, cc shim.Chaincode) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.chaincodes[name]; ok {
+		return errors.Errorf("chaincode %s is already running", name)
+	}
+
+	registry := &registry{registered: make(chan error, 1)}
+	rc := &runningChaincode{
+		version: version,
+		handler: h.newHandler(registry),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(rc.done)
+		run(name, version, cc, rc.handler, rc.stop)
+	}()
+
+	select {
+	case err := <-registry.registered:
+		if err != nil {
+			rc.Stop()
+			return errors.WithMessage(err, fmt.Sprintf("failed to register chaincode %s", name))
+		}
+	case <-time.After(h.executeTimeout):
+		rc.Stop()
+		return errors.Errorf("timeout expired while starting chaincode %s", name)
+	}
+
+	h.chaincodes[name] = rc
+	return nil
+}
+
+// stop stops the named chaincode, if it is running
+func (h *Harness) stop(name string) {
+	h.mutex.Lock()
+	rc := h.chaincodes[name]
+	delete(h.chaincodes, name)
+	h.mutex.Unlock()
+
+	if rc != nil {
+		rc.Stop()
+	}
+}
+
+// newHandler creates the peer side of the stream with a chaincode, which is
+// the same as for the chaincodes launched by a peer
+func (h *Harness) newHandler(registry chaincode.Registry) *chaincode.Handler {
+	return &chaincode.Handler{
+		Registry:           registry,
+		Invoker:            &invoker{harness: h},
+		DefinitionGetter:   &lscc.LifeCycleSysCC{},
+		ACLProvider:        allowAll{},
+		TXContexts:         chaincode.NewTransactionContexts(),
+		ActiveTransactions: chaincode.NewActiveTransactions(),
+		SystemCCProvider:   &sysCCProvider{harness: h},
+		SystemCCVersion:    util.GetSysCCVersion(),
+		InstantiationPolicyChecker: chaincode.CheckInstantiationPolicyFunc(
+			func(name, version string, cd *ccprovider.ChaincodeData) error { return nil },
+		),
+		QueryResponseBuilder: &chaincode.QueryResponseGenerator{MaxResultLimit: 100},
+		UUIDGenerator:        chaincode.UUIDGeneratorFunc(util.GenerateUUID),
+		LedgerGetter:         h,
+		AppConfig:            &sysCCProvider{harness: h},
+		Metrics:              chaincode.NewHandlerMetrics(&disabled.Provider{}),
+	}
+}
+
+// run connects the shim of the chaincode to its handler, until either of them
+// quits or the chaincode is stopped
+func run(name, version string, cc shim.Chaincode, handler *chaincode.Handler, stop <-chan struct{}) {
+	peerRcvCCSend := make(chan *pb.ChaincodeMessage)
+	ccRcvPeerSend := make(chan *pb.ChaincodeMessage)
+	ccDone := make(chan struct{})
+	handlerDone := make(chan struct{})
+
+	go func() {
+		defer close(ccDone)
+		env := []string{"CORE_CHAINCODE_ID_NAME=" + name + ":" + version}
+		err := shim.StartInProc(env, nil, cc, ccRcvPeerSend, peerRcvCCSend)
+		logger.Debugf("chaincode %s ended with err: %v", name, err)
+	}()
+	go func() {
+		defer close(handlerDone)
+		err := handler.ProcessStream(&stream{recv: peerRcvCCSend, send: ccRcvPeerSend})
+		logger.Debugf("stream with chaincode %s ended with err: %v", name, err)
+	}()
+
+	select {
+	case <-ccDone:
+	case <-handlerDone:
+	case <-stop:
+	}
+	// closing both channels makes the other end fail to send or receive
+	close(ccRcvPeerSend)
+	close(peerRcvCCSend)
+	<-ccDone
+	<-handlerDone
+}
+
+// execute sends the input to the chaincode as a message of the given type
+// and waits for its response
+func (h *Harness) execute(typ pb.ChaincodeMessage_Type, txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.ChaincodeMessage, error) {
+	h.mutex.Lock()
+	rc := h.chaincodes[cccid.Name]
+	h.mutex.Unlock()
+	if rc == nil || rc.version != cccid.Version {
+		return nil, errors.Errorf("chaincode %s is not running", cccid.GetCanonicalName())
+	}
+
+	input.Decorations = txParams.ProposalDecorations
+	payload, err := proto.Marshal(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal chaincode input")
+	}
+	msg := &pb.ChaincodeMessage{
+		Type:      typ,
+		Payload:   payload,
+		Txid:      txParams.TxID,
+		ChannelId: txParams.ChannelID,
+	}
+	return rc.handler.Execute(txParams, cccid, msg, h.executeTimeout)
+}
+
+// invoker invokes the chaincodes running in the harness on behalf of
+// other chaincodes
+type invoker struct {
+	harness *Harness
+}
+
+func (i *invoker) Invoke(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.ChaincodeMessage, error) {
+	return i.harness.execute(pb.ChaincodeMessage_TRANSACTION, txParams, cccid, input)
+}
+
+// registry tracks the registration of a single chaincode with its handler
+type registry struct {
+	registered chan error
+}
+
+func (r *registry) Register(*chaincode.Handler) error {
+	return nil
+}
+
+func (r *registry) Ready(string) {
+	r.notify(nil)
+}
+
+func (r *registry) Failed(_ string, err error) {
+	r.notify(err)
+}
+
+func (r *registry) Deregister(string) error {
+	return nil
+}
+
+func (r *registry) notify(err error) {
+	select {
+	case r.registered <- err:
+	default:
+	}
+}
+
+// stream is the end of the stream between a chaincode and its handler
+// which belongs to the handler
+type stream struct {
+	recv <-chan *pb.ChaincodeMessage
+	send chan<- *pb.ChaincodeMessage
+}
+
+// Send sends a message to the chaincode
+func (s *stream) Send(msg *pb.ChaincodeMessage) (err error) {
+	// the channel is closed when the chaincode is stopped
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to send message: %v", r)
+		}
+	}()
+	s.send <- msg
+	return nil
+}
+
+// Recv receives a message from the chaincode
+func (s *stream) Recv() (*pb.ChaincodeMessage, error) {
+	msg, ok := <-s.recv
+	if !ok {
+		return nil, errors.New("channel is closed")
+	}
+	return msg, nil
+}
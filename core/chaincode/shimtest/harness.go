@@ -0,0 +1,623 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package shimtest runs chaincodes in go tests against the ledger, the
+// chaincode support and the validator of a peer, in a single process.
+//
+// Unlike shim.MockStub, which reimplements the ledger, the harness endorses
+// proposals with the real handler of the chaincode stream, producing real
+// simulation results, and commits them in blocks which the real validator
+// checks before the real ledger commits them. Hence MVCC conflicts, the
+// history of keys, private data collections, key-level endorsement policies,
+// pagination and the rich queries of CouchDB behave as on a peer.
+//
+// The harness configures the ledger through the global viper configuration
+// and uses the global ledger and MSP management, so only one harness can be
+// open at a time.
+package shimtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/committer/txvalidator"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	lutils "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/semaphore"
+)
+
+var logger = flogging.MustGetLogger("shimtest")
+
+var (
+	openMutex sync.Mutex
+	open      bool
+)
+
+// Config is the configuration of a harness
+type Config struct {
+	// Dir is the directory of the ledger, a temporary one by default.
+	// The ledger in the directory is removed when the harness starts.
+	Dir string
+	// ChannelID is the ID of the channel, "testchannel" by default
+	ChannelID string
+	// Orgs are the MSP IDs of the organizations of the channel, which
+	// is "Org1MSP" alone by default. Each organization has a client and
+	// a peer identity issued by its own CA.
+	Orgs []string
+	// CouchDBAddress is the address of the CouchDB which holds the state,
+	// which is held by LevelDB in the directory of the ledger by default
+	CouchDBAddress string
+	// ExecuteTimeout is the time a chaincode has to start and to execute
+	// a transaction, 30 seconds by default
+	ExecuteTimeout time.Duration
+}
+
+// Chaincode is a chaincode to deploy in the harness
+type Chaincode struct {
+	Name      string
+	Version   string
+	Chaincode shim.Chaincode
+	// EndorsementPolicy is the endorsement policy of the chaincode, which is
+	// a signature by a member of any organization by default
+	EndorsementPolicy *common.SignaturePolicyEnvelope
+	// Collections are the private data collections of the chaincode
+	Collections *common.CollectionConfigPackage
+}
+
+// Proposal is a proposal to invoke a chaincode
+type Proposal struct {
+	Chaincode string
+	Args      [][]byte
+	Transient map[string][]byte
+	// Creator is the MSP ID of the organization of the client which creates
+	// the proposal, the first organization by default
+	Creator string
+	// Endorsers are the MSP IDs of the organizations whose peers endorse
+	// the proposal, all the organizations by default
+	Endorsers []string
+}
+
+// Transaction is an endorsed proposal
+type Transaction struct {
+	TxID     string
+	Response *pb.Response
+	Events   []*pb.ChaincodeEvent
+	Results  *ledger.TxSimulationResults
+	// Envelope is the transaction to commit, which is nil when the chaincode
+	// responded with an error, as such a response isn't endorsed
+	Envelope *common.Envelope
+	// ValidationCode is the result of the validation of the transaction, which
+	// is NOT_VALIDATED until the transaction is committed
+	ValidationCode pb.TxValidationCode
+	// BlockNumber is the number of the block the transaction is committed in
+	BlockNumber uint64
+}
+
+// Harness runs chaincodes in the process against the ledger of a channel
+type Harness struct {
+	channelID      string
+	dir            string
+	removeDir      bool
+	executeTimeout time.Duration
+
+	orgs       map[string]*org
+	mspIDs     []string
+	mspManager msp.MSPManager
+	appConfig  channelconfig.Application
+
+	ledger    ledger.PeerLedger
+	validator txvalidator.Validator
+
+	mutex       sync.Mutex
+	chaincodes  map[string]*runningChaincode
+	commitMutex sync.Mutex
+}
+
+// New creates the ledger of the channel and returns a harness which runs
+// chaincodes against it. The harness must be closed when it's no longer used.
+func New(config Config) (*Harness, error) {
+	if config.ChannelID == "" {
+		config.ChannelID = "testchannel"
+	}
+	if len(config.Orgs) == 0 {
+		config.Orgs = []string{"Org1MSP"}
+	}
+	if config.ExecuteTimeout == 0 {
+		config.ExecuteTimeout = 30 * time.Second
+	}
+
+	openMutex.Lock()
+	defer openMutex.Unlock()
+	if open {
+		return nil, errors.New("another harness is open")
+	}
+
+	h := &Harness{
+		channelID:      config.ChannelID,
+		dir:            config.Dir,
+		executeTimeout: config.ExecuteTimeout,
+		orgs:           map[string]*org{},
+		mspManager:     msp.NewMSPManager(),
+		appConfig: &mockconfig.MockApplication{
+			CapabilitiesRv: &mockconfig.MockApplicationCapabilities{
+				ForbidDuplicateTXIdInBlockRv: true,
+				PrivateChannelDataRv:         true,
+				CollectionUpgradeRv:          true,
+				V1_1ValidationRv:             true,
+				V1_2ValidationRv:             true,
+				KeyLevelEndorsementRv:        true,
+				V1_3ValidationRv:             true,
+				MultipleChaincodeEventsRv:    true,
+			},
+		},
+		chaincodes: map[string]*runningChaincode{},
+	}
+
+	var msps []msp.MSP
+	for _, mspID := range config.Orgs {
+		if _, ok := h.orgs[mspID]; ok {
+			return nil, errors.Errorf("organization %s is duplicated", mspID)
+		}
+		o, err := newOrg(mspID)
+		if err != nil {
+			return nil, err
+		}
+		h.orgs[mspID] = o
+		h.mspIDs = append(h.mspIDs, mspID)
+		msps = append(msps, o.msp)
+	}
+	if err := h.mspManager.Setup(msps); err != nil {
+		return nil, errors.WithMessage(err, "failed to set up the MSP manager")
+	}
+
+	if h.dir == "" {
+		dir, err := ioutil.TempDir("", "shimtest")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the directory of the ledger")
+		}
+		h.dir = dir
+		h.removeDir = true
+	}
+
+	viper.Set("peer.fileSystemPath", h.dir)
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	if config.CouchDBAddress != "" {
+		viper.Set("ledger.state.stateDatabase", "CouchDB")
+		viper.Set("ledger.state.couchDBConfig.couchDBAddress", config.CouchDBAddress)
+		viper.Set("ledger.state.couchDBConfig.maxRetries", 3)
+		viper.Set("ledger.state.couchDBConfig.maxRetriesOnStartup", 10)
+		viper.Set("ledger.state.couchDBConfig.requestTimeout", 35*time.Second)
+	} else {
+		viper.Set("ledger.state.stateDatabase", "goleveldb")
+	}
+
+	ledgermgmt.InitializeTestEnvWithInitializer(&ledgermgmt.Initializer{
+		DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+		MembershipInfoProvider:        alwaysMember{},
+		MetricsProvider:               &disabled.Provider{},
+	})
+	mspmgmt.XXXSetMSPManager(h.channelID, h.mspManager)
+
+	genesisBlock, err := newGenesisBlock(h.channelID)
+	if err == nil {
+		h.ledger, err = ledgermgmt.CreateLedger(genesisBlock)
+	}
+	if err != nil {
+		ledgermgmt.CleanupTestEnv()
+		if h.removeDir {
+			os.RemoveAll(h.dir)
+		}
+		return nil, errors.WithMessage(err, "failed to create the ledger")
+	}
+
+	h.validator = txvalidator.NewTxValidator(
+		h.channelID,
+		&validatorSupport{Weighted: semaphore.NewWeighted(1), harness: h},
+		&sysCCProvider{harness: h},
+		txvalidator.MapBasedPluginMapper{"vscc": &builtin.DefaultValidationFactory{}},
+	)
+
+	open = true
+	return h, nil
+}
+
+// newGenesisBlock returns a genesis block with an empty configuration, which
+// is all the ledger needs to create the channel
+func newGenesisBlock(channelID string) (*common.Block, error) {
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, channelID, nil, &common.ConfigEnvelope{Config: &common.Config{}}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	envBytes, err := proto.Marshal(env)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the config transaction")
+	}
+
+	block := common.NewBlock(0, nil)
+	block.Data.Data = [][]byte{envBytes}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutils.NewTxValidationFlagsSetValue(1, pb.TxValidationCode_VALID)
+	return block, nil
+}
+
+// Close stops the chaincodes and closes the ledger, which is removed if its
+// directory is a temporary one
+func (h *Harness) Close() {
+	h.mutex.Lock()
+	chaincodes := h.chaincodes
+	h.chaincodes = map[string]*runningChaincode{}
+	h.mutex.Unlock()
+	for _, rc := range chaincodes {
+		rc.Stop()
+	}
+
+	openMutex.Lock()
+	defer openMutex.Unlock()
+	if !open {
+		return
+	}
+	ledgermgmt.Close()
+	if h.removeDir {
+		os.RemoveAll(h.dir)
+	}
+	open = false
+}
+
+// Ledger returns the ledger of the channel
+func (h *Harness) Ledger() ledger.PeerLedger {
+	return h.ledger
+}
+
+// GetLedger returns the ledger of the channel with the given ID, which is
+// nil for every channel but the one of the harness
+func (h *Harness) GetLedger(cid string) ledger.PeerLedger {
+	if cid != h.channelID {
+		return nil
+	}
+	return h.ledger
+}
+
+// Deploy starts the chaincode and commits its definition, together with the
+// writes of its Init, which is invoked with the given arguments. A response
+// of Init with an error fails the deployment, and stops the chaincode.
+//
+// The definition is written as lscc does, but it isn't validated by lscc,
+// which doesn't run in the harness.
+func (h *Harness) Deploy(cc *Chaincode, args ...[]byte) (*Transaction, error) {
+	if cc.Name == "" || cc.Version == "" || cc.Chaincode == nil {
+		return nil, errors.New("the name, the version and the implementation of the chaincode must be set")
+	}
+
+	policy := cc.EndorsementPolicy
+	if policy == nil {
+		policy = cauthdsl.SignedByAnyMember(h.mspIDs)
+	}
+	policyBytes, err := proto.Marshal(policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the endorsement policy")
+	}
+	cdBytes, err := proto.Marshal(&ccprovider.ChaincodeData{
+		Name:    cc.Name,
+		Version: cc.Version,
+		Escc:    "escc",
+		Vscc:    "vscc",
+		Policy:  policyBytes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the chaincode definition")
+	}
+	var collectionsBytes []byte
+	if cc.Collections != nil {
+		if collectionsBytes, err = proto.Marshal(cc.Collections); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal the collections")
+		}
+	}
+
+	if err := h.launch(cc.Name, cc.Version, cc.Chaincode); err != nil {
+		return nil, err
+	}
+
+	tx, err := h.endorse(pb.ChaincodeMessage_INIT, &Proposal{Chaincode: cc.Name, Args: args}, func(sim ledger.TxSimulator) error {
+		if err := sim.SetState("lscc", cc.Name, cdBytes); err != nil {
+			return err
+		}
+		if collectionsBytes == nil {
+			return nil
+		}
+		return sim.SetState("lscc", privdata.BuildCollectionKVSKey(cc.Name), collectionsBytes)
+	})
+	if err == nil && tx.Envelope == nil {
+		err = errors.Errorf("Init responded with an error: %s", tx.Response.Message)
+	}
+	if err == nil {
+		err = h.commit([]*Transaction{tx}, false)
+	}
+	if err == nil && tx.ValidationCode != pb.TxValidationCode_VALID {
+		err = errors.Errorf("the transaction is invalid: %s", tx.ValidationCode)
+	}
+	if err != nil {
+		h.stop(cc.Name)
+		return tx, errors.WithMessage(err, fmt.Sprintf("failed to deploy chaincode %s", cc.Name))
+	}
+	return tx, nil
+}
+
+// Endorse simulates the proposal, and returns the transaction endorsed by the
+// peers of the endorsing organizations, which isn't committed yet
+func (h *Harness) Endorse(p *Proposal) (*Transaction, error) {
+	return h.endorse(pb.ChaincodeMessage_TRANSACTION, p, nil)
+}
+
+// endorse simulates the proposal as a message of the given type. The setup
+// function, when set, writes to the simulator before the chaincode runs.
+func (h *Harness) endorse(typ pb.ChaincodeMessage_Type, p *Proposal, setup func(ledger.TxSimulator) error) (*Transaction, error) {
+	creatorMSPID := p.Creator
+	if creatorMSPID == "" {
+		creatorMSPID = h.mspIDs[0]
+	}
+	creatorOrg, ok := h.orgs[creatorMSPID]
+	if !ok {
+		return nil, errors.Errorf("organization %s is unknown", creatorMSPID)
+	}
+	creator := creatorOrg.client
+	serializedCreator, err := creator.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize the creator")
+	}
+	endorserMSPIDs := p.Endorsers
+	if len(endorserMSPIDs) == 0 {
+		endorserMSPIDs = h.mspIDs
+	}
+	var endorsers []msp.SigningIdentity
+	for _, mspID := range endorserMSPIDs {
+		o, ok := h.orgs[mspID]
+		if !ok {
+			return nil, errors.Errorf("organization %s is unknown", mspID)
+		}
+		endorsers = append(endorsers, o.peer)
+	}
+
+	h.mutex.Lock()
+	rc := h.chaincodes[p.Chaincode]
+	h.mutex.Unlock()
+	if rc == nil {
+		return nil, errors.Errorf("chaincode %s isn't deployed", p.Chaincode)
+	}
+	cccid := &ccprovider.CCContext{Name: p.Chaincode, Version: rc.version}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: p.Chaincode},
+			Input:       &pb.ChaincodeInput{Args: p.Args},
+		},
+	}
+	prop, txID, err := utils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, h.channelID, cis, serializedCreator, p.Transient)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the proposal")
+	}
+	signedProp, err := utils.GetSignedProposal(prop, creator)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign the proposal")
+	}
+
+	sim, err := h.ledger.NewTxSimulator(txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the simulator")
+	}
+	defer sim.Done()
+	historyQueryExecutor, err := h.ledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the history query executor")
+	}
+	if setup != nil {
+		if err := setup(sim); err != nil {
+			return nil, errors.WithMessage(err, "failed to set up the simulator")
+		}
+	}
+
+	txParams := &ccprovider.TransactionParams{
+		TxID:                 txID,
+		ChannelID:            h.channelID,
+		SignedProp:           signedProp,
+		Proposal:             prop,
+		TXSimulator:          sim,
+		HistoryQueryExecutor: historyQueryExecutor,
+	}
+	resp, err := h.execute(typ, txParams, cccid, cis.ChaincodeSpec.Input)
+	res, events, err := executionResult(txID, p.Chaincode, resp, err)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		TxID:           txID,
+		Response:       res,
+		Events:         events,
+		ValidationCode: pb.TxValidationCode_NOT_VALIDATED,
+	}
+	if res.Status >= shim.ERRORTHRESHOLD {
+		return tx, nil
+	}
+
+	if tx.Results, err = sim.GetTxSimulationResults(); err != nil {
+		return nil, errors.WithMessage(err, "failed to get the simulation results")
+	}
+	if tx.Envelope, err = h.endorsedTx(prop, creator, endorsers, cccid, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// executionResult returns the response and the events of the chaincode, as the
+// chaincode support does
+func executionResult(txID, ccName string, resp *pb.ChaincodeMessage, err error) (*pb.Response, []*pb.ChaincodeEvent, error) {
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to execute transaction "+txID)
+	}
+
+	events := resp.ChaincodeEvents
+	if len(events) == 0 && resp.ChaincodeEvent != nil {
+		events = []*pb.ChaincodeEvent{resp.ChaincodeEvent}
+	}
+	for _, event := range events {
+		event.ChaincodeId = ccName
+		event.TxId = txID
+	}
+
+	switch resp.Type {
+	case pb.ChaincodeMessage_COMPLETED:
+		res := &pb.Response{}
+		if err := proto.Unmarshal(resp.Payload, res); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal response for transaction %s", txID)
+		}
+		return res, events, nil
+	case pb.ChaincodeMessage_ERROR:
+		return nil, nil, errors.Errorf("transaction returned with failure: %s", resp.Payload)
+	default:
+		return nil, nil, errors.Errorf("unexpected response type %d for transaction %s", resp.Type, txID)
+	}
+}
+
+// endorsedTx signs the response of the chaincode with the identities of the
+// endorsers, and returns the transaction signed by the creator
+func (h *Harness) endorsedTx(prop *pb.Proposal, creator msp.SigningIdentity, endorsers []msp.SigningIdentity, cccid *ccprovider.CCContext, tx *Transaction) (*common.Envelope, error) {
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	proposalHash, err := utils.GetProposalHash1(hdr, prop.Payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	results, err := tx.Results.GetPubSimulationBytes()
+	if err != nil {
+		return nil, err
+	}
+	var event []byte
+	if len(tx.Events) > 0 {
+		if event, err = utils.GetBytesChaincodeEvent(tx.Events[0]); err != nil {
+			return nil, err
+		}
+	}
+	var events []*pb.ChaincodeEvent
+	if len(tx.Events) > 1 {
+		events = tx.Events
+	}
+	ccid := &pb.ChaincodeID{Name: cccid.Name, Version: cccid.Version}
+	payload, err := utils.GetBytesProposalResponsePayloadWithEvents(proposalHash, tx.Response, results, event, events, ccid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resps []*pb.ProposalResponse
+	for _, endorser := range endorsers {
+		serializedEndorser, err := endorser.Serialize()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to serialize the endorser")
+		}
+		signature, err := endorser.Sign(append(append([]byte{}, payload...), serializedEndorser...))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to sign the proposal response")
+		}
+		resps = append(resps, &pb.ProposalResponse{
+			Version:     1,
+			Endorsement: &pb.Endorsement{Endorser: serializedEndorser, Signature: signature},
+			Payload:     payload,
+			Response:    tx.Response,
+		})
+	}
+	return utils.CreateSignedTx(prop, creator, resps...)
+}
+
+// Commit commits the transactions in a block, in order, and sets their
+// validation codes. The transactions are validated as a peer does.
+func (h *Harness) Commit(txs ...*Transaction) error {
+	return h.commit(txs, true)
+}
+
+// Invoke endorses the proposal and commits the transaction in a block of
+// its own, unless the chaincode responded with an error
+func (h *Harness) Invoke(p *Proposal) (*Transaction, error) {
+	tx, err := h.Endorse(p)
+	if err != nil || tx.Envelope == nil {
+		return tx, err
+	}
+	return tx, h.Commit(tx)
+}
+
+// commit commits the transactions in the next block. Unless they are to be
+// validated, all the transactions are committed as valid, but the ledger
+// still checks their reads.
+func (h *Harness) commit(txs []*Transaction, validate bool) error {
+	if len(txs) == 0 {
+		return errors.New("there are no transactions to commit")
+	}
+
+	h.commitMutex.Lock()
+	defer h.commitMutex.Unlock()
+
+	info, err := h.ledger.GetBlockchainInfo()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get the height of the ledger")
+	}
+	block := common.NewBlock(info.Height, info.CurrentBlockHash)
+	pvtData := ledger.TxPvtDataMap{}
+	for i, tx := range txs {
+		if tx.Envelope == nil {
+			return errors.Errorf("transaction %s isn't endorsed", tx.TxID)
+		}
+		env, err := proto.Marshal(tx.Envelope)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal transaction %s", tx.TxID)
+		}
+		block.Data.Data = append(block.Data.Data, env)
+		if tx.Results.PvtSimulationResults != nil {
+			pvtData[uint64(i)] = &ledger.TxPvtData{SeqInBlock: uint64(i), WriteSet: tx.Results.PvtSimulationResults}
+		}
+	}
+	block.Header.DataHash = block.Data.Hash()
+
+	if validate {
+		if err := h.validator.Validate(block); err != nil {
+			return errors.WithMessage(err, "failed to validate the block")
+		}
+	} else {
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutils.NewTxValidationFlagsSetValue(len(txs), pb.TxValidationCode_VALID)
+	}
+	if err := h.ledger.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block, PvtData: pvtData}, &ledger.CommitOptions{}); err != nil {
+		return errors.WithMessage(err, "failed to commit the block")
+	}
+
+	flags := lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i, tx := range txs {
+		tx.ValidationCode = flags.Flag(i)
+		tx.BlockNumber = block.Header.Number
+	}
+	return nil
+}
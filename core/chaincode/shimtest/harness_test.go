@@ -0,0 +1,368 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shimtest_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/chaincode/shim/ext/statebased"
+	"github.com/hyperledger/fabric/core/chaincode/shimtest"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleCC is a chaincode which exercises the features the harness provides
+type sampleCC struct{}
+
+func (sampleCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetStringArgs()
+	if len(args)%2 != 0 {
+		return shim.Error("odd number of arguments")
+	}
+	for i := 0; i < len(args); i += 2 {
+		if err := stub.PutState(args[i], []byte(args[i+1])); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	return shim.Success(nil)
+}
+
+func (sampleCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	switch function {
+	case "put":
+		if err := stub.PutState(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.SetEvent("put", []byte(args[0])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "get":
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	case "incr":
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		n, _ := strconv.Atoi(string(value))
+		if err := stub.PutState(args[0], []byte(strconv.Itoa(n+1))); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "history":
+		iter, err := stub.GetHistoryForKey(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer iter.Close()
+		var values []string
+		for iter.HasNext() {
+			mod, err := iter.Next()
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			values = append(values, string(mod.Value))
+		}
+		return shim.Success([]byte(strings.Join(values, ",")))
+	case "range":
+		pageSize, _ := strconv.Atoi(args[2])
+		iter, metadata, err := stub.GetStateByRangeWithPagination(args[0], args[1], int32(pageSize), args[3])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer iter.Close()
+		var keys []string
+		for iter.HasNext() {
+			kv, err := iter.Next()
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			keys = append(keys, kv.Key)
+		}
+		return shim.Success([]byte(strings.Join(keys, ",") + "|" + metadata.Bookmark))
+	case "query":
+		iter, err := stub.GetQueryResult(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		iter.Close()
+		return shim.Success(nil)
+	case "endorsedBy":
+		ep, err := statebased.NewStateEP(nil)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := ep.AddOrgs(statebased.RoleTypePeer, args[1:]...); err != nil {
+			return shim.Error(err.Error())
+		}
+		policy, err := ep.Policy()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.SetStateValidationParameter(args[0], policy); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "putPrivate":
+		transient, err := stub.GetTransient()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutPrivateData(args[0], args[1], transient["value"]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "getPrivate":
+		value, err := stub.GetPrivateData(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	default:
+		return shim.Error("unknown function " + function)
+	}
+}
+
+func args(args ...string) [][]byte {
+	var bytes [][]byte
+	for _, arg := range args {
+		bytes = append(bytes, []byte(arg))
+	}
+	return bytes
+}
+
+func newHarness(t *testing.T, orgs ...string) *shimtest.Harness {
+	h, err := shimtest.New(shimtest.Config{Orgs: orgs})
+	require.NoError(t, err)
+	return h
+}
+
+func deploy(t *testing.T, h *shimtest.Harness, cc *shimtest.Chaincode, initArgs ...string) {
+	if cc.Chaincode == nil {
+		cc.Chaincode = sampleCC{}
+	}
+	tx, err := h.Deploy(cc, args(initArgs...)...)
+	require.NoError(t, err)
+	require.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+}
+
+func invoke(t *testing.T, h *shimtest.Harness, p *shimtest.Proposal) *shimtest.Transaction {
+	if p.Chaincode == "" {
+		p.Chaincode = "sample"
+	}
+	tx, err := h.Invoke(p)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestInvoke(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"}, "a", "1")
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("get", "a")})
+	assert.Equal(t, int32(shim.OK), tx.Response.Status)
+	assert.Equal(t, []byte("1"), tx.Response.Payload)
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("put", "b", "2")})
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	assert.Equal(t, uint64(3), tx.BlockNumber)
+	require.Len(t, tx.Events, 1)
+	assert.Equal(t, "sample", tx.Events[0].ChaincodeId)
+	assert.Equal(t, tx.TxID, tx.Events[0].TxId)
+
+	qe, err := h.Ledger().NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	value, err := qe.GetState("sample", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), value)
+}
+
+func TestErrorResponse(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"})
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("unknown")})
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	assert.Equal(t, "unknown function unknown", tx.Response.Message)
+	assert.Nil(t, tx.Envelope)
+	assert.Equal(t, pb.TxValidationCode_NOT_VALIDATED, tx.ValidationCode)
+
+	err := h.Commit(tx)
+	assert.EqualError(t, err, "transaction "+tx.TxID+" isn't endorsed")
+
+	_, err = h.Invoke(&shimtest.Proposal{Chaincode: "missing"})
+	assert.EqualError(t, err, "chaincode missing isn't deployed")
+
+	failing := &shimtest.Chaincode{Name: "failing", Version: "1.0", Chaincode: sampleCC{}}
+	_, err = h.Deploy(failing, args("odd")...)
+	assert.EqualError(t, err, "failed to deploy chaincode failing: transaction returned with failure: odd number of arguments")
+	_, err = h.Deploy(failing)
+	assert.NoError(t, err)
+	_, err = h.Deploy(&shimtest.Chaincode{Name: "sample", Version: "1.0", Chaincode: sampleCC{}})
+	assert.EqualError(t, err, "chaincode sample is already running")
+}
+
+func TestMVCCConflict(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"}, "counter", "0")
+
+	tx1, err := h.Endorse(&shimtest.Proposal{Chaincode: "sample", Args: args("incr", "counter")})
+	require.NoError(t, err)
+	tx2, err := h.Endorse(&shimtest.Proposal{Chaincode: "sample", Args: args("incr", "counter")})
+	require.NoError(t, err)
+
+	err = h.Commit(tx1, tx2)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_VALID, tx1.ValidationCode)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, tx2.ValidationCode)
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("get", "counter")})
+	assert.Equal(t, []byte("1"), tx.Response.Payload)
+}
+
+func TestHistory(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"}, "a", "1")
+
+	invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "2")})
+	invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "3")})
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("history", "a")})
+	assert.Equal(t, "1,2,3", string(tx.Response.Payload))
+}
+
+func TestPagination(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"}, "k1", "1", "k2", "2", "k3", "3", "k4", "4", "k5", "5")
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("range", "k1", "k9", "2", "")})
+	assert.Equal(t, "k1,k2|k3", string(tx.Response.Payload))
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("range", "k1", "k9", "2", "k3")})
+	assert.Equal(t, "k3,k4|k5", string(tx.Response.Payload))
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("range", "k1", "k9", "2", "k5")})
+	assert.Equal(t, "k5|", string(tx.Response.Payload))
+}
+
+func TestRichQueryOnLevelDB(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"})
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("query", `{"selector":{}}`)})
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	assert.Contains(t, tx.Response.Message, "ExecuteQuery not supported for leveldb")
+}
+
+func TestKeyLevelEndorsement(t *testing.T) {
+	h := newHarness(t, "Org1MSP", "Org2MSP")
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"}, "a", "1")
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("endorsedBy", "a", "Org2MSP"), Endorsers: []string{"Org1MSP"}})
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "2"), Endorsers: []string{"Org1MSP"}})
+	assert.Equal(t, pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, tx.ValidationCode)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "2"), Endorsers: []string{"Org2MSP"}})
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+}
+
+func TestEndorsementPolicy(t *testing.T) {
+	h := newHarness(t, "Org1MSP", "Org2MSP")
+	defer h.Close()
+	policy, err := cauthdsl.FromString("AND('Org1MSP.member', 'Org2MSP.member')")
+	require.NoError(t, err)
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0", EndorsementPolicy: policy})
+
+	tx := invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "1"), Endorsers: []string{"Org2MSP"}})
+	assert.Equal(t, pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, tx.ValidationCode)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("put", "a", "1")})
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+}
+
+func TestPrivateData(t *testing.T) {
+	h := newHarness(t, "Org1MSP", "Org2MSP")
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{
+		Name:    "sample",
+		Version: "1.0",
+		Collections: &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name: "org1",
+						MemberOrgsPolicy: &common.CollectionPolicyConfig{
+							Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+								SignaturePolicy: cauthdsl.SignedByAnyMember([]string{"Org1MSP"}),
+							},
+						},
+						MaximumPeerCount: 1,
+						MemberOnlyRead:   true,
+					},
+				},
+			}},
+		},
+	})
+
+	tx := invoke(t, h, &shimtest.Proposal{
+		Args:      args("putPrivate", "org1", "secret"),
+		Transient: map[string][]byte{"value": []byte("42")},
+	})
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	require.NotNil(t, tx.Results.PvtSimulationResults)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("getPrivate", "org1", "secret")})
+	assert.Equal(t, []byte("42"), tx.Response.Payload)
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("getPrivate", "org1", "secret"), Creator: "Org2MSP"})
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	assert.Contains(t, tx.Response.Message, "does not have read access")
+
+	tx = invoke(t, h, &shimtest.Proposal{Args: args("getPrivate", "missing", "secret")})
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+}
+
+func TestSingleHarness(t *testing.T) {
+	h := newHarness(t)
+	_, err := shimtest.New(shimtest.Config{})
+	assert.EqualError(t, err, "another harness is open")
+	h.Close()
+
+	h = newHarness(t)
+	h.Close()
+}
+
+func TestUnknownOrg(t *testing.T) {
+	h := newHarness(t)
+	defer h.Close()
+	deploy(t, h, &shimtest.Chaincode{Name: "sample", Version: "1.0"})
+
+	_, err := h.Invoke(&shimtest.Proposal{Chaincode: "sample", Creator: "Org2MSP"})
+	assert.EqualError(t, err, "organization Org2MSP is unknown")
+	_, err = h.Invoke(&shimtest.Proposal{Chaincode: "sample", Endorsers: []string{"Org2MSP"}})
+	assert.EqualError(t, err, "organization Org2MSP is unknown")
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shimtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	cryptogenmsp "github.com/hyperledger/fabric/common/tools/cryptogen/msp"
+	"github.com/hyperledger/fabric/msp"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// org is an organization of the channel, whose client and peer identities
+// are issued by its own CA and classified by the organizational units of
+// their certificates
+type org struct {
+	msp    msp.MSP
+	client msp.SigningIdentity
+	peer   msp.SigningIdentity
+}
+
+// newOrg generates the crypto material of an organization as cryptogen does,
+// and sets up the MSP of the organization from it
+func newOrg(mspID string) (*org, error) {
+	dir, err := ioutil.TempDir("", "shimtest-msp")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the directory of the crypto material")
+	}
+	defer os.RemoveAll(dir)
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), mspID, "ca."+mspID, "", "", "", "", "", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the CA")
+	}
+	tlsCA, err := ca.NewCA(filepath.Join(dir, "tlsca"), mspID, "tlsca."+mspID, "", "", "", "", "", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the TLS CA")
+	}
+
+	// the local MSPs of the client and of the peer have the same CA and node
+	// OUs, hence the one of the client serves as the MSP of the organization
+	o := &org{}
+	if o.msp, o.client, err = newLocalMSP(filepath.Join(dir, "client"), mspID, "client."+mspID, cryptogenmsp.CLIENT, signCA, tlsCA); err != nil {
+		return nil, err
+	}
+	if _, o.peer, err = newLocalMSP(filepath.Join(dir, "peer"), mspID, "peer."+mspID, cryptogenmsp.PEER, signCA, tlsCA); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// newLocalMSP generates an identity of the given node type, and returns the
+// local MSP set up from its directory along with its signing identity
+func newLocalMSP(dir, mspID, name string, nodeType int, signCA, tlsCA *ca.CA) (msp.MSP, msp.SigningIdentity, error) {
+	if err := cryptogenmsp.GenerateLocalMSP(dir, name, nil, signCA, tlsCA, nodeType, true); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to generate the identity %s", name)
+	}
+	mspDir := filepath.Join(dir, "msp")
+
+	// the BCCSP keystore is initialized once per process, hence it holds
+	// none of the generated keys, which are passed in the configuration
+	if err := factory.InitFactories(nil); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to initialize the BCCSP")
+	}
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, mspID, bccsp.SHA2, bccsp.SHA256)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to load the configuration of the MSP")
+	}
+	keys, err := ioutil.ReadDir(filepath.Join(mspDir, "keystore"))
+	if err != nil || len(keys) != 1 {
+		return nil, nil, errors.Errorf("failed to find the key of the identity %s", name)
+	}
+	keyPEM, err := ioutil.ReadFile(filepath.Join(mspDir, "keystore", keys[0].Name()))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read the key of the identity %s", name)
+	}
+	fabricConf := &mspproto.FabricMSPConfig{}
+	if err := proto.Unmarshal(conf.Config, fabricConf); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal the configuration of the MSP")
+	}
+	fabricConf.SigningIdentity.PrivateSigner = &mspproto.KeyInfo{KeyMaterial: keyPEM}
+	if conf.Config, err = proto.Marshal(fabricConf); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal the configuration of the MSP")
+	}
+
+	localMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_3}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := localMSP.Setup(conf); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to set up the MSP")
+	}
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, nil, err
+	}
+	return localMSP, signer, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shimtest
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/semaphore"
+)
+
+// systemChaincodes are the names of the system chaincodes of a peer, which
+// don't run in the harness
+var systemChaincodes = map[string]bool{
+	"cscc": true,
+	"escc": true,
+	"lscc": true,
+	"qscc": true,
+	"vscc": true,
+}
+
+// allowAll is an ACL provider which grants every access
+type allowAll struct{}
+
+func (allowAll) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	return nil
+}
+
+// alwaysMember is a membership provider which makes the peer of the harness
+// a member of every collection, so that it stores all the private data
+type alwaysMember struct{}
+
+func (alwaysMember) AmMemberOf(channelName string, collectionPolicyConfig *common.CollectionPolicyConfig) (bool, error) {
+	return true, nil
+}
+
+// sysCCProvider provides the system chaincode metadata and the channel
+// configuration to the handlers of the chaincodes and to the validator
+type sysCCProvider struct {
+	harness *Harness
+}
+
+func (p *sysCCProvider) IsSysCC(name string) bool {
+	return systemChaincodes[name]
+}
+
+func (p *sysCCProvider) IsSysCCAndNotInvokableCC2CC(name string) bool {
+	return systemChaincodes[name]
+}
+
+func (p *sysCCProvider) IsSysCCAndNotInvokableExternal(name string) bool {
+	return systemChaincodes[name]
+}
+
+func (p *sysCCProvider) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
+	l := p.harness.GetLedger(cid)
+	if l == nil {
+		return nil, errors.Errorf("channel %s doesn't exist", cid)
+	}
+	return l.NewQueryExecutor()
+}
+
+func (p *sysCCProvider) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
+	if cid != p.harness.channelID {
+		return nil, false
+	}
+	return p.harness.appConfig, true
+}
+
+func (p *sysCCProvider) PolicyManager(channelID string) (policies.Manager, bool) {
+	return nil, false
+}
+
+// validatorSupport provides the channel resources to the validator
+type validatorSupport struct {
+	*semaphore.Weighted
+	harness *Harness
+}
+
+func (s *validatorSupport) Ledger() ledger.PeerLedger {
+	return s.harness.ledger
+}
+
+func (s *validatorSupport) MSPManager() msp.MSPManager {
+	return s.harness.mspManager
+}
+
+func (s *validatorSupport) Apply(configtx *common.ConfigEnvelope) error {
+	return errors.New("config transactions aren't supported")
+}
+
+func (s *validatorSupport) GetMSPIDs(cid string) []string {
+	return s.harness.mspIDs
+}
+
+func (s *validatorSupport) Capabilities() channelconfig.ApplicationCapabilities {
+	return s.harness.appConfig.Capabilities()
+}
@@ -15,6 +15,17 @@ import (
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
+// ResourceUsage counts the resources used by a chaincode during a transaction.
+// The bytes are the ones of the values read or written, including the ones of
+// the query results.
+type ResourceUsage struct {
+	StateReads   int
+	StateWrites  int
+	BytesRead    int
+	BytesWritten int
+	QueryResults int
+}
+
 type TransactionContext struct {
 	ChainID              string
 	SignedProp           *pb.SignedProposal
@@ -31,6 +42,10 @@ type TransactionContext struct {
 	pendingQueryResults map[string]*PendingQueryResult
 	totalReturnCount    map[string]*int32
 
+	// counts the resources used by the chaincode during the transaction
+	usageMutex sync.Mutex
+	usage      ResourceUsage
+
 	// cache used to save the result of collection acl
 	// as a transactionContext is created for every chaincode
 	// invoke (even in case of chaincode-calling-chaincode,
@@ -113,3 +128,40 @@ func (t *TransactionContext) CloseQueryIterators() {
 		iter.Close()
 	}
 }
+
+// RecordStateReads records the reads of the given values
+func (t *TransactionContext) RecordStateReads(values ...[]byte) {
+	t.usageMutex.Lock()
+	defer t.usageMutex.Unlock()
+	for _, v := range values {
+		t.usage.StateReads++
+		t.usage.BytesRead += len(v)
+	}
+}
+
+// RecordStateWrites records the writes of the given values, which are nil for deletes
+func (t *TransactionContext) RecordStateWrites(values ...[]byte) {
+	t.usageMutex.Lock()
+	defer t.usageMutex.Unlock()
+	for _, v := range values {
+		t.usage.StateWrites++
+		t.usage.BytesWritten += len(v)
+	}
+}
+
+// RecordQueryResults records the results returned to the chaincode by a query
+func (t *TransactionContext) RecordQueryResults(results []*pb.QueryResultBytes) {
+	t.usageMutex.Lock()
+	defer t.usageMutex.Unlock()
+	for _, r := range results {
+		t.usage.QueryResults++
+		t.usage.BytesRead += len(r.ResultBytes)
+	}
+}
+
+// ResourceUsage returns the resources used by the chaincode so far
+func (t *TransactionContext) ResourceUsage() ResourceUsage {
+	t.usageMutex.Lock()
+	defer t.usageMutex.Unlock()
+	return t.usage
+}
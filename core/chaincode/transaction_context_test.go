@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -166,4 +167,28 @@ var _ = Describe("TransactionContext", func() {
 			}
 		})
 	})
+
+	Describe("ResourceUsage", func() {
+		It("is empty before any access to the state", func() {
+			Expect(transactionContext.ResourceUsage()).To(Equal(chaincode.ResourceUsage{}))
+		})
+
+		It("accumulates the reads, writes and query results", func() {
+			transactionContext.RecordStateReads([]byte("value-1"), nil)
+			transactionContext.RecordStateReads([]byte("v2"))
+			transactionContext.RecordStateWrites([]byte("value"), nil)
+			transactionContext.RecordQueryResults([]*pb.QueryResultBytes{
+				{ResultBytes: []byte("result-1")},
+				{ResultBytes: []byte("result-2")},
+			})
+
+			Expect(transactionContext.ResourceUsage()).To(Equal(chaincode.ResourceUsage{
+				StateReads:   3,
+				StateWrites:  2,
+				BytesRead:    25,
+				BytesWritten: 5,
+				QueryResults: 2,
+			}))
+		})
+	})
 })
@@ -112,6 +112,13 @@ func (c *TransactionContexts) Delete(chainID, txID string) {
 	c.mutex.Unlock()
 }
 
+// Len returns the number of active transaction contexts.
+func (c *TransactionContexts) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.contexts)
+}
+
 // Close closes all query iterators assocated with the context.
 func (c *TransactionContexts) Close() {
 	c.mutex.Lock()
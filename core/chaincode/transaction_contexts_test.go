@@ -140,6 +140,21 @@ var _ = Describe("TransactionContexts", func() {
 		})
 	})
 
+	Describe("Len", func() {
+		It("counts the transaction contexts", func() {
+			Expect(txContexts.Len()).To(Equal(0))
+
+			_, err := txContexts.Create(&ccprovider.TransactionParams{ChannelID: "chainID", TxID: "transactionID1"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = txContexts.Create(&ccprovider.TransactionParams{ChannelID: "chainID", TxID: "transactionID2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txContexts.Len()).To(Equal(2))
+
+			txContexts.Delete("chainID", "transactionID1")
+			Expect(txContexts.Len()).To(Equal(1))
+		})
+	})
+
 	Describe("Close", func() {
 		var fakeIterators []*mock.QueryResultsIterator
 
@@ -0,0 +1,250 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const defaultDrainPollInterval = 100 * time.Millisecond
+
+// ContainerInfoProvider provides the information to launch a version of a
+// chaincode installed on the peer.
+type ContainerInfoProvider interface {
+	ChaincodeContainerInfo(ccdef *cceventmgmt.ChaincodeDefinition) (*ccprovider.ChaincodeContainerInfo, error)
+}
+
+// ContainerInfoFunc is an adapter that allows regular functions to be used as
+// a ContainerInfoProvider.
+type ContainerInfoFunc func(ccdef *cceventmgmt.ChaincodeDefinition) (*ccprovider.ChaincodeContainerInfo, error)
+
+// ChaincodeContainerInfo provides the information to launch a chaincode.
+func (c ContainerInfoFunc) ChaincodeContainerInfo(ccdef *cceventmgmt.ChaincodeDefinition) (*ccprovider.ChaincodeContainerInfo, error) {
+	return c(ccdef)
+}
+
+// InstalledContainerInfo reads the information to launch a chaincode from its
+// package on the file system. When the definition has a hash, the package must
+// match it.
+func InstalledContainerInfo(ccdef *cceventmgmt.ChaincodeDefinition) (*ccprovider.ChaincodeContainerInfo, error) {
+	ccpack, err := ccprovider.GetChaincodeFromFS(ccdef.Name, ccdef.Version)
+	if err != nil {
+		return nil, err
+	}
+	if len(ccdef.Hash) != 0 && !bytes.Equal(ccpack.GetId(), ccdef.Hash) {
+		return nil, errors.Errorf("chaincode fingerprint mismatch for %s:%s", ccdef.Name, ccdef.Version)
+	}
+	return ccprovider.DeploymentSpecToChaincodeContainerInfo(ccpack.GetDepSpec()), nil
+}
+
+// ChannelLedgers provides the ledgers of the channels joined by the peer.
+type ChannelLedgers interface {
+	GetChannelsInfo() []*pb.ChannelInfo
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// Upgrader replaces the running versions of upgraded chaincodes without
+// failing their transactions in flight. The version of an upgrade is launched
+// while the upgrade is being committed, and invocations switch to it once the
+// upgrade is committed since they launch the version defined on the channel.
+// The previous versions are stopped when their transactions are done, or when
+// the grace period expires.
+type Upgrader struct {
+	Launcher         Launcher
+	Runtime          Runtime
+	Registry         *HandlerRegistry
+	ContainerInfo    ContainerInfoProvider
+	DefinitionGetter ChaincodeDefinitionGetter
+	Channels         ChannelLedgers
+	GracePeriod      time.Duration
+	PollInterval     time.Duration
+	Metrics          *UpgradeMetrics
+
+	mutex    sync.Mutex
+	draining map[string]bool
+}
+
+// ChannelListener returns the listener of the chaincode lifecycle events of a
+// channel which drives the upgrades of the chaincodes of the channel.
+func (u *Upgrader) ChannelListener(channelID string) cceventmgmt.ChaincodeLifecycleEventListener {
+	return &upgradeListener{upgrader: u, channelID: channelID}
+}
+
+// Prepare launches the version of a chaincode definition in the background,
+// if a different version of the chaincode is running, so that the version is
+// ready when the definition is committed.
+func (u *Upgrader) Prepare(ccdef *cceventmgmt.ChaincodeDefinition) {
+	cname := ccdef.Name + ":" + ccdef.Version
+	if u.Registry.Handler(cname) != nil || len(u.Registry.Versions(ccdef.Name)) == 0 {
+		return
+	}
+
+	ccci, err := u.ContainerInfo.ChaincodeContainerInfo(ccdef)
+	if err != nil {
+		chaincodeLogger.Warningf("cannot launch chaincode %s ahead of its upgrade: %s", cname, err)
+		return
+	}
+
+	go func() {
+		chaincodeLogger.Infof("launching chaincode %s ahead of its upgrade", cname)
+		if err := u.Launcher.Launch(ccci); err != nil {
+			chaincodeLogger.Warningf("failed to launch chaincode %s ahead of its upgrade: %s", cname, err)
+		}
+	}()
+}
+
+// Drain stops the running versions of a chaincode which are no longer defined
+// on any channel, once their transactions in flight are done or the grace
+// period has expired. It doesn't wait for them to be stopped.
+func (u *Upgrader) Drain(chaincodeName string) {
+	for _, version := range u.Registry.Versions(chaincodeName) {
+		if u.inUse(chaincodeName, version) {
+			continue
+		}
+
+		cname := chaincodeName + ":" + version
+		u.mutex.Lock()
+		if u.draining == nil {
+			u.draining = map[string]bool{}
+		}
+		if u.draining[cname] {
+			u.mutex.Unlock()
+			continue
+		}
+		u.draining[cname] = true
+		u.mutex.Unlock()
+
+		go u.drain(chaincodeName, version)
+	}
+}
+
+// inUse returns whether the version of a chaincode is the one defined on any
+// of the channels.
+func (u *Upgrader) inUse(chaincodeName, version string) bool {
+	for _, ci := range u.Channels.GetChannelsInfo() {
+		l := u.Channels.GetLedger(ci.ChannelId)
+		if l == nil {
+			continue
+		}
+		qe, err := l.NewQueryExecutor()
+		if err != nil {
+			chaincodeLogger.Warningf("[channel %s] cannot check the definition of chaincode %s: %s", ci.ChannelId, chaincodeName, err)
+			return true
+		}
+		ccdef, err := u.DefinitionGetter.ChaincodeDefinition(chaincodeName, qe)
+		qe.Done()
+		if err != nil {
+			// the chaincode isn't defined on the channel
+			continue
+		}
+		if ccdef.CCVersion() == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *Upgrader) drain(chaincodeName, version string) {
+	cname := chaincodeName + ":" + version
+	defer func() {
+		u.mutex.Lock()
+		delete(u.draining, cname)
+		u.mutex.Unlock()
+	}()
+
+	pollInterval := u.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultDrainPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(u.GracePeriod)
+	defer timer.Stop()
+
+	chaincodeLogger.Infof("draining the transactions of chaincode %s", cname)
+	startTime := time.Now()
+	drained := false
+poll:
+	for {
+		h := u.Registry.Handler(cname)
+		if h == nil {
+			// the chaincode has stopped by itself
+			return
+		}
+		if h.TXContexts.Len() == 0 {
+			drained = true
+			break
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			chaincodeLogger.Warningf("grace period of chaincode %s expired with %d transactions in flight", cname, h.TXContexts.Len())
+			u.Metrics.DrainTimeouts.With("chaincode", cname).Add(1)
+			break poll
+		}
+	}
+
+	u.Metrics.DrainDuration.With(
+		"chaincode", cname,
+		"success", strconv.FormatBool(drained),
+	).Observe(time.Since(startTime).Seconds())
+
+	ccci, err := u.ContainerInfo.ChaincodeContainerInfo(&cceventmgmt.ChaincodeDefinition{Name: chaincodeName, Version: version})
+	if err != nil {
+		chaincodeLogger.Warningf("cannot stop chaincode %s: %s", cname, err)
+		return
+	}
+	if err := u.Runtime.Stop(ccci); err != nil {
+		chaincodeLogger.Warningf("failed to stop chaincode %s: %s", cname, err)
+		return
+	}
+	chaincodeLogger.Infof("stopped chaincode %s replaced by an upgrade", cname)
+}
+
+// upgradeListener prepares the versions of the chaincodes deployed on a
+// channel, and drains the previous versions once the deployment is committed.
+type upgradeListener struct {
+	upgrader  *Upgrader
+	channelID string
+
+	mutex   sync.Mutex
+	pending []*cceventmgmt.ChaincodeDefinition
+}
+
+func (l *upgradeListener) HandleChaincodeDeploy(ccdef *cceventmgmt.ChaincodeDefinition, dbArtifactsTar []byte) error {
+	chaincodeLogger.Debugf("[channel %s] chaincode %s:%s is being deployed", l.channelID, ccdef.Name, ccdef.Version)
+	l.upgrader.Prepare(ccdef)
+
+	l.mutex.Lock()
+	l.pending = append(l.pending, ccdef)
+	l.mutex.Unlock()
+	return nil
+}
+
+func (l *upgradeListener) ChaincodeDeployDone(succeeded bool) {
+	l.mutex.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mutex.Unlock()
+
+	if !succeeded {
+		return
+	}
+	for _, ccdef := range pending {
+		l.upgrader.Drain(ccdef.Name)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/fake"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Upgrader", func() {
+	var (
+		fakeLauncher         *mock.Launcher
+		fakeRuntime          *mock.Runtime
+		fakeContainerInfo    *mock.ContainerInfoProvider
+		fakeDefinitionGetter *mock.ChaincodeDefinitionGetter
+		fakeChannels         *mock.ChannelLedgers
+		fakeLedger           *mock.PeerLedger
+		fakeQueryExecutor    *mock.TxSimulator
+		fakeDrainDuration    *metricsfakes.Histogram
+		fakeDrainTimeouts    *metricsfakes.Counter
+		registry             *chaincode.HandlerRegistry
+		oldContexts          *fake.ContextRegistry
+
+		upgrader *chaincode.Upgrader
+		listener cceventmgmt.ChaincodeLifecycleEventListener
+		ccdef    *cceventmgmt.ChaincodeDefinition
+	)
+
+	register := func(cname string, contexts chaincode.ContextRegistry) {
+		h := &chaincode.Handler{TXContexts: contexts}
+		chaincode.SetHandlerChaincodeID(h, &pb.ChaincodeID{Name: cname})
+		err := registry.Register(h)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		registry = chaincode.NewHandlerRegistry(true)
+		oldContexts = &fake.ContextRegistry{}
+		register("chaincode-name:1.0", oldContexts)
+
+		fakeLauncher = &mock.Launcher{}
+		fakeLauncher.LaunchStub = func(ccci *ccprovider.ChaincodeContainerInfo) error {
+			register(ccci.Name+":"+ccci.Version, &fake.ContextRegistry{})
+			return nil
+		}
+		fakeRuntime = &mock.Runtime{}
+		fakeRuntime.StopStub = func(ccci *ccprovider.ChaincodeContainerInfo) error {
+			return registry.Deregister(ccci.Name + ":" + ccci.Version)
+		}
+		fakeContainerInfo = &mock.ContainerInfoProvider{}
+		fakeContainerInfo.ChaincodeContainerInfoStub = func(ccdef *cceventmgmt.ChaincodeDefinition) (*ccprovider.ChaincodeContainerInfo, error) {
+			return &ccprovider.ChaincodeContainerInfo{Name: ccdef.Name, Version: ccdef.Version, ContainerType: "DOCKER"}, nil
+		}
+
+		fakeQueryExecutor = &mock.TxSimulator{}
+		fakeLedger = &mock.PeerLedger{}
+		fakeLedger.NewQueryExecutorReturns(fakeQueryExecutor, nil)
+		fakeChannels = &mock.ChannelLedgers{}
+		fakeChannels.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "channel-id"}})
+		fakeChannels.GetLedgerReturns(fakeLedger)
+		fakeDefinitionGetter = &mock.ChaincodeDefinitionGetter{}
+		fakeDefinitionGetter.ChaincodeDefinitionReturns(&ccprovider.ChaincodeData{Name: "chaincode-name", Version: "2.0"}, nil)
+
+		fakeDrainDuration = &metricsfakes.Histogram{}
+		fakeDrainDuration.WithReturns(fakeDrainDuration)
+		fakeDrainTimeouts = &metricsfakes.Counter{}
+		fakeDrainTimeouts.WithReturns(fakeDrainTimeouts)
+
+		upgrader = &chaincode.Upgrader{
+			Launcher:         fakeLauncher,
+			Runtime:          fakeRuntime,
+			Registry:         registry,
+			ContainerInfo:    fakeContainerInfo,
+			DefinitionGetter: fakeDefinitionGetter,
+			Channels:         fakeChannels,
+			GracePeriod:      time.Minute,
+			PollInterval:     10 * time.Millisecond,
+			Metrics: &chaincode.UpgradeMetrics{
+				DrainDuration: fakeDrainDuration,
+				DrainTimeouts: fakeDrainTimeouts,
+			},
+		}
+		listener = upgrader.ChannelListener("channel-id")
+		ccdef = &cceventmgmt.ChaincodeDefinition{Name: "chaincode-name", Version: "2.0", Hash: []byte("hash")}
+	})
+
+	It("launches the new version before the upgrade is committed", func() {
+		err := listener.HandleChaincodeDeploy(ccdef, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(fakeLauncher.LaunchCallCount).Should(Equal(1))
+		Expect(fakeContainerInfo.ChaincodeContainerInfoArgsForCall(0)).To(Equal(ccdef))
+		ccci := fakeLauncher.LaunchArgsForCall(0)
+		Expect(ccci.Name).To(Equal("chaincode-name"))
+		Expect(ccci.Version).To(Equal("2.0"))
+		Eventually(func() []string { return registry.Versions("chaincode-name") }).Should(Equal([]string{"1.0", "2.0"}))
+		Expect(fakeRuntime.StopCallCount()).To(Equal(0))
+	})
+
+	It("stops the old version once the upgrade is committed and its transactions are done", func() {
+		oldContexts.LenReturnsOnCall(0, 2)
+		oldContexts.LenReturnsOnCall(1, 1)
+		oldContexts.LenReturnsOnCall(2, 0)
+
+		err := listener.HandleChaincodeDeploy(ccdef, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(fakeLauncher.LaunchCallCount).Should(Equal(1))
+		listener.ChaincodeDeployDone(true)
+
+		Eventually(fakeRuntime.StopCallCount).Should(Equal(1))
+		Expect(oldContexts.LenCallCount()).To(Equal(3))
+		ccci := fakeRuntime.StopArgsForCall(0)
+		Expect(ccci.Name).To(Equal("chaincode-name"))
+		Expect(ccci.Version).To(Equal("1.0"))
+		Expect(ccci.ContainerType).To(Equal("DOCKER"))
+		Expect(registry.Versions("chaincode-name")).To(Equal([]string{"2.0"}))
+
+		Expect(fakeDrainDuration.WithCallCount()).To(Equal(1))
+		Expect(fakeDrainDuration.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:1.0", "success", "true"}))
+		Expect(fakeDrainDuration.ObserveCallCount()).To(Equal(1))
+		Expect(fakeDrainTimeouts.AddCallCount()).To(Equal(0))
+	})
+
+	Context("when the transactions of the old version outlast the grace period", func() {
+		BeforeEach(func() {
+			oldContexts.LenReturns(1)
+			upgrader.GracePeriod = 50 * time.Millisecond
+		})
+
+		It("stops the old version anyway", func() {
+			err := listener.HandleChaincodeDeploy(ccdef, nil)
+			Expect(err).NotTo(HaveOccurred())
+			listener.ChaincodeDeployDone(true)
+
+			Eventually(fakeRuntime.StopCallCount).Should(Equal(1))
+			Expect(fakeDrainTimeouts.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:1.0"}))
+			Expect(fakeDrainTimeouts.AddArgsForCall(0)).To(Equal(1.0))
+			Expect(fakeDrainDuration.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:1.0", "success", "false"}))
+		})
+	})
+
+	Context("when the deployment fails", func() {
+		It("keeps the old version running", func() {
+			err := listener.HandleChaincodeDeploy(ccdef, nil)
+			Expect(err).NotTo(HaveOccurred())
+			listener.ChaincodeDeployDone(false)
+
+			Consistently(fakeRuntime.StopCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("when another channel still defines the old version", func() {
+		BeforeEach(func() {
+			fakeChannels.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "channel-id"}, {ChannelId: "other-channel-id"}})
+			fakeDefinitionGetter.ChaincodeDefinitionReturnsOnCall(1, &ccprovider.ChaincodeData{Name: "chaincode-name", Version: "1.0"}, nil)
+		})
+
+		It("keeps the old version running", func() {
+			err := listener.HandleChaincodeDeploy(ccdef, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(fakeLauncher.LaunchCallCount).Should(Equal(1))
+			listener.ChaincodeDeployDone(true)
+
+			Consistently(fakeRuntime.StopCallCount).Should(Equal(0))
+			Expect(fakeLedger.NewQueryExecutorCallCount()).To(Equal(3))
+			Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("when no other version of the chaincode is running", func() {
+		BeforeEach(func() {
+			registry.Deregister("chaincode-name:1.0")
+		})
+
+		It("leaves the launch to the first invocation", func() {
+			err := listener.HandleChaincodeDeploy(ccdef, nil)
+			Expect(err).NotTo(HaveOccurred())
+			listener.ChaincodeDeployDone(true)
+
+			Consistently(fakeLauncher.LaunchCallCount).Should(Equal(0))
+			Expect(fakeRuntime.StopCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the new version isn't installed", func() {
+		BeforeEach(func() {
+			fakeContainerInfo.ChaincodeContainerInfoStub = nil
+			fakeContainerInfo.ChaincodeContainerInfoReturns(nil, errors.New("not installed"))
+		})
+
+		It("doesn't launch it", func() {
+			err := listener.HandleChaincodeDeploy(ccdef, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Consistently(fakeLauncher.LaunchCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("when the old version is drained twice", func() {
+		BeforeEach(func() {
+			oldContexts.LenReturns(1)
+		})
+
+		It("drains it once", func() {
+			upgrader.Drain("chaincode-name")
+			upgrader.Drain("chaincode-name")
+
+			Eventually(oldContexts.LenCallCount).Should(BeNumerically(">", 1))
+			Expect(fakeDefinitionGetter.ChaincodeDefinitionCallCount()).To(Equal(2))
+			Consistently(fakeDrainDuration.WithCallCount).Should(Equal(0))
+		})
+	})
+})
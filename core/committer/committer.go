@@ -61,6 +61,10 @@ type Committer interface {
 	// committed and instead the mismatch inforation is returned back
 	CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)
 
+	// RepairPvtDataOfOldBlocks replaces the stored private data of already committed blocks which doesn't
+	// match the corresponding hash present in the block, and commits the private data which isn't stored
+	RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)
+
 	// GetMissingPvtDataTracker return the MissingPvtDataTracker
 	GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, error)
 
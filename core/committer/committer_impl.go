@@ -32,6 +32,8 @@ type PeerLedgerSupport interface {
 
 	CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)
 
+	RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error)
+
 	GetBlockchainInfo() (*common.BlockchainInfo, error)
 
 	DoesPvtDataInfoExist(blockNum uint64) (bool, error)
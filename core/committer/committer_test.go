@@ -122,6 +122,10 @@ func (m *mockLedger) CommitPvtDataOfOldBlocks(blockPvtData []*ledger2.BlockPvtDa
 	panic("implement me")
 }
 
+func (m *mockLedger) RepairPvtDataOfOldBlocks(blockPvtData []*ledger2.BlockPvtData) ([]*ledger2.PvtdataHashMismatch, error) {
+	panic("implement me")
+}
+
 func (m *mockLedger) GetMissingPvtDataTracker() (ledger2.MissingPvtDataTracker, error) {
 	panic("implement me")
 }
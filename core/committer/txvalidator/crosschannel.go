@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// validateCrossChannelWrites checks that the writes of a transaction to the
+// namespace of xscc only record the cross-channel write intents and the locks
+// of the keys they write, and returns the namespaces written by the intents
+// on this channel. A transaction of an application chaincode may only record
+// the intent of its own writes, which it must defer entirely into the intent.
+// A transaction of xscc may only prepare an intent on its target channel, or
+// complete a prepared intent, and the locks and the writes of the intent are
+// checked against the intent and the locks committed on the channel.
+func (v *VsccValidatorImpl) validateCrossChannelWrites(chdr *common.ChannelHeader, ccID string, txRWSet *rwsetutil.TxRwSet) ([]string, error) {
+	originating := ccID != crosschannel.Namespace
+
+	var xsccRWSet *kvrwset.KVRWSet
+	var appWrites []*crosschannel.NsWrites
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace != crosschannel.Namespace {
+			if !v.txWritesToNamespace(ns) {
+				continue
+			}
+			if originating {
+				return nil, errors.Errorf("transaction %s recording a cross-channel write intent writes to namespace %s", chdr.TxId, ns.NameSpace)
+			}
+			if ns.KvRwSet == nil || len(ns.CollHashedRwSets) != 0 || len(ns.KvRwSet.MetadataWrites) != 0 {
+				return nil, errors.Errorf("transaction %s completing a cross-channel write intent writes private data or metadata to namespace %s", chdr.TxId, ns.NameSpace)
+			}
+			appWrites = append(appWrites, &crosschannel.NsWrites{Namespace: ns.NameSpace, Writes: ns.KvRwSet.Writes})
+			continue
+		}
+		if ns.KvRwSet == nil || len(ns.CollHashedRwSets) != 0 || len(ns.KvRwSet.MetadataWrites) != 0 {
+			return nil, errors.Errorf("transaction %s writes private data or metadata to the namespace of %s", chdr.TxId, crosschannel.Namespace)
+		}
+		xsccRWSet = ns.KvRwSet
+	}
+
+	// the committed state is only needed by the transactions of xscc, since
+	// the intent recorded by a transaction of an application chaincode is
+	// its own
+	var qe ledger.QueryExecutor
+	if !originating {
+		var err error
+		if qe, err = v.support.Ledger().NewQueryExecutor(); err != nil {
+			return nil, errors.WithMessage(err, "could not retrieve QueryExecutor")
+		}
+		defer qe.Done()
+	}
+
+	// the locks acquired and released by the intents, and the transactions
+	// holding them, and the writes applied by the intents committed
+	acquired := make(map[string]string)
+	released := make(map[string]string)
+	committedWrites := make(map[string]*kvrwset.KVWrite)
+	var namespaces []string
+	intents := 0
+	for _, write := range xsccRWSet.Writes {
+		if !crosschannel.IsIntentKey(write.Key) {
+			continue
+		}
+		if write.IsDelete {
+			return nil, errors.Errorf("transaction %s deletes a cross-channel write intent", chdr.TxId)
+		}
+		intent := &peer.CrossChannelIntent{}
+		if err := proto.Unmarshal(write.Value, intent); err != nil {
+			return nil, errors.Wrapf(err, "transaction %s records an invalid cross-channel write intent", chdr.TxId)
+		}
+		if write.Key != crosschannel.IntentKey(intent.TxId) {
+			return nil, errors.Errorf("transaction %s records the cross-channel write intent of transaction %s under another key", chdr.TxId, intent.TxId)
+		}
+		if originating && (intent.TxId != chdr.TxId || intent.SourceChannel != chdr.ChannelId || intent.Status != peer.CrossChannelIntent_PREPARED) {
+			return nil, errors.Errorf("transaction %s records a cross-channel write intent which isn't its own prepared intent", chdr.TxId)
+		}
+
+		prepared := originating
+		if !originating {
+			var err error
+			if prepared, err = validateIntentTransition(qe, chdr, intent, xsccRWSet.Reads); err != nil {
+				return nil, err
+			}
+		}
+
+		writes, err := crosschannel.IntentWrites(intent, chdr.ChannelId)
+		if err != nil {
+			return nil, err
+		}
+		for _, lockKey := range crosschannel.LockKeys(writes) {
+			if prepared {
+				acquired[lockKey] = intent.TxId
+			} else {
+				released[lockKey] = intent.TxId
+			}
+		}
+		for _, nsWrites := range writes {
+			if v.sccprovider.IsSysCC(nsWrites.Namespace) {
+				return nil, errors.Errorf("transaction %s records a cross-channel write intent writing to the namespace of system chaincode %s", chdr.TxId, nsWrites.Namespace)
+			}
+			if !contains(namespaces, nsWrites.Namespace) {
+				namespaces = append(namespaces, nsWrites.Namespace)
+			}
+			if intent.Status != peer.CrossChannelIntent_COMMITTED {
+				continue
+			}
+			for _, w := range nsWrites.Writes {
+				committedWrites[crosschannel.LockKey(nsWrites.Namespace, w.Key)] = w
+			}
+		}
+		intents++
+	}
+	if originating && intents != 1 {
+		return nil, errors.Errorf("transaction %s writes to the namespace of %s without recording its cross-channel write intent", chdr.TxId, crosschannel.Namespace)
+	}
+
+	locked, unlocked := 0, 0
+	for _, write := range xsccRWSet.Writes {
+		if crosschannel.IsIntentKey(write.Key) {
+			continue
+		}
+		if !write.IsDelete {
+			holder, ok := acquired[write.Key]
+			if !ok {
+				return nil, errors.Errorf("transaction %s writes key %q of the namespace of %s which isn't the lock of a key written by the cross-channel write intents it prepares", chdr.TxId, write.Key, crosschannel.Namespace)
+			}
+			if string(write.Value) != holder {
+				return nil, errors.Errorf("transaction %s sets lock %q to transaction %s instead of transaction %s", chdr.TxId, write.Key, write.Value, holder)
+			}
+			locked++
+			continue
+		}
+
+		holder, ok := released[write.Key]
+		if !ok {
+			return nil, errors.Errorf("transaction %s releases lock %q which isn't the lock of a key written by the cross-channel write intents it completes", chdr.TxId, write.Key)
+		}
+		committedHolder, err := qe.GetState(crosschannel.Namespace, write.Key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not retrieve cross-channel lock")
+		}
+		if string(committedHolder) != holder {
+			return nil, errors.Errorf("transaction %s releases lock %q held by transaction %s instead of transaction %s", chdr.TxId, write.Key, committedHolder, holder)
+		}
+		unlocked++
+	}
+	if locked != len(acquired) {
+		return nil, errors.Errorf("transaction %s doesn't lock all the keys written by its cross-channel write intents", chdr.TxId)
+	}
+	if unlocked != len(released) {
+		return nil, errors.Errorf("transaction %s doesn't release all the locks of the cross-channel write intents it completes", chdr.TxId)
+	}
+
+	// a transaction of xscc applies exactly the writes of the intents it commits
+	applied := 0
+	for _, nsWrites := range appWrites {
+		for _, write := range nsWrites.Writes {
+			expected, ok := committedWrites[crosschannel.LockKey(nsWrites.Namespace, write.Key)]
+			if !ok || !proto.Equal(expected, write) {
+				return nil, errors.Errorf("transaction %s writes key %q of namespace %s which isn't written by the cross-channel write intents it commits", chdr.TxId, write.Key, nsWrites.Namespace)
+			}
+			applied++
+		}
+	}
+	if applied != len(committedWrites) {
+		return nil, errors.Errorf("transaction %s doesn't apply all the writes of the cross-channel write intents it commits", chdr.TxId)
+	}
+
+	return namespaces, nil
+}
+
+// validateIntentTransition checks that a transaction of xscc records a valid
+// transition of a cross-channel write intent from the intent committed on the
+// channel: an intent is prepared on its target channel, and a prepared intent
+// is completed, as committed or aborted, without being altered otherwise. The
+// transaction must have read the intent, so that concurrent transitions of the
+// same intent fail the MVCC validation. It returns true if the intent is
+// prepared by the transaction.
+func validateIntentTransition(qe ledger.QueryExecutor, chdr *common.ChannelHeader, intent *peer.CrossChannelIntent, reads []*kvrwset.KVRead) (bool, error) {
+	intentKey := crosschannel.IntentKey(intent.TxId)
+	read := false
+	for _, r := range reads {
+		if r.Key == intentKey {
+			read = true
+		}
+	}
+	if !read {
+		return false, errors.Errorf("transaction %s records the cross-channel write intent of transaction %s without reading it", chdr.TxId, intent.TxId)
+	}
+
+	storedBytes, err := qe.GetState(crosschannel.Namespace, intentKey)
+	if err != nil {
+		return false, errors.WithMessage(err, "could not retrieve cross-channel write intent")
+	}
+	if storedBytes == nil {
+		if intent.Status != peer.CrossChannelIntent_PREPARED || intent.TargetChannel != chdr.ChannelId {
+			return false, errors.Errorf("transaction %s records the cross-channel write intent of transaction %s as %s, but it isn't prepared on channel %s", chdr.TxId, intent.TxId, intent.Status, chdr.ChannelId)
+		}
+		return true, nil
+	}
+
+	stored := &peer.CrossChannelIntent{}
+	if err := proto.Unmarshal(storedBytes, stored); err != nil {
+		return false, errors.Wrapf(err, "could not unmarshal the cross-channel write intent of transaction %s", intent.TxId)
+	}
+	if stored.Status != peer.CrossChannelIntent_PREPARED {
+		return false, errors.Errorf("transaction %s records the cross-channel write intent of transaction %s as %s, but it is already %s", chdr.TxId, intent.TxId, intent.Status, stored.Status)
+	}
+	if intent.Status != peer.CrossChannelIntent_COMMITTED && intent.Status != peer.CrossChannelIntent_ABORTED {
+		return false, errors.Errorf("transaction %s records the prepared cross-channel write intent of transaction %s as %s", chdr.TxId, intent.TxId, intent.Status)
+	}
+	expected := proto.Clone(stored).(*peer.CrossChannelIntent)
+	expected.Status = intent.Status
+	if !proto.Equal(expected, intent) {
+		return false, errors.Errorf("transaction %s records a cross-channel write intent of transaction %s which doesn't match the prepared intent", chdr.TxId, intent.TxId)
+	}
+	return false, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
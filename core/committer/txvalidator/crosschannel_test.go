@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator_test
+
+import (
+	"testing"
+
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func crossChannelCapabilities() *mockconfig.MockApplicationCapabilities {
+	c := v13Capabilities()
+	c.CrossChannelWritesRv = true
+	return c
+}
+
+// getCrossChannelEnv returns a transaction of the given chaincode whose
+// results are built from its transaction ID
+func getCrossChannelEnv(t *testing.T, ccID string, results func(txID, channelID string) []byte) *common.Envelope {
+	prop, err := getProposalWithType(ccID, common.HeaderType_ENDORSER_TRANSACTION)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+
+	res := results(chdr.TxId, chdr.ChannelId)
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, &peer.ChaincodeID{Name: ccID, Version: ccVersion}, nil, signer)
+	assert.NoError(t, err)
+	tx, err := utils.CreateSignedTx(prop, signer, presp)
+	assert.NoError(t, err)
+	return tx
+}
+
+// intentResults returns the results of a transaction recording the intent
+// of writing the given keys of a namespace, which locks the lockedKeys
+func intentResults(t *testing.T, txID, channelID, namespace string, keys []string, lockedKeys []string, extraWrites bool) []byte {
+	source := rwsetutil.NewRWSetBuilder()
+	for _, key := range keys {
+		source.AddToWriteSet(namespace, key, []byte("value"))
+	}
+	sourceResults, err := source.GetTxSimulationResults()
+	assert.NoError(t, err)
+	sourceBytes, err := sourceResults.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	intent := &peer.CrossChannelIntent{
+		TxId:          txID,
+		SourceChannel: channelID,
+		TargetChannel: "target",
+		SourceResults: sourceBytes,
+		Status:        peer.CrossChannelIntent_PREPARED,
+	}
+
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToWriteSet(crosschannel.Namespace, crosschannel.IntentKey(txID), utils.MarshalOrPanic(intent))
+	for _, key := range lockedKeys {
+		builder.AddToWriteSet(crosschannel.Namespace, crosschannel.LockKey(namespace, key), []byte(txID))
+	}
+	if extraWrites {
+		builder.AddToWriteSet(namespace, "other", []byte("value"))
+	}
+	results, err := builder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	resultsBytes, err := results.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	return resultsBytes
+}
+
+func TestCrossChannelWritesNotEnabled(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+	tx := getCrossChannelEnv(t, ccID, func(txID, channelID string) []byte {
+		return intentResults(t, txID, channelID, ccID, []string{"key"}, []string{"key"}, false)
+	})
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
+func TestCrossChannelWritesIntent(t *testing.T) {
+	tests := []struct {
+		name        string
+		lockedKeys  []string
+		extraWrites bool
+		valid       bool
+	}{
+		{name: "valid", lockedKeys: []string{"k1", "k2"}, valid: true},
+		{name: "missing lock", lockedKeys: []string{"k1"}},
+		{name: "writes outside of the intent", lockedKeys: []string{"k1", "k2"}, extraWrites: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, v := setupLedgerAndValidatorWithCapabilities(t, crossChannelCapabilities())
+			defer ledgermgmt.CleanupTestEnv()
+			defer l.Close()
+
+			ccID := "mycc"
+			putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+			tx := getCrossChannelEnv(t, ccID, func(txID, channelID string) []byte {
+				return intentResults(t, txID, channelID, ccID, []string{"k1", "k2"}, test.lockedKeys, test.extraWrites)
+			})
+			b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+			err := v.Validate(b)
+			assert.NoError(t, err)
+			if test.valid {
+				assertValid(b, t)
+			} else {
+				assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	mocktxvalidator "github.com/hyperledger/fabric/core/mocks/txvalidator"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/semaphore"
+)
+
+// stateLedger is a ledger whose query executors read the given state of the
+// namespace of xscc
+type stateLedger struct {
+	ledger.PeerLedger
+	state map[string][]byte
+}
+
+func (l *stateLedger) NewQueryExecutor() (ledger.QueryExecutor, error) {
+	return &stateQueryExecutor{state: l.state}, nil
+}
+
+type stateQueryExecutor struct {
+	ledger.QueryExecutor
+	state map[string][]byte
+}
+
+func (qe *stateQueryExecutor) GetState(namespace, key string) ([]byte, error) {
+	if namespace != crosschannel.Namespace {
+		return nil, nil
+	}
+	return qe.state[key], nil
+}
+
+func (qe *stateQueryExecutor) Done() {}
+
+func preparedIntent(t *testing.T) *peer.CrossChannelIntent {
+	target := rwsetutil.NewRWSetBuilder()
+	target.AddToWriteSet("mycc", "key", []byte("value"))
+	targetResults, err := target.GetTxSimulationResults()
+	assert.NoError(t, err)
+	targetBytes, err := targetResults.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	return &peer.CrossChannelIntent{
+		TxId:          "intenttx",
+		SourceChannel: "source",
+		TargetChannel: "target",
+		TargetResults: targetBytes,
+		Status:        peer.CrossChannelIntent_PREPARED,
+	}
+}
+
+func TestValidateCrossChannelIntentTransitions(t *testing.T) {
+	lockKey := crosschannel.LockKey("mycc", "key")
+	intentKey := crosschannel.IntentKey("intenttx")
+	withStatus := func(status peer.CrossChannelIntent_Status) *peer.CrossChannelIntent {
+		intent := preparedIntent(t)
+		intent.Status = status
+		return intent
+	}
+
+	tests := []struct {
+		name         string
+		stored       *peer.CrossChannelIntent
+		lockHolder   string
+		intent       *peer.CrossChannelIntent
+		noRead       bool
+		lockWrite    []byte
+		releaseLock  bool
+		appWrite     []byte
+		errorMessage string
+	}{
+		{
+			name:      "prepare",
+			intent:    withStatus(peer.CrossChannelIntent_PREPARED),
+			lockWrite: []byte("intenttx"),
+		},
+		{
+			name:         "prepare without reading the intent",
+			intent:       withStatus(peer.CrossChannelIntent_PREPARED),
+			noRead:       true,
+			lockWrite:    []byte("intenttx"),
+			errorMessage: "transaction xscctx records the cross-channel write intent of transaction intenttx without reading it",
+		},
+		{
+			name:         "prepare with a lock held by another transaction",
+			intent:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockWrite:    []byte("othertx"),
+			errorMessage: fmt.Sprintf("transaction xscctx sets lock %q to transaction othertx instead of transaction intenttx", lockKey),
+		},
+		{
+			name:         "commit without preparing",
+			intent:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			releaseLock:  true,
+			appWrite:     []byte("value"),
+			errorMessage: "transaction xscctx records the cross-channel write intent of transaction intenttx as COMMITTED, but it isn't prepared on channel target",
+		},
+		{
+			name:        "commit",
+			stored:      withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:  "intenttx",
+			intent:      withStatus(peer.CrossChannelIntent_COMMITTED),
+			releaseLock: true,
+			appWrite:    []byte("value"),
+		},
+		{
+			name:         "commit with other writes",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:   "intenttx",
+			intent:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			releaseLock:  true,
+			appWrite:     []byte("other"),
+			errorMessage: `transaction xscctx writes key "key" of namespace mycc which isn't written by the cross-channel write intents it commits`,
+		},
+		{
+			name:         "commit without the writes",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:   "intenttx",
+			intent:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			releaseLock:  true,
+			errorMessage: "transaction xscctx doesn't apply all the writes of the cross-channel write intents it commits",
+		},
+		{
+			name:         "commit without releasing the locks",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:   "intenttx",
+			intent:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			appWrite:     []byte("value"),
+			errorMessage: "transaction xscctx doesn't release all the locks of the cross-channel write intents it completes",
+		},
+		{
+			name:         "commit releasing a lock held by another transaction",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:   "othertx",
+			intent:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			releaseLock:  true,
+			appWrite:     []byte("value"),
+			errorMessage: fmt.Sprintf("transaction xscctx releases lock %q held by transaction othertx instead of transaction intenttx", lockKey),
+		},
+		{
+			name:        "abort",
+			stored:      withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:  "intenttx",
+			intent:      withStatus(peer.CrossChannelIntent_ABORTED),
+			releaseLock: true,
+		},
+		{
+			name:         "abort with writes",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder:   "intenttx",
+			intent:       withStatus(peer.CrossChannelIntent_ABORTED),
+			releaseLock:  true,
+			appWrite:     []byte("value"),
+			errorMessage: `transaction xscctx writes key "key" of namespace mycc which isn't written by the cross-channel write intents it commits`,
+		},
+		{
+			name:         "prepare again",
+			stored:       withStatus(peer.CrossChannelIntent_PREPARED),
+			intent:       withStatus(peer.CrossChannelIntent_PREPARED),
+			errorMessage: "transaction xscctx records the prepared cross-channel write intent of transaction intenttx as PREPARED",
+		},
+		{
+			name:         "abort a committed intent",
+			stored:       withStatus(peer.CrossChannelIntent_COMMITTED),
+			intent:       withStatus(peer.CrossChannelIntent_ABORTED),
+			releaseLock:  true,
+			errorMessage: "transaction xscctx records the cross-channel write intent of transaction intenttx as ABORTED, but it is already COMMITTED",
+		},
+		{
+			name:       "alter the intent",
+			stored:     withStatus(peer.CrossChannelIntent_PREPARED),
+			lockHolder: "intenttx",
+			intent: func() *peer.CrossChannelIntent {
+				intent := withStatus(peer.CrossChannelIntent_COMMITTED)
+				intent.SourceChannel = "other"
+				return intent
+			}(),
+			releaseLock:  true,
+			appWrite:     []byte("value"),
+			errorMessage: "transaction xscctx records a cross-channel write intent of transaction intenttx which doesn't match the prepared intent",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := make(map[string][]byte)
+			if test.stored != nil {
+				state[intentKey] = utils.MarshalOrPanic(test.stored)
+			}
+			if test.lockHolder != "" {
+				state[lockKey] = []byte(test.lockHolder)
+			}
+			v := &VsccValidatorImpl{
+				support: struct {
+					*mocktxvalidator.Support
+					*semaphore.Weighted
+				}{&mocktxvalidator.Support{LedgerVal: &stateLedger{state: state}}, semaphore.NewWeighted(1)},
+				sccprovider: (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider(),
+			}
+
+			builder := rwsetutil.NewRWSetBuilder()
+			if !test.noRead {
+				builder.AddToReadSet(crosschannel.Namespace, intentKey, nil)
+			}
+			builder.AddToWriteSet(crosschannel.Namespace, intentKey, utils.MarshalOrPanic(test.intent))
+			if test.lockWrite != nil {
+				builder.AddToWriteSet(crosschannel.Namespace, lockKey, test.lockWrite)
+			}
+			if test.releaseLock {
+				builder.AddToWriteSet(crosschannel.Namespace, lockKey, nil)
+			}
+			if test.appWrite != nil {
+				builder.AddToWriteSet("mycc", "key", test.appWrite)
+			}
+			results, err := builder.GetTxSimulationResults()
+			assert.NoError(t, err)
+			txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(results.PubSimulationResults)
+			assert.NoError(t, err)
+
+			chdr := &common.ChannelHeader{TxId: "xscctx", ChannelId: "target"}
+			namespaces, err := v.validateCrossChannelWrites(chdr, crosschannel.Namespace, txRWSet)
+			if test.errorMessage != "" {
+				assert.EqualError(t, err, test.errorMessage)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"mycc"}, namespaces)
+		})
+	}
+}
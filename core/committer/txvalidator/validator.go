@@ -9,6 +9,7 @@ package txvalidator
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -62,6 +63,52 @@ type Validator interface {
 	Validate(block *common.Block) error
 }
 
+// PipelinedValidator is a Validator that splits the validation of a block in
+// two phases, so that the first one can overlap with the commit of the
+// previous block. Prevalidate performs the checks that do not read the
+// committed state, that is the checks on the format, the signature and the
+// creator of the transactions. ValidatePrevalidated performs the checks that
+// read the committed state, that is the check for duplicate txids in the
+// ledger and the VSCC, which evaluates the endorsement policies (including
+// the state-based ones) fetched from the committed state: it must be invoked
+// only after the previous block has been committed. As Prevalidate reads the
+// channel config, which the validation of a config transaction updates, it
+// must be invoked only after the previous block has been validated.
+// Validating a block in two phases sets the same flags as Validate.
+type PipelinedValidator interface {
+	Validator
+
+	// Prevalidate performs the checks of the transactions of a block
+	// that do not depend on the committed state
+	Prevalidate(block *common.Block) *PrevalidatedBlock
+
+	// ValidatePrevalidated completes the validation of a prevalidated block
+	ValidatePrevalidated(prevalidated *PrevalidatedBlock) error
+}
+
+// PrevalidatedBlock is a block whose transactions have gone
+// through the checks that do not depend on the committed state
+type PrevalidatedBlock struct {
+	block *common.Block
+	txs   []*prevalidatedTx
+}
+
+// Block returns the block that has been prevalidated
+func (p *PrevalidatedBlock) Block() *common.Block {
+	return p.block
+}
+
+// prevalidatedTx holds the outcome of the checks of a transaction
+// that do not depend on the committed state: either the final result,
+// if the transaction has been found invalid, or the unmarshalled
+// transaction that is still to be validated against the committed state
+type prevalidatedTx struct {
+	result  *blockValidationResult
+	env     *common.Envelope
+	payload *common.Payload
+	chdr    *common.ChannelHeader
+}
+
 // private interface to decouple tx validator
 // and vscc execution, in order to increase
 // testability of TxValidator
@@ -131,6 +178,53 @@ func (v *TxValidator) chainExists(chain string) bool {
 //    guaranteed to be alone in the block. If/when this assumption
 //    is violated, this code must be changed.
 func (v *TxValidator) Validate(block *common.Block) error {
+	return v.validate(block, v.validateTx)
+}
+
+// Prevalidate performs, in parallel as Validate does, the checks of
+// the transactions of a block that do not depend on the committed state
+func (v *TxValidator) Prevalidate(block *common.Block) *PrevalidatedBlock {
+	startPrevalidation := time.Now()
+	prevalidated := &PrevalidatedBlock{
+		block: block,
+		txs:   make([]*prevalidatedTx, len(block.Data.Data)),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(block.Data.Data))
+	for tIdx, d := range block.Data.Data {
+		// ensure that we don't have too many concurrent validation workers
+		v.Support.Acquire(context.Background(), 1)
+
+		go func(index int, data []byte) {
+			defer wg.Done()
+			defer v.Support.Release(1)
+
+			prevalidated.txs[index] = v.prevalidateTx(&blockValidationRequest{
+				d:     data,
+				block: block,
+				tIdx:  index,
+			})
+		}(tIdx, d)
+	}
+	wg.Wait()
+
+	elapsedPrevalidation := time.Since(startPrevalidation) / time.Millisecond // duration in ms
+	logger.Debugf("[%s] Prevalidated block [%d] in %dms", v.ChainID, block.Header.Number, elapsedPrevalidation)
+	return prevalidated
+}
+
+// ValidatePrevalidated completes the validation of a block by performing
+// the checks of its transactions that depend on the committed state
+func (v *TxValidator) ValidatePrevalidated(prevalidated *PrevalidatedBlock) error {
+	return v.validate(prevalidated.block, func(req *blockValidationRequest, results chan<- *blockValidationResult) {
+		results <- v.completeTxValidation(req, prevalidated.txs[req.tIdx])
+	})
+}
+
+// validate validates the transactions of a block in parallel with the given
+// function and, once all of them are validated, sets the validation flags
+func (v *TxValidator) validate(block *common.Block, validateTx func(req *blockValidationRequest, results chan<- *blockValidationResult)) error {
 	var err error
 	var errPos int
 
@@ -155,7 +249,7 @@ func (v *TxValidator) Validate(block *common.Block) error {
 			go func(index int, data []byte) {
 				defer v.Support.Release(1)
 
-				v.validateTx(&blockValidationRequest{
+				validateTx(&blockValidationRequest{
 					d:     data,
 					block: block,
 					tIdx:  index,
@@ -265,207 +359,238 @@ func markTXIdDuplicates(txids []string, txsfltr ledgerUtil.TxValidationFlags) {
 }
 
 func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *blockValidationResult) {
+	results <- v.completeTxValidation(req, v.prevalidateTx(req))
+}
+
+// prevalidateTx performs the checks of a transaction that do not depend on the committed state
+func (v *TxValidator) prevalidateTx(req *blockValidationRequest) *prevalidatedTx {
 	block := req.block
 	d := req.d
 	tIdx := req.tIdx
-	txID := ""
 
 	if d == nil {
-		results <- &blockValidationResult{
-			tIdx: tIdx,
+		return &prevalidatedTx{
+			result: &blockValidationResult{
+				tIdx: tIdx,
+			},
 		}
-		return
 	}
 
-	if env, err := utils.GetEnvelopeFromBlock(d); err != nil {
+	env, err := utils.GetEnvelopeFromBlock(d)
+	if err != nil {
 		logger.Warningf("Error getting tx from block: %+v", err)
-		results <- &blockValidationResult{
-			tIdx:           tIdx,
-			validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+		return &prevalidatedTx{
+			result: &blockValidationResult{
+				tIdx:           tIdx,
+				validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+			},
 		}
-		return
-	} else if env != nil {
-		// validate the transaction: here we check that the transaction
-		// is properly formed, properly signed and that the security
-		// chain binding proposal to endorsements to tx holds. We do
-		// NOT check the validity of endorsements, though. That's a
-		// job for VSCC below
-		logger.Debugf("[%s] validateTx starts for block %p env %p txn %d", v.ChainID, block, env, tIdx)
-		defer logger.Debugf("[%s] validateTx completes for block %p env %p txn %d", v.ChainID, block, env, tIdx)
-		var payload *common.Payload
-		var err error
-		var txResult peer.TxValidationCode
-		var txsChaincodeName *sysccprovider.ChaincodeInstance
-		var txsUpgradedChaincode *sysccprovider.ChaincodeInstance
-
-		if payload, txResult = validation.ValidateTransaction(env, v.Support.Capabilities()); txResult != peer.TxValidationCode_VALID {
-			logger.Errorf("Invalid transaction with index %d", tIdx)
-			results <- &blockValidationResult{
+	}
+	if env == nil {
+		logger.Warning("Nil tx from block")
+		return &prevalidatedTx{
+			result: &blockValidationResult{
+				tIdx:           tIdx,
+				validationCode: peer.TxValidationCode_NIL_ENVELOPE,
+			},
+		}
+	}
+
+	// validate the transaction: here we check that the transaction
+	// is properly formed, properly signed and that the security
+	// chain binding proposal to endorsements to tx holds. We do
+	// NOT check the validity of endorsements, though. That's a
+	// job for VSCC, once the transaction is validated against
+	// the committed state
+	logger.Debugf("[%s] prevalidateTx starts for block %p env %p txn %d", v.ChainID, block, env, tIdx)
+	defer logger.Debugf("[%s] prevalidateTx completes for block %p env %p txn %d", v.ChainID, block, env, tIdx)
+
+	payload, txResult := validation.ValidateTransaction(env, v.Support.Capabilities())
+	if txResult != peer.TxValidationCode_VALID {
+		logger.Errorf("Invalid transaction with index %d", tIdx)
+		return &prevalidatedTx{
+			result: &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: txResult,
-			}
-			return
+			},
 		}
+	}
 
-		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
-		if err != nil {
-			logger.Warningf("Could not unmarshal channel header, err %s, skipping", err)
-			results <- &blockValidationResult{
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Warningf("Could not unmarshal channel header, err %s, skipping", err)
+		return &prevalidatedTx{
+			result: &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
-			}
-			return
+			},
 		}
+	}
 
-		channel := chdr.ChannelId
-		logger.Debugf("Transaction is for channel %s", channel)
+	channel := chdr.ChannelId
+	logger.Debugf("Transaction is for channel %s", channel)
 
-		if !v.chainExists(channel) {
-			logger.Errorf("Dropping transaction for non-existent channel %s", channel)
-			results <- &blockValidationResult{
+	if !v.chainExists(channel) {
+		logger.Errorf("Dropping transaction for non-existent channel %s", channel)
+		return &prevalidatedTx{
+			result: &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND,
-			}
-			return
+			},
 		}
+	}
 
-		if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+	switch common.HeaderType(chdr.Type) {
+	case common.HeaderType_ENDORSER_TRANSACTION, common.HeaderType_CONFIG:
+	default:
+		logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
+			common.HeaderType(chdr.Type), block.Header.Number, tIdx)
+		return &prevalidatedTx{
+			result: &blockValidationResult{
+				tIdx:           tIdx,
+				validationCode: peer.TxValidationCode_UNKNOWN_TX_TYPE,
+			},
+		}
+	}
 
-			txID = chdr.TxId
+	return &prevalidatedTx{
+		env:     env,
+		payload: payload,
+		chdr:    chdr,
+	}
+}
 
-			// Check duplicate transactions
-			erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
-			if erroneousResultEntry != nil {
-				results <- erroneousResultEntry
-				return
-			}
+// completeTxValidation performs the checks of a prevalidated transaction
+// that depend on the committed state and returns the validation result
+func (v *TxValidator) completeTxValidation(req *blockValidationRequest, prevalidated *prevalidatedTx) *blockValidationResult {
+	if prevalidated.result != nil {
+		return prevalidated.result
+	}
 
-			// Validate tx with vscc and policy
-			logger.Debug("Validating transaction vscc tx validate")
-			err, cde := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
-			if err != nil {
-				logger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
-				switch err.(type) {
-				case *commonerrors.VSCCExecutionFailureError:
-					results <- &blockValidationResult{
-						tIdx: tIdx,
-						err:  err,
-					}
-					return
-				case *commonerrors.VSCCInfoLookupFailureError:
-					results <- &blockValidationResult{
-						tIdx: tIdx,
-						err:  err,
-					}
-					return
-				default:
-					results <- &blockValidationResult{
-						tIdx:           tIdx,
-						validationCode: cde,
-					}
-					return
-				}
-			}
+	block := req.block
+	d := req.d
+	tIdx := req.tIdx
+	txID := ""
+	env := prevalidated.env
+	payload := prevalidated.payload
+	chdr := prevalidated.chdr
+	channel := chdr.ChannelId
 
-			invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
-			if err != nil {
-				logger.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
-				results <- &blockValidationResult{
-					tIdx:           tIdx,
-					validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
-				}
-				return
-			}
-			txsChaincodeName = invokeCC
-			if upgradeCC != nil {
-				logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
-				txsUpgradedChaincode = upgradeCC
-			}
-			// FAB-12971 comment out below block before v1.4 cut. Will uncomment after v1.4.
-			/*
-				} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
-
-					txID = chdr.TxId
-					if !v.Support.Capabilities().FabToken() {
-						logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
-							common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-						results <- &blockValidationResult{
-							tIdx:           tIdx,
-							validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
-						}
-						return
-					}
+	logger.Debugf("[%s] validateTx starts for block %p env %p txn %d", v.ChainID, block, env, tIdx)
+	defer logger.Debugf("[%s] validateTx completes for block %p env %p txn %d", v.ChainID, block, env, tIdx)
+	var txsChaincodeName *sysccprovider.ChaincodeInstance
+	var txsUpgradedChaincode *sysccprovider.ChaincodeInstance
 
-					// Check if there is a duplicate of such transaction in the ledger and
-					// obtain the corresponding result that acknowledges the error type
-					erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
-					if erroneousResultEntry != nil {
-						results <- erroneousResultEntry
-						return
-					}
+	if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
 
-					// Set the namespace of the invocation field
-					txsChaincodeName = &sysccprovider.ChaincodeInstance{
-						ChainID:          channel,
-						ChaincodeName:    "Token",
-						ChaincodeVersion: ""}
-			*/
-		} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
-			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
-			if err != nil {
-				err = errors.WithMessage(err, "error unmarshalling config which passed initial validity checks")
-				logger.Criticalf("%+v", err)
-				results <- &blockValidationResult{
+		txID = chdr.TxId
+
+		// Check duplicate transactions
+		erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
+		if erroneousResultEntry != nil {
+			return erroneousResultEntry
+		}
+
+		// Validate tx with vscc and policy
+		logger.Debug("Validating transaction vscc tx validate")
+		err, cde := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
+		if err != nil {
+			logger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
+			switch err.(type) {
+			case *commonerrors.VSCCExecutionFailureError:
+				return &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
 				}
-				return
-			}
-
-			if err := v.Support.Apply(configEnvelope); err != nil {
-				err = errors.WithMessage(err, "error validating config which passed initial validity checks")
-				logger.Criticalf("%+v", err)
-				results <- &blockValidationResult{
+			case *commonerrors.VSCCInfoLookupFailureError:
+				return &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
 				}
-				return
+			default:
+				return &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: cde,
+				}
 			}
-			logger.Debugf("config transaction received for chain %s", channel)
-		} else {
-			logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
-				common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-			results <- &blockValidationResult{
+		}
+
+		invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
+		if err != nil {
+			logger.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
+			return &blockValidationResult{
 				tIdx:           tIdx,
-				validationCode: peer.TxValidationCode_UNKNOWN_TX_TYPE,
+				validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
 			}
-			return
 		}
+		txsChaincodeName = invokeCC
+		if upgradeCC != nil {
+			logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+			txsUpgradedChaincode = upgradeCC
+		}
+		// FAB-12971 comment out below block before v1.4 cut. Will uncomment after v1.4.
+		/*
+			} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
+
+				txID = chdr.TxId
+				if !v.Support.Capabilities().FabToken() {
+					logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
+						common.HeaderType(chdr.Type), block.Header.Number, tIdx)
+					return &blockValidationResult{
+						tIdx:           tIdx,
+						validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
+					}
+				}
 
-		if _, err := proto.Marshal(env); err != nil {
-			logger.Warningf("Cannot marshal transaction: %s", err)
-			results <- &blockValidationResult{
-				tIdx:           tIdx,
-				validationCode: peer.TxValidationCode_MARSHAL_TX_ERROR,
+				// Check if there is a duplicate of such transaction in the ledger and
+				// obtain the corresponding result that acknowledges the error type
+				erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
+				if erroneousResultEntry != nil {
+					return erroneousResultEntry
+				}
+
+				// Set the namespace of the invocation field
+				txsChaincodeName = &sysccprovider.ChaincodeInstance{
+					ChainID:          channel,
+					ChaincodeName:    "Token",
+					ChaincodeVersion: ""}
+		*/
+	} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
+		configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+		if err != nil {
+			err = errors.WithMessage(err, "error unmarshalling config which passed initial validity checks")
+			logger.Criticalf("%+v", err)
+			return &blockValidationResult{
+				tIdx: tIdx,
+				err:  err,
 			}
-			return
 		}
-		// Succeeded to pass down here, transaction is valid
-		results <- &blockValidationResult{
-			tIdx:                 tIdx,
-			txsChaincodeName:     txsChaincodeName,
-			txsUpgradedChaincode: txsUpgradedChaincode,
-			validationCode:       peer.TxValidationCode_VALID,
-			txid:                 txID,
+
+		if err := v.Support.Apply(configEnvelope); err != nil {
+			err = errors.WithMessage(err, "error validating config which passed initial validity checks")
+			logger.Criticalf("%+v", err)
+			return &blockValidationResult{
+				tIdx: tIdx,
+				err:  err,
+			}
 		}
-		return
-	} else {
-		logger.Warning("Nil tx from block")
-		results <- &blockValidationResult{
+		logger.Debugf("config transaction received for chain %s", channel)
+	}
+
+	if _, err := proto.Marshal(env); err != nil {
+		logger.Warningf("Cannot marshal transaction: %s", err)
+		return &blockValidationResult{
 			tIdx:           tIdx,
-			validationCode: peer.TxValidationCode_NIL_ENVELOPE,
+			validationCode: peer.TxValidationCode_MARSHAL_TX_ERROR,
 		}
-		return
+	}
+	// Succeeded to pass down here, transaction is valid
+	return &blockValidationResult{
+		tIdx:                 tIdx,
+		txsChaincodeName:     txsChaincodeName,
+		txsUpgradedChaincode: txsUpgradedChaincode,
+		validationCode:       peer.TxValidationCode_VALID,
+		txid:                 txID,
 	}
 }
 
@@ -669,6 +794,14 @@ func (ds *dynamicCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	return ds.support.Capabilities().ForbidDuplicateTXIdInBlock()
 }
 
+func (ds *dynamicCapabilities) CrossChannelWrites() bool {
+	return ds.support.Capabilities().CrossChannelWrites()
+}
+
+func (ds *dynamicCapabilities) MultipleChaincodeEvents() bool {
+	return ds.support.Capabilities().MultipleChaincodeEvents()
+}
+
 func (ds *dynamicCapabilities) KeyLevelEndorsement() bool {
 	return ds.support.Capabilities().KeyLevelEndorsement()
 }
@@ -423,6 +423,91 @@ func testInvokeOK(t *testing.T, l ledger.PeerLedger, v txvalidator.Validator) {
 	assertValid(b, t)
 }
 
+// getEnvWithEvents returns a transaction of the given chaincode whose action
+// carries the given list of chaincode events
+func getEnvWithEvents(ccID string, events []*peer.ChaincodeEvent, res []byte, t *testing.T) *common.Envelope {
+	prop, err := getProposalWithType(ccID, common.HeaderType_ENDORSER_TRANSACTION)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	pHashBytes, err := utils.GetProposalHash1(hdr, prop.Payload, nil)
+	assert.NoError(t, err)
+	eventBytes, err := utils.GetBytesChaincodeEvent(events[0])
+	assert.NoError(t, err)
+	prpBytes, err := utils.GetBytesProposalResponsePayloadWithEvents(pHashBytes, &peer.Response{Status: 200}, res, eventBytes, events, &peer.ChaincodeID{Name: ccID, Version: ccVersion})
+	assert.NoError(t, err)
+
+	endorser, err := signer.Serialize()
+	assert.NoError(t, err)
+	signature, err := signer.Sign(append(prpBytes, endorser...))
+	assert.NoError(t, err)
+	presp := &peer.ProposalResponse{
+		Version:     1,
+		Endorsement: &peer.Endorsement{Signature: signature, Endorser: endorser},
+		Payload:     prpBytes,
+		Response:    &peer.Response{Status: 200, Message: "OK"},
+	}
+
+	tx, err := utils.CreateSignedTx(prop, signer, presp)
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestInvokeMultipleChaincodeEvents(t *testing.T) {
+	ccID := "mycc"
+	events := []*peer.ChaincodeEvent{
+		{ChaincodeId: ccID, EventName: "first"},
+		{ChaincodeId: ccID, EventName: "second"},
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		c := v13Capabilities()
+		c.MultipleChaincodeEventsRv = true
+		l, v := setupLedgerAndValidatorWithCapabilities(t, c)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnvWithEvents(ccID, events, createRWset(t, ccID), t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+	})
+
+	t.Run("NotEnabled", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnvWithEvents(ccID, events, createRWset(t, ccID), t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+	})
+
+	t.Run("OtherChaincode", func(t *testing.T) {
+		c := v13Capabilities()
+		c.MultipleChaincodeEventsRv = true
+		l, v := setupLedgerAndValidatorWithCapabilities(t, c)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		otherEvents := []*peer.ChaincodeEvent{events[0], {ChaincodeId: "othercc", EventName: "second"}}
+		tx := getEnvWithEvents(ccID, otherEvents, createRWset(t, ccID), t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+	})
+}
+
 func TestInvokeNOKDuplicateNs(t *testing.T) {
 	t.Run("1.2Capability", func(t *testing.T) {
 		l, v := setupLedgerAndValidatorWithV12Capabilities(t)
@@ -804,6 +889,133 @@ func TestParallelValidation(t *testing.T) {
 	}
 }
 
+// TestPipelinedValidation validates and commits a sequence of blocks whose
+// transactions depend on the state committed by the previous blocks, both
+// sequentially and with the prevalidation of each block overlapping with the
+// commit of the previous one, and checks that the same flags are set
+func TestPipelinedValidation(t *testing.T) {
+	sequentialFlags := validateAndCommitBlocks(t, false)
+	pipelinedFlags := validateAndCommitBlocks(t, true)
+	assert.Equal(t, sequentialFlags, pipelinedFlags)
+
+	expectedCodes := [][]peer.TxValidationCode{
+		{peer.TxValidationCode_VALID, peer.TxValidationCode_VALID, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE},
+		// the first two transactions are invalid because of the state-based endorsement
+		// policy and the transaction committed in the previous block
+		{peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, peer.TxValidationCode_DUPLICATE_TXID, peer.TxValidationCode_VALID},
+	}
+	for blockNum, codes := range expectedCodes {
+		for txNum, code := range codes {
+			assert.Equal(t, code, pipelinedFlags[blockNum].Flag(txNum), "block %d, transaction %d", blockNum, txNum)
+		}
+	}
+}
+
+func validateAndCommitBlocks(t *testing.T, pipelined bool) []lutils.TxValidationFlags {
+	msp1 := &mockMSP{
+		ID: &mockSI{
+			MspID:        "Org1",
+			SerializedID: []byte("signer0"),
+		},
+		MspID: "Org1",
+	}
+	msp2 := &mockMSP{
+		ID: &mockSI{
+			MspID:        "Org2",
+			SerializedID: []byte("signer1"),
+			SatPrinError: errors.New("nope"),
+		},
+		SatPrinError: errors.New("nope"),
+		MspID:        "Org2",
+	}
+	mgr := mgmt.GetManagerForChain("foochain")
+	mgr.Setup([]msp.MSP{msp1, msp2})
+
+	l, v := setupLedgerAndValidatorExplicitWithMSP(t, &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, V1_3ValidationRv: true}, &builtin.DefaultValidation{}, mgr)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, utils.MarshalOrPanic(cauthdsl.SignedByMspPeer("Org1")), t)
+
+	sigID0 := &mockSI{
+		SerializedID: []byte("signer0"),
+		MspID:        "Org1",
+	}
+	sigID1 := &mockSI{
+		SerializedID: []byte("signer1"),
+		MspID:        "Org2",
+	}
+	tx := func(sig msp.SigningIdentity, build func(rwsetBuilder *rwsetutil.RWSetBuilder)) []byte {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		build(rwsetBuilder)
+		rwset, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		rwsetBytes, err := rwset.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		return utils.MarshalOrPanic(getEnvWithSigner(ccID, nil, rwsetBytes, sig, t))
+	}
+	vpKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	setSBEP := tx(sigID0, func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+		rwsetBuilder.AddToWriteSet(ccID, "key1", []byte("value"))
+		rwsetBuilder.AddToMetadataWriteSet(ccID, "key1", map[string][]byte{vpKey: cauthdsl.MarshaledRejectAllPolicy})
+	})
+	write := tx(sigID0, func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+		rwsetBuilder.AddToWriteSet(ccID, "key2", []byte("value"))
+	})
+	blocksData := [][][]byte{
+		{
+			setSBEP,
+			write,
+			tx(sigID1, func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+				rwsetBuilder.AddToWriteSet(ccID, "key3", []byte("value"))
+			}),
+		},
+		{
+			tx(sigID0, func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+				rwsetBuilder.AddToWriteSet(ccID, "key1", []byte("value"))
+			}),
+			write,
+			tx(sigID0, func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+				rwsetBuilder.AddToWriteSet(ccID, "key3", []byte("value"))
+			}),
+		},
+	}
+
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	var blocks []*common.Block
+	prevHash := bcInfo.CurrentBlockHash
+	for i, data := range blocksData {
+		blockData := &common.BlockData{Data: data}
+		header := &common.BlockHeader{Number: bcInfo.Height + uint64(i), PreviousHash: prevHash, DataHash: blockData.Hash()}
+		blocks = append(blocks, &common.Block{Header: header, Data: blockData})
+		prevHash = header.Hash()
+	}
+
+	// as in the committer, a block is committed while the next one is validated
+	pipelinedValidator := v.(txvalidator.PipelinedValidator)
+	commitErrs := make(chan error, 1)
+	commitErrs <- nil
+	var flags []lutils.TxValidationFlags
+	for _, block := range blocks {
+		if pipelined {
+			prevalidated := pipelinedValidator.Prevalidate(block)
+			assert.NoError(t, <-commitErrs)
+			assert.NoError(t, pipelinedValidator.ValidatePrevalidated(prevalidated))
+		} else {
+			assert.NoError(t, <-commitErrs)
+			assert.NoError(t, v.Validate(block))
+		}
+		flags = append(flags, lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]))
+		go func(block *common.Block) {
+			commitErrs <- l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block}, &ledger.CommitOptions{})
+		}(block)
+	}
+	assert.NoError(t, <-commitErrs)
+	return flags
+}
+
 func TestChaincodeEvent(t *testing.T) {
 	t.Run("PreV1.2", func(t *testing.T) {
 		t.Run("MisMatchedName", func(t *testing.T) {
@@ -1694,11 +1906,23 @@ func (m *mockLedger) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtDat
 	return nil, nil
 }
 
+func (m *mockLedger) RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	return nil, nil
+}
+
 func (m *mockLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, error) {
 	args := m.Called()
 	return args.Get(0).(ledger.MissingPvtDataTracker), nil
 }
 
+func (m *mockLedger) GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error) {
+	return nil, nil
+}
+
+func (m *mockLedger) GetChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (ledger2.ResultsIterator, error) {
+	return nil, nil
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
@@ -17,6 +17,7 @@ import (
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	coreUtil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -66,8 +67,10 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 	   at first, we establish a few facts about this invocation:
 	   1) which namespaces does it write to?
 	   2) does it write to LSCC's namespace?
-	   3) does it write to any cc that cannot be invoked? */
+	   3) does it write to any cc that cannot be invoked?
+	   4) does it write to XSCC's namespace? */
 	writesToLSCC := false
+	writesToXSCC := false
 	writesToNonInvokableSCC := false
 	respPayload, err := utils.GetActionFromEnvelope(envBytes)
 	if err != nil {
@@ -124,6 +127,20 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 	}
 
+	// the events beyond the first one are only recorded, as a list, by the
+	// channels whose peers all know of it
+	if len(respPayload.ChaincodeEvents) > 0 {
+		if !v.support.Capabilities().MultipleChaincodeEvents() {
+			return errors.Errorf("chaincode %s emitted %d events but multiple chaincode events are not enabled", ccID, len(respPayload.ChaincodeEvents)),
+				peer.TxValidationCode_INVALID_OTHER_REASON
+		}
+		for _, ccEvent := range respPayload.ChaincodeEvents {
+			if ccEvent.ChaincodeId != ccID {
+				return errors.Errorf("chaincode event chaincode id does not match chaincode action chaincode id"), peer.TxValidationCode_INVALID_OTHER_REASON
+			}
+		}
+	}
+
 	namespaces := make(map[string]struct{})
 	for _, ns := range txRWSet.NsRwSets {
 		// check to make sure there is no duplicate namespace in txRWSet
@@ -137,6 +154,13 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			continue
 		}
 
+		// the writes to XSCC's namespace are validated with the cross-channel
+		// write intents they record
+		if ns.NameSpace == crosschannel.Namespace {
+			writesToXSCC = true
+			continue
+		}
+
 		// Check to make sure we did not already populate this chaincode
 		// name to avoid checking the same namespace twice
 		if ns.NameSpace != ccID || !alwaysEnforceOriginalNamespace {
@@ -156,6 +180,25 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 	}
 
+	// a transaction recording or completing a cross-channel write intent
+	// is validated according to the endorsement policies of the namespaces
+	// written by the intent as well
+	if writesToXSCC {
+		if !v.support.Capabilities().CrossChannelWrites() {
+			return errors.Errorf("chaincode %s attempted to write to the namespace of %s but cross-channel writes are not enabled", ccID, crosschannel.Namespace),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+		intentNamespaces, err := v.validateCrossChannelWrites(chdr, ccID, txRWSet)
+		if err != nil {
+			return err, peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+		for _, ns := range intentNamespaces {
+			if !contains(wrNamespace, ns) {
+				wrNamespace = append(wrNamespace, ns)
+			}
+		}
+	}
+
 	// we've gathered all the info required to proceed to validation;
 	// validation will behave differently depending on the type of
 	// chaincode (system vs. application)
@@ -185,42 +228,8 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 
 		// validate *EACH* read write set according to its chaincode's endorsement policy
-		for _, ns := range wrNamespace {
-			// Get latest chaincode version, vscc and validate policy
-			txcc, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
-			if err != nil {
-				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
-				return err, peer.TxValidationCode_INVALID_OTHER_REASON
-			}
-
-			// if the namespace corresponds to the cc that was originally
-			// invoked, we check that the version of the cc that was
-			// invoked corresponds to the version that lscc has returned
-			if ns == ccID && txcc.ChaincodeVersion != ccVer {
-				err = errors.Errorf("chaincode %s:%s/%s didn't match %s:%s/%s in lscc", ccID, ccVer, chdr.ChannelId, txcc.ChaincodeName, txcc.ChaincodeVersion, chdr.ChannelId)
-				logger.Errorf("%+v", err)
-				return err, peer.TxValidationCode_EXPIRED_CHAINCODE
-			}
-
-			// do VSCC validation
-			ctx := &Context{
-				Seq:       seq,
-				Envelope:  envBytes,
-				Block:     block,
-				TxID:      chdr.TxId,
-				Channel:   chdr.ChannelId,
-				Namespace: ns,
-				Policy:    policy,
-				VSCCName:  vscc.ChaincodeName,
-			}
-			if err = v.VSCCValidateTxForCC(ctx); err != nil {
-				switch err.(type) {
-				case *commonerrors.VSCCEndorsementPolicyError:
-					return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
-				default:
-					return err, peer.TxValidationCode_INVALID_OTHER_REASON
-				}
-			}
+		if err, code := v.validateNamespaces(seq, envBytes, block, chdr, ccID, ccVer, wrNamespace); err != nil {
+			return err, code
 		}
 	} else {
 		// make sure that we can invoke this system chaincode - if the chaincode
@@ -262,11 +271,74 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
 			}
 		}
+
+		// XSCC writes to the namespaces of the application chaincodes when
+		// it commits a cross-channel write intent, so such a transaction has
+		// to satisfy their endorsement policies as well
+		if ccID == crosschannel.Namespace {
+			var namespaces []string
+			for _, ns := range wrNamespace {
+				if ns == ccID {
+					continue
+				}
+				if v.sccprovider.IsSysCC(ns) {
+					return errors.Errorf("chaincode %s attempted to write to the namespace of system chaincode %s", ccID, ns),
+						peer.TxValidationCode_ILLEGAL_WRITESET
+				}
+				namespaces = append(namespaces, ns)
+			}
+			if err, code := v.validateNamespaces(seq, envBytes, block, chdr, ccID, ccVer, namespaces); err != nil {
+				return err, code
+			}
+		}
 	}
 	logger.Debugf("[%s] VSCCValidateTx completes env bytes %p", chainID, envBytes)
 	return nil, peer.TxValidationCode_VALID
 }
 
+// validateNamespaces validates the transaction according to the endorsement
+// policy of each of the given namespaces
+func (v *VsccValidatorImpl) validateNamespaces(seq int, envBytes []byte, block *common.Block, chdr *common.ChannelHeader, ccID, ccVer string, namespaces []string) (error, peer.TxValidationCode) {
+	for _, ns := range namespaces {
+		// Get latest chaincode version, vscc and validate policy
+		txcc, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
+		if err != nil {
+			logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
+			return err, peer.TxValidationCode_INVALID_OTHER_REASON
+		}
+
+		// if the namespace corresponds to the cc that was originally
+		// invoked, we check that the version of the cc that was
+		// invoked corresponds to the version that lscc has returned
+		if ns == ccID && txcc.ChaincodeVersion != ccVer {
+			err = errors.Errorf("chaincode %s:%s/%s didn't match %s:%s/%s in lscc", ccID, ccVer, chdr.ChannelId, txcc.ChaincodeName, txcc.ChaincodeVersion, chdr.ChannelId)
+			logger.Errorf("%+v", err)
+			return err, peer.TxValidationCode_EXPIRED_CHAINCODE
+		}
+
+		// do VSCC validation
+		ctx := &Context{
+			Seq:       seq,
+			Envelope:  envBytes,
+			Block:     block,
+			TxID:      chdr.TxId,
+			Channel:   chdr.ChannelId,
+			Namespace: ns,
+			Policy:    policy,
+			VSCCName:  vscc.ChaincodeName,
+		}
+		if err = v.VSCCValidateTxForCC(ctx); err != nil {
+			switch err.(type) {
+			case *commonerrors.VSCCEndorsementPolicyError:
+				return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+			default:
+				return err, peer.TxValidationCode_INVALID_OTHER_REASON
+			}
+		}
+	}
+	return nil, peer.TxValidationCode_VALID
+}
+
 func (v *VsccValidatorImpl) VSCCValidateTxForCC(ctx *Context) error {
 	logger.Debug("Validating", ctx, "with plugin")
 	err := v.pluginValidator.ValidateWithPlugin(ctx)
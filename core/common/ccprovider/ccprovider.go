@@ -497,7 +497,7 @@ type ChaincodeContainerInfo struct {
 	Type        string
 	CodePackage []byte
 
-	// ContainerType is not a great name, but 'DOCKER' and 'SYSTEM' are the valid types
+	// ContainerType is not a great name, but 'DOCKER', 'SYSTEM', 'EXTERNAL' and 'WASM' are the valid types
 	ContainerType string
 }
 
@@ -523,20 +523,25 @@ type TransactionParams struct {
 // should be added below if necessary
 type ChaincodeProvider interface {
 	// Execute executes a standard chaincode invocation for a chaincode and an input
-	Execute(txParams *TransactionParams, cccid *CCContext, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
+	Execute(txParams *TransactionParams, cccid *CCContext, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error)
 	// ExecuteLegacyInit is a special case for executing chaincode deployment specs,
 	// which are not already in the LSCC, needed for old lifecycle
-	ExecuteLegacyInit(txParams *TransactionParams, cccid *CCContext, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error)
+	ExecuteLegacyInit(txParams *TransactionParams, cccid *CCContext, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error)
 	// Stop stops the chaincode give
 	Stop(ccci *ChaincodeContainerInfo) error
 }
 
 func DeploymentSpecToChaincodeContainerInfo(cds *pb.ChaincodeDeploymentSpec) *ChaincodeContainerInfo {
+	containerType := cds.ExecEnv.String()
+	// WebAssembly chaincode always runs in the peer, whatever its execution environment
+	if cds.CCType() == pb.ChaincodeSpec_WASM.String() {
+		containerType = pb.ChaincodeSpec_WASM.String()
+	}
 	return &ChaincodeContainerInfo{
 		Name:          cds.Name(),
 		Version:       cds.Version(),
 		Path:          cds.Path(),
 		Type:          cds.CCType(),
-		ContainerType: cds.ExecEnv.String(),
+		ContainerType: containerType,
 	}
 }
@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package crosschannel holds the conventions shared by the peer components
+// which implement the writes of a transaction spanning two channels hosted by
+// the same peer.
+//
+// A transaction of a source channel whose chaincode invokes a chaincode of a
+// target channel records a write intent in the namespace of the cross-channel
+// system chaincode. The writes of the transaction on the source channel are
+// deferred into the intent, and the keys they write are locked until the
+// intent is either committed or aborted by the cross-channel system chaincode,
+// which records the intent on the target channel as well.
+package crosschannel
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// Namespace is the name of the cross-channel system chaincode, whose namespace
+// holds the write intents and the locks of the keys they write.
+const Namespace = "xscc"
+
+// These are the functions of the cross-channel system chaincode
+const (
+	Prepare   = "Prepare"
+	Commit    = "Commit"
+	Abort     = "Abort"
+	GetIntent = "GetIntent"
+)
+
+const (
+	intentPrefix  = "intent"
+	pendingPrefix = "pending"
+	lockPrefix    = "lock"
+	separator     = "\x00"
+)
+
+// IntentKey returns the key of the write intent recorded by the given transaction
+func IntentKey(txID string) string {
+	return intentPrefix + separator + txID
+}
+
+// IsIntentKey returns true if the key is the key of a write intent
+func IsIntentKey(key string) bool {
+	return strings.HasPrefix(key, intentPrefix+separator)
+}
+
+// PendingIntentKey returns the key under which an invocation of a chaincode of
+// another channel records its write intent during the simulation of the given
+// transaction. As the invocations don't share a simulator, each of them records
+// its intent under its own key, until the intent of the transaction is built
+// from them once its simulation is complete.
+func PendingIntentKey(txID string, intent []byte) string {
+	return pendingPrefix + separator + txID + separator + hex.EncodeToString(util.ComputeSHA256(intent))
+}
+
+// LockKey returns the key of the lock of the given key of a namespace
+func LockKey(namespace, key string) string {
+	return lockPrefix + separator + namespace + separator + key
+}
+
+// IsLockKey returns true if the key is the key of a lock
+func IsLockKey(key string) bool {
+	return strings.HasPrefix(key, lockPrefix+separator)
+}
+
+// LockKeyRange returns the start and end keys of the range of the keys of the
+// locks in the namespace of the cross-channel system chaincode
+func LockKeyRange() (string, string) {
+	return lockPrefix + separator, lockPrefix + "\x01"
+}
+
+// NsWrites holds the writes of a transaction to the keys of a namespace
+type NsWrites struct {
+	Namespace string
+	Writes    []*kvrwset.KVWrite
+}
+
+// Writes returns the writes to the namespaces other than the cross-channel
+// namespace of the given serialized TxReadWriteSet
+func Writes(results []byte) ([]*NsWrites, error) {
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(results, txRWSet); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling read-write set")
+	}
+
+	var writes []*NsWrites
+	for _, nsRWSet := range txRWSet.NsRwset {
+		if nsRWSet.Namespace == Namespace {
+			continue
+		}
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling read-write set of namespace %s", nsRWSet.Namespace)
+		}
+		if len(kvRWSet.Writes) == 0 {
+			continue
+		}
+		writes = append(writes, &NsWrites{Namespace: nsRWSet.Namespace, Writes: kvRWSet.Writes})
+	}
+	return writes, nil
+}
+
+// IntentWrites returns the writes of the intent to the given channel
+func IntentWrites(intent *pb.CrossChannelIntent, channelID string) ([]*NsWrites, error) {
+	switch channelID {
+	case intent.SourceChannel:
+		return Writes(intent.SourceResults)
+	case intent.TargetChannel:
+		return Writes(intent.TargetResults)
+	default:
+		return nil, errors.Errorf("intent of transaction %s doesn't involve channel %s", intent.TxId, channelID)
+	}
+}
+
+// LockKeys returns the sorted keys of the locks of the given writes
+func LockKeys(writes []*NsWrites) []string {
+	var keys []string
+	for _, nsWrites := range writes {
+		for _, write := range nsWrites.Writes {
+			keys = append(keys, LockKey(nsWrites.Namespace, write.Key))
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeferWrites turns the pending write intent recorded during the simulation
+// of a transaction into the write intent of the transaction, moves the writes
+// of its public read-write set into the source results of the intent, and
+// locks the keys they write. The reads of the transaction are kept, so that
+// they are validated when the transaction is committed. It returns false if
+// the transaction didn't record a pending write intent.
+func DeferWrites(txRWSet *rwset.TxReadWriteSet, txID string) (bool, error) {
+	var xsccRWSet *rwset.NsReadWriteSet
+	for _, nsRWSet := range txRWSet.NsRwset {
+		if nsRWSet.Namespace == Namespace {
+			xsccRWSet = nsRWSet
+		}
+	}
+	if xsccRWSet == nil {
+		return false, nil
+	}
+
+	xsccKVRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(xsccRWSet.Rwset, xsccKVRWSet); err != nil {
+		return false, errors.Wrapf(err, "error unmarshaling read-write set of namespace %s", Namespace)
+	}
+	var pendingWrites, xsccWrites []*kvrwset.KVWrite
+	for _, write := range xsccKVRWSet.Writes {
+		if strings.HasPrefix(write.Key, pendingPrefix+separator+txID+separator) {
+			pendingWrites = append(pendingWrites, write)
+			continue
+		}
+		xsccWrites = append(xsccWrites, write)
+	}
+	switch len(pendingWrites) {
+	case 0:
+		return false, nil
+	case 1:
+	default:
+		return false, errors.Errorf("transaction %s wrote to the chaincodes of another channel through %d invocations, but a single one is supported", txID, len(pendingWrites))
+	}
+
+	intent := &pb.CrossChannelIntent{}
+	if err := proto.Unmarshal(pendingWrites[0].Value, intent); err != nil {
+		return false, errors.Wrap(err, "error unmarshaling write intent")
+	}
+
+	deferred := &rwset.TxReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
+	for _, nsRWSet := range txRWSet.NsRwset {
+		if nsRWSet.Namespace == Namespace {
+			continue
+		}
+		if len(nsRWSet.CollectionHashedRwset) != 0 {
+			return false, errors.Errorf("transaction %s accesses private data of namespace %s, which is not supported by cross-channel writes", txID, nsRWSet.Namespace)
+		}
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return false, errors.Wrapf(err, "error unmarshaling read-write set of namespace %s", nsRWSet.Namespace)
+		}
+		if len(kvRWSet.MetadataWrites) != 0 {
+			return false, errors.Errorf("transaction %s writes metadata of namespace %s, which is not supported by cross-channel writes", txID, nsRWSet.Namespace)
+		}
+		if len(kvRWSet.Writes) == 0 {
+			continue
+		}
+
+		deferredBytes, err := proto.Marshal(&kvrwset.KVRWSet{Writes: kvRWSet.Writes})
+		if err != nil {
+			return false, errors.Wrap(err, "error marshaling deferred writes")
+		}
+		deferred.NsRwset = append(deferred.NsRwset, &rwset.NsReadWriteSet{
+			Namespace: nsRWSet.Namespace,
+			Rwset:     deferredBytes,
+		})
+		for _, write := range kvRWSet.Writes {
+			xsccWrites = append(xsccWrites, &kvrwset.KVWrite{
+				Key:   LockKey(nsRWSet.Namespace, write.Key),
+				Value: []byte(txID),
+			})
+		}
+
+		kvRWSet.Writes = nil
+		if nsRWSet.Rwset, err = proto.Marshal(kvRWSet); err != nil {
+			return false, errors.Wrap(err, "error marshaling read-write set")
+		}
+	}
+
+	var err error
+	if intent.SourceResults, err = proto.Marshal(deferred); err != nil {
+		return false, errors.Wrap(err, "error marshaling deferred writes")
+	}
+	intentBytes, err := proto.Marshal(intent)
+	if err != nil {
+		return false, errors.Wrap(err, "error marshaling write intent")
+	}
+	xsccWrites = append(xsccWrites, &kvrwset.KVWrite{Key: IntentKey(txID), Value: intentBytes})
+	sort.Slice(xsccWrites, func(i, j int) bool {
+		return xsccWrites[i].Key < xsccWrites[j].Key
+	})
+	xsccKVRWSet.Writes = xsccWrites
+	if xsccRWSet.Rwset, err = proto.Marshal(xsccKVRWSet); err != nil {
+		return false, errors.Wrap(err, "error marshaling read-write set")
+	}
+	return true, nil
+}
+
+// AddReads adds to the public read-write set of a transaction the reads of the
+// given serialized TxReadWriteSet, so that the transaction is only valid if the
+// keys they read were not updated since.
+func AddReads(txRWSet *rwset.TxReadWriteSet, results []byte) error {
+	added := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(results, added); err != nil {
+		return errors.Wrap(err, "error unmarshaling read-write set")
+	}
+
+	for _, addedRWSet := range added.NsRwset {
+		if addedRWSet.Namespace == Namespace {
+			continue
+		}
+		addedKVRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(addedRWSet.Rwset, addedKVRWSet); err != nil {
+			return errors.Wrapf(err, "error unmarshaling read-write set of namespace %s", addedRWSet.Namespace)
+		}
+		if len(addedKVRWSet.Reads) == 0 {
+			continue
+		}
+
+		var nsRWSet *rwset.NsReadWriteSet
+		for _, n := range txRWSet.NsRwset {
+			if n.Namespace == addedRWSet.Namespace {
+				nsRWSet = n
+			}
+		}
+		if nsRWSet == nil {
+			nsRWSet = &rwset.NsReadWriteSet{Namespace: addedRWSet.Namespace}
+			txRWSet.NsRwset = append(txRWSet.NsRwset, nsRWSet)
+		}
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return errors.Wrapf(err, "error unmarshaling read-write set of namespace %s", nsRWSet.Namespace)
+		}
+
+		read := make(map[string]struct{})
+		for _, r := range kvRWSet.Reads {
+			read[r.Key] = struct{}{}
+		}
+		for _, r := range addedKVRWSet.Reads {
+			if _, ok := read[r.Key]; !ok {
+				kvRWSet.Reads = append(kvRWSet.Reads, r)
+			}
+		}
+		sort.Slice(kvRWSet.Reads, func(i, j int) bool {
+			return kvRWSet.Reads[i].Key < kvRWSet.Reads[j].Key
+		})
+
+		var err error
+		if nsRWSet.Rwset, err = proto.Marshal(kvRWSet); err != nil {
+			return errors.Wrap(err, "error marshaling read-write set")
+		}
+	}
+	sort.Slice(txRWSet.NsRwset, func(i, j int) bool {
+		return txRWSet.NsRwset[i].Namespace < txRWSet.NsRwset[j].Namespace
+	})
+	return nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosschannel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func nsRWSet(namespace string, kvRWSet *kvrwset.KVRWSet) *rwset.NsReadWriteSet {
+	return &rwset.NsReadWriteSet{Namespace: namespace, Rwset: marshalOrPanic(kvRWSet)}
+}
+
+func kvRWSetOf(t *testing.T, nsRWSet *rwset.NsReadWriteSet) *kvrwset.KVRWSet {
+	kvRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(nsRWSet.Rwset, kvRWSet))
+	return kvRWSet
+}
+
+func TestKeys(t *testing.T) {
+	assert.True(t, IsIntentKey(IntentKey("tx1")))
+	assert.False(t, IsLockKey(IntentKey("tx1")))
+	assert.True(t, IsLockKey(LockKey("mycc", "key")))
+	assert.False(t, IsIntentKey(LockKey("mycc", "key")))
+	assert.NotEqual(t, LockKey("a", "b\x00c"), LockKey("a\x00b", "c\x00"))
+
+	writes := []*NsWrites{
+		{Namespace: "mycc", Writes: []*kvrwset.KVWrite{{Key: "b"}, {Key: "a"}}},
+		{Namespace: "acc", Writes: []*kvrwset.KVWrite{{Key: "z"}}},
+	}
+	assert.Equal(t, []string{LockKey("acc", "z"), LockKey("mycc", "a"), LockKey("mycc", "b")}, LockKeys(writes))
+}
+
+func TestIntentWrites(t *testing.T) {
+	intent := &pb.CrossChannelIntent{
+		TxId:          "tx1",
+		SourceChannel: "source",
+		TargetChannel: "target",
+		SourceResults: marshalOrPanic(&rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc1", &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "k1", Value: []byte("v1")}}}),
+		}}),
+		TargetResults: marshalOrPanic(&rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc2", &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "r"}}}),
+			nsRWSet("cc3", &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "k3", IsDelete: true}}}),
+		}}),
+	}
+
+	writes, err := IntentWrites(intent, "source")
+	assert.NoError(t, err)
+	assert.Len(t, writes, 1)
+	assert.Equal(t, "cc1", writes[0].Namespace)
+	assert.Equal(t, "k1", writes[0].Writes[0].Key)
+
+	writes, err = IntentWrites(intent, "target")
+	assert.NoError(t, err)
+	assert.Len(t, writes, 1)
+	assert.Equal(t, "cc3", writes[0].Namespace)
+	assert.True(t, writes[0].Writes[0].IsDelete)
+
+	_, err = IntentWrites(intent, "other")
+	assert.EqualError(t, err, "intent of transaction tx1 doesn't involve channel other")
+
+	intent.SourceResults = []byte("garbage")
+	_, err = IntentWrites(intent, "source")
+	assert.Error(t, err)
+}
+
+func TestDeferWrites(t *testing.T) {
+	intent := &pb.CrossChannelIntent{TxId: "tx1", SourceChannel: "source", TargetChannel: "target"}
+	txRWSet := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc1", &kvrwset.KVRWSet{
+				Reads:  []*kvrwset.KVRead{{Key: "r1", Version: &kvrwset.Version{BlockNum: 1}}},
+				Writes: []*kvrwset.KVWrite{{Key: "k1", Value: []byte("v1")}, {Key: "k2", IsDelete: true}},
+			}),
+			nsRWSet("cc2", &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "r2"}}}),
+			nsRWSet(Namespace, &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: PendingIntentKey("tx1", marshalOrPanic(intent)), Value: marshalOrPanic(intent)}}}),
+		},
+	}
+
+	deferred, err := DeferWrites(txRWSet, "tx1")
+	assert.NoError(t, err)
+	assert.True(t, deferred)
+
+	cc1 := kvRWSetOf(t, txRWSet.NsRwset[0])
+	assert.Empty(t, cc1.Writes)
+	assert.Len(t, cc1.Reads, 1)
+	assert.Len(t, kvRWSetOf(t, txRWSet.NsRwset[1]).Reads, 1)
+
+	xscc := kvRWSetOf(t, txRWSet.NsRwset[2])
+	assert.Len(t, xscc.Writes, 3)
+	assert.Equal(t, IntentKey("tx1"), xscc.Writes[0].Key)
+	assert.Equal(t, LockKey("cc1", "k1"), xscc.Writes[1].Key)
+	assert.Equal(t, []byte("tx1"), xscc.Writes[1].Value)
+	assert.Equal(t, LockKey("cc1", "k2"), xscc.Writes[2].Key)
+
+	recorded := &pb.CrossChannelIntent{}
+	assert.NoError(t, proto.Unmarshal(xscc.Writes[0].Value, recorded))
+	writes, err := IntentWrites(recorded, "source")
+	assert.NoError(t, err)
+	assert.Len(t, writes, 1)
+	assert.Equal(t, "cc1", writes[0].Namespace)
+	assert.Len(t, writes[0].Writes, 2)
+	assert.Equal(t, xscc.Writes[1].Key, LockKeys(writes)[0])
+}
+
+func TestDeferWritesNoIntent(t *testing.T) {
+	txRWSet := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc1", &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "k1"}}}),
+		},
+	}
+	deferred, err := DeferWrites(txRWSet, "tx1")
+	assert.NoError(t, err)
+	assert.False(t, deferred)
+
+	// the pending intent of another transaction isn't the intent of this one
+	txRWSet.NsRwset = append(txRWSet.NsRwset, nsRWSet(Namespace, &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: PendingIntentKey("tx2", []byte{}), Value: []byte{}}},
+	}))
+	deferred, err = DeferWrites(txRWSet, "tx1")
+	assert.NoError(t, err)
+	assert.False(t, deferred)
+	assert.Len(t, kvRWSetOf(t, txRWSet.NsRwset[0]).Writes, 1)
+}
+
+func TestDeferWritesUnsupported(t *testing.T) {
+	intentRWSet := nsRWSet(Namespace, &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: PendingIntentKey("tx1", []byte("intent")), Value: marshalOrPanic(&pb.CrossChannelIntent{TxId: "tx1"})}},
+	})
+
+	txRWSet := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			{Namespace: "cc1", CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{{CollectionName: "coll"}}},
+			intentRWSet,
+		},
+	}
+	_, err := DeferWrites(txRWSet, "tx1")
+	assert.EqualError(t, err, "transaction tx1 accesses private data of namespace cc1, which is not supported by cross-channel writes")
+
+	txRWSet = &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc1", &kvrwset.KVRWSet{MetadataWrites: []*kvrwset.KVMetadataWrite{{Key: "k1"}}}),
+			intentRWSet,
+		},
+	}
+	_, err = DeferWrites(txRWSet, "tx1")
+	assert.EqualError(t, err, "transaction tx1 writes metadata of namespace cc1, which is not supported by cross-channel writes")
+
+	txRWSet = &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet(Namespace, &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{
+				{Key: PendingIntentKey("tx1", []byte("intent1"))},
+				{Key: PendingIntentKey("tx1", []byte("intent2"))},
+			}}),
+		},
+	}
+	_, err = DeferWrites(txRWSet, "tx1")
+	assert.EqualError(t, err, "transaction tx1 wrote to the chaincodes of another channel through 2 invocations, but a single one is supported")
+}
+
+func TestAddReads(t *testing.T) {
+	txRWSet := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			nsRWSet("cc2", &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "b"}}}),
+			nsRWSet(Namespace, &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: IntentKey("tx1")}}}),
+		},
+	}
+	results := marshalOrPanic(&rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{
+		nsRWSet("cc1", &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "a", Version: &kvrwset.Version{BlockNum: 3}}}}),
+		nsRWSet("cc2", &kvrwset.KVRWSet{
+			Reads:  []*kvrwset.KVRead{{Key: "b"}, {Key: "a"}},
+			Writes: []*kvrwset.KVWrite{{Key: "c"}},
+		}),
+		nsRWSet("cc3", &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "d"}}}),
+		nsRWSet(Namespace, &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "ignored"}}}),
+	}})
+
+	err := AddReads(txRWSet, results)
+	assert.NoError(t, err)
+	assert.Len(t, txRWSet.NsRwset, 3)
+
+	assert.Equal(t, "cc1", txRWSet.NsRwset[0].Namespace)
+	cc1 := kvRWSetOf(t, txRWSet.NsRwset[0])
+	assert.Len(t, cc1.Reads, 1)
+	assert.Equal(t, uint64(3), cc1.Reads[0].Version.BlockNum)
+
+	assert.Equal(t, "cc2", txRWSet.NsRwset[1].Namespace)
+	cc2 := kvRWSetOf(t, txRWSet.NsRwset[1])
+	assert.Len(t, cc2.Reads, 2)
+	assert.Equal(t, "a", cc2.Reads[0].Key)
+	assert.Empty(t, cc2.Writes)
+
+	assert.Equal(t, Namespace, txRWSet.NsRwset[2].Namespace)
+	assert.Len(t, kvRWSetOf(t, txRWSet.NsRwset[2]).Reads, 1)
+
+	assert.Error(t, AddReads(txRWSet, []byte("garbage")))
+}
@@ -8,7 +8,7 @@ package ccintf
 
 //This package defines the interfaces that support runtime and
 //communication between chaincode and peer (chaincode support).
-//Currently inproccontroller uses it. dockercontroller does not.
+//Currently inproccontroller and externalcontroller use it. dockercontroller does not.
 
 import (
 	"fmt"
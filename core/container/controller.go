@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package container
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -147,6 +148,18 @@ func (b *PlatformBuilder) Build() (io.Reader, error) {
 	)
 }
 
+// CodePackageBuilder implements the Build interface by returning the code
+// package itself, for the VMs which need the package rather than an image
+// built from it.
+type CodePackageBuilder struct {
+	CodePackage []byte
+}
+
+// Build returns a reader of the code package
+func (b *CodePackageBuilder) Build() (io.Reader, error) {
+	return bytes.NewReader(b.CodePackage), nil
+}
+
 func (si StartContainerReq) Do(v VM) error {
 	return v.Start(si.CCID, si.Args, si.Env, si.FilesToUpload, si.Builder)
 }
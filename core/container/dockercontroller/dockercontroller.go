@@ -35,6 +35,10 @@ import (
 // is registered with the container.VMController
 const ContainerType = "DOCKER"
 
+// cpuPeriod is the period, in microseconds, of the CFS scheduler of the
+// containers whose CPUs are limited
+const cpuPeriod = 100000
+
 var (
 	dockerLogger = flogging.MustGetLogger("dockercontroller")
 	hostConfig   *docker.HostConfig
@@ -51,6 +55,10 @@ type DockerVM struct {
 	PeerID       string
 	NetworkID    string
 	BuildMetrics *BuildMetrics
+
+	// ResourceLimits are the limits of the resources of the containers
+	// of the chaincodes
+	ResourceLimits container.ResourceLimitsConfig
 }
 
 //go:generate counterfeiter -o mock/dockerclient.go --fake-name DockerClient . dockerClient
@@ -91,9 +99,10 @@ type dockerClient interface {
 
 // Provider implements container.VMProvider
 type Provider struct {
-	PeerID       string
-	NetworkID    string
-	BuildMetrics *BuildMetrics
+	PeerID         string
+	NetworkID      string
+	BuildMetrics   *BuildMetrics
+	ResourceLimits container.ResourceLimitsConfig
 }
 
 // NewProvider creates a new instance of Provider
@@ -107,7 +116,9 @@ func NewProvider(peerID, networkID string, metricsProvider metrics.Provider) *Pr
 
 // NewVM creates a new DockerVM instance
 func (p *Provider) NewVM() container.VM {
-	return NewDockerVM(p.PeerID, p.NetworkID, p.BuildMetrics)
+	vm := NewDockerVM(p.PeerID, p.NetworkID, p.BuildMetrics)
+	vm.ResourceLimits = p.ResourceLimits
+	return vm
 }
 
 // NewDockerVM returns a new DockerVM instance
@@ -173,7 +184,25 @@ func getDockerHostConfig() *docker.HostConfig {
 	}
 }
 
-func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool) error {
+// getHostConfig returns the docker host config of the container of the
+// chaincode, whose memory and CPUs are limited by the resource limits of the
+// chaincode, if any
+func (vm *DockerVM) getHostConfig(ccName string) *docker.HostConfig {
+	config := *getDockerHostConfig()
+	limits := vm.ResourceLimits.For(ccName)
+	if limits.Memory != 0 {
+		// the container may not swap beyond its memory limit
+		config.Memory = limits.Memory
+		config.MemorySwap = limits.Memory
+	}
+	if limits.CPUs != 0 {
+		config.CPUPeriod = cpuPeriod
+		config.CPUQuota = int64(limits.CPUs * cpuPeriod)
+	}
+	return &config
+}
+
+func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, hostConfig *docker.HostConfig, attachStdout bool) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
 	logger.Debugw("create container")
 	_, err := client.CreateContainer(docker.CreateContainerOptions{
@@ -185,7 +214,7 @@ func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID st
 			AttachStdout: attachStdout,
 			AttachStderr: attachStdout,
 		},
-		HostConfig: getDockerHostConfig(),
+		HostConfig: hostConfig,
 	})
 	if err != nil {
 		return err
@@ -247,7 +276,8 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 
 	vm.stopInternal(client, containerName, 0, false, false)
 
-	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+	hostConfig := vm.getHostConfig(ccid.Name)
+	err = vm.createContainer(client, imageName, containerName, args, env, hostConfig, attachStdout)
 	if err == docker.ErrNoSuchImage {
 		reader, err := builder.Build()
 		if err != nil {
@@ -259,7 +289,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 			return err
 		}
 
-		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+		err = vm.createContainer(client, imageName, containerName, args, env, hostConfig, attachStdout)
 		if err != nil {
 			logger.Errorf("failed to create container: %s", err)
 			return err
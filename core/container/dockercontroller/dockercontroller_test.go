@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/container/dockercontroller/mock"
 	coreutil "github.com/hyperledger/fabric/core/testutil"
@@ -82,6 +83,44 @@ func TestGetDockerHostConfig(t *testing.T) {
 	assert.Equal(t, int64(0), hostConfig.CPUShares)
 }
 
+func TestStartWithResourceLimits(t *testing.T) {
+	coreutil.SetupTestConfig()
+	client := &mock.DockerClient{}
+	client.CreateContainerReturns(&docker.Container{}, nil)
+	provider := &Provider{
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		ResourceLimits: container.ResourceLimitsConfig{
+			Default: container.ResourceLimits{Memory: 256 * 1024 * 1024},
+			Chaincodes: []container.ChaincodeResourceLimits{
+				{Name: "limited", ResourceLimits: container.ResourceLimits{CPUs: 0.5}},
+			},
+		},
+	}
+	dvm := provider.NewVM().(*DockerVM)
+	dvm.getClientFnc = func() (dockerClient, error) { return client, nil }
+
+	err := dvm.Start(ccintf.CCID{Name: "limited", Version: "1.0"}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	hostConfig := client.CreateContainerArgsForCall(0).HostConfig
+	assert.Equal(t, int64(256*1024*1024), hostConfig.Memory)
+	assert.Equal(t, int64(256*1024*1024), hostConfig.MemorySwap)
+	assert.Equal(t, int64(100000), hostConfig.CPUPeriod)
+	assert.Equal(t, int64(50000), hostConfig.CPUQuota)
+	assert.Equal(t, "host", hostConfig.NetworkMode)
+
+	err = dvm.Start(ccintf.CCID{Name: "other", Version: "1.0"}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	hostConfig = client.CreateContainerArgsForCall(1).HostConfig
+	assert.Equal(t, int64(256*1024*1024), hostConfig.Memory)
+	assert.Equal(t, int64(0), hostConfig.CPUQuota)
+
+	// the configured host config applies to the chaincodes without limits
+	dvm.ResourceLimits = container.ResourceLimitsConfig{}
+	err = dvm.Start(ccintf.CCID{Name: "other", Version: "1.0"}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, getDockerHostConfig(), client.CreateContainerArgsForCall(2).HostConfig)
+}
+
 func Test_Start(t *testing.T) {
 	gt := NewGomegaWithT(t)
 
@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// DefaultEnvWhitelist are the environment variables of the peer which are
+// passed to all the builders, in addition to their own whitelist
+var DefaultEnvWhitelist = []string{"LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+// MetadataFile is the file of the metadata directory holding the ChaincodeMetadata
+const MetadataFile = "metadata.json"
+
+// RunConfigFile is the file of the run metadata directory holding the RunConfig
+const RunConfigFile = "chaincode.json"
+
+// Config is the configuration of an external builder, from the
+// chaincode.externalBuilders section of core.yaml
+type Config struct {
+	Name                 string   `mapstructure:"name" yaml:"name"`
+	Path                 string   `mapstructure:"path" yaml:"path"`
+	EnvironmentWhitelist []string `mapstructure:"environmentWhitelist" yaml:"environmentWhitelist"`
+}
+
+// ChaincodeMetadata is the metadata of the chaincode given to the builders
+type ChaincodeMetadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+}
+
+// RunConfig is the configuration of the chaincode given to the run executable.
+// The files the chaincode expects, such as its TLS material, are placed in the
+// run metadata directory at the path they are expected at, and the environment
+// variables referencing them are updated accordingly
+type RunConfig struct {
+	ChaincodeID string   `json:"chaincode_id"`
+	Args        []string `json:"args"`
+}
+
+// BuildContext holds the directories given to the builders for a chaincode,
+// all of which are within a scratch directory removed by Cleanup
+type BuildContext struct {
+	CCID        string
+	ScratchDir  string
+	SourceDir   string
+	MetadataDir string
+	BldDir      string
+	RunDir      string
+}
+
+// NewBuildContext creates the directories of the build context, extracts the
+// gzipped tar code package into the source directory and writes the metadata
+// of the chaincode into the metadata directory
+func NewBuildContext(ccid string, md *ChaincodeMetadata, codePackage []byte) (bc *BuildContext, err error) {
+	scratchDir, err := ioutil.TempDir("", "fabric-"+strings.Replace(ccid, string(os.PathSeparator), "-", -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the scratch directory")
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(scratchDir)
+		}
+	}()
+
+	bc = &BuildContext{
+		CCID:        ccid,
+		ScratchDir:  scratchDir,
+		SourceDir:   filepath.Join(scratchDir, "src"),
+		MetadataDir: filepath.Join(scratchDir, "metadata"),
+		BldDir:      filepath.Join(scratchDir, "bld"),
+		RunDir:      filepath.Join(scratchDir, "run"),
+	}
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BldDir, bc.RunDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return nil, errors.Wrapf(err, "could not create directory %s", dir)
+		}
+	}
+
+	if err := untar(codePackage, bc.SourceDir); err != nil {
+		return nil, errors.WithMessage(err, "could not extract the code package")
+	}
+
+	mdBytes, err := json.Marshal(md)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal the chaincode metadata")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.MetadataDir, MetadataFile), mdBytes, 0600); err != nil {
+		return nil, errors.Wrap(err, "could not write the chaincode metadata")
+	}
+
+	return bc, nil
+}
+
+// Cleanup removes the directories of the build context
+func (bc *BuildContext) Cleanup() {
+	os.RemoveAll(bc.ScratchDir)
+}
+
+func untar(codePackage []byte, dir string) error {
+	if len(codePackage) == 0 {
+		return nil
+	}
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return errors.Wrap(err, "could not read the code package")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read the code package")
+		}
+
+		name := filepath.Join(dir, filepath.Clean("/"+header.Name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(name, 0700); err != nil {
+				return errors.Wrapf(err, "could not create directory %s", name)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+				return errors.Wrapf(err, "could not create directory %s", filepath.Dir(name))
+			}
+			if err := writeFile(name, tr, os.FileMode(header.Mode).Perm()|0600); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("illegal file type %v of %s", header.Typeflag, header.Name)
+		}
+	}
+}
+
+func writeFile(name string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return errors.Wrapf(err, "could not create file %s", name)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Wrapf(err, "could not write file %s", name)
+	}
+	return nil
+}
+
+// Builder is an external builder, whose location holds the bin/detect,
+// bin/build and bin/run executables
+type Builder struct {
+	Name         string
+	Location     string
+	EnvWhitelist []string
+	Logger       *flogging.FabricLogger
+}
+
+// NewBuilders creates the builders of the given configuration, in the order
+// they are tried in
+func NewBuilders(configs []Config) []*Builder {
+	var builders []*Builder
+	for _, c := range configs {
+		builders = append(builders, &Builder{
+			Name:         c.Name,
+			Location:     c.Path,
+			EnvWhitelist: c.EnvironmentWhitelist,
+			Logger:       logger.Named(c.Name),
+		})
+	}
+	return builders
+}
+
+// Detect runs bin/detect SOURCE_DIR METADATA_DIR, whose successful exit tells
+// that the builder builds the chaincode
+func (b *Builder) Detect(bc *BuildContext) bool {
+	cmd := b.newCommand("detect", bc.SourceDir, bc.MetadataDir)
+	if err := b.runCommand(cmd); err != nil {
+		b.Logger.Debugf("Detection for chaincode '%s' failed: %s", bc.CCID, err)
+		return false
+	}
+	return true
+}
+
+// Build runs bin/build SOURCE_DIR METADATA_DIR BUILD_OUTPUT_DIR
+func (b *Builder) Build(bc *BuildContext) error {
+	cmd := b.newCommand("build", bc.SourceDir, bc.MetadataDir, bc.BldDir)
+	if err := b.runCommand(cmd); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("external builder '%s' failed to build chaincode '%s'", b.Name, bc.CCID))
+	}
+	return nil
+}
+
+// Run starts bin/run BUILD_OUTPUT_DIR RUN_METADATA_DIR, whose process is the
+// chaincode and is given the environment variables of the chaincode
+func (b *Builder) Run(bc *BuildContext, args []string, env []string, files map[string][]byte) (*Session, error) {
+	runConfig := &RunConfig{ChaincodeID: bc.CCID, Args: args}
+	runConfigBytes, err := json.Marshal(runConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal the run configuration")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.RunDir, RunConfigFile), runConfigBytes, 0600); err != nil {
+		return nil, errors.Wrap(err, "could not write the run configuration")
+	}
+
+	for path, contents := range files {
+		name := filepath.Join(bc.RunDir, filepath.Clean("/"+path))
+		if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+			return nil, errors.Wrapf(err, "could not create directory %s", filepath.Dir(name))
+		}
+		if err := writeFile(name, bytes.NewReader(contents), 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := b.newCommand("run", bc.BldDir, bc.RunDir)
+	for _, e := range env {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 2 {
+			if _, ok := files[kv[1]]; ok {
+				e = kv[0] + "=" + filepath.Join(bc.RunDir, filepath.Clean("/"+kv[1]))
+			}
+		}
+		cmd.Env = append(cmd.Env, e)
+	}
+
+	return startSession(cmd, b.Logger.With("command", "run", "chaincode", bc.CCID))
+}
+
+func (b *Builder) newCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(filepath.Join(b.Location, "bin", name), args...)
+	whitelist := append(append([]string{}, DefaultEnvWhitelist...), b.EnvWhitelist...)
+	for _, key := range whitelist {
+		if val, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, key+"="+val)
+		}
+	}
+	return cmd
+}
+
+func (b *Builder) runCommand(cmd *exec.Cmd) error {
+	session, err := startSession(cmd, b.Logger.With("command", filepath.Base(cmd.Path)))
+	if err != nil {
+		return err
+	}
+	exitCode, err := session.Wait()
+	if err != nil {
+		return errors.Wrapf(err, "%s failed", filepath.Base(cmd.Path))
+	}
+	if exitCode != 0 {
+		return errors.Errorf("%s exited with code %d", filepath.Base(cmd.Path), exitCode)
+	}
+	return nil
+}
+
+// Session is a running process started by a builder, whose standard error is logged
+type Session struct {
+	cmd    *exec.Cmd
+	exited chan struct{}
+	err    error
+}
+
+func startSession(cmd *exec.Cmd, logger *flogging.FabricLogger) (*Session, error) {
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the standard error")
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "could not start %s", cmd.Path)
+	}
+
+	session := &Session{
+		cmd:    cmd,
+		exited: make(chan struct{}),
+	}
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			logger.Info(scanner.Text())
+		}
+		session.err = cmd.Wait()
+		close(session.exited)
+	}()
+
+	return session, nil
+}
+
+// Wait blocks until the process exits and returns its exit code
+func (s *Session) Wait() (int, error) {
+	<-s.exited
+	if s.err == nil {
+		return 0, nil
+	}
+	if exitErr, ok := s.err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			if status.Signaled() {
+				return 128 + int(status.Signal()), nil
+			}
+			return status.ExitStatus(), nil
+		}
+	}
+	return -1, s.err
+}
+
+// Terminate sends SIGTERM to the process and kills it unless it exits within the timeout
+func (s *Session) Terminate(timeout time.Duration) {
+	s.cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-s.exited:
+	case <-time.After(timeout):
+		s.cmd.Process.Kill()
+		<-s.exited
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, headers ...*tar.Header) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, header := range headers {
+		contents := []byte("contents of " + header.Name)
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(contents))
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := tw.Write(contents)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func goCodePackage(t *testing.T) []byte {
+	return codePackage(t,
+		&tar.Header{Name: "src/github.com/mycc/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "src/github.com/mycc/main.go", Typeflag: tar.TypeReg, Mode: 0644},
+		&tar.Header{Name: "META-INF/statedb/couchdb/indexes/index.json", Typeflag: tar.TypeReg, Mode: 0644},
+	)
+}
+
+var goMetadata = &ChaincodeMetadata{Name: "mycc", Version: "1.0", Path: "github.com/mycc", Type: "GOLANG"}
+
+func TestNewBuildContext(t *testing.T) {
+	bc, err := NewBuildContext("mycc:1.0", goMetadata, goCodePackage(t))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+
+	assert.Equal(t, "mycc:1.0", bc.CCID)
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BldDir, bc.RunDir} {
+		assert.Equal(t, bc.ScratchDir, filepath.Dir(dir))
+	}
+	main, err := ioutil.ReadFile(filepath.Join(bc.SourceDir, "src/github.com/mycc/main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "contents of src/github.com/mycc/main.go", string(main))
+	_, err = os.Stat(filepath.Join(bc.SourceDir, "META-INF/statedb/couchdb/indexes/index.json"))
+	assert.NoError(t, err)
+	md, err := ioutil.ReadFile(filepath.Join(bc.MetadataDir, MetadataFile))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"mycc","version":"1.0","path":"github.com/mycc","type":"GOLANG"}`, string(md))
+
+	bc.Cleanup()
+	_, err = os.Stat(bc.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewBuildContextCodePackage(t *testing.T) {
+	// the files can't escape the source directory
+	bc, err := NewBuildContext("mycc:1.0", goMetadata, codePackage(t,
+		&tar.Header{Name: "../../escaped", Typeflag: tar.TypeReg, Mode: 0644},
+	))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+	_, err = os.Stat(filepath.Join(bc.SourceDir, "escaped"))
+	assert.NoError(t, err)
+
+	_, err = NewBuildContext("mycc:1.0", goMetadata, codePackage(t,
+		&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+	))
+	assert.EqualError(t, err, "could not extract the code package: illegal file type 50 of link")
+
+	_, err = NewBuildContext("mycc:1.0", goMetadata, []byte("garbage"))
+	assert.Contains(t, err.Error(), "could not extract the code package: could not read the code package")
+}
+
+func TestNewBuilders(t *testing.T) {
+	builders := NewBuilders([]Config{
+		{Name: "builder1", Path: "/opt/builder1", EnvironmentWhitelist: []string{"GOPROXY"}},
+		{Name: "builder2", Path: "/opt/builder2"},
+	})
+	require.Len(t, builders, 2)
+	assert.Equal(t, "builder1", builders[0].Name)
+	assert.Equal(t, "/opt/builder1", builders[0].Location)
+	assert.Equal(t, []string{"GOPROXY"}, builders[0].EnvWhitelist)
+	assert.NotNil(t, builders[0].Logger)
+	assert.Equal(t, "builder2", builders[1].Name)
+}
+
+func TestBuilderDetectAndBuild(t *testing.T) {
+	builders := NewBuilders([]Config{
+		{Name: "goodbuilder", Path: "testdata/goodbuilder"},
+		{Name: "failbuilder", Path: "testdata/failbuilder"},
+		{Name: "missingbuilder", Path: "testdata/missingbuilder"},
+	})
+
+	bc, err := NewBuildContext("mycc:1.0", goMetadata, goCodePackage(t))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+
+	assert.True(t, builders[0].Detect(bc))
+	assert.True(t, builders[1].Detect(bc))
+	assert.False(t, builders[2].Detect(bc))
+
+	err = builders[0].Build(bc)
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(bc.BldDir, "src/github.com/mycc/main.go"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(bc.BldDir, MetadataFile))
+	assert.NoError(t, err)
+
+	err = builders[1].Build(bc)
+	assert.EqualError(t, err, "external builder 'failbuilder' failed to build chaincode 'mycc:1.0': build exited with code 1")
+
+	// only the golang chaincodes are detected by the good builder
+	nodeBC, err := NewBuildContext("mycc:1.0", &ChaincodeMetadata{Name: "mycc", Version: "1.0", Type: "NODE"}, nil)
+	require.NoError(t, err)
+	defer nodeBC.Cleanup()
+	assert.False(t, builders[0].Detect(nodeBC))
+}
+
+func TestBuilderEnvironment(t *testing.T) {
+	os.Setenv("EXTERNALBUILDER_TEST_WHITELISTED", "yes")
+	os.Setenv("EXTERNALBUILDER_TEST_NOT_WHITELISTED", "no")
+	defer os.Unsetenv("EXTERNALBUILDER_TEST_WHITELISTED")
+	defer os.Unsetenv("EXTERNALBUILDER_TEST_NOT_WHITELISTED")
+
+	b := &Builder{Name: "builder", Location: "/opt/builder", EnvWhitelist: []string{"EXTERNALBUILDER_TEST_WHITELISTED"}}
+	cmd := b.newCommand("build", "arg")
+	assert.Equal(t, "/opt/builder/bin/build", cmd.Path)
+	assert.Equal(t, []string{"/opt/builder/bin/build", "arg"}, cmd.Args)
+	assert.Contains(t, cmd.Env, "EXTERNALBUILDER_TEST_WHITELISTED=yes")
+	assert.Contains(t, cmd.Env, "PATH="+os.Getenv("PATH"))
+	assert.NotContains(t, cmd.Env, "EXTERNALBUILDER_TEST_NOT_WHITELISTED=no")
+}
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("externalbuilder")
+
+// instance is a chaincode built and run by an external builder
+type instance struct {
+	buildContext *BuildContext
+	session      *Session
+}
+
+// Provider builds and runs the chaincodes with the first of its builders which
+// detects them, and hands the chaincodes none of them detects over to the
+// fallback, which is usually the docker provider. It implements container.VMProvider
+type Provider struct {
+	Builders []*Builder
+	Fallback container.VMProvider
+
+	mutex     sync.Mutex
+	instances map[string]*instance
+}
+
+// NewProvider creates a Provider trying the given builders in order. The fallback
+// may be nil, in which case the chaincodes must be detected by one of the builders
+func NewProvider(builders []*Builder, fallback container.VMProvider) *Provider {
+	return &Provider{
+		Builders:  builders,
+		Fallback:  fallback,
+		instances: make(map[string]*instance),
+	}
+}
+
+// NewVM creates an ExternalBuilderVM instance
+func (p *Provider) NewVM() container.VM {
+	vm := &ExternalBuilderVM{provider: p}
+	if p.Fallback != nil {
+		vm.fallback = p.Fallback.NewVM()
+	}
+	return vm
+}
+
+func (p *Provider) getInstance(name string) *instance {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.instances[name]
+}
+
+func (p *Provider) setInstance(name string, i *instance) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.instances[name] = i
+}
+
+func (p *Provider) removeInstance(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.instances, name)
+}
+
+// ExternalBuilderVM is a vm running the chaincodes with the external builders
+// of its provider, or with the fallback vm
+type ExternalBuilderVM struct {
+	provider *Provider
+	fallback container.VM
+}
+
+// Start builds and runs the chaincode with the first builder detecting it. The
+// builder must be a container.PlatformBuilder, which holds the code package
+func (vm *ExternalBuilderVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	name := ccid.GetName()
+	if i := vm.provider.getInstance(name); i != nil {
+		vm.stop(name, i, 0)
+	}
+
+	platformBuilder, ok := builder.(*container.PlatformBuilder)
+	if !ok || len(vm.provider.Builders) == 0 {
+		return vm.fallbackStart(ccid, args, env, filesToUpload, builder)
+	}
+
+	md := &ChaincodeMetadata{
+		Name:    platformBuilder.Name,
+		Version: platformBuilder.Version,
+		Path:    platformBuilder.Path,
+		Type:    platformBuilder.Type,
+	}
+	bc, err := NewBuildContext(ccid.Name+":"+ccid.Version, md, platformBuilder.CodePackage)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range vm.provider.Builders {
+		if !b.Detect(bc) {
+			continue
+		}
+
+		logger.Infof("Building chaincode %s with external builder '%s'", name, b.Name)
+		if err := b.Build(bc); err != nil {
+			bc.Cleanup()
+			return err
+		}
+		session, err := b.Run(bc, args, env, filesToUpload)
+		if err != nil {
+			bc.Cleanup()
+			return errors.WithMessage(err, "failed to run chaincode with external builder '"+b.Name+"'")
+		}
+		vm.provider.setInstance(name, &instance{buildContext: bc, session: session})
+		return nil
+	}
+
+	bc.Cleanup()
+	return vm.fallbackStart(ccid, args, env, filesToUpload, builder)
+}
+
+func (vm *ExternalBuilderVM) fallbackStart(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	if vm.fallback == nil {
+		return errors.Errorf("no external builder detected chaincode %s", ccid.GetName())
+	}
+	return vm.fallback.Start(ccid, args, env, filesToUpload, builder)
+}
+
+func (vm *ExternalBuilderVM) stop(name string, i *instance, timeout uint) {
+	i.session.Terminate(time.Duration(timeout) * time.Second)
+	i.buildContext.Cleanup()
+	vm.provider.removeInstance(name)
+}
+
+// Stop terminates the chaincode, killing it unless it exits within the timeout, in seconds
+func (vm *ExternalBuilderVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	name := ccid.GetName()
+	if i := vm.provider.getInstance(name); i != nil {
+		vm.stop(name, i, timeout)
+		return nil
+	}
+	if vm.fallback == nil {
+		return errors.Errorf("%s not found", name)
+	}
+	return vm.fallback.Stop(ccid, timeout, dontkill, dontremove)
+}
+
+// Wait blocks until the chaincode exits and returns its exit code
+func (vm *ExternalBuilderVM) Wait(ccid ccintf.CCID) (int, error) {
+	name := ccid.GetName()
+	if i := vm.provider.getInstance(name); i != nil {
+		return i.session.Wait()
+	}
+	if vm.fallback == nil {
+		return 0, errors.Errorf("%s not found", name)
+	}
+	return vm.fallback.Wait(ccid)
+}
+
+// HealthCheck checks the fallback vm, as the external builders have no health to check
+func (vm *ExternalBuilderVM) HealthCheck(ctx context.Context) error {
+	if vm.fallback == nil {
+		return nil
+	}
+	return vm.fallback.HealthCheck(ctx)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/mock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProvider(fallback container.VMProvider) *Provider {
+	return NewProvider(NewBuilders([]Config{
+		{Name: "goodbuilder", Path: "testdata/goodbuilder"},
+	}), fallback)
+}
+
+func platformBuilder(t *testing.T, ccType string) *container.PlatformBuilder {
+	return &container.PlatformBuilder{
+		Type:        ccType,
+		Name:        "mycc",
+		Version:     "1.0",
+		Path:        "github.com/mycc",
+		CodePackage: goCodePackage(t),
+	}
+}
+
+func waitForFile(t *testing.T, name string) []byte {
+	for i := 0; i < 500; i++ {
+		if contents, err := ioutil.ReadFile(name); err == nil {
+			return contents
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was not written", name)
+	return nil
+}
+
+func TestStartWaitStop(t *testing.T) {
+	provider := newProvider(nil)
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	env := []string{"CORE_CHAINCODE_ID_NAME=mycc:1.0", "CORE_TLS_CLIENT_KEY_PATH=/etc/hyperledger/fabric/client.key"}
+	files := map[string][]byte{"/etc/hyperledger/fabric/client.key": []byte("key")}
+	err := vm.Start(ccid, []string{"chaincode", "-peer.address=peer:7052"}, env, files, platformBuilder(t, "GOLANG"))
+	require.NoError(t, err)
+
+	i := provider.getInstance("mycc-1.0")
+	require.NotNil(t, i)
+	runDir := i.buildContext.RunDir
+
+	// the run executable is given the build output, the run configuration,
+	// the files of the chaincode and its environment
+	built := waitForFile(t, filepath.Join(runDir, "built.json"))
+	assert.JSONEq(t, `{"name":"mycc","version":"1.0","path":"github.com/mycc","type":"GOLANG"}`, string(built))
+	runConfig, err := ioutil.ReadFile(filepath.Join(runDir, RunConfigFile))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"chaincode_id":"mycc:1.0","args":["chaincode","-peer.address=peer:7052"]}`, string(runConfig))
+	key := waitForFile(t, filepath.Join(runDir, "key"))
+	assert.Equal(t, "key", string(key))
+	runEnv := string(waitForFile(t, filepath.Join(runDir, "env")))
+	assert.Contains(t, runEnv, "CORE_CHAINCODE_ID_NAME=mycc:1.0\n")
+	assert.Contains(t, runEnv, "CORE_TLS_CLIENT_KEY_PATH="+filepath.Join(runDir, "etc/hyperledger/fabric/client.key")+"\n")
+
+	exited := make(chan int, 1)
+	go func() {
+		exitCode, err := vm.Wait(ccid)
+		assert.NoError(t, err)
+		exited <- exitCode
+	}()
+
+	err = vm.Stop(ccid, 5, false, false)
+	assert.NoError(t, err)
+	select {
+	case exitCode := <-exited:
+		// terminated by SIGTERM
+		assert.Equal(t, 143, exitCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("chaincode did not exit")
+	}
+	assert.Nil(t, provider.getInstance("mycc-1.0"))
+	_, err = os.Stat(runDir)
+	assert.True(t, os.IsNotExist(err))
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+}
+
+func TestStartExitCode(t *testing.T) {
+	provider := newProvider(nil)
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	err := vm.Start(ccid, nil, []string{"EXIT_CODE=3"}, nil, platformBuilder(t, "GOLANG"))
+	require.NoError(t, err)
+	exitCode, err := vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+
+	// a restarted chaincode replaces the exited one
+	err = vm.Start(ccid, nil, []string{"EXIT_CODE=4"}, nil, platformBuilder(t, "GOLANG"))
+	require.NoError(t, err)
+	exitCode, err = vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, exitCode)
+
+	assert.NoError(t, vm.Stop(ccid, 0, false, false))
+}
+
+func TestStartFailures(t *testing.T) {
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	vm := newProvider(nil).NewVM()
+	err := vm.Start(ccid, nil, nil, nil, platformBuilder(t, "NODE"))
+	assert.EqualError(t, err, "no external builder detected chaincode mycc-1.0")
+
+	err = vm.Start(ccid, nil, nil, nil, &container.PlatformBuilder{Type: "GOLANG", CodePackage: []byte("garbage")})
+	assert.Contains(t, err.Error(), "could not extract the code package")
+
+	vm = NewProvider(NewBuilders([]Config{
+		{Name: "failbuilder", Path: "testdata/failbuilder"},
+		{Name: "goodbuilder", Path: "testdata/goodbuilder"},
+	}), nil).NewVM()
+	err = vm.Start(ccid, nil, nil, nil, platformBuilder(t, "GOLANG"))
+	assert.EqualError(t, err, "external builder 'failbuilder' failed to build chaincode 'mycc:1.0': build exited with code 1")
+}
+
+func TestFallback(t *testing.T) {
+	fakeVM := &mock.VM{}
+	fakeVM.WaitReturns(7, nil)
+	fakeVM.HealthCheckReturns(errors.New("docker-unhealthy"))
+	fakeProvider := &mock.VMProvider{}
+	fakeProvider.NewVMReturns(fakeVM)
+
+	provider := newProvider(fakeProvider)
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	// the chaincodes no builder detects are started by the fallback
+	builder := platformBuilder(t, "NODE")
+	err := vm.Start(ccid, []string{"arg"}, []string{"env"}, nil, builder)
+	assert.NoError(t, err)
+	require.Equal(t, 1, fakeVM.StartCallCount())
+	startCCID, args, env, _, startBuilder := fakeVM.StartArgsForCall(0)
+	assert.Equal(t, ccid, startCCID)
+	assert.Equal(t, []string{"arg"}, args)
+	assert.Equal(t, []string{"env"}, env)
+	assert.Equal(t, builder, startBuilder)
+	assert.Nil(t, provider.getInstance("mycc-1.0"))
+
+	exitCode, err := vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, exitCode)
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeVM.StopCallCount())
+	err = vm.HealthCheck(context.Background())
+	assert.EqualError(t, err, "docker-unhealthy")
+
+	// so are the chaincodes which aren't built from a platform
+	err = vm.Start(ccid, nil, nil, nil, &mock.Builder{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fakeVM.StartCallCount())
+
+	// as well as all the chaincodes when no builder is configured
+	vm = NewProvider(nil, fakeProvider).NewVM()
+	err = vm.Start(ccid, nil, nil, nil, platformBuilder(t, "GOLANG"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fakeVM.StartCallCount())
+}
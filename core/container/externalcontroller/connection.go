@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/pkg/errors"
+)
+
+// ConnectionFile is the file of the code package of a chaincode running as an
+// external service which holds the information needed to connect to it
+const ConnectionFile = "connection.json"
+
+// ConnectionInfo is the information needed to connect to a chaincode running as an
+// external service. The certificates and the key are PEM encoded
+type ConnectionInfo struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout,omitempty"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key,omitempty"`
+	ClientCert         string `json:"client_cert,omitempty"`
+	RootCert           string `json:"root_cert,omitempty"`
+}
+
+// ConnectionInfoFromCodePackage reads and validates the connection information
+// held by the connection file of the given gzipped tar code package
+func ConnectionInfoFromCodePackage(codePackage []byte) (*ConnectionInfo, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the code package")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("the code package does not contain %s", ConnectionFile)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the code package")
+		}
+		if header.Name != ConnectionFile {
+			continue
+		}
+		connInfoBytes, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", ConnectionFile)
+		}
+		connInfo := &ConnectionInfo{}
+		if err := json.Unmarshal(connInfoBytes, connInfo); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", ConnectionFile)
+		}
+		if err := connInfo.validate(); err != nil {
+			return nil, errors.WithMessage(err, "invalid "+ConnectionFile)
+		}
+		return connInfo, nil
+	}
+}
+
+func (c *ConnectionInfo) validate() error {
+	if c.Address == "" {
+		return errors.New("chaincode address is not specified")
+	}
+	if c.DialTimeout != "" {
+		if _, err := time.ParseDuration(c.DialTimeout); err != nil {
+			return errors.Wrapf(err, "invalid dial timeout [%s]", c.DialTimeout)
+		}
+	}
+	if !c.TLSRequired {
+		return nil
+	}
+	if c.RootCert == "" {
+		return errors.New("root certificate is required when TLS is required")
+	}
+	if c.ClientAuthRequired && (c.ClientKey == "" || c.ClientCert == "") {
+		return errors.New("client key and certificate are required when client authentication is required")
+	}
+	return nil
+}
+
+// ClientConfig returns the configuration of the gRPC client connecting to the chaincode
+func (c *ConnectionInfo) ClientConfig() (comm.ClientConfig, error) {
+	dialTimeout := DefaultDialTimeout
+	if c.DialTimeout != "" {
+		var err error
+		if dialTimeout, err = time.ParseDuration(c.DialTimeout); err != nil {
+			return comm.ClientConfig{}, errors.Wrapf(err, "invalid dial timeout [%s]", c.DialTimeout)
+		}
+	}
+	secOpts := &comm.SecureOptions{UseTLS: c.TLSRequired}
+	if c.TLSRequired {
+		secOpts.ServerRootCAs = [][]byte{[]byte(c.RootCert)}
+		if c.ClientAuthRequired {
+			secOpts.RequireClientCert = true
+			secOpts.Certificate = []byte(c.ClientCert)
+			secOpts.Key = []byte(c.ClientKey)
+		}
+	}
+	return comm.ClientConfig{
+		SecOpts: secOpts,
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: dialTimeout,
+	}, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalcontroller
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// ContainerType is the string which the external container type
+// is registered with the container.VMController
+const ContainerType = "EXTERNAL"
+
+// DefaultDialTimeout is the timeout of the connection to a chaincode
+// whose connection information does not specify one
+const DefaultDialTimeout = 10 * time.Second
+
+var logger = flogging.MustGetLogger("externalcontroller")
+
+// connection is an established connection to a chaincode running as an external service
+type connection struct {
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Provider tracks the connections to the chaincodes running as external services.
+// It implements container.VMProvider
+type Provider struct {
+	mutex       sync.Mutex
+	connections map[string]*connection
+
+	// ChaincodeSupport handles the streams established with the chaincodes.
+	// It must be set before any chaincode is started
+	ChaincodeSupport ccintf.CCSupport
+}
+
+// NewProvider creates a Provider, whose ChaincodeSupport must be set before use
+func NewProvider() *Provider {
+	return &Provider{
+		connections: make(map[string]*connection),
+	}
+}
+
+// NewVM creates an ExternalVM instance
+func (p *Provider) NewVM() container.VM {
+	return &ExternalVM{provider: p}
+}
+
+func (p *Provider) getConnection(name string) *connection {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.connections[name]
+}
+
+func (p *Provider) setConnection(name string, c *connection) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.connections[name] = c
+}
+
+func (p *Provider) removeConnection(name string, c *connection) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.connections[name] == c {
+		delete(p.connections, name)
+	}
+}
+
+// ExternalVM is a vm which, instead of running a chaincode, connects to a chaincode
+// running as an external service, using the connection information of its code package
+type ExternalVM struct {
+	provider *Provider
+}
+
+// Start connects to the chaincode and hands the established stream over to the chaincode support,
+// over which the chaincode registers. The builder must return the code package of the chaincode
+func (vm *ExternalVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	if vm.provider.ChaincodeSupport == nil {
+		logger.Panicf("Chaincode support is nil, most likely you forgot to set it immediately after calling externalcontroller.NewProvider()")
+	}
+
+	name := ccid.GetName()
+	if vm.provider.getConnection(name) != nil {
+		return errors.Errorf("chaincode %s is already connected", name)
+	}
+
+	reader, err := builder.Build()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get the code package")
+	}
+	codePackage, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the code package")
+	}
+	connInfo, err := ConnectionInfoFromCodePackage(codePackage)
+	if err != nil {
+		return err
+	}
+	clientConfig, err := connInfo.ClientConfig()
+	if err != nil {
+		return err
+	}
+	grpcClient, err := comm.NewGRPCClient(clientConfig)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the client of the chaincode")
+	}
+
+	logger.Debugf("connecting to chaincode %s at %s", name, connInfo.Address)
+	conn, err := grpcClient.NewConnection(connInfo.Address, "")
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to connect to chaincode %s at %s", name, connInfo.Address))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return errors.Wrapf(err, "failed to establish a stream with chaincode %s", name)
+	}
+
+	c := &connection{
+		conn:   conn,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	vm.provider.setConnection(name, c)
+	go func() {
+		defer close(c.done)
+		defer conn.Close()
+		defer vm.provider.removeConnection(name, c)
+		err := vm.provider.ChaincodeSupport.HandleChaincodeStream(stream)
+		logger.Debugf("stream with chaincode %s ended with err: %v", name, err)
+	}()
+
+	return nil
+}
+
+// Stop closes the connection to the chaincode
+func (vm *ExternalVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	name := ccid.GetName()
+	c := vm.provider.getConnection(name)
+	if c == nil {
+		return errors.Errorf("%s not found", name)
+	}
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// Wait blocks until the connection to the chaincode is closed
+func (vm *ExternalVM) Wait(ccid ccintf.CCID) (int, error) {
+	name := ccid.GetName()
+	c := vm.provider.getConnection(name)
+	if c == nil {
+		return 0, errors.Errorf("%s not found", name)
+	}
+	<-c.done
+	return 0, nil
+}
+
+// HealthCheck is provided in order to implement the VM interface.
+// It always returns nil, as the chaincodes are connected to on demand
+func (vm *ExternalVM) HealthCheck(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChaincode struct{}
+
+func (mockChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (mockChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// mockCCSupport records the registration of the chaincode and then keeps the
+// stream open until it is closed
+type mockCCSupport struct {
+	registered chan *pb.ChaincodeID
+}
+
+func (m *mockCCSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	chaincodeID := &pb.ChaincodeID{}
+	if err := proto.Unmarshal(msg.Payload, chaincodeID); err != nil {
+		return err
+	}
+	m.registered <- chaincodeID
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func codePackage(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(contents)), Mode: 0100644}))
+		_, err := tw.Write(contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func connectionPackage(t *testing.T, connInfo *ConnectionInfo) []byte {
+	connInfoBytes, err := json.Marshal(connInfo)
+	require.NoError(t, err)
+	return codePackage(t, map[string][]byte{ConnectionFile: connInfoBytes})
+}
+
+func TestConnectionInfoFromCodePackage(t *testing.T) {
+	connInfo, err := ConnectionInfoFromCodePackage(connectionPackage(t, &ConnectionInfo{Address: "localhost:9999", DialTimeout: "3s"}))
+	assert.NoError(t, err)
+	assert.Equal(t, &ConnectionInfo{Address: "localhost:9999", DialTimeout: "3s"}, connInfo)
+
+	_, err = ConnectionInfoFromCodePackage([]byte("garbage"))
+	assert.Contains(t, err.Error(), "failed to read the code package")
+
+	_, err = ConnectionInfoFromCodePackage(codePackage(t, map[string][]byte{"main.go": []byte("package main")}))
+	assert.EqualError(t, err, "the code package does not contain connection.json")
+
+	_, err = ConnectionInfoFromCodePackage(codePackage(t, map[string][]byte{ConnectionFile: []byte("{")}))
+	assert.Contains(t, err.Error(), "failed to unmarshal connection.json")
+
+	tests := []struct {
+		connInfo    *ConnectionInfo
+		expectedErr string
+	}{
+		{&ConnectionInfo{}, "invalid connection.json: chaincode address is not specified"},
+		{&ConnectionInfo{Address: "localhost:9999", DialTimeout: "forever"}, "invalid connection.json: invalid dial timeout [forever]"},
+		{&ConnectionInfo{Address: "localhost:9999", TLSRequired: true}, "invalid connection.json: root certificate is required when TLS is required"},
+		{&ConnectionInfo{Address: "localhost:9999", TLSRequired: true, RootCert: "cert", ClientAuthRequired: true}, "invalid connection.json: client key and certificate are required when client authentication is required"},
+	}
+	for _, test := range tests {
+		_, err := ConnectionInfoFromCodePackage(connectionPackage(t, test.connInfo))
+		assert.Contains(t, err.Error(), test.expectedErr)
+	}
+}
+
+func TestClientConfig(t *testing.T) {
+	clientConfig, err := (&ConnectionInfo{Address: "localhost:9999"}).ClientConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDialTimeout, clientConfig.Timeout)
+	assert.False(t, clientConfig.SecOpts.UseTLS)
+
+	clientConfig, err = (&ConnectionInfo{
+		Address:            "localhost:9999",
+		DialTimeout:        "3s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		RootCert:           "root",
+		ClientCert:         "cert",
+		ClientKey:          "key",
+	}).ClientConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "3s", clientConfig.Timeout.String())
+	assert.Equal(t, &comm.SecureOptions{
+		UseTLS:            true,
+		ServerRootCAs:     [][]byte{[]byte("root")},
+		RequireClientCert: true,
+		Certificate:       []byte("cert"),
+		Key:               []byte("key"),
+	}, clientConfig.SecOpts)
+}
+
+func TestStartStop(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	// serve the chaincode like ChaincodeServer.Start does, on an ephemeral port
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:            true,
+			Certificate:       serverPair.Cert,
+			Key:               serverPair.Key,
+			RequireClientCert: true,
+			ClientRootCAs:     [][]byte{ca.CertBytes()},
+		},
+	})
+	require.NoError(t, err)
+	pb.RegisterChaincodeServer(server.Server(), &shim.ChaincodeServer{CCID: "mycc:1.0", CC: mockChaincode{}})
+	go server.Start()
+	defer server.Stop()
+
+	ccSupport := &mockCCSupport{registered: make(chan *pb.ChaincodeID, 1)}
+	provider := NewProvider()
+	provider.ChaincodeSupport = ccSupport
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	builder := &container.CodePackageBuilder{CodePackage: connectionPackage(t, &ConnectionInfo{
+		Address:            server.Address(),
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		RootCert:           string(ca.CertBytes()),
+		ClientCert:         string(clientPair.Cert),
+		ClientKey:          string(clientPair.Key),
+	})}
+
+	err = vm.Start(ccid, nil, nil, nil, builder)
+	require.NoError(t, err)
+	assert.Equal(t, "mycc:1.0", (<-ccSupport.registered).Name)
+
+	err = vm.Start(ccid, nil, nil, nil, builder)
+	assert.EqualError(t, err, "chaincode mycc-1.0 is already connected")
+
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := vm.Wait(ccid)
+		waitErr <- err
+	}()
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	select {
+	case err := <-waitErr:
+		// Wait either returned on close of the connection or found it closed
+		if err != nil {
+			assert.EqualError(t, err, "mycc-1.0 not found")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return after Stop")
+	}
+	assert.Nil(t, provider.getConnection(ccid.GetName()))
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+}
+
+func TestStartFailures(t *testing.T) {
+	provider := NewProvider()
+	provider.ChaincodeSupport = &mockCCSupport{}
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	err := vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{CodePackage: []byte("garbage")})
+	assert.Contains(t, err.Error(), "failed to read the code package")
+
+	err = vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{CodePackage: connectionPackage(t, &ConnectionInfo{})})
+	assert.EqualError(t, err, "invalid connection.json: chaincode address is not specified")
+
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+
+	provider.ChaincodeSupport = nil
+	assert.Panics(t, func() { vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{}) })
+}
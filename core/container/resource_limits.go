@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+// ResourceLimits are the limits of the resources a chaincode may use.
+// A zero value leaves the resource as the VM of the chaincode configures it.
+type ResourceLimits struct {
+	// Memory is the number of bytes of memory
+	Memory int64 `mapstructure:"memory" yaml:"memory"`
+	// CPUs is the number of CPUs, which may be fractional
+	CPUs float64 `mapstructure:"cpus" yaml:"cpus"`
+}
+
+// ChaincodeResourceLimits are the resource limits of a chaincode, which
+// override the default limits
+type ChaincodeResourceLimits struct {
+	Name           string `mapstructure:"name" yaml:"name"`
+	ResourceLimits `mapstructure:",squash" yaml:",inline"`
+}
+
+// ResourceLimitsConfig holds the default resource limits of the chaincodes
+// and the limits of the chaincodes which override them
+type ResourceLimitsConfig struct {
+	Default    ResourceLimits            `mapstructure:"default" yaml:"default"`
+	Chaincodes []ChaincodeResourceLimits `mapstructure:"chaincodes" yaml:"chaincodes"`
+}
+
+// For returns the resource limits of the named chaincode. The limits which
+// are set for the chaincode take precedence over the default ones.
+func (c ResourceLimitsConfig) For(ccName string) ResourceLimits {
+	limits := c.Default
+	for _, cc := range c.Chaincodes {
+		if cc.Name != ccName {
+			continue
+		}
+		if cc.Memory != 0 {
+			limits.Memory = cc.Memory
+		}
+		if cc.CPUs != 0 {
+			limits.CPUs = cc.CPUs
+		}
+	}
+	return limits
+}
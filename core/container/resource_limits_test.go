@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLimitsFor(t *testing.T) {
+	config := container.ResourceLimitsConfig{
+		Default: container.ResourceLimits{Memory: 1024, CPUs: 1},
+		Chaincodes: []container.ChaincodeResourceLimits{
+			{Name: "big", ResourceLimits: container.ResourceLimits{Memory: 4096, CPUs: 2.5}},
+			{Name: "small", ResourceLimits: container.ResourceLimits{Memory: 512}},
+		},
+	}
+
+	assert.Equal(t, container.ResourceLimits{Memory: 1024, CPUs: 1}, config.For("other"))
+	assert.Equal(t, container.ResourceLimits{Memory: 4096, CPUs: 2.5}, config.For("big"))
+	assert.Equal(t, container.ResourceLimits{Memory: 512, CPUs: 1}, config.For("small"))
+	assert.Equal(t, container.ResourceLimits{}, container.ResourceLimitsConfig{}.For("other"))
+}
+
+func TestResourceLimitsConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(bytes.NewBufferString(`
+chaincode:
+  resourceLimits:
+    default:
+      memory: 268435456
+      cpus: 0.5
+    chaincodes:
+      - name: mycc
+        memory: 1073741824
+      - name: othercc
+        cpus: 2
+`))
+	require.NoError(t, err)
+
+	var config container.ResourceLimitsConfig
+	err = viperutil.EnhancedExactUnmarshalKey("chaincode.resourceLimits", &config)
+	require.NoError(t, err)
+	assert.Equal(t, container.ResourceLimitsConfig{
+		Default: container.ResourceLimits{Memory: 268435456, CPUs: 0.5},
+		Chaincodes: []container.ChaincodeResourceLimits{
+			{Name: "mycc", ResourceLimits: container.ResourceLimits{Memory: 1073741824}},
+			{Name: "othercc", ResourceLimits: container.ResourceLimits{CPUs: 2}},
+		},
+	}, config)
+}
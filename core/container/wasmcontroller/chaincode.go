@@ -0,0 +1,339 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller/interpreter"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ModuleFile is the file of the code package of WebAssembly chaincode which
+// holds its module
+const ModuleFile = "chaincode.wasm"
+
+// HostModule is the module which the host functions are imported from
+const HostModule = "fabric"
+
+// These are the functions which WebAssembly chaincode must export. They take
+// no arguments and return no results, as they communicate with the peer
+// through the host functions.
+const (
+	InitFunction   = "init"
+	InvokeFunction = "invoke"
+)
+
+// noResult is returned by the host functions which produce a result, as an
+// i32, when there is none
+const noResult = math.MaxUint32
+
+var (
+	i32 = interpreter.I32
+	i64 = interpreter.I64
+)
+
+func funcType(params []interpreter.ValueType, results ...interpreter.ValueType) interpreter.FuncType {
+	return interpreter.FuncType{Params: params, Results: results}
+}
+
+// call holds the state of a call of the init or invoke function of an instance
+type call struct {
+	stub    shim.ChaincodeStubInterface
+	result  []byte
+	payload []byte
+	message string
+	failed  bool
+}
+
+// hostFunctions returns the functions WebAssembly chaincode can import from
+// the host module. They are the only way for chaincode to access the world
+// outside of its instance, so that its execution only depends on the
+// proposal and the state.
+//
+// The functions producing a result hold it in a buffer and return its length,
+// or -1 if there is no result; the chaincode then copies it into its memory
+// with read_result.
+func hostFunctions(c *call) interpreter.Imports {
+	return interpreter.Imports{HostModule: {
+		// get_arg(index) returns the argument of the proposal at the given index
+		"get_arg": {
+			Type: funcType([]interpreter.ValueType{i32}, i32),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				all := c.stub.GetArgs()
+				idx := uint32(args[0])
+				if uint64(idx) >= uint64(len(all)) {
+					return c.setResult(nil, false), nil
+				}
+				return c.setResult(all[idx], true), nil
+			},
+		},
+		// read_result(ptr) copies the result of the last call to the memory
+		"read_result": {
+			Type: funcType([]interpreter.ValueType{i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				return nil, in.Write(uint32(args[0]), c.result)
+			},
+		},
+		// get_state(key_ptr, key_len) returns the value of the key
+		"get_state": {
+			Type: funcType([]interpreter.ValueType{i32, i32}, i32),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				key, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				value, err := c.stub.GetState(string(key))
+				if err != nil {
+					return nil, err
+				}
+				return c.setResult(value, value != nil), nil
+			},
+		},
+		// put_state(key_ptr, key_len, value_ptr, value_len) writes the value of the key
+		"put_state": {
+			Type: funcType([]interpreter.ValueType{i32, i32, i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				key, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				value, err := in.Read(uint32(args[2]), uint32(args[3]))
+				if err != nil {
+					return nil, err
+				}
+				return nil, c.stub.PutState(string(key), value)
+			},
+		},
+		// del_state(key_ptr, key_len) deletes the key
+		"del_state": {
+			Type: funcType([]interpreter.ValueType{i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				key, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				return nil, c.stub.DelState(string(key))
+			},
+		},
+		// get_tx_id() returns the ID of the transaction
+		"get_tx_id": {
+			Type: funcType(nil, i32),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				return c.setResult([]byte(c.stub.GetTxID()), true), nil
+			},
+		},
+		// get_channel_id() returns the ID of the channel
+		"get_channel_id": {
+			Type: funcType(nil, i32),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				return c.setResult([]byte(c.stub.GetChannelID()), true), nil
+			},
+		},
+		// get_creator() returns the serialized identity of the creator of the proposal
+		"get_creator": {
+			Type: funcType(nil, i32),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				creator, err := c.stub.GetCreator()
+				if err != nil {
+					return nil, err
+				}
+				return c.setResult(creator, true), nil
+			},
+		},
+		// get_tx_timestamp() returns the timestamp of the proposal in nanoseconds
+		// since the epoch, which is the only clock available to chaincode
+		"get_tx_timestamp": {
+			Type: funcType(nil, i64),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				ts, err := c.stub.GetTxTimestamp()
+				if err != nil {
+					return nil, err
+				}
+				return []uint64{uint64(ts.Seconds*1e9 + int64(ts.Nanos))}, nil
+			},
+		},
+		// set_event(name_ptr, name_len, payload_ptr, payload_len) sets the event of the transaction
+		"set_event": {
+			Type: funcType([]interpreter.ValueType{i32, i32, i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				name, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				payload, err := in.Read(uint32(args[2]), uint32(args[3]))
+				if err != nil {
+					return nil, err
+				}
+				return nil, c.stub.SetEvent(string(name), payload)
+			},
+		},
+		// log(ptr, len) logs a message at debug level
+		"log": {
+			Type: funcType([]interpreter.ValueType{i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				msg, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				logger.Debugf("[%s] %s", shorttxid(c.stub.GetTxID()), msg)
+				return nil, nil
+			},
+		},
+		// set_response(ptr, len) sets the payload of a successful response
+		"set_response": {
+			Type: funcType([]interpreter.ValueType{i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				payload, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				c.payload, c.message, c.failed = payload, "", false
+				return nil, nil
+			},
+		},
+		// set_error(ptr, len) sets the message of an error response
+		"set_error": {
+			Type: funcType([]interpreter.ValueType{i32, i32}),
+			Call: func(in *interpreter.Instance, args []uint64) ([]uint64, error) {
+				msg, err := in.Read(uint32(args[0]), uint32(args[1]))
+				if err != nil {
+					return nil, err
+				}
+				c.payload, c.message, c.failed = nil, string(msg), true
+				return nil, nil
+			},
+		},
+	}}
+}
+
+// setResult holds the result of a host function, and returns its length
+func (c *call) setResult(result []byte, ok bool) []uint64 {
+	if !ok {
+		c.result = nil
+		return []uint64{noResult}
+	}
+	c.result = result
+	return []uint64{uint64(len(result))}
+}
+
+func (c *call) response() pb.Response {
+	if c.failed {
+		return shim.Error(c.message)
+	}
+	return shim.Success(c.payload)
+}
+
+// Chaincode runs WebAssembly chaincode. It implements shim.Chaincode
+type Chaincode struct {
+	module *interpreter.Module
+	config interpreter.Config
+}
+
+// NewChaincode compiles and validates the given module, whose instances
+// are limited by the given configuration
+func NewChaincode(code []byte, config interpreter.Config) (*Chaincode, error) {
+	module, err := compile(code)
+	if err != nil {
+		return nil, err
+	}
+	return &Chaincode{module: module, config: config}, nil
+}
+
+// ValidateModule returns an error if the given module isn't valid WebAssembly
+// chaincode
+func ValidateModule(code []byte) error {
+	_, err := compile(code)
+	return err
+}
+
+func compile(code []byte) (*interpreter.Module, error) {
+	module, err := interpreter.Compile(code)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid WebAssembly module")
+	}
+	if err := module.CheckImports(hostFunctions(nil)); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{InitFunction, InvokeFunction} {
+		typ, ok := module.ExportedFunction(name)
+		if !ok {
+			return nil, errors.Errorf("function %s isn't exported", name)
+		}
+		if len(typ.Params) != 0 || len(typ.Results) != 0 {
+			return nil, errors.Errorf("function %s of type %s must take no arguments and return no results", name, typ)
+		}
+	}
+	return module, nil
+}
+
+// Init runs the init function of a new instance of the module
+func (cc *Chaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return cc.run(InitFunction, stub)
+}
+
+// Invoke runs the invoke function of a new instance of the module
+func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return cc.run(InvokeFunction, stub)
+}
+
+// run runs a function in a new instance, so that no state is kept in the
+// memory of the chaincode from one transaction to the next
+func (cc *Chaincode) run(function string, stub shim.ChaincodeStubInterface) pb.Response {
+	c := &call{stub: stub}
+	in, err := cc.module.Instantiate(hostFunctions(c), cc.config)
+	if err != nil {
+		return shim.Error(errors.WithMessage(err, "failed to instantiate the module").Error())
+	}
+	_, err = in.Call(function)
+	logger.Debugf("[%s] %s consumed %d fuel", shorttxid(stub.GetTxID()), function, cc.config.Fuel-in.Fuel())
+	if err != nil {
+		return shim.Error(errors.WithMessage(err, function+" failed").Error())
+	}
+	return c.response()
+}
+
+// ModuleFromCodePackage returns the module held by the module file of the
+// given gzipped tar code package
+func ModuleFromCodePackage(codePackage []byte) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the code package")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("the code package does not contain %s", ModuleFile)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the code package")
+		}
+		if header.Name != ModuleFile {
+			continue
+		}
+		module, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", ModuleFile)
+		}
+		return module, nil
+	}
+}
+
+func shorttxid(txid string) string {
+	if len(txid) < 8 {
+		return txid
+	}
+	return txid[0:8]
+}
@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller/interpreter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = interpreter.Config{Fuel: 100000, MaxMemoryPages: 1}
+
+func readModule(t *testing.T) []byte {
+	module, err := ioutil.ReadFile("testdata/chaincode.wasm")
+	require.NoError(t, err)
+	return module
+}
+
+func codePackage(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(contents)), Mode: 0100644}))
+		_, err := tw.Write(contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func args(args ...string) [][]byte {
+	var b [][]byte
+	for _, a := range args {
+		b = append(b, []byte(a))
+	}
+	return b
+}
+
+func TestChaincode(t *testing.T) {
+	cc, err := NewChaincode(readModule(t), testConfig)
+	require.NoError(t, err)
+	stub := shim.NewMockStub("wasmcc", cc)
+
+	res := stub.MockInit("tx1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status)
+
+	res = stub.MockInvoke("tx2", args("put", "key", "value"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("value"), stub.State["key"])
+
+	res = stub.MockInvoke("tx3", args("get", "key"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("value"), res.Payload)
+
+	res = stub.MockInvoke("tx4", args("get", "missing"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "key not found", res.Message)
+
+	res = stub.MockInvoke("tx5", args("delete"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "unknown function", res.Message)
+
+	// the only clock is the timestamp of the transaction
+	before := time.Now().UnixNano()
+	res = stub.MockInvoke("tx6", args("now"))
+	after := time.Now().UnixNano()
+	assert.Equal(t, int32(shim.OK), res.Status)
+	require.Len(t, res.Payload, 8)
+	now := int64(binary.LittleEndian.Uint64(res.Payload))
+	assert.True(t, before <= now && now <= after)
+}
+
+func TestChaincodeTraps(t *testing.T) {
+	cc, err := NewChaincode(readModule(t), testConfig)
+	require.NoError(t, err)
+	stub := shim.NewMockStub("wasmcc", cc)
+
+	res := stub.MockInvoke("tx1", args("loop"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "invoke failed: out of fuel", res.Message)
+
+	res = stub.MockInvoke("tx2", args("trap"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "invoke failed: unreachable")
+
+	// the value is read out of the bounds of the memory
+	res = stub.MockInvoke("tx3", args("put", "key"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "is out of the bounds of the memory")
+	assert.Empty(t, stub.State)
+
+	// a trap doesn't prevent the next transactions from running
+	res = stub.MockInvoke("tx4", args("put", "key", "value"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+
+	cc, err = NewChaincode(readModule(t), interpreter.Config{Fuel: 100000})
+	require.NoError(t, err)
+	res = shim.NewMockStub("wasmcc", cc).MockInvoke("tx1", args("get", "key"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "failed to instantiate the module: memory of 1 pages exceeds the limit of 0 pages", res.Message)
+}
+
+func TestValidateModule(t *testing.T) {
+	assert.NoError(t, ValidateModule(readModule(t)))
+
+	err := ValidateModule([]byte("garbage"))
+	assert.Contains(t, err.Error(), "invalid WebAssembly module")
+
+	module := readModule(t)
+	// rename the import of get_arg
+	i := bytes.Index(module, []byte("get_arg"))
+	module[i] = 'x'
+	err = ValidateModule(module)
+	assert.EqualError(t, err, "unknown import fabric.xet_arg")
+
+	module = readModule(t)
+	i = bytes.Index(module, []byte("invoke"))
+	module[i] = 'x'
+	err = ValidateModule(module)
+	assert.EqualError(t, err, "function invoke isn't exported")
+}
+
+func TestModuleFromCodePackage(t *testing.T) {
+	module, err := ModuleFromCodePackage(codePackage(t, map[string][]byte{
+		ModuleFile:                    []byte("module"),
+		"META-INF/statedb/index.json": []byte("{}"),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("module"), module)
+
+	_, err = ModuleFromCodePackage([]byte("garbage"))
+	assert.Contains(t, err.Error(), "failed to read the code package")
+
+	_, err = ModuleFromCodePackage(codePackage(t, map[string][]byte{"main.go": []byte("package main")}))
+	assert.EqualError(t, err, "the code package does not contain chaincode.wasm")
+}
@@ -0,0 +1,416 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"github.com/pkg/errors"
+)
+
+// These are the opcodes of the supported instructions
+const (
+	opUnreachable  = 0x00
+	opNop          = 0x01
+	opBlock        = 0x02
+	opLoop         = 0x03
+	opIf           = 0x04
+	opElse         = 0x05
+	opEnd          = 0x0b
+	opBr           = 0x0c
+	opBrIf         = 0x0d
+	opBrTable      = 0x0e
+	opReturn       = 0x0f
+	opCall         = 0x10
+	opCallIndirect = 0x11
+
+	opDrop        = 0x1a
+	opSelect      = 0x1b
+	opSelectTyped = 0x1c
+
+	opLocalGet  = 0x20
+	opLocalSet  = 0x21
+	opLocalTee  = 0x22
+	opGlobalGet = 0x23
+	opGlobalSet = 0x24
+
+	opI32Load    = 0x28
+	opI64Load    = 0x29
+	opF32Load    = 0x2a
+	opF64Load    = 0x2b
+	opI32Load8S  = 0x2c
+	opI32Load8U  = 0x2d
+	opI32Load16S = 0x2e
+	opI32Load16U = 0x2f
+	opI64Load8S  = 0x30
+	opI64Load8U  = 0x31
+	opI64Load16S = 0x32
+	opI64Load16U = 0x33
+	opI64Load32S = 0x34
+	opI64Load32U = 0x35
+	opI32Store   = 0x36
+	opI64Store   = 0x37
+	opF32Store   = 0x38
+	opF64Store   = 0x39
+	opI32Store8  = 0x3a
+	opI32Store16 = 0x3b
+	opI64Store8  = 0x3c
+	opI64Store16 = 0x3d
+	opI64Store32 = 0x3e
+	opMemorySize = 0x3f
+	opMemoryGrow = 0x40
+
+	opI32Const = 0x41
+	opI64Const = 0x42
+
+	opI32Eqz = 0x45
+	opI32Eq  = 0x46
+	opI32Ne  = 0x47
+	opI32LtS = 0x48
+	opI32LtU = 0x49
+	opI32GtS = 0x4a
+	opI32GtU = 0x4b
+	opI32LeS = 0x4c
+	opI32LeU = 0x4d
+	opI32GeS = 0x4e
+	opI32GeU = 0x4f
+
+	opI64Eqz = 0x50
+	opI64Eq  = 0x51
+	opI64Ne  = 0x52
+	opI64LtS = 0x53
+	opI64LtU = 0x54
+	opI64GtS = 0x55
+	opI64GtU = 0x56
+	opI64LeS = 0x57
+	opI64LeU = 0x58
+	opI64GeS = 0x59
+	opI64GeU = 0x5a
+
+	opI32Clz    = 0x67
+	opI32Ctz    = 0x68
+	opI32Popcnt = 0x69
+	opI32Add    = 0x6a
+	opI32Sub    = 0x6b
+	opI32Mul    = 0x6c
+	opI32DivS   = 0x6d
+	opI32DivU   = 0x6e
+	opI32RemS   = 0x6f
+	opI32RemU   = 0x70
+	opI32And    = 0x71
+	opI32Or     = 0x72
+	opI32Xor    = 0x73
+	opI32Shl    = 0x74
+	opI32ShrS   = 0x75
+	opI32ShrU   = 0x76
+	opI32Rotl   = 0x77
+	opI32Rotr   = 0x78
+
+	opI64Clz    = 0x79
+	opI64Ctz    = 0x7a
+	opI64Popcnt = 0x7b
+	opI64Add    = 0x7c
+	opI64Sub    = 0x7d
+	opI64Mul    = 0x7e
+	opI64DivS   = 0x7f
+	opI64DivU   = 0x80
+	opI64RemS   = 0x81
+	opI64RemU   = 0x82
+	opI64And    = 0x83
+	opI64Or     = 0x84
+	opI64Xor    = 0x85
+	opI64Shl    = 0x86
+	opI64ShrS   = 0x87
+	opI64ShrU   = 0x88
+	opI64Rotl   = 0x89
+	opI64Rotr   = 0x8a
+
+	opI32WrapI64     = 0xa7
+	opI64ExtendI32S  = 0xac
+	opI64ExtendI32U  = 0xad
+	opI32Extend8S    = 0xc0
+	opI32Extend16S   = 0xc1
+	opI64Extend8S    = 0xc2
+	opI64Extend16S   = 0xc3
+	opI64Extend32S   = 0xc4
+	opMiscPrefix     = 0xfc
+	opMiscMemoryCopy = 0x0a
+	opMiscMemoryFill = 0x0b
+)
+
+// isFloatOp returns true for the opcodes of the floating point instructions
+func isFloatOp(op byte) bool {
+	switch {
+	case op == opF32Load, op == opF64Load, op == opF32Store, op == opF64Store:
+		return true
+	case op == 0x43, op == 0x44: // f32.const, f64.const
+		return true
+	case op >= 0x5b && op <= 0x66: // comparisons
+		return true
+	case op >= 0x8b && op <= 0xa6: // arithmetic
+		return true
+	case op >= 0xa8 && op <= 0xab, op >= 0xae && op <= 0xbf: // conversions
+		return true
+	}
+	return false
+}
+
+// blockInfo is the static information of a block, loop or if instruction
+type blockInfo struct {
+	op      byte
+	next    int // position following the block type
+	elsePos int // position of the else opcode of an if, or -1
+	end     int // position of the end opcode
+	params  int
+	results int
+}
+
+type function struct {
+	typ    FuncType
+	locals int
+	body   []byte
+	blocks map[int]*blockInfo
+}
+
+func (m *Module) blockType(r *reader) (params, results int, err error) {
+	t, err := r.signed(33)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch t {
+	case -0x40: // empty
+		return 0, 0, nil
+	case -1, -2: // i32, i64
+		return 0, 1, nil
+	case -3, -4: // f32, f64
+		return 0, 0, errFloat
+	}
+	if t < 0 || t >= int64(len(m.types)) {
+		return 0, 0, errors.Errorf("invalid block type %d", t)
+	}
+	typ := m.types[t]
+	return len(typ.Params), len(typ.Results), nil
+}
+
+func (m *Module) memoryArg(r *reader) error {
+	if m.memory == nil {
+		return errors.New("memory instruction without memory")
+	}
+	if _, err := r.u32(); err != nil { // alignment
+		return err
+	}
+	_, err := r.u32() // offset
+	return err
+}
+
+func zeroByte(r *reader) error {
+	b, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if b != 0 {
+		return errors.Errorf("unexpected byte 0x%x instead of 0x00", b)
+	}
+	return nil
+}
+
+// compileFunction validates the body of a function, and records the
+// positions of the blocks it holds, so that they can be branched to.
+func (m *Module) compileFunction(typ FuncType, body []byte, declared int) (*function, error) {
+	f := &function{typ: typ, body: body, blocks: map[int]*blockInfo{}}
+	r := &reader{buf: body}
+
+	err := vector(r, func(uint32) error {
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if _, err := valueType(r); err != nil {
+			return err
+		}
+		f.locals += int(n)
+		if n > maxFunctionLocals || f.locals > maxFunctionLocals {
+			return errors.Errorf("function declares more than %d locals", maxFunctionLocals)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	numLocals := uint32(len(typ.Params) + f.locals)
+	codeStart := r.pos
+
+	// the enclosing blocks; the function body is the outermost one
+	stack := []*blockInfo{{elsePos: -1}}
+	for r.len() > 0 {
+		pos := r.pos
+		op, _ := r.byte()
+		if isFloatOp(op) {
+			return nil, errFloat
+		}
+
+		switch op {
+		case opUnreachable, opNop, opReturn, opDrop, opSelect:
+		case opBlock, opLoop, opIf:
+			params, results, err := m.blockType(r)
+			if err != nil {
+				return nil, err
+			}
+			b := &blockInfo{op: op, next: r.pos, elsePos: -1, params: params, results: results}
+			f.blocks[pos] = b
+			stack = append(stack, b)
+		case opElse:
+			b := stack[len(stack)-1]
+			if b.op != opIf || b.elsePos != -1 {
+				return nil, errors.New("else outside of an if")
+			}
+			b.elsePos = pos
+		case opEnd:
+			stack[len(stack)-1].end = pos
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 && r.len() != 0 {
+				return nil, errors.New("unexpected content after the end of the function")
+			}
+		case opBr, opBrIf:
+			if err := decodeLabel(r, len(stack)); err != nil {
+				return nil, err
+			}
+		case opBrTable:
+			err := vector(r, func(uint32) error {
+				return decodeLabel(r, len(stack))
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeLabel(r, len(stack)); err != nil {
+				return nil, err
+			}
+		case opCall:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if idx >= m.numFunctions(declared) {
+				return nil, errors.Errorf("call to unknown function %d", idx)
+			}
+		case opCallIndirect:
+			if _, err := m.typeIndex(r); err != nil {
+				return nil, err
+			}
+			if err := zeroByte(r); err != nil {
+				return nil, err
+			}
+			if m.table == nil {
+				return nil, errors.New("indirect call without table")
+			}
+		case opSelectTyped:
+			types, err := valueTypes(r)
+			if err != nil {
+				return nil, err
+			}
+			if len(types) != 1 {
+				return nil, errors.New("invalid types of select")
+			}
+		case opLocalGet, opLocalSet, opLocalTee:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if idx >= numLocals {
+				return nil, errors.Errorf("unknown local %d", idx)
+			}
+		case opGlobalGet, opGlobalSet:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if idx >= uint32(len(m.globals)) {
+				return nil, errors.Errorf("unknown global %d", idx)
+			}
+			if op == opGlobalSet && !m.globals[idx].mutable {
+				return nil, errors.Errorf("global %d is immutable", idx)
+			}
+		case opMemorySize, opMemoryGrow:
+			if m.memory == nil {
+				return nil, errors.New("memory instruction without memory")
+			}
+			if err := zeroByte(r); err != nil {
+				return nil, err
+			}
+		case opI32Const:
+			if _, err := r.s32(); err != nil {
+				return nil, err
+			}
+		case opI64Const:
+			if _, err := r.s64(); err != nil {
+				return nil, err
+			}
+		case opMiscPrefix:
+			sub, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case sub <= 7: // saturating truncations
+				return nil, errFloat
+			case sub == opMiscMemoryCopy:
+				if err := zeroByte(r); err != nil {
+					return nil, err
+				}
+				fallthrough
+			case sub == opMiscMemoryFill:
+				if m.memory == nil {
+					return nil, errors.New("memory instruction without memory")
+				}
+				if err := zeroByte(r); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, errors.Errorf("unsupported instruction 0xfc 0x%x", sub)
+			}
+		default:
+			switch {
+			case op >= opI32Load && op <= opI64Store32:
+				if err := m.memoryArg(r); err != nil {
+					return nil, err
+				}
+			case op >= opI32Eqz && op <= opI64GeU,
+				op >= opI32Clz && op <= opI64Rotr,
+				op == opI32WrapI64, op == opI64ExtendI32S, op == opI64ExtendI32U,
+				op >= opI32Extend8S && op <= opI64Extend32S:
+			default:
+				return nil, errors.Errorf("unsupported instruction 0x%x", op)
+			}
+		}
+	}
+	if len(stack) != 0 {
+		return nil, errors.New("function body isn't terminated")
+	}
+
+	f.body = body[codeStart:]
+	blocks := make(map[int]*blockInfo, len(f.blocks))
+	for pos, b := range f.blocks {
+		b.next -= codeStart
+		b.end -= codeStart
+		if b.elsePos != -1 {
+			b.elsePos -= codeStart
+		}
+		blocks[pos-codeStart] = b
+	}
+	f.blocks = blocks
+	return f, nil
+}
+
+// decodeLabel decodes the label of a branch nested in the given number of blocks
+func decodeLabel(r *reader, depth int) error {
+	l, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if l >= uint32(depth) {
+		return errors.Errorf("unknown label %d", l)
+	}
+	return nil
+}
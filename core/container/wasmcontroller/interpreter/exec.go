@@ -0,0 +1,537 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// label is a block which can be branched to during the execution of a function
+type label struct {
+	// arity is the number of values passed to the label by a branch: the
+	// parameters of a loop, or the results of any other block
+	arity int
+	// results is the number of values left by the block when it ends
+	results int
+	// height is the height of the stack below the parameters of the block
+	height int
+	// target is the position the execution continues at after a branch
+	target int
+}
+
+// unwind leaves the given number of values on top of the stack at the
+// given height
+func (in *Instance) unwind(height, arity int) {
+	top := len(in.stack) - arity
+	if top < height {
+		in.trap("stack underflow")
+	}
+	copy(in.stack[height:], in.stack[top:])
+	in.stack = in.stack[:height+arity]
+}
+
+func (in *Instance) push(v uint64) {
+	in.stack = append(in.stack, v)
+}
+
+func (in *Instance) pop() uint64 {
+	n := len(in.stack) - 1
+	v := in.stack[n]
+	in.stack = in.stack[:n]
+	return v
+}
+
+func (in *Instance) push32(v uint32) {
+	in.stack = append(in.stack, uint64(v))
+}
+
+func (in *Instance) pop32() uint32 {
+	return uint32(in.pop())
+}
+
+func (in *Instance) pushBool(b bool) {
+	if b {
+		in.push(1)
+	} else {
+		in.push(0)
+	}
+}
+
+// u32 decodes an immediate of a validated function body
+func u32(body []byte, pc *int) uint32 {
+	var result uint32
+	for shift := uint(0); ; shift += 7 {
+		b := body[*pc]
+		*pc++
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result
+		}
+	}
+}
+
+// s64 decodes a signed immediate of a validated function body
+func s64(body []byte, pc *int) int64 {
+	var result int64
+	var shift uint
+	for {
+		b := body[*pc]
+		*pc++
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				result |= -1 << shift
+			}
+			return result
+		}
+	}
+}
+
+// address returns the effective address of an access to size bytes of memory
+func (in *Instance) address(body []byte, pc *int, size uint64) int {
+	u32(body, pc) // alignment
+	offset := u32(body, pc)
+	ea := uint64(in.pop32()) + uint64(offset)
+	if ea+size > uint64(len(in.memory)) {
+		in.trap("out of bounds memory access")
+	}
+	return int(ea)
+}
+
+// memoryRange returns the given range of memory
+func (in *Instance) memoryRange(ptr, n uint32) []byte {
+	end := uint64(ptr) + uint64(n)
+	if end > uint64(len(in.memory)) {
+		in.trap("out of bounds memory access")
+	}
+	return in.memory[ptr:end]
+}
+
+// call calls the function of the given index with the arguments on the stack,
+// which are replaced by its results
+func (in *Instance) call(idx uint32) {
+	m := in.module
+	if idx < uint32(len(m.imports)) {
+		in.callHost(in.hostFuncs[idx])
+		return
+	}
+	f := m.functions[idx-uint32(len(m.imports))]
+
+	nParams := len(f.typ.Params)
+	if in.depth >= maxCallDepth {
+		panic(trap{err: ErrCallStackExhausted})
+	}
+	if len(in.stack)+in.locals+f.locals > maxStackHeight {
+		in.trap("stack overflow")
+	}
+	in.depth++
+	in.locals += nParams + f.locals
+	defer func() {
+		in.depth--
+		in.locals -= nParams + f.locals
+	}()
+
+	locals := make([]uint64, nParams+f.locals)
+	base := len(in.stack) - nParams
+	if base < 0 {
+		in.trap("stack underflow")
+	}
+	copy(locals, in.stack[base:])
+	in.stack = in.stack[:base]
+
+	body := f.body
+	labels := []label{{arity: len(f.typ.Results), results: len(f.typ.Results), height: base, target: len(body)}}
+	branch := func(depth uint32) int {
+		l := labels[len(labels)-1-int(depth)]
+		in.unwind(l.height, l.arity)
+		labels = labels[:len(labels)-1-int(depth)]
+		return l.target
+	}
+
+	pc := 0
+	for pc < len(body) {
+		if in.fuel == 0 {
+			panic(trap{err: ErrOutOfFuel})
+		}
+		in.fuel--
+
+		pos := pc
+		op := body[pc]
+		pc++
+		switch op {
+		case opUnreachable:
+			in.trap("unreachable")
+		case opNop:
+
+		case opBlock, opLoop, opIf:
+			b := f.blocks[pos]
+			l := label{arity: b.results, results: b.results, target: b.end + 1}
+			if op == opLoop {
+				l.arity = b.params
+				l.target = pos
+			}
+			pc = b.next
+			if op == opIf && in.pop32() == 0 {
+				if b.elsePos == -1 {
+					// the end of the block pops its label
+					pc = b.end
+				} else {
+					pc = b.elsePos + 1
+				}
+			}
+			l.height = len(in.stack) - b.params
+			labels = append(labels, l)
+		case opElse:
+			// the end of the then branch of an if
+			l := labels[len(labels)-1]
+			in.unwind(l.height, l.results)
+			labels = labels[:len(labels)-1]
+			pc = l.target
+		case opEnd:
+			l := labels[len(labels)-1]
+			in.unwind(l.height, l.results)
+			labels = labels[:len(labels)-1]
+		case opBr:
+			pc = branch(u32(body, &pc))
+		case opBrIf:
+			depth := u32(body, &pc)
+			if in.pop32() != 0 {
+				pc = branch(depth)
+			}
+		case opBrTable:
+			n := u32(body, &pc)
+			targets := make([]uint32, n+1)
+			for i := range targets {
+				targets[i] = u32(body, &pc)
+			}
+			i := in.pop32()
+			if i > n {
+				i = n
+			}
+			pc = branch(targets[i])
+		case opReturn:
+			pc = branch(uint32(len(labels) - 1))
+		case opCall:
+			in.call(u32(body, &pc))
+		case opCallIndirect:
+			typ := m.types[u32(body, &pc)]
+			pc++ // table
+			i := in.pop32()
+			if i >= uint32(len(in.table)) {
+				in.trap("undefined element")
+			}
+			target := in.table[i]
+			if target < 0 {
+				in.trap("uninitialized element %d", i)
+			}
+			if !m.funcType(uint32(target)).equal(typ) {
+				in.trap("indirect call type mismatch")
+			}
+			in.call(uint32(target))
+
+		case opDrop:
+			in.pop()
+		case opSelect, opSelectTyped:
+			if op == opSelectTyped {
+				pc += 2
+			}
+			c := in.pop32()
+			b := in.pop()
+			a := in.pop()
+			if c != 0 {
+				in.push(a)
+			} else {
+				in.push(b)
+			}
+
+		case opLocalGet:
+			in.push(locals[u32(body, &pc)])
+		case opLocalSet:
+			locals[u32(body, &pc)] = in.pop()
+		case opLocalTee:
+			locals[u32(body, &pc)] = in.stack[len(in.stack)-1]
+		case opGlobalGet:
+			in.push(in.globals[u32(body, &pc)])
+		case opGlobalSet:
+			in.globals[u32(body, &pc)] = in.pop()
+
+		case opI32Load:
+			a := in.address(body, &pc, 4)
+			in.push32(binary.LittleEndian.Uint32(in.memory[a:]))
+		case opI64Load:
+			a := in.address(body, &pc, 8)
+			in.push(binary.LittleEndian.Uint64(in.memory[a:]))
+		case opI32Load8S:
+			a := in.address(body, &pc, 1)
+			in.push32(uint32(int32(int8(in.memory[a]))))
+		case opI32Load8U:
+			a := in.address(body, &pc, 1)
+			in.push32(uint32(in.memory[a]))
+		case opI32Load16S:
+			a := in.address(body, &pc, 2)
+			in.push32(uint32(int32(int16(binary.LittleEndian.Uint16(in.memory[a:])))))
+		case opI32Load16U:
+			a := in.address(body, &pc, 2)
+			in.push32(uint32(binary.LittleEndian.Uint16(in.memory[a:])))
+		case opI64Load8S:
+			a := in.address(body, &pc, 1)
+			in.push(uint64(int64(int8(in.memory[a]))))
+		case opI64Load8U:
+			a := in.address(body, &pc, 1)
+			in.push(uint64(in.memory[a]))
+		case opI64Load16S:
+			a := in.address(body, &pc, 2)
+			in.push(uint64(int64(int16(binary.LittleEndian.Uint16(in.memory[a:])))))
+		case opI64Load16U:
+			a := in.address(body, &pc, 2)
+			in.push(uint64(binary.LittleEndian.Uint16(in.memory[a:])))
+		case opI64Load32S:
+			a := in.address(body, &pc, 4)
+			in.push(uint64(int64(int32(binary.LittleEndian.Uint32(in.memory[a:])))))
+		case opI64Load32U:
+			a := in.address(body, &pc, 4)
+			in.push(uint64(binary.LittleEndian.Uint32(in.memory[a:])))
+		case opI32Store, opI64Store, opI32Store8, opI32Store16, opI64Store8, opI64Store16, opI64Store32:
+			v := in.pop()
+			switch op {
+			case opI32Store, opI64Store32:
+				a := in.address(body, &pc, 4)
+				binary.LittleEndian.PutUint32(in.memory[a:], uint32(v))
+			case opI64Store:
+				a := in.address(body, &pc, 8)
+				binary.LittleEndian.PutUint64(in.memory[a:], v)
+			case opI32Store8, opI64Store8:
+				a := in.address(body, &pc, 1)
+				in.memory[a] = byte(v)
+			case opI32Store16, opI64Store16:
+				a := in.address(body, &pc, 2)
+				binary.LittleEndian.PutUint16(in.memory[a:], uint16(v))
+			}
+		case opMemorySize:
+			pc++
+			in.push32(uint32(len(in.memory) / PageSize))
+		case opMemoryGrow:
+			pc++
+			n := in.pop32()
+			pages := uint32(len(in.memory) / PageSize)
+			if uint64(pages)+uint64(n) > uint64(in.maxPages) {
+				in.push32(math.MaxUint32)
+				break
+			}
+			in.memory = append(in.memory, make([]byte, int(n)*PageSize)...)
+			in.push32(pages)
+
+		case opI32Const:
+			in.push32(uint32(int32(s64(body, &pc))))
+		case opI64Const:
+			in.push(uint64(s64(body, &pc)))
+
+		case opI32Eqz:
+			in.pushBool(in.pop32() == 0)
+		case opI64Eqz:
+			in.pushBool(in.pop() == 0)
+		case opI32Clz:
+			in.push32(uint32(bits.LeadingZeros32(in.pop32())))
+		case opI32Ctz:
+			in.push32(uint32(bits.TrailingZeros32(in.pop32())))
+		case opI32Popcnt:
+			in.push32(uint32(bits.OnesCount32(in.pop32())))
+		case opI64Clz:
+			in.push(uint64(bits.LeadingZeros64(in.pop())))
+		case opI64Ctz:
+			in.push(uint64(bits.TrailingZeros64(in.pop())))
+		case opI64Popcnt:
+			in.push(uint64(bits.OnesCount64(in.pop())))
+		case opI32WrapI64:
+			in.push32(uint32(in.pop()))
+		case opI64ExtendI32S:
+			in.push(uint64(int64(int32(in.pop32()))))
+		case opI64ExtendI32U:
+			in.push(uint64(in.pop32()))
+		case opI32Extend8S:
+			in.push32(uint32(int32(int8(in.pop32()))))
+		case opI32Extend16S:
+			in.push32(uint32(int32(int16(in.pop32()))))
+		case opI64Extend8S:
+			in.push(uint64(int64(int8(in.pop()))))
+		case opI64Extend16S:
+			in.push(uint64(int64(int16(in.pop()))))
+		case opI64Extend32S:
+			in.push(uint64(int64(int32(in.pop()))))
+
+		case opMiscPrefix:
+			sub := u32(body, &pc)
+			switch sub {
+			case opMiscMemoryCopy:
+				pc += 2
+				n, src, dst := in.pop32(), in.pop32(), in.pop32()
+				copy(in.memoryRange(dst, n), in.memoryRange(src, n))
+			case opMiscMemoryFill:
+				pc++
+				n, v, dst := in.pop32(), in.pop32(), in.pop32()
+				mem := in.memoryRange(dst, n)
+				for i := range mem {
+					mem[i] = byte(v)
+				}
+			}
+
+		default:
+			if op >= opI32Eq && op <= opI32GeU || op >= opI32Add && op <= opI32Rotr {
+				y := in.pop32()
+				x := in.pop32()
+				in.binary32(op, x, y)
+			} else {
+				y := in.pop()
+				x := in.pop()
+				in.binary64(op, x, y)
+			}
+		}
+	}
+}
+
+func (in *Instance) binary32(op byte, x, y uint32) {
+	switch op {
+	case opI32Eq:
+		in.pushBool(x == y)
+	case opI32Ne:
+		in.pushBool(x != y)
+	case opI32LtS:
+		in.pushBool(int32(x) < int32(y))
+	case opI32LtU:
+		in.pushBool(x < y)
+	case opI32GtS:
+		in.pushBool(int32(x) > int32(y))
+	case opI32GtU:
+		in.pushBool(x > y)
+	case opI32LeS:
+		in.pushBool(int32(x) <= int32(y))
+	case opI32LeU:
+		in.pushBool(x <= y)
+	case opI32GeS:
+		in.pushBool(int32(x) >= int32(y))
+	case opI32GeU:
+		in.pushBool(x >= y)
+	case opI32Add:
+		in.push32(x + y)
+	case opI32Sub:
+		in.push32(x - y)
+	case opI32Mul:
+		in.push32(x * y)
+	case opI32DivS:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		if int32(x) == math.MinInt32 && int32(y) == -1 {
+			in.trap("integer overflow")
+		}
+		in.push32(uint32(int32(x) / int32(y)))
+	case opI32DivU:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push32(x / y)
+	case opI32RemS:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push32(uint32(int32(x) % int32(y)))
+	case opI32RemU:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push32(x % y)
+	case opI32And:
+		in.push32(x & y)
+	case opI32Or:
+		in.push32(x | y)
+	case opI32Xor:
+		in.push32(x ^ y)
+	case opI32Shl:
+		in.push32(x << (y & 31))
+	case opI32ShrS:
+		in.push32(uint32(int32(x) >> (y & 31)))
+	case opI32ShrU:
+		in.push32(x >> (y & 31))
+	case opI32Rotl:
+		in.push32(bits.RotateLeft32(x, int(y&31)))
+	case opI32Rotr:
+		in.push32(bits.RotateLeft32(x, -int(y&31)))
+	}
+}
+
+func (in *Instance) binary64(op byte, x, y uint64) {
+	switch op {
+	case opI64Eq:
+		in.pushBool(x == y)
+	case opI64Ne:
+		in.pushBool(x != y)
+	case opI64LtS:
+		in.pushBool(int64(x) < int64(y))
+	case opI64LtU:
+		in.pushBool(x < y)
+	case opI64GtS:
+		in.pushBool(int64(x) > int64(y))
+	case opI64GtU:
+		in.pushBool(x > y)
+	case opI64LeS:
+		in.pushBool(int64(x) <= int64(y))
+	case opI64LeU:
+		in.pushBool(x <= y)
+	case opI64GeS:
+		in.pushBool(int64(x) >= int64(y))
+	case opI64GeU:
+		in.pushBool(x >= y)
+	case opI64Add:
+		in.push(x + y)
+	case opI64Sub:
+		in.push(x - y)
+	case opI64Mul:
+		in.push(x * y)
+	case opI64DivS:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		if int64(x) == math.MinInt64 && int64(y) == -1 {
+			in.trap("integer overflow")
+		}
+		in.push(uint64(int64(x) / int64(y)))
+	case opI64DivU:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push(x / y)
+	case opI64RemS:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push(uint64(int64(x) % int64(y)))
+	case opI64RemU:
+		if y == 0 {
+			in.trap("integer divide by zero")
+		}
+		in.push(x % y)
+	case opI64And:
+		in.push(x & y)
+	case opI64Or:
+		in.push(x | y)
+	case opI64Xor:
+		in.push(x ^ y)
+	case opI64Shl:
+		in.push(x << (y & 63))
+	case opI64ShrS:
+		in.push(uint64(int64(x) >> (y & 63)))
+	case opI64ShrU:
+		in.push(x >> (y & 63))
+	case opI64Rotl:
+		in.push(bits.RotateLeft64(x, int(y&63)))
+	case opI64Rotr:
+		in.push(bits.RotateLeft64(x, -int(y&63)))
+	}
+}
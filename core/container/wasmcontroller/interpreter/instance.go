@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxCallDepth is the maximum number of nested calls
+	maxCallDepth = 1024
+	// maxStackHeight is the maximum number of values and locals on the stack
+	maxStackHeight = 1 << 20
+)
+
+// These are the errors of the traps which don't depend on the module
+var (
+	ErrOutOfFuel          = errors.New("out of fuel")
+	ErrCallStackExhausted = errors.New("call stack exhausted")
+)
+
+// trap aborts the execution of an instance with an error
+type trap struct {
+	err error
+}
+
+// Config holds the limits of the execution of an instance
+type Config struct {
+	// Fuel is the number of instructions an instance can execute
+	Fuel uint64
+	// MaxMemoryPages is the maximum number of pages of the memory of an instance
+	MaxMemoryPages uint32
+}
+
+// HostFunc is a function provided by the host to the instances of a module.
+// Its arguments and results are encoded as the values on the stack; an error
+// traps the execution of the instance.
+type HostFunc struct {
+	Type FuncType
+	Call func(in *Instance, args []uint64) ([]uint64, error)
+}
+
+// Imports holds the host functions by module and name
+type Imports map[string]map[string]*HostFunc
+
+func (imports Imports) resolve(m *Module) ([]*HostFunc, error) {
+	var funcs []*HostFunc
+	for _, imp := range m.imports {
+		f, ok := imports[imp.Module][imp.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown import %s.%s", imp.Module, imp.Name)
+		}
+		if !f.Type.equal(imp.Type) {
+			return nil, errors.Errorf("import %s.%s of type %s doesn't match type %s", imp.Module, imp.Name, imp.Type, f.Type)
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs, nil
+}
+
+// CheckImports returns an error if the given host functions don't provide the
+// imports of the module
+func (m *Module) CheckImports(imports Imports) error {
+	_, err := imports.resolve(m)
+	return err
+}
+
+// Instance is an instance of a module, which holds its memory and globals.
+// An instance isn't safe for concurrent use.
+type Instance struct {
+	module    *Module
+	hostFuncs []*HostFunc
+	memory    []byte
+	maxPages  uint32
+	globals   []uint64
+	table     []int64
+
+	fuel   uint64
+	stack  []uint64
+	locals int
+	depth  int
+}
+
+// Instantiate creates an instance of the module, whose imports are provided by
+// the given host functions, and runs its start function
+func (m *Module) Instantiate(imports Imports, config Config) (*Instance, error) {
+	hostFuncs, err := imports.resolve(m)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Instance{
+		module:    m,
+		hostFuncs: hostFuncs,
+		fuel:      config.Fuel,
+	}
+
+	if m.memory != nil {
+		if m.memory.min > config.MaxMemoryPages {
+			return nil, errors.Errorf("memory of %d pages exceeds the limit of %d pages", m.memory.min, config.MaxMemoryPages)
+		}
+		in.maxPages = config.MaxMemoryPages
+		if m.memory.hasMax && m.memory.max < in.maxPages {
+			in.maxPages = m.memory.max
+		}
+		in.memory = make([]byte, int(m.memory.min)*PageSize)
+	}
+
+	for _, g := range m.globals {
+		in.globals = append(in.globals, g.init)
+	}
+
+	if m.table != nil {
+		in.table = make([]int64, m.table.min)
+		for i := range in.table {
+			in.table[i] = -1
+		}
+	}
+	for _, e := range m.elements {
+		if uint64(e.offset)+uint64(len(e.funcs)) > uint64(len(in.table)) {
+			return nil, errors.New("element segment is out of the bounds of the table")
+		}
+		for i, idx := range e.funcs {
+			in.table[int(e.offset)+i] = int64(idx)
+		}
+	}
+	for _, d := range m.data {
+		if uint64(d.offset)+uint64(len(d.data)) > uint64(len(in.memory)) {
+			return nil, errors.New("data segment is out of the bounds of the memory")
+		}
+		copy(in.memory[d.offset:], d.data)
+	}
+
+	if m.start != nil {
+		err := in.run(func() {
+			in.call(*m.start)
+		})
+		if err != nil {
+			return nil, errors.WithMessage(err, "start function failed")
+		}
+	}
+	return in, nil
+}
+
+// run runs f, and returns the error of the trap it raises
+func (in *Instance) run(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if t, ok := r.(trap); ok {
+				err = t.err
+			} else {
+				// runtime errors of the interpreter are reported as traps,
+				// as they only depend on the module
+				err = errors.Errorf("unexpected trap: %v", r)
+			}
+		}
+		in.stack = in.stack[:0]
+		in.locals = 0
+		in.depth = 0
+	}()
+	f()
+	return nil
+}
+
+// Call calls the function exported under the given name
+func (in *Instance) Call(name string, args ...uint64) ([]uint64, error) {
+	exp, ok := in.module.exports[name]
+	if !ok || exp.kind != exportFunc {
+		return nil, errors.Errorf("function %s isn't exported", name)
+	}
+	typ := in.module.funcType(exp.index)
+	if len(args) != len(typ.Params) {
+		return nil, errors.Errorf("function %s expects %d arguments, not %d", name, len(typ.Params), len(args))
+	}
+
+	var results []uint64
+	err := in.run(func() {
+		in.stack = append(in.stack, args...)
+		in.call(exp.index)
+		results = append(results, in.stack[len(in.stack)-len(typ.Results):]...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Fuel returns the remaining fuel of the instance
+func (in *Instance) Fuel() uint64 {
+	return in.fuel
+}
+
+// Read returns a copy of the given range of the memory of the instance
+func (in *Instance) Read(ptr, length uint32) ([]byte, error) {
+	if uint64(ptr)+uint64(length) > uint64(len(in.memory)) {
+		return nil, errors.Errorf("range [%d, %d) is out of the bounds of the memory", ptr, uint64(ptr)+uint64(length))
+	}
+	return append([]byte{}, in.memory[ptr:ptr+length]...), nil
+}
+
+// Write writes data to the memory of the instance at the given address
+func (in *Instance) Write(ptr uint32, data []byte) error {
+	if uint64(ptr)+uint64(len(data)) > uint64(len(in.memory)) {
+		return errors.Errorf("range [%d, %d) is out of the bounds of the memory", ptr, uint64(ptr)+uint64(len(data)))
+	}
+	copy(in.memory[ptr:], data)
+	return nil
+}
+
+func (in *Instance) trap(format string, args ...interface{}) {
+	panic(trap{err: errors.New(fmt.Sprintf(format, args...))})
+}
+
+// callHost calls a host function with the arguments on the stack
+func (in *Instance) callHost(f *HostFunc) {
+	n := len(f.Type.Params)
+	args := append([]uint64{}, in.stack[len(in.stack)-n:]...)
+	in.stack = in.stack[:len(in.stack)-n]
+
+	results, err := f.Call(in, args)
+	if err != nil {
+		panic(trap{err: err})
+	}
+	if len(results) != len(f.Type.Results) {
+		in.trap("host function returned %d results instead of %d", len(results), len(f.Type.Results))
+	}
+	for i, r := range results {
+		if f.Type.Results[i] == I32 {
+			r = uint64(uint32(r))
+		}
+		in.stack = append(in.stack, r)
+	}
+}
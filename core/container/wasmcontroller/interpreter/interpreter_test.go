@@ -0,0 +1,472 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var config = Config{Fuel: 1000000, MaxMemoryPages: 2}
+
+func uleb(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func sleb(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func vec(items ...[]byte) []byte {
+	return concat(uleb(uint64(len(items))), concat(items...))
+}
+
+func str(s string) []byte {
+	return concat(uleb(uint64(len(s))), []byte(s))
+}
+
+func section(id byte, items ...[]byte) []byte {
+	return rawSection(id, vec(items...))
+}
+
+func rawSection(id byte, contents []byte) []byte {
+	return concat([]byte{id}, uleb(uint64(len(contents))), contents)
+}
+
+func module(sections ...[]byte) []byte {
+	return concat(magic, concat(sections...))
+}
+
+func funcType(params []ValueType, results ...ValueType) []byte {
+	return concat([]byte{funcTypeForm}, vec(valueTypeBytesOf(params)...), vec(valueTypeBytesOf(results)...))
+}
+
+func valueTypeBytesOf(types []ValueType) [][]byte {
+	var b [][]byte
+	for _, t := range types {
+		b = append(b, []byte{byte(t)})
+	}
+	return b
+}
+
+// body returns the body of a function declaring the given number of i64
+// locals, holding the given code
+func body(locals int, code ...byte) []byte {
+	var decls [][]byte
+	if locals > 0 {
+		decls = append(decls, concat(uleb(uint64(locals)), []byte{byte(I64)}))
+	}
+	b := concat(vec(decls...), code, []byte{opEnd})
+	return concat(uleb(uint64(len(b))), b)
+}
+
+func exportFunction(name string, idx uint32) []byte {
+	return concat(str(name), []byte{exportFunc}, uleb(uint64(idx)))
+}
+
+// singleFunction returns a module exporting a single function named f
+func singleFunction(typ []byte, b []byte) []byte {
+	return module(
+		section(sectionType, typ),
+		section(sectionFunction, uleb(0)),
+		section(sectionMemory, []byte{0x01, 0x01, 0x02}),
+		section(sectionExport, exportFunction("f", 0)),
+		section(sectionCode, b),
+	)
+}
+
+func run(t *testing.T, code []byte, args ...uint64) ([]uint64, error) {
+	m, err := Compile(code)
+	require.NoError(t, err)
+	in, err := m.Instantiate(nil, config)
+	require.NoError(t, err)
+	return in.Call("f", args...)
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       byte
+		typ      ValueType
+		x, y     uint64
+		expected uint64
+	}{
+		{"i32.add overflows", opI32Add, I32, 0xffffffff, 1, 0},
+		{"i32.sub", opI32Sub, I32, 1, 2, 0xffffffff},
+		{"i32.div_s", opI32DivS, I32, uint64(uint32(0xfffffff9)), 2, uint64(uint32(0xfffffffd))},
+		{"i32.rem_s", opI32RemS, I32, uint64(uint32(0x80000000)), uint64(uint32(0xffffffff)), 0},
+		{"i32.shl masks the count", opI32Shl, I32, 1, 33, 2},
+		{"i32.shr_s", opI32ShrS, I32, 0x80000000, 31, 0xffffffff},
+		{"i32.rotr", opI32Rotr, I32, 1, 1, 0x80000000},
+		{"i32.lt_s", opI32LtS, I32, 0xffffffff, 0, 1},
+		{"i32.lt_u", opI32LtU, I32, 0xffffffff, 0, 0},
+		{"i64.mul", opI64Mul, I64, 1 << 32, 1 << 31, 1 << 63},
+		{"i64.div_u", opI64DivU, I64, 1 << 63, 2, 1 << 62},
+		{"i64.shr_u masks the count", opI64ShrU, I64, 1 << 63, 127, 1},
+		{"i64.rotl", opI64Rotl, I64, 1 << 63, 1, 1},
+		{"i64.ge_s", opI64GeS, I64, 1 << 63, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code := singleFunction(
+				funcType([]ValueType{test.typ, test.typ}, test.typ),
+				body(0, opLocalGet, 0, opLocalGet, 1, test.op),
+			)
+			results, err := run(t, code, test.x, test.y)
+			assert.NoError(t, err)
+			assert.Equal(t, []uint64{test.expected}, results)
+		})
+	}
+}
+
+func TestUnaryOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       byte
+		param    ValueType
+		result   ValueType
+		x        uint64
+		expected uint64
+	}{
+		{"i32.clz", opI32Clz, I32, I32, 1, 31},
+		{"i64.ctz", opI64Ctz, I64, I64, 1 << 40, 40},
+		{"i64.popcnt", opI64Popcnt, I64, I64, 0xff, 8},
+		{"i32.eqz", opI32Eqz, I32, I32, 0, 1},
+		{"i32.wrap_i64", opI32WrapI64, I64, I32, 0x123456789, 0x23456789},
+		{"i64.extend_i32_s", opI64ExtendI32S, I32, I64, 0xffffffff, 0xffffffffffffffff},
+		{"i64.extend_i32_u", opI64ExtendI32U, I32, I64, 0xffffffff, 0xffffffff},
+		{"i32.extend8_s", opI32Extend8S, I32, I32, 0x80, 0xffffff80},
+		{"i64.extend16_s", opI64Extend16S, I64, I64, 0x8000, 0xffffffffffff8000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code := singleFunction(
+				funcType([]ValueType{test.param}, test.result),
+				body(0, opLocalGet, 0, test.op),
+			)
+			results, err := run(t, code, test.x)
+			assert.NoError(t, err)
+			assert.Equal(t, []uint64{test.expected}, results)
+		})
+	}
+}
+
+func TestControlFlow(t *testing.T) {
+	// sum returns the sum of the integers up to its parameter
+	sum := singleFunction(
+		funcType([]ValueType{I64}, I64),
+		body(1,
+			opBlock, 0x40,
+			opLoop, 0x40,
+			opLocalGet, 0, opI64Eqz, opBrIf, 1,
+			opLocalGet, 1, opLocalGet, 0, opI64Add, opLocalSet, 1,
+			opLocalGet, 0, opI64Const, 1, opI64Sub, opLocalSet, 0,
+			opBr, 0,
+			opEnd,
+			opEnd,
+			opLocalGet, 1,
+		),
+	)
+	results, err := run(t, sum, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{5050}, results)
+
+	// fac returns the factorial of its parameter recursively
+	fac := singleFunction(
+		funcType([]ValueType{I64}, I64),
+		body(0,
+			opLocalGet, 0, opI64Eqz,
+			opIf, byte(I64),
+			opI64Const, 1,
+			opElse,
+			opLocalGet, 0,
+			opLocalGet, 0, opI64Const, 1, opI64Sub, opCall, 0,
+			opI64Mul,
+			opEnd,
+		),
+	)
+	results, err = run(t, fac, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2432902008176640000}, results)
+
+	// choose returns 10, 20 or 30 for 0, 1 and any other parameter
+	choose := singleFunction(
+		funcType([]ValueType{I32}, I32),
+		body(0,
+			opBlock, 0x40,
+			opBlock, 0x40,
+			opBlock, 0x40,
+			opLocalGet, 0, opBrTable, 2, 0, 1, 2,
+			opEnd,
+			opI32Const, 10, opReturn,
+			opEnd,
+			opI32Const, 20, opReturn,
+			opEnd,
+			opI32Const, 30,
+		),
+	)
+	for param, expected := range map[uint64]uint64{0: 10, 1: 20, 2: 30, 1000: 30} {
+		results, err = run(t, choose, param)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{expected}, results)
+	}
+
+	// max returns the maximum of its parameters through a select
+	max := singleFunction(
+		funcType([]ValueType{I32, I32}, I32),
+		body(0,
+			opLocalGet, 0, opLocalGet, 1,
+			opLocalGet, 0, opLocalGet, 1, opI32GtU,
+			opSelect,
+		),
+	)
+	results, err = run(t, max, 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{7}, results)
+}
+
+func TestMemory(t *testing.T) {
+	code := module(
+		section(sectionType, funcType(nil, I32), funcType([]ValueType{I32}, I32)),
+		section(sectionFunction, uleb(0), uleb(1), uleb(1)),
+		section(sectionMemory, []byte{0x01, 0x01, 0x03}),
+		section(sectionExport, exportFunction("load", 0), exportFunction("grow", 1), exportFunction("load16", 2)),
+		section(sectionCode,
+			// stores 0xff at 20, copies the data segment after it, and loads a
+			// 32-bit integer at 19
+			body(0,
+				opI32Const, 20, opI32Const, 0x7f, opI32Store8, 0x00, 0x00,
+				opI32Const, 21, opI32Const, 16, opI32Const, 3, opMiscPrefix, opMiscMemoryCopy, 0x00, 0x00,
+				opI32Const, 19, opI32Load, 0x02, 0x00,
+			),
+			body(0, opLocalGet, 0, opMemoryGrow, 0x00),
+			body(0, opLocalGet, 0, opI32Load16U, 0x01, 0x00),
+		),
+		section(sectionData, concat([]byte{0x00}, []byte{opI32Const, 16, opEnd}, str("abc"))),
+	)
+	m, err := Compile(code)
+	require.NoError(t, err)
+	in, err := m.Instantiate(nil, config)
+	require.NoError(t, err)
+
+	results, err := in.Call("load")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x6261ff00}, results)
+
+	mem, err := in.Read(16, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("abc\x00\xffabc"), mem)
+	assert.NoError(t, in.Write(PageSize-2, []byte{1, 2}))
+	_, err = in.Read(PageSize-1, 2)
+	assert.EqualError(t, err, "range [65535, 65537) is out of the bounds of the memory")
+
+	results, err = in.Call("load16", PageSize-2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x0201}, results)
+	_, err = in.Call("load16", PageSize-1)
+	assert.EqualError(t, err, "out of bounds memory access")
+
+	// the memory is limited by the configuration rather than the module
+	results, err = in.Call("grow", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, results)
+	results, err = in.Call("grow", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0xffffffff}, results)
+	results, err = in.Call("load16", PageSize-1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x02}, results)
+}
+
+func TestTraps(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  []byte
+		body []byte
+		err  string
+	}{
+		{"unreachable", funcType(nil), body(0, opUnreachable), "unreachable"},
+		{"divide by zero", funcType(nil, I32), body(0, opI32Const, 1, opI32Const, 0, opI32DivU), "integer divide by zero"},
+		{"overflow", funcType(nil, I64), body(0, concat([]byte{opI64Const}, sleb(math.MinInt64), []byte{opI64Const}, sleb(-1), []byte{opI64DivS})...), "integer overflow"},
+		{"infinite loop", funcType(nil), body(0, opLoop, 0x40, opBr, 0, opEnd), "out of fuel"},
+		{"infinite recursion", funcType(nil), body(0, opCall, 0), "call stack exhausted"},
+		{"out of bounds", funcType(nil, I64), body(0, opI32Const, 0x7f, opI64Load, 0x03, 0x00), "out of bounds memory access"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := run(t, singleFunction(test.typ, test.body))
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	m, err := Compile(singleFunction(funcType(nil), body(0, opLoop, 0x40, opBr, 0, opEnd)))
+	require.NoError(t, err)
+	in, err := m.Instantiate(nil, Config{Fuel: 100, MaxMemoryPages: 1})
+	require.NoError(t, err)
+	_, err = in.Call("f")
+	assert.Equal(t, ErrOutOfFuel, err)
+	assert.Equal(t, uint64(0), in.Fuel())
+}
+
+func TestHostFunctions(t *testing.T) {
+	code := module(
+		section(sectionType, funcType([]ValueType{I32}, I32), funcType(nil, I32)),
+		section(sectionImport, concat(str("env"), str("double"), []byte{exportFunc}, uleb(0))),
+		section(sectionFunction, uleb(1)),
+		section(sectionExport, exportFunction("f", 1)),
+		section(sectionCode, body(0, opI32Const, 21, opCall, 0)),
+	)
+	m, err := Compile(code)
+	require.NoError(t, err)
+	assert.Equal(t, []Import{{Module: "env", Name: "double", Type: FuncType{Params: []ValueType{I32}, Results: []ValueType{I32}}}}, m.Imports())
+
+	double := &HostFunc{
+		Type: FuncType{Params: []ValueType{I32}, Results: []ValueType{I32}},
+		Call: func(in *Instance, args []uint64) ([]uint64, error) {
+			return []uint64{args[0] * 2}, nil
+		},
+	}
+	in, err := m.Instantiate(Imports{"env": {"double": double}}, config)
+	require.NoError(t, err)
+	results, err := in.Call("f")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{42}, results)
+
+	failing := &HostFunc{
+		Type: double.Type,
+		Call: func(in *Instance, args []uint64) ([]uint64, error) {
+			return nil, errors.New("host failure")
+		},
+	}
+	in, err = m.Instantiate(Imports{"env": {"double": failing}}, config)
+	require.NoError(t, err)
+	_, err = in.Call("f")
+	assert.EqualError(t, err, "host failure")
+
+	err = m.CheckImports(Imports{})
+	assert.EqualError(t, err, "unknown import env.double")
+	_, err = m.Instantiate(Imports{"env": {"double": &HostFunc{Type: FuncType{Params: []ValueType{I64}}}}}, config)
+	assert.EqualError(t, err, "import env.double of type [i32] -> [i32] doesn't match type [i64] -> []")
+}
+
+func TestTablesAndGlobals(t *testing.T) {
+	code := module(
+		section(sectionType, funcType(nil, I32), funcType([]ValueType{I32}, I32), funcType(nil)),
+		section(sectionFunction, uleb(0), uleb(0), uleb(1), uleb(2)),
+		section(sectionTable, []byte{funcRefType, 0x00, 0x03}),
+		section(sectionGlobal, []byte{byte(I32), 0x01, opI32Const, 1, opEnd}),
+		section(sectionExport, exportFunction("f", 2)),
+		rawSection(sectionStart, uleb(3)),
+		section(sectionElement, concat(uleb(0), []byte{opI32Const, 1, opEnd}, vec(uleb(0), uleb(1)))),
+		section(sectionCode,
+			body(0, opGlobalGet, 0),
+			body(0, opI32Const, 2),
+			body(0, opLocalGet, 0, opCallIndirect, 0, 0x00),
+			// the start function increments the global
+			body(0, opGlobalGet, 0, opI32Const, 1, opI32Add, opGlobalSet, 0),
+		),
+	)
+	m, err := Compile(code)
+	require.NoError(t, err)
+	in, err := m.Instantiate(nil, config)
+	require.NoError(t, err)
+
+	for idx, expected := range map[uint64]string{0: "uninitialized element 0", 3: "undefined element"} {
+		_, err := in.Call("f", idx)
+		assert.EqualError(t, err, expected)
+	}
+	results, err := in.Call("f", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2}, results)
+	results, err = in.Call("f", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2}, results)
+
+	_, err = in.Call("g")
+	assert.EqualError(t, err, "function g isn't exported")
+	_, err = in.Call("f")
+	assert.EqualError(t, err, "function f expects 1 arguments, not 0")
+	typ, ok := m.ExportedFunction("f")
+	assert.True(t, ok)
+	assert.Equal(t, FuncType{Params: []ValueType{I32}, Results: []ValueType{I32}}, typ)
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		code []byte
+		err  string
+	}{
+		{"bad magic", []byte("\x00asm\x02\x00\x00\x00"), "not a WebAssembly module of version 1"},
+		{"truncated", module([]byte{sectionType, 0x05, 0x01}), "unexpected end of module"},
+		{"float parameter", singleFunction(funcType([]ValueType{valueTypeF64}), body(0)), "invalid section 1: " + errFloat.Error()},
+		{"float instruction", singleFunction(funcType(nil), body(0, 0x44, 0, 0, 0, 0, 0, 0, 0, 0, opDrop)), "invalid section 10: invalid function 0: " + errFloat.Error()},
+		{"unknown instruction", singleFunction(funcType(nil), body(0, 0xd0)), "invalid section 10: invalid function 0: unsupported instruction 0xd0"},
+		{"unknown label", singleFunction(funcType(nil), body(0, opBr, 1)), "invalid section 10: invalid function 0: unknown label 1"},
+		{"unknown local", singleFunction(funcType(nil), body(0, opLocalGet, 0, opDrop)), "invalid section 10: invalid function 0: unknown local 0"},
+		{"unterminated", singleFunction(funcType(nil), concat(uleb(3), []byte{0x00, opBlock, 0x40})), "invalid section 10: invalid function 0: function body isn't terminated"},
+		{"misordered sections", module(section(sectionFunction), section(sectionType)), "unexpected section 1"},
+		{"missing code", module(section(sectionType, funcType(nil)), section(sectionFunction, uleb(0))), "1 functions are declared, but 0 are defined"},
+		{"imported memory", module(section(sectionImport, concat(str("env"), str("memory"), []byte{exportMemory, 0x00, 0x01}))), "invalid section 2: import env.memory isn't a function, only functions can be imported"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Compile(test.code)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	code := module(
+		section(sectionMemory, []byte{0x00, 0x03}),
+	)
+	m, err := Compile(code)
+	require.NoError(t, err)
+	_, err = m.Instantiate(nil, config)
+	assert.EqualError(t, err, "memory of 3 pages exceeds the limit of 2 pages")
+
+	code = module(
+		section(sectionMemory, []byte{0x00, 0x01}),
+		section(sectionData, concat([]byte{0x00}, []byte{opI32Const, 0x80, 0x80, 0x04, opEnd}, str("a"))),
+	)
+	m, err = Compile(code)
+	require.NoError(t, err)
+	_, err = m.Instantiate(nil, config)
+	assert.EqualError(t, err, "data segment is out of the bounds of the memory")
+}
@@ -0,0 +1,626 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package interpreter executes WebAssembly modules deterministically.
+//
+// Only the integer subset of the WebAssembly MVP instruction set is supported,
+// along with the sign extension operators and the bulk memory copy and fill
+// operators. Modules using floating point values are rejected, as the results
+// of floating point operations are not deterministic across platforms. A
+// module has no access to its environment beyond the host functions it
+// imports, and the execution of an instance is metered: each instruction
+// consumes a unit of fuel, and the execution traps when the fuel runs out.
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ValueType is the type of a value. Only the integer types are supported.
+type ValueType byte
+
+// These are the supported value types
+const (
+	I32 ValueType = 0x7f
+	I64 ValueType = 0x7e
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	default:
+		return fmt.Sprintf("0x%x", byte(t))
+	}
+}
+
+const (
+	valueTypeF32 = 0x7d
+	valueTypeF64 = 0x7c
+
+	funcRefType  = 0x70
+	funcTypeForm = 0x60
+)
+
+const (
+	// PageSize is the size of a page of memory
+	PageSize = 65536
+
+	maxPages         = 65536
+	maxTableSize     = 100000
+	maxFunctionLocals = 50000
+)
+
+var errFloat = errors.New("floating point values are not supported, as their operations are not deterministic across platforms")
+
+// FuncType is the signature of a function
+type FuncType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+func (t FuncType) equal(o FuncType) bool {
+	return bytes.Equal(valueTypeBytes(t.Params), valueTypeBytes(o.Params)) &&
+		bytes.Equal(valueTypeBytes(t.Results), valueTypeBytes(o.Results))
+}
+
+func (t FuncType) String() string {
+	return fmt.Sprintf("%v -> %v", t.Params, t.Results)
+}
+
+func valueTypeBytes(types []ValueType) []byte {
+	b := make([]byte, len(types))
+	for i, t := range types {
+		b[i] = byte(t)
+	}
+	return b
+}
+
+// Import is a function imported by a module
+type Import struct {
+	Module string
+	Name   string
+	Type   FuncType
+}
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type global struct {
+	mutable bool
+	init    uint64
+}
+
+const (
+	exportFunc   = 0x00
+	exportTable  = 0x01
+	exportMemory = 0x02
+	exportGlobal = 0x03
+)
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+type element struct {
+	offset uint32
+	funcs  []uint32
+}
+
+type dataSegment struct {
+	offset uint32
+	data   []byte
+}
+
+// Module is a decoded and validated module, which can be instantiated
+type Module struct {
+	types     []FuncType
+	imports   []Import
+	functions []*function
+	table     *limits
+	memory    *limits
+	globals   []global
+	exports   map[string]export
+	start     *uint32
+	elements  []element
+	data      []dataSegment
+}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+// sectionOrder is the position of each section in a module, as the data count
+// section is placed before the code section
+var sectionOrder = map[byte]int{
+	sectionType:      1,
+	sectionImport:    2,
+	sectionFunction:  3,
+	sectionTable:     4,
+	sectionMemory:    5,
+	sectionGlobal:    6,
+	sectionExport:    7,
+	sectionStart:     8,
+	sectionElement:   9,
+	sectionDataCount: 10,
+	sectionCode:      11,
+	sectionData:      12,
+}
+
+var magic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// Compile decodes and validates a module in the binary format
+func Compile(code []byte) (*Module, error) {
+	if !bytes.HasPrefix(code, magic) {
+		return nil, errors.New("not a WebAssembly module of version 1")
+	}
+
+	m := &Module{exports: map[string]export{}}
+	var funcTypes []uint32
+	r := &reader{buf: code, pos: len(magic)}
+	lastOrder := 0
+	for r.len() > 0 {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		contents, err := r.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+		if id == sectionCustom {
+			continue
+		}
+		order, ok := sectionOrder[id]
+		if !ok || order <= lastOrder {
+			return nil, errors.Errorf("unexpected section %d", id)
+		}
+		lastOrder = order
+
+		s := &reader{buf: contents}
+		switch id {
+		case sectionType:
+			err = m.decodeTypes(s)
+		case sectionImport:
+			err = m.decodeImports(s)
+		case sectionFunction:
+			funcTypes, err = m.decodeFunctions(s)
+		case sectionTable:
+			err = m.decodeTable(s)
+		case sectionMemory:
+			err = m.decodeMemory(s)
+		case sectionGlobal:
+			err = m.decodeGlobals(s)
+		case sectionExport:
+			err = m.decodeExports(s, len(funcTypes))
+		case sectionStart:
+			err = m.decodeStart(s, len(funcTypes))
+		case sectionElement:
+			err = m.decodeElements(s, len(funcTypes))
+		case sectionCode:
+			err = m.decodeCode(s, funcTypes)
+		case sectionData:
+			err = m.decodeData(s)
+		case sectionDataCount:
+			_, err = s.u32()
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid section %d", id))
+		}
+		if s.len() != 0 {
+			return nil, errors.Errorf("invalid section %d: unexpected content at its end", id)
+		}
+	}
+
+	if len(m.functions) != len(funcTypes) {
+		return nil, errors.Errorf("%d functions are declared, but %d are defined", len(funcTypes), len(m.functions))
+	}
+	return m, nil
+}
+
+// vector calls decode for each element of a vector
+func vector(r *reader, decode func(i uint32) error) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	// each element is encoded in one byte at least
+	if int64(n) > int64(r.len()) {
+		return errUnexpectedEnd
+	}
+	for i := uint32(0); i < n; i++ {
+		if err := decode(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func valueType(r *reader) (ValueType, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case byte(I32), byte(I64):
+		return ValueType(b), nil
+	case valueTypeF32, valueTypeF64:
+		return 0, errFloat
+	default:
+		return 0, errors.Errorf("unsupported value type 0x%x", b)
+	}
+}
+
+func valueTypes(r *reader) ([]ValueType, error) {
+	var types []ValueType
+	err := vector(r, func(uint32) error {
+		t, err := valueType(r)
+		types = append(types, t)
+		return err
+	})
+	return types, err
+}
+
+func (m *Module) decodeTypes(r *reader) error {
+	return vector(r, func(uint32) error {
+		form, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if form != funcTypeForm {
+			return errors.Errorf("unexpected form 0x%x of function type", form)
+		}
+		params, err := valueTypes(r)
+		if err != nil {
+			return err
+		}
+		results, err := valueTypes(r)
+		if err != nil {
+			return err
+		}
+		m.types = append(m.types, FuncType{Params: params, Results: results})
+		return nil
+	})
+}
+
+func (m *Module) typeIndex(r *reader) (uint32, error) {
+	idx, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if idx >= uint32(len(m.types)) {
+		return 0, errors.Errorf("unknown type %d", idx)
+	}
+	return idx, nil
+}
+
+func (m *Module) decodeImports(r *reader) error {
+	return vector(r, func(uint32) error {
+		module, err := r.name()
+		if err != nil {
+			return err
+		}
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if kind != exportFunc {
+			return errors.Errorf("import %s.%s isn't a function, only functions can be imported", module, name)
+		}
+		idx, err := m.typeIndex(r)
+		if err != nil {
+			return err
+		}
+		m.imports = append(m.imports, Import{Module: module, Name: name, Type: m.types[idx]})
+		return nil
+	})
+}
+
+func (m *Module) decodeFunctions(r *reader) ([]uint32, error) {
+	var funcTypes []uint32
+	err := vector(r, func(uint32) error {
+		idx, err := m.typeIndex(r)
+		funcTypes = append(funcTypes, idx)
+		return err
+	})
+	return funcTypes, err
+}
+
+func decodeLimits(r *reader, max uint32) (*limits, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	l := &limits{}
+	if l.min, err = r.u32(); err != nil {
+		return nil, err
+	}
+	switch flag {
+	case 0x00:
+	case 0x01:
+		l.hasMax = true
+		if l.max, err = r.u32(); err != nil {
+			return nil, err
+		}
+		if l.max < l.min {
+			return nil, errors.New("maximum size is lower than the minimum size")
+		}
+	default:
+		return nil, errors.Errorf("unsupported limits flag 0x%x", flag)
+	}
+	if l.min > max {
+		return nil, errors.Errorf("minimum size %d exceeds the limit of %d", l.min, max)
+	}
+	return l, nil
+}
+
+func (m *Module) decodeTable(r *reader) error {
+	return vector(r, func(uint32) error {
+		if m.table != nil {
+			return errors.New("multiple tables are not supported")
+		}
+		elemType, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if elemType != funcRefType {
+			return errors.Errorf("unsupported table element type 0x%x", elemType)
+		}
+		m.table, err = decodeLimits(r, maxTableSize)
+		return err
+	})
+}
+
+func (m *Module) decodeMemory(r *reader) error {
+	return vector(r, func(uint32) error {
+		if m.memory != nil {
+			return errors.New("multiple memories are not supported")
+		}
+		var err error
+		m.memory, err = decodeLimits(r, maxPages)
+		return err
+	})
+}
+
+// constant decodes a constant expression of the given type
+func constant(r *reader, t ValueType) (uint64, error) {
+	op, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	switch {
+	case op == opI32Const && t == I32:
+		c, err := r.s32()
+		if err != nil {
+			return 0, err
+		}
+		v = uint64(uint32(c))
+	case op == opI64Const && t == I64:
+		c, err := r.s64()
+		if err != nil {
+			return 0, err
+		}
+		v = uint64(c)
+	default:
+		return 0, errors.Errorf("unsupported constant expression of type %s starting with 0x%x", t, op)
+	}
+	if end, err := r.byte(); err != nil {
+		return 0, err
+	} else if end != opEnd {
+		return 0, errors.New("constant expression isn't terminated")
+	}
+	return v, nil
+}
+
+func (m *Module) decodeGlobals(r *reader) error {
+	return vector(r, func(uint32) error {
+		t, err := valueType(r)
+		if err != nil {
+			return err
+		}
+		mut, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if mut > 1 {
+			return errors.Errorf("invalid mutability 0x%x", mut)
+		}
+		init, err := constant(r, t)
+		if err != nil {
+			return err
+		}
+		m.globals = append(m.globals, global{mutable: mut == 1, init: init})
+		return nil
+	})
+}
+
+func (m *Module) numFunctions(declared int) uint32 {
+	return uint32(len(m.imports) + declared)
+}
+
+func (m *Module) decodeExports(r *reader, declared int) error {
+	return vector(r, func(uint32) error {
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		if _, ok := m.exports[name]; ok {
+			return errors.Errorf("duplicate export %s", name)
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		idx, err := r.u32()
+		if err != nil {
+			return err
+		}
+		var valid bool
+		switch kind {
+		case exportFunc:
+			valid = idx < m.numFunctions(declared)
+		case exportTable:
+			valid = idx == 0 && m.table != nil
+		case exportMemory:
+			valid = idx == 0 && m.memory != nil
+		case exportGlobal:
+			valid = idx < uint32(len(m.globals))
+		}
+		if !valid {
+			return errors.Errorf("export %s of unknown kind 0x%x or index %d", name, kind, idx)
+		}
+		m.exports[name] = export{kind: kind, index: idx}
+		return nil
+	})
+}
+
+func (m *Module) decodeStart(r *reader, declared int) error {
+	idx, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if idx >= m.numFunctions(declared) {
+		return errors.Errorf("unknown start function %d", idx)
+	}
+	m.start = &idx
+	return nil
+}
+
+func (m *Module) decodeElements(r *reader, declared int) error {
+	return vector(r, func(uint32) error {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flag != 0 {
+			return errors.Errorf("unsupported element segment flags 0x%x", flag)
+		}
+		if m.table == nil {
+			return errors.New("element segment without table")
+		}
+		offset, err := constant(r, I32)
+		if err != nil {
+			return err
+		}
+		e := element{offset: uint32(offset)}
+		err = vector(r, func(uint32) error {
+			idx, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if idx >= m.numFunctions(declared) {
+				return errors.Errorf("unknown function %d", idx)
+			}
+			e.funcs = append(e.funcs, idx)
+			return nil
+		})
+		m.elements = append(m.elements, e)
+		return err
+	})
+}
+
+func (m *Module) decodeCode(r *reader, funcTypes []uint32) error {
+	return vector(r, func(i uint32) error {
+		if i >= uint32(len(funcTypes)) {
+			return errors.New("more functions are defined than declared")
+		}
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		body, err := r.bytes(size)
+		if err != nil {
+			return err
+		}
+		f, err := m.compileFunction(m.types[funcTypes[i]], body, len(funcTypes))
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid function %d", uint32(len(m.imports))+i))
+		}
+		m.functions = append(m.functions, f)
+		return nil
+	})
+}
+
+func (m *Module) decodeData(r *reader) error {
+	return vector(r, func(uint32) error {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flag != 0 {
+			return errors.Errorf("unsupported data segment flags 0x%x", flag)
+		}
+		if m.memory == nil {
+			return errors.New("data segment without memory")
+		}
+		offset, err := constant(r, I32)
+		if err != nil {
+			return err
+		}
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		data, err := r.bytes(n)
+		if err != nil {
+			return err
+		}
+		m.data = append(m.data, dataSegment{offset: uint32(offset), data: data})
+		return nil
+	})
+}
+
+// Imports returns the functions imported by the module
+func (m *Module) Imports() []Import {
+	return m.imports
+}
+
+// ExportedFunction returns the type of the function exported under the given
+// name, and false if the module exports no such function
+func (m *Module) ExportedFunction(name string) (FuncType, bool) {
+	exp, ok := m.exports[name]
+	if !ok || exp.kind != exportFunc {
+		return FuncType{}, false
+	}
+	return m.funcType(exp.index), true
+}
+
+func (m *Module) funcType(idx uint32) FuncType {
+	if idx < uint32(len(m.imports)) {
+		return m.imports[idx].Type
+	}
+	return m.functions[idx-uint32(len(m.imports))].typ
+}
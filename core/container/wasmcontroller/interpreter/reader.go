@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of module")
+
+// reader decodes the values of the binary format of a module
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) len() int {
+	return len(r.buf) - r.pos
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n uint32) ([]byte, error) {
+	if uint64(n) > uint64(r.len()) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// u32 decodes an unsigned LEB128 integer of at most 32 bits
+func (r *reader) u32() (uint32, error) {
+	var result uint32
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if shift == 28 && b&0x70 != 0 {
+			return 0, errors.New("integer representation too long")
+		}
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+		if shift == 28 {
+			return 0, errors.New("integer representation too long")
+		}
+	}
+}
+
+// signed decodes a signed LEB128 integer of at most the given number of bits
+func (r *reader) signed(bits uint) (int64, error) {
+	var result int64
+	var shift uint
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if shift+7 > bits+6 {
+			return 0, errors.New("integer representation too long")
+		}
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				result |= -1 << shift
+			}
+			if bits < 64 && (result < -(1<<(bits-1)) || result >= 1<<(bits-1)) {
+				return 0, errors.New("integer too large")
+			}
+			return result, nil
+		}
+	}
+}
+
+func (r *reader) s32() (int32, error) {
+	v, err := r.signed(32)
+	return int32(v), err
+}
+
+func (r *reader) s64() (int64, error) {
+	return r.signed(64)
+}
+
+func (r *reader) name() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8 encoding of name")
+	}
+	return string(b), nil
+}
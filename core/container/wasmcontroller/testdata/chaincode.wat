@@ -0,0 +1,64 @@
+;; chaincode.wasm is the binary encoding of this module. It stores the value
+;; of a key with "put <key> <value>", and returns it with "get <key>".
+;; "now" returns the timestamp of the transaction, "loop" never ends and
+;; "trap" traps. The tests of the WebAssembly platform package it as well.
+(module
+  (import "fabric" "get_arg" (func $get_arg (param i32) (result i32)))
+  (import "fabric" "read_result" (func $read_result (param i32)))
+  (import "fabric" "get_state" (func $get_state (param i32 i32) (result i32)))
+  (import "fabric" "put_state" (func $put_state (param i32 i32 i32 i32)))
+  (import "fabric" "set_response" (func $set_response (param i32 i32)))
+  (import "fabric" "set_error" (func $set_error (param i32 i32)))
+  (import "fabric" "get_tx_timestamp" (func $get_tx_timestamp (result i64)))
+
+  (memory 1)
+  (data (i32.const 0) "unknown functionkey not found")
+
+  ;; arg copies the argument at the given index to the given address,
+  ;; and returns its length
+  (func $arg (param $index i32) (param $ptr i32) (result i32)
+    (local $len i32)
+    (local.set $len (call $get_arg (local.get $index)))
+    (call $read_result (local.get $ptr))
+    (local.get $len))
+
+  (func (export "init"))
+
+  (func (export "invoke")
+    (local $key i32) (local $value i32) (local $fn i32)
+    (drop (call $arg (i32.const 0) (i32.const 1024)))
+    (local.set $key (call $arg (i32.const 1) (i32.const 2048)))
+    (local.set $fn (i32.load8_u (i32.const 1024)))
+
+    (if (i32.eq (local.get $fn) (i32.const 0x70)) ;; put
+      (then
+        (local.set $value (call $arg (i32.const 2) (i32.const 3072)))
+        (call $put_state (i32.const 2048) (local.get $key) (i32.const 3072) (local.get $value))
+        (return)))
+
+    (if (i32.eq (local.get $fn) (i32.const 0x67)) ;; get
+      (then
+        (local.set $value (call $get_state (i32.const 2048) (local.get $key)))
+        (if (i32.eq (local.get $value) (i32.const -1))
+          (then
+            (call $set_error (i32.const 16) (i32.const 13))
+            (return)))
+        (call $read_result (i32.const 3072))
+        (call $set_response (i32.const 3072) (local.get $value))
+        (return)))
+
+    (if (i32.eq (local.get $fn) (i32.const 0x6e)) ;; now
+      (then
+        (i64.store (i32.const 3072) (call $get_tx_timestamp))
+        (call $set_response (i32.const 3072) (i32.const 8))
+        (return)))
+
+    (if (i32.eq (local.get $fn) (i32.const 0x6c)) ;; loop
+      (then
+        (loop $forever (br $forever))))
+
+    (if (i32.eq (local.get $fn) (i32.const 0x74)) ;; trap
+      (then
+        (unreachable)))
+
+    (call $set_error (i32.const 0) (i32.const 16))))
@@ -0,0 +1,253 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller/interpreter"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ContainerType is the string which the WebAssembly container type
+// is registered with the container.VMController
+const ContainerType = "WASM"
+
+// These are the limits of the instances of the chaincodes when they aren't configured
+const (
+	DefaultFuel           = 100000000
+	DefaultMaxMemoryPages = 256
+)
+
+var logger = flogging.MustGetLogger("wasmcontroller")
+
+// instance is a running WebAssembly chaincode
+type instance struct {
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Provider tracks the WebAssembly chaincodes running in the peer.
+// It implements container.VMProvider
+type Provider struct {
+	mutex     sync.Mutex
+	instances map[string]*instance
+	config    interpreter.Config
+
+	// ResourceLimits are the limits of the resources of the chaincodes. Only
+	// the memory is limited, as it lowers the maximum number of memory pages.
+	ResourceLimits container.ResourceLimitsConfig
+
+	// ChaincodeSupport handles the streams established with the chaincodes.
+	// It must be set before any chaincode is started
+	ChaincodeSupport ccintf.CCSupport
+}
+
+// NewProvider creates a Provider, whose ChaincodeSupport must be set before use.
+// The limits of the configuration which are zero are set to their default.
+func NewProvider(config interpreter.Config) *Provider {
+	if config.Fuel == 0 {
+		config.Fuel = DefaultFuel
+	}
+	if config.MaxMemoryPages == 0 {
+		config.MaxMemoryPages = DefaultMaxMemoryPages
+	}
+	return &Provider{
+		instances: make(map[string]*instance),
+		config:    config,
+	}
+}
+
+// NewVM creates a WasmVM instance
+func (p *Provider) NewVM() container.VM {
+	return &WasmVM{provider: p}
+}
+
+// configFor returns the limits of the instances of the named chaincode, whose
+// maximum number of memory pages is lowered to fit its memory limit, if any
+func (p *Provider) configFor(ccName string) interpreter.Config {
+	config := p.config
+	if memory := p.ResourceLimits.For(ccName).Memory; memory != 0 {
+		if pages := memory / interpreter.PageSize; pages < int64(config.MaxMemoryPages) {
+			config.MaxMemoryPages = uint32(pages)
+		}
+	}
+	return config
+}
+
+func (p *Provider) getInstance(name string) *instance {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.instances[name]
+}
+
+func (p *Provider) setInstance(name string, inst *instance) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.instances[name] = inst
+}
+
+func (p *Provider) removeInstance(name string, inst *instance) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.instances[name] == inst {
+		delete(p.instances, name)
+	}
+}
+
+// WasmVM is a vm which runs WebAssembly chaincode in the peer. The chaincode
+// communicates with the chaincode support through the shim, like system chaincodes,
+// but its module is interpreted with metered fuel and memory, and can only
+// access the world through the functions of its stub.
+type WasmVM struct {
+	provider *Provider
+}
+
+// Start compiles the module of the chaincode and runs it. The builder must return
+// the code package of the chaincode, and the environment must hold the name the
+// chaincode registers with
+func (vm *WasmVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	if vm.provider.ChaincodeSupport == nil {
+		logger.Panicf("Chaincode support is nil, most likely you forgot to set it immediately after calling wasmcontroller.NewProvider()")
+	}
+
+	name := ccid.GetName()
+	if vm.provider.getInstance(name) != nil {
+		return errors.Errorf("chaincode %s is already running", name)
+	}
+
+	reader, err := builder.Build()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get the code package")
+	}
+	codePackage, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the code package")
+	}
+	module, err := ModuleFromCodePackage(codePackage)
+	if err != nil {
+		return err
+	}
+	cc, err := NewChaincode(module, vm.provider.configFor(ccid.Name))
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to compile chaincode %s", name))
+	}
+
+	inst := &instance{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	vm.provider.setInstance(name, inst)
+	go func() {
+		defer close(inst.done)
+		defer vm.provider.removeInstance(name, inst)
+		vm.run(name, inst, cc, args, env)
+	}()
+
+	return nil
+}
+
+// run connects the shim of the chaincode to the chaincode support, until either
+// of them quits or the chaincode is stopped
+func (vm *WasmVM) run(name string, inst *instance, cc shim.Chaincode, args []string, env []string) {
+	peerRcvCCSend := make(chan *pb.ChaincodeMessage)
+	ccRcvPeerSend := make(chan *pb.ChaincodeMessage)
+	ccDone := make(chan struct{})
+	supportDone := make(chan struct{})
+
+	go func() {
+		defer close(ccDone)
+		err := shim.StartInProc(env, args, cc, ccRcvPeerSend, peerRcvCCSend)
+		logger.Debugf("chaincode %s ended with err: %v", name, err)
+	}()
+	go func() {
+		defer close(supportDone)
+		err := vm.provider.ChaincodeSupport.HandleChaincodeStream(newStream(peerRcvCCSend, ccRcvPeerSend))
+		logger.Debugf("stream with chaincode %s ended with err: %v", name, err)
+	}()
+
+	select {
+	case <-ccDone:
+	case <-supportDone:
+	case <-inst.stop:
+	}
+	// closing both channels makes the other end fail to send or receive
+	close(ccRcvPeerSend)
+	close(peerRcvCCSend)
+	<-ccDone
+	<-supportDone
+}
+
+// Stop stops the chaincode
+func (vm *WasmVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	name := ccid.GetName()
+	inst := vm.provider.getInstance(name)
+	if inst == nil {
+		return errors.Errorf("%s not found", name)
+	}
+	inst.stopOnce.Do(func() { close(inst.stop) })
+	<-inst.done
+	return nil
+}
+
+// Wait blocks until the chaincode stops
+func (vm *WasmVM) Wait(ccid ccintf.CCID) (int, error) {
+	name := ccid.GetName()
+	inst := vm.provider.getInstance(name)
+	if inst == nil {
+		return 0, errors.Errorf("%s not found", name)
+	}
+	<-inst.done
+	return 0, nil
+}
+
+// HealthCheck is provided in order to implement the VM interface.
+// It always returns nil, as the chaincodes run in the peer
+func (vm *WasmVM) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// stream is the end of the stream between the chaincode support and the shim
+// of a chaincode which belongs to the chaincode support
+type stream struct {
+	recv <-chan *pb.ChaincodeMessage
+	send chan<- *pb.ChaincodeMessage
+}
+
+func newStream(recv <-chan *pb.ChaincodeMessage, send chan<- *pb.ChaincodeMessage) *stream {
+	return &stream{recv: recv, send: send}
+}
+
+// Send sends a message to the chaincode
+func (s *stream) Send(msg *pb.ChaincodeMessage) (err error) {
+	// the channel is closed when the chaincode is stopped
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to send message: %v", r)
+		}
+	}()
+	s.send <- msg
+	return nil
+}
+
+// Recv receives a message from the chaincode
+func (s *stream) Recv() (*pb.ChaincodeMessage, error) {
+	msg, ok := <-s.recv
+	if !ok {
+		return nil, errors.New("channel is closed")
+	}
+	return msg, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller/interpreter"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCCSupport records the registration of the chaincode and then keeps the
+// stream open until it is closed
+type mockCCSupport struct {
+	registered chan *pb.ChaincodeID
+}
+
+func (m *mockCCSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	chaincodeID := &pb.ChaincodeID{}
+	if err := proto.Unmarshal(msg.Payload, chaincodeID); err != nil {
+		return err
+	}
+	m.registered <- chaincodeID
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(interpreter.Config{})
+	assert.Equal(t, interpreter.Config{Fuel: DefaultFuel, MaxMemoryPages: DefaultMaxMemoryPages}, provider.config)
+
+	provider = NewProvider(testConfig)
+	assert.Equal(t, testConfig, provider.config)
+}
+
+func TestConfigForResourceLimits(t *testing.T) {
+	provider := NewProvider(interpreter.Config{Fuel: 1000, MaxMemoryPages: 16})
+	provider.ResourceLimits = container.ResourceLimitsConfig{
+		Default: container.ResourceLimits{Memory: 8 * interpreter.PageSize, CPUs: 1},
+		Chaincodes: []container.ChaincodeResourceLimits{
+			{Name: "large", ResourceLimits: container.ResourceLimits{Memory: 1 << 40}},
+		},
+	}
+	assert.Equal(t, interpreter.Config{Fuel: 1000, MaxMemoryPages: 8}, provider.configFor("mycc"))
+	// the memory limit never raises the configured maximum
+	assert.Equal(t, interpreter.Config{Fuel: 1000, MaxMemoryPages: 16}, provider.configFor("large"))
+
+	provider.ResourceLimits = container.ResourceLimitsConfig{}
+	assert.Equal(t, interpreter.Config{Fuel: 1000, MaxMemoryPages: 16}, provider.configFor("mycc"))
+}
+
+func TestStartStop(t *testing.T) {
+	ccSupport := &mockCCSupport{registered: make(chan *pb.ChaincodeID, 1)}
+	provider := NewProvider(testConfig)
+	provider.ChaincodeSupport = ccSupport
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	builder := &container.CodePackageBuilder{CodePackage: codePackage(t, map[string][]byte{ModuleFile: readModule(t)})}
+	env := []string{"CORE_CHAINCODE_ID_NAME=mycc:1.0"}
+
+	err := vm.Start(ccid, nil, env, nil, builder)
+	require.NoError(t, err)
+	assert.Equal(t, "mycc:1.0", (<-ccSupport.registered).Name)
+
+	err = vm.Start(ccid, nil, env, nil, builder)
+	assert.EqualError(t, err, "chaincode mycc-1.0 is already running")
+
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := vm.Wait(ccid)
+		waitErr <- err
+	}()
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	select {
+	case err := <-waitErr:
+		// Wait either returned on the stop of the chaincode or found it stopped
+		if err != nil {
+			assert.EqualError(t, err, "mycc-1.0 not found")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return after Stop")
+	}
+	assert.Nil(t, provider.getInstance(ccid.GetName()))
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+}
+
+func TestStartWithoutName(t *testing.T) {
+	provider := NewProvider(testConfig)
+	provider.ChaincodeSupport = &mockCCSupport{registered: make(chan *pb.ChaincodeID, 1)}
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	builder := &container.CodePackageBuilder{CodePackage: codePackage(t, map[string][]byte{ModuleFile: readModule(t)})}
+
+	// the shim quits, as the chaincode has no name to register with
+	err := vm.Start(ccid, nil, nil, nil, builder)
+	require.NoError(t, err)
+	_, err = vm.Wait(ccid)
+	if err != nil {
+		assert.EqualError(t, err, "mycc-1.0 not found")
+	}
+}
+
+func TestStartFailures(t *testing.T) {
+	provider := NewProvider(testConfig)
+	provider.ChaincodeSupport = &mockCCSupport{}
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	err := vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{CodePackage: []byte("garbage")})
+	assert.Contains(t, err.Error(), "failed to read the code package")
+
+	err = vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{CodePackage: codePackage(t, map[string][]byte{ModuleFile: []byte("garbage")})})
+	assert.Contains(t, err.Error(), "failed to compile chaincode mycc-1.0: invalid WebAssembly module")
+
+	_, err = vm.Wait(ccid)
+	assert.EqualError(t, err, "mycc-1.0 not found")
+
+	provider.ChaincodeSupport = nil
+	assert.Panics(t, func() { vm.Start(ccid, nil, nil, nil, &container.CodePackageBuilder{}) })
+}
@@ -258,6 +258,14 @@ func (bc *broadcastClient) Disconnect() {
 	bc.blocksDeliverer = nil
 }
 
+// GetEndpoint returns the endpoint the client is connected to,
+// or an empty string if it isn't connected
+func (bc *broadcastClient) GetEndpoint() string {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+	return bc.endpoint
+}
+
 // UpdateEndpoints update endpoints to new values
 func (bc *broadcastClient) UpdateEndpoints(endpoints []comm.EndpointCriteria) {
 	bc.mutex.Lock()
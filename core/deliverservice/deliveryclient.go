@@ -63,6 +63,10 @@ type DeliverService interface {
 	// UpdateEndpoints updates the ordering endpoints for the given chain.
 	UpdateEndpoints(chainID string, connCriteria ConnectionCriteria) error
 
+	// Endpoint returns the ordering service endpoint the peer pulls blocks
+	// of the given chain from, or an empty string if it isn't connected
+	Endpoint(chainID string) string
+
 	// Stop terminates delivery service and closes the connection
 	Stop()
 }
@@ -269,6 +273,18 @@ func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 	return nil
 }
 
+// Endpoint returns the ordering service endpoint the peer pulls blocks
+// of the given chain from, or an empty string if it isn't connected
+func (d *deliverServiceImpl) Endpoint(chainID string) string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	dc, exists := d.deliverClients[chainID]
+	if !exists {
+		return ""
+	}
+	return dc.bclient.GetEndpoint()
+}
+
 // Stop all service and release resources
 func (d *deliverServiceImpl) Stop() {
 	d.lock.Lock()
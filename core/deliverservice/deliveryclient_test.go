@@ -515,6 +515,8 @@ func TestDeliverServiceShutdown(t *testing.T) {
 	assertBlockDissemination(100, gossipServiceAdapter.GossipBlockDisseminations, t)
 	go os.SendBlock(uint64(101))
 	assertBlockDissemination(101, gossipServiceAdapter.GossipBlockDisseminations, t)
+	assert.Equal(t, "localhost:5614", service.Endpoint("TEST_CHAINID"))
+	assert.Empty(t, service.Endpoint("OTHER_CHAINID"))
 	atomic.StoreUint64(&li.Height, uint64(102))
 	os.SetNextExpectedSeek(uint64(102))
 	// Now stop the delivery service and make sure we don't disseminate a block
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
 	"github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -50,6 +51,10 @@ type Support interface {
 	// by way of the supplied txid
 	GetTxSimulator(ledgername string, txid string) (ledger.TxSimulator, error)
 
+	// GetQueryExecutor returns a query executor for the specified ledger, which
+	// is used instead of a transaction simulator to evaluate proposals
+	GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error)
+
 	// GetHistoryQueryExecutor gives handle to a history query executor for the
 	// specified ledger
 	GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error)
@@ -62,10 +67,10 @@ type Support interface {
 	IsSysCC(name string) bool
 
 	// Execute - execute proposal, return original response of chaincode
-	Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
+	Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error)
 
 	// ExecuteLegacyInit - executes a deployment proposal, return original response of chaincode
-	ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error)
+	ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error)
 
 	// GetChaincodeDefinition returns ccprovider.ChaincodeDefinition for the chaincode with the supplied name
 	GetChaincodeDefinition(chaincodeID string, txsim ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error)
@@ -74,6 +79,10 @@ type Support interface {
 	// SignedProposal from which an id can be extracted for testing against a policy
 	CheckACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, shdr *common.SignatureHeader, hdrext *pb.ChaincodeHeaderExtension) error
 
+	// CheckEvaluateACL checks the ACL for the evaluation of proposals on the channel
+	// using the SignedProposal from which an id can be extracted for testing against a policy
+	CheckEvaluateACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader) error
+
 	// IsJavaCC returns true if the CDS package bytes describe a chaincode
 	// that requires the java runtime environment to execute
 	IsJavaCC(buf []byte) (bool, error)
@@ -130,7 +139,7 @@ func NewEndorserServer(privDist privateDataDistributor, s Support, pr *platforms
 }
 
 // call specified chaincode (system or user)
-func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, version string, input *pb.ChaincodeInput, cid *pb.ChaincodeID) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, version string, input *pb.ChaincodeInput, cid *pb.ChaincodeID) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	endorserLogger.Infof("[%s][%s] Entry chaincode: %s", txParams.ChannelID, shorttxid(txParams.TxID), cid)
 	defer func(start time.Time) {
 		logger := endorserLogger.WithOptions(zap.AddCallerSkip(1))
@@ -140,10 +149,10 @@ func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, version
 
 	var err error
 	var res *pb.Response
-	var ccevent *pb.ChaincodeEvent
+	var ccevents []*pb.ChaincodeEvent
 
 	// is this a system chaincode
-	res, ccevent, err = e.s.Execute(txParams, txParams.ChannelID, cid.Name, version, txParams.TxID, txParams.SignedProp, txParams.Proposal, input)
+	res, ccevents, err = e.s.Execute(txParams, txParams.ChannelID, cid.Name, version, txParams.TxID, txParams.SignedProp, txParams.Proposal, input)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -193,7 +202,67 @@ func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, version
 	}
 	// ----- END -------
 
-	return res, ccevent, err
+	// xscc can't write to the namespaces of the chaincodes whose writes are
+	// held by the cross-channel write intent it commits, so they are applied
+	// here, under the same TxSimulator
+	if cid.Name == crosschannel.Namespace && len(input.Args) >= 1 && string(input.Args[0]) == crosschannel.Commit {
+		if err = applyIntentWrites(txParams, res.Payload); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return res, ccevents, err
+}
+
+// applyIntentWrites applies the writes to the channel of the serialized
+// cross-channel write intent
+func applyIntentWrites(txParams *ccprovider.TransactionParams, intentBytes []byte) error {
+	intent := &pb.CrossChannelIntent{}
+	if err := proto.Unmarshal(intentBytes, intent); err != nil {
+		return errors.Wrap(err, "failed to unmarshal cross-channel write intent")
+	}
+	writes, err := crosschannel.IntentWrites(intent, txParams.ChannelID)
+	if err != nil {
+		return err
+	}
+	for _, nsWrites := range writes {
+		for _, write := range nsWrites.Writes {
+			if write.IsDelete {
+				err = txParams.TXSimulator.DeleteState(nsWrites.Namespace, write.Key)
+			} else {
+				err = txParams.TXSimulator.SetState(nsWrites.Namespace, write.Key, write.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// processCrossChannelResults completes the public simulation results of the
+// transactions involved in cross-channel writes. The writes of a transaction
+// which recorded a pending write intent are deferred into its intent, and the
+// reads of an intent are added to the results of its preparation on the target
+// channel, so that they are validated when the intent is prepared.
+func processCrossChannelResults(txParams *ccprovider.TransactionParams, input *pb.ChaincodeInput, cid *pb.ChaincodeID, res *pb.Response, simResult *ledger.TxSimulationResults) error {
+	if simResult.PubSimulationResults == nil {
+		return nil
+	}
+
+	if cid.Name == crosschannel.Namespace {
+		if len(input.Args) < 1 || string(input.Args[0]) != crosschannel.Prepare || res.Status >= shim.ERRORTHRESHOLD {
+			return nil
+		}
+		intent := &pb.CrossChannelIntent{}
+		if err := proto.Unmarshal(res.Payload, intent); err != nil {
+			return errors.Wrap(err, "failed to unmarshal cross-channel write intent")
+		}
+		return crosschannel.AddReads(simResult.PubSimulationResults, intent.TargetResults)
+	}
+
+	_, err := crosschannel.DeferWrites(simResult.PubSimulationResults, txParams.TxID)
+	return err
 }
 
 func (e *Endorser) SanitizeUserCDS(userCDS *pb.ChaincodeDeploymentSpec) (*pb.ChaincodeDeploymentSpec, error) {
@@ -209,8 +278,27 @@ func (e *Endorser) SanitizeUserCDS(userCDS *pb.ChaincodeDeploymentSpec) (*pb.Cha
 	return sanitizedCDS, nil
 }
 
+// chaincodeVersion returns the definition and the version of the chaincode to
+// invoke, after checking the instantiation policy of application chaincodes
+func (e *Endorser) chaincodeVersion(cid *pb.ChaincodeID, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, string, error) {
+	if e.s.IsSysCC(cid.Name) {
+		return nil, util.GetSysCCVersion(), nil
+	}
+
+	cdLedger, err := e.s.GetChaincodeDefinition(cid.Name, qe)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, fmt.Sprintf("make sure the chaincode %s has been successfully instantiated and try again", cid.Name))
+	}
+	version := cdLedger.CCVersion()
+
+	if err = e.s.CheckInstantiationPolicy(cid.Name, version, cdLedger); err != nil {
+		return nil, "", err
+	}
+	return cdLedger, version, nil
+}
+
 // SimulateProposal simulates the proposal by calling the chaincode
-func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid *pb.ChaincodeID) (ccprovider.ChaincodeDefinition, *pb.Response, []byte, *pb.ChaincodeEvent, error) {
+func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid *pb.ChaincodeID) (ccprovider.ChaincodeDefinition, *pb.Response, []byte, []*pb.ChaincodeEvent, error) {
 	endorserLogger.Debugf("[%s][%s] Entry chaincode: %s", txParams.ChannelID, shorttxid(txParams.TxID), cid)
 	defer endorserLogger.Debugf("[%s][%s] Exit", txParams.ChannelID, shorttxid(txParams.TxID))
 	// we do expect the payload to be a ChaincodeInvocationSpec
@@ -221,30 +309,17 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid
 		return nil, nil, nil, nil, err
 	}
 
-	var cdLedger ccprovider.ChaincodeDefinition
-	var version string
-
-	if !e.s.IsSysCC(cid.Name) {
-		cdLedger, err = e.s.GetChaincodeDefinition(cid.Name, txParams.TXSimulator)
-		if err != nil {
-			return nil, nil, nil, nil, errors.WithMessage(err, fmt.Sprintf("make sure the chaincode %s has been successfully instantiated and try again", cid.Name))
-		}
-		version = cdLedger.CCVersion()
-
-		err = e.s.CheckInstantiationPolicy(cid.Name, version, cdLedger)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-	} else {
-		version = util.GetSysCCVersion()
+	cdLedger, version, err := e.chaincodeVersion(cid, txParams.TXSimulator)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	// ---3. execute the proposal and get simulation results
 	var simResult *ledger.TxSimulationResults
 	var pubSimResBytes []byte
 	var res *pb.Response
-	var ccevent *pb.ChaincodeEvent
-	res, ccevent, err = e.callChaincode(txParams, version, cis.ChaincodeSpec.Input, cid)
+	var ccevents []*pb.ChaincodeEvent
+	res, ccevents, err = e.callChaincode(txParams, version, cis.ChaincodeSpec.Input, cid)
 	if err != nil {
 		endorserLogger.Errorf("[%s][%s] failed to invoke chaincode %s, error: %+v", txParams.ChannelID, shorttxid(txParams.TxID), cid, err)
 		return nil, nil, nil, nil, err
@@ -256,6 +331,11 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid
 			return nil, nil, nil, nil, err
 		}
 
+		if err = processCrossChannelResults(txParams, cis.ChaincodeSpec.Input, cid, res, simResult); err != nil {
+			txParams.TXSimulator.Done()
+			return nil, nil, nil, nil, err
+		}
+
 		if simResult.PvtSimulationResults != nil {
 			if cid.Name == "lscc" {
 				// TODO: remove once we can store collection configuration outside of LSCC
@@ -290,11 +370,11 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid
 			return nil, nil, nil, nil, err
 		}
 	}
-	return cdLedger, res, pubSimResBytes, ccevent, nil
+	return cdLedger, res, pubSimResBytes, ccevents, nil
 }
 
 // endorse the proposal by calling the ESCC
-func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid string, signedProp *pb.SignedProposal, proposal *pb.Proposal, response *pb.Response, simRes []byte, event *pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator, cd ccprovider.ChaincodeDefinition) (*pb.ProposalResponse, error) {
+func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid string, signedProp *pb.SignedProposal, proposal *pb.Proposal, response *pb.Response, simRes []byte, events []*pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator, cd ccprovider.ChaincodeDefinition) (*pb.ProposalResponse, error) {
 	endorserLogger.Debugf("[%s][%s] Entry chaincode: %s", chainID, shorttxid(txid), ccid)
 	defer endorserLogger.Debugf("[%s][%s] Exit", chainID, shorttxid(txid))
 
@@ -313,13 +393,24 @@ func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid strin
 	// marshalling event bytes
 	var err error
 	var eventBytes []byte
-	if event != nil {
-		eventBytes, err = putils.GetBytesChaincodeEvent(event)
+	if len(events) > 0 {
+		eventBytes, err = putils.GetBytesChaincodeEvent(events[0])
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to marshal event bytes")
 		}
 	}
 
+	// the events beyond the first one are only recorded, as a list, by the
+	// channels whose peers all know of it
+	var chaincodeEvents []*pb.ChaincodeEvent
+	if len(events) > 1 {
+		ac, ok := e.s.GetApplicationConfig(chainID)
+		if !ok || !ac.Capabilities().MultipleChaincodeEvents() {
+			return nil, errors.Errorf("chaincode %s emitted %d events but channel %s does not support multiple chaincode events", ccid.Name, len(events), chainID)
+		}
+		chaincodeEvents = events
+	}
+
 	// set version of executing chaincode
 	if isSysCC {
 		// if we want to allow mixed fabric levels we should
@@ -335,6 +426,7 @@ func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid strin
 		SignedProposal: signedProp,
 		ChaincodeID:    ccid,
 		Event:          eventBytes,
+		Events:         chaincodeEvents,
 		SimRes:         simRes,
 		Response:       response,
 		Visibility:     visibility,
@@ -497,7 +589,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//       to validate the supplied action before endorsing it
 
 	// 1 -- simulate
-	cd, res, simulationResult, ccevent, err := e.SimulateProposal(txParams, hdrExt.ChaincodeId)
+	cd, res, simulationResult, ccevents, err := e.SimulateProposal(txParams, hdrExt.ChaincodeId)
 	if err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 	}
@@ -505,8 +597,8 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		if res.Status >= shim.ERROR {
 			endorserLogger.Errorf("[%s][%s] simulateProposal() resulted in chaincode %s response status %d for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, res.Status, txid)
 			var cceventBytes []byte
-			if ccevent != nil {
-				cceventBytes, err = putils.GetBytesChaincodeEvent(ccevent)
+			if len(ccevents) > 0 {
+				cceventBytes, err = putils.GetBytesChaincodeEvent(ccevents[0])
 				if err != nil {
 					return nil, errors.Wrap(err, "failed to marshal event bytes")
 				}
@@ -529,7 +621,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
 		// Note: To endorseProposal(), we pass the released txsim. Hence, an error would occur if we try to use this txsim
-		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevents, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
 
 		// if error, capture endorsement failure metric
 		meterLabels := []string{
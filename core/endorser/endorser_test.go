@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/endorser/mocks"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
@@ -33,6 +34,7 @@ import (
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -492,7 +494,7 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
-		ExecuteEvent:               &pb.ChaincodeEvent{},
+		ExecuteEvents:              []*pb.ChaincodeEvent{{}},
 	}
 	attachPluginEndorser(support, nil)
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
@@ -504,6 +506,110 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
+func TestEndorserMultipleEvents(t *testing.T) {
+	events := []*pb.ChaincodeEvent{{EventName: "event1"}, {EventName: "event2"}}
+	newEndorser := func(capabilities *mc.MockApplicationCapabilities) pb.EndorserServer {
+		m := &mock.Mock{}
+		m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+		m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+		m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+		support := &em.MockSupport{
+			Mock:                       m,
+			GetApplicationConfigBoolRv: true,
+			GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: capabilities},
+			GetTransactionByIDErr:      errors.New(""),
+			ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+			ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+			ExecuteEvents:              events,
+		}
+		attachPluginEndorser(support, nil)
+		return endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	}
+
+	signedProp := getSignedProp("ccid", "0", t)
+	pResp, err := newEndorser(&mc.MockApplicationCapabilities{}).ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "chaincode ccid emitted 2 events but channel testchainid does not support multiple chaincode events", pResp.Response.Message)
+
+	pResp, err = newEndorser(&mc.MockApplicationCapabilities{MultipleChaincodeEventsRv: true}).ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	prp, err := utils.GetProposalResponsePayload(pResp.Payload)
+	assert.NoError(t, err)
+	act, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+	// the first event is recorded for the consumers not knowing of the list
+	event, err := utils.GetChaincodeEvents(act.Events)
+	assert.NoError(t, err)
+	assert.Equal(t, "event1", event.EventName)
+	assert.Len(t, act.ChaincodeEvents, 2)
+	assert.Equal(t, "event2", act.ChaincodeEvents[1].EventName)
+}
+
+func TestEndorserCrossChannelWrites(t *testing.T) {
+	signedProp := getSignedProp("ccid", "0", t)
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+
+	// the simulation results of a transaction whose chaincode wrote to a
+	// chaincode of another channel
+	intent := utils.MarshalOrPanic(&pb.CrossChannelIntent{TxId: chdr.TxId, SourceChannel: util.GetTestChainID(), TargetChannel: "target"})
+	pubSimResults := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace: "ccid",
+				Rwset:     utils.MarshalOrPanic(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "key", Value: []byte("value")}}}),
+			},
+			{
+				Namespace: crosschannel.Namespace,
+				Rwset:     utils.MarshalOrPanic(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: crosschannel.PendingIntentKey(chdr.TxId, intent), Value: intent}}}),
+			},
+		},
+	}
+
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(&mockccprovider.MockTxSim{
+		GetTxSimulationResultsRv: &ledger.TxSimulationResults{PubSimulationResults: pubSimResults},
+	}, nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status, pResp.Response.Message)
+	prp, err := utils.GetProposalResponsePayload(pResp.Payload)
+	assert.NoError(t, err)
+	act, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+
+	// the writes are deferred into the intent of the transaction
+	results := &rwset.TxReadWriteSet{}
+	assert.NoError(t, proto.Unmarshal(act.Results, results))
+	assert.Len(t, results.NsRwset, 2)
+	kvRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(results.NsRwset[0].Rwset, kvRWSet))
+	assert.Empty(t, kvRWSet.Writes)
+	assert.NoError(t, proto.Unmarshal(results.NsRwset[1].Rwset, kvRWSet))
+	assert.Len(t, kvRWSet.Writes, 2)
+	assert.Equal(t, crosschannel.IntentKey(chdr.TxId), kvRWSet.Writes[0].Key)
+	assert.Equal(t, crosschannel.LockKey("ccid", "key"), kvRWSet.Writes[1].Key)
+}
+
 func TestEndorserBadChannel(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
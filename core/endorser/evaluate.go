@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/validation"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// errReadOnly is returned to the chaincodes which attempt to write to the
+// ledger while evaluating a proposal
+var errReadOnly = errors.New("the ledger is read-only when evaluating a proposal")
+
+// Evaluate evaluates a proposal which only reads the state of the ledger. The
+// chaincode is invoked against a query executor instead of a transaction
+// simulator, and the proposal is rejected if the chaincode attempts to write.
+// The result isn't endorsed, it carries the height of the ledger at which the
+// proposal was evaluated instead.
+func (e *Endorser) Evaluate(ctx context.Context, signedProp *pb.SignedProposal) (*pb.EvaluateResponse, error) {
+	startTime := time.Now()
+	e.Metrics.EvaluationsReceived.Add(1)
+
+	addr := util.ExtractRemoteAddress(ctx)
+	endorserLogger.Debug("Entering: evaluation request from", addr)
+
+	// variables to capture evaluation duration metric
+	var chainID string
+	var hdrExt *pb.ChaincodeHeaderExtension
+	var success bool
+	defer func() {
+		if hdrExt != nil {
+			meterLabels := []string{
+				"channel", chainID,
+				"chaincode", hdrExt.ChaincodeId.Name + ":" + hdrExt.ChaincodeId.Version,
+				"success", strconv.FormatBool(success),
+			}
+			e.Metrics.EvaluationDuration.With(meterLabels...).Observe(time.Since(startTime).Seconds())
+		}
+
+		endorserLogger.Debug("Exit: evaluation request from", addr)
+	}()
+
+	vr, err := e.preProcessEvaluation(signedProp)
+	if err != nil {
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	prop, hdrExt, chainID, txid := vr.prop, vr.hdrExt, vr.chainID, vr.txid
+
+	// the height is obtained before the query executor, which holds a shared
+	// lock on the state that a commit in progress would wait for, while the
+	// commit holds the lock on the height. The state read by the chaincode is
+	// thus at least as recent as the height.
+	height, err := e.s.GetLedgerHeight(chainID)
+	if err != nil {
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+
+	var sim *readOnlySimulator
+	var historyQueryExecutor ledger.HistoryQueryExecutor
+	txParams := &ccprovider.TransactionParams{
+		ChannelID:  chainID,
+		TxID:       txid,
+		SignedProp: signedProp,
+		Proposal:   prop,
+	}
+	if acquireTxSimulator(chainID, hdrExt.ChaincodeId) {
+		qe, err := e.s.GetQueryExecutor(chainID)
+		if err != nil {
+			return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		}
+		defer qe.Done()
+
+		if historyQueryExecutor, err = e.s.GetHistoryQueryExecutor(chainID); err != nil {
+			return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		}
+
+		sim = &readOnlySimulator{QueryExecutor: qe}
+		txParams.TXSimulator = sim
+		txParams.HistoryQueryExecutor = historyQueryExecutor
+	}
+
+	cis, err := putils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+
+	_, version, err := e.chaincodeVersion(hdrExt.ChaincodeId, txParams.TXSimulator)
+	if err != nil {
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+
+	res, _, err := e.callChaincode(txParams, version, cis.ChaincodeSpec.Input, hdrExt.ChaincodeId)
+	if sim != nil && sim.written() {
+		// the chaincode may have ignored the error returned by the write
+		e.Metrics.EvaluationWritesRejected.With(
+			"channel", chainID,
+			"chaincode", hdrExt.ChaincodeId.Name+":"+hdrExt.ChaincodeId.Version,
+		).Add(1)
+		err = errors.Errorf("chaincode %s attempted to write to the ledger, the proposal must be endorsed instead", hdrExt.ChaincodeId.Name)
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+	if err != nil {
+		endorserLogger.Errorf("[%s][%s] failed to evaluate chaincode %s, error: %+v", chainID, shorttxid(txid), hdrExt.ChaincodeId, err)
+		return &pb.EvaluateResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+
+	success = res.Status < shim.ERRORTHRESHOLD
+	return &pb.EvaluateResponse{Response: res, Height: height}, nil
+}
+
+// preProcessEvaluation validates a proposal to evaluate. Unlike the proposals
+// to endorse, the transaction ID of the proposal isn't checked for uniqueness
+// since the proposal never becomes a transaction, and the proposal must target
+// a channel since it is evaluated against the ledger of the channel.
+func (e *Endorser) preProcessEvaluation(signedProp *pb.SignedProposal) (*validateResult, error) {
+	prop, hdr, hdrExt, err := validation.ValidateProposalMessage(signedProp)
+	if err != nil {
+		e.Metrics.ProposalValidationFailed.Add(1)
+		return nil, err
+	}
+
+	chdr, err := putils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := putils.GetSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// block invocations to security-sensitive system chaincodes
+	if e.s.IsSysCCAndNotInvokableExternal(hdrExt.ChaincodeId.Name) {
+		endorserLogger.Errorf("Error: an attempt was made by %#v to invoke system chaincode %s", shdr.Creator, hdrExt.ChaincodeId.Name)
+		return nil, errors.Errorf("chaincode %s cannot be invoked through a proposal", hdrExt.ChaincodeId.Name)
+	}
+
+	chainID := chdr.ChannelId
+	txid := chdr.TxId
+	endorserLogger.Debugf("[%s][%s] evaluating txid: %s", chainID, shorttxid(txid), txid)
+
+	if chainID == "" {
+		return nil, errors.New("proposals without a channel cannot be evaluated")
+	}
+
+	// check ACL only for application chaincodes; ACLs
+	// for system chaincodes are checked elsewhere
+	if !e.s.IsSysCC(hdrExt.ChaincodeId.Name) {
+		if err = e.s.CheckEvaluateACL(signedProp, chdr); err != nil {
+			e.Metrics.ProposalACLCheckFailed.With(
+				"channel", chainID,
+				"chaincode", hdrExt.ChaincodeId.Name+":"+hdrExt.ChaincodeId.Version,
+			).Add(1)
+			return nil, err
+		}
+	}
+
+	return &validateResult{prop: prop, hdrExt: hdrExt, chainID: chainID, txid: txid}, nil
+}
+
+// readOnlySimulator is a transaction simulator backed by a query executor,
+// which rejects the writes of the chaincode evaluating a proposal
+type readOnlySimulator struct {
+	ledger.QueryExecutor
+	writes int32
+}
+
+func (s *readOnlySimulator) reject() error {
+	atomic.StoreInt32(&s.writes, 1)
+	return errReadOnly
+}
+
+// written returns whether the chaincode attempted to write
+func (s *readOnlySimulator) written() bool {
+	return atomic.LoadInt32(&s.writes) != 0
+}
+
+func (s *readOnlySimulator) SetState(namespace string, key string, value []byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) DeleteState(namespace string, key string) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) DeleteStateMetadata(namespace, key string) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) ExecuteUpdate(query string) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) SetPrivateData(namespace, collection, key string, value []byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) DeletePrivateData(namespace, collection, key string) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) DeletePrivateDataMetadata(namespace, collection, key string) error {
+	return s.reject()
+}
+
+func (s *readOnlySimulator) GetTxSimulationResults() (*ledger.TxSimulationResults, error) {
+	return nil, errReadOnly
+}
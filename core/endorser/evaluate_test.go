@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/mocks"
+	mockccprovider "github.com/hyperledger/fabric/core/mocks/ccprovider"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvaluationSupport() *mocks.Support {
+	fakeSupport := &mocks.Support{}
+	fakeSupport.GetLedgerHeightReturns(7, nil)
+	fakeSupport.GetQueryExecutorReturns(&mockccprovider.MockTxSim{}, nil)
+	fakeSupport.GetChaincodeDefinitionReturns(&ccprovider.ChaincodeData{Name: "ccid", Version: "1.0"}, nil)
+	fakeSupport.ExecuteReturns(&pb.Response{Status: 200, Payload: []byte("value")}, nil, nil)
+	return fakeSupport
+}
+
+func TestEvaluate(t *testing.T) {
+	fakeSupport := newEvaluationSupport()
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+	signedProp := getSignedProp("ccid", "0", t)
+	eResp, err := es.Evaluate(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, eResp.Response.Status)
+	assert.Equal(t, []byte("value"), eResp.Response.Payload)
+	assert.EqualValues(t, 7, eResp.Height)
+
+	assert.Equal(t, 1, fakeSupport.CheckEvaluateACLCallCount())
+	assert.Equal(t, 0, fakeSupport.CheckACLCallCount())
+	assert.Equal(t, 0, fakeSupport.GetTxSimulatorCallCount())
+	assert.Equal(t, 0, fakeSupport.GetTransactionByIDCallCount())
+	assert.Equal(t, util.GetTestChainID(), fakeSupport.GetQueryExecutorArgsForCall(0))
+	assert.Equal(t, 0, fakeSupport.EndorseWithPluginCallCount())
+
+	txParams, _, name, version, _, _, _, _ := fakeSupport.ExecuteArgsForCall(0)
+	assert.Equal(t, "ccid", name)
+	assert.Equal(t, "1.0", version)
+	assert.NotNil(t, txParams.TXSimulator)
+
+	t.Run("ChaincodeError", func(t *testing.T) {
+		fakeSupport.ExecuteReturns(&pb.Response{Status: 500, Message: "chaincode-error"}, nil, nil)
+		eResp, err := es.Evaluate(context.Background(), signedProp)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 500, eResp.Response.Status)
+		assert.Equal(t, "chaincode-error", eResp.Response.Message)
+		assert.EqualValues(t, 7, eResp.Height)
+	})
+}
+
+func TestEvaluateWrites(t *testing.T) {
+	fakeSupport := newEvaluationSupport()
+	fakeSupport.ExecuteStub = func(txParams *ccprovider.TransactionParams, _, _, _, _ string, _ *pb.SignedProposal, _ *pb.Proposal, _ *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error) {
+		// the error is ignored, as a careless chaincode would do
+		err := txParams.TXSimulator.SetState("ccid", "key", []byte("value"))
+		assert.Error(t, err)
+		_, err = txParams.TXSimulator.GetTxSimulationResults()
+		assert.Error(t, err)
+		return &pb.Response{Status: 200}, nil, nil
+	}
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+	writesRejected := &metricsfakes.Counter{}
+	writesRejected.WithReturns(writesRejected)
+	es.Metrics.EvaluationWritesRejected = writesRejected
+
+	eResp, err := es.Evaluate(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, eResp.Response.Status)
+	assert.Contains(t, eResp.Response.Message, "chaincode ccid attempted to write to the ledger")
+	assert.EqualValues(t, 0, eResp.Height)
+
+	assert.Equal(t, 1, writesRejected.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0"}, writesRejected.WithArgsForCall(0))
+	assert.EqualValues(t, 1, writesRejected.AddArgsForCall(0))
+}
+
+func TestEvaluateFailures(t *testing.T) {
+	t.Run("BadACL", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		fakeSupport.CheckEvaluateACLReturns(errors.New("access denied"))
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		eResp, err := es.Evaluate(context.Background(), getSignedProp("ccid", "0", t))
+		assert.EqualError(t, err, "access denied")
+		assert.EqualValues(t, 500, eResp.Response.Status)
+		assert.Equal(t, 0, fakeSupport.ExecuteCallCount())
+	})
+
+	t.Run("NilProposal", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		_, err := es.Evaluate(context.Background(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, 0, fakeSupport.ExecuteCallCount())
+	})
+
+	t.Run("NoChannel", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		_, err := es.Evaluate(context.Background(), getSignedPropWithCHID("ccid", "0", "", t))
+		assert.EqualError(t, err, "proposals without a channel cannot be evaluated")
+		assert.Equal(t, 0, fakeSupport.ExecuteCallCount())
+	})
+
+	t.Run("UninvokableSysCC", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		fakeSupport.IsSysCCAndNotInvokableExternalReturns(true)
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		_, err := es.Evaluate(context.Background(), getSignedProp("ccid", "0", t))
+		assert.EqualError(t, err, "chaincode ccid cannot be invoked through a proposal")
+	})
+
+	t.Run("NoChaincodeDefinition", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		fakeSupport.GetChaincodeDefinitionReturns(nil, errors.New("not found"))
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		eResp, err := es.Evaluate(context.Background(), getSignedProp("ccid", "0", t))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 500, eResp.Response.Status)
+		assert.Contains(t, eResp.Response.Message, "make sure the chaincode ccid has been successfully instantiated")
+	})
+
+	t.Run("NoLedger", func(t *testing.T) {
+		fakeSupport := newEvaluationSupport()
+		fakeSupport.GetLedgerHeightReturns(0, errors.New("no ledger"))
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, fakeSupport, nil, &disabled.Provider{})
+
+		eResp, err := es.Evaluate(context.Background(), getSignedProp("ccid", "0", t))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 500, eResp.Response.Status)
+		assert.Equal(t, "no ledger", eResp.Response.Message)
+		assert.Equal(t, 0, fakeSupport.GetQueryExecutorCallCount())
+	})
+}
@@ -66,6 +66,28 @@ var (
 		LabelNames:   []string{"channel", "chaincode"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
 	}
+
+	evaluationDurationHistogramOpts = metrics.HistogramOpts{
+		Namespace:    "endorser",
+		Name:         "evaluation_duration",
+		Help:         "The time to complete an evaluation.",
+		LabelNames:   []string{"channel", "chaincode", "success"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{success}",
+	}
+
+	receivedEvaluationsCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "evaluations_received",
+		Help:      "The number of evaluations received.",
+	}
+
+	evaluationWritesRejectedCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "evaluation_writes_rejected",
+		Help:         "The number of evaluations rejected because the chaincode attempted to write.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
 )
 
 type EndorserMetrics struct {
@@ -77,6 +99,9 @@ type EndorserMetrics struct {
 	InitFailed               metrics.Counter
 	EndorsementsFailed       metrics.Counter
 	DuplicateTxsFailure      metrics.Counter
+	EvaluationDuration       metrics.Histogram
+	EvaluationsReceived      metrics.Counter
+	EvaluationWritesRejected metrics.Counter
 }
 
 func NewEndorserMetrics(p metrics.Provider) *EndorserMetrics {
@@ -89,5 +114,8 @@ func NewEndorserMetrics(p metrics.Provider) *EndorserMetrics {
 		InitFailed:               p.NewCounter(initFailureCounterOpts),
 		EndorsementsFailed:       p.NewCounter(endorsementFailureCounterOpts),
 		DuplicateTxsFailure:      p.NewCounter(duplicateTxsFailureCounterOpts),
+		EvaluationDuration:       p.NewHistogram(evaluationDurationHistogramOpts),
+		EvaluationsReceived:      p.NewCounter(receivedEvaluationsCounterOpts),
+		EvaluationWritesRejected: p.NewCounter(evaluationWritesRejectedCounterOpts),
 	}
 }
@@ -30,14 +30,18 @@ func TestNewEndorserMetrics(t *testing.T) {
 		InitFailed:               &metricsfakes.Counter{},
 		EndorsementsFailed:       &metricsfakes.Counter{},
 		DuplicateTxsFailure:      &metricsfakes.Counter{},
+		EvaluationDuration:       &metricsfakes.Histogram{},
+		EvaluationsReceived:      &metricsfakes.Counter{},
+		EvaluationWritesRejected: &metricsfakes.Counter{},
 	}))
 
-	gt.Expect(provider.NewHistogramCallCount()).To(Equal(1))
+	gt.Expect(provider.NewHistogramCallCount()).To(Equal(2))
 	gt.Expect(provider.Invocations()["NewHistogram"]).To(ConsistOf([][]interface{}{
 		{proposalDurationHistogramOpts},
+		{evaluationDurationHistogramOpts},
 	}))
 
-	gt.Expect(provider.NewCounterCallCount()).To(Equal(7))
+	gt.Expect(provider.NewCounterCallCount()).To(Equal(9))
 	gt.Expect(provider.Invocations()["NewCounter"]).To(ConsistOf([][]interface{}{
 		{receivedProposalsCounterOpts},
 		{successfulProposalsCounterOpts},
@@ -46,5 +50,7 @@ func TestNewEndorserMetrics(t *testing.T) {
 		{initFailureCounterOpts},
 		{endorsementFailureCounterOpts},
 		{duplicateTxsFailureCounterOpts},
+		{receivedEvaluationsCounterOpts},
+		{evaluationWritesRejectedCounterOpts},
 	}))
 }
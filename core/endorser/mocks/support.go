@@ -75,6 +75,19 @@ type Support struct {
 		result1 ledger.HistoryQueryExecutor
 		result2 error
 	}
+	GetQueryExecutorStub        func(string) (ledger.QueryExecutor, error)
+	getQueryExecutorMutex       sync.RWMutex
+	getQueryExecutorArgsForCall []struct {
+		arg1 string
+	}
+	getQueryExecutorReturns struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}
+	getQueryExecutorReturnsOnCall map[int]struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}
 	GetTransactionByIDStub        func(chid, txID string) (*pb.ProcessedTransaction, error)
 	getTransactionByIDMutex       sync.RWMutex
 	getTransactionByIDArgsForCall []struct {
@@ -100,7 +113,7 @@ type Support struct {
 	isSysCCReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	ExecuteStub        func(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
+	ExecuteStub        func(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error)
 	executeMutex       sync.RWMutex
 	executeArgsForCall []struct {
 		txParams   *ccprovider.TransactionParams
@@ -114,15 +127,15 @@ type Support struct {
 	}
 	executeReturns struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}
 	executeReturnsOnCall map[int]struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}
-	ExecuteLegacyInitStub        func(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error)
+	ExecuteLegacyInitStub        func(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error)
 	executeLegacyInitMutex       sync.RWMutex
 	executeLegacyInitArgsForCall []struct {
 		txParams   *ccprovider.TransactionParams
@@ -136,12 +149,12 @@ type Support struct {
 	}
 	executeLegacyInitReturns struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}
 	executeLegacyInitReturnsOnCall map[int]struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}
 	GetChaincodeDefinitionStub        func(chaincodeID string, txsim ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error)
@@ -185,6 +198,18 @@ type Support struct {
 		result1 bool
 		result2 error
 	}
+	CheckEvaluateACLStub        func(*pb.SignedProposal, *common.ChannelHeader) error
+	checkEvaluateACLMutex       sync.RWMutex
+	checkEvaluateACLArgsForCall []struct {
+		arg1 *pb.SignedProposal
+		arg2 *common.ChannelHeader
+	}
+	checkEvaluateACLReturns struct {
+		result1 error
+	}
+	checkEvaluateACLReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckInstantiationPolicyStub        func(name, version string, cd ccprovider.ChaincodeDefinition) error
 	checkInstantiationPolicyMutex       sync.RWMutex
 	checkInstantiationPolicyArgsForCall []struct {
@@ -517,6 +542,69 @@ func (fake *Support) GetHistoryQueryExecutorReturnsOnCall(i int, result1 ledger.
 	}{result1, result2}
 }
 
+func (fake *Support) GetQueryExecutor(arg1 string) (ledger.QueryExecutor, error) {
+	fake.getQueryExecutorMutex.Lock()
+	ret, specificReturn := fake.getQueryExecutorReturnsOnCall[len(fake.getQueryExecutorArgsForCall)]
+	fake.getQueryExecutorArgsForCall = append(fake.getQueryExecutorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetQueryExecutor", []interface{}{arg1})
+	fake.getQueryExecutorMutex.Unlock()
+	if fake.GetQueryExecutorStub != nil {
+		return fake.GetQueryExecutorStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getQueryExecutorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Support) GetQueryExecutorCallCount() int {
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
+	return len(fake.getQueryExecutorArgsForCall)
+}
+
+func (fake *Support) GetQueryExecutorCalls(stub func(string) (ledger.QueryExecutor, error)) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = stub
+}
+
+func (fake *Support) GetQueryExecutorArgsForCall(i int) string {
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
+	argsForCall := fake.getQueryExecutorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Support) GetQueryExecutorReturns(result1 ledger.QueryExecutor, result2 error) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = nil
+	fake.getQueryExecutorReturns = struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Support) GetQueryExecutorReturnsOnCall(i int, result1 ledger.QueryExecutor, result2 error) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = nil
+	if fake.getQueryExecutorReturnsOnCall == nil {
+		fake.getQueryExecutorReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryExecutor
+			result2 error
+		})
+	}
+	fake.getQueryExecutorReturnsOnCall[i] = struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Support) GetTransactionByID(chid string, txID string) (*pb.ProcessedTransaction, error) {
 	fake.getTransactionByIDMutex.Lock()
 	ret, specificReturn := fake.getTransactionByIDReturnsOnCall[len(fake.getTransactionByIDArgsForCall)]
@@ -617,7 +705,7 @@ func (fake *Support) IsSysCCReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *Support) Execute(txParams *ccprovider.TransactionParams, cid string, name string, version string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (fake *Support) Execute(txParams *ccprovider.TransactionParams, cid string, name string, version string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	fake.executeMutex.Lock()
 	ret, specificReturn := fake.executeReturnsOnCall[len(fake.executeArgsForCall)]
 	fake.executeArgsForCall = append(fake.executeArgsForCall, struct {
@@ -653,32 +741,32 @@ func (fake *Support) ExecuteArgsForCall(i int) (*ccprovider.TransactionParams, s
 	return fake.executeArgsForCall[i].txParams, fake.executeArgsForCall[i].cid, fake.executeArgsForCall[i].name, fake.executeArgsForCall[i].version, fake.executeArgsForCall[i].txid, fake.executeArgsForCall[i].signedProp, fake.executeArgsForCall[i].prop, fake.executeArgsForCall[i].input
 }
 
-func (fake *Support) ExecuteReturns(result1 *pb.Response, result2 *pb.ChaincodeEvent, result3 error) {
+func (fake *Support) ExecuteReturns(result1 *pb.Response, result2 []*pb.ChaincodeEvent, result3 error) {
 	fake.ExecuteStub = nil
 	fake.executeReturns = struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *Support) ExecuteReturnsOnCall(i int, result1 *pb.Response, result2 *pb.ChaincodeEvent, result3 error) {
+func (fake *Support) ExecuteReturnsOnCall(i int, result1 *pb.Response, result2 []*pb.ChaincodeEvent, result3 error) {
 	fake.ExecuteStub = nil
 	if fake.executeReturnsOnCall == nil {
 		fake.executeReturnsOnCall = make(map[int]struct {
 			result1 *pb.Response
-			result2 *pb.ChaincodeEvent
+			result2 []*pb.ChaincodeEvent
 			result3 error
 		})
 	}
 	fake.executeReturnsOnCall[i] = struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *Support) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid string, name string, version string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (fake *Support) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid string, name string, version string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	fake.executeLegacyInitMutex.Lock()
 	ret, specificReturn := fake.executeLegacyInitReturnsOnCall[len(fake.executeLegacyInitArgsForCall)]
 	fake.executeLegacyInitArgsForCall = append(fake.executeLegacyInitArgsForCall, struct {
@@ -714,27 +802,27 @@ func (fake *Support) ExecuteLegacyInitArgsForCall(i int) (*ccprovider.Transactio
 	return fake.executeLegacyInitArgsForCall[i].txParams, fake.executeLegacyInitArgsForCall[i].cid, fake.executeLegacyInitArgsForCall[i].name, fake.executeLegacyInitArgsForCall[i].version, fake.executeLegacyInitArgsForCall[i].txid, fake.executeLegacyInitArgsForCall[i].signedProp, fake.executeLegacyInitArgsForCall[i].prop, fake.executeLegacyInitArgsForCall[i].spec
 }
 
-func (fake *Support) ExecuteLegacyInitReturns(result1 *pb.Response, result2 *pb.ChaincodeEvent, result3 error) {
+func (fake *Support) ExecuteLegacyInitReturns(result1 *pb.Response, result2 []*pb.ChaincodeEvent, result3 error) {
 	fake.ExecuteLegacyInitStub = nil
 	fake.executeLegacyInitReturns = struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *Support) ExecuteLegacyInitReturnsOnCall(i int, result1 *pb.Response, result2 *pb.ChaincodeEvent, result3 error) {
+func (fake *Support) ExecuteLegacyInitReturnsOnCall(i int, result1 *pb.Response, result2 []*pb.ChaincodeEvent, result3 error) {
 	fake.ExecuteLegacyInitStub = nil
 	if fake.executeLegacyInitReturnsOnCall == nil {
 		fake.executeLegacyInitReturnsOnCall = make(map[int]struct {
 			result1 *pb.Response
-			result2 *pb.ChaincodeEvent
+			result2 []*pb.ChaincodeEvent
 			result3 error
 		})
 	}
 	fake.executeLegacyInitReturnsOnCall[i] = struct {
 		result1 *pb.Response
-		result2 *pb.ChaincodeEvent
+		result2 []*pb.ChaincodeEvent
 		result3 error
 	}{result1, result2, result3}
 }
@@ -898,6 +986,67 @@ func (fake *Support) IsJavaCCReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *Support) CheckEvaluateACL(arg1 *pb.SignedProposal, arg2 *common.ChannelHeader) error {
+	fake.checkEvaluateACLMutex.Lock()
+	ret, specificReturn := fake.checkEvaluateACLReturnsOnCall[len(fake.checkEvaluateACLArgsForCall)]
+	fake.checkEvaluateACLArgsForCall = append(fake.checkEvaluateACLArgsForCall, struct {
+		arg1 *pb.SignedProposal
+		arg2 *common.ChannelHeader
+	}{arg1, arg2})
+	fake.recordInvocation("CheckEvaluateACL", []interface{}{arg1, arg2})
+	fake.checkEvaluateACLMutex.Unlock()
+	if fake.CheckEvaluateACLStub != nil {
+		return fake.CheckEvaluateACLStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.checkEvaluateACLReturns
+	return fakeReturns.result1
+}
+
+func (fake *Support) CheckEvaluateACLCallCount() int {
+	fake.checkEvaluateACLMutex.RLock()
+	defer fake.checkEvaluateACLMutex.RUnlock()
+	return len(fake.checkEvaluateACLArgsForCall)
+}
+
+func (fake *Support) CheckEvaluateACLCalls(stub func(*pb.SignedProposal, *common.ChannelHeader) error) {
+	fake.checkEvaluateACLMutex.Lock()
+	defer fake.checkEvaluateACLMutex.Unlock()
+	fake.CheckEvaluateACLStub = stub
+}
+
+func (fake *Support) CheckEvaluateACLArgsForCall(i int) (*pb.SignedProposal, *common.ChannelHeader) {
+	fake.checkEvaluateACLMutex.RLock()
+	defer fake.checkEvaluateACLMutex.RUnlock()
+	argsForCall := fake.checkEvaluateACLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Support) CheckEvaluateACLReturns(result1 error) {
+	fake.checkEvaluateACLMutex.Lock()
+	defer fake.checkEvaluateACLMutex.Unlock()
+	fake.CheckEvaluateACLStub = nil
+	fake.checkEvaluateACLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Support) CheckEvaluateACLReturnsOnCall(i int, result1 error) {
+	fake.checkEvaluateACLMutex.Lock()
+	defer fake.checkEvaluateACLMutex.Unlock()
+	fake.CheckEvaluateACLStub = nil
+	if fake.checkEvaluateACLReturnsOnCall == nil {
+		fake.checkEvaluateACLReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkEvaluateACLReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Support) CheckInstantiationPolicy(name string, version string, cd ccprovider.ChaincodeDefinition) error {
 	fake.checkInstantiationPolicyMutex.Lock()
 	ret, specificReturn := fake.checkInstantiationPolicyReturnsOnCall[len(fake.checkInstantiationPolicyArgsForCall)]
@@ -1216,6 +1365,8 @@ func (fake *Support) Invocations() map[string][][]interface{} {
 	defer fake.getTxSimulatorMutex.RUnlock()
 	fake.getHistoryQueryExecutorMutex.RLock()
 	defer fake.getHistoryQueryExecutorMutex.RUnlock()
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
 	fake.isSysCCMutex.RLock()
@@ -1230,6 +1381,8 @@ func (fake *Support) Invocations() map[string][][]interface{} {
 	defer fake.checkACLMutex.RUnlock()
 	fake.isJavaCCMutex.RLock()
 	defer fake.isJavaCCMutex.RUnlock()
+	fake.checkEvaluateACLMutex.RLock()
+	defer fake.checkEvaluateACLMutex.RUnlock()
 	fake.checkInstantiationPolicyMutex.RLock()
 	defer fake.checkInstantiationPolicyMutex.RUnlock()
 	fake.getChaincodeDeploymentSpecFSMutex.RLock()
@@ -61,6 +61,7 @@ type Context struct {
 	Visibility     []byte
 	Response       *pb.Response
 	Event          []byte
+	Events         []*pb.ChaincodeEvent
 	ChaincodeID    *pb.ChaincodeID
 	SimRes         []byte
 }
@@ -237,7 +238,7 @@ func proposalResponsePayloadFromContext(ctx Context) ([]byte, error) {
 		return nil, errors.Wrap(err, "could not compute proposal hash")
 	}
 
-	prpBytes, err := putils.GetBytesProposalResponsePayload(pHashBytes, ctx.Response, ctx.SimRes, ctx.Event, ctx.ChaincodeID)
+	prpBytes, err := putils.GetBytesProposalResponsePayloadWithEvents(pHashBytes, ctx.Response, ctx.SimRes, ctx.Event, ctx.Events, ctx.ChaincodeID)
 	if err != nil {
 		endorserLogger.Warning("Failed marshaling the proposal response payload to bytes", err)
 		return nil, errors.New("failure while marshaling the ProposalResponsePayload")
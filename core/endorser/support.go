@@ -67,6 +67,15 @@ func (s *SupportImpl) GetTxSimulator(ledgername string, txid string) (ledger.TxS
 	return lgr.NewTxSimulator(txid)
 }
 
+// GetQueryExecutor returns a query executor for the specified ledger
+func (s *SupportImpl) GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error) {
+	lgr := s.Peer.GetLedger(ledgername)
+	if lgr == nil {
+		return nil, errors.Errorf("Channel does not exist: %s", ledgername)
+	}
+	return lgr.NewQueryExecutor()
+}
+
 // GetHistoryQueryExecutor gives handle to a history query executor for the
 // specified ledger
 func (s *SupportImpl) GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error) {
@@ -122,7 +131,7 @@ func (s *SupportImpl) GetChaincodeDeploymentSpecFS(cds *pb.ChaincodeDeploymentSp
 }
 
 // ExecuteInit a deployment proposal and return the chaincode response
-func (s *SupportImpl) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, cds *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (s *SupportImpl) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, cds *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	cccid := &ccprovider.CCContext{
 		Name:    name,
 		Version: version,
@@ -132,7 +141,7 @@ func (s *SupportImpl) ExecuteLegacyInit(txParams *ccprovider.TransactionParams,
 }
 
 // Execute a proposal and return the chaincode response
-func (s *SupportImpl) Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (s *SupportImpl) Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, input *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error) {
 	cccid := &ccprovider.CCContext{
 		Name:    name,
 		Version: version,
@@ -158,6 +167,12 @@ func (s *SupportImpl) CheckACL(signedProp *pb.SignedProposal, chdr *common.Chann
 	return s.ACLProvider.CheckACL(resources.Peer_Propose, chdr.ChannelId, signedProp)
 }
 
+// CheckEvaluateACL checks the ACL for the evaluation of proposals on the Channel
+// using the SignedProposal from which an id can be extracted for testing against a policy
+func (s *SupportImpl) CheckEvaluateACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader) error {
+	return s.ACLProvider.CheckACL(resources.Peer_Evaluate, chdr.ChannelId, signedProp)
+}
+
 // IsJavaCC returns true if the CDS package bytes describe a chaincode
 // that requires the java runtime environment to execute
 func (s *SupportImpl) IsJavaCC(buf []byte) (bool, error) {
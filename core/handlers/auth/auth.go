@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package auth
 
 import (
+	"context"
+
 	"github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -35,3 +37,59 @@ func ChainFilters(endorser peer.EndorserServer, filters ...Filter) peer.Endorser
 
 	return filters[0]
 }
+
+// ChainFiltersWithEvaluator chains the given auth filters in the order provided in front
+// of both the endorser and the evaluator. The filters intercept the evaluations as
+// proposals, which the last filter forwards to the evaluator instead of the endorser
+func ChainFiltersWithEvaluator(endorser peer.EndorserServer, evaluator peer.EvaluatorServer, filters ...Filter) (peer.EndorserServer, peer.EvaluatorServer) {
+	if len(filters) == 0 {
+		return endorser, evaluator
+	}
+	first := ChainFilters(&router{endorser: endorser, evaluator: evaluator}, filters...)
+	return first, &filteredEvaluator{filters: first}
+}
+
+type evaluationKey struct{}
+
+// evaluation carries the response of the evaluator back through the filters
+type evaluation struct {
+	response *peer.EvaluateResponse
+}
+
+// router is the end of the chain of filters, which forwards the evaluations
+// to the evaluator and the other proposals to the endorser
+type router struct {
+	endorser  peer.EndorserServer
+	evaluator peer.EvaluatorServer
+}
+
+func (r *router) ProcessProposal(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	eval, isEvaluation := ctx.Value(evaluationKey{}).(*evaluation)
+	if !isEvaluation {
+		return r.endorser.ProcessProposal(ctx, signedProp)
+	}
+	resp, err := r.evaluator.Evaluate(ctx, signedProp)
+	if err != nil {
+		return nil, err
+	}
+	eval.response = resp
+	return &peer.ProposalResponse{Response: resp.GetResponse()}, nil
+}
+
+// filteredEvaluator passes the evaluations through the chain of filters
+type filteredEvaluator struct {
+	filters peer.EndorserServer
+}
+
+func (e *filteredEvaluator) Evaluate(ctx context.Context, signedProp *peer.SignedProposal) (*peer.EvaluateResponse, error) {
+	eval := &evaluation{}
+	resp, err := e.filters.ProcessProposal(context.WithValue(ctx, evaluationKey{}, eval), signedProp)
+	if err != nil {
+		return nil, err
+	}
+	if eval.response == nil {
+		// a filter responded without forwarding the evaluation
+		return &peer.EvaluateResponse{Response: resp.GetResponse()}, nil
+	}
+	return eval.response, nil
+}
@@ -9,6 +9,7 @@ package auth
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"testing"
 
 	"github.com/hyperledger/fabric/protos/peer"
@@ -40,6 +41,47 @@ func TestChainFilters(t *testing.T) {
 		"Expected endorser to be invoked first")
 }
 
+func TestChainFiltersWithEvaluator(t *testing.T) {
+	iterations := 3
+	filters := createNFilters(iterations)
+	endorser := &mockEndorserServer{}
+	evaluator := &mockEvaluatorServer{}
+	initialProposal := &peer.SignedProposal{ProposalBytes: make([]byte, 4)}
+
+	// the evaluations go through the filters to the evaluator
+	_, chainedEvaluator := ChainFiltersWithEvaluator(endorser, evaluator, filters...)
+	resp, err := chainedEvaluator.Evaluate(context.Background(), initialProposal)
+	assert.NoError(t, err)
+	assert.Equal(t, &peer.EvaluateResponse{Response: &peer.Response{Status: 200}, Height: 10}, resp)
+	for i := 0; i < iterations; i++ {
+		assert.Equal(t, uint32(i), filters[i].(*mockAuthFilter).sequence)
+	}
+	assert.Equal(t, uint32(iterations), evaluator.sequence)
+	assert.Equal(t, uint32(0), endorser.sequence)
+
+	// the other proposals go through the same filters to the endorser
+	binary.BigEndian.PutUint32(initialProposal.ProposalBytes, 0)
+	chainedEndorser, _ := ChainFiltersWithEvaluator(endorser, evaluator, filters...)
+	_, err = chainedEndorser.ProcessProposal(context.Background(), initialProposal)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(iterations), endorser.sequence)
+
+	// a filter which rejects the proposals also rejects the evaluations
+	evaluator.sequence = 0
+	rejecting := []Filter{&mockAuthFilter{}, &rejectingFilter{}}
+	chainedEndorser, chainedEvaluator = ChainFiltersWithEvaluator(endorser, evaluator, rejecting...)
+	_, err = chainedEvaluator.Evaluate(context.Background(), initialProposal)
+	assert.EqualError(t, err, "rejected")
+	_, err = chainedEndorser.ProcessProposal(context.Background(), initialProposal)
+	assert.EqualError(t, err, "rejected")
+	assert.Equal(t, uint32(0), evaluator.sequence)
+
+	// without filters, the endorser and the evaluator are served as they are
+	chainedEndorser, chainedEvaluator = ChainFiltersWithEvaluator(endorser, evaluator)
+	assert.Equal(t, endorser, chainedEndorser)
+	assert.Equal(t, evaluator, chainedEvaluator)
+}
+
 func createNFilters(n int) []Filter {
 	filters := make([]Filter, n)
 	for i := 0; i < n; i++ {
@@ -72,3 +114,24 @@ func (f *mockAuthFilter) ProcessProposal(ctx context.Context, prop *peer.SignedP
 func (f *mockAuthFilter) Init(next peer.EndorserServer) {
 	f.next = next
 }
+
+type mockEvaluatorServer struct {
+	sequence uint32
+}
+
+func (es *mockEvaluatorServer) Evaluate(ctx context.Context, prop *peer.SignedProposal) (*peer.EvaluateResponse, error) {
+	es.sequence = binary.BigEndian.Uint32(prop.ProposalBytes)
+	return &peer.EvaluateResponse{Response: &peer.Response{Status: 200}, Height: 10}, nil
+}
+
+type rejectingFilter struct {
+	next peer.EndorserServer
+}
+
+func (f *rejectingFilter) ProcessProposal(ctx context.Context, prop *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	return nil, errors.New("rejected")
+}
+
+func (f *rejectingFilter) Init(next peer.EndorserServer) {
+	f.next = next
+}
@@ -58,4 +58,12 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// MultipleChaincodeEvents returns true if the chaincodes of this channel may
+	// emit more than one event per transaction.
+	MultipleChaincodeEvents() bool
+
+	// CrossChannelWrites returns true if the chaincodes of this channel may write
+	// to the chaincodes of another channel they invoke, and vice versa.
+	CrossChannelWrites() bool
 }
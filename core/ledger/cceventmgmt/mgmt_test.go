@@ -82,6 +82,35 @@ func TestCCEventMgmt(t *testing.T) {
 	assert.Equal(t, 3, handler3.doneRecievedCount)
 }
 
+func TestCCEventMgmtDeregister(t *testing.T) {
+	cc1Def := &ChaincodeDefinition{Name: "cc1", Version: "v1", Hash: []byte("cc1")}
+	cc1DBArtifactsTar := []byte("cc1DBArtifacts")
+
+	mockProvider := newMockProvider()
+	mockProvider.setChaincodeInstalled(cc1Def, cc1DBArtifactsTar)
+	mockProvider.setChaincodeDeployed("channel1", cc1Def)
+	mockProvider.setChaincodeDeployed("channel2", cc1Def)
+	setEventMgrForTest(newMgr(mockProvider))
+	defer clearEventMgrForTest()
+
+	handler1, handler2 := &mockHandler{}, &mockHandler{}
+	eventMgr := GetMgr()
+	eventMgr.Register("channel1", handler1)
+	eventMgr.Register("channel2", handler2)
+	eventMgr.Deregister("channel1")
+
+	// only the handler of channel2 receives the install event
+	eventMgr.HandleChaincodeInstall(cc1Def, cc1DBArtifactsTar)
+	eventMgr.ChaincodeInstallDone(true)
+	assert.Empty(t, handler1.eventsRecieved)
+	assert.Equal(t, 0, handler1.doneRecievedCount)
+	assert.Contains(t, handler2.eventsRecieved, &mockEvent{cc1Def, cc1DBArtifactsTar})
+	assert.Equal(t, 1, handler2.doneRecievedCount)
+
+	// deregistering a ledger without listeners is a no-op
+	eventMgr.Deregister("channel3")
+}
+
 func TestLSCCListener(t *testing.T) {
 	channelName := "testChannel"
 
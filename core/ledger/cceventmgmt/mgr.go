@@ -59,6 +59,15 @@ func (m *Mgr) Register(ledgerid string, l ChaincodeLifecycleEventListener) {
 	m.ccLifecycleListeners[ledgerid] = append(m.ccLifecycleListeners[ledgerid], l)
 }
 
+// Deregister removes the ChaincodeLifecycleEventListeners registered for given ledgerid
+// `Deregister` is expected to be invoked when removing a ledger instance
+func (m *Mgr) Deregister(ledgerid string) {
+	// write lock to wait for the deploy and install operations in progress on the ledger
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+	delete(m.ccLifecycleListeners, ledgerid)
+}
+
 // HandleChaincodeDeploy is expected to be invoked when a chaincode is deployed via a deploy transaction
 // The `chaincodeDefinitions` parameter contains all the chaincodes deployed in a block
 // We need to store the last received `chaincodeDefinitions` because this function is expected to be invoked
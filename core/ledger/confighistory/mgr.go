@@ -28,6 +28,8 @@ const (
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	// DropLedger deletes the config history of the given ledger
+	DropLedger(ledgerID string) error
 	Close()
 }
 
@@ -96,6 +98,11 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	return &retriever{dbHandle: m.dbProvider.getDB(ledgerID), ledgerInfoRetriever: ledgerInfoRetriever}
 }
 
+// DropLedger implements the function in the interface 'Mgr'
+func (m *mgr) DropLedger(ledgerID string) error {
+	return m.dbProvider.GetDBHandle(ledgerID).DeleteAll()
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
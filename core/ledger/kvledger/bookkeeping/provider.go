@@ -21,12 +21,18 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// StateFingerprint maintains the fingerprints of the world state after each block
+	StateFingerprint
 )
 
+var categories = []Category{PvtdataExpiry, MetadataPresenceIndicator, StateFingerprint}
+
 // Provider provides handle to different bookkeepers for the given ledger
 type Provider interface {
 	// GetDBHandle returns a db handle that can be used for maintaining the bookkeeping of a given category
 	GetDBHandle(ledgerID string, cat Category) *leveldbhelper.DBHandle
+	// DropLedger drops the bookkeeping of all the categories for the given ledger
+	DropLedger(ledgerID string) error
 	// Close closes the BookkeeperProvider
 	Close()
 }
@@ -46,6 +52,16 @@ func (provider *provider) GetDBHandle(ledgerID string, cat Category) *leveldbhel
 	return provider.dbProvider.GetDBHandle(fmt.Sprintf(ledgerID+"/%d", cat))
 }
 
+// DropLedger implements the function in the interface 'BookkeeperProvider'
+func (provider *provider) DropLedger(ledgerID string) error {
+	for _, cat := range categories {
+		if err := provider.GetDBHandle(ledgerID, cat).DeleteAll(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close implements the function in the interface 'BookKeeperProvider'
 func (provider *provider) Close() {
 	provider.dbProvider.Close()
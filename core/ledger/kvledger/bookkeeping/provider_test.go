@@ -29,3 +29,25 @@ func TestProvider(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("value"), val)
 }
+
+func TestDropLedger(t *testing.T) {
+	testEnv := NewTestEnv(t)
+	defer testEnv.Cleanup()
+	p := testEnv.TestProvider
+	for _, ledgerID := range []string{"TestLedger1", "TestLedger2"} {
+		for _, cat := range categories {
+			db := p.GetDBHandle(ledgerID, cat)
+			assert.NoError(t, db.Put([]byte("key"), []byte("value"), true))
+		}
+	}
+
+	assert.NoError(t, p.DropLedger("TestLedger1"))
+	for _, cat := range categories {
+		val, err := p.GetDBHandle("TestLedger1", cat).Get([]byte("key"))
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+		val, err = p.GetDBHandle("TestLedger2", cat).Get([]byte("key"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), val)
+	}
+}
@@ -81,6 +81,10 @@ func (n *collElgNotifier) registerListener(ledgerID string, listener collElgList
 	n.listeners[ledgerID] = listener
 }
 
+func (n *collElgNotifier) deregisterListener(ledgerID string) {
+	delete(n.listeners, ledgerID)
+}
+
 func (n *collElgNotifier) invokeLedgerSpecificNotifier(ledgerID string, commtingBlk uint64, nsCollMap map[string][]string) {
 	listener := n.listeners[ledgerID]
 	listener.ProcessCollsEligibilityEnabled(commtingBlk, nsCollMap)
@@ -9,6 +9,10 @@ package kvledger
 import (
 	"os"
 
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/pkg/errors"
 )
@@ -66,3 +70,40 @@ func dropHistoryDB() error {
 	err := os.RemoveAll(histroryDBPath)
 	return errors.Wrapf(err, "error removing the HistoryDB located at %s", histroryDBPath)
 }
+
+func dropLedgerStateDB(ledgerID string) error {
+	if ledgerconfig.IsCouchDBEnabled() {
+		logger.Infof("Dropping the CouchDB databases of ledger [%s]", ledgerID)
+		dbProvider, err := statecouchdb.NewVersionedDBProvider(&disabled.Provider{}, nil)
+		if err != nil {
+			return err
+		}
+		defer dbProvider.Close()
+		return errors.WithMessage(dbProvider.DropChannelDBs(ledgerID), "error dropping the CouchDB databases of ledger "+ledgerID)
+	}
+	return dropLedgerLevelDB("StateLevelDB", ledgerconfig.GetStateLevelDBPath(), ledgerID)
+}
+
+func dropLedgerConfigHistoryDB(ledgerID string) error {
+	return dropLedgerLevelDB("ConfigHistoryDB", ledgerconfig.GetConfigHistoryPath(), ledgerID)
+}
+
+func dropLedgerBookkeeperDB(ledgerID string) error {
+	logger.Infof("Dropping the entries of ledger [%s] from BookkeeperDB", ledgerID)
+	bookkeepingProvider := bookkeeping.NewProvider()
+	defer bookkeepingProvider.Close()
+	return errors.WithMessage(bookkeepingProvider.DropLedger(ledgerID), "error dropping the entries of ledger "+ledgerID+" from BookkeeperDB")
+}
+
+func dropLedgerHistoryDB(ledgerID string) error {
+	return dropLedgerLevelDB("HistoryDB", ledgerconfig.GetHistoryLevelDBPath(), ledgerID)
+}
+
+// dropLedgerLevelDB deletes the entries of a ledger from a leveldb shared by all the ledgers
+func dropLedgerLevelDB(name, dbPath, ledgerID string) error {
+	logger.Infof("Dropping the entries of ledger [%s] from %s at location [%s]", ledgerID, name, dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	defer dbProvider.Close()
+	err := dbProvider.GetDBHandle(ledgerID).DeleteAll()
+	return errors.Wrapf(err, "error dropping the entries of ledger %s from the %s located at %s", ledgerID, name, dbPath)
+}
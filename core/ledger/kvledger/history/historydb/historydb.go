@@ -27,6 +27,8 @@ import (
 type HistoryDBProvider interface {
 	// GetDBHandle returns a handle to a HistoryDB
 	GetDBHandle(id string) (HistoryDB, error)
+	// DropLedger deletes the history of the given ledger, whose HistoryDB must not be in use
+	DropLedger(id string) error
 	// Close closes all the HistoryDB instances and releases any resources held by HistoryDBProvider
 	Close()
 }
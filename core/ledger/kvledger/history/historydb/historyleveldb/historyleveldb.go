@@ -53,6 +53,11 @@ func (provider *HistoryDBProvider) GetDBHandle(dbName string) (historydb.History
 	return newHistoryDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
 }
 
+// DropLedger deletes all the entries of a named database
+func (provider *HistoryDBProvider) DropLedger(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll()
+}
+
 // Close closes the underlying db
 func (provider *HistoryDBProvider) Close() {
 	provider.dbProvider.Close()
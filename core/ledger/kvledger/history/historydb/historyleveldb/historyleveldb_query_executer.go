@@ -7,9 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -43,6 +49,241 @@ func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key str
 	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
 }
 
+// GetHistoryForKeyWithOptions implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithOptions(namespace string, key string, options *ledger.HistoryQueryOptions) (ledger.QueryResultsIterator, error) {
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if options == nil {
+		options = &ledger.HistoryQueryOptions{}
+	}
+	if options.EndBlock != 0 && options.StartBlock > options.EndBlock {
+		return nil, errors.Errorf("start block %d is greater than end block %d", options.StartBlock, options.EndBlock)
+	}
+	if options.PageSize < 0 {
+		return nil, errors.Errorf("invalid page size %d", options.PageSize)
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := append(append([]byte{}, compositePartialKey...), util.EncodeOrderPreservingVarUint64(options.StartBlock)...)
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	endBlock := uint64(math.MaxUint64)
+	if options.EndBlock != 0 {
+		endBlock = options.EndBlock
+		if endBlock < math.MaxUint64 {
+			compositeEndKey = append(append([]byte{}, compositePartialKey...), util.EncodeOrderPreservingVarUint64(endBlock+1)...)
+		}
+	}
+
+	// The bookmark is the height of the first record of the next page, which
+	// becomes the (inclusive) boundary of the range the iteration starts from
+	if options.Bookmark != "" {
+		blockNum, tranNum, err := decodeHistoryBookmark(options.Bookmark)
+		if err != nil {
+			return nil, err
+		}
+		bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, blockNum, tranNum)
+		if options.Descending {
+			compositeEndKey = append(bookmarkKey, 0x00)
+		} else {
+			compositeStartKey = bookmarkKey
+		}
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	scanner := newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore)
+	scanner.startBlock = options.StartBlock
+	scanner.endBlock = endBlock
+	scanner.descending = options.Descending
+	scanner.pageSize = options.PageSize
+	return scanner, nil
+}
+
+// GetStateAtHeight implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateAtHeight(namespace string, key string, blockNum uint64) (*queryresult.KeyModification, error) {
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if err := q.checkHeight(blockNum); err != nil {
+		return nil, err
+	}
+	return q.getStateAtHeight(namespace, key, blockNum)
+}
+
+// GetStateRangeScanIteratorAtHeight implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateRangeScanIteratorAtHeight(namespace string, startKey string, endKey string, blockNum uint64) (commonledger.ResultsIterator, error) {
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if err := q.checkHeight(blockNum); err != nil {
+		return nil, err
+	}
+
+	// The history records of the range are streamed in the order of their keys, which are
+	// namespace~key~blocknum~trannum, and the value of every key at the height is looked up
+	// when the first record of the key is reached
+	compositeStartKey := historydb.ConstructPartialCompositeHistoryKey(namespace, startKey, false)
+	compositeStartKey = compositeStartKey[:len(compositeStartKey)-1]
+	var compositeEndKey []byte
+	if endKey == "" {
+		compositeEndKey = append([]byte(namespace), 0x01)
+	} else {
+		compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, endKey, false)
+		compositeEndKey = compositeEndKey[:len(compositeEndKey)-1]
+	}
+	return &stateAtHeightScanner{
+		q:           q,
+		namespace:   namespace,
+		startKey:    startKey,
+		endKey:      endKey,
+		blockNum:    blockNum,
+		nsPrefixLen: len(namespace) + len(historydb.CompositeKeySep),
+		dbItr:       q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey),
+	}, nil
+}
+
+// checkHeight verifies that the history DB has already indexed the given block
+func (q *LevelHistoryDBQueryExecutor) checkHeight(blockNum uint64) error {
+	savepoint, err := q.historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil || blockNum > savepoint.BlockNum {
+		return errors.Errorf("block %d has not been committed to the history database yet", blockNum)
+	}
+	return nil
+}
+
+// getStateAtHeight scans the history records of the key backwards, starting at the
+// records of the given block, and returns the first record that is found in the block storage
+func (q *LevelHistoryDBQueryExecutor) getStateAtHeight(namespace string, key string, blockNum uint64) (*queryresult.KeyModification, error) {
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeEndKey := append(append([]byte{}, compositePartialKey...), util.EncodeOrderPreservingVarUint64(blockNum+1)...)
+	dbItr := q.historyDB.db.GetIterator(compositePartialKey, compositeEndKey)
+	defer dbItr.Release()
+
+	for ok := dbItr.Last(); ok; ok = dbItr.Prev() {
+		historyKey := dbItr.Key()
+		// See historyScanner.Next for the details of false keys, which are skipped here as well
+		recordBlockNum, tranNum, err := decodeBlockNumTranNum(historyKey[len(compositePartialKey):])
+		if err != nil || recordBlockNum > blockNum {
+			continue
+		}
+		tranEnvelope, err := q.blockStore.RetrieveTxByBlockNumTranNum(recordBlockNum, tranNum)
+		if err == blkstorage.ErrNotFoundInIndex {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			continue
+		}
+		logger.Debugf("Found value of namespace:%s key:%s at block %d in block %d", namespace, key, blockNum, recordBlockNum)
+		return queryResult.(*queryresult.KeyModification), nil
+	}
+	if err := dbItr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "failed scanning history of key %s in namespace %s", key, namespace)
+	}
+	return nil, nil
+}
+
+// stateAtHeightScanner implements ResultsIterator for iterating through
+// the values of keys at a given height
+type stateAtHeightScanner struct {
+	q           *LevelHistoryDBQueryExecutor
+	namespace   string
+	startKey    string
+	endKey      string
+	blockNum    uint64
+	nsPrefixLen int
+	dbItr       iterator.Iterator
+	// keyAndHeight is the key~blocknum~trannum of the current record, and
+	// splitPos the position of the next candidate split in it
+	keyAndHeight []byte
+	splitPos     int
+	// visited holds the keys whose records are being iterated, which were
+	// already looked up. The records of a key are contiguous, except when a
+	// key is followed by a nil byte in other keys, whose records may then be
+	// interleaved with its own, hence the keys are nested.
+	visited []string
+}
+
+// Next returns the next key that existed at the height, along with its value at the height.
+// Since keys may contain nil bytes, every split of the key of a record that yields a decodable
+// blocknum~trannum is considered a candidate key. False candidates are filtered out by the lookup.
+func (scanner *stateAtHeightScanner) Next() (commonledger.QueryResult, error) {
+	for {
+		if scanner.keyAndHeight == nil {
+			if !scanner.dbItr.Next() {
+				break
+			}
+			scanner.keyAndHeight = append([]byte{}, scanner.dbItr.Key()[scanner.nsPrefixLen:]...)
+			scanner.splitPos = 0
+			scanner.closeVisitedKeys()
+		}
+
+		for ; scanner.splitPos < len(scanner.keyAndHeight); scanner.splitPos++ {
+			i := scanner.splitPos
+			if scanner.keyAndHeight[i] != historydb.CompositeKeySep[0] {
+				continue
+			}
+			if _, _, err := decodeBlockNumTranNum(scanner.keyAndHeight[i+1:]); err != nil {
+				continue
+			}
+			key := string(scanner.keyAndHeight[:i])
+			if key < scanner.startKey || (scanner.endKey != "" && key >= scanner.endKey) || scanner.isVisited(key) {
+				continue
+			}
+			scanner.visited = append(scanner.visited, key)
+			keyModification, err := scanner.q.getStateAtHeight(scanner.namespace, key, scanner.blockNum)
+			if err != nil {
+				return nil, err
+			}
+			if keyModification == nil || keyModification.IsDelete {
+				continue
+			}
+			scanner.splitPos++
+			return &queryresult.KV{Namespace: scanner.namespace, Key: key, Value: keyModification.Value}, nil
+		}
+		scanner.keyAndHeight = nil
+	}
+	if err := scanner.dbItr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "failed scanning history of range [%s, %s) in namespace %s", scanner.startKey, scanner.endKey, scanner.namespace)
+	}
+	return nil, nil
+}
+
+// closeVisitedKeys forgets the visited keys whose records are all iterated,
+// which are the ones that aren't a prefix of the key of the current record
+func (scanner *stateAtHeightScanner) closeVisitedKeys() {
+	for len(scanner.visited) > 0 {
+		visited := scanner.visited[len(scanner.visited)-1]
+		if len(scanner.keyAndHeight) > len(visited) && string(scanner.keyAndHeight[:len(visited)]) == visited &&
+			scanner.keyAndHeight[len(visited)] == historydb.CompositeKeySep[0] {
+			return
+		}
+		scanner.visited = scanner.visited[:len(scanner.visited)-1]
+	}
+}
+
+func (scanner *stateAtHeightScanner) isVisited(key string) bool {
+	for _, visited := range scanner.visited {
+		if visited == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (scanner *stateAtHeightScanner) Close() {
+	scanner.dbItr.Release()
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -50,11 +291,50 @@ type historyScanner struct {
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	startBlock          uint64
+	endBlock            uint64
+	descending          bool
+	pageSize            int32
+	returned            int32
+	started             bool
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+		endBlock:            math.MaxUint64,
+	}
+}
+
+// advance moves the underlying iterator to the next history record in the order of the scan
+func (scanner *historyScanner) advance() bool {
+	if !scanner.descending {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
+
+// decodeCurrent returns the blockNum and tranNum of the history record the iterator is positioned at,
+// or an error if the record is a false key or lies outside of the block range of the scan
+func (scanner *historyScanner) decodeCurrent() (uint64, uint64, error) {
+	_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(scanner.dbItr.Key(), scanner.compositePartialKey)
+	blockNum, tranNum, err := decodeBlockNumTranNum(blockNumTranNumBytes)
+	if err != nil {
+		return 0, 0, err
+	}
+	if blockNum < scanner.startBlock || blockNum > scanner.endBlock {
+		return 0, 0, errors.Errorf("block %d is outside of the range [%d, %d]", blockNum, scanner.startBlock, scanner.endBlock)
+	}
+	return blockNum, tranNum, nil
 }
 
 // Next iterates to the next key from history scanner, decodes blockNumTranNumBytes to get blockNum and tranNum,
@@ -68,15 +348,15 @@ func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 // was actually added for some other <ns, key, blockNum, tranNum>. It would cause this iterator to
 // return a history query result out of the order.
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.pageSize > 0 && scanner.returned >= scanner.pageSize {
+		return nil, nil
+	}
 	for {
-		if !scanner.dbItr.Next() {
+		if !scanner.advance() {
 			return nil, nil
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
 
-		// SplitCompositeKey(namespace~key~blocknum~trannum, namespace~key~) will return the blocknum~trannum in second position
-		_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, scanner.compositePartialKey)
-
 		//
 		// FAB-15450
 		// There may be false keys because a key may have nil byte(s).
@@ -94,7 +374,9 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		//
 		// Note: in some scenarios, this can map to a block:tran in the block storage that contains the key
 		// but is out of order of iteration and hence the results are not guaranteed to be in order.
-		blockNum, tranNum, err := decodeBlockNumTranNum(blockNumTranNumBytes)
+		// Records of the key outside of the block range of the scan, which may only be found at the boundaries
+		// because of the same clashes, are skipped as well.
+		blockNum, tranNum, err := scanner.decodeCurrent()
 		if err != nil {
 			logger.Warnf("Some other key [%#v] found in the range while scanning history for key [%#v]. Skipping (decoding error: %s)",
 				historyKey, scanner.key, err)
@@ -131,6 +413,7 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		}
 		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
 			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+		scanner.returned++
 		return queryResult, nil
 	}
 }
@@ -139,6 +422,42 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose returns the height of the history record following the last
+// returned one, or an empty bookmark if there are no more records in the range
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	for scanner.advance() {
+		blockNum, tranNum, err := scanner.decodeCurrent()
+		if err != nil {
+			continue
+		}
+		return encodeHistoryBookmark(blockNum, tranNum)
+	}
+	return ""
+}
+
+// encodeHistoryBookmark encodes the height of a history record as a bookmark
+func encodeHistoryBookmark(blockNum uint64, tranNum uint64) string {
+	return fmt.Sprintf("%d:%d", blockNum, tranNum)
+}
+
+// decodeHistoryBookmark decodes the height of a history record from a bookmark
+func decodeHistoryBookmark(bookmark string) (uint64, uint64, error) {
+	parts := strings.Split(bookmark, ":")
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid bookmark %s", bookmark)
+	}
+	blockNum, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid bookmark %s", bookmark)
+	}
+	tranNum, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid bookmark %s", bookmark)
+	}
+	return blockNum, tranNum, nil
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
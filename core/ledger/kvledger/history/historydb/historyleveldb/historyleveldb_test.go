@@ -198,6 +198,170 @@ func TestHistory(t *testing.T) {
 	assert.Equal(t, 4, count)
 }
 
+func TestStateAtHeight(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(writes map[string][]byte) {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		for key, value := range writes {
+			if value == nil {
+				simulator.DeleteState("ns1", key)
+				continue
+			}
+			simulator.SetState("ns1", key, value)
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	// the records of "key0" and "key0\x00\x01" are interleaved in the history DB
+	commitBlock(map[string][]byte{"key0": []byte("value1"), "key0\x00\x01": []byte("value1"), "key1": []byte("value1"), "key1\x00a": []byte("value1"), "key2": []byte("value1")})
+	commitBlock(map[string][]byte{"key0": []byte("value2"), "key1": []byte("value2"), "key3": []byte("value1")})
+	commitBlock(map[string][]byte{"key1": nil})
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	kmod, err := qhistory.GetStateAtHeight("ns1", "key1", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, kmod)
+	kmod, err = qhistory.GetStateAtHeight("ns1", "key1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), kmod.Value)
+	kmod, err = qhistory.GetStateAtHeight("ns1", "key1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), kmod.Value)
+	kmod, err = qhistory.GetStateAtHeight("ns1", "key1", 3)
+	assert.NoError(t, err)
+	assert.True(t, kmod.IsDelete)
+	kmod, err = qhistory.GetStateAtHeight("ns1", "key1\x00a", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), kmod.Value)
+	_, err = qhistory.GetStateAtHeight("ns1", "key1", 4)
+	assert.EqualError(t, err, "block 4 has not been committed to the history database yet")
+
+	verifyRange := func(startKey, endKey string, blockNum uint64, expected []string) {
+		itr, err := qhistory.GetStateRangeScanIteratorAtHeight("ns1", startKey, endKey, blockNum)
+		assert.NoError(t, err)
+		defer itr.Close()
+		retrieved := []string{}
+		for {
+			kv, err := itr.Next()
+			assert.NoError(t, err)
+			if kv == nil {
+				break
+			}
+			retrieved = append(retrieved, kv.(*queryresult.KV).Key+"="+string(kv.(*queryresult.KV).Value))
+		}
+		assert.Equal(t, expected, retrieved)
+	}
+	verifyRange("", "", 0, []string{})
+	verifyRange("", "", 1, []string{"key0\x00\x01=value1", "key0=value1", "key1=value1", "key1\x00a=value1", "key2=value1"})
+	verifyRange("", "", 2, []string{"key0\x00\x01=value1", "key0=value2", "key1=value2", "key1\x00a=value1", "key2=value1", "key3=value1"})
+	verifyRange("", "", 3, []string{"key0\x00\x01=value1", "key0=value2", "key1\x00a=value1", "key2=value1", "key3=value1"})
+	verifyRange("key1\x00", "key3", 2, []string{"key1\x00a=value1", "key2=value1"})
+	_, err = qhistory.GetStateRangeScanIteratorAtHeight("ns1", "", "", 4)
+	assert.EqualError(t, err, "block 4 has not been committed to the history database yet")
+}
+
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// blocks 1 to 5 write value1 to value5 of key1, along with a clashing key
+	for i := 1; i <= 5; i++ {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.SetState("ns1", "key1\x00a", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	query := func(options *ledger.HistoryQueryOptions) ([]string, string) {
+		itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key1", options)
+		assert.NoError(t, err)
+		retrieved := []string{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				break
+			}
+			retrieved = append(retrieved, string(kmod.(*queryresult.KeyModification).Value))
+		}
+		return retrieved, itr.GetBookmarkAndClose()
+	}
+
+	retrieved, bookmark := query(nil)
+	assert.Equal(t, []string{"value1", "value2", "value3", "value4", "value5"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{Descending: true})
+	assert.Equal(t, []string{"value5", "value4", "value3", "value2", "value1"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{StartBlock: 2, EndBlock: 4})
+	assert.Equal(t, []string{"value2", "value3", "value4"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{StartBlock: 4, Descending: true})
+	assert.Equal(t, []string{"value5", "value4"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{PageSize: 2})
+	assert.Equal(t, []string{"value1", "value2"}, retrieved)
+	assert.Equal(t, "3:0", bookmark)
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{PageSize: 2, Bookmark: bookmark})
+	assert.Equal(t, []string{"value3", "value4"}, retrieved)
+	assert.Equal(t, "5:0", bookmark)
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{PageSize: 2, Bookmark: bookmark})
+	assert.Equal(t, []string{"value5"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{EndBlock: 4, PageSize: 2, Descending: true})
+	assert.Equal(t, []string{"value4", "value3"}, retrieved)
+	assert.Equal(t, "2:0", bookmark)
+	retrieved, bookmark = query(&ledger.HistoryQueryOptions{EndBlock: 4, PageSize: 2, Bookmark: bookmark, Descending: true})
+	assert.Equal(t, []string{"value2", "value1"}, retrieved)
+	assert.Empty(t, bookmark)
+
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key1", &ledger.HistoryQueryOptions{StartBlock: 3, EndBlock: 2})
+	assert.EqualError(t, err, "start block 3 is greater than end block 2")
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key1", &ledger.HistoryQueryOptions{PageSize: -1})
+	assert.EqualError(t, err, "invalid page size -1")
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key1", &ledger.HistoryQueryOptions{Bookmark: "foo"})
+	assert.EqualError(t, err, "invalid bookmark foo")
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -252,6 +416,12 @@ func TestHistoryDisabled(t *testing.T) {
 	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
 	_, err2 := qhistory.GetHistoryForKey("ns1", "key7")
 	assert.Error(t, err2, "Error should have been returned for GetHistoryForKey() when history disabled")
+	_, err2 = qhistory.GetStateAtHeight("ns1", "key7", 0)
+	assert.Error(t, err2, "Error should have been returned for GetStateAtHeight() when history disabled")
+	_, err2 = qhistory.GetStateRangeScanIteratorAtHeight("ns1", "", "", 0)
+	assert.Error(t, err2, "Error should have been returned for GetStateRangeScanIteratorAtHeight() when history disabled")
+	_, err2 = qhistory.GetHistoryForKeyWithOptions("ns1", "key7", nil)
+	assert.Error(t, err2, "Error should have been returned for GetHistoryForKeyWithOptions() when history disabled")
 }
 
 //TestGenesisBlockNoError tests that Genesis blocks are ignored by history processing
@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -255,6 +256,8 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	numBlocks := lastBlockNum - firstBlockNum + 1
+	lastReportedProgress := uint64(0)
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
@@ -264,6 +267,11 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		// the progress is reported in steps of 10%
+		if progress := (blockNumber - firstBlockNum + 1) * 10 / numBlocks; progress > lastReportedProgress && blockNumber < lastBlockNum {
+			lastReportedProgress = progress
+			logger.Infof("Recommitted lost blocks up to block [%d] of [%d] (%d%%) for ledger [%s]", blockNumber, lastBlockNum, progress*10, l.ledgerID)
+		}
 	}
 	logger.Infof("Recommitted lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	return nil
@@ -293,6 +301,38 @@ func (l *kvLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	return bcInfo, err
 }
 
+// GetStateFingerprint returns the fingerprint of the world state right after the given block was committed
+func (l *kvLedger) GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error) {
+	// the block APIs lock makes the block store and the state database consistent
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if blockNum >= bcInfo.Height {
+		return nil, errors.Errorf("block %d has not been committed yet, the ledger height is %d", blockNum, bcInfo.Height)
+	}
+	fingerprint, err := l.txtmgmt.GetStateFingerprint(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint == nil {
+		return nil, errors.Errorf("the state fingerprint of block %d is not available", blockNum)
+	}
+	return fingerprint, nil
+}
+
+// GetChaincodeEvents returns an iterator over the events with the given name set by the given chaincode
+// in the valid transactions of the given range of blocks
+func (l *kvLedger) GetChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (commonledger.ResultsIterator, error) {
+	itr, err := l.blockStore.RetrieveChaincodeEvents(chaincodeID, eventName, startBlockNum, endBlockNum)
+	if err == blkstorage.ErrAttrNotIndexed {
+		return nil, errors.New("the chaincode event index is not enabled")
+	}
+	return itr, err
+}
+
 // GetBlockByNumber returns block at a given height
 // blockNumber of  math.MaxUint64 will return last block
 func (l *kvLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
@@ -479,6 +519,15 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.blockStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for at most
+// `maxBlock` blocks within the range [startBlock, endBlock], starting from `endBlock`.
+func (l *kvLedger) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	if l.blockStore.IsPvtStoreAheadOfBlockStore() {
+		return nil, nil
+	}
+	return l.blockStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock)
+}
+
 func (l *kvLedger) addBlockCommitHash(block *common.Block, updateBatchBytes []byte) {
 	var valueBytes []byte
 
@@ -558,6 +607,39 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*
 	return hashMismatches, nil
 }
 
+// RepairPvtDataOfOldBlocks implements the corresponding method in interface ledger.PeerLedger
+func (l *kvLedger) RepairPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
+		l.ledgerID, len(pvtData))
+
+	hashVerifiedPvtData, hashMismatches, err := constructValidAndInvalidPvtData(pvtData, l.blockStore)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("[%s:] Replacing the corrupted pvtData of [%d] old blocks in the pvtdatastore", l.ledgerID, len(pvtData))
+	missingPvtData, err := l.blockStore.ReplaceCorruptedPvtData(hashVerifiedPvtData)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingPvtData) == 0 {
+		return hashMismatches, nil
+	}
+
+	logger.Debugf("[%s:] Committing missing pvtData of [%d] old blocks to the pvtdatastore", l.ledgerID, len(missingPvtData))
+	err = l.blockStore.CommitPvtDataOfOldBlocks(missingPvtData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.applyValidTxPvtDataOfOldBlocks(missingPvtData)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashMismatches, nil
+}
+
 func (l *kvLedger) applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData map[uint64][]*ledger.TxPvtData) error {
 	logger.Debugf("[%s:] Filtering pvtData of invalidation transactions", l.ledgerID)
 	committedPvtData, err := filterPvtDataOfInvalidTx(hashVerifiedPvtData, l.blockStore)
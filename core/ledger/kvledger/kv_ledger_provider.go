@@ -7,7 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -36,6 +36,9 @@ var (
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
 	ledgerKeyPrefix            = []byte("l")
+	ledgerKeyStop              = []byte("m")
+	rebuildStatusKeyPrefix     = []byte("r")
+	underRemovalKeyPrefix      = []byte("d")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -99,6 +102,7 @@ func (provider *Provider) Initialize(initializer *ledger.Initializer) error {
 	}
 	provider.stats = newStats(initializer.MetricsProvider)
 	provider.recoverUnderConstructionLedger()
+	provider.recoverUnderRemovalLedgers()
 	return nil
 }
 
@@ -152,6 +156,17 @@ func (provider *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 }
 
 func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, error) {
+	// Databases dropped for a rebuild are rebuilt by the recovery performed when opening the ledger,
+	// provided that the drop has completed
+	rebuildStatus, err := provider.idStore.getRebuildStatus(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if rebuildStatus != nil && !rebuildStatus.Dropped {
+		return nil, errors.Errorf("the databases %s of ledger [%s] have been partially dropped for a rebuild, "+
+			"complete the rebuild by executing the 'peer node rebuild-dbs' command again", rebuildStatus.DBs, ledgerID)
+	}
+
 	// Get the block store for a chain/ledger
 	blockStore, err := provider.ledgerStoreProvider.Open(ledgerID)
 	if err != nil {
@@ -182,6 +197,13 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	if err != nil {
 		return nil, err
 	}
+	if rebuildStatus != nil {
+		if err := provider.idStore.unsetRebuildStatus(ledgerID); err != nil {
+			l.Close()
+			return nil, err
+		}
+		logger.Infof("The databases %s of ledger [%s] have been rebuilt", rebuildStatus.DBs, ledgerID)
+	}
 	return l, nil
 }
 
@@ -195,6 +217,50 @@ func (provider *Provider) List() ([]string, error) {
 	return provider.idStore.getAllLedgerIds()
 }
 
+// Remove implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function sets an under removal flag for the ledger, which removes it from the created ledgers
+// list atomically, before dropping any of its data. If a crash happens in between, the
+// 'recoverUnderRemovalLedgers' function completes the removal before declaring the provider to be usable
+func (provider *Provider) Remove(ledgerID string) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	if err := provider.idStore.setUnderRemovalFlag(ledgerID); err != nil {
+		return err
+	}
+	return provider.dropLedger(ledgerID)
+}
+
+// dropLedger drops all the data of a ledger that is under removal and then unsets its under removal flag
+func (provider *Provider) dropLedger(ledgerID string) error {
+	logger.Infof("Dropping the data of ledger [%s]", ledgerID)
+	provider.collElgNotifier.deregisterListener(ledgerID)
+	if err := provider.vdbProvider.DropLedger(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the state database of ledger [%s]", ledgerID))
+	}
+	if err := provider.configHistoryMgr.DropLedger(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the config history of ledger [%s]", ledgerID))
+	}
+	if err := provider.bookkeepingProvider.DropLedger(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the bookkeeping of ledger [%s]", ledgerID))
+	}
+	if err := provider.historydbProvider.DropLedger(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the history database of ledger [%s]", ledgerID))
+	}
+	if err := provider.ledgerStoreProvider.DropLedger(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the block store of ledger [%s]", ledgerID))
+	}
+	if err := provider.idStore.unsetUnderRemovalFlag(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Ledger [%s] has been removed", ledgerID)
+	return nil
+}
+
 // Close implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Close() {
 	provider.idStore.close()
@@ -243,6 +309,17 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	return
 }
 
+// recoverUnderRemovalLedgers completes the removal of the ledgers whose under removal flag is set - this would
+// be the case if a crash had happened while dropping the data of a ledger being removed
+func (provider *Provider) recoverUnderRemovalLedgers() {
+	ledgerIDs, err := provider.idStore.getUnderRemovalLedgerIDs()
+	panicOnErr(err, "Error while retrieving the ledgers under removal")
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("ledger [%s] found as under removal, completing its removal", ledgerID)
+		panicOnErr(provider.dropLedger(ledgerID), "Error while removing ledger [%s]", ledgerID)
+	}
+}
+
 // runCleanup cleans up blockstorage, statedb, and historydb for what
 // may have got created during in-complete ledger creation
 func (provider *Provider) runCleanup(ledgerID string) error {
@@ -322,16 +399,64 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	itr := s.db.GetIterator(ledgerKeyPrefix, ledgerKeyStop)
 	defer itr.Release()
-	itr.First()
-	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
-		}
+	for itr.Next() {
 		id := string(s.decodeLedgerID(itr.Key()))
 		ids = append(ids, id)
-		itr.Next()
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the ledger IDs")
+	}
+	return ids, nil
+}
+
+func (s *idStore) setRebuildStatus(ledgerID string, status *rebuildStatus) error {
+	val, err := json.Marshal(status)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the rebuild status")
+	}
+	return s.db.Put(s.encodeRebuildStatusKey(ledgerID), val, true)
+}
+
+func (s *idStore) unsetRebuildStatus(ledgerID string) error {
+	return s.db.Delete(s.encodeRebuildStatusKey(ledgerID), true)
+}
+
+func (s *idStore) getRebuildStatus(ledgerID string) (*rebuildStatus, error) {
+	val, err := s.db.Get(s.encodeRebuildStatusKey(ledgerID))
+	if err != nil || val == nil {
+		return nil, err
+	}
+	status := &rebuildStatus{}
+	if err := json.Unmarshal(val, status); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the rebuild status of ledger [%s]", ledgerID)
+	}
+	return status, nil
+}
+
+// setUnderRemovalFlag removes the ledger from the created ledgers list and flags it as under removal atomically
+func (s *idStore) setUnderRemovalFlag(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Delete(s.encodeLedgerKey(ledgerID))
+	batch.Delete(s.encodeRebuildStatusKey(ledgerID))
+	batch.Put(s.encodeUnderRemovalKey(ledgerID), []byte{})
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) unsetUnderRemovalFlag(ledgerID string) error {
+	return s.db.Delete(s.encodeUnderRemovalKey(ledgerID), true)
+}
+
+func (s *idStore) getUnderRemovalLedgerIDs() ([]string, error) {
+	var ids []string
+	itr := s.db.GetIterator(underRemovalKeyPrefix, []byte{underRemovalKeyPrefix[0] + 1})
+	defer itr.Release()
+	for itr.Next() {
+		ids = append(ids, string(itr.Key()[len(underRemovalKeyPrefix):]))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the ledgers under removal")
 	}
 	return ids, nil
 }
@@ -344,6 +469,14 @@ func (s *idStore) encodeLedgerKey(ledgerID string) []byte {
 	return append(ledgerKeyPrefix, []byte(ledgerID)...)
 }
 
+func (s *idStore) encodeRebuildStatusKey(ledgerID string) []byte {
+	return append(append([]byte{}, rebuildStatusKeyPrefix...), []byte(ledgerID)...)
+}
+
+func (s *idStore) encodeUnderRemovalKey(ledgerID string) []byte {
+	return append(append([]byte{}, underRemovalKeyPrefix...), []byte(ledgerID)...)
+}
+
 func (s *idStore) decodeLedgerID(key []byte) string {
 	return string(key[len(ledgerKeyPrefix):])
 }
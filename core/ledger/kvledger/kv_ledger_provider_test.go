@@ -184,6 +184,88 @@ func TestMultipleLedgerBasicRW(t *testing.T) {
 	}
 }
 
+func TestLedgerRemoval(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", nil)
+	provider := testutilNewProvider(t)
+
+	ledgerIDs := []string{constructTestLedgerID(0), constructTestLedgerID(1)}
+	genesisBlocks := make([]*common.Block, len(ledgerIDs))
+	for i, ledgerID := range ledgerIDs {
+		bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+		genesisBlocks[i] = gb
+		l, err := provider.Create(gb)
+		assert.NoError(t, err)
+		simulator, _ := l.NewTxSimulator(util.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte("value1"))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}, &lgr.CommitOptions{}))
+		l.Close()
+	}
+
+	assert.NoError(t, provider.Remove(ledgerIDs[0]))
+	assert.Equal(t, ErrNonExistingLedgerID, provider.Remove(ledgerIDs[0]))
+	existingLedgerIDs, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerIDs[1:], existingLedgerIDs)
+	_, err = provider.Open(ledgerIDs[0])
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+	_, err = os.Stat(filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, ledgerIDs[0]))
+	assert.True(t, os.IsNotExist(err))
+
+	// a ledger with the same id starts afresh
+	l, err := provider.Create(genesisBlocks[0])
+	assert.NoError(t, err)
+	assertLedgerHeightAndState(t, l, 1, nil)
+	hqe, err := l.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	itr, err := hqe.GetHistoryForKey("ns1", "key1")
+	assert.NoError(t, err)
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	itr.Close()
+	l.Close()
+
+	// the other ledger is untouched
+	l, err = provider.Open(ledgerIDs[1])
+	assert.NoError(t, err)
+	assertLedgerHeightAndState(t, l, 2, []byte("value1"))
+	l.Close()
+
+	// assume a crash happens after the ledger has been flagged as under removal
+	assert.NoError(t, provider.(*Provider).idStore.setUnderRemovalFlag(ledgerIDs[1]))
+	provider.Close()
+
+	// the removal is completed when the provider is initialized
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	existingLedgerIDs, err = provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerIDs[:1], existingLedgerIDs)
+	underRemovalLedgerIDs, err := provider.(*Provider).idStore.getUnderRemovalLedgerIDs()
+	assert.NoError(t, err)
+	assert.Empty(t, underRemovalLedgerIDs)
+	_, err = os.Stat(filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, ledgerIDs[1]))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func assertLedgerHeightAndState(t *testing.T, l lgr.PeerLedger, expectedHeight uint64, expectedValue []byte) {
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHeight, bcInfo.Height)
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedValue, val)
+}
+
 func TestLedgerBackup(t *testing.T) {
 	ledgerid := "TestLedger"
 	originalPath := "/tmp/fabric/ledgertests/kvledger1"
@@ -566,6 +566,54 @@ func testSyncStateDBWithPvtdatastore(t *testing.T) {
 	assert.Equal(t, value, []byte("pvtValue5"))
 }
 
+func TestStateFingerprint(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg1, gb1 := testutil.NewBlockGenerator(t, "testLedger1", false)
+	ledger1, err := provider.Create(gb1)
+	assert.NoError(t, err)
+	defer ledger1.Close()
+	bg2, gb2 := testutil.NewBlockGenerator(t, "testLedger2", false)
+	ledger2, err := provider.Create(gb2)
+	assert.NoError(t, err)
+	defer ledger2.Close()
+
+	commit := func(l lgr.PeerLedger, bg *testutil.BlockGenerator, writes map[string]string) *common.StateFingerprint {
+		simulator, _ := l.NewTxSimulator(util.GenerateUUID())
+		for key, value := range writes {
+			simulator.SetState("ns1", key, []byte(value))
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		fingerprint, err := l.GetStateFingerprint(block.Header.Number)
+		assert.NoError(t, err)
+		return fingerprint
+	}
+
+	// the same updates lead to the same fingerprints on different ledgers
+	fingerprint1 := commit(ledger1, bg1, map[string]string{"key1": "value1", "key2": "value2"})
+	fingerprint2 := commit(ledger2, bg2, map[string]string{"key2": "value2", "key1": "value1"})
+	assert.Equal(t, fingerprint1, fingerprint2)
+
+	fingerprint1 = commit(ledger1, bg1, map[string]string{"key1": "value3"})
+	fingerprint2 = commit(ledger2, bg2, map[string]string{"key1": "value4"})
+	assert.NotEqual(t, fingerprint1.PublicState, fingerprint2.PublicState)
+	assert.Equal(t, fingerprint1.PrivateDataHashes, fingerprint2.PrivateDataHashes)
+
+	fingerprint0, err := ledger1.GetStateFingerprint(0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), fingerprint0.BlockNumber)
+
+	_, err = ledger1.GetStateFingerprint(3)
+	assert.EqualError(t, err, "block 3 has not been committed yet, the ledger height is 3")
+}
+
 func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 
 	//call a helper method to load the core.yaml
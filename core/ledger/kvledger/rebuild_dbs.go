@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// The databases of a ledger that are derived from its block store and can be rebuilt from it
+const (
+	// RebuildableStateDB is the state database, along with the config history and the
+	// bookkeeping databases which are maintained in step with it
+	RebuildableStateDB = "statedb"
+	// RebuildableHistoryDB is the history database
+	RebuildableHistoryDB = "historydb"
+	// RebuildableBlockIndex is the block index
+	RebuildableBlockIndex = "blockindex"
+)
+
+// rebuildableDBs lists the rebuildable databases in the order in which they are dropped
+var rebuildableDBs = []string{RebuildableStateDB, RebuildableHistoryDB, RebuildableBlockIndex}
+
+// rebuildStatus records, in the ID store, the databases of a ledger that are being rebuilt.
+// Once all of them have been dropped, they are rebuilt by the recovery performed when the
+// ledger is opened next, which clears the status
+type rebuildStatus struct {
+	DBs     []string `json:"dbs"`
+	Dropped bool     `json:"dropped"`
+}
+
+// DropDBsForRebuild drops the given databases, or all the enabled ones if none is given, of the
+// given ledgers, or of all the ledgers if none is given, so that they are rebuilt from the block stores, without fetching any block,
+// when the ledgers are opened next. It returns the IDs of the ledgers to be opened.
+// The rebuild is resumable: if the drop is interrupted, the ledgers cannot be opened until the
+// function is invoked again, and the databases that have been dropped already are not dropped
+// again, so that their partial rebuild is resumed rather than restarted
+func DropDBsForRebuild(ledgerIDs []string, dbs []string) ([]string, error) {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	if len(dbs) == 0 {
+		dbs = []string{RebuildableStateDB, RebuildableBlockIndex}
+		if ledgerconfig.IsHistoryDBEnabled() {
+			dbs = append(dbs, RebuildableHistoryDB)
+		}
+	}
+	if err := validateRebuildableDBs(dbs); err != nil {
+		return nil, err
+	}
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	if len(ledgerIDs) == 0 {
+		var err error
+		if ledgerIDs, err = idStore.getAllLedgerIds(); err != nil {
+			return nil, err
+		}
+	}
+	for _, ledgerID := range ledgerIDs {
+		exists, err := idStore.ledgerIDExists(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+		}
+	}
+
+	for _, ledgerID := range ledgerIDs {
+		if err := dropLedgerDBsForRebuild(idStore, ledgerID, dbs); err != nil {
+			return nil, err
+		}
+	}
+	return ledgerIDs, nil
+}
+
+func validateRebuildableDBs(dbs []string) error {
+	for _, db := range dbs {
+		if !containsDB(rebuildableDBs, db) {
+			return errors.Errorf("database [%s] cannot be rebuilt, the databases that can be rebuilt are %s", db, rebuildableDBs)
+		}
+		if db == RebuildableHistoryDB && !ledgerconfig.IsHistoryDBEnabled() {
+			return errors.New("the history database cannot be rebuilt as it is disabled")
+		}
+	}
+	return nil
+}
+
+func dropLedgerDBsForRebuild(idStore *idStore, ledgerID string, dbs []string) error {
+	status, err := idStore.getRebuildStatus(ledgerID)
+	if err != nil {
+		return err
+	}
+	allDBs := inDropOrder(dbs)
+	dbsToDrop := allDBs
+	if status != nil {
+		allDBs = inDropOrder(append(append([]string{}, dbs...), status.DBs...))
+		if status.Dropped {
+			// the databases dropped already are being rebuilt
+			dbsToDrop = nil
+			for _, db := range allDBs {
+				if !containsDB(status.DBs, db) {
+					dbsToDrop = append(dbsToDrop, db)
+				}
+			}
+		} else {
+			// the drop has been interrupted, the databases may have been partially dropped
+			dbsToDrop = allDBs
+		}
+	}
+	if len(dbsToDrop) == 0 {
+		logger.Infof("Resuming the rebuild of the databases %s of ledger [%s]", status.DBs, ledgerID)
+		return nil
+	}
+
+	status = &rebuildStatus{DBs: allDBs}
+	if err := idStore.setRebuildStatus(ledgerID, status); err != nil {
+		return err
+	}
+	for _, db := range dbsToDrop {
+		if err := dropLedgerDB(ledgerID, db); err != nil {
+			return err
+		}
+	}
+	status.Dropped = true
+	if err := idStore.setRebuildStatus(ledgerID, status); err != nil {
+		return err
+	}
+	logger.Infof("Dropped the databases %s of ledger [%s] for a rebuild", dbsToDrop, ledgerID)
+	return nil
+}
+
+func dropLedgerDB(ledgerID, db string) error {
+	switch db {
+	case RebuildableStateDB:
+		// As in dropDBs, the stateDB is dropped first so that its rebuild also rebuilds
+		// the configHistoryDB and the bookkeeperDB
+		if err := dropLedgerStateDB(ledgerID); err != nil {
+			return err
+		}
+		if err := dropLedgerConfigHistoryDB(ledgerID); err != nil {
+			return err
+		}
+		return dropLedgerBookkeeperDB(ledgerID)
+	case RebuildableHistoryDB:
+		return dropLedgerHistoryDB(ledgerID)
+	default:
+		logger.Infof("Dropping the block index of ledger [%s]", ledgerID)
+		return ledgerstorage.DropBlockIndex(ledgerconfig.GetBlockStorePath(), ledgerID)
+	}
+}
+
+// inDropOrder returns the distinct given databases in the order in which they are dropped
+func inDropOrder(dbs []string) []string {
+	var ordered []string
+	for _, db := range rebuildableDBs {
+		if containsDB(dbs, db) {
+			ordered = append(ordered, db)
+		}
+	}
+	return ordered
+}
+
+func containsDB(dbs []string, db string) bool {
+	for _, d := range dbs {
+		if d == db {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropDBsForRebuildResumesInterruptedDrop(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	ledgerID := constructTestLedgerID(1)
+	genesisBlock, _ := configtxtest.MakeGenesisBlock(ledgerID)
+	lgr, err := provider.Create(genesisBlock)
+	assert.NoError(t, err)
+	lgr.Close()
+
+	// simulate a drop of the statedb that has been interrupted
+	idStore := provider.(*Provider).idStore
+	assert.NoError(t, idStore.setRebuildStatus(ledgerID, &rebuildStatus{DBs: []string{RebuildableStateDB}}))
+	ledgerIDs, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ledgerID}, ledgerIDs)
+	_, err = provider.Open(ledgerID)
+	assert.EqualError(t, err, "the databases [statedb] of ledger ["+ledgerID+"] have been partially dropped for a rebuild, "+
+		"complete the rebuild by executing the 'peer node rebuild-dbs' command again")
+	provider.Close()
+
+	_, err = DropDBsForRebuild(nil, []string{RebuildableBlockIndex})
+	assert.NoError(t, err)
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	idStore = provider.(*Provider).idStore
+	status, err := idStore.getRebuildStatus(ledgerID)
+	assert.NoError(t, err)
+	assert.Equal(t, &rebuildStatus{DBs: []string{RebuildableStateDB, RebuildableBlockIndex}, Dropped: true}, status)
+
+	// opening the ledger rebuilds the databases and clears the rebuild status
+	lgr, err = provider.Open(ledgerID)
+	assert.NoError(t, err)
+	defer lgr.Close()
+	bcInfo, err := lgr.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	status, err = idStore.getRebuildStatus(ledgerID)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+}
@@ -8,6 +8,9 @@ package tests
 
 import (
 	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRebuildComponents(t *testing.T) {
@@ -135,3 +138,54 @@ func TestRebuildComponentsWithBTL(t *testing.T) {
 		r.pvtdataShouldNotContain("cc1", "coll2")                   // <cc1, coll2> shold have been purged from the pvtdata storage
 	})
 }
+
+func TestDropDBsForRebuild(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+
+	h1, h2 := newTestHelperCreateLgr("ledger1", t), newTestHelperCreateLgr("ledger2", t)
+	dataHelper := newSampleDataHelper(t)
+	dataHelper.populateLedger(h1)
+	dataHelper.populateLedger(h2)
+	closeLedgerMgmt()
+
+	_, err := kvledger.DropDBsForRebuild([]string{"noLedger"}, []string{kvledger.RebuildableStateDB})
+	assert.EqualError(t, err, "ledgerID [noLedger] does not exist")
+	_, err = kvledger.DropDBsForRebuild(nil, []string{"noDB"})
+	assert.EqualError(t, err, "database [noDB] cannot be rebuilt, the databases that can be rebuilt are [statedb historydb blockindex]")
+	_, err = kvledger.DropDBsForRebuild(nil, []string{kvledger.RebuildableHistoryDB})
+	assert.EqualError(t, err, "the history database cannot be rebuilt as it is disabled")
+
+	ledgerIDs, err := kvledger.DropDBsForRebuild([]string{"ledger1"}, []string{kvledger.RebuildableStateDB, kvledger.RebuildableBlockIndex})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger1"}, ledgerIDs)
+	report, err := kvledger.VerifyKVLedger("ledger1", false, nil)
+	assert.NoError(t, err)
+	assert.Len(t, report.BlockIndex.Issues, 1)
+	assert.Len(t, report.StateDB.Warnings, 1)
+	report, err = kvledger.VerifyKVLedger("ledger2", false, nil)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Empty(t, report.StateDB.Warnings)
+
+	// the statedb of ledger1 has been dropped already, hence it is not dropped again
+	ledgerIDs, err = kvledger.DropDBsForRebuild(nil, []string{kvledger.RebuildableStateDB})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger1", "ledger2"}, ledgerIDs)
+	report, err = kvledger.VerifyKVLedger("ledger2", false, nil)
+	assert.NoError(t, err)
+	assert.Len(t, report.StateDB.Warnings, 1)
+
+	initLedgerMgmt()
+	h1, h2 = newTestHelperOpenLgr("ledger1", t), newTestHelperOpenLgr("ledger2", t)
+	dataHelper.verifyLedgerContent(h1)
+	dataHelper.verifyLedgerContent(h2)
+	closeLedgerMgmt()
+	defer initLedgerMgmt()
+	for _, ledgerID := range ledgerIDs {
+		report, err := kvledger.VerifyKVLedger(ledgerID, false, nil)
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.Empty(t, report.StateDB.Warnings)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyKVLedger(t *testing.T) {
+	env := newEnv(defaultConfig, t)
+	defer env.cleanup()
+	dataHelper := newSampleDataHelper(t)
+	h := newTestHelperCreateLgr("testLedger", t)
+	dataHelper.populateLedger(h)
+	bcInfo, err := h.lgr.GetBlockchainInfo()
+	assert.NoError(t, err)
+
+	closeLedgerMgmt()
+	_, err = kvledger.VerifyKVLedger("noLedger", false, nil)
+	assert.EqualError(t, err, "ledgerID [noLedger] does not exist")
+
+	var verifiedBlocks uint64
+	report, err := kvledger.VerifyKVLedger("testLedger", false, func(block *common.Block) error {
+		assert.Equal(t, verifiedBlocks, block.Header.Number)
+		verifiedBlocks++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, &kvledger.VerificationReport{
+		LedgerID:     "testLedger",
+		Height:       bcInfo.Height,
+		BlockFiles:   &kvledger.VerificationCheck{},
+		BlockIndex:   &kvledger.VerificationCheck{},
+		StateDB:      &kvledger.VerificationCheck{},
+		PvtdataStore: &kvledger.VerificationCheck{},
+	}, report)
+	assert.Equal(t, bcInfo.Height, verifiedBlocks)
+	initLedgerMgmt()
+
+	t.Run("dropped block index and statedb", func(t *testing.T) {
+		env.closeAllLedgersAndDrop(rebuildableBlockIndex | rebuildableStatedb)
+		closeLedgerMgmt()
+
+		report, err := kvledger.VerifyKVLedger("testLedger", false, nil)
+		assert.NoError(t, err)
+		assert.False(t, report.OK())
+		assert.Len(t, report.BlockIndex.Issues, 1)
+		assert.False(t, report.BlockIndex.Repaired)
+		assert.Empty(t, report.StateDB.Issues)
+		assert.Len(t, report.StateDB.Warnings, 1)
+
+		report, err = kvledger.VerifyKVLedger("testLedger", true, nil)
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.True(t, report.BlockIndex.Repaired)
+
+		report, err = kvledger.VerifyKVLedger("testLedger", false, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, report.BlockIndex.Issues)
+
+		initLedgerMgmt()
+		h := newTestHelperOpenLgr("testLedger", t)
+		dataHelper.verifyLedgerContent(h)
+	})
+
+	t.Run("invalid block signatures", func(t *testing.T) {
+		closeLedgerMgmt()
+		defer initLedgerMgmt()
+		report, err := kvledger.VerifyKVLedger("testLedger", false, func(block *common.Block) error {
+			if block.Header.Number == 2 {
+				return assert.AnError
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, report.OK())
+		assert.Equal(t, []string{"signatures of block [2] are not valid: " + assert.AnError.Error()}, report.BlockFiles.Issues)
+	})
+}
@@ -44,12 +44,13 @@ type CommonStorageDBProvider struct {
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
 	var vdbProvider statedb.VersionedDBProvider
 	var err error
+	cache := statedb.NewCache(ledgerconfig.GetStateCacheSize(), metricsProvider)
 	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider); err != nil {
+		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider, cache); err != nil {
 			return nil, err
 		}
 	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+		vdbProvider = stateleveldb.NewVersionedDBProvider(cache)
 	}
 
 	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
@@ -80,6 +81,11 @@ func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	return NewCommonStorageDB(vdb, id, metadataHint)
 }
 
+// DropLedger implements function from interface DBProvider
+func (p *CommonStorageDBProvider) DropLedger(id string) error {
+	return p.VersionedDBProvider.DropLedger(id)
+}
+
 // Close implements function from interface DBProvider
 func (p *CommonStorageDBProvider) Close() {
 	p.VersionedDBProvider.Close()
@@ -104,6 +110,61 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 	return ok
 }
 
+// IsFullScannable implements corresponding function in interface DB
+func (s *CommonStorageDB) IsFullScannable() bool {
+	_, ok := s.VersionedDB.(statedb.FullScannable)
+	return ok
+}
+
+// VisitPubAndHashedState implements corresponding function in interface DB. It scans the whole
+// database and invokes the given functions on the entries of the public data and of the hashed data
+// respectively. The private data is skipped
+func (s *CommonStorageDB) VisitPubAndHashedState(visitPub func(namespace, key string, vv *statedb.VersionedValue),
+	visitHashed func(hashedKey *HashedCompositeKey, vv *statedb.VersionedValue)) error {
+
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return errors.New("the state database doesn't support full scans")
+	}
+	itr, err := fullScannable.GetFullScanIterator()
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		queryResult, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if queryResult == nil {
+			return nil
+		}
+		kv := queryResult.(*statedb.VersionedKV)
+		vv := kv.VersionedValue
+		nsComponents := strings.SplitN(kv.Namespace, nsJoiner, 2)
+		if len(nsComponents) == 1 {
+			visitPub(kv.Namespace, kv.Key, &vv)
+			continue
+		}
+		if !strings.HasPrefix(nsComponents[1], hashDataPrefix) {
+			continue
+		}
+		keyHash := kv.Key
+		if !s.BytesKeySupported() {
+			keyHashBytes, err := base64.StdEncoding.DecodeString(kv.Key)
+			if err != nil {
+				return errors.Wrapf(err, "failed decoding the key hash of namespace %s", kv.Namespace)
+			}
+			keyHash = string(keyHashBytes)
+		}
+		visitHashed(&HashedCompositeKey{
+			Namespace:      nsComponents[0],
+			CollectionName: strings.TrimPrefix(nsComponents[1], hashDataPrefix),
+			KeyHash:        keyHash,
+		}, &vv)
+	}
+}
+
 // LoadCommittedVersionsOfPubAndHashedKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
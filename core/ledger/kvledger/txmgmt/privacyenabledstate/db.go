@@ -18,6 +18,8 @@ import (
 type DBProvider interface {
 	// GetDBHandle returns a handle to a PvtVersionedDB
 	GetDBHandle(id string) (DB, error)
+	// DropLedger deletes the public, hashed and private state of the given ledger, whose DB must not be in use
+	DropLedger(id string) error
 	// Close closes all the PvtVersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -26,6 +28,8 @@ type DBProvider interface {
 type DB interface {
 	statedb.VersionedDB
 	IsBulkOptimizable() bool
+	IsFullScannable() bool
+	VisitPubAndHashedState(visitPub func(namespace, key string, vv *statedb.VersionedValue), visitHashed func(hashedKey *HashedCompositeKey, vv *statedb.VersionedValue)) error
 	LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey, hashedKeys []*HashedCompositeKey) error
 	GetCachedKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, bool)
 	ClearCachedVersions()
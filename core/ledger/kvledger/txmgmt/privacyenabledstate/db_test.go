@@ -176,6 +176,47 @@ func testGetStateMultipleKeys(t *testing.T, env TestEnv) {
 		pvtVersionedVals)
 }
 
+func TestVisitPubAndHashedState(t *testing.T) {
+	for _, env := range testEnvs {
+		t.Run(env.GetName(), func(t *testing.T) {
+			testVisitPubAndHashedState(t, env)
+		})
+	}
+}
+
+func testVisitPubAndHashedState(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle("test-ledger-id")
+	assert.True(t, db.IsFullScannable())
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 3))
+	db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 3))
+
+	pubEntries := make(map[statedb.CompositeKey]*statedb.VersionedValue)
+	hashedEntries := make(map[HashedCompositeKey]*statedb.VersionedValue)
+	err := db.VisitPubAndHashedState(
+		func(ns, key string, vv *statedb.VersionedValue) {
+			pubEntries[statedb.CompositeKey{Namespace: ns, Key: key}] = vv
+		},
+		func(hashedKey *HashedCompositeKey, vv *statedb.VersionedValue) {
+			hashedEntries[*hashedKey] = vv
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[statedb.CompositeKey]*statedb.VersionedValue{
+		{Namespace: "ns1", Key: "key1"}: {Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		{Namespace: "ns2", Key: "key2"}: {Value: []byte("value2"), Version: version.NewHeight(1, 2)},
+	}, pubEntries)
+	assert.Equal(t, map[HashedCompositeKey]*statedb.VersionedValue{
+		{Namespace: "ns1", CollectionName: "coll1", KeyHash: string(util.ComputeStringHash("key1"))}: {
+			Value: util.ComputeHash([]byte("pvt_value1")), Version: version.NewHeight(1, 3)},
+	}, hashedEntries)
+}
+
 func TestGetStateRangeScanIterator(t *testing.T) {
 	for _, env := range testEnvs {
 		t.Run(env.GetName(), func(t *testing.T) {
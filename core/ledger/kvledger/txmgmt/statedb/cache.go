@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// cacheEntryOverhead approximates the memory held by an entry of the cache
+// in addition to its key, value and metadata
+const cacheEntryOverhead = 128
+
+var (
+	cacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "hits",
+		Help:         "Number of reads of the state database that were served by the state cache.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	cacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "misses",
+		Help:         "Number of reads of the state database that were not served by the state cache.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// Cache is a size-bounded, least recently used cache of the committed values of the
+// state databases. A single instance is shared by the state databases of all the
+// channels, hence the entries are keyed by channel, namespace and key.
+// The cache is populated when a value is read from a state database and when it is
+// committed, and the entries of the keys deleted by a block are evicted at commit time.
+// A nil Cache is valid and caches nothing.
+type Cache struct {
+	maxSize int
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+	mutex   sync.Mutex
+	hits    metrics.Counter
+	misses  metrics.Counter
+}
+
+type cacheEntry struct {
+	key   string
+	value *VersionedValue
+	size  int
+}
+
+// NewCache constructs a cache that holds up to sizeMB megabytes of values.
+// It returns nil, i.e., a cache that caches nothing, if sizeMB is zero.
+func NewCache(sizeMB int, metricsProvider metrics.Provider) *Cache {
+	if sizeMB <= 0 {
+		return nil
+	}
+	return newCache(sizeMB*1024*1024, metricsProvider)
+}
+
+func newCache(maxSize int, metricsProvider metrics.Provider) *Cache {
+	return &Cache{
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		hits:    metricsProvider.NewCounter(cacheHitsOpts),
+		misses:  metricsProvider.NewCounter(cacheMissesOpts),
+	}
+}
+
+// GetState returns the cached value of the given key of the given channel and namespace,
+// or nil if the value isn't cached
+func (c *Cache) GetState(chainID, namespace, key string) *VersionedValue {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[cacheKey(chainID, namespace, key)]
+	if !ok {
+		c.misses.With("channel", chainID).Add(1)
+		return nil
+	}
+	c.hits.With("channel", chainID).Add(1)
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).value
+}
+
+// PutState caches the value of the given key of the given channel and namespace that was
+// read from the state database. The value is expected not to be modified after this call.
+func (c *Cache) PutState(chainID, namespace, key string, value *VersionedValue) {
+	if c == nil || value == nil || value.Value == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.put(cacheKey(chainID, namespace, key), value)
+}
+
+// UpdateStates applies the updates of the given batch, which was committed to the state
+// database of the given channel, to the cache
+func (c *Cache) UpdateStates(chainID string, batch *UpdateBatch) {
+	c.applyBatch(chainID, batch, true)
+}
+
+// EvictStates evicts the keys updated by the given batch, which was committed to the state
+// database of the given channel, from the cache. It is meant for the state databases that
+// don't return the committed values verbatim, and hence can't populate the cache on commit.
+func (c *Cache) EvictStates(chainID string, batch *UpdateBatch) {
+	c.applyBatch(chainID, batch, false)
+}
+
+// EvictChain evicts all the entries of the given channel from the cache
+func (c *Cache) EvictChain(chainID string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	prefix := chainID + "\x00"
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *Cache) applyBatch(chainID string, batch *UpdateBatch, populate bool) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for key, vv := range batch.GetUpdates(ns) {
+			ck := cacheKey(chainID, ns, key)
+			if !populate || vv.Value == nil {
+				if elem, ok := c.entries[ck]; ok {
+					c.remove(elem)
+				}
+				continue
+			}
+			c.put(ck, vv)
+		}
+	}
+}
+
+func (c *Cache) put(key string, value *VersionedValue) {
+	size := len(key) + len(value.Value) + len(value.Metadata) + cacheEntryOverhead
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	if size > c.maxSize {
+		return
+	}
+	for c.size+size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, size: size})
+	c.size += size
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+func cacheKey(chainID, namespace, key string) string {
+	return chainID + "\x00" + namespace + "\x00" + key
+}
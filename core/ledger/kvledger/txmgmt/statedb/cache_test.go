@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDisabled(t *testing.T) {
+	cache := NewCache(0, &disabled.Provider{})
+	assert.Nil(t, cache)
+
+	cache.PutState("ch1", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key1"))
+	batch := NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	cache.UpdateStates("ch1", batch)
+	cache.EvictStates("ch1", batch)
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key1"))
+}
+
+func TestCacheGetAndPut(t *testing.T) {
+	cache := NewCache(1, &disabled.Provider{})
+	vv1 := &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	cache.PutState("ch1", "ns1", "key1", vv1)
+	assert.Equal(t, vv1, cache.GetState("ch1", "ns1", "key1"))
+
+	// entries are keyed by channel and namespace
+	assert.Nil(t, cache.GetState("ch2", "ns1", "key1"))
+	assert.Nil(t, cache.GetState("ch1", "ns2", "key1"))
+
+	// non-existing keys aren't cached
+	cache.PutState("ch1", "ns1", "key2", nil)
+	cache.PutState("ch1", "ns1", "key3", &VersionedValue{Version: version.NewHeight(1, 2)})
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key2"))
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key3"))
+}
+
+func TestCacheUpdateAndEvictStates(t *testing.T) {
+	cache := NewCache(1, &disabled.Provider{})
+	cache.PutState("ch1", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	cache.PutState("ch1", "ns1", "key2", &VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 1)})
+	cache.PutState("ch2", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+
+	batch := NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1-new"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns2", "key3", []byte("value3"), version.NewHeight(2, 3))
+	cache.UpdateStates("ch1", batch)
+
+	assert.Equal(t, &VersionedValue{Value: []byte("value1-new"), Version: version.NewHeight(2, 1)}, cache.GetState("ch1", "ns1", "key1"))
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key2"))
+	assert.Equal(t, &VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 3)}, cache.GetState("ch1", "ns2", "key3"))
+	// the other channels are unaffected
+	assert.Equal(t, &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, cache.GetState("ch2", "ns1", "key1"))
+
+	cache.EvictStates("ch1", batch)
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key1"))
+	assert.Nil(t, cache.GetState("ch1", "ns2", "key3"))
+	assert.NotNil(t, cache.GetState("ch2", "ns1", "key1"))
+
+	// evicting a channel leaves the channels whose id it prefixes untouched
+	cache.PutState("ch2x", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	cache.EvictChain("ch2")
+	assert.Nil(t, cache.GetState("ch2", "ns1", "key1"))
+	assert.NotNil(t, cache.GetState("ch2x", "ns1", "key1"))
+}
+
+func TestCacheEviction(t *testing.T) {
+	entrySize := len(cacheKey("ch1", "ns1", "key1")) + len("value1") + cacheEntryOverhead
+	cache := newCache(3*entrySize, &disabled.Provider{})
+	for _, key := range []string{"key1", "key2", "key3"} {
+		cache.PutState("ch1", "ns1", key, &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	}
+	// key1 becomes the most recently used entry, hence key2 is evicted in favor of key4
+	assert.NotNil(t, cache.GetState("ch1", "ns1", "key1"))
+	cache.PutState("ch1", "ns1", "key4", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	assert.NotNil(t, cache.GetState("ch1", "ns1", "key1"))
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key2"))
+	assert.NotNil(t, cache.GetState("ch1", "ns1", "key3"))
+	assert.NotNil(t, cache.GetState("ch1", "ns1", "key4"))
+	assert.Equal(t, 3*entrySize, cache.size)
+
+	// values larger than the cache aren't cached
+	cache.PutState("ch1", "ns1", "key5", &VersionedValue{Value: make([]byte, 3*entrySize), Version: version.NewHeight(1, 1)})
+	assert.Nil(t, cache.GetState("ch1", "ns1", "key5"))
+	assert.Len(t, cache.entries, 3)
+}
+
+func TestCacheMetrics(t *testing.T) {
+	hits, misses := &metricsfakes.Counter{}, &metricsfakes.Counter{}
+	hits.WithReturns(hits)
+	misses.WithReturns(misses)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewCounterReturnsOnCall(0, hits)
+	fakeProvider.NewCounterReturnsOnCall(1, misses)
+
+	cache := NewCache(1, fakeProvider)
+	assert.Equal(t, 2, fakeProvider.NewCounterCallCount())
+	assert.Equal(t, cacheHitsOpts, fakeProvider.NewCounterArgsForCall(0))
+	assert.Equal(t, cacheMissesOpts, fakeProvider.NewCounterArgsForCall(1))
+
+	cache.GetState("ch1", "ns1", "key1")
+	assert.Equal(t, 1, misses.AddCallCount())
+	assert.Equal(t, []string{"channel", "ch1"}, misses.WithArgsForCall(0))
+
+	cache.PutState("ch1", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	cache.GetState("ch1", "ns1", "key1")
+	assert.Equal(t, 1, hits.AddCallCount())
+	assert.Equal(t, []string{"channel", "ch1"}, hits.WithArgsForCall(0))
+	assert.Equal(t, float64(1), hits.AddArgsForCall(0))
+}
@@ -10,6 +10,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -37,10 +39,12 @@ type VersionedDBProvider struct {
 	databases     map[string]*VersionedDB
 	mux           sync.Mutex
 	openCounts    uint64
+	cache         *statedb.Cache
 }
 
-// NewVersionedDBProvider instantiates VersionedDBProvider
-func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvider, error) {
+// NewVersionedDBProvider instantiates VersionedDBProvider. The given cache,
+// which may be nil, is shared by all the VersionedDB instances
+func NewVersionedDBProvider(metricsProvider metrics.Provider, cache *statedb.Cache) (*VersionedDBProvider, error) {
 	logger.Debugf("constructing CouchDB VersionedDBProvider")
 	couchDBDef := couchdb.GetCouchDBDefinition()
 	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
@@ -48,7 +52,7 @@ func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvi
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0, cache}, nil
 }
 
 // GetDBHandle gets the handle to a named database
@@ -58,7 +62,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	vdb := provider.databases[dbName]
 	if vdb == nil {
 		var err error
-		vdb, err = newVersionedDB(provider.couchInstance, dbName)
+		vdb, err = newVersionedDB(provider.couchInstance, dbName, provider.cache)
 		if err != nil {
 			return nil, err
 		}
@@ -67,6 +71,49 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return vdb, nil
 }
 
+// DropChannelDBs drops the metadata database and the namespace databases of a chain/channel.
+// The metadata database, which holds the savepoint, is dropped first. The databases must not be in use
+func (provider *VersionedDBProvider) DropChannelDBs(chainName string) error {
+	prefix, err := couchdb.ConstructChainDBNamePrefix(chainName)
+	if err != nil {
+		return err
+	}
+	dbNames, err := provider.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	var chainDBNames []string
+	for _, dbName := range dbNames {
+		switch {
+		case dbName == prefix:
+			// the name of the metadata database is the prefix itself
+			chainDBNames = append([]string{dbName}, chainDBNames...)
+		case strings.HasPrefix(dbName, prefix):
+			chainDBNames = append(chainDBNames, dbName)
+		}
+	}
+	for _, dbName := range chainDBNames {
+		logger.Infof("Dropping CouchDB database [%s] of channel [%s]", dbName, chainName)
+		db := &couchdb.CouchDatabase{CouchInstance: provider.couchInstance, DBName: dbName}
+		if _, err := db.DropDatabase(); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error dropping CouchDB database [%s]", dbName))
+		}
+	}
+	provider.mux.Lock()
+	delete(provider.databases, chainName)
+	provider.mux.Unlock()
+	return nil
+}
+
+// DropLedger drops the databases of a chain/channel and evicts its entries from the cache
+func (provider *VersionedDBProvider) DropLedger(chainName string) error {
+	if err := provider.DropChannelDBs(chainName); err != nil {
+		return err
+	}
+	provider.cache.EvictChain(chainName)
+	return nil
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
@@ -87,6 +134,7 @@ type VersionedDB struct {
 	verCacheLock       sync.RWMutex
 	mux                sync.RWMutex
 	lsccStateCache     *lsccStateCache
+	cache              *statedb.Cache // Shared by the state databases of all the channels.
 }
 
 type lsccStateCache struct {
@@ -139,7 +187,7 @@ func (l *lsccStateCache) evictARandomEntry() {
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string, cache *statedb.Cache) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -158,6 +206,7 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 		lsccStateCache: &lsccStateCache{
 			cache: make(map[string]*statedb.VersionedValue),
 		},
+		cache: cache,
 	}, nil
 }
 
@@ -289,6 +338,9 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 			return value, nil
 		}
 	}
+	if value := vdb.cache.GetState(vdb.chainName, namespace, key); value != nil {
+		return value, nil
+	}
 
 	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
@@ -309,6 +361,7 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 	if namespace == "lscc" {
 		vdb.lsccStateCache.setState(key, kv.VersionedValue)
 	}
+	vdb.cache.PutState(vdb.chainName, namespace, key, kv.VersionedValue)
 
 	return kv.VersionedValue, nil
 }
@@ -545,6 +598,9 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	for key, value := range lsccUpdates {
 		vdb.lsccStateCache.updateState(key, value)
 	}
+	// CouchDB re-encodes the JSON values, hence the committed values are only
+	// cached once they are read back
+	vdb.cache.EvictStates(vdb.chainName, updates)
 
 	return nil
 }
@@ -631,6 +687,70 @@ func (vdb *VersionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return decodeSavepoint(couchDoc)
 }
 
+// GetFullScanIterator implements method in FullScannable interface. The namespace databases of the
+// chain/channel are scanned one after the other, each one by pages of the internal query limit.
+// The metadata database, which holds only the savepoint, is not scanned
+func (vdb *VersionedDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	prefix, err := couchdb.ConstructChainDBNamePrefix(vdb.chainName)
+	if err != nil {
+		return nil, err
+	}
+	dbNames, err := vdb.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, dbName := range dbNames {
+		if dbName == prefix || !strings.HasPrefix(dbName, prefix) {
+			continue
+		}
+		namespace, err := couchdb.NamespaceFromDBName(prefix, dbName)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return &fullScanner{vdb: vdb, namespaces: namespaces}, nil
+}
+
+type fullScanner struct {
+	vdb        *VersionedDB
+	namespaces []string
+	nsItr      statedb.ResultsIterator
+}
+
+func (scanner *fullScanner) Next() (statedb.QueryResult, error) {
+	for {
+		if scanner.nsItr == nil {
+			if len(scanner.namespaces) == 0 {
+				return nil, nil
+			}
+			nsItr, err := scanner.vdb.GetStateRangeScanIterator(scanner.namespaces[0], "", "")
+			if err != nil {
+				return nil, err
+			}
+			scanner.nsItr = nsItr
+			scanner.namespaces = scanner.namespaces[1:]
+		}
+		queryResult, err := scanner.nsItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult != nil {
+			return queryResult, nil
+		}
+		scanner.nsItr.Close()
+		scanner.nsItr = nil
+	}
+}
+
+func (scanner *fullScanner) Close() {
+	if scanner.nsItr != nil {
+		scanner.nsItr.Close()
+	}
+}
+
 // applyAdditionalQueryOptions will add additional fields to the query required for query processing
 func applyAdditionalQueryOptions(queryString string, queryLimit int32, queryBookmark string) (string, error) {
 	const jsonQueryFields = "fields"
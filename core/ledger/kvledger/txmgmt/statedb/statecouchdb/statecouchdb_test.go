@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
@@ -761,6 +762,40 @@ func TestLSCCStateCache(t *testing.T) {
 	assert.Equal(t, true, db.(*VersionedDB).lsccStateCache.isCacheFull())
 }
 
+func TestStateCache(t *testing.T) {
+	cache := statedb.NewCache(1, &disabled.Provider{})
+	dbProvider, err := NewVersionedDBProvider(&disabled.Provider{}, cache)
+	assert.NoError(t, err)
+	defer func() {
+		CleanupDB(t, dbProvider)
+		dbProvider.Close()
+	}()
+
+	db, err := dbProvider.GetDBHandle("testcache")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"b":1, "a":2}`), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	// the committed values aren't cached, since CouchDB re-encodes them
+	assert.Nil(t, cache.GetState("testcache", "ns1", "key1"))
+
+	// GetState() populates the cache
+	valueFromDB, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"a":2,"b":1}`), valueFromDB.Value)
+	assert.Equal(t, valueFromDB, cache.GetState("testcache", "ns1", "key1"))
+
+	// the updated values are evicted at commit
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"a":3}`), version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	assert.Nil(t, cache.GetState("testcache", "ns1", "key1"))
+	valueFromDB, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte(`{"a":3}`), Version: version.NewHeight(2, 1)}, valueFromDB)
+}
+
 func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -837,3 +872,43 @@ func assertQueryResults(t *testing.T, results []*couchdb.QueryResult, expectedId
 	}
 	assert.Equal(t, expectedIds, actualIds)
 }
+
+func TestDropChannelDBs(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	provider := env.DBProvider.(*VersionedDBProvider)
+
+	for _, chainName := range []string{"testdropchannel", "testdropchannel1"} {
+		db, err := provider.GetDBHandle(chainName)
+		assert.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+		batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 2))
+		assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+	}
+
+	assert.NoError(t, provider.DropChannelDBs("testdropchannel"))
+	dbNames, err := provider.couchInstance.RetrieveApplicationDBNames()
+	assert.NoError(t, err)
+	assert.NotContains(t, dbNames, "testdropchannel_")
+	assert.NotContains(t, dbNames, "testdropchannel_ns1")
+	assert.NotContains(t, dbNames, "testdropchannel_ns2")
+	assert.Contains(t, dbNames, "testdropchannel1_")
+	assert.Contains(t, dbNames, "testdropchannel1_ns1")
+
+	// the dropped databases are recreated empty
+	db, err := provider.GetDBHandle("testdropchannel")
+	assert.NoError(t, err)
+	savepoint, err := db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+
+	db, err = provider.GetDBHandle("testdropchannel1")
+	assert.NoError(t, err)
+	vv, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+}
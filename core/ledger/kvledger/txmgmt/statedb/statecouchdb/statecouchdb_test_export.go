@@ -23,7 +23,7 @@ type TestVDBEnv struct {
 func NewTestVDBEnv(t testing.TB) *TestVDBEnv {
 	t.Logf("Creating new TestVDBEnv")
 
-	dbProvider, _ := NewVersionedDBProvider(&disabled.Provider{})
+	dbProvider, _ := NewVersionedDBProvider(&disabled.Provider{}, nil)
 	testVDBEnv := &TestVDBEnv{t, dbProvider}
 	// No cleanup for new test environment.  Need to cleanup per test for each DB used in the test.
 	return testVDBEnv
@@ -22,6 +22,8 @@ import (
 type VersionedDBProvider interface {
 	// GetDBHandle returns a handle to a VersionedDB
 	GetDBHandle(id string) (VersionedDB, error)
+	// DropLedger deletes the state of the given ledger, whose VersionedDB must not be in use
+	DropLedger(id string) error
 	// Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -88,6 +90,14 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScannable interface provides additional functions for
+//databases capable of iterating over all their entries
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the entries of all the namespaces.
+	// The results are of type *VersionedKV
+	GetFullScanIterator() (ResultsIterator, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -26,19 +26,30 @@ var savePointKey = []byte{0x00}
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	cache      *statedb.Cache
 }
 
-// NewVersionedDBProvider instantiates VersionedDBProvider
-func NewVersionedDBProvider() *VersionedDBProvider {
+// NewVersionedDBProvider instantiates VersionedDBProvider. The given cache,
+// which may be nil, is shared by all the VersionedDB instances
+func NewVersionedDBProvider(cache *statedb.Cache) *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{dbProvider, cache}
 }
 
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName, provider.cache), nil
+}
+
+// DropLedger deletes all the entries of a named database and evicts them from the cache
+func (provider *VersionedDBProvider) DropLedger(dbName string) error {
+	if err := provider.dbProvider.GetDBHandle(dbName).DeleteAll(); err != nil {
+		return err
+	}
+	provider.cache.EvictChain(dbName)
+	return nil
 }
 
 // Close closes the underlying db
@@ -50,11 +61,12 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	cache  *statedb.Cache
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string, cache *statedb.Cache) *versionedDB {
+	return &versionedDB{db, dbName, cache}
 }
 
 // Open implements method in VersionedDB interface
@@ -81,6 +93,9 @@ func (vdb *versionedDB) BytesKeySupported() bool {
 // GetState implements method in VersionedDB interface
 func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
 	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	if vv := vdb.cache.GetState(vdb.dbName, namespace, key); vv != nil {
+		return vv, nil
+	}
 	compositeKey := constructCompositeKey(namespace, key)
 	dbVal, err := vdb.db.Get(compositeKey)
 	if err != nil {
@@ -89,7 +104,12 @@ func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.Version
 	if dbVal == nil {
 		return nil, nil
 	}
-	return decodeValue(dbVal)
+	vv, err := decodeValue(dbVal)
+	if err != nil {
+		return nil, err
+	}
+	vdb.cache.PutState(vdb.dbName, namespace, key, vv)
+	return vv, nil
 }
 
 // GetVersion implements method in VersionedDB interface
@@ -196,6 +216,7 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
 		return err
 	}
+	vdb.cache.UpdateStates(vdb.dbName, batch)
 	return nil
 }
 
@@ -231,6 +252,40 @@ type kvScanner struct {
 	totalRecordsReturned int32
 }
 
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	return &fullScanner{vdb.db.GetIterator(nil, nil)}, nil
+}
+
+type fullScanner struct {
+	dbItr iterator.Iterator
+}
+
+func (scanner *fullScanner) Next() (statedb.QueryResult, error) {
+	for scanner.dbItr.Next() {
+		dbKey := scanner.dbItr.Key()
+		if bytes.Equal(dbKey, savePointKey) {
+			continue
+		}
+		dbVal := scanner.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		ns, key := splitCompositeKey(dbKey)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv}, nil
+	}
+	return nil, nil
+}
+
+func (scanner *fullScanner) Close() {
+	scanner.dbItr.Release()
+}
+
 func newKVScanner(namespace string, dbItr iterator.Iterator, requestedLimit int32) *kvScanner {
 	return &kvScanner{namespace, dbItr, requestedLimit, 0}
 }
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -136,3 +137,42 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestStateCache(t *testing.T) {
+	removeDBPath(t, "TestStateCache")
+	cache := statedb.NewCache(1, &disabled.Provider{})
+	dbProvider := NewVersionedDBProvider(cache)
+	defer func() {
+		dbProvider.Close()
+		removeDBPath(t, "TestStateCache")
+	}()
+	db, err := dbProvider.GetDBHandle("testcache")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+	// the committed values are cached
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, cache.GetState("testcache", "ns1", "key1"))
+
+	// the deleted values are evicted
+	batch = statedb.NewUpdateBatch()
+	batch.Delete("ns1", "key1", version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	assert.Nil(t, cache.GetState("testcache", "ns1", "key1"))
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+
+	// the values read from the db are cached
+	db2 := newVersionedDB(dbProvider.dbProvider.GetDBHandle("testcache"), "testcache", nil)
+	assert.Nil(t, cache.GetState("testcache", "ns1", "key3"))
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(3, 1))
+	assert.NoError(t, db2.ApplyUpdates(batch, version.NewHeight(3, 1)))
+	vv, err = db.GetState("ns1", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(3, 1)}, vv)
+	assert.Equal(t, vv, cache.GetState("testcache", "ns1", "key3"))
+}
@@ -34,7 +34,7 @@ type TestVDBEnv struct {
 func NewTestVDBEnv(t testing.TB) *TestVDBEnv {
 	t.Logf("Creating new TestVDBEnv")
 	removeDBPath(t, "NewTestVDBEnv")
-	dbProvider := NewVersionedDBProvider()
+	dbProvider := NewVersionedDBProvider(nil)
 	return &TestVDBEnv{t, dbProvider}
 }
 
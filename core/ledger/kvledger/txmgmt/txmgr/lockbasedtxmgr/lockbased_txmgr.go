@@ -35,6 +35,7 @@ type LockBasedTxMgr struct {
 	ledgerid        string
 	db              privacyenabledstate.DB
 	pvtdataPurgeMgr *pvtdataPurgeMgr
+	fingerprinter   *stateFingerprinter
 	validator       validator.Validator
 	stateListeners  []ledger.StateListener
 	ccInfoProvider  ledger.DeployedChaincodeInfoProvider
@@ -72,6 +73,16 @@ func NewLockBasedTxMgr(ledgerid string, db privacyenabledstate.DB, stateListener
 		return nil, err
 	}
 	txmgr.pvtdataPurgeMgr = &pvtdataPurgeMgr{pvtstatePurgeMgr, false}
+	txmgr.fingerprinter = newStateFingerprinter(ledgerid, bookkeepingProvider)
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint != nil {
+		if err := txmgr.fingerprinter.bootstrap(savepoint.BlockNum, db); err != nil {
+			return nil, err
+		}
+	}
 	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db)
 	return txmgr, nil
 }
@@ -82,6 +93,11 @@ func (txmgr *LockBasedTxMgr) GetLastSavepoint() (*version.Height, error) {
 	return txmgr.db.GetLatestSavePoint()
 }
 
+// GetStateFingerprint implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error) {
+	return txmgr.fingerprinter.get(blockNum)
+}
+
 // NewQueryExecutor implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) NewQueryExecutor(txid string) (ledger.QueryExecutor, error) {
 	qe := newQueryExecutor(txmgr, txid)
@@ -468,6 +484,12 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 		return err
 	}
 
+	// the fingerprint is computed against the committed state, and
+	// hence before the updates are applied to the state database
+	if err := txmgr.fingerprinter.update(txmgr.current.blockNum(), txmgr.current.batch, txmgr.db); err != nil {
+		return err
+	}
+
 	commitHeight := version.NewHeight(txmgr.current.blockNum(), txmgr.current.maxTxNumber())
 	txmgr.commitRWLock.Lock()
 	logger.Debugf("Write lock acquired for committing updates to state database")
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const fingerprintPrefix = 'f'
+
+// fingerprintModulus is the modulus of the sums of the digests of the entries of the state
+var fingerprintModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// stateFingerprinter maintains the fingerprints of the world state after each block.
+// A fingerprint is the sum, modulo 2^256, of the SHA256 digests of all the entries of the
+// state, which makes it independent of the order in which the entries were written and
+// allows to update it incrementally by adding the digests of the entries written by a block
+// and subtracting the digests of the entries they replace. The public state and the hashes
+// of the private data are fingerprinted separately, and the private data itself isn't,
+// since peers that aren't eligible to a collection don't have it.
+// The fingerprint of a block is derived from the fingerprint of the previous block. For a ledger
+// whose state was built before the fingerprinting was introduced, the fingerprint of the last
+// committed block is computed by scanning the whole state when the ledger is opened.
+type stateFingerprinter struct {
+	db *leveldbhelper.DBHandle
+}
+
+func newStateFingerprinter(ledgerid string, bookkeepingProvider bookkeeping.Provider) *stateFingerprinter {
+	return &stateFingerprinter{db: bookkeepingProvider.GetDBHandle(ledgerid, bookkeeping.StateFingerprint)}
+}
+
+// get returns the fingerprint of the state after the given block, or nil if it isn't available
+func (f *stateFingerprinter) get(blockNum uint64) (*common.StateFingerprint, error) {
+	fingerprintBytes, err := f.db.Get(encodeFingerprintKey(blockNum))
+	if err != nil || fingerprintBytes == nil {
+		return nil, err
+	}
+	fingerprint := &common.StateFingerprint{}
+	if err := proto.Unmarshal(fingerprintBytes, fingerprint); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling the state fingerprint of block %d", blockNum)
+	}
+	return fingerprint, nil
+}
+
+// bootstrap computes and persists the fingerprint of the state after the given block, which is
+// expected to be the last block committed to the state database, by scanning the whole state.
+// It does nothing if the fingerprint is already available, and fails if the state cannot be scanned.
+func (f *stateFingerprinter) bootstrap(blockNum uint64, db privacyenabledstate.DB) error {
+	fingerprint, err := f.get(blockNum)
+	if err != nil || fingerprint != nil {
+		return err
+	}
+	if !db.IsFullScannable() {
+		return errors.Errorf("the state fingerprint of block %d cannot be computed as the state database doesn't support full scans", blockNum)
+	}
+
+	logger.Infof("Computing the state fingerprint of block %d by scanning the whole state", blockNum)
+	pubFingerprint, hashedFingerprint := new(big.Int), new(big.Int)
+	err = db.VisitPubAndHashedState(
+		func(ns, key string, vv *statedb.VersionedValue) {
+			pubFingerprint.Add(pubFingerprint, entryDigest(vv, ns, key))
+		},
+		func(hashedKey *privacyenabledstate.HashedCompositeKey, vv *statedb.VersionedValue) {
+			hashedFingerprint.Add(hashedFingerprint, entryDigest(vv, hashedKey.Namespace, hashedKey.CollectionName, hashedKey.KeyHash))
+		},
+	)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed computing the state fingerprint of block %d", blockNum))
+	}
+	return f.put(blockNum, pubFingerprint, hashedFingerprint)
+}
+
+// update computes and persists the fingerprint of the state after the given block is committed.
+// It is expected to be invoked before the updates of the block are applied to the state database,
+// which lets a block that is recommitted during recovery produce the same fingerprint again.
+func (f *stateFingerprinter) update(blockNum uint64, batch *privacyenabledstate.UpdateBatch, db privacyenabledstate.DB) error {
+	pubFingerprint, hashedFingerprint := new(big.Int), new(big.Int)
+	if blockNum > 0 {
+		prev, err := f.get(blockNum - 1)
+		if err != nil {
+			return err
+		}
+		if prev == nil {
+			logger.Debugf("No state fingerprint is available for block %d, not computing the one of block %d", blockNum-1, blockNum)
+			return nil
+		}
+		pubFingerprint.SetBytes(prev.PublicState)
+		hashedFingerprint.SetBytes(prev.PrivateDataHashes)
+	}
+
+	for _, ns := range batch.PubUpdates.GetUpdatedNamespaces() {
+		updates := batch.PubUpdates.GetUpdates(ns)
+		keys := make([]string, 0, len(updates))
+		for key := range updates {
+			keys = append(keys, key)
+		}
+		committedValues, err := db.GetStateMultipleKeys(ns, keys)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			pubFingerprint.Sub(pubFingerprint, entryDigest(committedValues[i], ns, key))
+			pubFingerprint.Add(pubFingerprint, entryDigest(updates[key], ns, key))
+		}
+	}
+
+	for hashedKey, vv := range batch.HashUpdates.ToCompositeKeyMap() {
+		committedValue, err := db.GetValueHash(hashedKey.Namespace, hashedKey.CollectionName, []byte(hashedKey.KeyHash))
+		if err != nil {
+			return err
+		}
+		hashedFingerprint.Sub(hashedFingerprint, entryDigest(committedValue, hashedKey.Namespace, hashedKey.CollectionName, hashedKey.KeyHash))
+		hashedFingerprint.Add(hashedFingerprint, entryDigest(vv, hashedKey.Namespace, hashedKey.CollectionName, hashedKey.KeyHash))
+	}
+
+	return f.put(blockNum, pubFingerprint, hashedFingerprint)
+}
+
+func (f *stateFingerprinter) put(blockNum uint64, pubFingerprint, hashedFingerprint *big.Int) error {
+	fingerprintBytes, err := proto.Marshal(&common.StateFingerprint{
+		BlockNumber:       blockNum,
+		PublicState:       fingerprintToBytes(pubFingerprint),
+		PrivateDataHashes: fingerprintToBytes(hashedFingerprint),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed marshaling the state fingerprint of block %d", blockNum)
+	}
+	return f.db.Put(encodeFingerprintKey(blockNum), fingerprintBytes, true)
+}
+
+// entryDigest returns the digest of an entry of the state identified by the given key components,
+// or zero if the entry doesn't exist or is being deleted
+func entryDigest(vv *statedb.VersionedValue, keyComponents ...string) *big.Int {
+	if vv == nil || vv.Value == nil {
+		return new(big.Int)
+	}
+	h := sha256.New()
+	writeLengthPrefixed := func(b []byte) {
+		var length [binary.MaxVarintLen64]byte
+		h.Write(length[:binary.PutUvarint(length[:], uint64(len(b)))])
+		h.Write(b)
+	}
+	for _, component := range keyComponents {
+		writeLengthPrefixed([]byte(component))
+	}
+	writeLengthPrefixed(canonicalValue(vv.Value))
+	writeLengthPrefixed(vv.Metadata)
+	writeLengthPrefixed(vv.Version.ToBytes())
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// canonicalValue returns a representation of the value that doesn't depend on the state database.
+// CouchDB stores the values that are JSON objects as documents, decoding their numbers as floats,
+// and returns them re-encoded. The value is converted the same way, so that the representation of
+// the value written by a block is also the one of the value read back from either database. As a
+// consequence, the numbers of the JSON objects are fingerprinted with the precision of a float64.
+func canonicalValue(value []byte) []byte {
+	var jsonValue map[string]interface{}
+	if json.Unmarshal(value, &jsonValue) != nil || jsonValue == nil {
+		return value
+	}
+	canonical, err := json.Marshal(jsonValue)
+	if err != nil {
+		return value
+	}
+	return canonical
+}
+
+func fingerprintToBytes(fingerprint *big.Int) []byte {
+	fingerprint.Mod(fingerprint, fingerprintModulus)
+	b := make([]byte, sha256.Size)
+	fingerprintBytes := fingerprint.Bytes()
+	copy(b[sha256.Size-len(fingerprintBytes):], fingerprintBytes)
+	return b
+}
+
+func encodeFingerprintKey(blockNum uint64) []byte {
+	return append([]byte{fingerprintPrefix}, util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
@@ -0,0 +1,244 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateFingerprint(t *testing.T) {
+	ledgerid := "testfingerprint"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns", "coll"}: 0,
+		},
+	)
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, ledgerid, btlPolicy)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr), []collConfigkey{{"ns", "coll"}}, version.NewHeight(1, 1))
+
+	commit := func(blkAndPvtdata *ledger.BlockAndPvtData) *common.StateFingerprint {
+		_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+		assert.NoError(t, err)
+		assert.NoError(t, txMgr.Commit())
+		fingerprint, err := txMgr.GetStateFingerprint(blkAndPvtdata.Block.Header.Number)
+		assert.NoError(t, err)
+		return fingerprint
+	}
+
+	// the state is empty after the genesis block
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	fingerprint0 := commit(&ledger.BlockAndPvtData{Block: gb})
+	assert.Equal(t, &common.StateFingerprint{
+		BlockNumber:       0,
+		PublicState:       make([]byte, 32),
+		PrivateDataHashes: make([]byte, 32),
+	}, fingerprint0)
+
+	fingerprint1 := commit(prepareNextBlockForTest(t, txMgr, bg, "txid-1",
+		map[string]string{"key1": "value1"}, map[string]string{"pvtkey1": "pvt-value1"}, false))
+	assert.Equal(t, uint64(1), fingerprint1.BlockNumber)
+	assert.NotEqual(t, fingerprint0.PublicState, fingerprint1.PublicState)
+	assert.NotEqual(t, fingerprint0.PrivateDataHashes, fingerprint1.PrivateDataHashes)
+
+	// deleting all the keys brings back the fingerprint of the empty state
+	simulator, _ := txMgr.NewTxSimulator("txid-2")
+	simulator.DeleteState("ns", "key1")
+	simulator.DeletePrivateData("ns", "coll", "pvtkey1")
+	simulator.Done()
+	fingerprint2 := commit(prepareNextBlockForTestFromSimulator(t, bg, simulator))
+	assert.Equal(t, fingerprint0.PublicState, fingerprint2.PublicState)
+	assert.Equal(t, fingerprint0.PrivateDataHashes, fingerprint2.PrivateDataHashes)
+
+	// the incremental fingerprint is the sum of the digests of the entries of the state
+	fingerprint3 := commit(prepareNextBlockForTest(t, txMgr, bg, "txid-3",
+		map[string]string{"key1": "value1", "key2": `{"b":1, "a":2}`}, nil, false))
+	expected := new(big.Int)
+	expected.Add(expected, entryDigest(&statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(3, 0)}, "ns", "key1"))
+	expected.Add(expected, entryDigest(&statedb.VersionedValue{Value: []byte(`{"a":2,"b":1}`), Version: version.NewHeight(3, 0)}, "ns", "key2"))
+	assert.Equal(t, fingerprintToBytes(expected), fingerprint3.PublicState)
+	assert.Equal(t, fingerprint0.PrivateDataHashes, fingerprint3.PrivateDataHashes)
+
+	fingerprint, err := txMgr.GetStateFingerprint(4)
+	assert.NoError(t, err)
+	assert.Nil(t, fingerprint)
+}
+
+func TestStateFingerprintNotAvailable(t *testing.T) {
+	ledgerid := "testfingerprintnotavailable"
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, ledgerid, nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+
+	// the genesis block isn't committed, as if the ledger predates the fingerprints
+	bg, _ := testutil.NewBlockGenerator(t, ledgerid, false)
+	_, _, err := txMgr.ValidateAndPrepare(prepareNextBlockForTest(t, txMgr, bg, "txid-1",
+		map[string]string{"key1": "value1"}, nil, false), true)
+	assert.NoError(t, err)
+	assert.NoError(t, txMgr.Commit())
+
+	fingerprint, err := txMgr.GetStateFingerprint(1)
+	assert.NoError(t, err)
+	assert.Nil(t, fingerprint)
+}
+
+func TestStateFingerprintBootstrap(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Run(testEnv.getName(), func(t *testing.T) {
+			testStateFingerprintBootstrap(t, testEnv.(*lockBasedEnv))
+		})
+	}
+}
+
+func testStateFingerprintBootstrap(t *testing.T, testEnv *lockBasedEnv) {
+	ledgerid := "testfingerprintbootstrap"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns", "coll"}: 0,
+		},
+	)
+	testEnv.init(t, ledgerid, btlPolicy)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr), []collConfigkey{{"ns", "coll"}}, version.NewHeight(1, 1))
+
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	for _, blkAndPvtdata := range []*ledger.BlockAndPvtData{
+		{Block: gb},
+		prepareNextBlockForTest(t, txMgr, bg, "txid-1",
+			map[string]string{"key1": "value1", "key2": `{"a":12345678901234567890}`}, map[string]string{"pvtkey1": "pvt-value1"}, false),
+		prepareNextBlockForTest(t, txMgr, bg, "txid-2",
+			map[string]string{"key1": "value2"}, map[string]string{"pvtkey2": "pvt-value2"}, false),
+	} {
+		_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+		assert.NoError(t, err)
+		assert.NoError(t, txMgr.Commit())
+	}
+	expected, err := txMgr.GetStateFingerprint(2)
+	assert.NoError(t, err)
+	assert.NotNil(t, expected)
+
+	// drop the fingerprints, as if the ledger predates them, and reopen the ledger
+	fingerprinter := txMgr.(*LockBasedTxMgr).fingerprinter
+	for blockNum := uint64(0); blockNum <= 2; blockNum++ {
+		assert.NoError(t, fingerprinter.db.Delete(encodeFingerprintKey(blockNum), true))
+	}
+	err = fingerprinter.bootstrap(2, &notFullScannableDB{testEnv.getVDB()})
+	assert.EqualError(t, err, "the state fingerprint of block 2 cannot be computed as the state database doesn't support full scans")
+	txMgr, err = NewLockBasedTxMgr(ledgerid, testEnv.getVDB(), nil, btlPolicy,
+		testEnv.testBookkeepingEnv.TestProvider, &mock.DeployedChaincodeInfoProvider{})
+	assert.NoError(t, err)
+	testEnv.txmgr = txMgr
+
+	fingerprint, err := txMgr.GetStateFingerprint(2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fingerprint)
+
+	// the following blocks are fingerprinted incrementally from the bootstrapped fingerprint
+	_, _, err = txMgr.ValidateAndPrepare(prepareNextBlockForTest(t, txMgr, bg, "txid-3",
+		map[string]string{"key3": "value3"}, nil, false), true)
+	assert.NoError(t, err)
+	assert.NoError(t, txMgr.Commit())
+	fingerprint, err = txMgr.GetStateFingerprint(3)
+	assert.NoError(t, err)
+	assert.NotNil(t, fingerprint)
+}
+
+// TestStateFingerprintCouchDBAndLevelDB checks that the same blocks produce the same fingerprints,
+// whether the state is stored in LevelDB or in CouchDB, which re-encodes the JSON values
+func TestStateFingerprintCouchDBAndLevelDB(t *testing.T) {
+	ledgerid := "testfingerprintcouchdbandleveldb"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns", "coll"}: 0,
+		},
+	)
+	levelDBEnv := testEnvsMap[levelDBtestEnvName].(*lockBasedEnv)
+	levelDBEnv.init(t, ledgerid, btlPolicy)
+	defer levelDBEnv.cleanup()
+	couchDBEnv := testEnvsMap[couchDBtestEnvName].(*lockBasedEnv)
+	couchDBEnv.init(t, ledgerid, btlPolicy)
+	defer couchDBEnv.cleanup()
+	envs := []*lockBasedEnv{levelDBEnv, couchDBEnv}
+	for _, env := range envs {
+		populateCollConfigForTest(t, env.getTxMgr().(*LockBasedTxMgr), []collConfigkey{{"ns", "coll"}}, version.NewHeight(1, 1))
+	}
+
+	// the transactions only write, so the blocks are valid on both ledgers
+	txMgr := levelDBEnv.getTxMgr()
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := []*ledger.BlockAndPvtData{
+		{Block: gb},
+		prepareNextBlockForTest(t, txMgr, bg, "txid-1",
+			map[string]string{"key1": "value1", "key2": `{"b":1.0, "a":12345678901234567891}`, "key3": `{"c":[1e2,"x"]}`},
+			map[string]string{"pvtkey1": `{"b":2, "a":0.10000000000000000001}`}, false),
+		prepareNextBlockForTest(t, txMgr, bg, "txid-2",
+			map[string]string{"key2": `{"a":2.50}`, "key3": "value3"}, map[string]string{"pvtkey1": "pvt-value1"}, false),
+	}
+	for _, blkAndPvtdata := range blocks {
+		var fingerprints []*common.StateFingerprint
+		for _, env := range envs {
+			_, _, err := env.getTxMgr().ValidateAndPrepare(blkAndPvtdata, true)
+			assert.NoError(t, err)
+			assert.NoError(t, env.getTxMgr().Commit())
+			fingerprint, err := env.getTxMgr().GetStateFingerprint(blkAndPvtdata.Block.Header.Number)
+			assert.NoError(t, err)
+			assert.NotNil(t, fingerprint)
+			fingerprints = append(fingerprints, fingerprint)
+		}
+		assert.Equal(t, fingerprints[0], fingerprints[1])
+	}
+
+	// the fingerprint computed by scanning the CouchDB state is the incremental one as well
+	fingerprinter := couchDBEnv.getTxMgr().(*LockBasedTxMgr).fingerprinter
+	expected, err := fingerprinter.get(2)
+	assert.NoError(t, err)
+	assert.NoError(t, fingerprinter.db.Delete(encodeFingerprintKey(2), true))
+	assert.NoError(t, fingerprinter.bootstrap(2, couchDBEnv.getVDB()))
+	fingerprint, err := fingerprinter.get(2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fingerprint)
+}
+
+type notFullScannableDB struct {
+	privacyenabledstate.DB
+}
+
+func (db *notFullScannableDB) IsFullScannable() bool {
+	return false
+}
+
+func TestCanonicalValue(t *testing.T) {
+	assert.Equal(t, []byte("value1"), canonicalValue([]byte("value1")))
+	assert.Equal(t, []byte(`["b","a"]`), canonicalValue([]byte(`["b","a"]`)))
+	assert.Equal(t, []byte(`{"a":2`), canonicalValue([]byte(`{"a":2`)))
+	assert.Equal(t, []byte(`{"a":2} {"b":1}`), canonicalValue([]byte(`{"a":2} {"b":1}`)))
+	assert.Equal(t, []byte(`{"a":2,"b":1}`), canonicalValue([]byte(` {"b":1, "a":2}`)))
+	assert.Equal(t, []byte("null"), canonicalValue([]byte("null")))
+	// the numbers are converted as CouchDB does, so the equivalent encodings of a float64 collapse
+	for _, value := range []string{`{"a":1,"b":0.1}`, `{"b":0.10000000000000000001,"a":1.0}`, `{"a":1e0,"b":1E-1}`} {
+		assert.Equal(t, []byte(`{"a":1,"b":0.1}`), canonicalValue([]byte(value)))
+	}
+	assert.Equal(t, []byte(`{"a":12345678901234567000}`), canonicalValue([]byte(`{"a":12345678901234567891}`)))
+	// the conversion is stable, a value read back from CouchDB has the representation of the value written
+	canonical := canonicalValue([]byte(`{"b":{"d":[1.50,"x"],"c":true},"a":12345678901234567891}`))
+	assert.Equal(t, canonical, canonicalValue(canonical))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// crossChannelLocksInUse returns true if a key is locked by a cross-channel
+// write intent in the committed state, or if a transaction of the block
+// writes to the lock of a key. Otherwise no transaction of the block can
+// conflict with a lock, and the locks are not checked.
+func (v *Validator) crossChannelLocksInUse(block *internal.Block) (bool, error) {
+	for _, tx := range block.Txs {
+		for _, nsRWSet := range tx.RWSet.NsRwSets {
+			if nsRWSet.NameSpace != crosschannel.Namespace {
+				continue
+			}
+			for _, write := range nsRWSet.KvRwSet.Writes {
+				if crosschannel.IsLockKey(write.Key) {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	startKey, endKey := crosschannel.LockKeyRange()
+	itr, err := v.db.GetStateRangeScanIterator(crosschannel.Namespace, startKey, endKey)
+	if err != nil {
+		return false, err
+	}
+	defer itr.Close()
+	res, err := itr.Next()
+	if err != nil {
+		return false, err
+	}
+	return res != nil, nil
+}
+
+// validateCrossChannelLocks checks that a transaction which passed the MVCC
+// validation neither writes to a key locked by a cross-channel write intent,
+// unless it releases the lock, nor locks a key already locked. The locks are
+// those of the committed state, updated by the preceding valid transactions
+// of the block.
+func (v *Validator) validateCrossChannelLocks(txRWSet *rwsetutil.TxRwSet, updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+	acquired := make(map[string]struct{})
+	released := make(map[string]struct{})
+	var written []string
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace == crosschannel.Namespace {
+			for _, write := range nsRWSet.KvRwSet.Writes {
+				if !crosschannel.IsLockKey(write.Key) {
+					continue
+				}
+				if write.IsDelete {
+					released[write.Key] = struct{}{}
+				} else {
+					acquired[write.Key] = struct{}{}
+				}
+			}
+			continue
+		}
+		for _, write := range nsRWSet.KvRwSet.Writes {
+			written = append(written, crosschannel.LockKey(nsRWSet.NameSpace, write.Key))
+		}
+		for _, write := range nsRWSet.KvRwSet.MetadataWrites {
+			written = append(written, crosschannel.LockKey(nsRWSet.NameSpace, write.Key))
+		}
+	}
+
+	checked := make([]string, 0, len(acquired)+len(written))
+	for lockKey := range acquired {
+		checked = append(checked, lockKey)
+	}
+	for _, lockKey := range written {
+		if _, ok := released[lockKey]; !ok {
+			checked = append(checked, lockKey)
+		}
+	}
+	for _, lockKey := range checked {
+		locked, err := v.isLocked(lockKey, updates.PubUpdates)
+		if err != nil {
+			return peer.TxValidationCode(-1), err
+		}
+		if locked {
+			return peer.TxValidationCode_CROSS_CHANNEL_LOCK_CONFLICT, nil
+		}
+	}
+	return peer.TxValidationCode_VALID, nil
+}
+
+// isLocked returns true if the lock is held according to the updates of the
+// preceding valid transactions of the block, or else to the committed state
+func (v *Validator) isLocked(lockKey string, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	if vv := updates.Get(crosschannel.Namespace, lockKey); vv != nil {
+		return vv.Value != nil, nil
+	}
+	committedVersion, err := v.db.GetVersion(crosschannel.Namespace, lockKey)
+	if err != nil {
+		return false, err
+	}
+	return committedVersion != nil, nil
+}
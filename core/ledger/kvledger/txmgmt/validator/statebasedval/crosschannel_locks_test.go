@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorCrossChannelLocks(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put(crosschannel.Namespace, crosschannel.LockKey("ns1", "locked"), []byte("tx0"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1))
+
+	validator := NewValidator(db)
+
+	// writes to a key locked in the committed state
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToWriteSet("ns1", "locked", []byte("value"))
+
+	// locks a key but fails the MVCC validation, hence its lock doesn't count
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 5))
+	rwsetBuilder2.AddToWriteSet(crosschannel.Namespace, crosschannel.LockKey("ns1", "key2"), []byte("tx2"))
+
+	// locks the same key
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToWriteSet(crosschannel.Namespace, crosschannel.LockKey("ns1", "key2"), []byte("tx3"))
+
+	// writes to the key locked by the preceding transaction
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToWriteSet("ns1", "key2", []byte("value"))
+
+	// releases the lock of the key it writes
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToWriteSet(crosschannel.Namespace, crosschannel.LockKey("ns1", "locked"), nil)
+	rwsetBuilder5.AddToWriteSet("ns1", "locked", []byte("value"))
+
+	// writes to the key released by the preceding transaction
+	rwsetBuilder6 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder6.AddToWriteSet("ns1", "locked", []byte("value"))
+
+	txRWSets := getTestPubSimulationRWSet(t, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4, rwsetBuilder5, rwsetBuilder6)
+	var txs []*internal.Transaction
+	for i, txRWSet := range txRWSets {
+		txs = append(txs, &internal.Transaction{IndexInBlock: i, RWSet: txRWSet})
+	}
+	block := &internal.Block{Num: 2, Txs: txs}
+	_, err := validator.ValidateAndPrepareBatch(block, true)
+	assert.NoError(t, err)
+
+	expectedCodes := []peer.TxValidationCode{
+		peer.TxValidationCode_CROSS_CHANNEL_LOCK_CONFLICT,
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+		peer.TxValidationCode_VALID,
+		peer.TxValidationCode_CROSS_CHANNEL_LOCK_CONFLICT,
+		peer.TxValidationCode_VALID,
+		peer.TxValidationCode_VALID,
+	}
+	for i, tx := range block.Txs {
+		assert.Equal(t, expectedCodes[i], tx.ValidationCode, "transaction %d", i)
+	}
+}
+
+func TestValidatorCrossChannelLocksNotInUse(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	validator := NewValidator(db)
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet("ns1", "key1", []byte("value1"))
+	block := &internal.Block{Num: 1, Txs: []*internal.Transaction{{RWSet: getTestPubSimulationRWSet(t, rwsetBuilder)[0]}}}
+	inUse, err := validator.crossChannelLocksInUse(block)
+	assert.NoError(t, err)
+	assert.False(t, inUse)
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put(crosschannel.Namespace, crosschannel.LockKey("ns1", "key1"), []byte("tx0"), version.NewHeight(1, 0))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 0))
+	inUse, err = validator.crossChannelLocksInUse(block)
+	assert.NoError(t, err)
+	assert.True(t, inUse)
+}
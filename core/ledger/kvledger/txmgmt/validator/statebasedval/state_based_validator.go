@@ -97,6 +97,14 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 		}
 	}
 
+	checkCrossChannelLocks := false
+	if doMVCCValidation {
+		var err error
+		if checkCrossChannelLocks, err = v.crossChannelLocksInUse(block); err != nil {
+			return nil, err
+		}
+	}
+
 	updates := internal.NewPubAndHashUpdates()
 	for _, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
@@ -104,6 +112,14 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
 			return nil, err
 		}
+		// the locks of the cross-channel write intents are checked once the
+		// transaction passed the MVCC validation, so that only the locks of
+		// the transactions which are committed are taken into account
+		if validationCode == peer.TxValidationCode_VALID && checkCrossChannelLocks {
+			if validationCode, err = v.validateCrossChannelLocks(tx.RWSet, updates); err != nil {
+				return nil, err
+			}
+		}
 
 		tx.ValidationCode = validationCode
 		if validationCode == peer.TxValidationCode_VALID {
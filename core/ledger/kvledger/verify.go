@@ -0,0 +1,275 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// VerificationReport is the outcome of the offline verification of a ledger
+type VerificationReport struct {
+	LedgerID     string             `json:"ledger_id"`
+	Height       uint64             `json:"height"`
+	BlockFiles   *VerificationCheck `json:"block_files"`
+	BlockIndex   *VerificationCheck `json:"block_index"`
+	StateDB      *VerificationCheck `json:"state_db"`
+	HistoryDB    *VerificationCheck `json:"history_db,omitempty"`
+	PvtdataStore *VerificationCheck `json:"pvtdata_store"`
+}
+
+// VerificationCheck lists the problems found in one of the stores of a ledger.
+// Issues denote corrupted data, whereas warnings denote inconsistencies that
+// are resolved by the peer when it starts.
+type VerificationCheck struct {
+	Issues   []string `json:"issues"`
+	Warnings []string `json:"warnings,omitempty"`
+	Repaired bool     `json:"repaired,omitempty"`
+}
+
+// OK returns true if no issue was found in the ledger, or if all the issues have been repaired
+func (r *VerificationReport) OK() bool {
+	for _, c := range []*VerificationCheck{r.BlockFiles, r.BlockIndex, r.StateDB, r.HistoryDB, r.PvtdataStore} {
+		if c != nil && len(c.Issues) > 0 && !c.Repaired {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyKVLedger verifies the consistency of the stores of a ledger. The block files are checked for
+// the hash chain of the block headers and for the data hashes, and each block is passed to the function
+// verifyBlockSignatures, if not nil, which is expected to check the signatures of the orderers.
+// The block index is cross-checked against the block files, and the savepoints of the state and history
+// databases and the private data against the block store. If rebuildIndex is true and the block index
+// is found to be inconsistent, the block index is rebuilt from the block files.
+// The peer must be offline when this function is invoked.
+func VerifyKVLedger(ledgerID string, rebuildIndex bool, verifyBlockSignatures func(block *common.Block) error) (*VerificationReport, error) {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	// none of the stores is opened for an unknown ledger, as opening them would create them
+	blockstorePath := ledgerconfig.GetBlockStorePath()
+	if err := ledgerstorage.ValidateLedgerExists(blockstorePath, ledgerID); err != nil {
+		return nil, err
+	}
+
+	report := &VerificationReport{
+		LedgerID:     ledgerID,
+		BlockFiles:   &VerificationCheck{},
+		BlockIndex:   &VerificationCheck{},
+		StateDB:      &VerificationCheck{},
+		PvtdataStore: &VerificationCheck{},
+	}
+
+	pvtdataStoreProvider := pvtdatastorage.NewProvider()
+	defer pvtdataStoreProvider.Close()
+	pvtdataStore, err := pvtdataStoreProvider.OpenStore(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	pvtdataStore.Init(&neverExpiringBTLPolicy{})
+	pvtdataStoreHeight, err := pvtdataStore.LastCommittedBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	var pvtdataErr error
+	verifyBlock := func(block *common.Block) []string {
+		if block.Header.Number < pvtdataStoreHeight && pvtdataErr == nil {
+			var issues []string
+			issues, pvtdataErr = verifyPvtdataHashes(block, pvtdataStore)
+			report.PvtdataStore.Issues = append(report.PvtdataStore.Issues, issues...)
+		}
+		if verifyBlockSignatures == nil {
+			return nil
+		}
+		if err := verifyBlockSignatures(block); err != nil {
+			return []string{fmt.Sprintf("signatures of block [%d] are not valid: %s", block.Header.Number, err)}
+		}
+		return nil
+	}
+
+	logger.Infof("Verifying the block store of ledger [%s]", ledgerID)
+	blockStoreVerification, err := ledgerstorage.VerifyBlockStore(blockstorePath, ledgerID, rebuildIndex, verifyBlock)
+	if err != nil {
+		return nil, err
+	}
+	if pvtdataErr != nil {
+		return nil, pvtdataErr
+	}
+	report.Height = blockStoreVerification.Height
+	report.BlockFiles.Issues = blockStoreVerification.BlockFileIssues
+	report.BlockIndex.Issues = blockStoreVerification.IndexIssues
+	report.BlockIndex.Repaired = blockStoreVerification.IndexRebuilt
+
+	logger.Infof("Verifying the pvtdata store of ledger [%s]", ledgerID)
+	switch {
+	case pvtdataStoreHeight < report.Height:
+		report.PvtdataStore.Issues = append(report.PvtdataStore.Issues, fmt.Sprintf(
+			"pvtdata store height [%d] is lower than block store height [%d]", pvtdataStoreHeight, report.Height))
+	case pvtdataStoreHeight == report.Height+1:
+		// the private data is committed before the block, hence a crash in between leaves
+		// the pvtdata store ahead by one block until the block is committed again
+		report.PvtdataStore.Warnings = append(report.PvtdataStore.Warnings, fmt.Sprintf(
+			"pvtdata store height [%d] is ahead of block store height [%d]", pvtdataStoreHeight, report.Height))
+	case pvtdataStoreHeight > report.Height+1:
+		report.PvtdataStore.Issues = append(report.PvtdataStore.Issues, fmt.Sprintf(
+			"pvtdata store height [%d] is higher than block store height [%d]", pvtdataStoreHeight, report.Height))
+	}
+
+	logger.Infof("Verifying the state database of ledger [%s]", ledgerID)
+	stateSavepoint, err := retrieveStateSavepoint(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	verifySavepoint(report.StateDB, "state database", stateSavepoint, report.Height)
+
+	if ledgerconfig.IsHistoryDBEnabled() {
+		logger.Infof("Verifying the history database of ledger [%s]", ledgerID)
+		historySavepoint, err := retrieveHistorySavepoint(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		report.HistoryDB = &VerificationCheck{}
+		verifySavepoint(report.HistoryDB, "history database", historySavepoint, report.Height)
+	}
+	return report, nil
+}
+
+// verifySavepoint checks the savepoint of a database against the height of the block store.
+// A database behind the block store is caught up by the peer when it starts.
+func verifySavepoint(check *VerificationCheck, dbName string, savepoint *version.Height, height uint64) {
+	savepointHeight := uint64(0)
+	if savepoint != nil {
+		savepointHeight = savepoint.BlockNum + 1
+	}
+	switch {
+	case savepointHeight > height:
+		check.Issues = append(check.Issues, fmt.Sprintf(
+			"%s savepoint is at block [%d] while block store height is [%d]", dbName, savepoint.BlockNum, height))
+	case savepointHeight < height:
+		check.Warnings = append(check.Warnings, fmt.Sprintf(
+			"%s height [%d] is lower than block store height [%d]", dbName, savepointHeight, height))
+	}
+}
+
+func retrieveStateSavepoint(ledgerID string) (*version.Height, error) {
+	var vdbProvider statedb.VersionedDBProvider
+	if ledgerconfig.IsCouchDBEnabled() {
+		var err error
+		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(&disabled.Provider{}, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		vdbProvider = stateleveldb.NewVersionedDBProvider(nil)
+	}
+	defer vdbProvider.Close()
+	vdb, err := vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	return vdb.GetLatestSavePoint()
+}
+
+func retrieveHistorySavepoint(ledgerID string) (*version.Height, error) {
+	historydbProvider := historyleveldb.NewHistoryDBProvider()
+	defer historydbProvider.Close()
+	historyDB, err := historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	return historyDB.GetLastSavepoint()
+}
+
+// verifyPvtdataHashes compares the hashes of the private data of a block held in the pvtdata store
+// with the hashes of the private writes in the rwsets of the corresponding transactions of the block
+func verifyPvtdataHashes(block *common.Block, pvtdataStore pvtdatastorage.Store) ([]string, error) {
+	blockNum := block.Header.Number
+	blockPvtdata, err := pvtdataStore.GetPvtDataByBlockNum(blockNum, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	for _, txPvtdata := range blockPvtdata {
+		txNum := txPvtdata.SeqInBlock
+		if txNum >= uint64(len(block.Data.Data)) {
+			issues = append(issues, fmt.Sprintf(
+				"private data found for transaction [%d] of block [%d] that does not exist", txNum, blockNum))
+			continue
+		}
+		txRWSet, err := extractTxRWSet(block.Data.Data[txNum])
+		if err != nil {
+			issues = append(issues, fmt.Sprintf(
+				"private data found for transaction [%d] of block [%d] whose rwset cannot be decoded: %s", txNum, blockNum, err))
+			continue
+		}
+		for _, nsPvtRwset := range txPvtdata.WriteSet.NsPvtRwset {
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				ns, coll := nsPvtRwset.Namespace, collPvtRwset.CollectionName
+				rwsetHash := txRWSet.GetPvtDataHash(ns, coll)
+				switch {
+				case rwsetHash == nil:
+					issues = append(issues, fmt.Sprintf(
+						"private data found for namespace [%s] collection [%s] not accessed by transaction [%d] of block [%d]",
+						ns, coll, txNum, blockNum))
+				case !bytes.Equal(util.ComputeHash(collPvtRwset.Rwset), rwsetHash):
+					issues = append(issues, fmt.Sprintf(
+						"hash of the private data of namespace [%s] collection [%s] does not match the rwset of transaction [%d] of block [%d]",
+						ns, coll, txNum, blockNum))
+				}
+			}
+		}
+	}
+	return issues, nil
+}
+
+func extractTxRWSet(envBytes []byte) (*rwsetutil.TxRwSet, error) {
+	action, err := utils.GetActionFromEnvelope(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(action.Results); err != nil {
+		return nil, err
+	}
+	return txRWSet, nil
+}
+
+// neverExpiringBTLPolicy lets the private data be retrieved from the pvtdata store
+// for as long as it has not been purged
+type neverExpiringBTLPolicy struct{}
+
+func (p *neverExpiringBTLPolicy) GetBTL(ns string, coll string) (uint64, error) {
+	return 0, nil
+}
+
+func (p *neverExpiringBTLPolicy) GetExpiringBlock(ns string, coll string, committingBlock uint64) (uint64, error) {
+	return math.MaxUint64, nil
+}
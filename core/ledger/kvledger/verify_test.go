@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+)
+
+type verifyTestPvtdataStore struct {
+	pvtdatastorage.Store
+	blockPvtdata []*ledger.TxPvtData
+}
+
+func (s *verifyTestPvtdataStore) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	return s.blockPvtdata, nil
+}
+
+func TestVerifyPvtdataHashes(t *testing.T) {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll2", "key2", []byte("value2"))
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimulationBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	block := testutil.ConstructBlock(t, 5, nil, [][]byte{pubSimulationBytes}, false)
+
+	t.Run("matching private data", func(t *testing.T) {
+		store := &verifyTestPvtdataStore{
+			blockPvtdata: []*ledger.TxPvtData{{SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults}},
+		}
+		issues, err := verifyPvtdataHashes(block, store)
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("mismatching private data", func(t *testing.T) {
+		tamperedWriteSet := proto.Clone(simRes.PvtSimulationResults).(*rwset.TxPvtReadWriteSet)
+		tamperedWriteSet.NsPvtRwset[0].CollectionPvtRwset[1].Rwset = []byte("tampered")
+		tamperedWriteSet.NsPvtRwset = append(tamperedWriteSet.NsPvtRwset, &rwset.NsPvtReadWriteSet{
+			Namespace:          "ns2",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "coll1"}},
+		})
+		store := &verifyTestPvtdataStore{
+			blockPvtdata: []*ledger.TxPvtData{
+				{SeqInBlock: 0, WriteSet: tamperedWriteSet},
+				{SeqInBlock: 1, WriteSet: simRes.PvtSimulationResults},
+			},
+		}
+		issues, err := verifyPvtdataHashes(block, store)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"hash of the private data of namespace [ns1] collection [coll2] does not match the rwset of transaction [0] of block [5]",
+			"private data found for namespace [ns2] collection [coll1] not accessed by transaction [0] of block [5]",
+			"private data found for transaction [1] of block [5] that does not exist",
+		}, issues)
+	})
+}
+
+func TestVerifySavepoint(t *testing.T) {
+	check := &VerificationCheck{}
+	verifySavepoint(check, "state database", version.NewHeight(9, 2), 10)
+	assert.Equal(t, &VerificationCheck{}, check)
+
+	verifySavepoint(check, "state database", version.NewHeight(10, 2), 10)
+	assert.Equal(t, []string{"state database savepoint is at block [10] while block store height is [10]"}, check.Issues)
+
+	verifySavepoint(check, "history database", nil, 10)
+	assert.Equal(t, []string{"history database height [0] is lower than block store height [10]"}, check.Warnings)
+}
+
+func TestVerifyKVLedgerNonExistingLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	_, err := VerifyKVLedger("unknown-ledger", false, nil)
+	assert.EqualError(t, err, "ledgerID [unknown-ledger] does not exist")
+
+	_, err = os.Stat(ledgerconfig.GetPvtdataStorePath())
+	assert.True(t, os.IsNotExist(err))
+}
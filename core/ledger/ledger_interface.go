@@ -14,6 +14,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -41,6 +42,8 @@ type PeerLedgerProvider interface {
 	Exists(ledgerID string) (bool, error)
 	// List lists the ids of the existing ledgers
 	List() ([]string, error)
+	// Remove removes the ledger with given id, which must not be open, along with all its data
+	Remove(ledgerID string) error
 	// Close closes the PeerLedgerProvider
 	Close()
 }
@@ -89,11 +92,25 @@ type PeerLedger interface {
 	Prune(policy commonledger.PrunePolicy) error
 	// GetConfigHistoryRetriever returns the ConfigHistoryRetriever
 	GetConfigHistoryRetriever() (ConfigHistoryRetriever, error)
+	// GetStateFingerprint returns the fingerprint of the world state right after the given block was committed.
+	// The fingerprints of peers at the same height are equal if and only if the peers have the same state.
+	GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error)
+	// GetChaincodeEvents returns an iterator over the events with the given name set by the given chaincode in the
+	// valid transactions of the given range of blocks, in the order of the transactions. The results of the iterator
+	// are of type *peer.ChaincodeEventInfo. An error is returned if the chaincode event index is not enabled or if
+	// it does not cover the start block, as only the blocks committed since the index is enabled are indexed.
+	GetChaincodeEvents(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) (commonledger.ResultsIterator, error)
 	// CommitPvtDataOfOldBlocks commits the private data corresponding to already committed block
 	// If hashes for some of the private data supplied in this function does not match
 	// the corresponding hash present in the block, the unmatched private data is not
 	// committed and instead the mismatch inforation is returned back
 	CommitPvtDataOfOldBlocks(blockPvtData []*BlockPvtData) ([]*PvtdataHashMismatch, error)
+	// RepairPvtDataOfOldBlocks replaces the stored private data of already committed blocks which
+	// doesn't match the corresponding hash present in the block, and commits the supplied private
+	// data which isn't stored as CommitPvtDataOfOldBlocks does. The state database isn't updated
+	// with the replaced private data. The supplied private data which doesn't match the hash
+	// present in the block isn't written, and the mismatch information is returned back
+	RepairPvtDataOfOldBlocks(blockPvtData []*BlockPvtData) ([]*PvtdataHashMismatch, error)
 	// GetMissingPvtDataTracker return the MissingPvtDataTracker
 	GetMissingPvtDataTracker() (MissingPvtDataTracker, error)
 	// DoesPvtDataInfoExist returns true when
@@ -178,6 +195,35 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key, restricted to a range of
+	// blocks, in the requested order and paginated according to the given options.
+	// The returned QueryResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithOptions(namespace string, key string, options *HistoryQueryOptions) (QueryResultsIterator, error)
+	// GetStateAtHeight retrieves the last modification of a key in the blocks up to and including
+	// the given block, i.e., the value of the key as it was right after the block was committed.
+	// The returned KeyModification is nil if the key was never written until that block.
+	GetStateAtHeight(namespace string, key string, blockNum uint64) (*queryresult.KeyModification, error)
+	// GetStateRangeScanIteratorAtHeight returns an iterator that contains all the key-values between given
+	// key ranges as they were right after the given block was committed. startKey is included in the
+	// results and endKey is excluded. An empty endKey refers to the last available key.
+	// The results are in the order of the keys, except that a key followed by a nil byte in
+	// other keys of the range may come after them, as in the history database.
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIteratorAtHeight(namespace string, startKey string, endKey string, blockNum uint64) (commonledger.ResultsIterator, error)
+}
+
+// HistoryQueryOptions bounds, orders and paginates the results of a history query
+type HistoryQueryOptions struct {
+	// StartBlock is the lowest block whose modifications are returned
+	StartBlock uint64
+	// EndBlock is the highest block whose modifications are returned, zero meaning no upper bound
+	EndBlock uint64
+	// PageSize is the maximum number of modifications returned, zero meaning no limit
+	PageSize int32
+	// Bookmark is the bookmark returned along with a previous page, from which the query resumes
+	Bookmark string
+	// Descending returns the modifications from the newest to the oldest instead of the other way around
+	Descending bool
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -384,6 +430,9 @@ type ConfigHistoryRetriever interface {
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information for at most
+	// `maxBlocks` blocks within the range [startBlock, endBlock], visiting the most recent blocks first
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlocks int) (MissingPvtDataInfo, error)
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
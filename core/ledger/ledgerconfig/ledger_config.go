@@ -38,6 +38,9 @@ const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confStateCacheSize = "ledger.state.cacheSize"
+const confBlockfileCompression = "ledger.blockchain.compression"
+const confEnableChaincodeEventIndex = "ledger.blockchain.enableChaincodeEventIndex"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
@@ -94,6 +97,18 @@ func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+// GetBlockfileCompression returns the compression of the block files created by the block store,
+// i.e. "" for no compression or "snappy". It does not affect the existing block files
+func GetBlockfileCompression() string {
+	return viper.GetString(confBlockfileCompression)
+}
+
+// IsChaincodeEventIndexEnabled returns whether the chaincode events set by the valid transactions
+// are indexed by chaincode id and event name in the block index
+func IsChaincodeEventIndexEnabled() bool {
+	return viper.GetBool(confEnableChaincodeEventIndex)
+}
+
 // GetTotalQueryLimit exposes the totalLimit variable
 func GetTotalQueryLimit() int {
 	totalQueryLimit := viper.GetInt(confTotalQueryLimit)
@@ -192,6 +207,17 @@ func GetWarmIndexesAfterNBlocks() int {
 	return warmAfterNBlocks
 }
 
+// GetStateCacheSize returns the size, in megabytes, of the cache of committed
+// values shared by the state databases of all the channels. Zero disables the cache.
+func GetStateCacheSize() int {
+	cacheSize := viper.GetInt(confStateCacheSize)
+	// if cacheSize was unset, default to 64
+	if !viper.IsSet(confStateCacheSize) {
+		cacheSize = 64
+	}
+	return cacheSize
+}
+
 type conf struct {
 	Name       string
 	DefaultVal int
@@ -198,6 +198,22 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	assert.False(t, updatedValue) //test config returns false
 }
 
+func TestIsChaincodeEventIndexEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsChaincodeEventIndexEnabled()) //test default config is false
+	viper.Set("ledger.blockchain.enableChaincodeEventIndex", true)
+	assert.True(t, IsChaincodeEventIndexEnabled())
+}
+
+func TestGetBlockfileCompression(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, "", GetBlockfileCompression()) //test default config is no compression
+	viper.Set("ledger.blockchain.compression", "snappy")
+	assert.Equal(t, "snappy", GetBlockfileCompression())
+}
+
 func TestIsAutoWarmIndexesEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsAutoWarmIndexesEnabled()
@@ -246,6 +262,26 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	assert.Equal(t, 10, updatedValue)
 }
 
+func TestGetStateCacheSizeDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 64, defaultValue)
+}
+
+func TestGetStateCacheSizeUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 64, defaultValue)
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.cacheSize", 0)
+	updatedValue := GetStateCacheSize()
+	assert.Equal(t, 0, updatedValue)
+}
+
 func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
 }
@@ -131,6 +131,24 @@ func OpenLedger(id string) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// RemoveLedger removes the ledger with the given id along with all its data. The ledger must have been closed
+func RemoveLedger(id string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return errors.Errorf("ledger [%s] must be closed before it is removed", id)
+	}
+	logger.Infof("Removing ledger [%s]", id)
+	if err := ledgerProvider.Remove(id); err != nil {
+		return err
+	}
+	logger.Infof("Removed ledger [%s]", id)
+	return nil
+}
+
 // GetLedgerIDs returns the ids of the ledgers created
 func GetLedgerIDs() ([]string, error) {
 	lock.Lock()
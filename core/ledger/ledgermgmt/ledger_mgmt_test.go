@@ -54,6 +54,8 @@ func TestLedgerMgmt(t *testing.T) {
 	assert.Nil(t, ids)
 	assert.Equal(t, ErrLedgerMgmtNotInitialized, err)
 
+	assert.Equal(t, ErrLedgerMgmtNotInitialized, RemoveLedger(ledgerID))
+
 	Close()
 
 	InitializeTestEnv()
@@ -86,6 +88,17 @@ func TestLedgerMgmt(t *testing.T) {
 	l, err = OpenLedger(ledgerID)
 	assert.Equal(t, ErrLedgerAlreadyOpened, err)
 
+	// an opened ledger cannot be removed
+	removedLedgerID := constructTestLedgerID(3)
+	assert.EqualError(t, RemoveLedger(removedLedgerID), fmt.Sprintf("ledger [%s] must be closed before it is removed", removedLedgerID))
+	ledgers[3].Close()
+	assert.NoError(t, RemoveLedger(removedLedgerID))
+	ids, _ = GetLedgerIDs()
+	assert.Len(t, ids, numLedgers-1)
+	assert.NotContains(t, ids, removedLedgerID)
+	_, err = OpenLedger(removedLedgerID)
+	assert.Error(t, err)
+
 	// close all opened ledgers and ledger mgmt
 	Close()
 
@@ -47,12 +47,22 @@ var attrsToIndex = []blkstorage.IndexableAttr{
 	blkstorage.IndexableAttrTxValidationCode,
 }
 
+// newIndexConfig returns the configuration of the block index, which includes
+// the chaincode events if the chaincode event index is enabled
+func newIndexConfig() *blkstorage.IndexConfig {
+	attrs := append([]blkstorage.IndexableAttr{}, attrsToIndex...)
+	if ledgerconfig.IsChaincodeEventIndexEnabled() {
+		attrs = append(attrs, blkstorage.IndexableAttrChaincodeEvent)
+	}
+	return &blkstorage.IndexConfig{AttrsToIndex: attrs}
+}
+
 // NewProvider returns the handle to the provider
 func NewProvider(metricsProvider metrics.Provider) *Provider {
 	// Initialize the block storage
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	indexConfig := newIndexConfig()
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithCompression(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(), ledgerconfig.GetBlockfileCompression()),
 		indexConfig,
 		metricsProvider)
 
@@ -93,6 +103,16 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// DropLedger deletes the private data and the blocks of the store of a ledger, which must not be open.
+// The private data is deleted first so that the store of a partially dropped ledger is never
+// ahead of its block store
+func (p *Provider) DropLedger(ledgerid string) error {
+	if err := p.pvtdataStoreProvider.DropLedger(ledgerid); err != nil {
+		return err
+	}
+	return p.blkStoreProvider.DropLedger(ledgerid)
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -192,6 +212,12 @@ func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 	return nil
 }
 
+// ReplaceCorruptedPvtData replaces the stored pvtData of old blocks which differs from the given one,
+// and returns the given pvtData which isn't stored
+func (s *Store) ReplaceCorruptedPvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error) {
+	return s.pvtdataStore.ReplaceCorruptedPvtData(blocksPvtData)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (s *Store) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -253,6 +279,11 @@ func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return s.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange invokes the function on underlying pvtdata store
+func (s *Store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	return s.pvtdataStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock)
+}
+
 // ProcessCollsEligibilityEnabled invokes the function on underlying pvtdata store
 func (s *Store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap map[string][]string) error {
 	return s.pvtdataStore.ProcessCollsEligibilityEnabled(committingBlk, nsCollMap)
@@ -364,6 +395,24 @@ func ValidateRollbackParams(blockstorePath, ledgerID string, blockNum uint64) er
 
 // Rollback reverts changes made to the block store beyond a given block number.
 func Rollback(blockstorePath, ledgerID string, blockNum uint64) error {
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	indexConfig := newIndexConfig()
 	return fsblkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig)
 }
+
+// VerifyBlockStore verifies the block files and the block index of a ledger and optionally
+// rebuilds the block index from the block files if it is found to be inconsistent.
+func VerifyBlockStore(blockstorePath, ledgerID string, rebuildIndex bool, verifyBlock func(block *common.Block) []string) (*fsblkstorage.BlockStoreVerification, error) {
+	indexConfig := newIndexConfig()
+	return fsblkstorage.VerifyBlockStore(blockstorePath, ledgerID, indexConfig, rebuildIndex, verifyBlock)
+}
+
+// ValidateLedgerExists returns an error if the block store holds no ledger with the given ID.
+func ValidateLedgerExists(blockstorePath, ledgerID string) error {
+	return fsblkstorage.ValidateLedgerExists(blockstorePath, ledgerID)
+}
+
+// DropBlockIndex drops the block index of a ledger, which is rebuilt from the block files
+// when the ledger is opened next.
+func DropBlockIndex(blockstorePath, ledgerID string) error {
+	return fsblkstorage.DropBlockIndex(blockstorePath, ledgerID)
+}
@@ -183,6 +183,20 @@ func createRangeScanKeysForEligibleMissingDataEntries(blkNum uint64) (startKey,
 	return startKey, endKey
 }
 
+// createRangeScanKeysForEligibleMissingDataEntriesInRange returns the keys for scanning the eligible
+// missing data entries of the blocks [startBlkNum, endBlkNum]. As the block numbers are encoded in the
+// reverse order, the scan visits endBlkNum first. Similar to the scan of the full range, the
+// entries of the genesis block are never included.
+func createRangeScanKeysForEligibleMissingDataEntriesInRange(startBlkNum, endBlkNum uint64) (startKey, endKey []byte) {
+	if startBlkNum == 0 {
+		return createRangeScanKeysForEligibleMissingDataEntries(endBlkNum)
+	}
+	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(endBlkNum)...)
+	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(startBlkNum-1)...)
+
+	return startKey, endKey
+}
+
 func createRangeScanKeysForIneligibleMissingData(maxBlkNum uint64, ns, coll string) (startKey, endKey []byte) {
 	startKey = encodeMissingDataKey(
 		&missingDataKey{
@@ -15,6 +15,8 @@ import (
 // private write sets for a ledger
 type Provider interface {
 	OpenStore(id string) (Store, error)
+	// DropLedger deletes the private write sets of the given ledger, whose store must not be open
+	DropLedger(id string) error
 	Close()
 }
 
@@ -41,6 +43,10 @@ type Store interface {
 	// GetMissingPvtDataInfoForMostRecentBlocks returns the missing private data information for the
 	// most recent `maxBlock` blocks which miss at least a private data of a eligible collection.
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information for at most
+	// `maxBlock` blocks, within the range [startBlock, endBlock], which miss at least a private data
+	// of a eligible collection. The blocks are visited starting from `endBlock` towards `startBlock`.
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error)
 	// Prepare prepares the Store for commiting the pvt data and storing both eligible and ineligible
 	// missing private data --- `eligible` denotes that the missing private data belongs to a collection
 	// for which this peer is a member; `ineligible` denotes that the missing private data belong to a
@@ -65,6 +71,12 @@ type Store interface {
 	// these pvtData, the `lastUpdatedOldBlocksList` must be removed. During the peer startup,
 	// if the `lastUpdatedOldBlocksList` exists, stateDB needs to be updated with the appropriate pvtData.
 	CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error
+	// ReplaceCorruptedPvtData replaces the pvtData of old blocks which is stored but differs from the
+	// given one. The given pvtData must match the hashes present in the blocks, hence the stored pvtData
+	// which differs from it is corrupted. Only the stored entries are written, and the `lastUpdatedOldBlocksList`
+	// isn't changed, as the stateDB was updated with the original pvtData. The given pvtData which isn't
+	// stored is returned, so that it can be committed through `CommitPvtDataOfOldBlocks`
+	ReplaceCorruptedPvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error)
 	// GetLastUpdatedOldBlocksPvtData returns the pvtdata of blocks listed in `lastUpdatedOldBlocksList`
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
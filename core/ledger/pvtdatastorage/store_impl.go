@@ -130,6 +130,11 @@ func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	return s, nil
 }
 
+// DropLedger deletes all the entries of the store of a ledger
+func (p *provider) DropLedger(ledgerid string) error {
+	return p.dbProvider.GetDBHandle(ledgerid).DeleteAll()
+}
+
 // Close closes the store
 func (p *provider) Close() {
 	p.dbProvider.Close()
@@ -277,6 +282,61 @@ func (s *store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 	return nil
 }
 
+// ReplaceCorruptedPvtData implements the function in the interface `Store`
+func (s *store) ReplaceCorruptedPvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error) {
+	// the purger must not delete an entry in between it is read and replaced,
+	// otherwise the expired pvtData would be written back
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	replaced := 0
+	notStoredPvtData := make(map[uint64][]*ledger.TxPvtData)
+	for blkNum, pvtData := range blocksPvtData {
+		for _, txPvtData := range pvtData {
+			if txPvtData.WriteSet == nil {
+				continue
+			}
+			notStored := newTxPvtdataAssembler(blkNum, txPvtData.SeqInBlock)
+			isNotStored := false
+			for _, nsPvtdata := range txPvtData.WriteSet.NsPvtRwset {
+				for _, collPvtdata := range nsPvtdata.CollectionPvtRwset {
+					key := encodeDataKey(&dataKey{nsCollBlk{nsPvtdata.Namespace, collPvtdata.CollectionName, blkNum}, txPvtData.SeqInBlock})
+					storedBytes, err := s.db.Get(key)
+					if err != nil {
+						return nil, err
+					}
+					if storedBytes == nil {
+						notStored.add(nsPvtdata.Namespace, collPvtdata)
+						isNotStored = true
+						continue
+					}
+					// a stored entry which cannot be decoded is corrupted as well
+					if stored, err := decodeDataValue(storedBytes); err == nil && proto.Equal(stored, collPvtdata) {
+						continue
+					}
+					valBytes, err := encodeDataValue(collPvtdata)
+					if err != nil {
+						return nil, err
+					}
+					batch.Put(key, valBytes)
+					replaced++
+				}
+			}
+			if isNotStored {
+				notStoredPvtData[blkNum] = append(notStoredPvtData[blkNum], notStored.getTxPvtdata())
+			}
+		}
+	}
+	if replaced > 0 {
+		if err := s.commitBatch(batch); err != nil {
+			return nil, err
+		}
+		logger.Infof("Replaced [%d] corrupted pvtData entries of old blocks", replaced)
+	}
+	return notStoredPvtData, nil
+}
+
 func constructDataEntriesFromBlocksPvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) []*dataEntry {
 	// construct dataEntries for all pvtData
 	var dataEntries []*dataEntry
@@ -660,17 +720,32 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	if maxBlock < 1 {
 		return nil, nil
 	}
+	// as we are not acquiring a read lock, new blocks can get committed while we
+	// construct the MissingPvtDataInfo. As a result, lastCommittedBlock can get
+	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	return s.getMissingPvtDataInfo(0, lastCommittedBlock, maxBlock)
+}
+
+// GetMissingPvtDataInfoForBlockRange implements the function in the interface `Store`
+func (s *store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	if maxBlock < 1 || startBlock > endBlock {
+		return nil, nil
+	}
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	if endBlock > lastCommittedBlock {
+		endBlock = lastCommittedBlock
+	}
+	return s.getMissingPvtDataInfo(startBlock, endBlock, maxBlock)
+}
 
+func (s *store) getMissingPvtDataInfo(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
 	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
 	numberOfBlockProcessed := 0
 	lastProcessedBlock := uint64(0)
 	isMaxBlockLimitReached := false
-	// as we are not acquiring a read lock, new blocks can get committed while we
-	// construct the MissingPvtDataInfo. As a result, lastCommittedBlock can get
-	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
-	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
 
-	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntries(lastCommittedBlock)
+	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntriesInRange(startBlock, endBlock)
 	dbItr := s.db.GetIterator(startKey, endKey)
 	defer dbItr.Release()
 
@@ -692,7 +767,7 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 		// data (less possibility of expiring now), such scenario would be rare. In the
 		// best case, we can load the latest lastCommittedBlock value here atomically to
 		// make this scenario very rare.
-		lastCommittedBlock = atomic.LoadUint64(&s.lastCommittedBlock)
+		lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
 		expired, err := isExpired(missingDataKey.nsCollBlk, s.btlPolicy, lastCommittedBlock)
 		if err != nil {
 			return nil, err
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -160,6 +161,68 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 }
 
+func TestGetMissingPvtDataInfoForBlockRange(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestGetMissingPvtDataInfoForBlockRange", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Commit())
+
+	// blocks 1 to 4 miss eligible private data in tx1 for ns-1:coll-1
+	// and additionally block 3 misses ns-1:coll-2 in tx2
+	for blkNum := uint64(1); blkNum <= 4; blkNum++ {
+		missingData := make(ledger.TxMissingPvtDataMap)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		if blkNum == 3 {
+			missingData.Add(2, "ns-1", "coll-2", true)
+		}
+		assert.NoError(store.Prepare(blkNum, nil, missingData))
+		assert.NoError(store.Commit())
+	}
+
+	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-1")
+	expectedMissingPvtDataInfo.Add(3, 2, "ns-1", "coll-2")
+	expectedMissingPvtDataInfo.Add(2, 1, "ns-1", "coll-1")
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(2, 3, 10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// maxBlock limits the result to the most recent blocks within the range
+	expectedMissingPvtDataInfo = make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-1")
+	expectedMissingPvtDataInfo.Add(3, 2, "ns-1", "coll-2")
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(1, 3, 1)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// endBlock beyond the last committed block is capped
+	expectedMissingPvtDataInfo = make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(4, 1, "ns-1", "coll-1")
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(4, 100, 10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// a range starting from the genesis block behaves as the most recent blocks query
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(0, 4, 10)
+	assert.NoError(err)
+	expectedMissingPvtDataInfo, err = store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(3, 2, 10)
+	assert.NoError(err)
+	assert.Nil(missingPvtDataInfo)
+}
+
 func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	viper.Set("ledger.pvtdataStore.purgeInterval", 2)
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -379,6 +442,64 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	assert.True(testDataKeyExists(t, store, ns3Coll2Blk1Tx2))  // never expires
 }
 
+func TestReplaceCorruptedPvtData(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestReplaceCorruptedPvtData", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	db := env.TestStore.(*store).db
+	store := env.TestStore
+
+	testData := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"}),
+	}
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(3, "ns-1", "coll-1", true)
+
+	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Commit())
+	assert.NoError(store.Prepare(1, testData, blk1MissingData))
+	assert.NoError(store.Commit())
+
+	// corrupt the pvtData of tx2: the value of coll-1 differs, and the one of coll-2 cannot be decoded
+	corruptedValue, err := encodeDataValue(&rwset.CollectionPvtReadWriteSet{CollectionName: "coll-1", Rwset: []byte("corrupted")})
+	assert.NoError(err)
+	assert.NoError(db.Put(encodeDataKey(&dataKey{nsCollBlk{"ns-1", "coll-1", 1}, 2}), corruptedValue, true))
+	assert.NoError(db.Put(encodeDataKey(&dataKey{nsCollBlk{"ns-1", "coll-2", 1}, 2}), []byte{0xff}, true))
+
+	// the pvtData of tx3 isn't stored, hence it is returned instead of being written
+	tx3PvtData := produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"})
+	notStored, err := store.ReplaceCorruptedPvtData(map[uint64][]*ledger.TxPvtData{
+		1: {testData[0], tx3PvtData, testData[1]},
+	})
+	assert.NoError(err)
+	assert.Equal(map[uint64][]*ledger.TxPvtData{1: {tx3PvtData}}, notStored)
+
+	retrievedData, err := store.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Len(retrievedData, 2)
+	for i := range testData {
+		assert.True(proto.Equal(testData[i].WriteSet, retrievedData[i].WriteSet))
+	}
+	assert.False(testDataKeyExists(t, store, &dataKey{nsCollBlk{"ns-1", "coll-1", 1}, 3}))
+
+	// the missing data and the lastUpdatedOldBlocksList are unchanged
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(1, 3, "ns-1", "coll-1")
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+	lastUpdatedOldBlocksPvtData, err := store.GetLastUpdatedOldBlocksPvtData()
+	assert.NoError(err)
+	assert.Empty(lastUpdatedOldBlocksPvtData)
+}
+
 func TestExpiryDataNotIncluded(t *testing.T) {
 	ledgerid := "TestExpiryDataNotIncluded"
 	btlPolicy := btltestutil.SampleBTLPolicy(
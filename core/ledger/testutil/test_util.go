@@ -80,6 +80,9 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.cacheSize", 64)
+	viper.Set("ledger.blockchain.compression", "")
+	viper.Set("ledger.blockchain.enableChaincodeEventIndex", false)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
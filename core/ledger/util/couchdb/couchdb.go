@@ -451,6 +451,37 @@ func (couchInstance *CouchInstance) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// RetrieveApplicationDBNames returns the names of all the databases of the CouchDB instance,
+// except for the system databases whose names begin with an underscore
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", couchInstance.conf.URL)
+	}
+	maxRetries := couchInstance.conf.MaxRetries
+
+	resp, _, err := couchInstance.handleRequest(context.Background(), http.MethodGet, "", "RetrieveApplicationDBNames", connectURL, nil,
+		"", "", maxRetries, true, nil, "_all_dbs")
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	if err := json.NewDecoder(resp.Body).Decode(&dbNames); err != nil {
+		return nil, errors.Wrap(err, "error decoding response body")
+	}
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	logger.Debugf("Application databases: %s", applicationDBNames)
+	return applicationDBNames, nil
+}
+
 //DropDatabase provides method to drop an existing database
 func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 	dbName := dbclient.DBName
@@ -299,6 +299,26 @@ func TestDBCreateEnsureFullCommit(t *testing.T) {
 	assert.NoError(t, commiterr, "Error when trying to ensure a full commit")
 }
 
+func TestRetrieveApplicationDBNames(t *testing.T) {
+
+	database := "testretrieveapplicationdbnames"
+	err := cleanup(database)
+	assert.NoError(t, err, "Error when trying to cleanup  Error: %s", err)
+	defer cleanup(database)
+
+	couchInstance, err := CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB, &disabled.Provider{})
+	assert.NoError(t, err, "Error when trying to create couch instance")
+	db := CouchDatabase{CouchInstance: couchInstance, DBName: database}
+	assert.NoError(t, db.CreateDatabaseIfNotExist())
+
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	assert.NoError(t, err)
+	assert.Contains(t, dbNames, database)
+	assert.NotContains(t, dbNames, "_users")
+	assert.NotContains(t, dbNames, "_replicator")
+}
+
 func TestDBBadDatabaseName(t *testing.T) {
 
 	//create a new instance and database object using a valid database name mixed case
@@ -245,6 +245,47 @@ func ConstructNamespaceDBName(chainName, namespace string) string {
 	return namespaceDBName
 }
 
+// ConstructChainDBNamePrefix returns the prefix shared by the names of the metadata database
+// and of the namespace databases of a chain/channel, once mapped to CouchDB database names.
+// As the chain/channel name is truncated in the names of the namespace databases, the prefix
+// identifies the databases of a chain/channel only if its name is shorter than the allowed length
+func ConstructChainDBNamePrefix(chainName string) (string, error) {
+	if len(chainName) >= chainNameAllowedLength {
+		return "", errors.Errorf("the databases of chain [%s] cannot be told apart from the ones of other chains "+
+			"as its name is not shorter than %d characters", chainName, chainNameAllowedLength)
+	}
+	return mapAndValidateDatabaseName(chainName + "_")
+}
+
+// NamespaceFromDBName returns the namespace whose database, in the chain/channel of the given
+// prefix (see ConstructChainDBNamePrefix), has the given CouchDB database name. It reverts the
+// escaping of the upper-case letters, the '$$' joiner of the namespace and the collection being
+// kept as is. An error is returned if the name was truncated, as the namespace cannot be recovered
+func NamespaceFromDBName(chainDBNamePrefix, dbName string) (string, error) {
+	if !strings.HasPrefix(dbName, chainDBNamePrefix) {
+		return "", errors.Errorf("database name [%s] does not start with prefix [%s]", dbName, chainDBNamePrefix)
+	}
+	escapedNamespace := dbName[len(chainDBNamePrefix):]
+	if strings.Contains(escapedNamespace, "(") {
+		return "", errors.Errorf("the namespace of database [%s] cannot be recovered as it was truncated", dbName)
+	}
+	var namespace strings.Builder
+	for i := 0; i < len(escapedNamespace); i++ {
+		c := escapedNamespace[i]
+		switch {
+		case c != '$' || i+1 == len(escapedNamespace):
+			namespace.WriteByte(c)
+		case escapedNamespace[i+1] == '$':
+			namespace.WriteString("$$")
+			i++
+		default:
+			namespace.WriteString(strings.ToUpper(escapedNamespace[i+1 : i+2]))
+			i++
+		}
+	}
+	return namespace.String(), nil
+}
+
 //mapAndValidateDatabaseName checks to see if the database name contains illegal characters
 //CouchDB Rules: Only lowercase characters (a-z), digits (0-9), and any of the characters
 //_, $, (, ), +, -, and / are allowed. Must begin with a letter.
@@ -7,6 +7,7 @@ package couchdb
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -140,6 +141,38 @@ func TestConstructMetadataDBName(t *testing.T) {
 	assert.Equal(t, expectedDBName, constructedDBName)
 }
 
+func TestConstructChainDBNamePrefix(t *testing.T) {
+	prefix, err := ConstructChainDBNamePrefix("my.chain-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "my$chain-1_", prefix)
+	mappedMetadataDBName, err := mapAndValidateDatabaseName(ConstructMetadataDBName("my.chain-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, prefix, mappedMetadataDBName)
+
+	_, err = ConstructChainDBNamePrefix(strings.Repeat("a", chainNameAllowedLength))
+	assert.EqualError(t, err, "the databases of chain ["+strings.Repeat("a", chainNameAllowedLength)+
+		"] cannot be told apart from the ones of other chains as its name is not shorter than 50 characters")
+}
+
+func TestNamespaceFromDBName(t *testing.T) {
+	prefix, err := ConstructChainDBNamePrefix("my.chain-1")
+	assert.NoError(t, err)
+	for _, namespace := range []string{"", "mycc", "myCC_2-b", "myCC$$hColl-1", "myC$$pcoll"} {
+		dbName, err := mapAndValidateDatabaseName(ConstructNamespaceDBName("my.chain-1", namespace))
+		assert.NoError(t, err)
+		ns, err := NamespaceFromDBName(prefix, dbName)
+		assert.NoError(t, err)
+		assert.Equal(t, namespace, ns)
+	}
+
+	_, err = NamespaceFromDBName(prefix, "other_mycc")
+	assert.EqualError(t, err, "database name [other_mycc] does not start with prefix [my$chain-1_]")
+
+	dbName := ConstructNamespaceDBName("my.chain-1", strings.Repeat("a", maxLength))
+	_, err = NamespaceFromDBName("my.chain-1_", dbName)
+	assert.EqualError(t, err, "the namespace of database ["+dbName+"] cannot be recovered as it was truncated")
+}
+
 func TestConstructedNamespaceDBName(t *testing.T) {
 	// === SCENARIO 1: chainName_ns$$coll ===
 
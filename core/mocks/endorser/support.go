@@ -23,18 +23,21 @@ type MockSupport struct {
 	IsSysCCAndNotInvokableExternalRv bool
 	IsSysCCRv                        bool
 	ExecuteCDSResp                   *pb.Response
-	ExecuteCDSEvent                  *pb.ChaincodeEvent
+	ExecuteCDSEvents                 []*pb.ChaincodeEvent
 	ExecuteCDSError                  error
 	ExecuteResp                      *pb.Response
-	ExecuteEvent                     *pb.ChaincodeEvent
+	ExecuteEvents                    []*pb.ChaincodeEvent
 	ExecuteError                     error
 	ChaincodeDefinitionRv            ccprovider.ChaincodeDefinition
 	ChaincodeDefinitionError         error
 	GetTxSimulatorRv                 *mc.MockTxSim
 	GetTxSimulatorErr                error
+	GetQueryExecutorRv               ledger.QueryExecutor
+	GetQueryExecutorErr              error
 	CheckInstantiationPolicyError    error
 	GetTransactionByIDErr            error
 	CheckACLErr                      error
+	CheckEvaluateACLErr              error
 	SysCCMap                         map[string]struct{}
 	IsJavaRV                         bool
 	IsJavaErr                        error
@@ -73,6 +76,10 @@ func (s *MockSupport) GetTxSimulator(ledgername string, txid string) (ledger.TxS
 	return args.Get(0).(ledger.TxSimulator), args.Error(1)
 }
 
+func (s *MockSupport) GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error) {
+	return s.GetQueryExecutorRv, s.GetQueryExecutorErr
+}
+
 func (s *MockSupport) GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error) {
 	return nil, nil
 }
@@ -94,12 +101,12 @@ func (s *MockSupport) IsSysCC(name string) bool {
 	return s.IsSysCCRv
 }
 
-func (s *MockSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error) {
-	return s.ExecuteCDSResp, s.ExecuteCDSEvent, s.ExecuteCDSError
+func (s *MockSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, []*pb.ChaincodeEvent, error) {
+	return s.ExecuteCDSResp, s.ExecuteCDSEvents, s.ExecuteCDSError
 }
 
-func (s *MockSupport) Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
-	return s.ExecuteResp, s.ExecuteEvent, s.ExecuteError
+func (s *MockSupport) Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeInput) (*pb.Response, []*pb.ChaincodeEvent, error) {
+	return s.ExecuteResp, s.ExecuteEvents, s.ExecuteError
 }
 
 func (s *MockSupport) GetChaincodeDeploymentSpecFS(cds *pb.ChaincodeDeploymentSpec) (*pb.ChaincodeDeploymentSpec, error) {
@@ -114,6 +121,10 @@ func (s *MockSupport) CheckACL(signedProp *pb.SignedProposal, chdr *common.Chann
 	return s.CheckACLErr
 }
 
+func (s *MockSupport) CheckEvaluateACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader) error {
+	return s.CheckEvaluateACLErr
+}
+
 func (s *MockSupport) IsJavaCC(buf []byte) (bool, error) {
 	return s.IsJavaRV, s.IsJavaErr
 }
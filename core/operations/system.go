@@ -144,6 +144,12 @@ func (s *System) RegisterChecker(component string, checker healthz.HealthChecker
 	return s.healthHandler.RegisterChecker(component, checker)
 }
 
+// RegisterHandler registers an administrative handler for the given path. Like the
+// logging handler, the handler requires a client certificate when TLS is enabled.
+func (s *System) RegisterHandler(path string, handler http.Handler) {
+	s.mux.Handle(path, s.handlerChain(handler, s.options.TLS.Enabled))
+}
+
 func (s *System) initializeServer() {
 	s.mux = http.NewServeMux()
 	s.httpServer = &http.Server{
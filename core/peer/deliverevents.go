@@ -241,12 +241,16 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
 		}
 
-		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
+		ccEvents, err := utils.GetChaincodeActionEvents(caPayload)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
 		}
 
-		if ccEvent.GetChaincodeId() != "" {
+		// each event of the action is exposed as a filtered action of its own
+		for _, ccEvent := range ccEvents {
+			if ccEvent.GetChaincodeId() == "" {
+				continue
+			}
 			filteredAction := &peer.FilteredChaincodeAction{
 				ChaincodeEvent: &peer.ChaincodeEvent{
 					TxId:        ccEvent.TxId,
@@ -167,6 +167,33 @@ func TestFilteredBlockResponseSenderIsFiltered(t *testing.T) {
 	assert.True(t, filtered.IsFiltered(), "should return true from IsFiltered")
 }
 
+func TestToFilteredActionsMultipleEvents(t *testing.T) {
+	events := []*peer.ChaincodeEvent{
+		{ChaincodeId: "mycc", TxId: "txid", EventName: "event1", Payload: []byte("payload1")},
+		{ChaincodeId: "mycc", TxId: "txid", EventName: "event2", Payload: []byte("payload2")},
+	}
+	actionBytes := utils.MarshalOrPanic(&peer.ChaincodeAction{
+		ChaincodeId:     &peer.ChaincodeID{Name: "mycc"},
+		Events:          utils.MarshalOrPanic(events[0]),
+		ChaincodeEvents: events,
+	})
+	chaincodeActionPayload := &peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: utils.MarshalOrPanic(&peer.ProposalResponsePayload{Extension: actionBytes}),
+		},
+	}
+	ta := transactionActions{{Payload: utils.MarshalOrPanic(chaincodeActionPayload)}}
+
+	filteredActions, err := ta.toFilteredActions()
+	assert.NoError(t, err)
+	chaincodeActions := filteredActions.TransactionActions.ChaincodeActions
+	assert.Len(t, chaincodeActions, 2)
+	for i, event := range events {
+		// the payloads of the events are filtered out
+		assert.Equal(t, &peer.ChaincodeEvent{ChaincodeId: "mycc", TxId: "txid", EventName: event.EventName}, chaincodeActions[i].ChaincodeEvent)
+	}
+}
+
 func TestEventsServer_DeliverFiltered(t *testing.T) {
 	viper.Set("peer.authentication.timewindow", "1s")
 	tests := []testCase{
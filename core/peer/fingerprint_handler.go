@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/httputil"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// StateFingerprintSource is the subset of the ledger of a channel that
+// serves the state fingerprints
+type StateFingerprintSource interface {
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error)
+}
+
+// StateFingerprintHandler serves the state fingerprint of a channel after a given block,
+// or after the last committed block if none is specified, on GET requests
+type StateFingerprintHandler struct {
+	Ledgers func(channel string) StateFingerprintSource
+}
+
+// StateFingerprintResponse is the body of the response to a successful request
+type StateFingerprintResponse struct {
+	Channel           string `json:"channel"`
+	BlockNumber       uint64 `json:"block_number"`
+	PublicState       string `json:"public_state"`
+	PrivateDataHashes string `json:"private_data_hashes"`
+}
+
+func (h *StateFingerprintHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		httputil.SendJSONResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	channel := req.URL.Query().Get("channel")
+	if channel == "" {
+		httputil.SendJSONResponse(resp, http.StatusNotFound, fmt.Errorf("channel is not specified"))
+		return
+	}
+	ledger := h.Ledgers(channel)
+	if ledger == nil {
+		httputil.SendJSONResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s does not exist", channel))
+		return
+	}
+
+	var blockNum uint64
+	if block := req.URL.Query().Get("block"); block != "" {
+		var err error
+		if blockNum, err = strconv.ParseUint(block, 10, 64); err != nil {
+			httputil.SendJSONResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid block number %s", block))
+			return
+		}
+	} else {
+		bcInfo, err := ledger.GetBlockchainInfo()
+		if err != nil {
+			httputil.SendJSONResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		if bcInfo.Height == 0 {
+			httputil.SendJSONResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s has no blocks", channel))
+			return
+		}
+		blockNum = bcInfo.Height - 1
+	}
+
+	fingerprint, err := ledger.GetStateFingerprint(blockNum)
+	if err != nil {
+		httputil.SendJSONResponse(resp, http.StatusNotFound, err)
+		return
+	}
+	httputil.SendJSONResponse(resp, http.StatusOK, &StateFingerprintResponse{
+		Channel:           channel,
+		BlockNumber:       fingerprint.BlockNumber,
+		PublicState:       hex.EncodeToString(fingerprint.PublicState),
+		PrivateDataHashes: hex.EncodeToString(fingerprint.PrivateDataHashes),
+	})
+}
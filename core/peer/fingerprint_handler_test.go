@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/httputil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type fingerprintSourceStub struct {
+	height uint64
+}
+
+func (s *fingerprintSourceStub) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	return &common.BlockchainInfo{Height: s.height}, nil
+}
+
+func (s *fingerprintSourceStub) GetStateFingerprint(blockNum uint64) (*common.StateFingerprint, error) {
+	if blockNum >= s.height {
+		return nil, errors.New("block has not been committed yet")
+	}
+	return &common.StateFingerprint{
+		BlockNumber:       blockNum,
+		PublicState:       []byte{1, 2},
+		PrivateDataHashes: []byte{3, 4},
+	}, nil
+}
+
+func TestStateFingerprintHandler(t *testing.T) {
+	handler := &StateFingerprintHandler{
+		Ledgers: func(channel string) StateFingerprintSource {
+			if channel != "mychannel" {
+				return nil
+			}
+			return &fingerprintSourceStub{height: 5}
+		},
+	}
+
+	var tests = []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+		expected     *StateFingerprintResponse
+		expectedErr  string
+	}{
+		{
+			name:         "latest block",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint?channel=mychannel",
+			expectedCode: http.StatusOK,
+			expected:     &StateFingerprintResponse{Channel: "mychannel", BlockNumber: 4, PublicState: "0102", PrivateDataHashes: "0304"},
+		},
+		{
+			name:         "given block",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint?channel=mychannel&block=2",
+			expectedCode: http.StatusOK,
+			expected:     &StateFingerprintResponse{Channel: "mychannel", BlockNumber: 2, PublicState: "0102", PrivateDataHashes: "0304"},
+		},
+		{
+			name:         "uncommitted block",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint?channel=mychannel&block=5",
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "block has not been committed yet",
+		},
+		{
+			name:         "invalid block",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint?channel=mychannel&block=foo",
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid block number foo",
+		},
+		{
+			name:         "missing channel",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint",
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "channel is not specified",
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodGet,
+			url:          "/ledger/fingerprint?channel=foo",
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "channel foo does not exist",
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			url:          "/ledger/fingerprint?channel=mychannel",
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid request method: POST",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(test.method, test.url, nil))
+			assert.Equal(t, test.expectedCode, resp.Code)
+			assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+			if test.expected != nil {
+				fingerprint := &StateFingerprintResponse{}
+				assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), fingerprint))
+				assert.Equal(t, test.expected, fingerprint)
+				return
+			}
+			errResp := &httputil.ErrorResponse{}
+			assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+			assert.Equal(t, test.expectedErr, errResp.Error)
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/transientstore"
@@ -137,6 +138,19 @@ func (sp *storeProvider) OpenStore(ledgerID string) (transientstore.Store, error
 	return store, err
 }
 
+// DropStore forgets the transient store of the given ledger and deletes its private write sets
+func (sp *storeProvider) DropStore(ledgerID string) error {
+	sp.Lock()
+	defer sp.Unlock()
+	store, ok := sp.stores[ledgerID]
+	if !ok {
+		return nil
+	}
+	store.Shutdown()
+	delete(sp.stores, ledgerID)
+	return sp.StoreProvider.DropLedger(ledgerID)
+}
+
 func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
 	err := cs.ConfigtxValidator().Validate(configtx)
 	if err != nil {
@@ -198,6 +212,31 @@ func (cs *chainSupport) Reader() blockledger.Reader {
 	return fileledger.NewFileLedger(fileLedgerBlockStore{cs.ledger})
 }
 
+// ChaincodeEventBlocks returns the numbers of the blocks of the given range in which a valid
+// transaction set the given chaincode event, as found in the chaincode event index of the ledger
+func (cs *chainSupport) ChaincodeEventBlocks(chaincodeID, eventName string, startBlockNum, endBlockNum uint64) ([]uint64, error) {
+	itr, err := cs.ledger.GetChaincodeEvents(chaincodeID, eventName, startBlockNum, endBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+	var blockNums []uint64
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return blockNums, nil
+		}
+		// a block appears once for each of its events
+		blockNum := result.(*pb.ChaincodeEventInfo).BlockNumber
+		if len(blockNums) == 0 || blockNums[len(blockNums)-1] != blockNum {
+			blockNums = append(blockNums, blockNum)
+		}
+	}
+}
+
 // Errored returns a channel that can be used to determine
 // if a backing resource has errored. At this point in time,
 // the peer does not have any error conditions that lead to
@@ -523,6 +562,35 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// UnjoinChannel removes the chain with the given ID from the peer. It deregisters the chaincode lifecycle
+// listeners of the channel, stops its block delivery and gossip, which closes its ledger, and deletes the
+// ledger along with its transient store
+func UnjoinChannel(cid string) error {
+	chains.Lock()
+	_, ok := chains.list[cid]
+	if !ok {
+		chains.Unlock()
+		return errors.Errorf("channel [%s] does not exist", cid)
+	}
+	delete(chains.list, cid)
+	chains.Unlock()
+
+	peerLogger.Infof("Unjoining channel [%s]", cid)
+	// the chaincode lifecycle listeners of the channel are deregistered before its ledger is closed,
+	// once the deploy and install operations in progress on the ledger are done
+	cceventmgmt.GetMgr().Deregister(cid)
+	// closing the channel in gossip stops its state provider, which closes the ledger through the committer
+	service.GetGossipService().CloseChannel(cid)
+	if err := TransientStoreFactory.DropStore(cid); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to drop the transient store of channel [%s]", cid))
+	}
+	if err := ledgermgmt.RemoveLedger(cid); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to remove the ledger of channel [%s]", cid))
+	}
+	peerLogger.Infof("Unjoined channel [%s]", cid)
+	return nil
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -18,12 +18,14 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
+	ccmock "github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
 	fakeconfig "github.com/hyperledger/fabric/core/peer/mocks"
@@ -33,6 +35,8 @@ import (
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -45,6 +49,11 @@ func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, _ deliverclient.Co
 	return nil
 }
 
+// Endpoint returns the ordering service endpoint blocks of the chain are pulled from
+func (ds *mockDeliveryClient) Endpoint(chainID string) string {
+	return ""
+}
+
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, f func()) error {
@@ -195,6 +204,19 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatalf("incorrect number of channels")
 	}
 
+	// unjoining the channel removes its ledger and transient store
+	assert.NotNil(t, TransientStoreFactory.StoreForChannel(testChainID))
+	err = UnjoinChannel(testChainID)
+	assert.NoError(t, err)
+	assert.Nil(t, GetLedger(testChainID))
+	assert.Nil(t, TransientStoreFactory.StoreForChannel(testChainID))
+	assert.Empty(t, GetChannelsInfo())
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.NotContains(t, ledgerIDs, testChainID)
+	err = UnjoinChannel(testChainID)
+	assert.EqualError(t, err, fmt.Sprintf("channel [%s] does not exist", testChainID))
+
 	// cleanup the chain referenes to enable execution with -count n
 	chains.Lock()
 	chains.list = map[string]*chain{}
@@ -219,6 +241,36 @@ func TestDeliverSupportManager(t *testing.T) {
 	assert.NotNil(t, chainSupport, "chain support should not be nil")
 }
 
+func TestChaincodeEventBlocks(t *testing.T) {
+	itr := &ccmock.QueryResultsIterator{}
+	for i, blockNum := range []uint64{2, 2, 5, 7, 7} {
+		itr.NextReturnsOnCall(i, &pb.ChaincodeEventInfo{BlockNumber: blockNum}, nil)
+	}
+	fakeLedger := &ccmock.PeerLedger{}
+	fakeLedger.GetChaincodeEventsReturns(itr, nil)
+	cs := &chainSupport{ledger: fakeLedger}
+
+	blockNums, err := cs.ChaincodeEventBlocks("cc1", "transfer", 1, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2, 5, 7}, blockNums)
+	chaincodeID, eventName, startBlockNum, endBlockNum := fakeLedger.GetChaincodeEventsArgsForCall(0)
+	assert.Equal(t, "cc1", chaincodeID)
+	assert.Equal(t, "transfer", eventName)
+	assert.Equal(t, uint64(1), startBlockNum)
+	assert.Equal(t, uint64(8), endBlockNum)
+	assert.Equal(t, 1, itr.CloseCallCount())
+
+	fakeLedger.GetChaincodeEventsReturns(nil, errors.New("the chaincode event index is not enabled"))
+	_, err = cs.ChaincodeEventBlocks("cc1", "transfer", 1, 8)
+	assert.EqualError(t, err, "the chaincode event index is not enabled")
+
+	itr = &ccmock.QueryResultsIterator{}
+	itr.NextReturns(nil, errors.New("boom"))
+	fakeLedger.GetChaincodeEventsReturns(itr, nil)
+	_, err = cs.ChaincodeEventBlocks("cc1", "transfer", 1, 8)
+	assert.EqualError(t, err, "boom")
+}
+
 func TestGossipChannelConfigOrdererAddressesByOrgs(t *testing.T) {
 	ordererOrg1 := &fakeconfig.OrdererOrg{}
 	ordererOrg2 := &fakeconfig.OrdererOrg{}
@@ -83,6 +83,11 @@ func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, _ deliverclient.Co
 	return nil
 }
 
+// Endpoint returns the ordering service endpoint blocks of the chain are pulled from
+func (ds *mockDeliveryClient) Endpoint(chainID string) string {
+	return ""
+}
+
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, f func()) error {
@@ -10,6 +10,18 @@ import (
 )
 
 type ChaincodeStub struct {
+	AddEventStub        func(string, []byte) error
+	addEventMutex       sync.RWMutex
+	addEventArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	addEventReturns struct {
+		result1 error
+	}
+	addEventReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateCompositeKeyStub        func(string, []string) (string, error)
 	createCompositeKeyMutex       sync.RWMutex
 	createCompositeKeyArgsForCall []struct {
@@ -138,6 +150,51 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithOptionsStub        func(string, *peer.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyWithOptionsMutex       sync.RWMutex
+	getHistoryForKeyWithOptionsArgsForCall []struct {
+		arg1 string
+		arg2 *peer.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}
+	getHistoryForKeyWithOptionsReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithOptionsReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	GetMultiplePrivateDataStub        func(string, ...string) ([][]byte, error)
+	getMultiplePrivateDataMutex       sync.RWMutex
+	getMultiplePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getMultiplePrivateDataReturns struct {
+		result1 [][]byte
+		result2 error
+	}
+	getMultiplePrivateDataReturnsOnCall map[int]struct {
+		result1 [][]byte
+		result2 error
+	}
+	GetMultipleStatesStub        func(...string) ([][]byte, error)
+	getMultipleStatesMutex       sync.RWMutex
+	getMultipleStatesArgsForCall []struct {
+		arg1 []string
+	}
+	getMultipleStatesReturns struct {
+		result1 [][]byte
+		result2 error
+	}
+	getMultipleStatesReturnsOnCall map[int]struct {
+		result1 [][]byte
+		result2 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -279,6 +336,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateAtHeightStub        func(string, uint64) ([]byte, error)
+	getStateAtHeightMutex       sync.RWMutex
+	getStateAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	getStateAtHeightReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAtHeightReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetStateByPartialCompositeKeyStub        func(string, []string) (shim.StateQueryIteratorInterface, error)
 	getStateByPartialCompositeKeyMutex       sync.RWMutex
 	getStateByPartialCompositeKeyArgsForCall []struct {
@@ -325,6 +396,21 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByRangeAtHeightStub        func(string, string, uint64) (shim.StateQueryIteratorInterface, error)
+	getStateByRangeAtHeightMutex       sync.RWMutex
+	getStateByRangeAtHeightArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateByRangeAtHeightReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByRangeAtHeightReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByRangeWithPaginationStub        func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
 	getStateByRangeWithPaginationMutex       sync.RWMutex
 	getStateByRangeWithPaginationArgsForCall []struct {
@@ -494,6 +580,72 @@ type ChaincodeStub struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ChaincodeStub) AddEvent(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.addEventMutex.Lock()
+	ret, specificReturn := fake.addEventReturnsOnCall[len(fake.addEventArgsForCall)]
+	fake.addEventArgsForCall = append(fake.addEventArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	fake.recordInvocation("AddEvent", []interface{}{arg1, arg2Copy})
+	fake.addEventMutex.Unlock()
+	if fake.AddEventStub != nil {
+		return fake.AddEventStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.addEventReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) AddEventCallCount() int {
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
+	return len(fake.addEventArgsForCall)
+}
+
+func (fake *ChaincodeStub) AddEventCalls(stub func(string, []byte) error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = stub
+}
+
+func (fake *ChaincodeStub) AddEventArgsForCall(i int) (string, []byte) {
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
+	argsForCall := fake.addEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) AddEventReturns(result1 error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = nil
+	fake.addEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) AddEventReturnsOnCall(i int, result1 error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = nil
+	if fake.addEventReturnsOnCall == nil {
+		fake.addEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChaincodeStub) CreateCompositeKey(arg1 string, arg2 []string) (string, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1123,6 +1275,202 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptions(arg1 string, arg2 *peer.HistoryQueryOptions, arg3 int32, arg4 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithOptionsReturnsOnCall[len(fake.getHistoryForKeyWithOptionsArgsForCall)]
+	fake.getHistoryForKeyWithOptionsArgsForCall = append(fake.getHistoryForKeyWithOptionsArgsForCall, struct {
+		arg1 string
+		arg2 *peer.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyWithOptions", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	if fake.GetHistoryForKeyWithOptionsStub != nil {
+		return fake.GetHistoryForKeyWithOptionsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyWithOptionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsCallCount() int {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithOptionsArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsCalls(stub func(string, *peer.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsArgsForCall(i int) (string, *peer.HistoryQueryOptions, int32, string) {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	fake.getHistoryForKeyWithOptionsReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	if fake.getHistoryForKeyWithOptionsReturnsOnCall == nil {
+		fake.getHistoryForKeyWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithOptionsReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateData(arg1 string, arg2 ...string) ([][]byte, error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	ret, specificReturn := fake.getMultiplePrivateDataReturnsOnCall[len(fake.getMultiplePrivateDataArgsForCall)]
+	fake.getMultiplePrivateDataArgsForCall = append(fake.getMultiplePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	fake.recordInvocation("GetMultiplePrivateData", []interface{}{arg1, arg2})
+	fake.getMultiplePrivateDataMutex.Unlock()
+	if fake.GetMultiplePrivateDataStub != nil {
+		return fake.GetMultiplePrivateDataStub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMultiplePrivateDataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataCallCount() int {
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	return len(fake.getMultiplePrivateDataArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataCalls(stub func(string, ...string) ([][]byte, error)) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = stub
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataArgsForCall(i int) (string, []string) {
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	argsForCall := fake.getMultiplePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataReturns(result1 [][]byte, result2 error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = nil
+	fake.getMultiplePrivateDataReturns = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataReturnsOnCall(i int, result1 [][]byte, result2 error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = nil
+	if fake.getMultiplePrivateDataReturnsOnCall == nil {
+		fake.getMultiplePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 [][]byte
+			result2 error
+		})
+	}
+	fake.getMultiplePrivateDataReturnsOnCall[i] = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultipleStates(arg1 ...string) ([][]byte, error) {
+	fake.getMultipleStatesMutex.Lock()
+	ret, specificReturn := fake.getMultipleStatesReturnsOnCall[len(fake.getMultipleStatesArgsForCall)]
+	fake.getMultipleStatesArgsForCall = append(fake.getMultipleStatesArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	fake.recordInvocation("GetMultipleStates", []interface{}{arg1})
+	fake.getMultipleStatesMutex.Unlock()
+	if fake.GetMultipleStatesStub != nil {
+		return fake.GetMultipleStatesStub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMultipleStatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesCallCount() int {
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
+	return len(fake.getMultipleStatesArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesCalls(stub func(...string) ([][]byte, error)) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = stub
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesArgsForCall(i int) []string {
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
+	argsForCall := fake.getMultipleStatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesReturns(result1 [][]byte, result2 error) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = nil
+	fake.getMultipleStatesReturns = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesReturnsOnCall(i int, result1 [][]byte, result2 error) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = nil
+	if fake.getMultipleStatesReturnsOnCall == nil {
+		fake.getMultipleStatesReturnsOnCall = make(map[int]struct {
+			result1 [][]byte
+			result2 error
+		})
+	}
+	fake.getMultipleStatesReturnsOnCall[i] = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1763,6 +2111,70 @@ func (fake *ChaincodeStub) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateAtHeight(arg1 string, arg2 uint64) ([]byte, error) {
+	fake.getStateAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateAtHeightReturnsOnCall[len(fake.getStateAtHeightArgsForCall)]
+	fake.getStateAtHeightArgsForCall = append(fake.getStateAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("GetStateAtHeight", []interface{}{arg1, arg2})
+	fake.getStateAtHeightMutex.Unlock()
+	if fake.GetStateAtHeightStub != nil {
+		return fake.GetStateAtHeightStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightCallCount() int {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	return len(fake.getStateAtHeightArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightCalls(stub func(string, uint64) ([]byte, error)) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightArgsForCall(i int) (string, uint64) {
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightReturns(result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	fake.getStateAtHeightReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateAtHeightReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateAtHeightMutex.Lock()
+	defer fake.getStateAtHeightMutex.Unlock()
+	fake.GetStateAtHeightStub = nil
+	if fake.getStateAtHeightReturnsOnCall == nil {
+		fake.getStateAtHeightReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAtHeightReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKey(arg1 string, arg2 []string) (shim.StateQueryIteratorInterface, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1970,6 +2382,71 @@ func (fake *ChaincodeStub) GetStateByRangeReturnsOnCall(i int, result1 shim.Stat
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByRangeAtHeight(arg1 string, arg2 string, arg3 uint64) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	ret, specificReturn := fake.getStateByRangeAtHeightReturnsOnCall[len(fake.getStateByRangeAtHeightArgsForCall)]
+	fake.getStateByRangeAtHeightArgsForCall = append(fake.getStateByRangeAtHeightArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateByRangeAtHeight", []interface{}{arg1, arg2, arg3})
+	fake.getStateByRangeAtHeightMutex.Unlock()
+	if fake.GetStateByRangeAtHeightStub != nil {
+		return fake.GetStateByRangeAtHeightStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByRangeAtHeightReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightCallCount() int {
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
+	return len(fake.getStateByRangeAtHeightArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightCalls(stub func(string, string, uint64) (shim.StateQueryIteratorInterface, error)) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightArgsForCall(i int) (string, string, uint64) {
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
+	argsForCall := fake.getStateByRangeAtHeightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = nil
+	fake.getStateByRangeAtHeightReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAtHeightReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAtHeightMutex.Lock()
+	defer fake.getStateByRangeAtHeightMutex.Unlock()
+	fake.GetStateByRangeAtHeightStub = nil
+	if fake.getStateByRangeAtHeightReturnsOnCall == nil {
+		fake.getStateByRangeAtHeightReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByRangeAtHeightReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByRangeWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
 	fake.getStateByRangeWithPaginationMutex.Lock()
 	ret, specificReturn := fake.getStateByRangeWithPaginationReturnsOnCall[len(fake.getStateByRangeWithPaginationArgsForCall)]
@@ -2784,6 +3261,8 @@ func (fake *ChaincodeStub) SplitCompositeKeyReturnsOnCall(i int, result1 string,
 func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
 	fake.createCompositeKeyMutex.RLock()
 	defer fake.createCompositeKeyMutex.RUnlock()
 	fake.delPrivateDataMutex.RLock()
@@ -2806,6 +3285,12 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
@@ -2826,12 +3311,16 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getSignedProposalMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateAtHeightMutex.RLock()
+	defer fake.getStateAtHeightMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getStateByRangeMutex.RLock()
 	defer fake.getStateByRangeMutex.RUnlock()
+	fake.getStateByRangeAtHeightMutex.RLock()
+	defer fake.getStateByRangeAtHeightMutex.RUnlock()
 	fake.getStateByRangeWithPaginationMutex.RLock()
 	defer fake.getStateByRangeWithPaginationMutex.RUnlock()
 	fake.getStateValidationParameterMutex.RLock()
@@ -40,6 +40,9 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetStateAtHeight returns the last modification of a key up to a block
+// - GetStateFingerprint returns the fingerprint of the world state after a block
+// - GetChaincodeEvents returns a page of the chaincode events set in a range of blocks
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -48,11 +51,14 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo        string = "GetChainInfo"
+	GetBlockByNumber    string = "GetBlockByNumber"
+	GetBlockByHash      string = "GetBlockByHash"
+	GetTransactionByID  string = "GetTransactionByID"
+	GetBlockByTxID      string = "GetBlockByTxID"
+	GetStateAtHeight    string = "GetStateAtHeight"
+	GetStateFingerprint string = "GetStateFingerprint"
+	GetChaincodeEvents  string = "GetChaincodeEvents"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +77,15 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetStateAtHeight: Return the last modification of the key in args[3] of the
+//   chaincode in args[2] up to and including the block number in args[4]
+// # GetStateFingerprint: Return the StateFingerprint of the world state right
+//   after the block number in args[2] was committed
+// # GetChaincodeEvents: Return a ChaincodeEventsPage of at most args[6] events
+//   named args[3] of the chaincode in args[2], set by the valid transactions of
+//   the blocks args[4] to args[5], continuing after the optional bookmark in
+//   args[7] returned by the previous page, which is the position of the next
+//   event as "block:transaction:event"
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -84,6 +99,14 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
 
+	if fname == GetStateAtHeight && len(args) < 5 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
+	if fname == GetChaincodeEvents && len(args) < 7 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
 	targetLedger := peer.GetLedger(cid)
 	if targetLedger == nil {
 		return shim.Error(fmt.Sprintf("Invalid chain ID, %s", cid))
@@ -115,6 +138,16 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetStateAtHeight:
+		return getStateAtHeight(targetLedger, args[2], args[3], args[4])
+	case GetStateFingerprint:
+		return getStateFingerprint(targetLedger, args[2])
+	case GetChaincodeEvents:
+		var bookmark []byte
+		if len(args) > 7 {
+			bookmark = args[7]
+		}
+		return getChaincodeEvents(targetLedger, args[2], args[3], args[4], args[5], args[6], bookmark)
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +247,112 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getStateAtHeight(vledger ledger.PeerLedger, namespace, key, number []byte) pb.Response {
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor with error %s", err))
+	}
+	keyModification, err := hqe.GetStateAtHeight(string(namespace), string(key), bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state of key %s in namespace %s at block %d, error %s", string(key), string(namespace), bnum, err))
+	}
+	// A key that did not exist at the block is returned as an empty payload
+	if keyModification == nil {
+		return shim.Success(nil)
+	}
+
+	bytes, err := utils.Marshal(keyModification)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func getStateFingerprint(vledger ledger.PeerLedger, number []byte) pb.Response {
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+
+	fingerprint, err := vledger.GetStateFingerprint(bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state fingerprint of block %d, error %s", bnum, err))
+	}
+
+	bytes, err := utils.Marshal(fingerprint)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func getChaincodeEvents(vledger ledger.PeerLedger, chaincodeID, eventName, startNumber, endNumber, size, bookmark []byte) pb.Response {
+	startBlockNum, err := strconv.ParseUint(string(startNumber), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+	}
+	endBlockNum, err := strconv.ParseUint(string(endNumber), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+	}
+	pageSize, err := strconv.Atoi(string(size))
+	if err != nil || pageSize <= 0 {
+		return shim.Error(fmt.Sprintf("Invalid page size %s", string(size)))
+	}
+
+	// the bookmark is the position of the first event of the page
+	var bookmarkBlockNum, bookmarkTxNum, bookmarkEventNum uint64
+	if len(bookmark) > 0 {
+		if _, err := fmt.Sscanf(string(bookmark), "%d:%d:%d", &bookmarkBlockNum, &bookmarkTxNum, &bookmarkEventNum); err != nil {
+			return shim.Error(fmt.Sprintf("Invalid bookmark %s", string(bookmark)))
+		}
+		if bookmarkBlockNum > startBlockNum {
+			startBlockNum = bookmarkBlockNum
+		}
+	}
+
+	itr, err := vledger.GetChaincodeEvents(string(chaincodeID), string(eventName), startBlockNum, endBlockNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get chaincode events %s of chaincode %s, error %s", string(eventName), string(chaincodeID), err))
+	}
+	defer itr.Close()
+
+	page := &pb.ChaincodeEventsPage{}
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get chaincode events %s of chaincode %s, error %s", string(eventName), string(chaincodeID), err))
+		}
+		if result == nil {
+			break
+		}
+		eventInfo := result.(*pb.ChaincodeEventInfo)
+		if eventInfo.BlockNumber == bookmarkBlockNum && (eventInfo.TxNumber < bookmarkTxNum ||
+			eventInfo.TxNumber == bookmarkTxNum && eventInfo.EventNumber < bookmarkEventNum) {
+			continue
+		}
+		if len(page.Events) == pageSize {
+			page.Bookmark = fmt.Sprintf("%d:%d:%d", eventInfo.BlockNumber, eventInfo.TxNumber, eventInfo.EventNumber)
+			break
+		}
+		page.Events = append(page.Events, eventInfo)
+	}
+
+	bytes, err := utils.Marshal(page)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -29,6 +30,7 @@ import (
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -328,6 +330,178 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetStateAtHeight(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", nil)
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addBlockForTesting(t, chainid)
+
+	args := [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAtHeight failed with err: %s", res.Message)
+	keyModification := &queryresult.KeyModification{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, keyModification))
+	assert.Equal(t, []byte("value1"), keyModification.Value)
+
+	// key1 did not exist at the genesis block
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("0")}
+	prop = resetProvider(resources.Qscc_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAtHeight failed with err: %s", res.Message)
+	assert.Empty(t, res.Payload)
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("2")}
+	prop = resetProvider(resources.Qscc_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed for a block that was not committed yet")
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("foo")}
+	prop = resetProvider(resources.Qscc_GetStateAtHeight, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed for an invalid block number")
+
+	args = [][]byte{[]byte(GetStateAtHeight), []byte(chainid), []byte("ns1"), []byte("key1")}
+	res = stub.MockInvoke("5", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAtHeight should have failed due to incorrect number of arguments")
+}
+
+func TestQueryGetStateFingerprint(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addBlockForTesting(t, chainid)
+
+	args := [][]byte{[]byte(GetStateFingerprint), []byte(chainid), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetStateFingerprint, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateFingerprint failed with err: %s", res.Message)
+	fingerprint := &common.StateFingerprint{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, fingerprint))
+	assert.Equal(t, uint64(1), fingerprint.BlockNumber)
+	assert.Len(t, fingerprint.PublicState, 32)
+
+	args = [][]byte{[]byte(GetStateFingerprint), []byte(chainid), []byte("2")}
+	prop = resetProvider(resources.Qscc_GetStateFingerprint, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateFingerprint should have failed for a block that was not committed yet")
+
+	args = [][]byte{[]byte(GetStateFingerprint), []byte(chainid), []byte("foo")}
+	prop = resetProvider(resources.Qscc_GetStateFingerprint, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateFingerprint should have failed for an invalid block number")
+}
+
+func TestQueryGetChaincodeEvents(t *testing.T) {
+	chainid := "mytestchainid11"
+	path := tempDir(t, "test11")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.blockchain.enableChaincodeEventIndex", true)
+	defer viper.Set("ledger.blockchain.enableChaincodeEventIndex", nil)
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addBlockWithChaincodeEventsForTesting(t, chainid)
+
+	getPage := func(txid string, args ...string) *peer2.ChaincodeEventsPage {
+		invokeArgs := [][]byte{[]byte(GetChaincodeEvents), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetChaincodeEvents, chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal(txid, invokeArgs, prop)
+		assert.Equal(t, int32(shim.OK), res.Status, "GetChaincodeEvents failed with err: %s", res.Message)
+		page := &peer2.ChaincodeEventsPage{}
+		assert.NoError(t, proto.Unmarshal(res.Payload, page))
+		return page
+	}
+
+	page := getPage("1", "cc1", "transfer", "0", "10", "2")
+	assert.Len(t, page.Events, 2)
+	assert.Equal(t, []byte("tx0"), page.Events[0].ChaincodeEvent.Payload)
+	assert.Equal(t, []byte("tx1"), page.Events[1].ChaincodeEvent.Payload)
+	assert.Equal(t, "1:2:0", page.Bookmark)
+
+	page = getPage("2", "cc1", "transfer", "0", "10", "2", page.Bookmark)
+	assert.Len(t, page.Events, 1)
+	assert.Equal(t, uint64(1), page.Events[0].BlockNumber)
+	assert.Equal(t, uint64(2), page.Events[0].TxNumber)
+	assert.Equal(t, []byte("tx2"), page.Events[0].ChaincodeEvent.Payload)
+	assert.Empty(t, page.Bookmark)
+
+	page = getPage("3", "cc1", "transfer", "2", "10", "2")
+	assert.Empty(t, page.Events)
+
+	// the bookmark skips the events set before it by the same transaction
+	page = getPage("4", "cc1", "transfer", "0", "10", "2", "1:0:1")
+	assert.Len(t, page.Events, 2)
+	assert.Equal(t, []byte("tx1"), page.Events[0].ChaincodeEvent.Payload)
+	assert.Equal(t, []byte("tx2"), page.Events[1].ChaincodeEvent.Payload)
+	assert.Empty(t, page.Bookmark)
+
+	for i, args := range [][]string{
+		{"cc1", "transfer", "0", "10"},
+		{"cc1", "transfer", "foo", "10", "2"},
+		{"cc1", "transfer", "0", "10", "0"},
+		{"cc1", "transfer", "0", "10", "2", "foo"},
+		{"cc1", "transfer", "0", "10", "2", "1:2"},
+		{"cc1", "", "0", "10", "2"},
+	} {
+		invokeArgs := [][]byte{[]byte(GetChaincodeEvents), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetChaincodeEvents, chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal(fmt.Sprintf("err%d", i), invokeArgs, prop)
+		assert.Equal(t, int32(shim.ERROR), res.Status, "GetChaincodeEvents should have failed for args %v", args)
+	}
+}
+
+func addBlockWithChaincodeEventsForTesting(t *testing.T, chainid string) *common.Block {
+	ledger := peer.GetLedger(chainid)
+	defer ledger.Close()
+
+	var txs []*testutil.TxDetails
+	for i := 0; i < 4; i++ {
+		txid := fmt.Sprintf("tx%d", i)
+		simulator, _ := ledger.NewTxSimulator(txid)
+		simulator.SetState("cc1", fmt.Sprintf("key%d", i), []byte("value"))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		tx := &testutil.TxDetails{TxID: txid, ChaincodeName: "cc1", ChaincodeVersion: "v1", SimulationResults: pubSimResBytes}
+		// the last transaction sets no event
+		if i < 3 {
+			tx.ChaincodeEvent = &peer2.ChaincodeEvent{ChaincodeId: "cc1", TxId: txid, EventName: "transfer", Payload: []byte(txid)}
+		}
+		txs = append(txs, tx)
+	}
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	block1 := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     1,
+		PreviousHash: bcInfo.CurrentBlockHash,
+		Txs:          txs,
+	}, false)
+	assert.NoError(t, ledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block1}, &ledger2.CommitOptions{}))
+	return block1
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
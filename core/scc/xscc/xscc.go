@@ -0,0 +1,363 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package xscc implements the cross-channel system chaincode, which
+// coordinates the write intents of the transactions spanning two channels
+// hosted by the same peer.
+//
+// A transaction of a source channel whose chaincode writes to a chaincode of
+// a target channel records a write intent on the source channel, holding its
+// writes on both channels, and locks the keys of the source channel it writes.
+// The intent is then prepared on the target channel, which locks the keys of
+// the target channel it writes, and committed or aborted on the source
+// channel, which acts as the coordinator, before being committed or aborted
+// likewise on the target channel. The writes of the intent are applied on
+// each channel when it is committed there.
+//
+// The endorsers of the target channel trust the endorsers of the source
+// channel for the writes of the intent to the target channel, since they were
+// produced by the simulation of the transaction on the source channel. The
+// reads of the target channel are validated when the intent is prepared.
+package xscc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// LedgerGetter gets the ledger of a channel
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ApplicationConfigRetriever retrieves the application configuration of a channel
+type ApplicationConfigRetriever interface {
+	GetApplicationConfig(cid string) (channelconfig.Application, bool)
+}
+
+// New returns an instance of XSCC.
+// Typically this is called once per peer.
+func New(ledgerGetter LedgerGetter, appConfig ApplicationConfigRetriever) *CrossChannelCoordinator {
+	return &CrossChannelCoordinator{
+		ledgerGetter: ledgerGetter,
+		appConfig:    appConfig,
+	}
+}
+
+func (c *CrossChannelCoordinator) Name() string              { return crosschannel.Namespace }
+func (c *CrossChannelCoordinator) Path() string              { return "github.com/hyperledger/fabric/core/scc/xscc" }
+func (c *CrossChannelCoordinator) InitArgs() [][]byte        { return nil }
+func (c *CrossChannelCoordinator) Chaincode() shim.Chaincode { return c }
+func (c *CrossChannelCoordinator) InvokableExternal() bool   { return true }
+func (c *CrossChannelCoordinator) InvokableCC2CC() bool      { return false }
+func (c *CrossChannelCoordinator) Enabled() bool             { return true }
+
+// CrossChannelCoordinator implements the functions coordinating the write
+// intents of the cross-channel transactions, including:
+// - Prepare records on the target channel the intent recorded on the source channel
+// - Commit commits an intent, whose writes to the channel are then applied
+// - Abort aborts an intent, whose writes are discarded
+// - GetIntent returns an intent
+type CrossChannelCoordinator struct {
+	ledgerGetter LedgerGetter
+	appConfig    ApplicationConfigRetriever
+}
+
+var logger = flogging.MustGetLogger("xscc")
+
+// Init is called once per chain when the chain is created.
+func (c *CrossChannelCoordinator) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke is called with args[0] contains the function name and args[1] the id
+// of the transaction which recorded the intent on the source channel.
+// # Prepare: Record on the channel the intent recorded on the source channel
+//   in args[2], and lock the keys it writes to the channel
+// # Commit: Commit the intent, and release the locks of the keys it writes to
+//   the channel. The intent must be prepared on the target channel before it
+//   is committed on the source channel, and it must be committed on the source
+//   channel before it is committed on the target channel
+// # Abort: Abort the intent, and release the locks of the keys it writes to
+//   the channel. On the source channel, the intent can only be aborted by the
+//   creator of the transaction which recorded it, as long as it isn't
+//   committed. On the target channel, it must be aborted on the source channel
+//   first
+// # GetIntent: Return the CrossChannelIntent
+func (c *CrossChannelCoordinator) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) < 2 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+	}
+	fname := string(args[0])
+	txID := string(args[1])
+	channelID := stub.GetChannelID()
+
+	if fname == crosschannel.Prepare && len(args) < 3 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
+	if err := c.checkCapability(channelID); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	logger.Debugf("Invoke function: %s on chain: %s", fname, channelID)
+
+	var intent *pb.CrossChannelIntent
+	var err error
+	switch fname {
+	case crosschannel.Prepare:
+		intent, err = c.prepare(stub, txID, string(args[2]))
+	case crosschannel.Commit:
+		intent, err = c.commit(stub, txID)
+	case crosschannel.Abort:
+		intent, err = c.abort(stub, txID)
+	case crosschannel.GetIntent:
+		intent, err = getIntent(stub, txID)
+	default:
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
+	}
+	if err != nil {
+		return shim.Error(fmt.Sprintf("%s of the intent of transaction %s failed on channel %s: %s", fname, txID, channelID, err))
+	}
+
+	intentBytes, err := proto.Marshal(intent)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(intentBytes)
+}
+
+func (c *CrossChannelCoordinator) checkCapability(channelID string) error {
+	ac, exists := c.appConfig.GetApplicationConfig(channelID)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", channelID)
+	}
+	if !ac.Capabilities().CrossChannelWrites() {
+		return errors.Errorf("cross-channel writes are not enabled on channel %s", channelID)
+	}
+	return nil
+}
+
+func (c *CrossChannelCoordinator) prepare(stub shim.ChaincodeStubInterface, txID, sourceChannel string) (*pb.CrossChannelIntent, error) {
+	local, err := stub.GetState(crosschannel.IntentKey(txID))
+	if err != nil {
+		return nil, err
+	}
+	if local != nil {
+		return nil, errors.New("intent is already recorded")
+	}
+
+	if err := c.checkCapability(sourceChannel); err != nil {
+		return nil, err
+	}
+	intent, err := c.getRemoteIntent(sourceChannel, txID)
+	if err != nil {
+		return nil, err
+	}
+	if intent.SourceChannel != sourceChannel || intent.TargetChannel != stub.GetChannelID() {
+		return nil, errors.Errorf("intent targets channel %s from channel %s", intent.TargetChannel, intent.SourceChannel)
+	}
+	if intent.Status != pb.CrossChannelIntent_PREPARED {
+		return nil, errors.Errorf("intent is %s on channel %s", intent.Status, sourceChannel)
+	}
+
+	lockKeys, err := intentLockKeys(intent, stub.GetChannelID())
+	if err != nil {
+		return nil, err
+	}
+	for _, lockKey := range lockKeys {
+		holder, err := stub.GetState(lockKey)
+		if err != nil {
+			return nil, err
+		}
+		if holder != nil {
+			return nil, errors.Errorf("intent writes to a key locked by transaction %s", holder)
+		}
+		if err := stub.PutState(lockKey, []byte(txID)); err != nil {
+			return nil, err
+		}
+	}
+
+	return intent, putIntent(stub, intent)
+}
+
+func (c *CrossChannelCoordinator) commit(stub shim.ChaincodeStubInterface, txID string) (*pb.CrossChannelIntent, error) {
+	intent, err := getPreparedIntent(stub, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stub.GetChannelID() == intent.SourceChannel {
+		target, err := c.getRemoteIntent(intent.TargetChannel, txID)
+		if err != nil {
+			return nil, err
+		}
+		if target.Status != pb.CrossChannelIntent_PREPARED {
+			return nil, errors.Errorf("intent is %s on channel %s", target.Status, intent.TargetChannel)
+		}
+	} else {
+		source, err := c.getRemoteIntent(intent.SourceChannel, txID)
+		if err != nil {
+			return nil, err
+		}
+		if source.Status != pb.CrossChannelIntent_COMMITTED {
+			return nil, errors.Errorf("intent is %s on channel %s", source.Status, intent.SourceChannel)
+		}
+		if !bytes.Equal(source.TargetResults, intent.TargetResults) {
+			return nil, errors.Errorf("intent doesn't match the intent of channel %s", intent.SourceChannel)
+		}
+	}
+
+	return intent, complete(stub, intent, pb.CrossChannelIntent_COMMITTED)
+}
+
+func (c *CrossChannelCoordinator) abort(stub shim.ChaincodeStubInterface, txID string) (*pb.CrossChannelIntent, error) {
+	intent, err := getPreparedIntent(stub, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stub.GetChannelID() == intent.SourceChannel {
+		creator, err := c.getTxCreator(intent.SourceChannel, txID)
+		if err != nil {
+			return nil, err
+		}
+		submitter, err := stub.GetCreator()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(creator, submitter) {
+			return nil, errors.New("intent can only be aborted by the creator of the transaction")
+		}
+	} else {
+		source, err := c.getRemoteIntent(intent.SourceChannel, txID)
+		if err != nil {
+			return nil, err
+		}
+		if source.Status != pb.CrossChannelIntent_ABORTED {
+			return nil, errors.Errorf("intent is %s on channel %s", source.Status, intent.SourceChannel)
+		}
+	}
+
+	return intent, complete(stub, intent, pb.CrossChannelIntent_ABORTED)
+}
+
+// complete sets the final status of the intent and releases the locks of the
+// keys it writes to the channel
+func complete(stub shim.ChaincodeStubInterface, intent *pb.CrossChannelIntent, status pb.CrossChannelIntent_Status) error {
+	lockKeys, err := intentLockKeys(intent, stub.GetChannelID())
+	if err != nil {
+		return err
+	}
+	for _, lockKey := range lockKeys {
+		if err := stub.DelState(lockKey); err != nil {
+			return err
+		}
+	}
+
+	intent.Status = status
+	return putIntent(stub, intent)
+}
+
+// getRemoteIntent returns the intent committed on another channel
+func (c *CrossChannelCoordinator) getRemoteIntent(channelID, txID string) (*pb.CrossChannelIntent, error) {
+	lgr := c.ledgerGetter.GetLedger(channelID)
+	if lgr == nil {
+		return nil, errors.Errorf("failed to find ledger for channel: %s", channelID)
+	}
+	qe, err := lgr.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	intentBytes, err := qe.GetState(crosschannel.Namespace, crosschannel.IntentKey(txID))
+	if err != nil {
+		return nil, err
+	}
+	if intentBytes == nil {
+		return nil, errors.Errorf("intent not found on channel %s", channelID)
+	}
+	intent := &pb.CrossChannelIntent{}
+	if err := proto.Unmarshal(intentBytes, intent); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling intent of channel %s", channelID)
+	}
+	return intent, nil
+}
+
+// getTxCreator returns the creator of a transaction committed on a channel
+func (c *CrossChannelCoordinator) getTxCreator(channelID, txID string) ([]byte, error) {
+	lgr := c.ledgerGetter.GetLedger(channelID)
+	if lgr == nil {
+		return nil, errors.Errorf("failed to find ledger for channel: %s", channelID)
+	}
+	processedTran, err := lgr.GetTransactionByID(txID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.GetPayload(processedTran.TransactionEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	return shdr.Creator, nil
+}
+
+func getIntent(stub shim.ChaincodeStubInterface, txID string) (*pb.CrossChannelIntent, error) {
+	intentBytes, err := stub.GetState(crosschannel.IntentKey(txID))
+	if err != nil {
+		return nil, err
+	}
+	if intentBytes == nil {
+		return nil, errors.New("intent not found")
+	}
+	intent := &pb.CrossChannelIntent{}
+	if err := proto.Unmarshal(intentBytes, intent); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling intent")
+	}
+	return intent, nil
+}
+
+func getPreparedIntent(stub shim.ChaincodeStubInterface, txID string) (*pb.CrossChannelIntent, error) {
+	intent, err := getIntent(stub, txID)
+	if err != nil {
+		return nil, err
+	}
+	if intent.Status != pb.CrossChannelIntent_PREPARED {
+		return nil, errors.Errorf("intent is %s", intent.Status)
+	}
+	return intent, nil
+}
+
+func putIntent(stub shim.ChaincodeStubInterface, intent *pb.CrossChannelIntent) error {
+	intentBytes, err := proto.Marshal(intent)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(crosschannel.IntentKey(intent.TxId), intentBytes)
+}
+
+func intentLockKeys(intent *pb.CrossChannelIntent, channelID string) ([]string, error) {
+	writes, err := crosschannel.IntentWrites(intent, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return crosschannel.LockKeys(writes), nil
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package xscc
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type appConfig map[string]bool
+
+func (a appConfig) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
+	enabled, ok := a[cid]
+	return &config.MockApplication{
+		CapabilitiesRv: &config.MockApplicationCapabilities{CrossChannelWritesRv: enabled},
+	}, ok
+}
+
+// ledgers serves the state of the cross-channel namespace of the channels
+// from the state of their stubs
+type ledgers struct {
+	stubs   map[string]*shim.MockStub
+	creator []byte
+}
+
+func (l *ledgers) GetLedger(cid string) ledger.PeerLedger {
+	stub, ok := l.stubs[cid]
+	if !ok {
+		return nil
+	}
+
+	qe := &mock.TxSimulator{}
+	qe.GetStateStub = func(namespace, key string) ([]byte, error) {
+		return stub.State[key], nil
+	}
+	env := &common.Envelope{
+		Payload: marshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				SignatureHeader: marshalOrPanic(&common.SignatureHeader{Creator: l.creator}),
+			},
+		}),
+	}
+	lgr := &mock.PeerLedger{}
+	lgr.NewQueryExecutorReturns(qe, nil)
+	lgr.GetTransactionByIDReturns(&pb.ProcessedTransaction{TransactionEnvelope: env}, nil)
+	return lgr
+}
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func results(namespace string, keys ...string) []byte {
+	kvRWSet := &kvrwset.KVRWSet{}
+	for _, key := range keys {
+		kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("value")})
+	}
+	return marshalOrPanic(&rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{{Namespace: namespace, Rwset: marshalOrPanic(kvRWSet)}},
+	})
+}
+
+func setup(t *testing.T) (source, target *shim.MockStub, l *ledgers, caps appConfig) {
+	caps = appConfig{"source": true, "target": true}
+	l = &ledgers{stubs: map[string]*shim.MockStub{}}
+	xscc := New(l, caps)
+
+	source = shim.NewMockStub("xscc", xscc)
+	source.ChannelID = "source"
+	target = shim.NewMockStub("xscc", xscc)
+	target.ChannelID = "target"
+	l.stubs["source"] = source
+	l.stubs["target"] = target
+
+	// record the intent as the originating transaction of the source channel would
+	intent := &pb.CrossChannelIntent{
+		TxId:          "tx1",
+		SourceChannel: "source",
+		TargetChannel: "target",
+		SourceResults: results("cc1", "k1"),
+		TargetResults: results("cc2", "k2"),
+	}
+	source.MockTransactionStart("tx1")
+	assert.NoError(t, source.PutState(crosschannel.IntentKey("tx1"), marshalOrPanic(intent)))
+	assert.NoError(t, source.PutState(crosschannel.LockKey("cc1", "k1"), []byte("tx1")))
+	source.MockTransactionEnd("tx1")
+
+	return source, target, l, caps
+}
+
+func invoke(stub *shim.MockStub, args ...string) (*pb.CrossChannelIntent, pb.Response) {
+	var byteArgs [][]byte
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+	res := stub.MockInvoke("tx", byteArgs)
+	if res.Status != shim.OK {
+		return nil, res
+	}
+	intent := &pb.CrossChannelIntent{}
+	if err := proto.Unmarshal(res.Payload, intent); err != nil {
+		panic(err)
+	}
+	return intent, res
+}
+
+func TestInit(t *testing.T) {
+	stub := shim.NewMockStub("xscc", New(&ledgers{}, appConfig{}))
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status)
+}
+
+func TestCommit(t *testing.T) {
+	source, target, _, _ := setup(t)
+
+	_, res := invoke(source, crosschannel.Commit, "tx1")
+	assert.Equal(t, "Commit of the intent of transaction tx1 failed on channel source: intent not found on channel target", res.Message)
+
+	intent, res := invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_PREPARED, intent.Status)
+	assert.Equal(t, []byte("tx1"), target.State[crosschannel.LockKey("cc2", "k2")])
+	assert.NotNil(t, target.State[crosschannel.IntentKey("tx1")])
+
+	_, res = invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, "Prepare of the intent of transaction tx1 failed on channel target: intent is already recorded", res.Message)
+
+	_, res = invoke(target, crosschannel.Commit, "tx1")
+	assert.Equal(t, "Commit of the intent of transaction tx1 failed on channel target: intent is PREPARED on channel source", res.Message)
+
+	intent, res = invoke(source, crosschannel.Commit, "tx1")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_COMMITTED, intent.Status)
+	assert.Equal(t, results("cc1", "k1"), intent.SourceResults)
+	assert.Nil(t, source.State[crosschannel.LockKey("cc1", "k1")])
+
+	_, res = invoke(source, crosschannel.Abort, "tx1")
+	assert.Equal(t, "Abort of the intent of transaction tx1 failed on channel source: intent is COMMITTED", res.Message)
+
+	intent, res = invoke(target, crosschannel.Commit, "tx1")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_COMMITTED, intent.Status)
+	assert.Nil(t, target.State[crosschannel.LockKey("cc2", "k2")])
+
+	intent, res = invoke(target, crosschannel.GetIntent, "tx1")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_COMMITTED, intent.Status)
+}
+
+func TestAbort(t *testing.T) {
+	source, target, l, _ := setup(t)
+
+	_, res := invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	_, res = invoke(target, crosschannel.Abort, "tx1")
+	assert.Equal(t, "Abort of the intent of transaction tx1 failed on channel target: intent is PREPARED on channel source", res.Message)
+
+	l.creator = []byte("alice")
+	_, res = invoke(source, crosschannel.Abort, "tx1")
+	assert.Equal(t, "Abort of the intent of transaction tx1 failed on channel source: intent can only be aborted by the creator of the transaction", res.Message)
+
+	// the creator of the mock stub is nil
+	l.creator = nil
+	intent, res := invoke(source, crosschannel.Abort, "tx1")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_ABORTED, intent.Status)
+	assert.Nil(t, source.State[crosschannel.LockKey("cc1", "k1")])
+
+	_, res = invoke(target, crosschannel.Commit, "tx1")
+	assert.Equal(t, "Commit of the intent of transaction tx1 failed on channel target: intent is ABORTED on channel source", res.Message)
+
+	intent, res = invoke(target, crosschannel.Abort, "tx1")
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, pb.CrossChannelIntent_ABORTED, intent.Status)
+	assert.Nil(t, target.State[crosschannel.LockKey("cc2", "k2")])
+}
+
+func TestPrepareFailures(t *testing.T) {
+	source, target, _, caps := setup(t)
+
+	_, res := invoke(target, crosschannel.Prepare, "tx1")
+	assert.Equal(t, "Incorrect number of arguments for Prepare, 2", res.Message)
+
+	_, res = invoke(target, crosschannel.Prepare, "tx2", "source")
+	assert.Equal(t, "Prepare of the intent of transaction tx2 failed on channel target: intent not found on channel source", res.Message)
+
+	_, res = invoke(target, crosschannel.Prepare, "tx1", "other")
+	assert.Equal(t, "Prepare of the intent of transaction tx1 failed on channel target: application config does not exist for other", res.Message)
+
+	_, res = invoke(source, crosschannel.Prepare, "tx1", "target")
+	assert.Equal(t, "Prepare of the intent of transaction tx1 failed on channel source: intent is already recorded", res.Message)
+
+	target.MockTransactionStart("tx0")
+	assert.NoError(t, target.PutState(crosschannel.LockKey("cc2", "k2"), []byte("tx0")))
+	target.MockTransactionEnd("tx0")
+	_, res = invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, "Prepare of the intent of transaction tx1 failed on channel target: intent writes to a key locked by transaction tx0", res.Message)
+
+	caps["source"] = false
+	_, res = invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, "Prepare of the intent of transaction tx1 failed on channel target: cross-channel writes are not enabled on channel source", res.Message)
+
+	caps["target"] = false
+	_, res = invoke(target, crosschannel.Prepare, "tx1", "source")
+	assert.Equal(t, "cross-channel writes are not enabled on channel target", res.Message)
+}
+
+func TestInvokeFailures(t *testing.T) {
+	_, target, _, _ := setup(t)
+
+	_, res := invoke(target, crosschannel.Commit)
+	assert.Equal(t, "Incorrect number of arguments, 1", res.Message)
+
+	_, res = invoke(target, "Unknown", "tx1")
+	assert.Equal(t, "Requested function Unknown not found.", res.Message)
+
+	_, res = invoke(target, crosschannel.GetIntent, "tx1")
+	assert.Equal(t, "GetIntent of the intent of transaction tx1 failed on channel target: intent not found", res.Message)
+}
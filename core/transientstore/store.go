@@ -34,6 +34,8 @@ var ErrStoreEmpty = errors.New("Transient store is empty")
 // StoreProvider provides an instance of a TransientStore
 type StoreProvider interface {
 	OpenStore(ledgerID string) (Store, error)
+	// DropLedger deletes the private write sets of the given ledger
+	DropLedger(ledgerID string) error
 	Close()
 }
 
@@ -131,6 +133,11 @@ func (provider *storeProvider) OpenStore(ledgerID string) (Store, error) {
 	return &store{db: dbHandle, ledgerID: ledgerID}, nil
 }
 
+// DropLedger deletes all the entries of a ledgerId in Store
+func (provider *storeProvider) DropLedger(ledgerID string) error {
+	return provider.dbProvider.GetDBHandle(ledgerID).DeleteAll()
+}
+
 // Close closes the TransientStoreProvider
 func (provider *storeProvider) Close() {
 	provider.dbProvider.Close()
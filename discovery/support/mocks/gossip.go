@@ -94,6 +94,16 @@ type Gossip struct {
 	gossipArgsForCall []struct {
 		msg *proto.GossipMessage
 	}
+	MembershipStateStub        func() []discovery.MemberState
+	membershipStateMutex       sync.RWMutex
+	membershipStateArgsForCall []struct {
+	}
+	membershipStateReturns struct {
+		result1 []discovery.MemberState
+	}
+	membershipStateReturnsOnCall map[int]struct {
+		result1 []discovery.MemberState
+	}
 	PeerFilterStub        func(channel common.ChainID, messagePredicate api.SubChannelSelectionCriteria) (filter.RoutingFilter, error)
 	peerFilterMutex       sync.RWMutex
 	peerFilterArgsForCall []struct {
@@ -512,6 +522,58 @@ func (fake *Gossip) GossipArgsForCall(i int) *proto.GossipMessage {
 	return fake.gossipArgsForCall[i].msg
 }
 
+func (fake *Gossip) MembershipState() []discovery.MemberState {
+	fake.membershipStateMutex.Lock()
+	ret, specificReturn := fake.membershipStateReturnsOnCall[len(fake.membershipStateArgsForCall)]
+	fake.membershipStateArgsForCall = append(fake.membershipStateArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MembershipState", []interface{}{})
+	fake.membershipStateMutex.Unlock()
+	if fake.MembershipStateStub != nil {
+		return fake.MembershipStateStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.membershipStateReturns
+	return fakeReturns.result1
+}
+
+func (fake *Gossip) MembershipStateCallCount() int {
+	fake.membershipStateMutex.RLock()
+	defer fake.membershipStateMutex.RUnlock()
+	return len(fake.membershipStateArgsForCall)
+}
+
+func (fake *Gossip) MembershipStateCalls(stub func() []discovery.MemberState) {
+	fake.membershipStateMutex.Lock()
+	defer fake.membershipStateMutex.Unlock()
+	fake.MembershipStateStub = stub
+}
+
+func (fake *Gossip) MembershipStateReturns(result1 []discovery.MemberState) {
+	fake.membershipStateMutex.Lock()
+	defer fake.membershipStateMutex.Unlock()
+	fake.MembershipStateStub = nil
+	fake.membershipStateReturns = struct {
+		result1 []discovery.MemberState
+	}{result1}
+}
+
+func (fake *Gossip) MembershipStateReturnsOnCall(i int, result1 []discovery.MemberState) {
+	fake.membershipStateMutex.Lock()
+	defer fake.membershipStateMutex.Unlock()
+	fake.MembershipStateStub = nil
+	if fake.membershipStateReturnsOnCall == nil {
+		fake.membershipStateReturnsOnCall = make(map[int]struct {
+			result1 []discovery.MemberState
+		})
+	}
+	fake.membershipStateReturnsOnCall[i] = struct {
+		result1 []discovery.MemberState
+	}{result1}
+}
+
 func (fake *Gossip) PeerFilter(channel common.ChainID, messagePredicate api.SubChannelSelectionCriteria) (filter.RoutingFilter, error) {
 	fake.peerFilterMutex.Lock()
 	ret, specificReturn := fake.peerFilterReturnsOnCall[len(fake.peerFilterArgsForCall)]
@@ -768,6 +830,8 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.updateChaincodesMutex.RUnlock()
 	fake.gossipMutex.RLock()
 	defer fake.gossipMutex.RUnlock()
+	fake.membershipStateMutex.RLock()
+	defer fake.membershipStateMutex.RUnlock()
 	fake.peerFilterMutex.RLock()
 	defer fake.peerFilterMutex.RUnlock()
 	fake.acceptMutex.RLock()
@@ -8,6 +8,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -96,6 +97,15 @@ func (n NetworkMember) PreferredEndpoint() string {
 	return n.Endpoint
 }
 
+// MemberState is the state of a member as known to the discovery layer
+type MemberState struct {
+	NetworkMember
+	Alive    bool
+	IncNum   uint64
+	SeqNum   uint64
+	LastSeen time.Time
+}
+
 // PeerIdentification encompasses a remote peer's
 // PKI-ID and whether its in the same org as the current
 // peer or not
@@ -126,6 +136,10 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// MembershipState returns the alive and dead members in the view,
+	// along with their incarnation numbers
+	MembershipState() []MemberState
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
@@ -954,6 +954,47 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+// MembershipState returns the alive and dead members in the view,
+// along with their incarnation numbers
+func (d *gossipDiscoveryImpl) MembershipState() []MemberState {
+	if d.toDie() {
+		return []MemberState{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []MemberState{}
+	for _, m := range d.aliveMembership.ToSlice() {
+		response = append(response, d.memberState(m, true, d.aliveLastTS))
+	}
+	for _, m := range d.deadMembership.ToSlice() {
+		response = append(response, d.memberState(m, false, d.deadLastTS))
+	}
+	return response
+}
+
+func (d *gossipDiscoveryImpl) memberState(m *proto.SignedGossipMessage, alive bool, lastTS map[string]*timestamp) MemberState {
+	member := m.GetAliveMsg()
+	state := MemberState{
+		NetworkMember: NetworkMember{
+			PKIid:    member.Membership.PkiId,
+			Endpoint: member.Membership.Endpoint,
+			Metadata: member.Membership.Metadata,
+			Envelope: m.Envelope,
+		},
+		Alive: alive,
+	}
+	if nm, exists := d.id2Member[string(member.Membership.PkiId)]; exists {
+		state.InternalEndpoint = nm.InternalEndpoint
+	}
+	if ts, exists := lastTS[string(member.Membership.PkiId)]; exists {
+		state.IncNum = uint64(ts.incTime.UnixNano())
+		state.SeqNum = ts.seqNum
+		state.LastSeen = ts.lastSeen
+	}
+	return state
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -849,6 +849,21 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	// The stopped instances are in the view as dead members
+	aliveCount, deadCount := 0, 0
+	for _, member := range instances[0].MembershipState() {
+		if member.Alive {
+			aliveCount++
+		} else {
+			deadCount++
+		}
+		assert.NotZero(t, member.IncNum)
+		assert.NotZero(t, member.SeqNum)
+		assert.False(t, member.LastSeen.IsZero())
+	}
+	assert.Equal(t, nodeNum-3, aliveCount)
+	assert.Equal(t, 2, deadCount)
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
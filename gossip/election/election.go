@@ -99,6 +99,10 @@ type LeaderElectionService interface {
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Leader returns the ID of the peer that was last known to be the leader,
+	// and the time since which it is the leader, or nil if no leader is known
+	Leader() ([]byte, time.Time)
 }
 
 type peerID []byte
@@ -185,6 +189,9 @@ type leaderElectionSvcImpl struct {
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
 	config        ElectionConfig
+	leaderLock    sync.RWMutex
+	leaderID      peerID
+	leaderSince   time.Time
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -227,6 +234,7 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		le.proposals.Add(string(msg.SenderID()))
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
+		le.setLeader(msg.SenderID())
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
@@ -401,6 +409,7 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
 	atomic.StoreInt32(&le.isLeader, int32(1))
+	le.setLeader(le.id)
 	le.callback(true)
 }
 
@@ -410,6 +419,25 @@ func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.callback(false)
 }
 
+// setLeader records the given peer as the leader, if it isn't already
+func (le *leaderElectionSvcImpl) setLeader(id peerID) {
+	le.leaderLock.Lock()
+	defer le.leaderLock.Unlock()
+	if bytes.Equal(le.leaderID, id) {
+		return
+	}
+	le.leaderID = id
+	le.leaderSince = time.Now()
+}
+
+// Leader returns the ID of the peer that was last known to be the leader,
+// and the time since which it is the leader, or nil if no leader is known
+func (le *leaderElectionSvcImpl) Leader() ([]byte, time.Time) {
+	le.leaderLock.RLock()
+	defer le.leaderLock.RUnlock()
+	return le.leaderID, le.leaderSince
+}
+
 func (le *leaderElectionSvcImpl) shouldStop() bool {
 	return atomic.LoadInt32(&le.toDie) == int32(1)
 }
@@ -353,11 +353,26 @@ func TestLeadershipTakeover(t *testing.T) {
 	leaders := waitForLeaderElection(t, peers)
 	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
 	assert.Equal(t, "p5", leaders[0])
+	knowLeader := func(peers []*peer, leader string) func() bool {
+		return func() bool {
+			for _, p := range peers {
+				if id, _ := p.Leader(); string(id) != leader {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	waitForBoolFunc(t, knowLeader(peers, "p5"), true)
+	_, p5Since := peers[1].Leader()
 	peers[0].Stop()
 	time.Sleep(testLeadershipDeclarationInterval + testLeaderAliveThreshold*3)
 	leaders = waitForLeaderElection(t, peers[1:])
 	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
 	assert.Equal(t, "p2", leaders[0])
+	waitForBoolFunc(t, knowLeader(peers[1:], "p2"), true)
+	_, p2Since := peers[1].Leader()
+	assert.True(t, p2Since.After(p5Since))
 }
 
 func TestYield(t *testing.T) {
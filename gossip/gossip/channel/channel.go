@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/gossip/erasure"
 	"github.com/hyperledger/fabric/gossip/gossip/msgstore"
 	"github.com/hyperledger/fabric/gossip/gossip/pull"
 	"github.com/hyperledger/fabric/gossip/metrics"
@@ -49,6 +50,13 @@ type Config struct {
 	RequestWaitTime             time.Duration
 	ResponseWaitTime            time.Duration
 	MsgExpirationTimeout        time.Duration
+
+	// ErasureCodingMinBlockSize is the minimum size of a block that is disseminated
+	// to the peers of the organization as erasure coded fragments, or 0 if blocks
+	// are never disseminated as fragments
+	ErasureCodingMinBlockSize    int
+	ErasureCodingDataFragments   int
+	ErasureCodingParityFragments int
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -87,6 +95,10 @@ type GossipChannel interface {
 	// AddToMsgStore adds a given GossipMessage to the message store
 	AddToMsgStore(msg *proto.SignedGossipMessage)
 
+	// DisseminateFragments disseminates the given block to the peers of the organization
+	// as erasure coded fragments, and returns false if the block should be gossiped as is
+	DisseminateFragments(msg *proto.SignedGossipMessage) bool
+
 	// ConfigureChannel (re)configures the list of organizations
 	// that are eligible to be in the channel
 	ConfigureChannel(joinMsg api.JoinChannelMessage)
@@ -128,6 +140,10 @@ type Adapter interface {
 	// hasn't been signed correctly, nil otherwise.
 	ValidateStateInfoMessage(message *proto.SignedGossipMessage) error
 
+	// IsAuthenticatedBySessionKey returns whether a StateInfo message carries a valid
+	// HMAC computed with the session key its origin shared with us
+	IsAuthenticatedBySessionKey(message *proto.SignedGossipMessage) bool
+
 	// GetOrgOfPeer returns the organization ID of a given peer PKI-ID
 	GetOrgOfPeer(pkiID common.PKIidType) api.OrgIdentityType
 
@@ -160,6 +176,8 @@ type gossipChannel struct {
 	incTime                   uint64
 	leftChannel               int32
 	membershipTracker         *membershipTracker
+	coder                     *erasure.Coder
+	fragments                 *fragmentAssembler
 }
 
 type membershipFilter struct {
@@ -203,6 +221,17 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 
 	gc.memFilter = &membershipFilter{adapter: gc.Adapter, gossipChannel: gc}
 
+	// Fragments of blocks that couldn't be reconstructed in time are discarded,
+	// and the blocks are pulled from other peers
+	gc.fragments = newFragmentAssembler(adapter.GetConf().PullInterval * 2)
+	if conf := adapter.GetConf(); conf.ErasureCodingMinBlockSize > 0 {
+		coder, err := erasure.NewCoder(conf.ErasureCodingDataFragments, conf.ErasureCodingParityFragments)
+		if err != nil {
+			gc.logger.Warningf("Blocks of channel %s will not be disseminated as fragments: %+v", string(chainID), err)
+		}
+		gc.coder = coder
+	}
+
 	comparator := proto.NewGossipMessageComparator(adapter.GetConf().MaxBlockCountToStore)
 
 	gc.blocksPuller = gc.createBlockPuller()
@@ -258,6 +287,12 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 			gc.logger.Warning("peer", peerIdentity, "'s organization(", string(org), ") isn't in the channel", string(chainID))
 			return false
 		}
+		// A session key of the channel is installed only after the signature of its
+		// origin was verified by VerifyByChannel, and it is dropped when the config of
+		// the channel changes, so its HMAC stands for the result of the verification
+		if adapter.IsAuthenticatedBySessionKey(msg) {
+			return true
+		}
 		if err := gc.mcs.VerifyByChannel(chainID, peerIdentity, msg.Signature, msg.Payload); err != nil {
 			gc.logger.Warningf("Peer %v isn't eligible for channel %s : %+v", peerIdentity, string(chainID), errors.WithStack(err))
 			return false
@@ -589,6 +624,11 @@ func (gc *gossipChannel) HandleMessage(msg proto.ReceivedMessage) {
 		return
 	}
 
+	if m.IsBlockFragmentMsg() {
+		gc.handleBlockFragment(msg)
+		return
+	}
+
 	if m.IsDataMsg() || m.IsStateInfoMsg() {
 		added := false
 
@@ -245,6 +245,10 @@ func (ga *gossipAdapterMock) ValidateStateInfoMessage(msg *proto.SignedGossipMes
 	return args.Get(0).(error)
 }
 
+func (ga *gossipAdapterMock) IsAuthenticatedBySessionKey(msg *proto.SignedGossipMessage) bool {
+	return false
+}
+
 func (ga *gossipAdapterMock) GetOrgOfPeer(PKIIID common.PKIidType) api.OrgIdentityType {
 	args := ga.Called(PKIIID)
 	if args.Get(0) == nil {
@@ -954,6 +958,160 @@ func TestChannelAddToMessageStore(t *testing.T) {
 	assert.True(t, gc.EligibleForChannel(discovery.NetworkMember{PKIid: pkiIDInOrg1}))
 }
 
+func TestChannelBlockFragments(t *testing.T) {
+	t.Parallel()
+
+	erasureConf := conf
+	erasureConf.ErasureCodingMinBlockSize = 10
+	erasureConf.ErasureCodingDataFragments = 2
+	erasureConf.ErasureCodingParityFragments = 1
+
+	configureErasureAdapter := func(adapter *gossipAdapterMock, members ...discovery.NetworkMember) {
+		adapter.On("GetConf").Return(erasureConf)
+		adapter.On("GetMembership").Return(members)
+		adapter.On("GetOrgOfPeer", pkiIDInOrg1).Return(orgInChannelA)
+		adapter.On("GetOrgOfPeer", pkiIDInOrg1ButNotEligible).Return(orgInChannelA)
+		adapter.On("GetOrgOfPeer", pkiIDinOrg2).Return(orgNotInChannelA)
+		adapter.On("GetOrgOfPeer", mock.Anything).Return(api.OrgIdentityType(nil))
+	}
+	members := []discovery.NetworkMember{{PKIid: pkiIDInOrg1}, {PKIid: pkiIDInOrg1ButNotEligible}}
+
+	type sentMsg struct {
+		msg   *proto.SignedGossipMessage
+		peers []*comm.RemotePeer
+	}
+
+	cs := &cryptoService{}
+	cs.On("VerifyBlock", mock.Anything).Return(nil)
+
+	// The leader disseminates large blocks as fragments, one to each peer
+	leaderSent := make(chan sentMsg, 10)
+	leaderAdapter := new(gossipAdapterMock)
+	configureErasureAdapter(leaderAdapter, members...)
+	leaderAdapter.On("Gossip", mock.Anything)
+	leaderAdapter.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		leaderSent <- sentMsg{msg: args.Get(0).(*proto.SignedGossipMessage), peers: args.Get(1).([]*comm.RemotePeer)}
+	})
+	leader := NewGossipChannel(common.PKIidType("leader"), orgInChannelA, cs, channelA, leaderAdapter, &joinChanMsg{}, disabledMetrics)
+	defer leader.Stop()
+	leader.AddToMsgStore(createStateInfoMsg(1, pkiIDInOrg1, channelA))
+	leader.AddToMsgStore(createStateInfoMsg(1, pkiIDInOrg1ButNotEligible, channelA))
+
+	smallBlock := createDataMsg(5, channelA)
+	smallBlock.GetDataMsg().Payload.Data = []byte{1, 2, 3}
+	assert.False(t, leader.DisseminateFragments(smallBlock))
+
+	largeBlock := createDataMsg(5, channelA)
+	largeBlock.GetDataMsg().Payload.Data = bytes.Repeat([]byte{1, 2, 3}, 100)
+	assert.True(t, leader.DisseminateFragments(largeBlock))
+
+	var fragments []*proto.SignedGossipMessage
+	recipients := make(map[string]int)
+	for i := 0; i < 3; i++ {
+		select {
+		case sent := <-leaderSent:
+			assert.Len(t, sent.peers, 1)
+			recipients[string(sent.peers[0].PKIID)]++
+			assert.True(t, sent.msg.IsBlockFragmentMsg())
+			assert.Equal(t, uint32(i), sent.msg.GetBlockFragment().Index)
+			fragments = append(fragments, sent.msg)
+		case <-time.After(time.Second):
+			t.Fatal("Didn't send all fragments in time")
+		}
+	}
+	assert.Len(t, recipients, 2)
+
+	// A peer relays fragments it gets from the leader, and reconstructs the block
+	// once it has enough fragments, without forwarding it
+	peerSent := make(chan sentMsg, 10)
+	demuxedMsgs := make(chan *proto.SignedGossipMessage, 10)
+	peerAdapter := new(gossipAdapterMock)
+	configureErasureAdapter(peerAdapter, members...)
+	peerAdapter.On("Gossip", mock.Anything)
+	peerAdapter.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		peerSent <- sentMsg{msg: args.Get(0).(*proto.SignedGossipMessage), peers: args.Get(1).([]*comm.RemotePeer)}
+	})
+	peerAdapter.On("DeMultiplex", mock.Anything).Run(func(args mock.Arguments) {
+		demuxedMsgs <- args.Get(0).(*proto.SignedGossipMessage)
+	})
+	peer := NewGossipChannel(common.PKIidType("peer"), orgInChannelA, cs, channelA, peerAdapter, &joinChanMsg{}, disabledMetrics)
+	defer peer.Stop()
+	peer.AddToMsgStore(createStateInfoMsg(1, pkiIDInOrg1, channelA))
+	peer.AddToMsgStore(createStateInfoMsg(1, pkiIDInOrg1ButNotEligible, channelA))
+
+	// Fragments of peers from other organizations are discarded
+	peer.HandleMessage(&receivedMsg{msg: fragments[0], PKIID: pkiIDinOrg2})
+	peer.HandleMessage(&receivedMsg{msg: fragments[0], PKIID: pkiIDInOrg1})
+	select {
+	case sent := <-peerSent:
+		assert.Len(t, sent.peers, 1)
+		assert.Equal(t, pkiIDInOrg1ButNotEligible, sent.peers[0].PKIID)
+		assert.True(t, sent.msg.GetBlockFragment().Relayed)
+		assert.Equal(t, uint32(0), sent.msg.GetBlockFragment().Index)
+	case <-time.After(time.Second):
+		t.Fatal("Didn't relay the fragment in time")
+	}
+	assert.Len(t, demuxedMsgs, 0)
+
+	relayed, err := (&proto.GossipMessage{
+		Channel: []byte(channelA),
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: fragments[2].Content,
+	}).NoopSign()
+	assert.NoError(t, err)
+	relayed.GetBlockFragment().Relayed = true
+	peer.HandleMessage(&receivedMsg{msg: relayed, PKIID: pkiIDInOrg1ButNotEligible})
+	select {
+	case msg := <-demuxedMsgs:
+		assert.Equal(t, largeBlock.GetDataMsg().Payload.SeqNum, msg.GetDataMsg().Payload.SeqNum)
+		assert.Equal(t, largeBlock.GetDataMsg().Payload.Data, msg.GetDataMsg().Payload.Data)
+	case <-time.After(time.Second):
+		t.Fatal("Didn't reconstruct the block in time")
+	}
+	assert.Len(t, peerSent, 0)
+
+	// Fragments of a block that was already reconstructed are ignored
+	peer.HandleMessage(&receivedMsg{msg: fragments[1], PKIID: pkiIDInOrg1ButNotEligible})
+	assert.Len(t, peerSent, 0)
+	assert.Len(t, demuxedMsgs, 0)
+}
+
+func TestFragmentAssemblerInvalidFragments(t *testing.T) {
+	fa := newFragmentAssembler(time.Minute)
+	for _, testCase := range []struct {
+		fragment    *proto.BlockFragment
+		expectedErr string
+	}{
+		{
+			fragment:    &proto.BlockFragment{SeqNum: 1, DataFragments: 0, ParityFragments: 1},
+			expectedErr: "block 1 is split into an invalid number of fragments (0 data, 1 parity)",
+		},
+		{
+			fragment:    &proto.BlockFragment{SeqNum: 1, DataFragments: 200, ParityFragments: 100},
+			expectedErr: "block 1 is split into an invalid number of fragments (200 data, 100 parity)",
+		},
+		{
+			fragment:    &proto.BlockFragment{SeqNum: 1, DataFragments: 2, ParityFragments: 1, Index: 3},
+			expectedErr: "fragment index 3 of block 1 is out of range, there are 3 fragments",
+		},
+		{
+			fragment:    &proto.BlockFragment{SeqNum: 1, DataFragments: 2, ParityFragments: 1},
+			expectedErr: "block 1 is of invalid size 0",
+		},
+		{
+			fragment:    &proto.BlockFragment{SeqNum: 1, DataFragments: 2, ParityFragments: 1, PayloadSize: 5, Fragment: []byte{1}},
+			expectedErr: "fragment 0 of block 1 is of size 1, expected 3",
+		},
+	} {
+		_, _, err := fa.add(testCase.fragment)
+		assert.EqualError(t, err, testCase.expectedErr)
+	}
+
+	// Fragments that don't add up to the hash they carry are discarded
+	_, _, err := fa.add(&proto.BlockFragment{SeqNum: 1, DataFragments: 1, PayloadSize: 2, Fragment: []byte{1, 2}, PayloadHash: []byte{3}})
+	assert.EqualError(t, err, "reconstructed block 1 doesn't match its hash")
+}
+
 func TestChannelBlockExpiration(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,275 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	protoG "github.com/golang/protobuf/proto"
+	common_utils "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip/erasure"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+// maxFragmentedPayloadSize is the maximum size of a Payload
+// that is reconstructed from fragments
+const maxFragmentedPayloadSize = 512 * 1024 * 1024
+
+type fragmentsKey struct {
+	seqNum      uint64
+	payloadHash string
+}
+
+type fragmentedPayload struct {
+	coder     *erasure.Coder
+	size      int
+	fragments [][]byte
+	count     int
+	completed bool
+	created   time.Time
+}
+
+// fragmentAssembler collects fragments of blocks, until enough
+// fragments of a block are collected to reconstruct it
+type fragmentAssembler struct {
+	sync.Mutex
+	expiration time.Duration
+	payloads   map[fragmentsKey]*fragmentedPayload
+}
+
+func newFragmentAssembler(expiration time.Duration) *fragmentAssembler {
+	return &fragmentAssembler{
+		expiration: expiration,
+		payloads:   make(map[fragmentsKey]*fragmentedPayload),
+	}
+}
+
+// add adds the given fragment, and returns whether it wasn't added before,
+// and the reconstructed Payload if the fragment completes it
+func (fa *fragmentAssembler) add(f *proto.BlockFragment) (bool, *proto.Payload, error) {
+	if err := validateFragment(f); err != nil {
+		return false, nil, err
+	}
+	fa.Lock()
+	defer fa.Unlock()
+
+	fa.purgeExpired()
+
+	key := fragmentsKey{seqNum: f.SeqNum, payloadHash: string(f.PayloadHash)}
+	fp, exists := fa.payloads[key]
+	if !exists {
+		coder, err := erasure.NewCoder(int(f.DataFragments), int(f.ParityFragments))
+		if err != nil {
+			return false, nil, err
+		}
+		fp = &fragmentedPayload{
+			coder:     coder,
+			size:      int(f.PayloadSize),
+			fragments: make([][]byte, f.DataFragments+f.ParityFragments),
+			created:   time.Now(),
+		}
+		fa.payloads[key] = fp
+	}
+	if fp.coder.DataFragments() != int(f.DataFragments) || fp.coder.ParityFragments() != int(f.ParityFragments) || fp.size != int(f.PayloadSize) {
+		return false, nil, errors.Errorf("fragment %d of block %d doesn't match the previous fragments of the block", f.Index, f.SeqNum)
+	}
+	if fp.completed || fp.fragments[f.Index] != nil {
+		return false, nil, nil
+	}
+	fp.fragments[f.Index] = f.Fragment
+	fp.count++
+	if fp.count < fp.coder.DataFragments() {
+		return true, nil, nil
+	}
+
+	// We have enough fragments, so the block is either reconstructed now or never
+	fp.completed = true
+	fragments := fp.fragments
+	fp.fragments = nil
+	rawPayload, err := fp.coder.Join(fragments, fp.size)
+	if err != nil {
+		return true, nil, errors.WithStack(err)
+	}
+	if !bytes.Equal(common_utils.ComputeSHA256(rawPayload), f.PayloadHash) {
+		return true, nil, errors.Errorf("reconstructed block %d doesn't match its hash", f.SeqNum)
+	}
+	payload := &proto.Payload{}
+	if err := protoG.Unmarshal(rawPayload, payload); err != nil {
+		return true, nil, errors.Wrapf(err, "failed unmarshaling reconstructed block %d", f.SeqNum)
+	}
+	if payload.SeqNum != f.SeqNum {
+		return true, nil, errors.Errorf("reconstructed block is %d, but its fragments are of block %d", payload.SeqNum, f.SeqNum)
+	}
+	return true, payload, nil
+}
+
+func (fa *fragmentAssembler) purgeExpired() {
+	for key, fp := range fa.payloads {
+		if time.Since(fp.created) > fa.expiration {
+			delete(fa.payloads, key)
+		}
+	}
+}
+
+func validateFragment(f *proto.BlockFragment) error {
+	total := f.DataFragments + f.ParityFragments
+	if f.DataFragments == 0 || total > erasure.MaxFragments {
+		return errors.Errorf("block %d is split into an invalid number of fragments (%d data, %d parity)", f.SeqNum, f.DataFragments, f.ParityFragments)
+	}
+	if f.Index >= total {
+		return errors.Errorf("fragment index %d of block %d is out of range, there are %d fragments", f.Index, f.SeqNum, total)
+	}
+	if f.PayloadSize == 0 || f.PayloadSize > maxFragmentedPayloadSize {
+		return errors.Errorf("block %d is of invalid size %d", f.SeqNum, f.PayloadSize)
+	}
+	fragmentSize := (f.PayloadSize + uint64(f.DataFragments) - 1) / uint64(f.DataFragments)
+	if uint64(len(f.Fragment)) != fragmentSize {
+		return errors.Errorf("fragment %d of block %d is of size %d, expected %d", f.Index, f.SeqNum, len(f.Fragment), fragmentSize)
+	}
+	return nil
+}
+
+// DisseminateFragments splits the given block into erasure coded fragments
+// and sends them to the peers of the organization in the channel, which relay
+// them to each other. Returns false if the block should be gossiped as is.
+func (gc *gossipChannel) DisseminateFragments(msg *proto.SignedGossipMessage) bool {
+	conf := gc.GetConf()
+	if gc.coder == nil || !msg.IsDataMsg() || msg.GetDataMsg().Payload == nil {
+		return false
+	}
+	payload := msg.GetDataMsg().Payload
+	if len(payload.Data) < conf.ErasureCodingMinBlockSize {
+		return false
+	}
+	peers := gc.memFilter.GetMembership()
+	if len(peers) == 0 {
+		return false
+	}
+	rawPayload, err := protoG.Marshal(payload)
+	if err != nil {
+		gc.logger.Warningf("Failed marshaling block %d: %+v", payload.SeqNum, errors.WithStack(err))
+		return false
+	}
+	payloadHash := common_utils.ComputeSHA256(rawPayload)
+
+	// Every fragment is sent to a single peer, and if there are more fragments
+	// than peers, some of the peers get more than a single fragment
+	fragments := gc.coder.Split(rawPayload)
+	offset := int(payload.SeqNum % uint64(len(peers)))
+	for i, fragment := range fragments {
+		fragmentMsg, err := (&proto.GossipMessage{
+			Channel: gc.chainID,
+			Tag:     proto.GossipMessage_CHAN_AND_ORG,
+			Content: &proto.GossipMessage_BlockFragment{
+				BlockFragment: &proto.BlockFragment{
+					SeqNum:          payload.SeqNum,
+					PayloadHash:     payloadHash,
+					PayloadSize:     uint64(len(rawPayload)),
+					DataFragments:   uint32(gc.coder.DataFragments()),
+					ParityFragments: uint32(gc.coder.ParityFragments()),
+					Index:           uint32(i),
+					Fragment:        fragment,
+				},
+			},
+		}).NoopSign()
+		if err != nil {
+			gc.logger.Warningf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
+			return false
+		}
+		peer := peers[(offset+i)%len(peers)]
+		gc.Send(fragmentMsg, &comm.RemotePeer{PKIID: peer.PKIid, Endpoint: peer.PreferredEndpoint()})
+	}
+	gc.logger.Debugf("Disseminated block %d of %d bytes as %d fragments to %d peers", payload.SeqNum, len(rawPayload), len(fragments), len(peers))
+	return true
+}
+
+func (gc *gossipChannel) handleBlockFragment(msg proto.ReceivedMessage) {
+	sender := msg.GetConnectionInfo().ID
+	if !bytes.Equal(gc.GetOrgOfPeer(sender), gc.selfOrg) {
+		gc.logger.Warning("Received a block fragment from", msg.GetConnectionInfo(), "which isn't in our organization")
+		return
+	}
+	f := msg.GetGossipMessage().GetBlockFragment()
+	isNew, payload, err := gc.fragments.add(f)
+	if isNew && !f.Relayed {
+		gc.relayFragment(f, sender)
+	}
+	if err != nil {
+		gc.logger.Warningf("Failed handling fragment %d of block %d from %s: %+v", f.Index, f.SeqNum, msg.GetConnectionInfo(), err)
+		return
+	}
+	if payload != nil {
+		gc.handleReconstructedBlock(payload, sender)
+	}
+}
+
+// relayFragment sends a fragment the leader sent us to the rest of the peers of the organization
+func (gc *gossipChannel) relayFragment(f *proto.BlockFragment, sender common.PKIidType) {
+	relayed := *f
+	relayed.Relayed = true
+	fragmentMsg, err := (&proto.GossipMessage{
+		Channel: gc.chainID,
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_BlockFragment{
+			BlockFragment: &relayed,
+		},
+	}).NoopSign()
+	if err != nil {
+		gc.logger.Warningf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
+		return
+	}
+	var peers []*comm.RemotePeer
+	for _, peer := range gc.memFilter.GetMembership() {
+		if bytes.Equal(peer.PKIid, sender) {
+			continue
+		}
+		peers = append(peers, &comm.RemotePeer{PKIID: peer.PKIid, Endpoint: peer.PreferredEndpoint()})
+	}
+	gc.Send(fragmentMsg, peers...)
+}
+
+// handleReconstructedBlock handles a block reconstructed from fragments like a block
+// that was received as is, except that it isn't forwarded to other peers, since
+// they reconstruct it from fragments on their own
+func (gc *gossipChannel) handleReconstructedBlock(payload *proto.Payload, sender common.PKIidType) {
+	msg, err := (&proto.GossipMessage{
+		Channel: gc.chainID,
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: payload,
+			},
+		},
+	}).NoopSign()
+	if err != nil {
+		gc.logger.Warningf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
+		return
+	}
+	// Would this block go into the message store if it was verified?
+	if !gc.blockMsgStore.CheckValid(msg) {
+		return
+	}
+	if !gc.verifyBlock(msg.GossipMessage, sender) {
+		gc.logger.Warning("Failed verifying block", payload.SeqNum, "reconstructed from fragments")
+		return
+	}
+	gc.Lock()
+	added := gc.blockMsgStore.Add(msg)
+	if added {
+		gc.blocksPuller.Add(msg)
+	}
+	gc.Unlock()
+	if added {
+		gc.logger.Debugf("Reconstructed block %d from fragments", payload.SeqNum)
+		gc.DeMultiplex(msg)
+	}
+}
@@ -94,6 +94,17 @@ func (cs *channelState) getGossipChannelByChainID(chainID common.ChainID) channe
 	return cs.channels[string(chainID)]
 }
 
+// chainIDs returns the channels the peer joined
+func (cs *channelState) chainIDs() []common.ChainID {
+	cs.RLock()
+	defer cs.RUnlock()
+	var chainIDs []common.ChainID
+	for chanName := range cs.channels {
+		chainIDs = append(chainIDs, common.ChainID(chanName))
+	}
+	return chainIDs
+}
+
 func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID common.ChainID,
 	metrics *metrics.MembershipMetrics) {
 	if cs.isStopping() {
@@ -131,6 +142,10 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		RequestWaitTime:             ga.conf.RequestWaitTime,
 		ResponseWaitTime:            ga.conf.ResponseWaitTime,
 		MsgExpirationTimeout:        ga.conf.MsgExpirationTimeout,
+
+		ErasureCodingMinBlockSize:    ga.conf.ErasureCodingMinBlockSize,
+		ErasureCodingDataFragments:   ga.conf.ErasureCodingDataFragments,
+		ErasureCodingParityFragments: ga.conf.ErasureCodingParityFragments,
 	}
 }
 
@@ -145,10 +160,14 @@ func (ga *gossipAdapterImpl) Sign(msg *proto.GossipMessage) (*proto.SignedGossip
 	if err != nil {
 		return nil, err
 	}
-	return &proto.SignedGossipMessage{
+	sMsg = &proto.SignedGossipMessage{
 		Envelope:      e,
 		GossipMessage: msg,
-	}, nil
+	}
+	if ga.sessionKeys != nil && sMsg.IsStateInfoMsg() {
+		ga.sessionKeys.authenticateStateInfoMsg(sMsg)
+	}
+	return sMsg, nil
 }
 
 // Gossip gossips a message
@@ -179,6 +198,12 @@ func (ga *gossipAdapterImpl) ValidateStateInfoMessage(msg *proto.SignedGossipMes
 	return ga.gossipServiceImpl.validateStateInfoMsg(msg)
 }
 
+// IsAuthenticatedBySessionKey returns whether the given StateInfo message carries
+// a valid HMAC computed with the session key its origin shared with us
+func (ga *gossipAdapterImpl) IsAuthenticatedBySessionKey(msg *proto.SignedGossipMessage) bool {
+	return ga.sessionKeys != nil && ga.sessionKeys.verifyStateInfoMsg(msg)
+}
+
 // GetOrgOfPeer returns the organization identifier of a certain peer
 func (ga *gossipAdapterImpl) GetOrgOfPeer(PKIID common.PKIidType) api.OrgIdentityType {
 	return ga.gossipServiceImpl.getOrgOfPeer(PKIID)
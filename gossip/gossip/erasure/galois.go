@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package erasure
+
+import (
+	"github.com/pkg/errors"
+)
+
+// generator is the primitive polynomial x^8 + x^4 + x^3 + x^2 + 1
+// that defines the multiplication in GF(2^8)
+const generator = 0x11d
+
+var (
+	expTable [510]byte
+	logTable [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		expTable[i+255] = byte(x)
+		logTable[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= generator
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[logTable[a]+logTable[b]]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[logTable[a]+255-logTable[b]]
+}
+
+func gfExp(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return expTable[(logTable[a]*n)%255]
+}
+
+// row is a row of a matrix over GF(2^8)
+type row []byte
+
+// combine sets out to the linear combination of the given vectors
+// with the coefficients of the row
+func (r row) combine(vectors [][]byte, out []byte) {
+	for i := range out {
+		out[i] = 0
+	}
+	for i, coefficient := range r {
+		if coefficient == 0 {
+			continue
+		}
+		for j, b := range vectors[i] {
+			out[j] ^= gfMul(coefficient, b)
+		}
+	}
+}
+
+// matrix is a matrix over GF(2^8)
+type matrix []row
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for i := range m {
+		m[i] = make(row, cols)
+	}
+	return m
+}
+
+// newVandermonde returns a matrix whose element at (r, c) is r^c,
+// every square sub-matrix of which made of distinct rows is invertible
+func newVandermonde(rows, cols int) matrix {
+	m := newMatrix(rows, cols)
+	for r := range m {
+		for c := range m[r] {
+			m[r][c] = gfExp(byte(r), c)
+		}
+	}
+	return m
+}
+
+func (m matrix) multiply(other matrix) matrix {
+	res := newMatrix(len(m), len(other[0]))
+	for r := range res {
+		for c := range res[r] {
+			var v byte
+			for i := range other {
+				v ^= gfMul(m[r][i], other[i][c])
+			}
+			res[r][c] = v
+		}
+	}
+	return res
+}
+
+// invert returns the inverse of a square matrix using Gauss-Jordan elimination
+func (m matrix) invert() (matrix, error) {
+	n := len(m)
+	// work is m augmented with the identity matrix
+	work := newMatrix(n, 2*n)
+	for r := range m {
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+
+	for c := 0; c < n; c++ {
+		pivot := c
+		for pivot < n && work[pivot][c] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("matrix is singular")
+		}
+		work[c], work[pivot] = work[pivot], work[c]
+
+		if work[c][c] != 1 {
+			scale := work[c][c]
+			for i := range work[c] {
+				work[c][i] = gfDiv(work[c][i], scale)
+			}
+		}
+		for r := 0; r < n; r++ {
+			if r == c || work[r][c] == 0 {
+				continue
+			}
+			factor := work[r][c]
+			for i := range work[r] {
+				work[r][i] ^= gfMul(factor, work[c][i])
+			}
+		}
+	}
+
+	inverse := make(matrix, n)
+	for r := range work {
+		inverse[r] = work[r][n:]
+	}
+	return inverse, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package erasure
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MaxFragments is the maximum number of data and parity fragments
+// a Coder can split data into
+const MaxFragments = 256
+
+// Coder splits data into data fragments and parity fragments
+// using a systematic Reed-Solomon code over GF(2^8), such that the data
+// can be reconstructed from any subset of the fragments of the size of
+// the number of data fragments.
+type Coder struct {
+	dataFragments   int
+	parityFragments int
+	// encoding is a (dataFragments + parityFragments) x dataFragments matrix
+	// whose top dataFragments rows are the identity matrix
+	encoding matrix
+}
+
+// NewCoder creates a Coder that splits data into the given number
+// of data fragments and parity fragments
+func NewCoder(dataFragments, parityFragments int) (*Coder, error) {
+	if dataFragments <= 0 {
+		return nil, errors.Errorf("number of data fragments must be positive, but is %d", dataFragments)
+	}
+	if parityFragments < 0 {
+		return nil, errors.Errorf("number of parity fragments must not be negative, but is %d", parityFragments)
+	}
+	if dataFragments+parityFragments > MaxFragments {
+		return nil, errors.Errorf("total number of fragments must not exceed %d, but is %d", MaxFragments, dataFragments+parityFragments)
+	}
+	vandermonde := newVandermonde(dataFragments+parityFragments, dataFragments)
+	topInverse, err := vandermonde[:dataFragments].invert()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Coder{
+		dataFragments:   dataFragments,
+		parityFragments: parityFragments,
+		encoding:        vandermonde.multiply(topInverse),
+	}, nil
+}
+
+// DataFragments returns the number of data fragments
+func (c *Coder) DataFragments() int {
+	return c.dataFragments
+}
+
+// ParityFragments returns the number of parity fragments
+func (c *Coder) ParityFragments() int {
+	return c.parityFragments
+}
+
+// FragmentSize returns the size of every fragment of data of the given size
+func (c *Coder) FragmentSize(size int) int {
+	return (size + c.dataFragments - 1) / c.dataFragments
+}
+
+// Split splits the given data into data fragments followed by parity fragments,
+// all of the same size. The last data fragment is padded with zeros.
+func (c *Coder) Split(data []byte) [][]byte {
+	fragmentSize := c.FragmentSize(len(data))
+	padded := make([]byte, fragmentSize*(c.dataFragments+c.parityFragments))
+	copy(padded, data)
+
+	fragments := make([][]byte, c.dataFragments+c.parityFragments)
+	for i := range fragments {
+		fragments[i] = padded[i*fragmentSize : (i+1)*fragmentSize]
+	}
+	for i := 0; i < c.parityFragments; i++ {
+		c.encoding[c.dataFragments+i].combine(fragments[:c.dataFragments], fragments[c.dataFragments+i])
+	}
+	return fragments
+}
+
+// Join reconstructs data of the given size from the given fragments,
+// in which missing fragments are nil. At least as many fragments as the
+// number of data fragments should be present, and all of them should be
+// of the same size.
+func (c *Coder) Join(fragments [][]byte, size int) ([]byte, error) {
+	if len(fragments) != c.dataFragments+c.parityFragments {
+		return nil, errors.Errorf("expected %d fragments, got %d", c.dataFragments+c.parityFragments, len(fragments))
+	}
+	fragmentSize := c.FragmentSize(size)
+
+	// Pick the first dataFragments fragments that are present
+	var rows []int
+	for i, fragment := range fragments {
+		if fragment == nil {
+			continue
+		}
+		if len(fragment) != fragmentSize {
+			return nil, errors.Errorf("fragment %d is of size %d, expected %d", i, len(fragment), fragmentSize)
+		}
+		rows = append(rows, i)
+		if len(rows) == c.dataFragments {
+			break
+		}
+	}
+	if len(rows) < c.dataFragments {
+		return nil, errors.Errorf("%d fragments are needed to reconstruct the data, but only %d are present", c.dataFragments, len(rows))
+	}
+
+	data := make([]byte, fragmentSize*c.dataFragments)
+	present := make([][]byte, c.dataFragments)
+	for i, row := range rows {
+		present[i] = fragments[row]
+	}
+
+	// Data fragments that are present are copied as is, and the rest
+	// are computed by inverting the rows of the present fragments
+	sub := make(matrix, c.dataFragments)
+	for i, row := range rows {
+		sub[i] = c.encoding[row]
+	}
+	var decoding matrix
+	for i := 0; i < c.dataFragments; i++ {
+		out := data[i*fragmentSize : (i+1)*fragmentSize]
+		if fragments[i] != nil {
+			copy(out, fragments[i])
+			continue
+		}
+		if decoding == nil {
+			var err error
+			if decoding, err = sub.invert(); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		decoding[i].combine(present, out)
+	}
+	return data[:size], nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package erasure
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCoder(t *testing.T) {
+	_, err := NewCoder(0, 2)
+	assert.EqualError(t, err, "number of data fragments must be positive, but is 0")
+	_, err = NewCoder(4, -1)
+	assert.EqualError(t, err, "number of parity fragments must not be negative, but is -1")
+	_, err = NewCoder(200, 57)
+	assert.EqualError(t, err, "total number of fragments must not exceed 256, but is 257")
+
+	c, err := NewCoder(200, 56)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, c.DataFragments())
+	assert.Equal(t, 56, c.ParityFragments())
+}
+
+func TestGaloisField(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			assert.Equal(t, byte(a), gfDiv(gfMul(byte(a), byte(b)), byte(b)))
+		}
+	}
+	assert.Equal(t, byte(0), gfMul(0, 7))
+	assert.Equal(t, byte(8), gfExp(2, 3))
+}
+
+func TestSplitIsSystematic(t *testing.T) {
+	c, err := NewCoder(3, 2)
+	assert.NoError(t, err)
+	fragments := c.Split([]byte("abcdefgh"))
+	assert.Len(t, fragments, 5)
+	assert.Equal(t, []byte("abc"), fragments[0])
+	assert.Equal(t, []byte("def"), fragments[1])
+	assert.Equal(t, []byte{'g', 'h', 0}, fragments[2])
+	assert.Len(t, fragments[3], 3)
+	assert.Len(t, fragments[4], 3)
+}
+
+func TestJoin(t *testing.T) {
+	data := make([]byte, 1001)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	c, err := NewCoder(4, 3)
+	assert.NoError(t, err)
+
+	// Every subset of 4 fragments out of 7 reconstructs the data
+	for mask := 0; mask < 1<<7; mask++ {
+		fragments := c.Split(data)
+		present := 0
+		for i := range fragments {
+			if mask&(1<<uint(i)) == 0 {
+				fragments[i] = nil
+				continue
+			}
+			present++
+		}
+		joined, err := c.Join(fragments, len(data))
+		if present < 4 {
+			assert.EqualError(t, err, "4 fragments are needed to reconstruct the data, but only "+string(rune('0'+present))+" are present")
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, data, joined)
+	}
+}
+
+func TestJoinInvalidFragments(t *testing.T) {
+	c, err := NewCoder(2, 1)
+	assert.NoError(t, err)
+	fragments := c.Split([]byte("abcd"))
+
+	_, err = c.Join(fragments[:2], 4)
+	assert.EqualError(t, err, "expected 3 fragments, got 2")
+
+	fragments[1] = []byte("d")
+	_, err = c.Join(fragments, 4)
+	assert.EqualError(t, err, "fragment 1 is of size 1, expected 2")
+}
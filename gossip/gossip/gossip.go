@@ -36,6 +36,10 @@ type Gossip interface {
 	// GetPeers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
 
+	// MembershipState returns the alive and dead peers known to the discovery layer,
+	// along with their incarnation numbers
+	MembershipState() []discovery.MemberState
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChainID) []discovery.NetworkMember
@@ -151,4 +155,10 @@ type Config struct {
 	AliveExpirationCheckInterval time.Duration // Alive expiration check interval
 	ReconnectInterval            time.Duration // Reconnect interval
 
+	SessionKeysEnabled             bool          // Whether peers of the same org authenticate alive and state info messages with session keys
+	SessionKeyDistributionInterval time.Duration // Interval in which session keys are sent to peers of the same org
+
+	ErasureCodingMinBlockSize    int // Minimum size of blocks the leader disseminates as erasure coded fragments, 0 disables it
+	ErasureCodingDataFragments   int // Number of data fragments a block is split into
+	ErasureCodingParityFragments int // Number of parity fragments a block is split into
 }
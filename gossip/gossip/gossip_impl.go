@@ -65,6 +65,7 @@ type gossipServiceImpl struct {
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
 	gossipMetrics     *metrics.GossipMetrics
+	sessionKeys       *sessionKeys
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -96,6 +97,9 @@ func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	g.idMapper = identity.NewIdentityMapper(mcs, selfIdentity, func(pkiID common.PKIidType, identity api.PeerIdentityType) {
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 		g.certPuller.Remove(string(pkiID))
+		if g.sessionKeys != nil {
+			g.sessionKeys.remove(pkiID)
+		}
 	}, sa)
 
 	commConfig := comm.CommConfig{
@@ -112,6 +116,14 @@ func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		return nil
 	}
 
+	if conf.SessionKeysEnabled {
+		if conf.TLSCerts == nil {
+			lgr.Warning("Session keys can only be shared over TLS, alive and state info messages will be authenticated with signatures only")
+		} else {
+			g.sessionKeys = newSessionKeys(g.comm.GetPKIid())
+		}
+	}
+
 	g.chanState = newChannelState(g)
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
 		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
@@ -184,6 +196,10 @@ func (g *gossipServiceImpl) toDie() bool {
 func (g *gossipServiceImpl) JoinChan(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 	// joinMsg is supposed to have been already verified
 	g.chanState.joinChannel(joinMsg, chainID, g.gossipMetrics.MembershipMetrics)
+	// the peers eligible for the channel may have changed with its config
+	if g.sessionKeys != nil {
+		g.sessionKeys.resetChannel(string(chainID))
+	}
 
 	g.logger.Info("Joining gossip network of channel", string(chainID), "with", len(joinMsg.Members()), "organizations")
 	for _, org := range joinMsg.Members() {
@@ -198,6 +214,9 @@ func (g *gossipServiceImpl) LeaveChan(chainID common.ChainID) {
 		return
 	}
 	gc.LeaveChannel()
+	if g.sessionKeys != nil {
+		g.sessionKeys.resetChannel(string(chainID))
+	}
 }
 
 // SuspectPeers makes the gossip instance validate identities of suspected peers, and close
@@ -299,6 +318,9 @@ func (g *gossipServiceImpl) syncDiscovery() {
 func (g *gossipServiceImpl) start() {
 	go g.syncDiscovery()
 	go g.handlePresumedDead()
+	if g.sessionKeys != nil {
+		go g.distributeSessionKeys()
+	}
 
 	msgSelector := func(msg interface{}) bool {
 		gMsg, isGossipMsg := msg.(proto.ReceivedMessage)
@@ -353,6 +375,11 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 		return
 	}
 
+	if msg.IsSessionKeyMsg() {
+		g.handleSessionKey(m)
+		return
+	}
+
 	if msg.IsChannelRestricted() {
 		if gc := g.chanState.lookupChannelForMsg(m); gc == nil {
 			// If we're not in the channel, we should still forward to peers of our org
@@ -406,6 +433,123 @@ func (g *gossipServiceImpl) handleMessage(m proto.ReceivedMessage) {
 	}
 }
 
+// handleSessionKey stores a session key a peer of our organization sent us.
+// The key must belong to the peer on the other side of the connection, which
+// the comm layer authenticated during the handshake, and the message carrying
+// it must be signed by the peer, which has to be eligible for the channel of
+// the key.
+func (g *gossipServiceImpl) handleSessionKey(m proto.ReceivedMessage) {
+	if g.sessionKeys == nil {
+		return
+	}
+	sender := m.GetConnectionInfo().ID
+	msg := m.GetGossipMessage()
+	sessionKey := msg.GetSessionKey()
+	if !bytes.Equal(sessionKey.PkiId, sender) {
+		g.logger.Warning("Got a session key of", common.PKIidType(sessionKey.PkiId), "from", m.GetConnectionInfo(), ", discarding it")
+		return
+	}
+	if !g.isInMyorg(discovery.NetworkMember{PKIid: sender}) {
+		g.logger.Warning("Got a session key from", m.GetConnectionInfo(), "which isn't in our organization, discarding it")
+		return
+	}
+	identity, err := g.idMapper.Get(sender)
+	if err != nil {
+		g.logger.Warningf("Failed obtaining identity of %s: %+v", m.GetConnectionInfo(), err)
+		return
+	}
+	if err := g.verifySessionKeyMsg(msg, identity); err != nil {
+		g.logger.Warningf("Failed verifying session key of %s: %+v", m.GetConnectionInfo(), err)
+		return
+	}
+	if err := g.sessionKeys.put(sender, string(msg.Channel), sessionKey.Key); err != nil {
+		g.logger.Warningf("Got an invalid session key from %s: %+v", m.GetConnectionInfo(), err)
+	}
+}
+
+// verifySessionKeyMsg verifies the signature of a message carrying a session
+// key, against the channel of the key if it isn't the key of AliveMessages
+func (g *gossipServiceImpl) verifySessionKeyMsg(msg *proto.SignedGossipMessage, identity api.PeerIdentityType) error {
+	chainID := common.ChainID(msg.Channel)
+	if len(chainID) == 0 {
+		return msg.Verify(identity, func(peerIdentity []byte, signature, message []byte) error {
+			return g.mcs.Verify(api.PeerIdentityType(peerIdentity), signature, message)
+		})
+	}
+	if g.chanState.getGossipChannelByChainID(chainID) == nil {
+		return errors.Errorf("not in channel %s", string(chainID))
+	}
+	return msg.Verify(identity, func(peerIdentity []byte, signature, message []byte) error {
+		return g.mcs.VerifyByChannel(chainID, api.PeerIdentityType(peerIdentity), signature, message)
+	})
+}
+
+func (g *gossipServiceImpl) distributeSessionKeys() {
+	g.logger.Debug("Entering session key distribution with interval", g.conf.SessionKeyDistributionInterval)
+	defer g.logger.Debug("Exiting session key distribution loop")
+	// the peers of our organization we sent our key of each channel to
+	recipients := make(map[string]map[string]struct{})
+	for !g.toDie() {
+		g.sendSessionKey(nil, g.disc.GetMembership(), recipients)
+		for _, chainID := range g.chanState.chainIDs() {
+			if gc := g.chanState.getGossipChannelByChainID(chainID); gc != nil {
+				g.sendSessionKey(chainID, gc.GetPeers(), recipients)
+			}
+		}
+		time.Sleep(g.conf.SessionKeyDistributionInterval)
+	}
+}
+
+// sendSessionKey sends our session key of the given channel, or of our
+// AliveMessages if the channel is nil, to the given peers of our organization.
+// The key is rotated first if a peer it was sent to isn't among them anymore.
+func (g *gossipServiceImpl) sendSessionKey(chainID common.ChainID, members []discovery.NetworkMember, recipients map[string]map[string]struct{}) {
+	var peers []*comm.RemotePeer
+	current := make(map[string]struct{})
+	for _, member := range members {
+		if !g.isInMyorg(member) || bytes.Equal(member.PKIid, g.comm.GetPKIid()) {
+			continue
+		}
+		peers = append(peers, &comm.RemotePeer{PKIID: member.PKIid, Endpoint: member.PreferredEndpoint()})
+		current[string(member.PKIid)] = struct{}{}
+	}
+	for pkiID := range recipients[string(chainID)] {
+		if _, exists := current[pkiID]; !exists {
+			g.logger.Debug("Peer", common.PKIidType(pkiID), "left, rotating session key of channel", string(chainID))
+			g.sessionKeys.rotate(string(chainID))
+			break
+		}
+	}
+	recipients[string(chainID)] = current
+	if len(peers) == 0 {
+		return
+	}
+	key, err := g.sessionKeys.selfKey(string(chainID))
+	if err != nil {
+		g.logger.Warningf("Failed obtaining session key of channel %s: %+v", chainID, err)
+		return
+	}
+	msg := &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Channel: chainID,
+			Tag:     proto.GossipMessage_ORG_ONLY,
+			Content: &proto.GossipMessage_SessionKey{
+				SessionKey: &proto.SessionKey{
+					Key:   key,
+					PkiId: g.comm.GetPKIid(),
+				},
+			},
+		},
+	}
+	if _, err := msg.Sign(func(msg []byte) ([]byte, error) {
+		return g.mcs.Sign(msg)
+	}); err != nil {
+		g.logger.Warningf("Failed signing session key: %+v", errors.WithStack(err))
+		return
+	}
+	g.comm.Send(msg, peers...)
+}
+
 func (g *gossipServiceImpl) forwardDiscoveryMsg(msg proto.ReceivedMessage) {
 	defer func() { // can be closed while shutting down
 		recover()
@@ -687,6 +831,10 @@ func (g *gossipServiceImpl) Gossip(msg *proto.GossipMessage) {
 		}
 		if msg.IsDataMsg() {
 			gc.AddToMsgStore(sMsg)
+			// Large blocks are disseminated to the peers of our organization as fragments
+			if gc.DisseminateFragments(sMsg) {
+				return
+			}
 		}
 	}
 
@@ -716,6 +864,12 @@ func (g *gossipServiceImpl) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// MembershipState returns the alive and dead peers known to the discovery layer,
+// along with their incarnation numbers
+func (g *gossipServiceImpl) MembershipState() []discovery.MemberState {
+	return g.disc.MembershipState()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.NetworkMember {
@@ -988,6 +1142,7 @@ type discoverySecurityAdapter struct {
 	mcs                   api.MessageCryptoService
 	c                     comm.Comm
 	logger                util.Logger
+	sessionKeys           *sessionKeys
 }
 
 func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
@@ -999,6 +1154,7 @@ func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdap
 		logger:                g.logger,
 		includeIdentityPeriod: g.includeIdentityPeriod,
 		identity:              g.selfIdentity,
+		sessionKeys:           g.sessionKeys,
 	}
 }
 
@@ -1053,19 +1209,28 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 		return nil
 	}
 
-	if internalEndpoint == "" {
-		return e
+	if internalEndpoint != "" {
+		e.SignSecret(signer, &proto.Secret{
+			Content: &proto.Secret_InternalEndpoint{
+				InternalEndpoint: internalEndpoint,
+			},
+		})
+	}
+	if sa.sessionKeys != nil && m.IsAliveMsg() {
+		if err := sa.sessionKeys.authenticateAliveMsg(e); err != nil {
+			sa.logger.Warningf("Failed authenticating message with a session key: %+v", errors.WithStack(err))
+		}
 	}
-	e.SignSecret(signer, &proto.Secret{
-		Content: &proto.Secret_InternalEndpoint{
-			InternalEndpoint: internalEndpoint,
-		},
-	})
 	return e
 }
 
 func (sa *discoverySecurityAdapter) validateAliveMsgSignature(m *proto.SignedGossipMessage, identity api.PeerIdentityType) bool {
 	am := m.GetAliveMsg()
+	// Peers of our organization that shared their session key with us authenticate
+	// their AliveMessages with an HMAC, which is much cheaper to verify than a signature
+	if sa.sessionKeys != nil && sa.sessionKeys.verifyAliveMsg(m) {
+		return true
+	}
 	// At this point we got the certificate of the peer, proceed to verifying the AliveMessage
 	verifier := func(peerIdentity []byte, signature, message []byte) error {
 		return sa.mcs.Verify(api.PeerIdentityType(peerIdentity), signature, message)
@@ -1224,6 +1389,9 @@ func (g *gossipServiceImpl) validateLeadershipMessage(msg *proto.SignedGossipMes
 }
 
 func (g *gossipServiceImpl) validateStateInfoMsg(msg *proto.SignedGossipMessage) error {
+	if g.sessionKeys != nil && g.sessionKeys.verifyStateInfoMsg(msg) {
+		return nil
+	}
 	verifier := func(identity []byte, signature, message []byte) error {
 		pkiID := g.idMapper.GetPKIidOfCert(api.PeerIdentityType(identity))
 		if pkiID == nil {
@@ -1277,6 +1445,7 @@ func (g *gossipServiceImpl) disclosurePolicy(remotePeer *discovery.NetworkMember
 			envelope := protoG.Clone(msg.Envelope).(*proto.Envelope)
 			if !bytes.Equal(g.selfOrg, remotePeerOrg) {
 				envelope.SecretEnvelope = nil
+				envelope.Hmac = nil
 			}
 			return envelope
 		}
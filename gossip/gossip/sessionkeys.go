@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+const sessionKeySize = 32
+
+type sessionKeyID struct {
+	pkiID   string
+	channel string
+}
+
+// sessionKeys maintains the symmetric keys that peers of the same organization
+// authenticate their AliveMessages and StateInfo messages with, so that peers
+// of the organization verify an HMAC instead of a signature.
+// Every peer has a key for its AliveMessages (the empty channel), and a key for
+// its StateInfo messages of every channel it joined, and shares them only with
+// peers of its organization over their mutual-TLS connection.
+type sessionKeys struct {
+	sync.RWMutex
+	selfPKIID  common.PKIidType
+	selfKeys   map[string][]byte
+	remoteKeys map[sessionKeyID][]byte
+}
+
+func newSessionKeys(selfPKIID common.PKIidType) *sessionKeys {
+	return &sessionKeys{
+		selfPKIID:  selfPKIID,
+		selfKeys:   make(map[string][]byte),
+		remoteKeys: make(map[sessionKeyID][]byte),
+	}
+}
+
+// selfKey returns the key of the given channel, and creates it if it doesn't exist yet
+func (sk *sessionKeys) selfKey(channel string) ([]byte, error) {
+	sk.Lock()
+	defer sk.Unlock()
+	if key, exists := sk.selfKeys[channel]; exists {
+		return key, nil
+	}
+	key := make([]byte, sessionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "failed generating session key")
+	}
+	sk.selfKeys[channel] = key
+	return key, nil
+}
+
+// rotate discards our key of the given channel, so that a new key is created
+// and shared with the peers of our organization
+func (sk *sessionKeys) rotate(channel string) {
+	sk.Lock()
+	defer sk.Unlock()
+	delete(sk.selfKeys, channel)
+}
+
+// resetChannel rotates our key of the given channel and removes the keys
+// other peers shared with us for it
+func (sk *sessionKeys) resetChannel(channel string) {
+	sk.Lock()
+	defer sk.Unlock()
+	delete(sk.selfKeys, channel)
+	for id := range sk.remoteKeys {
+		if id.channel == channel {
+			delete(sk.remoteKeys, id)
+		}
+	}
+}
+
+// put stores the key a peer of our organization shared with us for the given channel
+func (sk *sessionKeys) put(pkiID common.PKIidType, channel string, key []byte) error {
+	if len(key) != sessionKeySize {
+		return errors.Errorf("session key should be %d bytes, but is %d bytes", sessionKeySize, len(key))
+	}
+	sk.Lock()
+	defer sk.Unlock()
+	sk.remoteKeys[sessionKeyID{pkiID: string(pkiID), channel: channel}] = key
+	return nil
+}
+
+// remove removes all keys the given peer shared with us
+func (sk *sessionKeys) remove(pkiID common.PKIidType) {
+	sk.Lock()
+	defer sk.Unlock()
+	for id := range sk.remoteKeys {
+		if id.pkiID == string(pkiID) {
+			delete(sk.remoteKeys, id)
+		}
+	}
+}
+
+// authenticateAliveMsg computes the HMAC of the given envelope of an AliveMessage
+func (sk *sessionKeys) authenticateAliveMsg(e *proto.Envelope) error {
+	key, err := sk.selfKey("")
+	if err != nil {
+		return err
+	}
+	e.Hmac = computeHMAC(key, e)
+	return nil
+}
+
+// authenticateStateInfoMsg computes the HMAC of the given StateInfo message,
+// if we already have a key for the channel the message belongs to
+func (sk *sessionKeys) authenticateStateInfoMsg(msg *proto.SignedGossipMessage) {
+	mac := msg.GetStateInfo().Channel_MAC
+	sk.RLock()
+	defer sk.RUnlock()
+	for chainID, key := range sk.selfKeys {
+		if chainID != "" && bytes.Equal(channel.GenerateMAC(sk.selfPKIID, common.ChainID(chainID)), mac) {
+			msg.Envelope.Hmac = computeHMAC(key, msg.Envelope)
+			return
+		}
+	}
+}
+
+// verifyAliveMsg returns whether the given AliveMessage carries a valid HMAC
+func (sk *sessionKeys) verifyAliveMsg(msg *proto.SignedGossipMessage) bool {
+	if msg.Envelope == nil || len(msg.Envelope.Hmac) == 0 {
+		return false
+	}
+	sk.RLock()
+	key, exists := sk.remoteKeys[sessionKeyID{pkiID: string(msg.GetAliveMsg().Membership.PkiId)}]
+	sk.RUnlock()
+	return exists && hmac.Equal(computeHMAC(key, msg.Envelope), msg.Envelope.Hmac)
+}
+
+// verifyStateInfoMsg returns whether the given StateInfo message carries a valid HMAC
+func (sk *sessionKeys) verifyStateInfoMsg(msg *proto.SignedGossipMessage) bool {
+	if msg.Envelope == nil || len(msg.Envelope.Hmac) == 0 {
+		return false
+	}
+	si := msg.GetStateInfo()
+	sk.RLock()
+	defer sk.RUnlock()
+	for id, key := range sk.remoteKeys {
+		if id.pkiID != string(si.PkiId) || id.channel == "" {
+			continue
+		}
+		if bytes.Equal(channel.GenerateMAC(si.PkiId, common.ChainID(id.channel)), si.Channel_MAC) {
+			return hmac.Equal(computeHMAC(key, msg.Envelope), msg.Envelope.Hmac)
+		}
+	}
+	return false
+}
+
+func computeHMAC(key []byte, e *proto.Envelope) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(e.Payload)
+	if e.SecretEnvelope != nil {
+		mac.Write(e.SecretEnvelope.Payload)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func aliveMsgOf(t *testing.T, pkiID common.PKIidType) *proto.SignedGossipMessage {
+	msg, err := (&proto.GossipMessage{
+		Tag: proto.GossipMessage_EMPTY,
+		Content: &proto.GossipMessage_AliveMsg{
+			AliveMsg: &proto.AliveMessage{
+				Membership: &proto.Member{
+					PkiId:    pkiID,
+					Endpoint: "p1:7051",
+				},
+				Timestamp: &proto.PeerTime{IncNum: 1, SeqNum: 1},
+			},
+		},
+	}).NoopSign()
+	assert.NoError(t, err)
+	return msg
+}
+
+func stateInfoMsgOf(t *testing.T, pkiID common.PKIidType, chainID common.ChainID) *proto.SignedGossipMessage {
+	msg, err := (&proto.GossipMessage{
+		Tag: proto.GossipMessage_CHAN_OR_ORG,
+		Content: &proto.GossipMessage_StateInfo{
+			StateInfo: &proto.StateInfo{
+				PkiId:       pkiID,
+				Channel_MAC: channel.GenerateMAC(pkiID, chainID),
+				Timestamp:   &proto.PeerTime{IncNum: 1, SeqNum: 1},
+			},
+		},
+	}).NoopSign()
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestSessionKeysAliveMsg(t *testing.T) {
+	p1 := newSessionKeys(common.PKIidType("p1"))
+	p2 := newSessionKeys(common.PKIidType("p2"))
+
+	msg := aliveMsgOf(t, common.PKIidType("p1"))
+	assert.NoError(t, p1.authenticateAliveMsg(msg.Envelope))
+	assert.Len(t, msg.Envelope.Hmac, 32)
+
+	// p2 didn't get the key of p1 yet
+	assert.False(t, p2.verifyAliveMsg(msg))
+
+	key, err := p1.selfKey("")
+	assert.NoError(t, err)
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "", key))
+	assert.True(t, p2.verifyAliveMsg(msg))
+
+	// The key of a channel doesn't authenticate AliveMessages
+	chKey, err := p1.selfKey("A")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, chKey)
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "", chKey))
+	assert.False(t, p2.verifyAliveMsg(msg))
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "", key))
+
+	// A tampered payload fails verification
+	tampered := aliveMsgOf(t, common.PKIidType("p1"))
+	tampered.Envelope.Hmac = msg.Envelope.Hmac
+	tampered.Envelope.Payload = append(tampered.Envelope.Payload, 0)
+	assert.False(t, p2.verifyAliveMsg(tampered))
+
+	// A message without an HMAC isn't authenticated
+	assert.False(t, p2.verifyAliveMsg(aliveMsgOf(t, common.PKIidType("p1"))))
+
+	// Keys of purged peers are removed
+	p2.remove(common.PKIidType("p1"))
+	assert.False(t, p2.verifyAliveMsg(msg))
+}
+
+func TestSessionKeysStateInfoMsg(t *testing.T) {
+	p1 := newSessionKeys(common.PKIidType("p1"))
+	p2 := newSessionKeys(common.PKIidType("p2"))
+
+	// No key for the channel yet, so the message isn't authenticated
+	msg := stateInfoMsgOf(t, common.PKIidType("p1"), common.ChainID("A"))
+	p1.authenticateStateInfoMsg(msg)
+	assert.Empty(t, msg.Envelope.Hmac)
+
+	keyA, err := p1.selfKey("A")
+	assert.NoError(t, err)
+	keyB, err := p1.selfKey("B")
+	assert.NoError(t, err)
+	p1.authenticateStateInfoMsg(msg)
+	assert.NotEmpty(t, msg.Envelope.Hmac)
+
+	// p2 only has the key of channel B
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "B", keyB))
+	assert.False(t, p2.verifyStateInfoMsg(msg))
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "A", keyA))
+	assert.True(t, p2.verifyStateInfoMsg(msg))
+
+	// A message of another peer with the same channel isn't authenticated by the key of p1
+	other := stateInfoMsgOf(t, common.PKIidType("p3"), common.ChainID("A"))
+	other.Envelope.Hmac = msg.Envelope.Hmac
+	assert.False(t, p2.verifyStateInfoMsg(other))
+}
+
+func TestSessionKeysPut(t *testing.T) {
+	sk := newSessionKeys(common.PKIidType("p1"))
+	err := sk.put(common.PKIidType("p2"), "A", []byte{1, 2, 3})
+	assert.EqualError(t, err, "session key should be 32 bytes, but is 3 bytes")
+	assert.Empty(t, sk.remoteKeys)
+}
+
+func TestSessionKeysRotation(t *testing.T) {
+	p1 := newSessionKeys(common.PKIidType("p1"))
+	p2 := newSessionKeys(common.PKIidType("p2"))
+
+	keyA, err := p1.selfKey("A")
+	assert.NoError(t, err)
+	aliveKey, err := p1.selfKey("")
+	assert.NoError(t, err)
+
+	// A rotated key is replaced by a new one, the other keys are kept
+	p1.rotate("A")
+	rotated, err := p1.selfKey("A")
+	assert.NoError(t, err)
+	assert.NotEqual(t, keyA, rotated)
+	key, err := p1.selfKey("")
+	assert.NoError(t, err)
+	assert.Equal(t, aliveKey, key)
+
+	// A reset of a channel drops the keys other peers shared for it
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "A", rotated))
+	assert.NoError(t, p2.put(common.PKIidType("p1"), "", aliveKey))
+	msg := stateInfoMsgOf(t, common.PKIidType("p1"), common.ChainID("A"))
+	p1.authenticateStateInfoMsg(msg)
+	assert.True(t, p2.verifyStateInfoMsg(msg))
+	p2.resetChannel("A")
+	assert.False(t, p2.verifyStateInfoMsg(msg))
+	alive := aliveMsgOf(t, common.PKIidType("p1"))
+	assert.NoError(t, p1.authenticateAliveMsg(alive.Envelope))
+	assert.True(t, p2.verifyAliveMsg(alive))
+}
+
+func TestVerifySessionKeyMsg(t *testing.T) {
+	g := &gossipServiceImpl{
+		mcs: &naiveCryptoService{allowedPkiIDS: map[string]struct{}{"p1": {}}},
+	}
+	g.chanState = &channelState{
+		channels: map[string]channel.GossipChannel{"A": struct{ channel.GossipChannel }{}},
+		g:        g,
+	}
+
+	sessionKeyMsg := func(chainID string) *proto.SignedGossipMessage {
+		msg := &proto.SignedGossipMessage{
+			GossipMessage: &proto.GossipMessage{
+				Channel: common.ChainID(chainID),
+				Tag:     proto.GossipMessage_ORG_ONLY,
+				Content: &proto.GossipMessage_SessionKey{
+					SessionKey: &proto.SessionKey{
+						Key:   make([]byte, sessionKeySize),
+						PkiId: common.PKIidType("p1"),
+					},
+				},
+			},
+		}
+		_, err := msg.Sign(g.mcs.Sign)
+		assert.NoError(t, err)
+		return msg
+	}
+
+	assert.NoError(t, g.verifySessionKeyMsg(sessionKeyMsg(""), api.PeerIdentityType("p1")))
+	assert.NoError(t, g.verifySessionKeyMsg(sessionKeyMsg("A"), api.PeerIdentityType("p1")))
+
+	// The peer isn't eligible for the channel
+	assert.Error(t, g.verifySessionKeyMsg(sessionKeyMsg("A"), api.PeerIdentityType("p2")))
+	// We aren't in the channel
+	assert.EqualError(t, g.verifySessionKeyMsg(sessionKeyMsg("B"), api.PeerIdentityType("p1")), "not in channel B")
+	// The signature is invalid
+	tampered := sessionKeyMsg("")
+	tampered.Envelope.Signature = []byte{1}
+	assert.Error(t, g.verifySessionKeyMsg(tampered, api.PeerIdentityType("p1")))
+}
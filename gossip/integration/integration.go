@@ -72,6 +72,11 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 	conf.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*conf.AliveTimeInterval)
 	conf.AliveExpirationCheckInterval = conf.AliveExpirationTimeout / 10
 	conf.ReconnectInterval = util.GetDurationOrDefault("peer.gossip.reconnectInterval", conf.AliveExpirationTimeout)
+	conf.SessionKeysEnabled = viper.GetBool("peer.gossip.sessionKeys.enabled")
+	conf.SessionKeyDistributionInterval = util.GetDurationOrDefault("peer.gossip.sessionKeys.distributionInterval", 10*time.Second)
+	conf.ErasureCodingMinBlockSize = viper.GetInt("peer.gossip.erasureCoding.minBlockSize")
+	conf.ErasureCodingDataFragments = util.GetIntOrDefault("peer.gossip.erasureCoding.dataFragments", 4)
+	conf.ErasureCodingParityFragments = util.GetIntOrDefault("peer.gossip.erasureCoding.parityFragments", 2)
 
 	return conf, nil
 }
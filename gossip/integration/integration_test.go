@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package integration
 
 import (
+	"crypto/rand"
 	"fmt"
 	"net"
 	"strings"
@@ -17,10 +18,12 @@ import (
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -72,6 +75,91 @@ func TestNewGossipCryptoService(t *testing.T) {
 	go s3.Serve(ll3)
 }
 
+func TestErasureCodedBlockDissemination(t *testing.T) {
+	setupTestEnv()
+	viper.Set("peer.gossip.erasureCoding.minBlockSize", 1024)
+	viper.Set("peer.gossip.erasureCoding.dataFragments", 2)
+	viper.Set("peer.gossip.erasureCoding.parityFragments", 1)
+	defer viper.Set("peer.gossip.erasureCoding.minBlockSize", 0)
+
+	chainID := common.ChainID("A")
+	gossipMetrics := metrics.NewGossipMetrics(&disabled.Provider{})
+	var peers []gossip.Gossip
+	var bootstrap string
+	for i := 0; i < 4; i++ {
+		s := grpc.NewServer()
+		ll, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		endpoint := ll.Addr().String()
+		var bootPeers []string
+		if i == 0 {
+			bootstrap = endpoint
+		} else {
+			bootPeers = append(bootPeers, bootstrap)
+		}
+		// Every peer's identity is its endpoint
+		g, err := NewGossipComponent([]byte(endpoint), endpoint, s, secAdv, cryptSvc,
+			defaultSecureDialOpts, nil, gossipMetrics, bootPeers...)
+		assert.NoError(t, err)
+		defer g.Stop()
+		go s.Serve(ll)
+		g.JoinChan(&joinChanMsg{}, chainID)
+		g.UpdateLedgerHeight(1, chainID)
+		peers = append(peers, g)
+	}
+
+	// Wait for all peers to know each other in the channel
+	fullMembership := func() bool {
+		for _, g := range peers {
+			if len(g.PeersOfChannel(chainID)) != len(peers)-1 {
+				return false
+			}
+		}
+		return true
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for !fullMembership() {
+		if time.Now().After(deadline) {
+			t.Fatal("Peers didn't form a membership in the channel in time")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var blocks []<-chan *proto.GossipMessage
+	for _, g := range peers[1:] {
+		blockChan, _ := g.Accept(func(o interface{}) bool {
+			return o.(*proto.GossipMessage).IsDataMsg()
+		}, false)
+		blocks = append(blocks, blockChan)
+	}
+
+	data := make([]byte, 10*1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	peers[0].Gossip(&proto.GossipMessage{
+		Channel: chainID,
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: &proto.Payload{
+					SeqNum: 1,
+					Data:   data,
+				},
+			},
+		},
+	})
+
+	for i, blockChan := range blocks {
+		select {
+		case msg := <-blockChan:
+			assert.Equal(t, uint64(1), msg.GetDataMsg().Payload.SeqNum)
+			assert.Equal(t, data, msg.GetDataMsg().Payload.Data)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Peer %d didn't get the block in time", i+1)
+		}
+	}
+}
+
 func setupTestEnv() {
 	viper.SetConfigName("core")
 	viper.SetEnvPrefix("CORE")
@@ -121,3 +209,18 @@ func (s *cryptoService) VerifyByChannel(chainID common.ChainID, peerIdentity api
 func (s *cryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
+
+type joinChanMsg struct {
+}
+
+func (*joinChanMsg) SequenceNumber() uint64 {
+	return 1
+}
+
+func (*joinChanMsg) Members() []api.OrgIdentityType {
+	return []api.OrgIdentityType{api.OrgIdentityType("SampleOrg")}
+}
+
+func (*joinChanMsg) AnchorPeersOf(org api.OrgIdentityType) []api.AnchorPeer {
+	return nil
+}
@@ -167,6 +167,11 @@ type PrivdataMetrics struct {
 	ReconciliationDuration         metrics.Histogram
 	PullDuration                   metrics.Histogram
 	RetrieveDuration               metrics.Histogram
+	AntiEntropyDuration            metrics.Histogram
+	AntiEntropyMismatchedBlocks    metrics.Counter
+	AntiEntropyCorruptedElements   metrics.Counter
+	AntiEntropyMissingElements     metrics.Counter
+	AntiEntropyRepairedElements    metrics.Counter
 }
 
 func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
@@ -180,6 +185,11 @@ func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
 		ReconciliationDuration:         p.NewHistogram(ReconciliationDurationOpts),
 		PullDuration:                   p.NewHistogram(PullDurationOpts),
 		RetrieveDuration:               p.NewHistogram(RetrieveDurationOpts),
+		AntiEntropyDuration:            p.NewHistogram(AntiEntropyDurationOpts),
+		AntiEntropyMismatchedBlocks:    p.NewCounter(AntiEntropyMismatchedBlocksOpts),
+		AntiEntropyCorruptedElements:   p.NewCounter(AntiEntropyCorruptedElementsOpts),
+		AntiEntropyMissingElements:     p.NewCounter(AntiEntropyMissingElementsOpts),
+		AntiEntropyRepairedElements:    p.NewCounter(AntiEntropyRepairedElementsOpts),
 	}
 }
 
@@ -264,4 +274,49 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	AntiEntropyDurationOpts = metrics.HistogramOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "anti_entropy_duration",
+		Help:         "Time it takes for an anti-entropy round to complete (in seconds)",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	AntiEntropyMismatchedBlocksOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "anti_entropy_mismatched_blocks",
+		Help:         "Number of blocks whose private data hashes differ from those of peers of the same organization",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	AntiEntropyCorruptedElementsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "anti_entropy_corrupted_elements",
+		Help:         "Number of stored private data elements that do not match the hashes in the block",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	AntiEntropyMissingElementsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "anti_entropy_missing_elements",
+		Help:         "Number of eligible private data elements that were found missing by the anti-entropy",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	AntiEntropyRepairedElementsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "anti_entropy_repaired_elements",
+		Help:         "Number of private data elements repaired by the anti-entropy",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
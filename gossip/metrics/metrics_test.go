@@ -56,4 +56,9 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.ReconciliationDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PullDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.RetrieveDuration)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.AntiEntropyDuration)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.AntiEntropyMismatchedBlocks)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.AntiEntropyCorruptedElements)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.AntiEntropyMissingElements)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.AntiEntropyRepairedElements)
 }
@@ -36,6 +36,11 @@ type TestMetricProvider struct {
 	FakeReconciliationDuration         *metricsfakes.Histogram
 	FakePullDuration                   *metricsfakes.Histogram
 	FakeRetrieveDuration               *metricsfakes.Histogram
+	FakeAntiEntropyDuration            *metricsfakes.Histogram
+	FakeAntiEntropyMismatchedBlocks    *metricsfakes.Counter
+	FakeAntiEntropyCorruptedElements   *metricsfakes.Counter
+	FakeAntiEntropyMissingElements     *metricsfakes.Counter
+	FakeAntiEntropyRepairedElements    *metricsfakes.Counter
 }
 
 func TestUtilConstructMetricProvider() *TestMetricProvider {
@@ -62,6 +67,11 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeReconciliationDuration := testUtilConstructHist()
 	fakePullDuration := testUtilConstructHist()
 	fakeRetrieveDuration := testUtilConstructHist()
+	fakeAntiEntropyDuration := testUtilConstructHist()
+	fakeAntiEntropyMismatchedBlocks := testUtilConstructCounter()
+	fakeAntiEntropyCorruptedElements := testUtilConstructCounter()
+	fakeAntiEntropyMissingElements := testUtilConstructCounter()
+	fakeAntiEntropyRepairedElements := testUtilConstructCounter()
 
 	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
 		switch opts.Name {
@@ -71,6 +81,14 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.AntiEntropyMismatchedBlocksOpts.Name:
+			return fakeAntiEntropyMismatchedBlocks
+		case gmetrics.AntiEntropyCorruptedElementsOpts.Name:
+			return fakeAntiEntropyCorruptedElements
+		case gmetrics.AntiEntropyMissingElementsOpts.Name:
+			return fakeAntiEntropyMissingElements
+		case gmetrics.AntiEntropyRepairedElementsOpts.Name:
+			return fakeAntiEntropyRepairedElements
 		}
 		return nil
 	}
@@ -96,6 +114,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakePullDuration
 		case gmetrics.RetrieveDurationOpts.Name:
 			return fakeRetrieveDuration
+		case gmetrics.AntiEntropyDurationOpts.Name:
+			return fakeAntiEntropyDuration
 		}
 		return nil
 	}
@@ -132,6 +152,11 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeReconciliationDuration,
 		fakePullDuration,
 		fakeRetrieveDuration,
+		fakeAntiEntropyDuration,
+		fakeAntiEntropyMismatchedBlocks,
+		fakeAntiEntropyCorruptedElements,
+		fakeAntiEntropyMissingElements,
+		fakeAntiEntropyRepairedElements,
 	}
 }
 
@@ -0,0 +1,540 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/committer"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/filter"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// maxPvtDataHashesRange bounds the number of blocks whose private data hashes
+// are computed in response to a single request of a remote peer
+const maxPvtDataHashesRange = 1000
+
+// PvtDataAntiEntropy periodically compares the hashes of the private data stored by this peer
+// with the ones of the peers of the same organization, and repairs the private data of the
+// blocks that differ, if it doesn't match the hashes recorded in the block or if it is missing
+type PvtDataAntiEntropy interface {
+	// Start starts the anti-entropy in the background
+	Start()
+	// Stop stops the anti-entropy
+	Stop()
+}
+
+// NoOpAntiEntropy non functional anti-entropy to be used
+// in case the anti-entropy has been disabled
+type NoOpAntiEntropy struct {
+}
+
+func (*NoOpAntiEntropy) Start() {
+	// do nothing
+	logger.Debug("Private data anti-entropy has been disabled")
+}
+
+func (*NoOpAntiEntropy) Stop() {
+	// do nothing
+}
+
+// AntiEntropyConfig holds config flags that are read from core.yaml
+type AntiEntropyConfig struct {
+	IsEnabled bool
+	// Interval is the time between two consecutive anti-entropy rounds
+	Interval time.Duration
+	// BlockWindow is the number of blocks whose private data hashes are compared in a round
+	BlockWindow int
+	// PeerNum is the number of peers of the same organization that are contacted in a round
+	PeerNum int
+}
+
+// AntiEntropySupport encapsulates the components the anti-entropy depends on
+type AntiEntropySupport struct {
+	ChainID string
+	privdata.CollectionStore
+	committer.Committer
+	ReconciliationFetcher
+	api.SecurityAdvisor
+}
+
+type antiEntropy struct {
+	mspID          string
+	selfSignedData common.SignedData
+	AntiEntropySupport
+	gossip
+	metrics  *metrics.PrivdataMetrics
+	config   *AntiEntropyConfig
+	pubSub   *util.PubSub
+	msgChan  <-chan proto.ReceivedMessage
+	stopChan chan struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	// cursor is the most recent block of the window that is compared in the next round,
+	// zero means that the next round starts from the top of the ledger
+	cursor uint64
+}
+
+// NewAntiEntropy creates a new instance of the private data anti-entropy
+func NewAntiEntropy(mspID string, support AntiEntropySupport, g gossip, selfSignedData common.SignedData,
+	metrics *metrics.PrivdataMetrics, config *AntiEntropyConfig) PvtDataAntiEntropy {
+	logger.Debug("Private data anti-entropy is enabled")
+	ae := &antiEntropy{
+		mspID:              mspID,
+		selfSignedData:     selfSignedData,
+		AntiEntropySupport: support,
+		gossip:             g,
+		metrics:            metrics,
+		config:             config,
+		pubSub:             util.NewPubSub(),
+		stopChan:           make(chan struct{}),
+	}
+	_, ae.msgChan = ae.Accept(func(o interface{}) bool {
+		msg := o.(proto.ReceivedMessage).GetGossipMessage()
+		if !bytes.Equal(msg.Channel, []byte(ae.ChainID)) {
+			return false
+		}
+		return msg.IsPvtDataHashesMsg()
+	}, true)
+	return ae
+}
+
+func (ae *antiEntropy) Start() {
+	ae.startOnce.Do(func() {
+		go ae.listen()
+		go ae.run()
+	})
+}
+
+func (ae *antiEntropy) Stop() {
+	ae.stopOnce.Do(func() {
+		close(ae.stopChan)
+	})
+}
+
+func (ae *antiEntropy) listen() {
+	for {
+		select {
+		case <-ae.stopChan:
+			return
+		case msg := <-ae.msgChan:
+			if msg == nil {
+				// comm module stopped, hence this channel
+				// closed
+				return
+			}
+			if msg.GetGossipMessage().GetPvtHashesRes() != nil {
+				ae.pubSub.Publish(nonceTopic(msg.GetGossipMessage().Nonce), msg.GetGossipMessage().GetPvtHashesRes())
+			}
+			if msg.GetGossipMessage().GetPvtHashesReq() != nil {
+				ae.handleRequest(msg)
+			}
+		}
+	}
+}
+
+func (ae *antiEntropy) run() {
+	for {
+		select {
+		case <-ae.stopChan:
+			return
+		case <-time.After(ae.config.Interval):
+			if err := ae.round(); err != nil {
+				logger.Warning("Private data anti-entropy round failed:", err)
+			}
+		}
+	}
+}
+
+func (ae *antiEntropy) handleRequest(message proto.ReceivedMessage) {
+	connInfo := message.GetConnectionInfo()
+	if !bytes.Equal(ae.OrgByPeerIdentity(connInfo.Identity), []byte(ae.mspID)) {
+		logger.Warning("Peer", connInfo.Endpoint, "isn't in our organization, ignoring its private data hashes request")
+		return
+	}
+	req := message.GetGossipMessage().GetPvtHashesReq()
+	hashes, err := ae.blockHashes(req.StartBlock, req.EndBlock)
+	if err != nil {
+		logger.Warningf("Failed computing private data hashes of blocks [%d - %d] for %s: %s", req.StartBlock, req.EndBlock, connInfo.Endpoint, err)
+		return
+	}
+	message.Respond(&proto.GossipMessage{
+		Channel: []byte(ae.ChainID),
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Nonce:   message.GetGossipMessage().Nonce,
+		Content: &proto.GossipMessage_PvtHashesRes{
+			PvtHashesRes: &proto.PvtDataHashesResponse{
+				Hashes: hashes,
+			},
+		},
+	})
+}
+
+// blockHashes returns the private data hashes of the committed blocks in the range [startBlock, endBlock]
+func (ae *antiEntropy) blockHashes(startBlock, endBlock uint64) ([]*proto.BlockPvtDataHash, error) {
+	height, err := ae.LedgerHeight()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if height == 0 {
+		return nil, nil
+	}
+	if endBlock > height-1 {
+		endBlock = height - 1
+	}
+	if startBlock > endBlock {
+		return nil, nil
+	}
+	if endBlock-startBlock >= maxPvtDataHashesRange {
+		startBlock = endBlock - maxPvtDataHashesRange + 1
+	}
+	var hashes []*proto.BlockPvtDataHash
+	for blockNum := startBlock; blockNum <= endBlock; blockNum++ {
+		hash, err := ae.blockHash(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, &proto.BlockPvtDataHash{
+			BlockNum: blockNum,
+			Hash:     hash,
+		})
+	}
+	return hashes, nil
+}
+
+// blockHash computes a hash over the private data stored for the given block,
+// or returns nil if no private data is stored for it
+func (ae *antiEntropy) blockHash(blockNum uint64) ([]byte, error) {
+	pvtData, err := ae.GetPvtDataByNum(blockNum, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed retrieving private data of block %d", blockNum)
+	}
+	var entries []string
+	for _, txPvtData := range pvtData {
+		if txPvtData == nil || txPvtData.WriteSet == nil {
+			continue
+		}
+		for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+			for _, col := range ns.CollectionPvtRwset {
+				entries = append(entries, fmt.Sprintf("%d\x00%s\x00%s\x00%x", txPvtData.SeqInBlock, ns.Namespace, col.CollectionName, util2.ComputeHash(col.Rwset)))
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	sort.Strings(entries)
+	var buff bytes.Buffer
+	for _, entry := range entries {
+		buff.WriteString(entry)
+		buff.WriteByte('\n')
+	}
+	return util2.ComputeHash(buff.Bytes()), nil
+}
+
+// round compares the private data hashes of the next window of blocks with
+// the ones of peers of the same organization, and repairs the blocks that differ
+func (ae *antiEntropy) round() error {
+	startTime := time.Now()
+	defer func() {
+		ae.metrics.AntiEntropyDuration.With("channel", ae.ChainID).Observe(time.Since(startTime).Seconds())
+	}()
+
+	height, err := ae.LedgerHeight()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// the genesis block carries no private data
+	if height < 2 {
+		return nil
+	}
+	endBlock := ae.cursor
+	if endBlock == 0 || endBlock > height-1 {
+		endBlock = height - 1
+	}
+	startBlock := uint64(1)
+	if window := uint64(ae.config.BlockWindow); endBlock > window {
+		startBlock = endBlock - window + 1
+	}
+	ae.cursor = startBlock - 1
+
+	mismatched, err := ae.compare(startBlock, endBlock)
+	if err != nil {
+		return err
+	}
+	if len(mismatched) == 0 {
+		logger.Debugf("Private data of blocks [%d - %d] is consistent with peers of our organization", startBlock, endBlock)
+		return nil
+	}
+	ae.metrics.AntiEntropyMismatchedBlocks.With("channel", ae.ChainID).Add(float64(len(mismatched)))
+	logger.Infof("Private data of blocks %v differs from peers of our organization, verifying it", mismatched)
+	for _, blockNum := range mismatched {
+		if err := ae.repairBlock(blockNum, height); err != nil {
+			logger.Warningf("Failed repairing private data of block %d: %s", blockNum, err)
+		}
+	}
+	return nil
+}
+
+// compare returns the blocks in the range [startBlock, endBlock] whose
+// private data hashes differ from the ones of peers of the same organization
+func (ae *antiEntropy) compare(startBlock, endBlock uint64) ([]uint64, error) {
+	localHashes, err := ae.blockHashes(startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[uint64][]byte)
+	for _, blockHash := range localHashes {
+		local[blockHash.BlockNum] = blockHash.Hash
+	}
+
+	mismatched := make(map[uint64]struct{})
+	for _, response := range ae.requestHashes(startBlock, endBlock) {
+		for _, blockHash := range response.Hashes {
+			localHash, exists := local[blockHash.BlockNum]
+			if !exists {
+				continue
+			}
+			if !bytes.Equal(localHash, blockHash.Hash) {
+				mismatched[blockHash.BlockNum] = struct{}{}
+			}
+		}
+	}
+
+	var res []uint64
+	for blockNum := range mismatched {
+		res = append(res, blockNum)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] > res[j]
+	})
+	return res, nil
+}
+
+// requestHashes sends a private data hashes request to peers of the same organization and gathers their responses
+func (ae *antiEntropy) requestHashes(startBlock, endBlock uint64) []*proto.PvtDataHashesResponse {
+	sameOrg, err := ae.PeerFilter(gcommon.ChainID(ae.ChainID), func(signature api.PeerSignature) bool {
+		return bytes.Equal(ae.OrgByPeerIdentity(signature.PeerIdentity), []byte(ae.mspID))
+	})
+	if err != nil {
+		logger.Warning("Failed obtaining peers of our organization:", err)
+		return nil
+	}
+	peers := filter.SelectPeers(ae.config.PeerNum, ae.PeersOfChannel(gcommon.ChainID(ae.ChainID)), sameOrg)
+	if len(peers) == 0 {
+		logger.Debug("No peer of our organization is known in channel", ae.ChainID)
+		return nil
+	}
+
+	var subscriptions []util.Subscription
+	for _, peer := range peers {
+		nonce := util.RandomUInt64()
+		subscriptions = append(subscriptions, ae.pubSub.Subscribe(nonceTopic(nonce), responseWaitTime))
+		ae.Send(&proto.GossipMessage{
+			Channel: []byte(ae.ChainID),
+			Tag:     proto.GossipMessage_CHAN_AND_ORG,
+			Nonce:   nonce,
+			Content: &proto.GossipMessage_PvtHashesReq{
+				PvtHashesReq: &proto.PvtDataHashesRequest{
+					StartBlock: startBlock,
+					EndBlock:   endBlock,
+				},
+			},
+		}, peer)
+	}
+
+	var responses []*proto.PvtDataHashesResponse
+	for _, sub := range subscriptions {
+		res, err := sub.Listen()
+		if err != nil {
+			logger.Debug("Didn't receive private data hashes from a peer of our organization:", err)
+			continue
+		}
+		responses = append(responses, res.(*proto.PvtDataHashesResponse))
+	}
+	return responses
+}
+
+// repairBlock verifies the private data stored for the given block against the hashes recorded in the block,
+// and pulls from other peers the private data that is corrupted, or missing although this peer is eligible for it
+func (ae *antiEntropy) repairBlock(blockNum uint64, height uint64) error {
+	dig2collectionCfg, corrupted, missing, err := ae.verifyBlock(blockNum, height)
+	if err != nil {
+		return err
+	}
+	ae.metrics.AntiEntropyCorruptedElements.With("channel", ae.ChainID).Add(float64(corrupted))
+	ae.metrics.AntiEntropyMissingElements.With("channel", ae.ChainID).Add(float64(missing))
+	if len(dig2collectionCfg) == 0 {
+		logger.Debugf("Private data of block %d matches the hashes in the block", blockNum)
+		return nil
+	}
+
+	fetchedData, err := ae.FetchReconciledItems(dig2collectionCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed fetching private data from other peers")
+	}
+	if len(fetchedData.AvailableElements) == 0 {
+		return nil
+	}
+	pvtdataHashMismatch, err := ae.RepairPvtDataOfOldBlocks(preparePvtDataToCommit(fetchedData.AvailableElements))
+	if err != nil {
+		return errors.Wrap(err, "failed to repair private data")
+	}
+	logMismatched(pvtdataHashMismatch)
+
+	// the private data which was written may not be stored, e.g. if it expired in the meantime,
+	// hence only the private data which is verified to match the block now counts as repaired
+	unrepaired, _, _, err := ae.verifyBlock(blockNum, height)
+	if err != nil {
+		return err
+	}
+	repaired := 0
+	for digKey := range dig2collectionCfg {
+		if _, isUnrepaired := unrepaired[digKey]; !isUnrepaired {
+			repaired++
+		}
+	}
+	logger.Infof("Repaired %d private data elements of block %d", repaired, blockNum)
+	ae.metrics.AntiEntropyRepairedElements.With("channel", ae.ChainID).Add(float64(repaired))
+	return nil
+}
+
+// verifyBlock verifies the private data stored for the given block against the hashes recorded in the block,
+// and returns the private data that is corrupted, or missing although this peer is eligible for it, along
+// with the number of the corrupted and missing elements
+func (ae *antiEntropy) verifyBlock(blockNum uint64, height uint64) (privdatacommon.Dig2CollectionConfig, int, int, error) {
+	blockAndPvtData, err := ae.GetPvtDataAndBlockByNum(blockNum)
+	if err != nil {
+		return nil, 0, 0, errors.WithStack(err)
+	}
+	block := blockAndPvtData.Block
+	if block.Data == nil || block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil, 0, 0, errors.New("Block.Metadata is nil or Block.Metadata lacks a Tx filter bitmap")
+	}
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFilter) != len(block.Data.Data) {
+		return nil, 0, 0, errors.Errorf("Block data size(%d) is different from Tx filter size(%d)", len(block.Data.Data), len(txsFilter))
+	}
+
+	var corrupted, missing int
+	collectionConfigCache := make(map[collectionConfigKey]*common.StaticCollectionConfig)
+	dig2collectionCfg := make(privdatacommon.Dig2CollectionConfig)
+	data := blockData(block.Data.Data)
+	_, err = data.forEachTxn(false, txsFilter, func(seqInBlock uint64, chdr *common.ChannelHeader, txRWSet *rwsetutil.TxRwSet, _ []*peer.Endorsement) error {
+		for _, ns := range txRWSet.NsRwSets {
+			for _, hashedCollection := range ns.CollHashedRwSets {
+				if !containsWrites(chdr.TxId, ns.NameSpace, hashedCollection) {
+					continue
+				}
+				storedHash, isStored := storedRWSetHash(blockAndPvtData.PvtData[seqInBlock], ns.NameSpace, hashedCollection.CollectionName)
+				if isStored && bytes.Equal(storedHash, hashedCollection.PvtRwSetHash) {
+					continue
+				}
+				if !isStored && !ae.isEligible(chdr, ns.NameSpace, hashedCollection.CollectionName) {
+					continue
+				}
+				collConfigKey := collectionConfigKey{
+					chaincodeName:  ns.NameSpace,
+					collectionName: hashedCollection.CollectionName,
+					blockNum:       blockNum,
+				}
+				if _, exists := collectionConfigCache[collConfigKey]; !exists {
+					collectionConfig, err := getMostRecentCollectionConfig(ae.Committer, ns.NameSpace, hashedCollection.CollectionName, blockNum)
+					if err != nil {
+						logger.Debug(err)
+						continue
+					}
+					collectionConfigCache[collConfigKey] = collectionConfig
+				}
+				collectionConfig := collectionConfigCache[collConfigKey]
+				if btl := collectionConfig.BlockToLive; btl > 0 && blockNum+btl < height {
+					// the private data has expired, and may have been purged
+					continue
+				}
+				if isStored {
+					logger.Warningf("Private data of chaincode %s, collection %s, block num %d, tx num %d doesn't match the hash in the block",
+						ns.NameSpace, hashedCollection.CollectionName, blockNum, seqInBlock)
+					corrupted++
+				} else {
+					missing++
+				}
+				dig2collectionCfg[privdatacommon.DigKey{
+					TxId:       chdr.TxId,
+					Namespace:  ns.NameSpace,
+					Collection: hashedCollection.CollectionName,
+					BlockSeq:   blockNum,
+					SeqInBlock: seqInBlock,
+				}] = collectionConfig
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return dig2collectionCfg, corrupted, missing, nil
+}
+
+// isEligible returns whether this peer is eligible for the private data of the given collection
+func (ae *antiEntropy) isEligible(chdr *common.ChannelHeader, namespace, collection string) bool {
+	cc := common.CollectionCriteria{
+		Channel:    chdr.ChannelId,
+		TxId:       chdr.TxId,
+		Namespace:  namespace,
+		Collection: collection,
+	}
+	policy, err := ae.RetrieveCollectionAccessPolicy(cc)
+	if err != nil {
+		logger.Warning("Failed obtaining policy for", cc, ":", err)
+		return false
+	}
+	if util.Contains(ae.mspID, policy.MemberOrgs()) {
+		return true
+	}
+	isAuthorized := policy.AccessFilter()
+	return isAuthorized != nil && isAuthorized(ae.selfSignedData)
+}
+
+// storedRWSetHash returns the hash of the private rwset stored for the given collection, and whether it is stored at all
+func storedRWSetHash(txPvtData *ledger.TxPvtData, namespace, collection string) ([]byte, bool) {
+	if txPvtData == nil || txPvtData.WriteSet == nil {
+		return nil, false
+	}
+	for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+		if ns.Namespace != namespace {
+			continue
+		}
+		for _, col := range ns.CollectionPvtRwset {
+			if col.CollectionName == collection {
+				return util2.ComputeHash(col.Rwset), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func nonceTopic(nonce uint64) string {
+	return fmt.Sprintf("%d", nonce)
+}
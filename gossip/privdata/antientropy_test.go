@@ -0,0 +1,279 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	gmetricsmocks "github.com/hyperledger/fabric/gossip/metrics/mocks"
+	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
+	"github.com/hyperledger/fabric/gossip/privdata/mocks"
+	fcommon "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type orgsByIdentity map[string]string
+
+func (orgs orgsByIdentity) OrgByPeerIdentity(identity api.PeerIdentityType) api.OrgIdentityType {
+	return api.OrgIdentityType(orgs[string(identity)])
+}
+
+func txPvtData(seqInBlock uint64, namespace, collection string, rws []byte) *ledger.TxPvtData {
+	return &ledger.TxPvtData{
+		SeqInBlock: seqInBlock,
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: namespace,
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: collection, Rwset: rws},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAntiEntropyBlockHash(t *testing.T) {
+	committer := &mocks.Committer{}
+	committer.On("GetPvtDataByNum", uint64(1), mock.Anything).Return([]*ledger.TxPvtData{
+		txPvtData(0, "ns1", "c1", []byte("rws1")),
+		txPvtData(1, "ns1", "c2", []byte("rws2")),
+	}, nil)
+	committer.On("GetPvtDataByNum", uint64(2), mock.Anything).Return([]*ledger.TxPvtData{
+		txPvtData(1, "ns1", "c2", []byte("rws2")),
+		txPvtData(0, "ns1", "c1", []byte("rws1")),
+	}, nil)
+	committer.On("GetPvtDataByNum", uint64(3), mock.Anything).Return([]*ledger.TxPvtData{
+		txPvtData(0, "ns1", "c1", []byte("rws1")),
+		txPvtData(1, "ns1", "c2", []byte("corrupted")),
+	}, nil)
+	committer.On("GetPvtDataByNum", uint64(4), mock.Anything).Return(nil, nil)
+	committer.On("LedgerHeight").Return(uint64(5), nil)
+
+	ae := &antiEntropy{AntiEntropySupport: AntiEntropySupport{ChainID: "A", Committer: committer}}
+
+	hashes, err := ae.blockHashes(1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 4)
+	// the hash doesn't depend on the order in which the private data is retrieved
+	assert.NotNil(t, hashes[0].Hash)
+	assert.Equal(t, hashes[0].Hash, hashes[1].Hash)
+	// but it does depend on the content of the private data
+	assert.NotEqual(t, hashes[0].Hash, hashes[2].Hash)
+	// blocks without private data have no hash
+	assert.Equal(t, uint64(4), hashes[3].BlockNum)
+	assert.Nil(t, hashes[3].Hash)
+
+	hashes, err = ae.blockHashes(5, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestAntiEntropyCompare(t *testing.T) {
+	// Scenario: p1 and p2 belong to the same organization, while p3 belongs to a different one.
+	// The private data of block 2 stored by p1 differs from the one stored by p2,
+	// so p1 should find block 2 mismatched, without sending requests to p3.
+	orgs := orgsByIdentity{"p1": "Org1MSP", "p2": "Org1MSP", "p3": "Org2MSP"}
+	gn := &gossipNetwork{}
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	newAntiEntropy := func(id string, c *mocks.Committer, knownMembers ...discovery.NetworkMember) *antiEntropy {
+		g := newMockGossip(&comm.RemotePeer{PKIID: common.PKIidType(id), Endpoint: id})
+		g.network = gn
+		g.On("PeersOfChannel", mock.Anything).Return(knownMembers)
+		gn.peers = append(gn.peers, g)
+		support := AntiEntropySupport{ChainID: "A", Committer: c, SecurityAdvisor: orgs}
+		config := &AntiEntropyConfig{IsEnabled: true, Interval: time.Hour, BlockWindow: 10, PeerNum: 2}
+		return NewAntiEntropy(orgs[id], support, g, fcommon.SignedData{Identity: []byte(id)}, metrics, config).(*antiEntropy)
+	}
+
+	c1 := &mocks.Committer{}
+	c1.On("LedgerHeight").Return(uint64(3), nil)
+	c1.On("GetPvtDataByNum", uint64(1), mock.Anything).Return([]*ledger.TxPvtData{txPvtData(0, "ns1", "c1", []byte("rws1"))}, nil)
+	c1.On("GetPvtDataByNum", uint64(2), mock.Anything).Return([]*ledger.TxPvtData{txPvtData(0, "ns1", "c1", []byte("corrupted"))}, nil)
+	c2 := &mocks.Committer{}
+	c2.On("LedgerHeight").Return(uint64(3), nil)
+	c2.On("GetPvtDataByNum", uint64(1), mock.Anything).Return([]*ledger.TxPvtData{txPvtData(0, "ns1", "c1", []byte("rws1"))}, nil)
+	c2.On("GetPvtDataByNum", uint64(2), mock.Anything).Return([]*ledger.TxPvtData{txPvtData(0, "ns1", "c1", []byte("rws2"))}, nil)
+	c3 := &mocks.Committer{}
+
+	p1 := newAntiEntropy("p1", c1, membership(peerData{"p2", 3}, peerData{"p3", 3})...)
+	p2 := newAntiEntropy("p2", c2)
+	p3 := newAntiEntropy("p3", c3)
+	for _, ae := range []*antiEntropy{p1, p2, p3} {
+		ae.Start()
+		defer ae.Stop()
+	}
+
+	mismatched, err := p1.compare(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2}, mismatched)
+	c3.AssertNotCalled(t, "LedgerHeight")
+}
+
+func TestAntiEntropyHandleRequest(t *testing.T) {
+	// Scenario: p1 responds with the private data hashes of its committed blocks
+	// to p2 which is in its organization, but ignores the requests of p3
+	committer := &mocks.Committer{}
+	committer.On("LedgerHeight").Return(uint64(3), nil)
+	committer.On("GetPvtDataByNum", mock.Anything, mock.Anything).Return([]*ledger.TxPvtData{txPvtData(0, "ns1", "c1", []byte("rws1"))}, nil)
+	ae := &antiEntropy{
+		mspID:              "Org1MSP",
+		AntiEntropySupport: AntiEntropySupport{ChainID: "A", Committer: committer, SecurityAdvisor: orgsByIdentity{"p2": "Org1MSP", "p3": "Org2MSP"}},
+	}
+
+	request := func(from string) *receivedMsg {
+		msg, _ := (&proto.GossipMessage{
+			Channel: []byte("A"),
+			Tag:     proto.GossipMessage_CHAN_AND_ORG,
+			Nonce:   100,
+			Content: &proto.GossipMessage_PvtHashesReq{
+				PvtHashesReq: &proto.PvtDataHashesRequest{StartBlock: 1, EndBlock: 10},
+			},
+		}).NoopSign()
+		return &receivedMsg{
+			responseChan:        make(chan proto.ReceivedMessage, 1),
+			RemotePeer:          &comm.RemotePeer{PKIID: common.PKIidType(from), Endpoint: from},
+			SignedGossipMessage: msg,
+		}
+	}
+
+	msg := request("p2")
+	ae.handleRequest(msg)
+	response := <-msg.responseChan
+	assert.Equal(t, uint64(100), response.GetGossipMessage().Nonce)
+	hashes := response.GetGossipMessage().GetPvtHashesRes().Hashes
+	assert.Len(t, hashes, 2)
+	assert.Equal(t, uint64(1), hashes[0].BlockNum)
+	assert.Equal(t, uint64(2), hashes[1].BlockNum)
+
+	msg = request("p3")
+	ae.handleRequest(msg)
+	assert.Empty(t, msg.responseChan)
+}
+
+func TestAntiEntropyRepairBlock(t *testing.T) {
+	// Scenario: block 5 has 3 transactions with private data of collections c1, c2 and c3.
+	// The private data of c1 stored by the peer is corrupted, the private data of c2 is missing
+	// although the peer is eligible for it, and the private data of c3 is missing since the peer
+	// isn't eligible for it.
+	// The private data of c1 and c2 should be pulled and repaired, and the metrics should be updated.
+	// The private data of c2 isn't stored by the repair, e.g. since it expired in the meantime, hence
+	// only the private data of c1 counts as repaired.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+
+	bf := &blockFactory{channelID: "A"}
+	block := bf.AddTxn("tx1", "ns1", util2.ComputeHash([]byte("rws1")), "c1").
+		AddTxn("tx2", "ns1", util2.ComputeHash([]byte("rws2")), "c2").
+		AddTxn("tx3", "ns1", util2.ComputeHash([]byte("rws3")), "c3").create()
+	block.Header.Number = 5
+	committer.On("GetPvtDataAndBlockByNum", uint64(5)).Return(&ledger.BlockAndPvtData{
+		Block: block,
+		PvtData: ledger.TxPvtDataMap{
+			0: txPvtData(0, "ns1", "c1", []byte("corrupted")),
+		},
+	}, nil).Once()
+	committer.On("GetPvtDataAndBlockByNum", uint64(5)).Return(&ledger.BlockAndPvtData{
+		Block: block,
+		PvtData: ledger.TxPvtDataMap{
+			0: txPvtData(0, "ns1", "c1", []byte("rws1")),
+		},
+	}, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, "ns1").Return(collectionConfigInfoOf("c1", "c2", "c3"), nil)
+
+	expectedDig2CollectionConfig := privdatacommon.Dig2CollectionConfig{
+		{TxId: "tx1", Namespace: "ns1", Collection: "c1", BlockSeq: 5, SeqInBlock: 0}: {Name: "c1"},
+		{TxId: "tx2", Namespace: "ns1", Collection: "c2", BlockSeq: 5, SeqInBlock: 1}: {Name: "c2"},
+	}
+	fetcher.On("FetchReconciledItems", expectedDig2CollectionConfig).Return(availableElements(expectedDig2CollectionConfig), nil)
+	var repaired []*ledger.BlockPvtData
+	committer.On("RepairPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		repaired = args.Get(0).([]*ledger.BlockPvtData)
+	}).Return(nil, nil)
+
+	cs := newCollectionStore()
+	cs.withPolicy("c1", 0).thatMapsTo("p1")
+	cs.withPolicy("c2", 0).thatMapsTo("p1")
+	cs.withPolicy("c3", 0).thatMapsTo("p2")
+
+	testMetricProvider := gmetricsmocks.TestUtilConstructMetricProvider()
+	ae := &antiEntropy{
+		mspID:          "Org1MSP",
+		selfSignedData: fcommon.SignedData{Identity: []byte("p1")},
+		AntiEntropySupport: AntiEntropySupport{
+			ChainID:               "A",
+			CollectionStore:       cs,
+			Committer:             committer,
+			ReconciliationFetcher: fetcher,
+		},
+		metrics: metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics,
+		config:  &AntiEntropyConfig{IsEnabled: true, Interval: time.Hour, BlockWindow: 10, PeerNum: 2},
+	}
+
+	err := ae.repairBlock(5, 10)
+	assert.NoError(t, err)
+	fetcher.AssertNumberOfCalls(t, "FetchReconciledItems", 1)
+	committer.AssertNotCalled(t, "CommitPvtDataOfOldBlocks", mock.Anything)
+	assert.Len(t, repaired, 1)
+	assert.Equal(t, uint64(5), repaired[0].BlockNum)
+	assert.Len(t, repaired[0].WriteSets, 2)
+
+	assert.Equal(t, float64(1), testMetricProvider.FakeAntiEntropyCorruptedElements.AddArgsForCall(0))
+	assert.Equal(t, float64(1), testMetricProvider.FakeAntiEntropyMissingElements.AddArgsForCall(0))
+	assert.Equal(t, float64(1), testMetricProvider.FakeAntiEntropyRepairedElements.AddArgsForCall(0))
+	assert.Equal(t, []string{"channel", "A"}, testMetricProvider.FakeAntiEntropyRepairedElements.WithArgsForCall(0))
+
+	// once the private data has expired, it isn't repaired anymore
+	configHistoryRetriever.Mock = mock.Mock{}
+	configInfo := collectionConfigInfoOf("c1", "c2", "c3")
+	for _, config := range configInfo.CollectionConfig.Config {
+		config.GetStaticCollectionConfig().BlockToLive = 3
+	}
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, "ns1").Return(configInfo, nil)
+	err = ae.repairBlock(5, 10)
+	assert.NoError(t, err)
+	fetcher.AssertNumberOfCalls(t, "FetchReconciledItems", 1)
+}
+
+func TestAntiEntropyRound(t *testing.T) {
+	// Scenario: the anti-entropy walks the ledger backwards, one window of blocks per round,
+	// and starts over from the top of the ledger once it reaches the first block
+	committer := &mocks.Committer{}
+	committer.On("LedgerHeight").Return(uint64(26), nil)
+	committer.On("GetPvtDataByNum", mock.Anything, mock.Anything).Return(nil, nil)
+	g := newMockGossip(&comm.RemotePeer{PKIID: common.PKIidType("p1"), Endpoint: "p1"})
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{})
+
+	ae := &antiEntropy{
+		mspID:              "Org1MSP",
+		AntiEntropySupport: AntiEntropySupport{ChainID: "A", Committer: committer, SecurityAdvisor: orgsByIdentity{}},
+		gossip:             g,
+		metrics:            metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		config:             &AntiEntropyConfig{IsEnabled: true, Interval: time.Hour, BlockWindow: 10, PeerNum: 2},
+	}
+	var cursors []uint64
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, ae.round())
+		cursors = append(cursors, ae.cursor)
+	}
+	assert.Equal(t, []uint64{15, 5, 0, 15}, cursors)
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -134,12 +135,32 @@ type coordinator struct {
 	metrics                        *metrics.PrivdataMetrics
 	pullRetryThreshold             time.Duration
 	skipPullingInvalidTransactions bool
+	pipelinedValidation            bool
+
+	// lock guards pendingCommit
+	lock          sync.Mutex
+	pendingCommit *pendingCommit
+}
+
+// pendingCommit tracks the commit of a block that is
+// in progress in the background, in pipelined mode
+type pendingCommit struct {
+	blockNum uint64
+	done     chan struct{}
+	err      error
 }
 
 type CoordinatorConfig struct {
 	TransientBlockRetention        uint64
 	PullRetryThreshold             time.Duration
 	SkipPullingInvalidTransactions bool
+	// PipelinedValidation makes StoreBlock return as soon as the block is
+	// validated, leaving its commit in progress in the background, so that
+	// the checks of the next block that do not depend on the committed state
+	// overlap with the commit. The next StoreBlock waits for the commit to
+	// complete before performing the checks that depend on the committed state,
+	// and returns the error of the commit, if any
+	PipelinedValidation bool
 }
 
 // NewCoordinator creates a new instance of coordinator
@@ -151,7 +172,8 @@ func NewCoordinator(mspID string, support Support, selfSignedData common.SignedD
 		transientBlockRetention:        config.TransientBlockRetention,
 		metrics:                        metrics,
 		pullRetryThreshold:             config.PullRetryThreshold,
-		skipPullingInvalidTransactions: config.SkipPullingInvalidTransactions}
+		skipPullingInvalidTransactions: config.SkipPullingInvalidTransactions,
+		pipelinedValidation:            config.PipelinedValidation}
 }
 
 // StorePvtData used to persist private date into transient store
@@ -170,6 +192,10 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 
 	logger.Infof("[%s] Received block [%d] from buffer", c.ChainID, block.Header.Number)
 
+	if c.pipelinedValidation {
+		return c.storeBlockPipelined(block, privateDataSets)
+	}
+
 	logger.Debugf("[%s] Validating block [%d]", c.ChainID, block.Header.Number)
 
 	validationStart := time.Now()
@@ -180,6 +206,85 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		return err
 	}
 
+	return c.commitBlock(block, privateDataSets)
+}
+
+// storeBlockPipelined validates the block, overlapping the checks that do not
+// depend on the committed state with the commit of the previous block, and
+// then starts its commit in the background
+func (c *coordinator) storeBlockPipelined(block *common.Block, privateDataSets util.PvtDataCollections) error {
+	logger.Debugf("[%s] Validating block [%d]", c.ChainID, block.Header.Number)
+
+	validationStart := time.Now()
+	pipelinedValidator, isPipelined := c.Validator.(txvalidator.PipelinedValidator)
+	var prevalidated *txvalidator.PrevalidatedBlock
+	if isPipelined {
+		prevalidated = pipelinedValidator.Prevalidate(block)
+	}
+	validationDuration := time.Since(validationStart)
+
+	// the checks that depend on the committed state need the previous block to be committed
+	if err := c.waitForPendingCommit(); err != nil {
+		return err
+	}
+
+	validationStart = time.Now()
+	var err error
+	if isPipelined {
+		err = pipelinedValidator.ValidatePrevalidated(prevalidated)
+	} else {
+		err = c.Validator.Validate(block)
+	}
+	c.reportValidationDuration(validationDuration + time.Since(validationStart))
+	if err != nil {
+		logger.Errorf("Validation failed: %+v", err)
+		return err
+	}
+
+	pending := &pendingCommit{
+		blockNum: block.Header.Number,
+		done:     make(chan struct{}),
+	}
+	c.lock.Lock()
+	c.pendingCommit = pending
+	c.lock.Unlock()
+	go func() {
+		defer close(pending.done)
+		pending.err = c.commitBlock(block, privateDataSets)
+	}()
+	return nil
+}
+
+// waitForPendingCommit waits for the commit in progress in the
+// background, if any, to complete and returns its error
+func (c *coordinator) waitForPendingCommit() error {
+	c.lock.Lock()
+	pending := c.pendingCommit
+	c.pendingCommit = nil
+	c.lock.Unlock()
+	if pending == nil {
+		return nil
+	}
+	<-pending.done
+	if pending.err != nil {
+		return errors.WithMessage(pending.err, fmt.Sprintf("failed committing block [%d]", pending.blockNum))
+	}
+	return nil
+}
+
+// Close waits for the commit in progress in the background,
+// if any, to complete and then closes the committer
+func (c *coordinator) Close() {
+	if err := c.waitForPendingCommit(); err != nil {
+		logger.Errorf("[%s] %+v", c.ChainID, err)
+	}
+	c.Committer.Close()
+}
+
+// commitBlock commits the validated block along with its private data,
+// fetching the missing private data from the transient store or from
+// the other peers, and purges the transient store
+func (c *coordinator) commitBlock(block *common.Block, privateDataSets util.PvtDataCollections) error {
 	blockAndPvtData := &ledger.BlockAndPvtData{
 		Block:          block,
 		PvtData:        make(ledger.TxPvtDataMap),
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -216,6 +217,24 @@ func (v *validatorMock) Validate(block *common.Block) error {
 	return nil
 }
 
+type pipelinedValidatorMock struct {
+	validatorMock
+	lastPrevalidated uint64
+	prevalidated     chan uint64
+	validated        chan uint64
+}
+
+func (v *pipelinedValidatorMock) Prevalidate(block *common.Block) *txvalidator.PrevalidatedBlock {
+	v.lastPrevalidated = block.Header.Number
+	v.prevalidated <- block.Header.Number
+	return &txvalidator.PrevalidatedBlock{}
+}
+
+func (v *pipelinedValidatorMock) ValidatePrevalidated(prevalidated *txvalidator.PrevalidatedBlock) error {
+	v.validated <- v.lastPrevalidated
+	return v.err
+}
+
 type digests []privdatacommon.DigKey
 
 func (d digests) Equal(other digests) bool {
@@ -1345,6 +1364,91 @@ func TestCoordinatorStoreBlockWhenPvtDataExistInLedger(t *testing.T) {
 	assertCommitHappened()
 }
 
+func TestCoordinatorStoreBlockPipelined(t *testing.T) {
+	// Scenario: blocks are stored in pipelined mode. The prevalidation of a block
+	// overlaps with the commit of the previous block, while the rest of its
+	// validation waits for that commit to complete. The error of a commit is
+	// returned when the next block is stored.
+	peerSelfSignedData := common.SignedData{}
+	blockNum := func(num uint64) interface{} {
+		return mock.MatchedBy(func(blockAndPvtData *ledger.BlockAndPvtData) bool {
+			return blockAndPvtData.Block.Header.Number == num
+		})
+	}
+	releaseCommit := make(chan struct{})
+	committer := &mocks.Committer{}
+	committer.On("DoesPvtDataInfoExistInLedger", mock.Anything).Return(false, nil)
+	committer.On("CommitWithPvtData", blockNum(1), mock.Anything).Run(func(_ mock.Arguments) {
+		<-releaseCommit
+	}).Return(nil)
+	committer.On("CommitWithPvtData", blockNum(2), mock.Anything).Return(errors.New("disk full"))
+	committer.On("Close")
+
+	validator := &pipelinedValidatorMock{
+		prevalidated: make(chan uint64, 10),
+		validated:    make(chan uint64, 10),
+	}
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	capabilityProvider := &capabilitymock.CapabilityProvider{}
+	appCapability := &capabilitymock.AppCapabilities{}
+	capabilityProvider.On("Capabilities").Return(appCapability)
+	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	config := testConfig
+	config.PipelinedValidation = true
+	coordinator := NewCoordinator("Org1MSP", Support{
+		CollectionStore:    createcollectionStore(peerSelfSignedData).thatAcceptsAll(),
+		Committer:          committer,
+		Fetcher:            &fetcherMock{t: t},
+		TransientStore:     &mockTransientStore{t: t},
+		Validator:          validator,
+		CapabilityProvider: capabilityProvider,
+	}, peerSelfSignedData, metrics, config)
+
+	bf := &blockFactory{
+		channelID: "test",
+	}
+	newBlock := func(num uint64) *common.Block {
+		block := bf.create()
+		block.Header.Number = num
+		return block
+	}
+
+	// the commit of block 1 is left in progress
+	assert.NoError(t, coordinator.StoreBlock(newBlock(1), nil))
+	assert.Equal(t, uint64(1), <-validator.prevalidated)
+	assert.Equal(t, uint64(1), <-validator.validated)
+
+	storeErr := make(chan error)
+	go func() {
+		storeErr <- coordinator.StoreBlock(newBlock(2), nil)
+	}()
+	// block 2 is prevalidated, but not validated, while block 1 is being committed
+	assert.Equal(t, uint64(2), <-validator.prevalidated)
+	select {
+	case <-validator.validated:
+		t.Fatal("block 2 has been validated before the commit of block 1 completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(releaseCommit)
+	assert.Equal(t, uint64(2), <-validator.validated)
+	assert.NoError(t, <-storeErr)
+
+	// the commit of block 2 fails
+	err := coordinator.StoreBlock(newBlock(3), nil)
+	assert.EqualError(t, err, "failed committing block [2]: commit failed: disk full")
+	assert.Equal(t, uint64(3), <-validator.prevalidated)
+	assert.Len(t, validator.validated, 0)
+
+	// a validation failure is returned once the previous commit completes
+	validator.err = errors.New("failed validating block")
+	err = coordinator.StoreBlock(newBlock(3), nil)
+	assert.EqualError(t, err, "failed validating block")
+	committer.AssertNumberOfCalls(t, "CommitWithPvtData", 2)
+
+	coordinator.Close()
+	committer.AssertCalled(t, "Close")
+}
+
 func TestProceedWithoutPrivateData(t *testing.T) {
 	// Scenario: we are missing private data (c2 in ns3) and it cannot be obtained from any peer.
 	// Block needs to be committed with missing private data.
@@ -202,3 +202,26 @@ func (_m *Committer) LedgerHeight() (uint64, error) {
 
 	return r0, r1
 }
+
+// RepairPvtDataOfOldBlocks provides a mock function with given fields: blockPvtData
+func (_m *Committer) RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	ret := _m.Called(blockPvtData)
+
+	var r0 []*ledger.PvtdataHashMismatch
+	if rf, ok := ret.Get(0).(func([]*ledger.BlockPvtData) []*ledger.PvtdataHashMismatch); ok {
+		r0 = rf(blockPvtData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ledger.PvtdataHashMismatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*ledger.BlockPvtData) error); ok {
+		r1 = rf(blockPvtData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -40,17 +41,67 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Trigger schedules an immediate reconciliation of the missing private data described by the given request
+	Trigger(req ReconcileRequest) error
+	// Progress reports the state of the reconciliation along with the missing private data
+	// information that is currently recorded in the ledger
+	Progress() (*ReconcileProgress, error)
 }
 
+// ReconcileRequest describes an on-demand reconciliation of the missing private data of
+// the blocks in the range [StartBlock, EndBlock], optionally restricted to a namespace or
+// to a single collection of a namespace. An EndBlock of zero denotes the most recent block.
+type ReconcileRequest struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+	Namespace  string `json:"namespace,omitempty"`
+	Collection string `json:"collection,omitempty"`
+}
+
+func (req *ReconcileRequest) matches(namespace, collection string) bool {
+	if req == nil || req.Namespace == "" {
+		return true
+	}
+	if req.Namespace != namespace {
+		return false
+	}
+	return req.Collection == "" || req.Collection == collection
+}
+
+// ReconcileProgress reports the state of the reconciliation of a channel
+type ReconcileProgress struct {
+	Channel                string            `json:"channel"`
+	InProgress             bool              `json:"in_progress"`
+	PendingRequest         *ReconcileRequest `json:"pending_request,omitempty"`
+	LastCycleStart         time.Time         `json:"last_cycle_start,omitempty"`
+	LastCycleEnd           time.Time         `json:"last_cycle_end,omitempty"`
+	LastCycleReconciled    int               `json:"last_cycle_reconciled"`
+	TotalReconciled        int               `json:"total_reconciled"`
+	MissingBlocks          int               `json:"missing_blocks"`
+	MissingItems           int               `json:"missing_items"`
+	OldestMissingBlock     uint64            `json:"oldest_missing_block"`
+	NewestMissingBlock     uint64            `json:"newest_missing_block"`
+	MissingByCollection    map[string]int    `json:"missing_by_collection,omitempty"`
+	MissingInfoIsTruncated bool              `json:"missing_info_is_truncated"`
+}
+
+// maxProgressBlocks bounds the number of blocks with missing private data
+// that are inspected when the reconciliation progress is reported
+const maxProgressBlocks = 10000
+
 type Reconciler struct {
 	channel string
 	metrics *metrics.PrivdataMetrics
 	config  *ReconcilerConfig
 	ReconciliationFetcher
 	committer.Committer
-	stopChan  chan struct{}
-	startOnce sync.Once
-	stopOnce  sync.Once
+	stopChan    chan struct{}
+	triggerChan chan *ReconcileRequest
+	startOnce   sync.Once
+	stopOnce    sync.Once
+
+	lock     sync.RWMutex
+	progress ReconcileProgress
 }
 
 // NoOpReconciler non functional reconciler to be used
@@ -67,11 +118,73 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+func (*NoOpReconciler) Trigger(req ReconcileRequest) error {
+	return errors.New("private data reconciliation is disabled")
+}
+
+func (*NoOpReconciler) Progress() (*ReconcileProgress, error) {
+	return nil, errors.New("private data reconciliation is disabled")
+}
+
+// ReconcileOrder determines the order in which missing private data is reconciled
+type ReconcileOrder string
+
+const (
+	// NewestFirst reconciles the missing private data of the most recent blocks first, and pulls
+	// all the collections of every batch of blocks at once. It is the order the reconciler had
+	// before the order could be configured, hence it is the default
+	NewestFirst ReconcileOrder = "newest-first"
+	// CollectionPriority reconciles the missing private data of the most recent blocks first,
+	// and within every batch of blocks pulls the collections in the order of the configured priorities
+	CollectionPriority ReconcileOrder = "collection-priority"
+)
+
 // ReconcilerConfig holds config flags that are read from core.yaml
 type ReconcilerConfig struct {
 	SleepInterval time.Duration
 	BatchSize     int
 	IsEnabled     bool
+	Order         ReconcileOrder
+	// CollectionPriorities lists namespaces ("ns") and collections ("ns/coll") in decreasing
+	// order of priority. Collections that are not listed have the lowest priority.
+	CollectionPriorities []string
+	// RateLimiter caps the rate at which the reconcilers of all the channels of this
+	// peer pull private data from other peers. Nil means unlimited.
+	RateLimiter *RateLimiter
+}
+
+// RateLimiter spreads over time the pulls of private data of the reconcilers that
+// share it, so that together they don't exceed a number of bytes per second
+type RateLimiter struct {
+	bytesPerSecond int
+	lock           sync.Mutex
+	// next is the time by which the bytes reserved so far are pulled at the rate
+	next time.Time
+}
+
+// NewRateLimiter creates a RateLimiter of the given number of bytes per second,
+// or returns nil if the rate isn't positive
+func NewRateLimiter(bytesPerSecond int) *RateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// Reserve accounts for the pull of the given number of bytes, and returns the time
+// to wait until all the bytes reserved so far are pulled at the rate
+func (l *RateLimiter) Reserve(size int) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(float64(size) / float64(l.bytesPerSecond) * float64(time.Second)))
+	return l.next.Sub(now)
 }
 
 // NewReconciler creates a new instance of reconciler
@@ -85,6 +198,8 @@ func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer
 		Committer:             c,
 		ReconciliationFetcher: fetcher,
 		stopChan:              make(chan struct{}),
+		triggerChan:           make(chan *ReconcileRequest, 1),
+		progress:              ReconcileProgress{Channel: channel},
 	}
 }
 
@@ -100,11 +215,79 @@ func (r *Reconciler) Start() {
 	})
 }
 
+// Trigger schedules an immediate reconciliation of the missing private data described by the given request.
+// Only a single request can be pending at a time.
+func (r *Reconciler) Trigger(req ReconcileRequest) error {
+	if req.EndBlock == 0 {
+		req.EndBlock = math.MaxUint64
+	}
+	if req.StartBlock > req.EndBlock {
+		return errors.Errorf("invalid block range [%d - %d]", req.StartBlock, req.EndBlock)
+	}
+	if req.Collection != "" && req.Namespace == "" {
+		return errors.New("a collection cannot be reconciled without specifying its namespace")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	select {
+	case r.triggerChan <- &req:
+		r.progress.PendingRequest = &req
+		return nil
+	default:
+		return errors.New("a reconciliation request is already pending")
+	}
+}
+
+// Progress reports the state of the reconciliation along with the missing private data
+// information of up to maxProgressBlocks blocks that is currently recorded in the ledger
+func (r *Reconciler) Progress() (*ReconcileProgress, error) {
+	missingPvtDataTracker, err := r.getMissingPvtDataTracker()
+	if err != nil {
+		return nil, err
+	}
+	missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(maxProgressBlocks)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get missing private data info")
+	}
+
+	r.lock.RLock()
+	progress := r.progress
+	r.lock.RUnlock()
+
+	progress.MissingBlocks = len(missingPvtDataInfo)
+	progress.MissingInfoIsTruncated = len(missingPvtDataInfo) >= maxProgressBlocks
+	progress.OldestMissingBlock, progress.NewestMissingBlock = math.MaxUint64, 0
+	progress.MissingByCollection = make(map[string]int)
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		if blockNum < progress.OldestMissingBlock {
+			progress.OldestMissingBlock = blockNum
+		}
+		if blockNum > progress.NewestMissingBlock {
+			progress.NewestMissingBlock = blockNum
+		}
+		for _, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				progress.MissingItems++
+				progress.MissingByCollection[pvtDataInfo.Namespace+"/"+pvtDataInfo.Collection]++
+			}
+		}
+	}
+	if progress.MissingBlocks == 0 {
+		progress.OldestMissingBlock = 0
+	}
+	return &progress, nil
+}
+
 func (r *Reconciler) run() {
 	for {
 		select {
 		case <-r.stopChan:
 			return
+		case req := <-r.triggerChan:
+			logger.Infof("Start reconcile missing private info of blocks [%d - %d] on demand", req.StartBlock, req.EndBlock)
+			if err := r.reconcileRequest(req); err != nil {
+				logger.Error("Failed to reconcile missing private info on demand, error: ", err.Error())
+			}
 		case <-time.After(r.config.SleepInterval):
 			logger.Debug("Start reconcile missing private info")
 			if err := r.reconcile(); err != nil {
@@ -115,23 +298,39 @@ func (r *Reconciler) run() {
 	}
 }
 
-// returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
 func (r *Reconciler) reconcile() error {
-	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	return r.reconcileRequest(nil)
+}
+
+// reconcileRequest reconciles the missing private data described by the given request.
+// A nil request denotes a scheduled cycle, which keeps reconciling the most recent blocks
+// until either nothing is missing or the missing data is not available on other peers.
+// An on-demand request walks its block range from the most recent block backwards.
+func (r *Reconciler) reconcileRequest(req *ReconcileRequest) error {
+	missingPvtDataTracker, err := r.getMissingPvtDataTracker()
 	if err != nil {
-		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
 		return err
 	}
-	if missingPvtDataTracker == nil {
-		logger.Error("got nil as MissingPvtDataTracker, exiting...")
-		return errors.New("got nil as MissingPvtDataTracker, exiting...")
-	}
 	totalReconciled, minBlock, maxBlock := 0, uint64(math.MaxUint64), uint64(0)
 
 	defer r.reportReconciliationDuration(time.Now())
+	r.cycleStarted(req)
+	defer func() {
+		r.cycleEnded(totalReconciled)
+	}()
+
+	endBlock := uint64(math.MaxUint64)
+	if req != nil {
+		endBlock = req.EndBlock
+	}
 
 	for {
-		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.config.BatchSize)
+		var missingPvtDataInfo ledger.MissingPvtDataInfo
+		if req == nil {
+			missingPvtDataInfo, err = missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.config.BatchSize)
+		} else {
+			missingPvtDataInfo, err = missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(req.StartBlock, endBlock, r.config.BatchSize)
+		}
 		if err != nil {
 			logger.Error("reconciliation error when trying to get missing pvt data info recent blocks:", err)
 			return err
@@ -148,32 +347,151 @@ func (r *Reconciler) reconcile() error {
 
 		logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
 
-		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
-		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo, req)
+		reconciled, err := r.fetchAndCommit(dig2collectionCfg)
+		totalReconciled += reconciled
 		if err != nil {
-			logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
 			return err
 		}
-		if len(fetchedData.AvailableElements) == 0 {
+		if reconciled == 0 && req == nil {
 			logger.Warning("missing private data is not available on other peers")
 			return nil
 		}
+		if reconciled > 0 {
+			if minB < minBlock {
+				minBlock = minB
+			}
+			if maxB > maxBlock {
+				maxBlock = maxB
+			}
+		}
+		if req != nil {
+			// as the missing private data of the blocks that were just visited may not be available
+			// or may be filtered out by the request, continue with the blocks that precede them
+			if minB <= req.StartBlock {
+				logger.Infof("Reconciliation of blocks [%d - %d] finished. reconciled %d private data keys", req.StartBlock, req.EndBlock, totalReconciled)
+				return nil
+			}
+			endBlock = minB - 1
+		}
+	}
+}
+
+func (r *Reconciler) getMissingPvtDataTracker() (ledger.MissingPvtDataTracker, error) {
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
+		return nil, err
+	}
+	if missingPvtDataTracker == nil {
+		logger.Error("got nil as MissingPvtDataTracker, exiting...")
+		return nil, errors.New("got nil as MissingPvtDataTracker, exiting...")
+	}
+	return missingPvtDataTracker, nil
+}
+
+// fetchAndCommit pulls the missing private data from other peers, in the order determined by the
+// configured priorities, commits it to the ledger and returns the number of reconciled items
+func (r *Reconciler) fetchAndCommit(dig2collectionCfg privdatacommon.Dig2CollectionConfig) (int, error) {
+	reconciled := 0
+	for _, dig2collectionCfg := range r.prioritize(dig2collectionCfg) {
+		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+		if err != nil {
+			logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
+			return reconciled, err
+		}
+		if len(fetchedData.AvailableElements) == 0 {
+			continue
+		}
 
-		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
+		pvtDataToCommit := preparePvtDataToCommit(fetchedData.AvailableElements)
 		// commit missing private data that was reconciled and log mismatched
 		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit)
 		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
+			return reconciled, errors.Wrap(err, "failed to commit private data")
 		}
-		r.logMismatched(pvtdataHashMismatch)
-		if minB < minBlock {
-			minBlock = minB
+		logMismatched(pvtdataHashMismatch)
+		reconciled += len(fetchedData.AvailableElements)
+		r.throttle(fetchedData.AvailableElements)
+	}
+	return reconciled, nil
+}
+
+// prioritize splits the given digests into groups that are ordered by the configured collection priorities.
+// Unless the reconciler is configured to reconcile by collection priority, a single group is returned.
+func (r *Reconciler) prioritize(dig2collectionCfg privdatacommon.Dig2CollectionConfig) []privdatacommon.Dig2CollectionConfig {
+	if r.config.Order != CollectionPriority || len(dig2collectionCfg) == 0 {
+		return []privdatacommon.Dig2CollectionConfig{dig2collectionCfg}
+	}
+
+	groups := make(map[int]privdatacommon.Dig2CollectionConfig)
+	for digKey, collectionConfig := range dig2collectionCfg {
+		priority := r.priorityOf(digKey.Namespace, digKey.Collection)
+		if _, exists := groups[priority]; !exists {
+			groups[priority] = make(privdatacommon.Dig2CollectionConfig)
 		}
-		if maxB > maxBlock {
-			maxBlock = maxB
+		groups[priority][digKey] = collectionConfig
+	}
+
+	var priorities []int
+	for priority := range groups {
+		priorities = append(priorities, priority)
+	}
+	sort.Ints(priorities)
+
+	var res []privdatacommon.Dig2CollectionConfig
+	for _, priority := range priorities {
+		res = append(res, groups[priority])
+	}
+	return res
+}
+
+// priorityOf returns the priority of the given collection, where a lower value denotes a higher priority
+func (r *Reconciler) priorityOf(namespace, collection string) int {
+	for i, entry := range r.config.CollectionPriorities {
+		if entry == namespace || entry == namespace+"/"+collection {
+			return i
 		}
-		totalReconciled += len(fetchedData.AvailableElements)
 	}
+	return len(r.config.CollectionPriorities)
+}
+
+// throttle blocks until the given elements, and the ones pulled before them by the
+// reconcilers sharing the rate limiter, are pulled at the configured rate
+func (r *Reconciler) throttle(elements []*gossip2.PvtDataElement) {
+	if r.config.RateLimiter == nil {
+		return
+	}
+	size := 0
+	for _, element := range elements {
+		for _, rws := range element.Payload {
+			size += len(rws)
+		}
+	}
+	delay := r.config.RateLimiter.Reserve(size)
+	select {
+	case <-r.stopChan:
+	case <-time.After(delay):
+	}
+}
+
+func (r *Reconciler) cycleStarted(req *ReconcileRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if req != nil && r.progress.PendingRequest == req {
+		r.progress.PendingRequest = nil
+	}
+	r.progress.InProgress = true
+	r.progress.LastCycleStart = time.Now()
+}
+
+func (r *Reconciler) cycleEnded(reconciled int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.progress.InProgress = false
+	r.progress.LastCycleEnd = time.Now()
+	r.progress.LastCycleReconciled = reconciled
+	r.progress.TotalReconciled += reconciled
 }
 
 func (r *Reconciler) reportReconciliationDuration(startTime time.Time) {
@@ -185,7 +503,7 @@ type collectionConfigKey struct {
 	blockNum                      uint64
 }
 
-func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPvtDataInfo) (privdatacommon.Dig2CollectionConfig, uint64, uint64) {
+func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPvtDataInfo, req *ReconcileRequest) (privdatacommon.Dig2CollectionConfig, uint64, uint64) {
 	var minBlock, maxBlock uint64
 	minBlock = math.MaxUint64
 	maxBlock = 0
@@ -200,13 +518,16 @@ func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPv
 		}
 		for seqInBlock, collectionPvtDataInfo := range blockPvtDataInfo {
 			for _, pvtDataInfo := range collectionPvtDataInfo {
+				if !req.matches(pvtDataInfo.Namespace, pvtDataInfo.Collection) {
+					continue
+				}
 				collConfigKey := collectionConfigKey{
 					chaincodeName:  pvtDataInfo.Namespace,
 					collectionName: pvtDataInfo.Collection,
 					blockNum:       blockNum,
 				}
 				if _, exists := collectionConfigCache[collConfigKey]; !exists {
-					collectionConfig, err := r.getMostRecentCollectionConfig(pvtDataInfo.Namespace, pvtDataInfo.Collection, blockNum)
+					collectionConfig, err := getMostRecentCollectionConfig(r.Committer, pvtDataInfo.Namespace, pvtDataInfo.Collection, blockNum)
 					if err != nil {
 						logger.Debug(err)
 						continue
@@ -226,8 +547,8 @@ func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPv
 	return dig2collectionCfg, minBlock, maxBlock
 }
 
-func (r *Reconciler) getMostRecentCollectionConfig(chaincodeName string, collectionName string, blockNum uint64) (*common.StaticCollectionConfig, error) {
-	configHistoryRetriever, err := r.GetConfigHistoryRetriever()
+func getMostRecentCollectionConfig(c committer.Committer, chaincodeName string, collectionName string, blockNum uint64) (*common.StaticCollectionConfig, error) {
+	configHistoryRetriever, err := c.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, errors.Wrap(err, "configHistoryRetriever is not available")
 	}
@@ -252,8 +573,8 @@ func (r *Reconciler) getMostRecentCollectionConfig(chaincodeName string, collect
 	return staticCollectionConfig.StaticCollectionConfig, nil
 }
 
-func (r *Reconciler) preparePvtDataToCommit(elements []*gossip2.PvtDataElement) []*ledger.BlockPvtData {
-	rwSetByBlockByKeys := r.groupRwsetByBlock(elements)
+func preparePvtDataToCommit(elements []*gossip2.PvtDataElement) []*ledger.BlockPvtData {
+	rwSetByBlockByKeys := groupRwsetByBlock(elements)
 
 	// populate the private RWSets passed to the ledger
 	var pvtDataToCommit []*ledger.BlockPvtData
@@ -276,7 +597,7 @@ func (r *Reconciler) preparePvtDataToCommit(elements []*gossip2.PvtDataElement)
 	return pvtDataToCommit
 }
 
-func (r *Reconciler) logMismatched(pvtdataMismatched []*ledger.PvtdataHashMismatch) {
+func logMismatched(pvtdataMismatched []*ledger.PvtdataHashMismatch) {
 	if len(pvtdataMismatched) > 0 {
 		for _, hashMismatch := range pvtdataMismatched {
 			logger.Warningf("failed to reconcile pvtdata chaincode %s, collection %s, block num %d, tx num %d due to hash mismatch",
@@ -286,7 +607,7 @@ func (r *Reconciler) logMismatched(pvtdataMismatched []*ledger.PvtdataHashMismat
 }
 
 // return a mapping from block num to rwsetByKeys
-func (r *Reconciler) groupRwsetByBlock(elements []*gossip2.PvtDataElement) map[uint64]rwsetByKeys {
+func groupRwsetByBlock(elements []*gossip2.PvtDataElement) map[uint64]rwsetByKeys {
 	rwSetByBlockByKeys := make(map[uint64]rwsetByKeys) // map from block num to rwsetByKeys
 
 	// Iterate over data fetched from peers
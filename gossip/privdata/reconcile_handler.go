@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/httputil"
+)
+
+// ReconcilerProvider returns the private data reconciler of a channel, or nil
+// if the peer hasn't joined the channel
+type ReconcilerProvider func(channel string) PvtDataReconciler
+
+// ReconcileHandler serves the reconciliation progress of a channel on GET requests,
+// and triggers an immediate reconciliation on POST requests
+type ReconcileHandler struct {
+	Reconcilers ReconcilerProvider
+}
+
+// ReconcileHandlerRequest is the body of a POST request
+type ReconcileHandlerRequest struct {
+	Channel string `json:"channel"`
+	ReconcileRequest
+}
+
+func (h *ReconcileHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		reconciler, err := h.reconciler(req.URL.Query().Get("channel"))
+		if err != nil {
+			httputil.SendJSONResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		progress, err := reconciler.Progress()
+		if err != nil {
+			httputil.SendJSONResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		httputil.SendJSONResponse(resp, http.StatusOK, progress)
+
+	case http.MethodPost:
+		var reconcileReq ReconcileHandlerRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&reconcileReq); err != nil {
+			httputil.SendJSONResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		reconciler, err := h.reconciler(reconcileReq.Channel)
+		if err != nil {
+			httputil.SendJSONResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		if err := reconciler.Trigger(reconcileReq.ReconcileRequest); err != nil {
+			httputil.SendJSONResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		resp.WriteHeader(http.StatusAccepted)
+
+	default:
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		httputil.SendJSONResponse(resp, http.StatusBadRequest, err)
+	}
+}
+
+func (h *ReconcileHandler) reconciler(channel string) (PvtDataReconciler, error) {
+	if channel == "" {
+		return nil, fmt.Errorf("channel is not specified")
+	}
+	reconciler := h.Reconcilers(channel)
+	if reconciler == nil {
+		return nil, fmt.Errorf("channel %s does not exist", channel)
+	}
+	return reconciler, nil
+}
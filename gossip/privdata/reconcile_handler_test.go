@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type reconcilerStub struct {
+	triggered []ReconcileRequest
+	err       error
+}
+
+func (*reconcilerStub) Start() {}
+
+func (*reconcilerStub) Stop() {}
+
+func (r *reconcilerStub) Trigger(req ReconcileRequest) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.triggered = append(r.triggered, req)
+	return nil
+}
+
+func (r *reconcilerStub) Progress() (*ReconcileProgress, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &ReconcileProgress{Channel: "mychannel", MissingBlocks: 3}, nil
+}
+
+func TestReconcileHandler(t *testing.T) {
+	reconciler := &reconcilerStub{}
+	handler := &ReconcileHandler{
+		Reconcilers: func(channel string) PvtDataReconciler {
+			if channel != "mychannel" {
+				return nil
+			}
+			return reconciler
+		},
+	}
+
+	t.Run("progress", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/privdata/reconcile?channel=mychannel", nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		progress := &ReconcileProgress{}
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), progress))
+		assert.Equal(t, "mychannel", progress.Channel)
+		assert.Equal(t, 3, progress.MissingBlocks)
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		body := `{"channel": "mychannel", "start_block": 10, "end_block": 20, "namespace": "ns1", "collection": "col1"}`
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/privdata/reconcile", strings.NewReader(body)))
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Equal(t, []ReconcileRequest{{StartBlock: 10, EndBlock: 20, Namespace: "ns1", Collection: "col1"}}, reconciler.triggered)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/privdata/reconcile?channel=foo", nil))
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.JSONEq(t, `{"error": "channel foo does not exist"}`, resp.Body.String())
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/privdata/reconcile", strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.JSONEq(t, `{"error": "channel is not specified"}`, resp.Body.String())
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/privdata/reconcile", strings.NewReader(`{`)))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, "/privdata/reconcile", nil))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.JSONEq(t, `{"error": "invalid request method: DELETE"}`, resp.Body.String())
+	})
+
+	t.Run("reconciler failure", func(t *testing.T) {
+		reconciler.err = errors.New("a reconciliation request is already pending")
+		defer func() { reconciler.err = nil }()
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/privdata/reconcile", strings.NewReader(`{"channel": "mychannel"}`)))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.JSONEq(t, `{"error": "a reconciliation request is already pending"}`, resp.Body.String())
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/privdata/reconcile?channel=mychannel", nil))
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -8,6 +8,7 @@ package privdata
 
 import (
 	"errors"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -477,3 +478,208 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
+
+func availableElements(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+	result := &privdatacommon.FetchedPvtDataContainer{}
+	for digest := range dig2CollectionConfig {
+		element := &gossip2.PvtDataElement{
+			Digest: &gossip2.PvtDataDigest{
+				TxId:       digest.TxId,
+				BlockSeq:   digest.BlockSeq,
+				Collection: digest.Collection,
+				Namespace:  digest.Namespace,
+				SeqInBlock: digest.SeqInBlock,
+			},
+			Payload: [][]byte{util2.ComputeHash([]byte("rws-pre-image"))},
+		}
+		result.AvailableElements = append(result.AvailableElements, element)
+	}
+	return result
+}
+
+func collectionConfigInfoOf(collections ...string) *ledger.CollectionConfigInfo {
+	configPackage := &common.CollectionConfigPackage{}
+	for _, collection := range collections {
+		configPackage.Config = append(configPackage.Config, &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{
+					Name: collection,
+				},
+			},
+		})
+	}
+	return &ledger.CollectionConfigInfo{CollectionConfig: configPackage, CommittingBlockNum: 1}
+}
+
+func TestReconciliationByCollectionPriority(t *testing.T) {
+	// Scenario: a batch of missing private data spans two collections, and the reconciler
+	// is configured to prioritize the namespace of the second one.
+	// The high priority collection should be fetched and committed first.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := ledger.MissingPvtDataInfo{
+		5: ledger.MissingBlockPvtdataInfo{
+			1: {{Collection: "col1", Namespace: "ns1"}},
+			2: {{Collection: "col2", Namespace: "ns2"}},
+		},
+	}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(missingInfo, nil).Run(func(_ mock.Arguments) {
+		missingPvtDataTracker.Mock = mock.Mock{}
+		missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(nil, nil)
+	})
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(collectionConfigInfoOf("col1", "col2"), nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Return([]*ledger.PvtdataHashMismatch{}, nil)
+
+	var fetchedNamespaces []string
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		assert.Equal(t, 1, len(dig2CollectionConfig))
+		for digest := range dig2CollectionConfig {
+			fetchedNamespaces = append(fetchedNamespaces, digest.Namespace)
+		}
+		return availableElements(dig2CollectionConfig)
+	}, nil)
+
+	r := &Reconciler{channel: "", metrics: metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		config: &ReconcilerConfig{SleepInterval: time.Minute, BatchSize: 1, IsEnabled: true,
+			Order: CollectionPriority, CollectionPriorities: []string{"ns3/col3", "ns2"}},
+		ReconciliationFetcher: fetcher, Committer: committer}
+	err := r.reconcile()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns2", "ns1"}, fetchedNamespaces)
+	committer.AssertNumberOfCalls(t, "CommitPvtDataOfOldBlocks", 2)
+}
+
+func TestReconciliationOnDemandBlockRange(t *testing.T) {
+	// Scenario: an on-demand reconciliation is requested for a block range and a namespace.
+	// The reconciler should walk the range from its most recent block backwards,
+	// pulling only the missing private data of the requested namespace.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(10), 1).Return(ledger.MissingPvtDataInfo{
+		7: ledger.MissingBlockPvtdataInfo{
+			1: {{Collection: "col1", Namespace: "ns1"}, {Collection: "col1", Namespace: "ns2"}},
+		},
+	}, nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(6), 1).Return(ledger.MissingPvtDataInfo{
+		3: ledger.MissingBlockPvtdataInfo{
+			4: {{Collection: "col2", Namespace: "ns2"}},
+		},
+	}, nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(2), 1).Return(ledger.MissingPvtDataInfo{
+		2: ledger.MissingBlockPvtdataInfo{
+			3: {{Collection: "col1", Namespace: "ns1"}},
+		},
+	}, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(collectionConfigInfoOf("col1", "col2"), nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var committedBlocks []uint64
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		for _, blockPvtData := range args.Get(0).([]*ledger.BlockPvtData) {
+			committedBlocks = append(committedBlocks, blockPvtData.BlockNum)
+		}
+	}).Return([]*ledger.PvtdataHashMismatch{}, nil)
+
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		for digest := range dig2CollectionConfig {
+			assert.Equal(t, "ns1", digest.Namespace)
+		}
+		return availableElements(dig2CollectionConfig)
+	}, nil)
+
+	r := &Reconciler{channel: "", metrics: metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		config:                &ReconcilerConfig{SleepInterval: time.Minute, BatchSize: 1, IsEnabled: true},
+		ReconciliationFetcher: fetcher, Committer: committer}
+	err := r.reconcileRequest(&ReconcileRequest{StartBlock: 2, EndBlock: 10, Namespace: "ns1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{7, 2}, committedBlocks)
+	fetcher.AssertNumberOfCalls(t, "FetchReconciledItems", 3)
+	missingPvtDataTracker.AssertExpectations(t)
+}
+
+func TestReconciliationThrottling(t *testing.T) {
+	r := &Reconciler{config: &ReconcilerConfig{RateLimiter: NewRateLimiter(1000)}}
+	elements := []*gossip2.PvtDataElement{{Payload: [][]byte{make([]byte, 100)}}}
+
+	start := time.Now()
+	r.throttle(elements)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+
+	// the reconcilers of the other channels wait for the pulls of this one
+	other := &Reconciler{config: &ReconcilerConfig{RateLimiter: r.config.RateLimiter}}
+	r.config.RateLimiter.Reserve(200)
+	start = time.Now()
+	other.throttle(elements)
+	assert.True(t, time.Since(start) >= 300*time.Millisecond)
+
+	r.config.RateLimiter = NewRateLimiter(0)
+	assert.Nil(t, r.config.RateLimiter)
+	start = time.Now()
+	r.throttle(elements)
+	assert.True(t, time.Since(start) < 100*time.Millisecond)
+}
+
+func TestRateLimiter(t *testing.T) {
+	var unlimited *RateLimiter
+	assert.Equal(t, time.Duration(0), unlimited.Reserve(100))
+
+	l := NewRateLimiter(1000)
+	assert.InDelta(t, float64(100*time.Millisecond), float64(l.Reserve(100)), float64(10*time.Millisecond))
+	assert.InDelta(t, float64(300*time.Millisecond), float64(l.Reserve(200)), float64(10*time.Millisecond))
+
+	// the bytes reserved in the past don't delay the pulls of the future
+	l.next = time.Now().Add(-time.Second)
+	assert.InDelta(t, float64(100*time.Millisecond), float64(l.Reserve(100)), float64(10*time.Millisecond))
+}
+
+func TestReconcilerTriggerAndProgress(t *testing.T) {
+	committer := &mocks.Committer{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", maxProgressBlocks).Return(ledger.MissingPvtDataInfo{
+		8: ledger.MissingBlockPvtdataInfo{
+			1: {{Collection: "col1", Namespace: "ns1"}, {Collection: "col2", Namespace: "ns1"}},
+		},
+		4: ledger.MissingBlockPvtdataInfo{
+			2: {{Collection: "col1", Namespace: "ns1"}},
+		},
+	}, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer,
+		&mocks.ReconciliationFetcher{}, &ReconcilerConfig{SleepInterval: time.Minute, BatchSize: 1, IsEnabled: true})
+
+	err := r.Trigger(ReconcileRequest{StartBlock: 5, EndBlock: 4})
+	assert.EqualError(t, err, "invalid block range [5 - 4]")
+	err = r.Trigger(ReconcileRequest{Collection: "col1"})
+	assert.EqualError(t, err, "a collection cannot be reconciled without specifying its namespace")
+
+	assert.NoError(t, r.Trigger(ReconcileRequest{StartBlock: 4, Namespace: "ns1"}))
+	err = r.Trigger(ReconcileRequest{StartBlock: 1})
+	assert.EqualError(t, err, "a reconciliation request is already pending")
+
+	progress, err := r.Progress()
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", progress.Channel)
+	assert.Equal(t, &ReconcileRequest{StartBlock: 4, EndBlock: math.MaxUint64, Namespace: "ns1"}, progress.PendingRequest)
+	assert.Equal(t, 2, progress.MissingBlocks)
+	assert.Equal(t, 3, progress.MissingItems)
+	assert.Equal(t, uint64(4), progress.OldestMissingBlock)
+	assert.Equal(t, uint64(8), progress.NewestMissingBlock)
+	assert.Equal(t, map[string]int{"ns1/col1": 2, "ns1/col2": 1}, progress.MissingByCollection)
+
+	noop := &NoOpReconciler{}
+	assert.Error(t, noop.Trigger(ReconcileRequest{}))
+	_, err = noop.Progress()
+	assert.Error(t, err)
+}
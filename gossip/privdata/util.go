@@ -327,6 +327,10 @@ const (
 	reconcileBatchSizeConfigKey      = "peer.gossip.pvtData.reconcileBatchSize"
 	reconcileBatchSizeDefault        = 10
 	reconciliationEnabledConfigKey   = "peer.gossip.pvtData.reconciliationEnabled"
+	reconcileOrderConfigKey          = "peer.gossip.pvtData.reconcileOrder"
+	reconcileOrderDefault            = NewestFirst
+	reconcilePrioritiesConfigKey     = "peer.gossip.pvtData.reconcileCollectionPriorities"
+	reconcileMaxBytesPerSecKey       = "peer.gossip.pvtData.reconcileMaxBytesPerSecond"
 )
 
 // this func reads reconciler configuration values from core.yaml and returns ReconcilerConfig
@@ -342,7 +346,64 @@ func GetReconcilerConfig() *ReconcilerConfig {
 		reconcileBatchSize = reconcileBatchSizeDefault
 	}
 	isEnabled := viper.GetBool(reconciliationEnabledConfigKey)
-	return &ReconcilerConfig{SleepInterval: reconcileSleepInterval, BatchSize: reconcileBatchSize, IsEnabled: isEnabled}
+	reconcileOrder := ReconcileOrder(viper.GetString(reconcileOrderConfigKey))
+	switch reconcileOrder {
+	case NewestFirst, CollectionPriority:
+	case "":
+		reconcileOrder = reconcileOrderDefault
+	default:
+		logger.Warning("Configuration key", reconcileOrderConfigKey, "has an invalid value", reconcileOrder, ", defaulting to", reconcileOrderDefault)
+		reconcileOrder = reconcileOrderDefault
+	}
+	return &ReconcilerConfig{
+		SleepInterval:        reconcileSleepInterval,
+		BatchSize:            reconcileBatchSize,
+		IsEnabled:            isEnabled,
+		Order:                reconcileOrder,
+		CollectionPriorities: viper.GetStringSlice(reconcilePrioritiesConfigKey),
+	}
+}
+
+// GetReconcileRateLimiter returns the rate limiter which the reconcilers of all the
+// channels share, or nil if the reconciliation rate isn't limited
+func GetReconcileRateLimiter() *RateLimiter {
+	reconcileMaxBytesPerSecond := viper.GetInt(reconcileMaxBytesPerSecKey)
+	if reconcileMaxBytesPerSecond < 0 {
+		logger.Warning("Configuration key", reconcileMaxBytesPerSecKey, "is negative, reconciliation rate is not limited")
+	}
+	return NewRateLimiter(reconcileMaxBytesPerSecond)
+}
+
+const (
+	antiEntropyEnabledConfigKey     = "peer.gossip.pvtData.antiEntropy.enabled"
+	antiEntropyIntervalConfigKey    = "peer.gossip.pvtData.antiEntropy.interval"
+	antiEntropyIntervalDefault      = time.Minute * 10
+	antiEntropyBlockWindowConfigKey = "peer.gossip.pvtData.antiEntropy.blockWindow"
+	antiEntropyBlockWindowDefault   = 100
+	antiEntropyPeerNumConfigKey     = "peer.gossip.pvtData.antiEntropy.peerNum"
+	antiEntropyPeerNumDefault       = 2
+)
+
+// GetAntiEntropyConfig reads the private data anti-entropy configuration values from core.yaml and returns AntiEntropyConfig
+func GetAntiEntropyConfig() *AntiEntropyConfig {
+	interval := viper.GetDuration(antiEntropyIntervalConfigKey)
+	if interval <= 0 {
+		interval = antiEntropyIntervalDefault
+	}
+	blockWindow := viper.GetInt(antiEntropyBlockWindowConfigKey)
+	if blockWindow <= 0 {
+		blockWindow = antiEntropyBlockWindowDefault
+	}
+	peerNum := viper.GetInt(antiEntropyPeerNumConfigKey)
+	if peerNum <= 0 {
+		peerNum = antiEntropyPeerNumDefault
+	}
+	return &AntiEntropyConfig{
+		IsEnabled:   viper.GetBool(antiEntropyEnabledConfigKey),
+		Interval:    interval,
+		BlockWindow: blockWindow,
+		PeerNum:     peerNum,
+	}
 }
 
 const (
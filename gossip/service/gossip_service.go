@@ -51,8 +51,17 @@ type GossipService interface {
 	NewConfigEventer() ConfigProcessor
 	// InitializeChannel allocates the state provider and should be invoked once per channel per execution
 	InitializeChannel(chainID string, oac OrdererAddressConfig, support Support)
+	// CloseChannel stops the state provider, the private data handlers, the leader election and
+	// the delivery service of the given chain and makes the peer leave the gossip channel
+	CloseChannel(chainID string)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// PvtDataReconciler returns the private data reconciler of the given chain,
+	// or nil if the chain hasn't been initialized
+	PvtDataReconciler(chainID string) privdata2.PvtDataReconciler
+	// Introspect returns a snapshot of the membership and of the state of the given
+	// chain, or of all chains if the chainID is empty
+	Introspect(chainID string) (*Introspection, error)
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -94,11 +103,13 @@ type privateHandler struct {
 	coordinator privdata2.Coordinator
 	distributor privdata2.PvtDataDistributor
 	reconciler  privdata2.PvtDataReconciler
+	antiEntropy privdata2.PvtDataAntiEntropy
 }
 
 func (p privateHandler) close() {
 	p.coordinator.Close()
 	p.reconciler.Stop()
+	p.antiEntropy.Stop()
 }
 
 type gossipServiceImpl struct {
@@ -113,6 +124,8 @@ type gossipServiceImpl struct {
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
 	metrics         *gossipMetrics.GossipMetrics
+	// reconcileRateLimiter limits the rate of the reconciliation of all the chains
+	reconcileRateLimiter *privdata2.RateLimiter
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -212,6 +225,8 @@ func InitGossipServiceCustomDeliveryFactory(
 			peerIdentity:    peerIdentity,
 			secAdv:          secAdv,
 			metrics:         gossipMetrics,
+
+			reconcileRateLimiter: privdata2.GetReconcileRateLimiter(),
 		}
 	})
 	return errors.WithStack(err)
@@ -292,6 +307,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 		TransientBlockRetention:        privdata2.GetTransientBlockRetention(),
 		PullRetryThreshold:             viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold"),
 		SkipPullingInvalidTransactions: viper.GetBool("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit"),
+		PipelinedValidation:            viper.GetBool("peer.validatorPipelining"),
 	}
 
 	selfSignedData := g.createSelfSignedData()
@@ -307,6 +323,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 	}, selfSignedData, g.metrics.PrivdataMetrics, coordinatorConfig)
 
 	reconcilerConfig := privdata2.GetReconcilerConfig()
+	reconcilerConfig.RateLimiter = g.reconcileRateLimiter
 	var reconciler privdata2.PvtDataReconciler
 
 	if reconcilerConfig.IsEnabled {
@@ -316,14 +333,31 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 		reconciler = &privdata2.NoOpReconciler{}
 	}
 
+	antiEntropyConfig := privdata2.GetAntiEntropyConfig()
+	var antiEntropy privdata2.PvtDataAntiEntropy
+
+	if antiEntropyConfig.IsEnabled {
+		antiEntropy = privdata2.NewAntiEntropy(mspID, privdata2.AntiEntropySupport{
+			ChainID:               chainID,
+			CollectionStore:       support.Cs,
+			Committer:             support.Committer,
+			ReconciliationFetcher: fetcher,
+			SecurityAdvisor:       g.secAdv,
+		}, g.gossipSvc, selfSignedData, g.metrics.PrivdataMetrics, antiEntropyConfig)
+	} else {
+		antiEntropy = &privdata2.NoOpAntiEntropy{}
+	}
+
 	pushAckTimeout := viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	g.privateHandlers[chainID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
 		distributor: privdata2.NewDistributor(chainID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
 		reconciler:  reconciler,
+		antiEntropy: antiEntropy,
 	}
 	g.privateHandlers[chainID].reconciler.Start()
+	g.privateHandlers[chainID].antiEntropy.Start()
 
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator,
 		g.metrics.StateMetrics, getStateConfiguration())
@@ -417,6 +451,31 @@ func (g *gossipServiceImpl) updateEndpoints(chainID string, criteria deliverclie
 	}
 }
 
+// CloseChannel stops the components of the given chain and leaves its gossip channel
+func (g *gossipServiceImpl) CloseChannel(chainID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	logger.Info("Closing chain", chainID)
+	if le, exists := g.leaderElection[chainID]; exists {
+		le.Stop()
+		delete(g.leaderElection, chainID)
+	}
+	if stateProvider, exists := g.chains[chainID]; exists {
+		stateProvider.Stop()
+		delete(g.chains, chainID)
+	}
+	if handler, exists := g.privateHandlers[chainID]; exists {
+		handler.close()
+		delete(g.privateHandlers, chainID)
+	}
+	if g.deliveryService[chainID] != nil {
+		g.deliveryService[chainID].Stop()
+	}
+	delete(g.deliveryService, chainID)
+	g.gossipSvc.LeaveChan(gossipCommon.ChainID(chainID))
+}
+
 // AddPayload appends message payload to for given chain
 func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload) error {
 	g.lock.RLock()
@@ -424,6 +483,17 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// PvtDataReconciler returns the private data reconciler of the given chain
+func (g *gossipServiceImpl) PvtDataReconciler(chainID string) privdata2.PvtDataReconciler {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	handler, exists := g.privateHandlers[chainID]
+	if !exists {
+		return nil
+	}
+	return handler.reconciler
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
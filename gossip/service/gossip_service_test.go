@@ -277,6 +277,51 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestCloseChannel(t *testing.T) {
+	util.SetVal("peer.gossip.useLeaderElection", false)
+	util.SetVal("peer.gossip.orgLeader", true)
+
+	n := 2
+	gossips := startPeers(t, n, 0, 1)
+	defer stopPeers(gossips)
+
+	peerIndexes := []int{0, 1}
+	for _, channelName := range []string{"chanA", "chanB"} {
+		addPeersToChannel(t, n, channelName, gossips, peerIndexes)
+	}
+	waitForFullMembership(t, gossips, n, time.Second*30, time.Second*2)
+
+	deliverServices := make([]*mockDeliverService, n)
+	for i := 0; i < n; i++ {
+		deliverServices[i] = &mockDeliverService{running: make(map[string]bool)}
+		gossips[i].(*gossipGRPC).gossipServiceImpl.deliveryFactory = &mockDeliverServiceFactory{service: deliverServices[i]}
+		for _, channelName := range []string{"chanA", "chanB"} {
+			gossips[i].InitializeChannel(channelName, endpointConfig, Support{
+				Committer: &mockLedgerInfo{1},
+				Store:     &mockTransientStore{},
+			})
+		}
+	}
+
+	gossips[0].CloseChannel("chanA")
+	gossipSvc := gossips[0].(*gossipGRPC).gossipServiceImpl
+	assert.True(t, deliverServices[0].stopped)
+	assert.NotContains(t, gossipSvc.deliveryService, "chanA")
+	assert.NotContains(t, gossipSvc.chains, "chanA")
+	assert.NotContains(t, gossipSvc.privateHandlers, "chanA")
+	assert.Nil(t, gossipSvc.PvtDataReconciler("chanA"))
+	assert.Empty(t, gossips[0].PeersOfChannel(gossipCommon.ChainID("chanA")))
+
+	// the other channels of the peer and the channel on the other peers are unaffected
+	assert.Contains(t, gossipSvc.chains, "chanB")
+	assert.NotNil(t, gossipSvc.PvtDataReconciler("chanB"))
+	assert.Contains(t, gossips[1].(*gossipGRPC).gossipServiceImpl.chains, "chanA")
+	assert.False(t, deliverServices[1].stopped)
+
+	// closing a channel that isn't initialized is harmless
+	gossips[0].CloseChannel("chanC")
+}
+
 func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	util.SetVal("peer.gossip.useLeaderElection", true)
 	util.SetVal("peer.gossip.orgLeader", true)
@@ -323,12 +368,17 @@ func (mf *mockDeliverServiceFactory) Service(_ GossipService, _ OrdererAddressCo
 
 type mockDeliverService struct {
 	running map[string]bool
+	stopped bool
 }
 
 func (ds *mockDeliverService) UpdateEndpoints(_ string, _ deliverclient.ConnectionCriteria) error {
 	panic("implement me")
 }
 
+func (ds *mockDeliverService) Endpoint(_ string) string {
+	panic("implement me")
+}
+
 func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	ds.running[chainID] = true
 	return nil
@@ -340,6 +390,7 @@ func (ds *mockDeliverService) StopDeliverForChannel(chainID string) error {
 }
 
 func (ds *mockDeliverService) Stop() {
+	ds.stopped = true
 }
 
 type mockLedgerInfo struct {
@@ -366,6 +417,10 @@ func (li *mockLedgerInfo) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockP
 	panic("implement me")
 }
 
+func (li *mockLedgerInfo) RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	panic("implement me")
+}
+
 func (li *mockLedgerInfo) GetPvtDataAndBlockByNum(seqNum uint64) (*ledger.BlockAndPvtData, error) {
 	panic("implement me")
 }
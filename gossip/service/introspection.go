@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/common/httputil"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/state"
+	"github.com/spf13/viper"
+)
+
+const (
+	// LeaderElected means the leader of the channel is dynamically elected
+	LeaderElected = "election"
+	// LeaderStatic means this peer is statically configured as the leader of the channel
+	LeaderStatic = "static"
+	// LeaderNone means this peer doesn't pull blocks from the ordering service
+	LeaderNone = "none"
+)
+
+// Introspection is a read-only snapshot of the gossip membership
+// and of the state of the channels this peer has joined
+type Introspection struct {
+	Members  []MemberIntrospection  `json:"members"`
+	Channels []ChannelIntrospection `json:"channels"`
+}
+
+// MemberIntrospection describes a peer known to the discovery layer
+type MemberIntrospection struct {
+	Endpoint         string    `json:"endpoint"`
+	InternalEndpoint string    `json:"internal_endpoint,omitempty"`
+	PKIID            string    `json:"pki_id"`
+	Alive            bool      `json:"alive"`
+	IncNum           uint64    `json:"inc_num"`
+	SeqNum           uint64    `json:"seq_num"`
+	LastSeen         time.Time `json:"last_seen"`
+}
+
+// ChannelIntrospection describes the state of a channel
+type ChannelIntrospection struct {
+	Channel         string                `json:"channel"`
+	Peers           []PeerIntrospection   `json:"peers"`
+	Leader          LeaderIntrospection   `json:"leader"`
+	DeliverEndpoint string                `json:"deliver_endpoint,omitempty"`
+	PendingPayloads state.PendingPayloads `json:"pending_payloads"`
+}
+
+// PeerIntrospection describes a peer in the membership view of a channel
+type PeerIntrospection struct {
+	Endpoint     string   `json:"endpoint"`
+	PKIID        string   `json:"pki_id"`
+	LedgerHeight uint64   `json:"ledger_height"`
+	Chaincodes   []string `json:"chaincodes,omitempty"`
+}
+
+// LeaderIntrospection describes the leader of the peers of the organization in a channel
+type LeaderIntrospection struct {
+	Mode  string    `json:"mode"`
+	PKIID string    `json:"pki_id,omitempty"`
+	Since time.Time `json:"since,omitempty"`
+	Self  bool      `json:"self"`
+}
+
+// Introspect returns a snapshot of the membership and of the state of the given
+// channel, or of all channels if the channel is empty
+func (g *gossipServiceImpl) Introspect(chainID string) (*Introspection, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	var chainIDs []string
+	if chainID != "" {
+		if _, exists := g.chains[chainID]; !exists {
+			return nil, fmt.Errorf("channel %s does not exist", chainID)
+		}
+		chainIDs = []string{chainID}
+	} else {
+		for chainID := range g.chains {
+			chainIDs = append(chainIDs, chainID)
+		}
+		sort.Strings(chainIDs)
+	}
+
+	res := &Introspection{
+		Members:  []MemberIntrospection{},
+		Channels: []ChannelIntrospection{},
+	}
+	for _, m := range g.gossipSvc.MembershipState() {
+		res.Members = append(res.Members, MemberIntrospection{
+			Endpoint:         m.Endpoint,
+			InternalEndpoint: m.InternalEndpoint,
+			PKIID:            hex.EncodeToString(m.PKIid),
+			Alive:            m.Alive,
+			IncNum:           m.IncNum,
+			SeqNum:           m.SeqNum,
+			LastSeen:         m.LastSeen,
+		})
+	}
+	for _, chainID := range chainIDs {
+		res.Channels = append(res.Channels, g.introspectChannel(chainID))
+	}
+	return res, nil
+}
+
+func (g *gossipServiceImpl) introspectChannel(chainID string) ChannelIntrospection {
+	ci := ChannelIntrospection{
+		Channel:         chainID,
+		Peers:           []PeerIntrospection{},
+		Leader:          LeaderIntrospection{Mode: LeaderNone},
+		PendingPayloads: g.chains[chainID].PendingPayloads(),
+	}
+	for _, peer := range g.gossipSvc.PeersOfChannel(gossipCommon.ChainID(chainID)) {
+		pi := PeerIntrospection{
+			Endpoint: peer.Endpoint,
+			PKIID:    hex.EncodeToString(peer.PKIid),
+		}
+		if peer.Properties != nil {
+			pi.LedgerHeight = peer.Properties.LedgerHeight
+			for _, cc := range peer.Properties.Chaincodes {
+				pi.Chaincodes = append(pi.Chaincodes, cc.Name+":"+cc.Version)
+			}
+		}
+		ci.Peers = append(ci.Peers, pi)
+	}
+
+	if le, exists := g.leaderElection[chainID]; exists {
+		leaderID, since := le.Leader()
+		ci.Leader = LeaderIntrospection{
+			Mode:  LeaderElected,
+			PKIID: hex.EncodeToString(leaderID),
+			Since: since,
+			Self:  le.IsLeader(),
+		}
+	} else if g.deliveryService[chainID] != nil && viper.GetBool("peer.gossip.orgLeader") {
+		ci.Leader = LeaderIntrospection{
+			Mode:  LeaderStatic,
+			PKIID: hex.EncodeToString(g.mcs.GetPKIidOfCert(g.peerIdentity)),
+			Self:  true,
+		}
+	}
+	if g.deliveryService[chainID] != nil {
+		ci.DeliverEndpoint = g.deliveryService[chainID].Endpoint(chainID)
+	}
+	return ci
+}
+
+// IntrospectionHandler serves the gossip introspection of the channel
+// specified by the channel query parameter, or of all channels
+type IntrospectionHandler struct {
+	Introspect func(chainID string) (*Introspection, error)
+}
+
+func (h *IntrospectionHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		httputil.SendJSONResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	introspection, err := h.Introspect(req.URL.Query().Get("channel"))
+	if err != nil {
+		httputil.SendJSONResponse(resp, http.StatusNotFound, err)
+		return
+	}
+	httputil.SendJSONResponse(resp, http.StatusOK, introspection)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/hyperledger/fabric/gossip/state"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+type introspectedGossip struct {
+	gossipSvc
+}
+
+func (*introspectedGossip) MembershipState() []discovery.MemberState {
+	return []discovery.MemberState{
+		{
+			NetworkMember: discovery.NetworkMember{Endpoint: "p1:7051", PKIid: common.PKIidType("p1")},
+			Alive:         true,
+			IncNum:        1,
+			SeqNum:        10,
+		},
+		{
+			NetworkMember: discovery.NetworkMember{Endpoint: "p2:7051", PKIid: common.PKIidType("p2")},
+			IncNum:        2,
+			SeqNum:        5,
+		},
+	}
+}
+
+func (*introspectedGossip) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	return []discovery.NetworkMember{
+		{
+			Endpoint: "p1:7051",
+			PKIid:    common.PKIidType("p1"),
+			Properties: &proto.Properties{
+				LedgerHeight: 100,
+				Chaincodes:   []*proto.Chaincode{{Name: "mycc", Version: "1.0"}},
+			},
+		},
+	}
+}
+
+type introspectedState struct {
+	state.GossipStateProvider
+}
+
+func (*introspectedState) PendingPayloads() state.PendingPayloads {
+	return state.PendingPayloads{Next: 100, Count: 2, Lowest: 102, Highest: 105}
+}
+
+type introspectedElection struct {
+	election.LeaderElectionService
+	since time.Time
+}
+
+func (*introspectedElection) IsLeader() bool {
+	return false
+}
+
+func (le *introspectedElection) Leader() ([]byte, time.Time) {
+	return []byte("p1"), le.since
+}
+
+type introspectedDeliverService struct {
+	deliverclient.DeliverService
+}
+
+func (*introspectedDeliverService) Endpoint(string) string {
+	return "orderer:7050"
+}
+
+func TestIntrospect(t *testing.T) {
+	since := time.Unix(1000, 0).UTC()
+	g := &gossipServiceImpl{
+		gossipSvc: &introspectedGossip{},
+		chains: map[string]state.GossipStateProvider{
+			"A": &introspectedState{},
+			"B": &introspectedState{},
+		},
+		leaderElection: map[string]election.LeaderElectionService{
+			"A": &introspectedElection{since: since},
+		},
+		deliveryService: map[string]deliverclient.DeliverService{
+			"A": &introspectedDeliverService{},
+		},
+	}
+
+	_, err := g.Introspect("C")
+	assert.EqualError(t, err, "channel C does not exist")
+
+	res, err := g.Introspect("")
+	assert.NoError(t, err)
+	assert.Equal(t, []MemberIntrospection{
+		{Endpoint: "p1:7051", PKIID: "7031", Alive: true, IncNum: 1, SeqNum: 10},
+		{Endpoint: "p2:7051", PKIID: "7032", IncNum: 2, SeqNum: 5},
+	}, res.Members)
+	assert.Len(t, res.Channels, 2)
+	assert.Equal(t, ChannelIntrospection{
+		Channel: "A",
+		Peers: []PeerIntrospection{
+			{Endpoint: "p1:7051", PKIID: "7031", LedgerHeight: 100, Chaincodes: []string{"mycc:1.0"}},
+		},
+		Leader:          LeaderIntrospection{Mode: LeaderElected, PKIID: "7031", Since: since},
+		DeliverEndpoint: "orderer:7050",
+		PendingPayloads: state.PendingPayloads{Next: 100, Count: 2, Lowest: 102, Highest: 105},
+	}, res.Channels[0])
+	assert.Equal(t, "B", res.Channels[1].Channel)
+	assert.Equal(t, LeaderIntrospection{Mode: LeaderNone}, res.Channels[1].Leader)
+	assert.Empty(t, res.Channels[1].DeliverEndpoint)
+
+	res, err = g.Introspect("B")
+	assert.NoError(t, err)
+	assert.Len(t, res.Channels, 1)
+	assert.Equal(t, "B", res.Channels[0].Channel)
+}
+
+func TestPendingPayloadsJSON(t *testing.T) {
+	js, err := json.Marshal(state.PendingPayloads{Next: 100, Count: 2, Lowest: 102, Highest: 105})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"next": 100, "count": 2, "lowest": 102, "highest": 105}`, string(js))
+}
+
+func TestIntrospectionHandler(t *testing.T) {
+	handler := &IntrospectionHandler{
+		Introspect: func(chainID string) (*Introspection, error) {
+			if chainID != "mychannel" {
+				return nil, errors.New("channel foo does not exist")
+			}
+			return &Introspection{Channels: []ChannelIntrospection{{Channel: chainID}}}, nil
+		},
+	}
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/introspect?channel=mychannel", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	introspection := &Introspection{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), introspection))
+	assert.Equal(t, "mychannel", introspection.Channels[0].Channel)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/introspect?channel=foo", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error": "channel foo does not exist"}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/introspect", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"error": "invalid request method: POST"}`, resp.Body.String())
+}
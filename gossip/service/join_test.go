@@ -64,6 +64,10 @@ func (*gossipMock) Peers() []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) MembershipState() []discovery.MemberState {
+	panic("implement me")
+}
+
 func (*gossipMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
 	panic("implement me")
 }
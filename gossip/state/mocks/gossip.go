@@ -61,6 +61,10 @@ func (g *GossipMock) Peers() []discovery.NetworkMember {
 	return g.Called().Get(0).([]discovery.NetworkMember)
 }
 
+func (g *GossipMock) MembershipState() []discovery.MemberState {
+	return g.Called().Get(0).([]discovery.MemberState)
+}
+
 func (g *GossipMock) PeersOfChannel(chainID common.ChainID) []discovery.NetworkMember {
 	args := g.Called(chainID)
 	return args.Get(0).([]discovery.NetworkMember)
@@ -32,6 +32,10 @@ type PayloadsBuffer interface {
 	// Get current buffer size
 	Size() int
 
+	// Range returns the lowest and highest sequence numbers of the
+	// payloads in the buffer, or zeros if the buffer is empty
+	Range() (uint64, uint64)
+
 	// Channel to indicate event when new payload pushed with sequence
 	// number equal to the next expected value.
 	Ready() chan struct{}
@@ -135,6 +139,25 @@ func (b *PayloadsBufferImpl) drainReadChannel() {
 	}
 }
 
+// Range returns the lowest and highest sequence numbers of the
+// payloads in the buffer, or zeros if the buffer is empty
+func (b *PayloadsBufferImpl) Range() (uint64, uint64) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	var lowest, highest uint64
+	first := true
+	for seqNum := range b.buf {
+		if first || seqNum < lowest {
+			first = false
+			lowest = seqNum
+		}
+		if seqNum > highest {
+			highest = seqNum
+		}
+	}
+	return lowest, highest
+}
+
 // Size returns current number of payloads stored within buffer
 func (b *PayloadsBufferImpl) Size() int {
 	b.mutex.RLock()
@@ -73,6 +73,22 @@ func TestPayloadsBufferImpl_Push(t *testing.T) {
 	assert.Equal(t, buffer.Size(), 1)
 }
 
+func TestPayloadsBufferImpl_Range(t *testing.T) {
+	buffer := NewPayloadsBuffer(5)
+	lowest, highest := buffer.Range()
+	assert.Equal(t, uint64(0), lowest)
+	assert.Equal(t, uint64(0), highest)
+
+	for _, seqNum := range []uint64{9, 6, 12} {
+		payload, err := randomPayloadWithSeqNum(seqNum)
+		assert.NoError(t, err)
+		buffer.Push(payload)
+	}
+	lowest, highest = buffer.Range()
+	assert.Equal(t, uint64(6), lowest)
+	assert.Equal(t, uint64(12), highest)
+}
+
 func TestPayloadsBufferImpl_Ready(t *testing.T) {
 	fin := make(chan struct{})
 	buffer := NewPayloadsBuffer(1)
@@ -27,12 +27,27 @@ import (
 	"github.com/pkg/errors"
 )
 
+// PendingPayloads describes the payloads that were received but not committed yet
+type PendingPayloads struct {
+	// Next is the sequence number of the next block to commit
+	Next uint64 `json:"next"`
+	// Count is the number of payloads in the buffer
+	Count int `json:"count"`
+	// Lowest and Highest are the lowest and highest sequence
+	// numbers of the payloads in the buffer
+	Lowest  uint64 `json:"lowest"`
+	Highest uint64 `json:"highest"`
+}
+
 // GossipStateProvider is the interface to acquire sequences of the ledger blocks
 // capable to full fill missing blocks by running state replication and
 // sending request to get missing block to other nodes
 type GossipStateProvider interface {
 	AddPayload(payload *proto.Payload) error
 
+	// PendingPayloads returns the payloads that were received but not committed yet
+	PendingPayloads() PendingPayloads
+
 	// Stop terminates state transfer object
 	Stop()
 }
@@ -528,6 +543,17 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage)
 	return max, nil
 }
 
+// PendingPayloads returns the payloads that were received but not committed yet
+func (s *GossipStateProviderImpl) PendingPayloads() PendingPayloads {
+	lowest, highest := s.payloads.Range()
+	return PendingPayloads{
+		Next:    s.payloads.Next(),
+		Count:   s.payloads.Size(),
+		Lowest:  lowest,
+		Highest: highest,
+	}
+}
+
 // Stop function sends halting signal to all go routines
 func (s *GossipStateProviderImpl) Stop() {
 	// Make sure stop won't be executed twice
@@ -269,6 +269,10 @@ func (*mockCommitter) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtDa
 	panic("implement me")
 }
 
+func (*mockCommitter) RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	panic("implement me")
+}
+
 func (*mockCommitter) Close() {
 }
 
@@ -285,6 +289,10 @@ func (mock *ramLedger) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtD
 	panic("implement me")
 }
 
+func (mock *ramLedger) RepairPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	panic("implement me")
+}
+
 func (mock *ramLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
 	panic("implement me")
 }
@@ -117,6 +117,7 @@ peer:
       vscc:
         name: DefaultValidation
   validatorPoolSize:
+  validatorPipelining: false
   discovery:
     enabled: true
     authCacheEnabled: true
@@ -165,6 +166,7 @@ chaincode:
     cscc: enable
     lscc: enable
     qscc: enable
+    xscc: enable
   systemPlugins:
   logging:
     level:  info
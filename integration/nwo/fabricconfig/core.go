@@ -49,6 +49,7 @@ type Peer struct {
 	AdminService           *Service        `yaml:"adminService,omitempty"`
 	Handlers               *Handlers       `yaml:"handlers,omitempty"`
 	ValidatorPoolSize      int             `yaml:"validatorPoolSize,omitempty"`
+	ValidatorPipelining    bool            `yaml:"validatorPipelining,omitempty"`
 	Discovery              *Discovery      `yaml:"discovery,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
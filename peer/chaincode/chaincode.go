@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,6 +38,7 @@ var platformRegistry = platforms.NewRegistry(
 	&car.Platform{},
 	&java.Platform{},
 	&node.Platform{},
+	&wasm.Platform{},
 )
 
 func addFlags(cmd *cobra.Command) {
@@ -82,6 +84,7 @@ var (
 	peerAddresses         []string
 	tlsRootCertFiles      []string
 	connectionProfile     string
+	externalService       string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
 )
@@ -138,6 +141,8 @@ func resetFlags() {
 		fmt.Sprint("If TLS is enabled, the paths to the TLS root cert files of the peers to connect to. The order and number of certs specified should match the --peerAddresses flag"))
 	flags.StringVarP(&connectionProfile, "connectionProfile", "", common.UndefinedParamValue,
 		fmt.Sprint("Connection profile that provides the necessary connection information for the network. Note: currently only supported for providing peer connection information"))
+	flags.StringVarP(&externalService, "externalService", "", common.UndefinedParamValue,
+		fmt.Sprint("Path to the connection.json file of a chaincode running as an external service, which is packaged in place of the chaincode source"))
 	flags.BoolVar(&waitForEvent, "waitForEvent", false,
 		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -23,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/externalcontroller"
 	"github.com/hyperledger/fabric/msp"
 	ccapi "github.com/hyperledger/fabric/peer/chaincode/api"
 	"github.com/hyperledger/fabric/peer/common"
@@ -48,6 +52,10 @@ func checkSpec(spec *pb.ChaincodeSpec) error {
 
 // getChaincodeDeploymentSpec get chaincode deployment spec given the chaincode spec
 func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.ChaincodeDeploymentSpec, error) {
+	if crtPkg && externalService != common.UndefinedParamValue {
+		return getExternalChaincodeDeploymentSpec(spec, externalService)
+	}
+
 	var codePackageBytes []byte
 	if chaincode.IsDevMode() == false && crtPkg {
 		var err error
@@ -65,6 +73,47 @@ func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.Chainc
 	return chaincodeDeploymentSpec, nil
 }
 
+// getExternalChaincodeDeploymentSpec gets the deployment spec of a chaincode running as an
+// external service, whose code package only holds the given connection file
+func getExternalChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, connectionFile string) (*pb.ChaincodeDeploymentSpec, error) {
+	connInfoBytes, err := ioutil.ReadFile(connectionFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the connection file")
+	}
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	header := &tar.Header{
+		Name: externalcontroller.ConnectionFile,
+		Size: int64(len(connInfoBytes)),
+		Mode: 0100644,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, errors.Wrap(err, "error writing the connection file to the code package")
+	}
+	if _, err := tw.Write(connInfoBytes); err != nil {
+		return nil, errors.Wrap(err, "error writing the connection file to the code package")
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing the code package")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing the code package")
+	}
+
+	// fail early rather than on the first invocation of the chaincode
+	if _, err := externalcontroller.ConnectionInfoFromCodePackage(buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: spec,
+		CodePackage:   buf.Bytes(),
+		ExecEnv:       pb.ChaincodeDeploymentSpec_EXTERNAL,
+	}, nil
+}
+
 // getChaincodeSpec get chaincode spec from the cli cmd pramameters
 func getChaincodeSpec(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	spec := &pb.ChaincodeSpec{}
@@ -50,6 +50,7 @@ func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"path",
 		"name",
 		"version",
+		"externalService",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -170,7 +171,7 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 
 	var ccpackmsg proto.Message
 	if ccpackfile == "" {
-		if chaincodePath == common.UndefinedParamValue || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
+		if (chaincodePath == common.UndefinedParamValue && externalService == common.UndefinedParamValue) || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
 			return fmt.Errorf("Must supply value for %s name, path and version parameters.", chainFuncName)
 		}
 		//generate a raw ChaincodeDeploymentSpec
@@ -10,12 +10,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/core/container/externalcontroller"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func initInstallTest(fsPath string, t *testing.T) (*cobra.Command, *ChaincodeCmdFactory) {
@@ -172,3 +175,54 @@ func TestInstall(t *testing.T) {
 		t.Fatalf("Install failed with error: %v", err)
 	}
 }
+
+func TestInstallExternalService(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	connectionFile := filepath.Join(pdir, "connection.json")
+	err := ioutil.WriteFile(connectionFile, []byte(`{"address": "mycc.example.com:9999"}`), 0644)
+	assert.NoError(t, err)
+
+	fsPath := "/tmp/installtest"
+	cmd, mockCF := initInstallTest(fsPath, t)
+	defer cleanupInstallTest(fsPath)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
+	mockCF.EndorserClients = []pb.EndorserClient{mockEndorserClient}
+
+	// the path of the chaincode is not needed
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--externalService", connectionFile})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestGetExternalChaincodeDeploymentSpec(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	spec := &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}}
+	connectionFile := filepath.Join(pdir, "connection.json")
+	err := ioutil.WriteFile(connectionFile, []byte(`{"address": "mycc.example.com:9999"}`), 0644)
+	assert.NoError(t, err)
+
+	cds, err := getExternalChaincodeDeploymentSpec(spec, connectionFile)
+	assert.NoError(t, err)
+	assert.Equal(t, spec, cds.ChaincodeSpec)
+	assert.Equal(t, pb.ChaincodeDeploymentSpec_EXTERNAL, cds.ExecEnv)
+	connInfo, err := externalcontroller.ConnectionInfoFromCodePackage(cds.CodePackage)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc.example.com:9999", connInfo.Address)
+
+	_, err = getExternalChaincodeDeploymentSpec(spec, filepath.Join(pdir, "missing.json"))
+	assert.Contains(t, err.Error(), "error reading the connection file")
+
+	err = ioutil.WriteFile(connectionFile, []byte(`{}`), 0644)
+	assert.NoError(t, err)
+	_, err = getExternalChaincodeDeploymentSpec(spec, connectionFile)
+	assert.EqualError(t, err, "invalid connection.json: chaincode address is not specified")
+}
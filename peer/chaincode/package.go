@@ -69,6 +69,7 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 		"path",
 		"name",
 		"version",
+		"externalService",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
@@ -104,6 +104,10 @@ func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *cb.Envelope,
 	return &empty.Empty{}, m.err
 }
 
+func (m *mockAdminClient) UnjoinChannel(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
+
 func (m *mockAdminClient) GetLogSpec(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.LogSpecResponse, error) {
 	response := &pb.LogSpecResponse{LogSpec: "info"}
 	return response, m.err
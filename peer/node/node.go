@@ -26,7 +26,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|status|reset|rollback."
+	nodeCmdDes   = "Operate a peer node: start|status|reset|rollback|verify|rebuild-dbs."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -37,6 +37,9 @@ func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(statusCmd())
 	nodeCmd.AddCommand(resetCmd())
 	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(verifyCmd())
+	nodeCmd.AddCommand(rebuildDBsCmd())
+	nodeCmd.AddCommand(unjoinCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	channelIDs []string
+	dbs        []string
+)
+
+func rebuildDBsCmd() *cobra.Command {
+	nodeRebuildDBsCmd.ResetFlags()
+	flags := nodeRebuildDBsCmd.Flags()
+	flags.StringSliceVarP(&channelIDs, "channelID", "c", nil, "Channels whose databases are rebuilt, all the channels if not specified.")
+	flags.StringSliceVarP(&dbs, "dbs", "d", nil, "Databases to rebuild, among statedb, historydb and blockindex, all the enabled ones if not specified. "+
+		"The statedb includes the config history and the bookkeeping databases.")
+
+	return nodeRebuildDBsCmd
+}
+
+var nodeRebuildDBsCmd = &cobra.Command{
+	Use:   "rebuild-dbs",
+	Short: "Rebuilds the databases of channels.",
+	Long:  `Drops the selected databases of the selected channels and rebuilds them by replaying the blocks in the block store, without fetching any block from an orderer or another peer. When the command is executed, the peer must be offline. The progress of the rebuild is logged. If the command is interrupted, executing it again resumes the rebuild; if it is interrupted while dropping the databases, the affected channels cannot be opened until it is executed again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ledgerIDs, err := kvledger.DropDBsForRebuild(channelIDs, dbs)
+		if err != nil {
+			return err
+		}
+		return rebuildLedgers(ledgerIDs)
+	},
+}
+
+// rebuildLedgers opens the given ledgers, which rebuilds their dropped databases
+func rebuildLedgers(ledgerIDs []string) error {
+	initializeLedgerMgmt()
+	defer ledgermgmt.Close()
+
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Rebuilding the databases of channel [%s]", ledgerID)
+		lgr, err := ledgermgmt.OpenLedger(ledgerID)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error rebuilding the databases of channel [%s]", ledgerID))
+		}
+		bcInfo, err := lgr.GetBlockchainInfo()
+		lgr.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("The databases of channel [%s] have been rebuilt up to block [%d]\n", ledgerID, bcInfo.Height-1)
+	}
+	return nil
+}
+
+// initializeLedgerMgmt initializes the ledger management of the offline peer node commands
+// the same way as a starting peer does, without metrics
+func initializeLedgerMgmt() {
+	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
+		return mgmt.GetManagerForChain(chainID)
+	}
+	mspID := viper.GetString("peer.localMspId")
+	ledgermgmt.Initialize(
+		&ledgermgmt.Initializer{
+			CustomTxProcessors: peer.ConfigTxProcessors,
+			PlatformRegistry: platforms.NewRegistry(
+				&golang.Platform{},
+				&node.Platform{},
+				&java.Platform{},
+				&car.Platform{},
+				&wasm.Platform{},
+			),
+			DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+			MembershipInfoProvider:        privdata.NewMembershipInfoProvider(mspID, createSelfSignedData(), identityDeserializerFactory),
+			MetricsProvider:               &disabled.Provider{},
+			HealthCheckRegistry:           healthz.NewHealthHandler(),
+		},
+	)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildDBsCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rebuilddbs")
+	assert.NoError(t, err)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when a specified database cannot be rebuilt", func(t *testing.T) {
+		cmd := rebuildDBsCmd()
+		args := []string{"-c", "ch1", "--dbs", "statedb,pvtdatastore"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		expectedErr := "database [pvtdatastore] cannot be rebuilt, the databases that can be rebuilt are [statedb historydb blockindex]"
+		assert.Equal(t, expectedErr, err.Error())
+	})
+
+	t.Run("when a specified channelID does not exist", func(t *testing.T) {
+		cmd := rebuildDBsCmd()
+		args := []string{"-c", "ch1,ch2", "--dbs", "statedb"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		expectedErr := "ledgerID [ch1] does not exist"
+		assert.Equal(t, expectedErr, err.Error())
+	})
+}
@@ -7,12 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRollbackCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rollback")
+	assert.NoError(t, err)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
 	t.Run("when the channelID is not supplied", func(t *testing.T) {
 		cmd := rollbackCmd()
 		args := []string{}
@@ -45,13 +45,18 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/externalcontroller"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller/interpreter"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
@@ -68,6 +73,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/core/scc/xscc"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
@@ -76,6 +82,7 @@ import (
 	"github.com/hyperledger/fabric/discovery/support/config"
 	"github.com/hyperledger/fabric/discovery/support/gossip"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -159,6 +166,7 @@ func serve(args []string) error {
 		&node.Platform{},
 		&java.Platform{},
 		&car.Platform{},
+		&wasm.Platform{},
 	)
 
 	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
@@ -275,9 +283,6 @@ func serve(args []string) error {
 
 	logger.Debugf("Running peer")
 
-	// Start the Admin server
-	startAdminServer(listenAddr, peerServer.Server(), serverConfig)
-
 	privDataDist := func(channel string, txID string, privateData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error {
 		return service.GetGossipService().DistributePrivateData(channel, txID, privateData, blkHt)
 	}
@@ -336,6 +341,21 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	opsSystem.RegisterHandler("/privdata/reconcile", &gossipprivdata.ReconcileHandler{
+		Reconcilers: service.GetGossipService().PvtDataReconciler,
+	})
+	opsSystem.RegisterHandler("/gossip/introspect", &service.IntrospectionHandler{
+		Introspect: service.GetGossipService().Introspect,
+	})
+	opsSystem.RegisterHandler("/ledger/fingerprint", &peer.StateFingerprintHandler{
+		Ledgers: func(channel string) peer.StateFingerprintSource {
+			if l := peer.GetLedger(channel); l != nil {
+				return l
+			}
+			return nil
+		},
+	})
+
 	// register prover grpc service
 	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
 	// err = registerProverService(peerServer, aclProvider, signingIdentity)
@@ -372,6 +392,10 @@ func serve(args []string) error {
 			logger.Panicf("Failed subscribing to chaincode lifecycle updates")
 		}
 		cceventmgmt.GetMgr().Register(cid, sub)
+		// chaincodes run by the user in dev mode can't be launched or stopped
+		if !chaincodeSupport.UserRunsCC {
+			cceventmgmt.GetMgr().Register(cid, chaincodeSupport.Upgrader.ChannelListener(cid))
+		}
 	}, ccp, sccp, txvalidator.MapBasedPluginMapper(validationPluginsByName),
 		pr, deployedCCInfoProvider, membershipInfoProvider, metricsProvider)
 
@@ -379,6 +403,9 @@ func serve(args []string) error {
 		registerDiscoveryService(peerServer, policyMgr, lifecycle)
 	}
 
+	// Start the Admin server
+	startAdminServer(listenAddr, peerServer.Server(), serverConfig, lifecycle)
+
 	networkID := viper.GetString("peer.networkId")
 
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]", peerEndpoint.Id, networkID, peerEndpoint.Address)
@@ -427,9 +454,12 @@ func serve(args []string) error {
 	}
 
 	// start the peer server
-	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
+	auth, authEvaluator := authHandler.ChainFiltersWithEvaluator(serverEndorser, serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
+	// Register the Evaluator server, which serves read-only proposals without
+	// endorsing them
+	pb.RegisterEvaluatorServer(peerServer.Server(), authEvaluator)
 
 	go func() {
 		var grpcErr error
@@ -684,12 +714,27 @@ func registerChaincodeSupport(
 		viper.GetString("peer.networkId"),
 		ops.Provider,
 	)
+	if err := viperutil.EnhancedExactUnmarshalKey("chaincode.resourceLimits", &dockerProvider.ResourceLimits); err != nil {
+		logger.Panicf("failed to load the chaincode resource limits configuration: %s", err)
+	}
 	dockerVM := dockercontroller.NewDockerVM(
 		dockerProvider.PeerID,
 		dockerProvider.NetworkID,
 		dockerProvider.BuildMetrics,
 	)
 
+	var externalBuilders []externalbuilder.Config
+	if err := viperutil.EnhancedExactUnmarshalKey("chaincode.externalBuilders", &externalBuilders); err != nil {
+		logger.Panicf("failed to load the external builders configuration: %s", err)
+	}
+	builderProvider := externalbuilder.NewProvider(externalbuilder.NewBuilders(externalBuilders), dockerProvider)
+	externalProvider := externalcontroller.NewProvider()
+	wasmProvider := wasmcontroller.NewProvider(interpreter.Config{
+		Fuel:           uint64(viper.GetInt("chaincode.wasm.fuel")),
+		MaxMemoryPages: uint32(viper.GetInt("chaincode.wasm.maxMemoryPages")),
+	})
+	wasmProvider.ResourceLimits = dockerProvider.ResourceLimits
+
 	err := ops.RegisterChecker("docker", dockerVM)
 	if err != nil {
 		logger.Panicf("failed to register docker health check: %s", err)
@@ -706,8 +751,10 @@ func registerChaincodeSupport(
 		aclProvider,
 		container.NewVMController(
 			map[string]container.VMProvider{
-				dockercontroller.ContainerType: dockerProvider,
-				inproccontroller.ContainerType: ipRegistry,
+				dockercontroller.ContainerType:   builderProvider,
+				inproccontroller.ContainerType:   ipRegistry,
+				externalcontroller.ContainerType: externalProvider,
+				wasmcontroller.ContainerType:     wasmProvider,
 			},
 		),
 		sccp,
@@ -716,6 +763,8 @@ func registerChaincodeSupport(
 		ops.Provider,
 	)
 	ipRegistry.ChaincodeSupport = chaincodeSupport
+	externalProvider.ChaincodeSupport = chaincodeSupport
+	wasmProvider.ChaincodeSupport = chaincodeSupport
 	ccp := chaincode.NewProvider(chaincodeSupport)
 
 	ccSrv := pb.ChaincodeSupportServer(chaincodeSupport)
@@ -725,10 +774,11 @@ func registerChaincodeSupport(
 
 	csccInst := cscc.New(ccp, sccp, aclProvider)
 	qsccInst := qscc.New(aclProvider)
+	xsccInst := xscc.New(peer.Default, peer.DefaultSupport)
 
 	//Now that chaincode is initialized, register all system chaincodes.
 	sccs := scc.CreatePluginSysCCs(sccp)
-	for _, cc := range append([]scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, lifecycleSCC}, sccs...) {
+	for _, cc := range append([]scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, xsccInst, lifecycleSCC}, sccs...) {
 		sccp.RegisterSysCC(cc)
 	}
 	pb.RegisterChaincodeSupportServer(grpcServer.Server(), ccSrv)
@@ -811,7 +861,7 @@ func adminHasSeparateListener(peerListenAddr string, adminListenAddress string)
 	return adminPort != peerPort
 }
 
-func startAdminServer(peerListenAddr string, peerServer *grpc.Server, baseServerConfig comm.ServerConfig) {
+func startAdminServer(peerListenAddr string, peerServer *grpc.Server, baseServerConfig comm.ServerConfig, lifecycle *cc.Lifecycle) {
 	adminListenAddress := viper.GetString("peer.adminService.listenAddress")
 	separateLsnrForAdmin := adminHasSeparateListener(peerListenAddr, adminListenAddress)
 	mspID := viper.GetString("peer.localMspId")
@@ -838,7 +888,14 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, baseServer
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	unjoinChannel := func(channelID string) error {
+		err := peer.UnjoinChannel(channelID)
+		// the chaincode metadata of the channel is removed once its lifecycle listeners are deregistered,
+		// which happens even if the removal of its ledger fails
+		lifecycle.RemoveChannel(channelID)
+		return err
+	}
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, admin.UnjoinChannelFunc(unjoinChannel)))
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
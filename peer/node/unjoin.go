@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func unjoinCmd() *cobra.Command {
+	nodeUnjoinCmd.ResetFlags()
+	flags := nodeUnjoinCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to unjoin.")
+
+	return nodeUnjoinCmd
+}
+
+var nodeUnjoinCmd = &cobra.Command{
+	Use:   "unjoin",
+	Short: "Unjoins the peer from a channel.",
+	Long:  `Unjoins the peer from a channel by removing the block store, the state database, the history database, the private data and the transient store of the channel. When the command is executed, the peer must be offline. If the command is interrupted, the removal of the channel is completed when the peer starts. A running peer can be unjoined from a channel through the UnjoinChannel operation of its admin service.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		initializeLedgerMgmt()
+		defer ledgermgmt.Close()
+		return unjoinChannel(channelID)
+	},
+}
+
+// unjoinChannel removes the ledger and the transient store of the given channel
+func unjoinChannel(channelID string) error {
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	if err != nil {
+		return err
+	}
+	if !contains(ledgerIDs, channelID) {
+		return errors.Errorf("channel [%s] does not exist", channelID)
+	}
+
+	transientStoreProvider := transientstore.NewStoreProvider()
+	defer transientStoreProvider.Close()
+	if err := transientStoreProvider.DropLedger(channelID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping the transient store of channel [%s]", channelID))
+	}
+	if err := ledgermgmt.RemoveLedger(channelID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error removing the ledger of channel [%s]", channelID))
+	}
+	fmt.Printf("The peer has been unjoined from channel [%s]\n", channelID)
+	return nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnjoinCmd(t *testing.T) {
+	cmd := unjoinCmd()
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "Must supply channel ID")
+}
+
+func TestUnjoinChannel(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "unjoin")
+	require.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+	for _, ledgerID := range []string{"ch1", "ch2"} {
+		gb, err := test.MakeGenesisBlock(ledgerID)
+		require.NoError(t, err)
+		lgr, err := ledgermgmt.CreateLedger(gb)
+		require.NoError(t, err)
+		lgr.Close()
+	}
+	transientStoreProvider := transientstore.NewStoreProvider()
+	_, err = transientStoreProvider.OpenStore("ch1")
+	require.NoError(t, err)
+	transientStoreProvider.Close()
+
+	assert.EqualError(t, unjoinChannel("ch3"), "channel [ch3] does not exist")
+
+	assert.NoError(t, unjoinChannel("ch1"))
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ch2"}, ledgerIDs)
+	_, err = ledgermgmt.OpenLedger("ch1")
+	assert.Error(t, err)
+
+	assert.EqualError(t, unjoinChannel("ch1"), "channel [ch1] does not exist")
+}
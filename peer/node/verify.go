@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/peer/common"
+	peergossip "github.com/hyperledger/fabric/peer/gossip"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var rebuildIndex bool
+
+func verifyCmd() *cobra.Command {
+	nodeVerifyCmd.ResetFlags()
+	flags := nodeVerifyCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to verify.")
+	flags.BoolVarP(&rebuildIndex, "rebuildIndex", "r", false, "Rebuild the block index from the block files if it is found to be inconsistent.")
+
+	return nodeVerifyCmd
+}
+
+var nodeVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies the ledger of a channel.",
+	Long:  `Verifies the integrity of the ledger of a channel: the hash chain, data hashes and orderer signatures of the blocks, the block index, the savepoints of the state and history databases and the hashes of the private data. When the command is executed, the peer must be offline. The block index can optionally be rebuilt from the block files if it is found to be inconsistent.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		verifier := newBlockSignatureVerifier(channelID)
+		report, err := kvledger.VerifyKVLedger(channelID, rebuildIndex, verifier.verify)
+		if err != nil {
+			return err
+		}
+		reportBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "error marshaling the verification report")
+		}
+		fmt.Println(string(reportBytes))
+		if !report.OK() {
+			return errors.Errorf("the ledger of channel [%s] is corrupted", channelID)
+		}
+		return nil
+	},
+}
+
+// blockSignatureVerifier verifies the signatures of the blocks of a channel, which are expected
+// to be supplied in order, against the block validation policy of the latest channel config
+type blockSignatureVerifier struct {
+	channelID string
+	bundle    *channelconfig.Bundle
+	mcs       *peergossip.MSPMessageCryptoService
+}
+
+func newBlockSignatureVerifier(channelID string) *blockSignatureVerifier {
+	v := &blockSignatureVerifier{channelID: channelID}
+	v.mcs = peergossip.NewMCS(v, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	return v
+}
+
+// Manager implements policies.ChannelPolicyManagerGetter
+func (v *blockSignatureVerifier) Manager(channelID string) (policies.Manager, bool) {
+	if v.bundle == nil || channelID != v.channelID {
+		return nil, false
+	}
+	return v.bundle.PolicyManager(), true
+}
+
+func (v *blockSignatureVerifier) verify(block *cb.Block) error {
+	// the genesis block is not signed by the orderers
+	if block.Header.Number > 0 {
+		if v.bundle == nil {
+			return errors.New("no channel config found in the previous blocks")
+		}
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return errors.Wrap(err, "error marshaling block")
+		}
+		if err := v.mcs.VerifyBlock([]byte(v.channelID), block.Header.Number, blockBytes); err != nil {
+			return err
+		}
+	}
+
+	if !utils.IsConfigBlock(block) {
+		return nil
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return errors.WithMessage(err, "error extracting the config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return errors.WithMessage(err, "error parsing the channel config")
+	}
+	v.bundle = bundle
+	return nil
+}
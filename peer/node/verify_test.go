@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "verify")
+	assert.NoError(t, err)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := verifyCmd()
+		args := []string{}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := verifyCmd()
+		args := []string{"-c", "ch1"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		expectedErr := "ledgerID [ch1] does not exist"
+		assert.Equal(t, expectedErr, err.Error())
+	})
+}
+
+func TestBlockSignatureVerifier(t *testing.T) {
+	genesisBlock, err := configtxtest.MakeGenesisBlock("testchain")
+	assert.NoError(t, err)
+	block := cb.NewBlock(1, genesisBlock.Header.Hash())
+	block.Data = genesisBlock.Data
+	block.Header.DataHash = block.Data.Hash()
+
+	verifier := newBlockSignatureVerifier("testchain")
+	assert.EqualError(t, verifier.verify(block), "no channel config found in the previous blocks")
+
+	assert.NoError(t, verifier.verify(genesisBlock))
+	assert.NotNil(t, verifier.bundle)
+	_, ok := verifier.Manager("testchain")
+	assert.True(t, ok)
+	_, ok = verifier.Manager("otherchain")
+	assert.False(t, ok)
+
+	// the block carries no orderer signature
+	assert.Error(t, verifier.verify(block))
+}
@@ -33,7 +33,7 @@ func (m *BlockchainInfo) Reset()         { *m = BlockchainInfo{} }
 func (m *BlockchainInfo) String() string { return proto.CompactTextString(m) }
 func (*BlockchainInfo) ProtoMessage()    {}
 func (*BlockchainInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ledger_109361caaf4d9d5c, []int{0}
+	return fileDescriptor_ledger_d647d891f2ef425d, []int{0}
 }
 func (m *BlockchainInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockchainInfo.Unmarshal(m, b)
@@ -74,24 +74,89 @@ func (m *BlockchainInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+// Contains the fingerprint of the world state of a channel right after the
+// block of the given number was committed. The fingerprint of the public state
+// and the one of the hashes of the private data are order-independent digests
+// of all the key-value pairs, so that peers have the same state at a height if
+// and only if they have the same fingerprints at that height.
+type StateFingerprint struct {
+	BlockNumber          uint64   `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	PublicState          []byte   `protobuf:"bytes,2,opt,name=public_state,json=publicState,proto3" json:"public_state,omitempty"`
+	PrivateDataHashes    []byte   `protobuf:"bytes,3,opt,name=private_data_hashes,json=privateDataHashes,proto3" json:"private_data_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateFingerprint) Reset()         { *m = StateFingerprint{} }
+func (m *StateFingerprint) String() string { return proto.CompactTextString(m) }
+func (*StateFingerprint) ProtoMessage()    {}
+func (*StateFingerprint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ledger_d647d891f2ef425d, []int{1}
+}
+func (m *StateFingerprint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateFingerprint.Unmarshal(m, b)
+}
+func (m *StateFingerprint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateFingerprint.Marshal(b, m, deterministic)
+}
+func (dst *StateFingerprint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateFingerprint.Merge(dst, src)
+}
+func (m *StateFingerprint) XXX_Size() int {
+	return xxx_messageInfo_StateFingerprint.Size(m)
+}
+func (m *StateFingerprint) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateFingerprint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateFingerprint proto.InternalMessageInfo
+
+func (m *StateFingerprint) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *StateFingerprint) GetPublicState() []byte {
+	if m != nil {
+		return m.PublicState
+	}
+	return nil
+}
+
+func (m *StateFingerprint) GetPrivateDataHashes() []byte {
+	if m != nil {
+		return m.PrivateDataHashes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainInfo)(nil), "common.BlockchainInfo")
+	proto.RegisterType((*StateFingerprint)(nil), "common.StateFingerprint")
 }
 
-func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_109361caaf4d9d5c) }
-
-var fileDescriptor_ledger_109361caaf4d9d5c = []byte{
-	// 186 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4e, 0xce, 0xcf, 0xcd,
-	0xcd, 0xcf, 0xd3, 0xcf, 0x49, 0x4d, 0x49, 0x4f, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x62, 0x83, 0x08, 0x2a, 0x35, 0x31, 0x72, 0xf1, 0x39, 0xe5, 0xe4, 0x27, 0x67, 0x27, 0x67, 0x24,
-	0x66, 0xe6, 0x79, 0xe6, 0xa5, 0xe5, 0x0b, 0x89, 0x71, 0xb1, 0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94,
-	0x48, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x04, 0x41, 0x79, 0x42, 0x5a, 0x5c, 0x02, 0xc9, 0xa5, 0x45,
-	0x45, 0xa9, 0x79, 0x25, 0x60, 0x0d, 0x1e, 0x89, 0xc5, 0x19, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c,
-	0x41, 0x18, 0xe2, 0x42, 0x3a, 0x5c, 0x82, 0x05, 0x45, 0xa9, 0x65, 0x99, 0xf9, 0xa5, 0xc5, 0x08,
-	0xc5, 0xcc, 0x60, 0xc5, 0x98, 0x12, 0x4e, 0xc1, 0x5c, 0x2a, 0xf9, 0x45, 0xe9, 0x7a, 0x19, 0x95,
-	0x05, 0xa9, 0x45, 0x50, 0x57, 0xa6, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0x1c, 0x5b, 0xac, 0x07,
-	0x71, 0x6c, 0x94, 0x76, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0x88, 0xab, 0x8f, 0xa4, 0x58, 0x1f,
-	0xa2, 0x58, 0x1f, 0xa2, 0x58, 0x1f, 0xa2, 0x38, 0x89, 0x0d, 0xcc, 0x35, 0x06, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x9f, 0xcc, 0x05, 0xd1, 0xff, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_d647d891f2ef425d) }
+
+var fileDescriptor_ledger_d647d891f2ef425d = []byte{
+	// 264 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x41, 0x4b, 0x3b, 0x31,
+	0x10, 0xc5, 0xd9, 0xff, 0x5f, 0xf6, 0x90, 0x16, 0xa9, 0x29, 0x48, 0x8f, 0xb5, 0x78, 0x28, 0x2a,
+	0xbb, 0x07, 0xbf, 0x41, 0x11, 0xa9, 0x17, 0x0f, 0xed, 0xcd, 0xcb, 0x92, 0xa4, 0xd3, 0x4d, 0x70,
+	0x37, 0x09, 0x93, 0x49, 0xc1, 0xab, 0x27, 0x3f, 0xb6, 0x6c, 0xb2, 0x50, 0xa1, 0xc7, 0xf7, 0xde,
+	0x6f, 0x86, 0xc7, 0x63, 0x73, 0xe5, 0xfa, 0xde, 0xd9, 0xba, 0x83, 0x43, 0x0b, 0x58, 0x79, 0x74,
+	0xe4, 0x78, 0x99, 0xcd, 0xd5, 0x77, 0xc1, 0xae, 0x37, 0x9d, 0x53, 0x9f, 0x4a, 0x0b, 0x63, 0xdf,
+	0xec, 0xd1, 0xf1, 0x5b, 0x56, 0x6a, 0x30, 0xad, 0xa6, 0x45, 0xb1, 0x2c, 0xd6, 0x57, 0xbb, 0x51,
+	0xf1, 0x07, 0x36, 0x53, 0x11, 0x11, 0x2c, 0xa5, 0x83, 0xad, 0x08, 0x7a, 0xf1, 0x6f, 0x59, 0xac,
+	0xa7, 0xbb, 0x0b, 0x9f, 0x3f, 0xb1, 0x1b, 0x8f, 0x70, 0x32, 0x2e, 0x86, 0x33, 0xfc, 0x3f, 0xc1,
+	0x97, 0xc1, 0xea, 0xa7, 0x60, 0xb3, 0x3d, 0x09, 0x82, 0x57, 0x63, 0x5b, 0x40, 0x8f, 0xc6, 0x12,
+	0xbf, 0x63, 0x53, 0x39, 0x10, 0x8d, 0x8d, 0xbd, 0x04, 0x1c, 0xcb, 0x4c, 0x92, 0xf7, 0x9e, 0xac,
+	0x01, 0xf1, 0x51, 0x76, 0x46, 0x35, 0x61, 0xb8, 0x1e, 0xdb, 0x4c, 0xb2, 0x97, 0x1e, 0xf2, 0x8a,
+	0xcd, 0x3d, 0x9a, 0x93, 0x20, 0x68, 0x0e, 0x82, 0x44, 0xa3, 0x45, 0xd0, 0x10, 0xce, 0x55, 0x52,
+	0xf4, 0x22, 0x48, 0x6c, 0x53, 0xb0, 0xd9, 0xb3, 0x7b, 0x87, 0x6d, 0xa5, 0xbf, 0x3c, 0xe0, 0x38,
+	0xd8, 0x51, 0x48, 0x34, 0x2a, 0xef, 0x16, 0xaa, 0xbc, 0xdb, 0xc7, 0x63, 0x6b, 0x48, 0x47, 0x39,
+	0xc8, 0xfa, 0x0f, 0x5c, 0x67, 0xb8, 0xce, 0x70, 0x9d, 0x61, 0x59, 0x26, 0xf9, 0xfc, 0x3b, 0x00,
+	0x41, 0xa6, 0xd7, 0x38, 0x8a, 0x01, 0x00, 0x00,
 }
@@ -205,7 +205,22 @@ func (m *GossipMessage) IsDataReq() bool {
 
 // IsPrivateDataMsg returns whether this message is related to private data
 func (m *GossipMessage) IsPrivateDataMsg() bool {
-	return m.GetPrivateReq() != nil || m.GetPrivateRes() != nil || m.GetPrivateData() != nil
+	return m.GetPrivateReq() != nil || m.GetPrivateRes() != nil || m.GetPrivateData() != nil || m.IsPvtDataHashesMsg()
+}
+
+// IsPvtDataHashesMsg returns whether this message is used by the private data anti-entropy
+func (m *GossipMessage) IsPvtDataHashesMsg() bool {
+	return m.GetPvtHashesReq() != nil || m.GetPvtHashesRes() != nil
+}
+
+// IsSessionKeyMsg returns whether this GossipMessage carries a session key
+func (m *GossipMessage) IsSessionKeyMsg() bool {
+	return m.GetSessionKey() != nil
+}
+
+// IsBlockFragmentMsg returns whether this GossipMessage is a fragment of a block
+func (m *GossipMessage) IsBlockFragmentMsg() bool {
+	return m.GetBlockFragment() != nil
 }
 
 // IsAck returns whether this GossipMessage is an acknowledgement
@@ -245,7 +260,7 @@ func (m *GossipMessage) IsTagLegal() error {
 	if m.Tag == GossipMessage_UNDEFINED {
 		return fmt.Errorf("Undefined tag")
 	}
-	if m.IsDataMsg() {
+	if m.IsDataMsg() || m.IsBlockFragmentMsg() {
 		if m.Tag != GossipMessage_CHAN_AND_ORG {
 			return fmt.Errorf("Tag should be %s", GossipMessage_Tag_name[int32(GossipMessage_CHAN_AND_ORG)])
 		}
@@ -259,7 +274,7 @@ func (m *GossipMessage) IsTagLegal() error {
 		return nil
 	}
 
-	if m.IsIdentityMsg() {
+	if m.IsIdentityMsg() || m.IsSessionKeyMsg() {
 		if m.Tag != GossipMessage_ORG_ONLY {
 			return fmt.Errorf("Tag should be %s", GossipMessage_Tag_name[int32(GossipMessage_ORG_ONLY)])
 		}
@@ -521,7 +536,11 @@ func (m *SignedGossipMessage) String() string {
 			sl := len(m.SecretEnvelope.Signature)
 			secretEnv = fmt.Sprintf(" Secret payload: %d bytes, Secret Signature: %d bytes", pl, sl)
 		}
-		env = fmt.Sprintf("%d bytes, Signature: %d bytes%s", len(m.Envelope.Payload), len(m.Envelope.Signature), secretEnv)
+		var hmac string
+		if len(m.Envelope.Hmac) > 0 {
+			hmac = fmt.Sprintf(" HMAC: %d bytes", len(m.Envelope.Hmac))
+		}
+		env = fmt.Sprintf("%d bytes, Signature: %d bytes%s%s", len(m.Envelope.Payload), len(m.Envelope.Signature), secretEnv, hmac)
 	}
 	gMsg := "No gossipMessage"
 	if m.GossipMessage != nil {
@@ -539,6 +558,12 @@ func (m *SignedGossipMessage) String() string {
 			gMsg = m.GetStateSnapshot().toString()
 		} else if m.GetPrivateRes() != nil {
 			gMsg = m.GetPrivateRes().ToString()
+		} else if m.IsBlockFragmentMsg() {
+			f := m.GetBlockFragment()
+			gMsg = fmt.Sprintf("BlockFragment %d/%d of block %d", f.Index, f.DataFragments+f.ParityFragments, f.SeqNum)
+		} else if m.IsSessionKeyMsg() {
+			// Never log the key itself
+			gMsg = fmt.Sprintf("SessionKey of %d bytes", len(m.GetSessionKey().Key))
 		} else {
 			gMsg = m.GossipMessage.String()
 			isSimpleMsg = true
@@ -147,6 +147,25 @@ func TestToString(t *testing.T) {
 		Envelope: envelopes()[0],
 	}
 	assert.NotContains(t, fmt.Sprintf("%v", sMsg), "2")
+
+	sMsg = &SignedGossipMessage{
+		GossipMessage: &GossipMessage{
+			Channel: []byte("A"),
+			Tag:     GossipMessage_ORG_ONLY,
+			Nonce:   5,
+			Content: &GossipMessage_SessionKey{
+				SessionKey: &SessionKey{
+					Key: []byte{2, 2, 2},
+				},
+			},
+		},
+		Envelope: &Envelope{
+			Payload:   []byte{0, 1, 2, 3, 4, 5, 6},
+			Signature: []byte{0, 1, 2},
+			Hmac:      []byte{2, 2, 2},
+		},
+	}
+	assert.NotContains(t, fmt.Sprintf("%v", sMsg), "2")
 }
 
 func TestAliveMessageNoActionTaken(t *testing.T) {
@@ -586,6 +605,39 @@ func TestGossipMessageIdentityMessageTagType(t *testing.T) {
 	assert.Error(t, msg.IsTagLegal())
 }
 
+func TestGossipMessageSessionKeyMessageTagType(t *testing.T) {
+	var msg *SignedGossipMessage
+	channelID := "testID1"
+
+	msg = signedGossipMessage(channelID, GossipMessage_ORG_ONLY, &GossipMessage_SessionKey{
+		SessionKey: &SessionKey{},
+	})
+	assert.True(t, msg.IsSessionKeyMsg())
+	assert.NoError(t, msg.IsTagLegal())
+
+	msg = signedGossipMessage(channelID, GossipMessage_CHAN_AND_ORG, &GossipMessage_SessionKey{
+		SessionKey: &SessionKey{},
+	})
+	assert.Error(t, msg.IsTagLegal())
+}
+
+func TestGossipMessageBlockFragmentMessageTagType(t *testing.T) {
+	var msg *SignedGossipMessage
+	channelID := "testID1"
+
+	msg = signedGossipMessage(channelID, GossipMessage_CHAN_AND_ORG, &GossipMessage_BlockFragment{
+		BlockFragment: &BlockFragment{SeqNum: 10, Index: 1, DataFragments: 4, ParityFragments: 2},
+	})
+	assert.True(t, msg.IsBlockFragmentMsg())
+	assert.NoError(t, msg.IsTagLegal())
+	assert.Contains(t, msg.String(), "BlockFragment 1/6 of block 10")
+
+	msg = signedGossipMessage(channelID, GossipMessage_ORG_ONLY, &GossipMessage_BlockFragment{
+		BlockFragment: &BlockFragment{},
+	})
+	assert.Error(t, msg.IsTagLegal())
+}
+
 func TestGossipMessagePullMessageTagType(t *testing.T) {
 	var msg *SignedGossipMessage
 	channelID := "testID1"
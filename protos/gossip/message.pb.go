@@ -47,7 +47,7 @@ func (x PullMsgType) String() string {
 	return proto.EnumName(PullMsgType_name, int32(x))
 }
 func (PullMsgType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_cacb879a4502ff63, []int{0}
 }
 
 type GossipMessage_Tag int32
@@ -82,17 +82,20 @@ func (x GossipMessage_Tag) String() string {
 	return proto.EnumName(GossipMessage_Tag_name, int32(x))
 }
 func (GossipMessage_Tag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3, 0}
+	return fileDescriptor_message_cacb879a4502ff63, []int{3, 0}
 }
 
 // Envelope contains a marshalled
 // GossipMessage and a signature over it.
 // It may also contain a SecretEnvelope
-// which is a marshalled Secret
+// which is a marshalled Secret, and an HMAC
+// over the payloads computed with the session key
+// the signer shares with peers of its organization
 type Envelope struct {
 	Payload              []byte          `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature            []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	SecretEnvelope       *SecretEnvelope `protobuf:"bytes,3,opt,name=secret_envelope,json=secretEnvelope,proto3" json:"secret_envelope,omitempty"`
+	Hmac                 []byte          `protobuf:"bytes,4,opt,name=hmac,proto3" json:"hmac,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -102,7 +105,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_cacb879a4502ff63, []int{0}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -143,6 +146,13 @@ func (m *Envelope) GetSecretEnvelope() *SecretEnvelope {
 	return nil
 }
 
+func (m *Envelope) GetHmac() []byte {
+	if m != nil {
+		return m.Hmac
+	}
+	return nil
+}
+
 // SecretEnvelope is a marshalled Secret
 // and a signature over it.
 // The signature should be validated by the peer
@@ -160,7 +170,7 @@ func (m *SecretEnvelope) Reset()         { *m = SecretEnvelope{} }
 func (m *SecretEnvelope) String() string { return proto.CompactTextString(m) }
 func (*SecretEnvelope) ProtoMessage()    {}
 func (*SecretEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{1}
+	return fileDescriptor_message_cacb879a4502ff63, []int{1}
 }
 func (m *SecretEnvelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretEnvelope.Unmarshal(m, b)
@@ -210,7 +220,7 @@ func (m *Secret) Reset()         { *m = Secret{} }
 func (m *Secret) String() string { return proto.CompactTextString(m) }
 func (*Secret) ProtoMessage()    {}
 func (*Secret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{2}
+	return fileDescriptor_message_cacb879a4502ff63, []int{2}
 }
 func (m *Secret) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Secret.Unmarshal(m, b)
@@ -339,6 +349,10 @@ type GossipMessage struct {
 	//	*GossipMessage_PrivateReq
 	//	*GossipMessage_PrivateRes
 	//	*GossipMessage_PrivateData
+	//	*GossipMessage_PvtHashesReq
+	//	*GossipMessage_PvtHashesRes
+	//	*GossipMessage_SessionKey
+	//	*GossipMessage_BlockFragment
 	Content              isGossipMessage_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -349,7 +363,7 @@ func (m *GossipMessage) Reset()         { *m = GossipMessage{} }
 func (m *GossipMessage) String() string { return proto.CompactTextString(m) }
 func (*GossipMessage) ProtoMessage()    {}
 func (*GossipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3}
+	return fileDescriptor_message_cacb879a4502ff63, []int{3}
 }
 func (m *GossipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipMessage.Unmarshal(m, b)
@@ -478,6 +492,22 @@ type GossipMessage_PrivateData struct {
 	PrivateData *PrivateDataMessage `protobuf:"bytes,25,opt,name=private_data,json=privateData,proto3,oneof"`
 }
 
+type GossipMessage_PvtHashesReq struct {
+	PvtHashesReq *PvtDataHashesRequest `protobuf:"bytes,26,opt,name=pvt_hashes_req,json=pvtHashesReq,proto3,oneof"`
+}
+
+type GossipMessage_PvtHashesRes struct {
+	PvtHashesRes *PvtDataHashesResponse `protobuf:"bytes,27,opt,name=pvt_hashes_res,json=pvtHashesRes,proto3,oneof"`
+}
+
+type GossipMessage_SessionKey struct {
+	SessionKey *SessionKey `protobuf:"bytes,28,opt,name=session_key,json=sessionKey,proto3,oneof"`
+}
+
+type GossipMessage_BlockFragment struct {
+	BlockFragment *BlockFragment `protobuf:"bytes,29,opt,name=block_fragment,json=blockFragment,proto3,oneof"`
+}
+
 func (*GossipMessage_AliveMsg) isGossipMessage_Content() {}
 
 func (*GossipMessage_MemReq) isGossipMessage_Content() {}
@@ -520,6 +550,14 @@ func (*GossipMessage_PrivateRes) isGossipMessage_Content() {}
 
 func (*GossipMessage_PrivateData) isGossipMessage_Content() {}
 
+func (*GossipMessage_PvtHashesReq) isGossipMessage_Content() {}
+
+func (*GossipMessage_PvtHashesRes) isGossipMessage_Content() {}
+
+func (*GossipMessage_SessionKey) isGossipMessage_Content() {}
+
+func (*GossipMessage_BlockFragment) isGossipMessage_Content() {}
+
 func (m *GossipMessage) GetContent() isGossipMessage_Content {
 	if m != nil {
 		return m.Content
@@ -674,6 +712,34 @@ func (m *GossipMessage) GetPrivateData() *PrivateDataMessage {
 	return nil
 }
 
+func (m *GossipMessage) GetPvtHashesReq() *PvtDataHashesRequest {
+	if x, ok := m.GetContent().(*GossipMessage_PvtHashesReq); ok {
+		return x.PvtHashesReq
+	}
+	return nil
+}
+
+func (m *GossipMessage) GetPvtHashesRes() *PvtDataHashesResponse {
+	if x, ok := m.GetContent().(*GossipMessage_PvtHashesRes); ok {
+		return x.PvtHashesRes
+	}
+	return nil
+}
+
+func (m *GossipMessage) GetSessionKey() *SessionKey {
+	if x, ok := m.GetContent().(*GossipMessage_SessionKey); ok {
+		return x.SessionKey
+	}
+	return nil
+}
+
+func (m *GossipMessage) GetBlockFragment() *BlockFragment {
+	if x, ok := m.GetContent().(*GossipMessage_BlockFragment); ok {
+		return x.BlockFragment
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*GossipMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _GossipMessage_OneofMarshaler, _GossipMessage_OneofUnmarshaler, _GossipMessage_OneofSizer, []interface{}{
@@ -698,6 +764,10 @@ func (*GossipMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 		(*GossipMessage_PrivateReq)(nil),
 		(*GossipMessage_PrivateRes)(nil),
 		(*GossipMessage_PrivateData)(nil),
+		(*GossipMessage_PvtHashesReq)(nil),
+		(*GossipMessage_PvtHashesRes)(nil),
+		(*GossipMessage_SessionKey)(nil),
+		(*GossipMessage_BlockFragment)(nil),
 	}
 }
 
@@ -810,6 +880,26 @@ func _GossipMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.PrivateData); err != nil {
 			return err
 		}
+	case *GossipMessage_PvtHashesReq:
+		b.EncodeVarint(26<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PvtHashesReq); err != nil {
+			return err
+		}
+	case *GossipMessage_PvtHashesRes:
+		b.EncodeVarint(27<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PvtHashesRes); err != nil {
+			return err
+		}
+	case *GossipMessage_SessionKey:
+		b.EncodeVarint(28<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SessionKey); err != nil {
+			return err
+		}
+	case *GossipMessage_BlockFragment:
+		b.EncodeVarint(29<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockFragment); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("GossipMessage.Content has unexpected type %T", x)
@@ -988,6 +1078,38 @@ func _GossipMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Content = &GossipMessage_PrivateData{msg}
 		return true, err
+	case 26: // content.pvt_hashes_req
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PvtDataHashesRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_PvtHashesReq{msg}
+		return true, err
+	case 27: // content.pvt_hashes_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PvtDataHashesResponse)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_PvtHashesRes{msg}
+		return true, err
+	case 28: // content.session_key
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SessionKey)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_SessionKey{msg}
+		return true, err
+	case 29: // content.block_fragment
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockFragment)
+		err := b.DecodeMessage(msg)
+		m.Content = &GossipMessage_BlockFragment{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1102,6 +1224,26 @@ func _GossipMessage_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *GossipMessage_PvtHashesReq:
+		s := proto.Size(x.PvtHashesReq)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GossipMessage_PvtHashesRes:
+		s := proto.Size(x.PvtHashesRes)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GossipMessage_SessionKey:
+		s := proto.Size(x.SessionKey)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GossipMessage_BlockFragment:
+		s := proto.Size(x.BlockFragment)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1128,7 +1270,7 @@ func (m *StateInfo) Reset()         { *m = StateInfo{} }
 func (m *StateInfo) String() string { return proto.CompactTextString(m) }
 func (*StateInfo) ProtoMessage()    {}
 func (*StateInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{4}
+	return fileDescriptor_message_cacb879a4502ff63, []int{4}
 }
 func (m *StateInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfo.Unmarshal(m, b)
@@ -1189,7 +1331,7 @@ func (m *Properties) Reset()         { *m = Properties{} }
 func (m *Properties) String() string { return proto.CompactTextString(m) }
 func (*Properties) ProtoMessage()    {}
 func (*Properties) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{5}
+	return fileDescriptor_message_cacb879a4502ff63, []int{5}
 }
 func (m *Properties) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Properties.Unmarshal(m, b)
@@ -1242,7 +1384,7 @@ func (m *StateInfoSnapshot) Reset()         { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()    {}
 func (*StateInfoSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{6}
+	return fileDescriptor_message_cacb879a4502ff63, []int{6}
 }
 func (m *StateInfoSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoSnapshot.Unmarshal(m, b)
@@ -1285,7 +1427,7 @@ func (m *StateInfoPullRequest) Reset()         { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()    {}
 func (*StateInfoPullRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{7}
+	return fileDescriptor_message_cacb879a4502ff63, []int{7}
 }
 func (m *StateInfoPullRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoPullRequest.Unmarshal(m, b)
@@ -1328,7 +1470,7 @@ func (m *ConnEstablish) Reset()         { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()    {}
 func (*ConnEstablish) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{8}
+	return fileDescriptor_message_cacb879a4502ff63, []int{8}
 }
 func (m *ConnEstablish) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnEstablish.Unmarshal(m, b)
@@ -1385,7 +1527,7 @@ func (m *PeerIdentity) Reset()         { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()    {}
 func (*PeerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{9}
+	return fileDescriptor_message_cacb879a4502ff63, []int{9}
 }
 func (m *PeerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerIdentity.Unmarshal(m, b)
@@ -1441,7 +1583,7 @@ func (m *DataRequest) Reset()         { *m = DataRequest{} }
 func (m *DataRequest) String() string { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()    {}
 func (*DataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{10}
+	return fileDescriptor_message_cacb879a4502ff63, []int{10}
 }
 func (m *DataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
@@ -1497,7 +1639,7 @@ func (m *GossipHello) Reset()         { *m = GossipHello{} }
 func (m *GossipHello) String() string { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()    {}
 func (*GossipHello) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{11}
+	return fileDescriptor_message_cacb879a4502ff63, []int{11}
 }
 func (m *GossipHello) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipHello.Unmarshal(m, b)
@@ -1553,7 +1695,7 @@ func (m *DataUpdate) Reset()         { *m = DataUpdate{} }
 func (m *DataUpdate) String() string { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()    {}
 func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{12}
+	return fileDescriptor_message_cacb879a4502ff63, []int{12}
 }
 func (m *DataUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdate.Unmarshal(m, b)
@@ -1609,7 +1751,7 @@ func (m *DataDigest) Reset()         { *m = DataDigest{} }
 func (m *DataDigest) String() string { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()    {}
 func (*DataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{13}
+	return fileDescriptor_message_cacb879a4502ff63, []int{13}
 }
 func (m *DataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataDigest.Unmarshal(m, b)
@@ -1662,7 +1804,7 @@ func (m *DataMessage) Reset()         { *m = DataMessage{} }
 func (m *DataMessage) String() string { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()    {}
 func (*DataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{14}
+	return fileDescriptor_message_cacb879a4502ff63, []int{14}
 }
 func (m *DataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataMessage.Unmarshal(m, b)
@@ -1689,6 +1831,108 @@ func (m *DataMessage) GetPayload() *Payload {
 	return nil
 }
 
+// BlockFragment is a Reed-Solomon coded fragment of the marshaled
+// Payload of a block, which the leader peer of an organization sends
+// to peers of its organization instead of the block itself.
+// Each peer that gets a fragment from the leader relays it to the
+// rest of the peers of its organization, and any data_fragments
+// fragments of the same block are enough to reconstruct it.
+type BlockFragment struct {
+	SeqNum uint64 `protobuf:"varint,1,opt,name=seq_num,json=seqNum,proto3" json:"seq_num,omitempty"`
+	// SHA256 hash of the marshaled Payload
+	PayloadHash     []byte `protobuf:"bytes,2,opt,name=payload_hash,json=payloadHash,proto3" json:"payload_hash,omitempty"`
+	PayloadSize     uint64 `protobuf:"varint,3,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	DataFragments   uint32 `protobuf:"varint,4,opt,name=data_fragments,json=dataFragments,proto3" json:"data_fragments,omitempty"`
+	ParityFragments uint32 `protobuf:"varint,5,opt,name=parity_fragments,json=parityFragments,proto3" json:"parity_fragments,omitempty"`
+	Index           uint32 `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
+	Fragment        []byte `protobuf:"bytes,7,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// Whether the fragment was relayed by a peer, rather than sent by the leader
+	Relayed              bool     `protobuf:"varint,8,opt,name=relayed,proto3" json:"relayed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockFragment) Reset()         { *m = BlockFragment{} }
+func (m *BlockFragment) String() string { return proto.CompactTextString(m) }
+func (*BlockFragment) ProtoMessage()    {}
+func (*BlockFragment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_cacb879a4502ff63, []int{15}
+}
+func (m *BlockFragment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockFragment.Unmarshal(m, b)
+}
+func (m *BlockFragment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockFragment.Marshal(b, m, deterministic)
+}
+func (dst *BlockFragment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockFragment.Merge(dst, src)
+}
+func (m *BlockFragment) XXX_Size() int {
+	return xxx_messageInfo_BlockFragment.Size(m)
+}
+func (m *BlockFragment) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockFragment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockFragment proto.InternalMessageInfo
+
+func (m *BlockFragment) GetSeqNum() uint64 {
+	if m != nil {
+		return m.SeqNum
+	}
+	return 0
+}
+
+func (m *BlockFragment) GetPayloadHash() []byte {
+	if m != nil {
+		return m.PayloadHash
+	}
+	return nil
+}
+
+func (m *BlockFragment) GetPayloadSize() uint64 {
+	if m != nil {
+		return m.PayloadSize
+	}
+	return 0
+}
+
+func (m *BlockFragment) GetDataFragments() uint32 {
+	if m != nil {
+		return m.DataFragments
+	}
+	return 0
+}
+
+func (m *BlockFragment) GetParityFragments() uint32 {
+	if m != nil {
+		return m.ParityFragments
+	}
+	return 0
+}
+
+func (m *BlockFragment) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BlockFragment) GetFragment() []byte {
+	if m != nil {
+		return m.Fragment
+	}
+	return nil
+}
+
+func (m *BlockFragment) GetRelayed() bool {
+	if m != nil {
+		return m.Relayed
+	}
+	return false
+}
+
 // PrivateDataMessage message which includes private
 // data information to distributed once transaction
 // has been endorsed
@@ -1703,7 +1947,7 @@ func (m *PrivateDataMessage) Reset()         { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()    {}
 func (*PrivateDataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{15}
+	return fileDescriptor_message_cacb879a4502ff63, []int{16}
 }
 func (m *PrivateDataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivateDataMessage.Unmarshal(m, b)
@@ -1744,7 +1988,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{16}
+	return fileDescriptor_message_cacb879a4502ff63, []int{17}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -1804,7 +2048,7 @@ func (m *PrivatePayload) Reset()         { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()    {}
 func (*PrivatePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{17}
+	return fileDescriptor_message_cacb879a4502ff63, []int{18}
 }
 func (m *PrivatePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivatePayload.Unmarshal(m, b)
@@ -1881,7 +2125,7 @@ func (m *AliveMessage) Reset()         { *m = AliveMessage{} }
 func (m *AliveMessage) String() string { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()    {}
 func (*AliveMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{18}
+	return fileDescriptor_message_cacb879a4502ff63, []int{19}
 }
 func (m *AliveMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AliveMessage.Unmarshal(m, b)
@@ -1937,7 +2181,7 @@ func (m *LeadershipMessage) Reset()         { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()    {}
 func (*LeadershipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{19}
+	return fileDescriptor_message_cacb879a4502ff63, []int{20}
 }
 func (m *LeadershipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LeadershipMessage.Unmarshal(m, b)
@@ -1991,7 +2235,7 @@ func (m *PeerTime) Reset()         { *m = PeerTime{} }
 func (m *PeerTime) String() string { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()    {}
 func (*PeerTime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{20}
+	return fileDescriptor_message_cacb879a4502ff63, []int{21}
 }
 func (m *PeerTime) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerTime.Unmarshal(m, b)
@@ -2039,7 +2283,7 @@ func (m *MembershipRequest) Reset()         { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()    {}
 func (*MembershipRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{21}
+	return fileDescriptor_message_cacb879a4502ff63, []int{22}
 }
 func (m *MembershipRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipRequest.Unmarshal(m, b)
@@ -2086,7 +2330,7 @@ func (m *MembershipResponse) Reset()         { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()    {}
 func (*MembershipResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{22}
+	return fileDescriptor_message_cacb879a4502ff63, []int{23}
 }
 func (m *MembershipResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipResponse.Unmarshal(m, b)
@@ -2135,7 +2379,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{23}
+	return fileDescriptor_message_cacb879a4502ff63, []int{24}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Member.Unmarshal(m, b)
@@ -2187,7 +2431,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{24}
+	return fileDescriptor_message_cacb879a4502ff63, []int{25}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -2221,7 +2465,7 @@ func (m *RemoteStateRequest) Reset()         { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()    {}
 func (*RemoteStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{25}
+	return fileDescriptor_message_cacb879a4502ff63, []int{26}
 }
 func (m *RemoteStateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateRequest.Unmarshal(m, b)
@@ -2268,7 +2512,7 @@ func (m *RemoteStateResponse) Reset()         { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()    {}
 func (*RemoteStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{26}
+	return fileDescriptor_message_cacb879a4502ff63, []int{27}
 }
 func (m *RemoteStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateResponse.Unmarshal(m, b)
@@ -2308,7 +2552,7 @@ func (m *RemotePvtDataRequest) Reset()         { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()    {}
 func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{27}
+	return fileDescriptor_message_cacb879a4502ff63, []int{28}
 }
 func (m *RemotePvtDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataRequest.Unmarshal(m, b)
@@ -2351,7 +2595,7 @@ func (m *PvtDataDigest) Reset()         { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()    {}
 func (*PvtDataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{28}
+	return fileDescriptor_message_cacb879a4502ff63, []int{29}
 }
 func (m *PvtDataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataDigest.Unmarshal(m, b)
@@ -2419,7 +2663,7 @@ func (m *RemotePvtDataResponse) Reset()         { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()    {}
 func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{29}
+	return fileDescriptor_message_cacb879a4502ff63, []int{30}
 }
 func (m *RemotePvtDataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataResponse.Unmarshal(m, b)
@@ -2459,7 +2703,7 @@ func (m *PvtDataElement) Reset()         { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()    {}
 func (*PvtDataElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{30}
+	return fileDescriptor_message_cacb879a4502ff63, []int{31}
 }
 func (m *PvtDataElement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataElement.Unmarshal(m, b)
@@ -2493,6 +2737,196 @@ func (m *PvtDataElement) GetPayload() [][]byte {
 	return nil
 }
 
+// PvtDataHashesRequest is used by the private data
+// anti-entropy to ask a peer of the same organization for
+// the hashes of the private data it stores for the blocks
+// in the range [start_block, end_block]
+type PvtDataHashesRequest struct {
+	StartBlock           uint64   `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataHashesRequest) Reset()         { *m = PvtDataHashesRequest{} }
+func (m *PvtDataHashesRequest) String() string { return proto.CompactTextString(m) }
+func (*PvtDataHashesRequest) ProtoMessage()    {}
+func (*PvtDataHashesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_cacb879a4502ff63, []int{32}
+}
+func (m *PvtDataHashesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataHashesRequest.Unmarshal(m, b)
+}
+func (m *PvtDataHashesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataHashesRequest.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataHashesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataHashesRequest.Merge(dst, src)
+}
+func (m *PvtDataHashesRequest) XXX_Size() int {
+	return xxx_messageInfo_PvtDataHashesRequest.Size(m)
+}
+func (m *PvtDataHashesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataHashesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataHashesRequest proto.InternalMessageInfo
+
+func (m *PvtDataHashesRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataHashesRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// PvtDataHashesResponse is the response to a PvtDataHashesRequest
+type PvtDataHashesResponse struct {
+	Hashes               []*BlockPvtDataHash `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PvtDataHashesResponse) Reset()         { *m = PvtDataHashesResponse{} }
+func (m *PvtDataHashesResponse) String() string { return proto.CompactTextString(m) }
+func (*PvtDataHashesResponse) ProtoMessage()    {}
+func (*PvtDataHashesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_cacb879a4502ff63, []int{33}
+}
+func (m *PvtDataHashesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataHashesResponse.Unmarshal(m, b)
+}
+func (m *PvtDataHashesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataHashesResponse.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataHashesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataHashesResponse.Merge(dst, src)
+}
+func (m *PvtDataHashesResponse) XXX_Size() int {
+	return xxx_messageInfo_PvtDataHashesResponse.Size(m)
+}
+func (m *PvtDataHashesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataHashesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataHashesResponse proto.InternalMessageInfo
+
+func (m *PvtDataHashesResponse) GetHashes() []*BlockPvtDataHash {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// BlockPvtDataHash is the hash of the private write sets that
+// a peer stores for a block. The hash is empty if the peer
+// doesn't store any private data for the block
+type BlockPvtDataHash struct {
+	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockPvtDataHash) Reset()         { *m = BlockPvtDataHash{} }
+func (m *BlockPvtDataHash) String() string { return proto.CompactTextString(m) }
+func (*BlockPvtDataHash) ProtoMessage()    {}
+func (*BlockPvtDataHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_cacb879a4502ff63, []int{34}
+}
+func (m *BlockPvtDataHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockPvtDataHash.Unmarshal(m, b)
+}
+func (m *BlockPvtDataHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockPvtDataHash.Marshal(b, m, deterministic)
+}
+func (dst *BlockPvtDataHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockPvtDataHash.Merge(dst, src)
+}
+func (m *BlockPvtDataHash) XXX_Size() int {
+	return xxx_messageInfo_BlockPvtDataHash.Size(m)
+}
+func (m *BlockPvtDataHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockPvtDataHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockPvtDataHash proto.InternalMessageInfo
+
+func (m *BlockPvtDataHash) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *BlockPvtDataHash) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// SessionKey is sent by a peer to peers of its organization
+// over their mutual-TLS connection. It carries the key the peer
+// computes HMACs of its AliveMessages with (when the channel of
+// the GossipMessage is empty), or of its StateInfo messages
+// of the channel of the GossipMessage. The message is signed
+// by the peer, and the key is bound to the PKI-ID of the peer
+type SessionKey struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	PkiId                []byte   `protobuf:"bytes,2,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionKey) Reset()         { *m = SessionKey{} }
+func (m *SessionKey) String() string { return proto.CompactTextString(m) }
+func (*SessionKey) ProtoMessage()    {}
+func (*SessionKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_cacb879a4502ff63, []int{35}
+}
+func (m *SessionKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionKey.Unmarshal(m, b)
+}
+func (m *SessionKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionKey.Marshal(b, m, deterministic)
+}
+func (dst *SessionKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionKey.Merge(dst, src)
+}
+func (m *SessionKey) XXX_Size() int {
+	return xxx_messageInfo_SessionKey.Size(m)
+}
+func (m *SessionKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionKey proto.InternalMessageInfo
+
+func (m *SessionKey) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SessionKey) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
 // PvtPayload augments private rwset data and tx index
 // inside the block
 type PvtDataPayload struct {
@@ -2509,7 +2943,7 @@ func (m *PvtDataPayload) Reset()         { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()    {}
 func (*PvtDataPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{31}
+	return fileDescriptor_message_cacb879a4502ff63, []int{36}
 }
 func (m *PvtDataPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataPayload.Unmarshal(m, b)
@@ -2554,7 +2988,7 @@ func (m *Acknowledgement) Reset()         { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()    {}
 func (*Acknowledgement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{32}
+	return fileDescriptor_message_cacb879a4502ff63, []int{37}
 }
 func (m *Acknowledgement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Acknowledgement.Unmarshal(m, b)
@@ -2596,7 +3030,7 @@ func (m *Chaincode) Reset()         { *m = Chaincode{} }
 func (m *Chaincode) String() string { return proto.CompactTextString(m) }
 func (*Chaincode) ProtoMessage()    {}
 func (*Chaincode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{33}
+	return fileDescriptor_message_cacb879a4502ff63, []int{38}
 }
 func (m *Chaincode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chaincode.Unmarshal(m, b)
@@ -2653,6 +3087,7 @@ func init() {
 	proto.RegisterType((*DataUpdate)(nil), "gossip.DataUpdate")
 	proto.RegisterType((*DataDigest)(nil), "gossip.DataDigest")
 	proto.RegisterType((*DataMessage)(nil), "gossip.DataMessage")
+	proto.RegisterType((*BlockFragment)(nil), "gossip.BlockFragment")
 	proto.RegisterType((*PrivateDataMessage)(nil), "gossip.PrivateDataMessage")
 	proto.RegisterType((*Payload)(nil), "gossip.Payload")
 	proto.RegisterType((*PrivatePayload)(nil), "gossip.PrivatePayload")
@@ -2669,6 +3104,10 @@ func init() {
 	proto.RegisterType((*PvtDataDigest)(nil), "gossip.PvtDataDigest")
 	proto.RegisterType((*RemotePvtDataResponse)(nil), "gossip.RemotePvtDataResponse")
 	proto.RegisterType((*PvtDataElement)(nil), "gossip.PvtDataElement")
+	proto.RegisterType((*PvtDataHashesRequest)(nil), "gossip.PvtDataHashesRequest")
+	proto.RegisterType((*PvtDataHashesResponse)(nil), "gossip.PvtDataHashesResponse")
+	proto.RegisterType((*BlockPvtDataHash)(nil), "gossip.BlockPvtDataHash")
+	proto.RegisterType((*SessionKey)(nil), "gossip.SessionKey")
 	proto.RegisterType((*PvtDataPayload)(nil), "gossip.PvtDataPayload")
 	proto.RegisterType((*Acknowledgement)(nil), "gossip.Acknowledgement")
 	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
@@ -2818,126 +3257,144 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 	Metadata: "gossip/message.proto",
 }
 
-func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_7c42328ef5ef9997) }
-
-var fileDescriptor_message_7c42328ef5ef9997 = []byte{
-	// 1874 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xe3, 0xc6,
-	0x15, 0x16, 0x6d, 0x5d, 0x8f, 0x2e, 0x96, 0xc7, 0xde, 0x5d, 0xc6, 0x49, 0x13, 0x87, 0xed, 0x26,
-	0xdb, 0x7a, 0x23, 0x6f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0x5b, 0x52, 0x2c, 0x21, 0x2b, 0xad,
-	0x4b, 0x7b, 0xd1, 0xba, 0x2f, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x3b, 0xf6,
-	0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0x6f, 0xe8, 0x53, 0xff, 0x66, 0x31, 0x33, 0xbc, 0x4a, 0xf6,
-	0x02, 0x1b, 0x20, 0x6f, 0x3c, 0xf7, 0x99, 0x33, 0x67, 0xbe, 0x73, 0x86, 0xb0, 0xed, 0x86, 0x8c,
-	0x79, 0xd1, 0x7e, 0x40, 0x18, 0xc3, 0x2e, 0x19, 0x44, 0x71, 0xc8, 0x43, 0x54, 0x57, 0xdc, 0x9d,
-	0x67, 0x76, 0x18, 0x04, 0x21, 0xdd, 0xb7, 0x43, 0xdf, 0x27, 0x36, 0xf7, 0x42, 0xaa, 0x14, 0x8c,
-	0x7f, 0x69, 0xd0, 0x1c, 0xd3, 0x5b, 0xe2, 0x87, 0x11, 0x41, 0x3a, 0x34, 0x22, 0x7c, 0xef, 0x87,
-	0xd8, 0xd1, 0xb5, 0x5d, 0xed, 0x45, 0xc7, 0x4c, 0x49, 0xf4, 0x09, 0xb4, 0x98, 0xe7, 0x52, 0xcc,
-	0x6f, 0x62, 0xa2, 0xaf, 0x49, 0x59, 0xce, 0x40, 0xaf, 0x61, 0x83, 0x11, 0x3b, 0x26, 0xdc, 0x22,
-	0x89, 0x2b, 0x7d, 0x7d, 0x57, 0x7b, 0xd1, 0x3e, 0x78, 0x3a, 0x50, 0xf1, 0x07, 0xa7, 0x52, 0x9c,
-	0x06, 0x32, 0x7b, 0xac, 0x44, 0x1b, 0x13, 0xe8, 0x95, 0x35, 0x7e, 0xea, 0x52, 0x8c, 0x43, 0xa8,
-	0x2b, 0x4f, 0xe8, 0x25, 0xf4, 0x3d, 0xca, 0x49, 0x4c, 0xb1, 0x3f, 0xa6, 0x4e, 0x14, 0x7a, 0x94,
-	0x4b, 0x57, 0xad, 0x49, 0xc5, 0x5c, 0x91, 0x1c, 0xb5, 0xa0, 0x61, 0x87, 0x94, 0x13, 0xca, 0x8d,
-	0x1f, 0xdb, 0xd0, 0x3d, 0x96, 0xcb, 0x9e, 0xa9, 0x5c, 0xa2, 0x6d, 0xa8, 0xd1, 0x90, 0xda, 0x44,
-	0xda, 0x57, 0x4d, 0x45, 0x88, 0x25, 0xda, 0x0b, 0x4c, 0x29, 0xf1, 0x93, 0x65, 0xa4, 0x24, 0xda,
-	0x83, 0x75, 0x8e, 0x5d, 0x99, 0x83, 0xde, 0xc1, 0x47, 0x69, 0x0e, 0x4a, 0x3e, 0x07, 0x67, 0xd8,
-	0x35, 0x85, 0x16, 0xfa, 0x1a, 0x5a, 0xd8, 0xf7, 0x6e, 0x89, 0x15, 0x30, 0x57, 0xaf, 0xc9, 0xb4,
-	0x6d, 0xa7, 0x26, 0x87, 0x42, 0x90, 0x58, 0x4c, 0x2a, 0x66, 0x53, 0x2a, 0xce, 0x98, 0x8b, 0x7e,
-	0x07, 0x8d, 0x80, 0x04, 0x56, 0x4c, 0xae, 0xf5, 0xba, 0x34, 0xc9, 0xa2, 0xcc, 0x48, 0x70, 0x41,
-	0x62, 0xb6, 0xf0, 0x22, 0x93, 0x5c, 0xdf, 0x10, 0xc6, 0x27, 0x15, 0xb3, 0x1e, 0x90, 0xc0, 0x24,
-	0xd7, 0xe8, 0xf7, 0xa9, 0x15, 0xd3, 0x1b, 0xd2, 0x6a, 0xe7, 0x21, 0x2b, 0x16, 0x85, 0x94, 0x91,
-	0xcc, 0x8c, 0xa1, 0x57, 0xd0, 0x74, 0x30, 0xc7, 0x72, 0x81, 0x4d, 0x69, 0xb7, 0x95, 0xda, 0x8d,
-	0x30, 0xc7, 0xf9, 0xfa, 0x1a, 0x42, 0x4d, 0x2c, 0x6f, 0x0f, 0x6a, 0x0b, 0xe2, 0xfb, 0xa1, 0xde,
-	0x2a, 0xab, 0xab, 0x14, 0x4c, 0x84, 0x68, 0x52, 0x31, 0x95, 0x0e, 0xda, 0x4f, 0xdc, 0x3b, 0x9e,
-	0xab, 0x83, 0xd4, 0x47, 0x45, 0xf7, 0x23, 0xcf, 0x55, 0xbb, 0x90, 0xde, 0x47, 0x9e, 0x9b, 0xad,
-	0x47, 0xec, 0xbe, 0xbd, 0xba, 0x9e, 0x7c, 0xdf, 0xd2, 0x42, 0x6d, 0xbc, 0x2d, 0x2d, 0x6e, 0x22,
-	0x07, 0x73, 0xa2, 0x77, 0x56, 0xa3, 0xbc, 0x93, 0x92, 0x49, 0xc5, 0x04, 0x27, 0xa3, 0xd0, 0x73,
-	0xa8, 0x91, 0x20, 0xe2, 0xf7, 0x7a, 0x57, 0x1a, 0x74, 0x53, 0x83, 0xb1, 0x60, 0x8a, 0x0d, 0x48,
-	0x29, 0xda, 0x83, 0xaa, 0x1d, 0x52, 0xaa, 0xf7, 0xa4, 0xd6, 0x93, 0x54, 0x6b, 0x18, 0x52, 0x3a,
-	0x66, 0x1c, 0x5f, 0xf8, 0x1e, 0x5b, 0x4c, 0x2a, 0xa6, 0x54, 0x42, 0x07, 0x00, 0x8c, 0x63, 0x4e,
-	0x2c, 0x8f, 0x5e, 0x86, 0xfa, 0x86, 0x34, 0xd9, 0xcc, 0xae, 0x89, 0x90, 0x4c, 0xe9, 0xa5, 0xc8,
-	0x4e, 0x8b, 0xa5, 0x04, 0x3a, 0x82, 0x9e, 0xb2, 0x61, 0x14, 0x47, 0x6c, 0x11, 0x72, 0xbd, 0x5f,
-	0x3e, 0xf4, 0xcc, 0xee, 0x34, 0x51, 0x98, 0x54, 0xcc, 0xae, 0x34, 0x49, 0x19, 0x68, 0x06, 0x5b,
-	0x79, 0x5c, 0x2b, 0xba, 0xf1, 0x7d, 0x99, 0xbf, 0x4d, 0xe9, 0xe8, 0x93, 0x15, 0x47, 0x27, 0x37,
-	0xbe, 0x9f, 0x27, 0xb2, 0xcf, 0x96, 0xf8, 0xe8, 0x10, 0x94, 0x7f, 0xe1, 0x44, 0x28, 0xe9, 0xa8,
-	0x5c, 0x50, 0x26, 0x09, 0x42, 0x4e, 0xa4, 0xbb, 0xdc, 0x4d, 0x87, 0x15, 0x68, 0x34, 0x4a, 0x77,
-	0x15, 0x27, 0x25, 0xa7, 0x6f, 0x49, 0x1f, 0x1f, 0x3f, 0xe8, 0x23, 0xab, 0xca, 0x2e, 0x2b, 0x32,
-	0x44, 0x6e, 0x7c, 0x82, 0x1d, 0x55, 0xbc, 0xb2, 0x44, 0xb7, 0xcb, 0xb9, 0x79, 0x93, 0x49, 0xf3,
-	0x42, 0xed, 0xe6, 0x26, 0xa2, 0x5c, 0xbf, 0x85, 0x6e, 0x44, 0x48, 0x6c, 0x79, 0x0e, 0xa1, 0xdc,
-	0xe3, 0xf7, 0xfa, 0x93, 0xf2, 0x35, 0x3c, 0x21, 0x24, 0x9e, 0x26, 0x32, 0xb1, 0x8d, 0xa8, 0x40,
-	0x8b, 0xcb, 0x8e, 0xed, 0x2b, 0xfd, 0xa9, 0x34, 0x79, 0x96, 0xdd, 0x5c, 0xfb, 0x8a, 0x86, 0x3f,
-	0xf8, 0xc4, 0x71, 0x49, 0x40, 0xa8, 0xd8, 0xbc, 0xd0, 0x42, 0x7f, 0x06, 0x88, 0x62, 0xef, 0x56,
-	0x65, 0x41, 0x7f, 0x56, 0x4e, 0xbe, 0xda, 0xef, 0xc9, 0x2d, 0x2f, 0x57, 0x71, 0xc1, 0x02, 0xbd,
-	0x2e, 0xd8, 0x33, 0x5d, 0x97, 0xf6, 0xbf, 0x78, 0xc4, 0x3e, 0xcb, 0x58, 0xc1, 0x04, 0xbd, 0x86,
-	0x4e, 0x42, 0x59, 0xa2, 0xd0, 0xf5, 0x8f, 0xca, 0xc7, 0x76, 0xa2, 0x64, 0xe5, 0x6b, 0xdd, 0x8e,
-	0x72, 0xae, 0x61, 0xc1, 0xfa, 0x19, 0x76, 0x51, 0x17, 0x5a, 0xef, 0xe6, 0xa3, 0xf1, 0x77, 0xd3,
-	0xf9, 0x78, 0xd4, 0xaf, 0xa0, 0x16, 0xd4, 0xc6, 0xb3, 0x93, 0xb3, 0xf3, 0xbe, 0x86, 0x3a, 0xd0,
-	0x7c, 0x6b, 0x1e, 0x5b, 0x6f, 0xe7, 0x6f, 0xce, 0xfb, 0x6b, 0x42, 0x6f, 0x38, 0x39, 0x9c, 0x2b,
-	0x72, 0x1d, 0xf5, 0xa1, 0x23, 0xc9, 0xc3, 0xf9, 0xc8, 0x7a, 0x6b, 0x1e, 0xf7, 0xab, 0x68, 0x03,
-	0xda, 0x4a, 0xc1, 0x94, 0x8c, 0x5a, 0x11, 0x89, 0xff, 0xa7, 0x41, 0x2b, 0xab, 0x48, 0x34, 0x80,
-	0x16, 0xf7, 0x02, 0xc2, 0x38, 0x0e, 0x22, 0x89, 0xb8, 0xed, 0x83, 0x7e, 0xf1, 0x84, 0xce, 0xbc,
-	0x80, 0x98, 0xb9, 0x0a, 0x7a, 0x02, 0xf5, 0xe8, 0xca, 0xb3, 0x3c, 0x47, 0x02, 0x71, 0xc7, 0xac,
-	0x45, 0x57, 0xde, 0xd4, 0x41, 0x9f, 0x41, 0x3b, 0xc1, 0x69, 0x6b, 0x76, 0x38, 0xd4, 0xab, 0x52,
-	0x06, 0x09, 0x6b, 0x76, 0x38, 0x14, 0x37, 0x34, 0x8a, 0xc3, 0x88, 0xc4, 0xdc, 0x23, 0x2c, 0x41,
-	0x64, 0x94, 0x27, 0x28, 0x95, 0x98, 0x05, 0x2d, 0xe3, 0x47, 0x0d, 0x20, 0x17, 0xa1, 0x5f, 0x42,
-	0x57, 0x1e, 0x7d, 0x6c, 0x2d, 0x88, 0xe7, 0x2e, 0x78, 0xd2, 0x38, 0x3a, 0x8a, 0x39, 0x91, 0x3c,
-	0xf4, 0x39, 0x74, 0x7c, 0x72, 0xc9, 0xad, 0x62, 0x13, 0x69, 0x9a, 0x6d, 0xc1, 0x1b, 0x26, 0x8d,
-	0xe4, 0xb7, 0x20, 0x16, 0xe6, 0x51, 0x3b, 0x74, 0x08, 0xd3, 0xd7, 0x77, 0xd7, 0x8b, 0x60, 0x31,
-	0x4c, 0x25, 0x66, 0x41, 0xc9, 0x38, 0x84, 0xcd, 0x15, 0x34, 0x40, 0x2f, 0xa1, 0x49, 0x7c, 0x59,
-	0x88, 0x4c, 0xd7, 0xa4, 0x97, 0x2c, 0x73, 0x59, 0x4f, 0xce, 0x34, 0x8c, 0x3f, 0xc0, 0xf6, 0x43,
-	0x38, 0xb0, 0x9c, 0x39, 0x6d, 0x39, 0x73, 0xc6, 0x25, 0x74, 0x4b, 0xa0, 0x57, 0x38, 0x02, 0xad,
-	0x78, 0x04, 0x3b, 0xd0, 0xcc, 0xae, 0x9a, 0x6a, 0x9d, 0x19, 0x8d, 0x0c, 0xe8, 0x72, 0x9f, 0x59,
-	0x36, 0x89, 0xb9, 0xb5, 0xc0, 0x6c, 0x91, 0x1c, 0x5e, 0x9b, 0xfb, 0x6c, 0x48, 0x62, 0x3e, 0xc1,
-	0x6c, 0x61, 0xbc, 0x83, 0x4e, 0xf1, 0x4a, 0x3e, 0x16, 0x06, 0x41, 0x55, 0xb8, 0x49, 0x42, 0xc8,
-	0x6f, 0x11, 0x3a, 0x20, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x33, 0xda, 0x08, 0xa0, 0x5d, 0xb8, 0x79,
-	0x8f, 0x77, 0x7d, 0x47, 0x76, 0x24, 0xa6, 0xaf, 0xed, 0xae, 0x8b, 0xae, 0x9f, 0x90, 0x68, 0x00,
-	0xcd, 0x80, 0xb9, 0x16, 0xbf, 0x4f, 0xc6, 0x9f, 0x5e, 0xde, 0x96, 0x44, 0x16, 0x67, 0xcc, 0x3d,
-	0xbb, 0x8f, 0x88, 0xd9, 0x08, 0xd4, 0x87, 0x11, 0x42, 0xbb, 0xd0, 0x0f, 0x1f, 0x09, 0x57, 0x5c,
-	0xef, 0x5a, 0x79, 0xbd, 0x1f, 0x1c, 0xf0, 0x0e, 0x20, 0x6f, 0x75, 0x8f, 0xc4, 0xfb, 0x15, 0x54,
-	0x93, 0x58, 0x0f, 0x57, 0x49, 0xf5, 0x27, 0x45, 0xf6, 0x55, 0x64, 0xd5, 0xca, 0x7f, 0xf6, 0xc4,
-	0x7e, 0xa3, 0xce, 0x31, 0x9d, 0xde, 0x7e, 0x5d, 0x1e, 0x25, 0xdb, 0x07, 0x1b, 0x99, 0xb5, 0x62,
-	0x67, 0xb3, 0xa5, 0xf1, 0x1d, 0xa0, 0x55, 0x04, 0x44, 0xaf, 0x96, 0x1d, 0x3c, 0x5d, 0x82, 0xcb,
-	0x15, 0x3f, 0xe7, 0xd0, 0x48, 0x78, 0xe8, 0x19, 0x34, 0x18, 0xb9, 0xb6, 0xe8, 0x4d, 0x90, 0x6c,
-	0xb7, 0xce, 0xc8, 0xf5, 0xfc, 0x26, 0x10, 0xd5, 0x59, 0x38, 0x55, 0x95, 0xd7, 0xcf, 0x97, 0xd0,
-	0x79, 0x5d, 0x26, 0xa2, 0x84, 0xbf, 0xff, 0x59, 0x83, 0x5e, 0x39, 0x2c, 0xfa, 0x12, 0x36, 0xf2,
-	0xb9, 0xde, 0xa2, 0x38, 0x50, 0x99, 0x6d, 0x99, 0xbd, 0x9c, 0x3d, 0xc7, 0x01, 0x11, 0xa3, 0xb3,
-	0x90, 0xb2, 0x08, 0xdb, 0x6a, 0x74, 0x6e, 0x99, 0x39, 0x03, 0x6d, 0x41, 0x8d, 0xdf, 0xa5, 0x70,
-	0xd9, 0x32, 0xab, 0xfc, 0x6e, 0xea, 0x08, 0x24, 0x4b, 0x57, 0x14, 0xff, 0xc0, 0x08, 0x4f, 0xf0,
-	0x32, 0x5d, 0xa6, 0x29, 0x78, 0xe8, 0x25, 0xa0, 0x54, 0x89, 0x79, 0x41, 0x8a, 0x79, 0x35, 0xb9,
-	0xdd, 0x7e, 0x22, 0x39, 0xf5, 0x82, 0x04, 0xf7, 0xe6, 0x80, 0x0a, 0xcb, 0xb5, 0x43, 0x7a, 0xe9,
-	0xb9, 0x2c, 0x19, 0x63, 0x3f, 0x1b, 0xa8, 0x87, 0xca, 0x60, 0x98, 0x69, 0x0c, 0xa5, 0xc2, 0x09,
-	0xb6, 0xaf, 0xb0, 0x4b, 0xcc, 0x4d, 0x7b, 0x49, 0xc0, 0x8c, 0x7f, 0x6b, 0xd0, 0x29, 0x0e, 0xca,
-	0x68, 0x00, 0x10, 0x64, 0xf3, 0x6c, 0x72, 0x64, 0xbd, 0xf2, 0xa4, 0x6b, 0x16, 0x34, 0x3e, 0xb8,
-	0xb1, 0x14, 0xe1, 0xab, 0x5a, 0x86, 0x2f, 0xe3, 0x9f, 0x1a, 0x6c, 0xae, 0x4c, 0x1c, 0x8f, 0x01,
-	0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x8f, 0x59, 0x0e, 0xb1, 0x7d, 0x1c, 0x63, 0x91, 0x02, 0x79,
-	0x54, 0x4d, 0xb3, 0xeb, 0xb1, 0x51, 0xce, 0x34, 0xfe, 0x08, 0xcd, 0xd4, 0x5a, 0x94, 0x9f, 0x47,
-	0xed, 0x62, 0xf9, 0x79, 0xd4, 0x16, 0xe5, 0x57, 0xa8, 0xcb, 0xb5, 0x62, 0x5d, 0x1a, 0x97, 0xb0,
-	0xb9, 0xf2, 0x86, 0x40, 0xdf, 0x42, 0x9f, 0x11, 0xff, 0x52, 0x0e, 0x8f, 0x71, 0xa0, 0x62, 0x6b,
-	0xe5, 0x05, 0x67, 0x10, 0xb1, 0x21, 0x34, 0xa7, 0xb9, 0xa2, 0xb8, 0xef, 0x62, 0x18, 0xa2, 0xc9,
-	0xbd, 0x56, 0x84, 0x71, 0x01, 0x68, 0xf5, 0xd5, 0x81, 0xbe, 0x80, 0x9a, 0x7c, 0xe4, 0x3c, 0xda,
-	0xa6, 0x94, 0x58, 0xe2, 0x14, 0xc1, 0xce, 0x7b, 0x70, 0x8a, 0x60, 0xc7, 0xf8, 0x2b, 0xd4, 0x55,
-	0x0c, 0x71, 0x66, 0xa4, 0xf4, 0x0a, 0x34, 0x33, 0xfa, 0xbd, 0x18, 0xfb, 0xf0, 0x10, 0x61, 0x34,
-	0xa0, 0x26, 0x1f, 0x01, 0xc6, 0xdf, 0x00, 0xad, 0x8e, 0xba, 0xa2, 0x89, 0x31, 0x8e, 0x63, 0x6e,
-	0x95, 0xaf, 0x7e, 0x5b, 0x32, 0x4f, 0xd5, 0xfd, 0xff, 0x14, 0xda, 0x84, 0x3a, 0x56, 0xf9, 0x10,
-	0x5a, 0x84, 0x3a, 0x4a, 0x6e, 0x1c, 0xc1, 0xd6, 0x03, 0x03, 0x30, 0xda, 0x83, 0x66, 0x82, 0x32,
-	0x69, 0x2b, 0x5f, 0x81, 0xb3, 0x4c, 0xc1, 0x38, 0x86, 0xed, 0x87, 0x86, 0x4a, 0xb4, 0x9f, 0x63,
-	0xad, 0xf2, 0x91, 0x3d, 0x5a, 0x12, 0x45, 0x85, 0xd4, 0x19, 0x04, 0x1b, 0xff, 0xd5, 0xa0, 0x5b,
-	0x12, 0xe5, 0x68, 0xa1, 0x15, 0xd0, 0xe2, 0xfd, 0x00, 0xf3, 0x29, 0x40, 0x7e, 0x7b, 0x13, 0x94,
-	0x29, 0x70, 0xd0, 0xc7, 0xd0, 0xba, 0xf0, 0x43, 0xfb, 0x4a, 0xe4, 0x44, 0x5e, 0xac, 0xaa, 0xd9,
-	0x94, 0x8c, 0x53, 0x72, 0x8d, 0x76, 0xa1, 0x23, 0x52, 0xe5, 0x51, 0x4b, 0xb2, 0x12, 0x74, 0x01,
-	0x46, 0xae, 0xa7, 0xf4, 0x48, 0x70, 0x8c, 0xef, 0xe1, 0xc9, 0x83, 0x13, 0x30, 0x3a, 0x58, 0x99,
-	0x7e, 0x9e, 0x2e, 0x6d, 0x77, 0xac, 0xc4, 0x85, 0x19, 0xe8, 0x1c, 0x7a, 0x65, 0x19, 0xfa, 0x0a,
-	0xea, 0x2a, 0x1b, 0x49, 0xe1, 0x3f, 0x92, 0xb2, 0x44, 0xa9, 0xf8, 0x03, 0x23, 0x69, 0x67, 0x69,
-	0x73, 0xf8, 0x4b, 0xe6, 0x3a, 0x05, 0xf0, 0xe7, 0xb0, 0xc1, 0xef, 0xac, 0xd2, 0xf6, 0x92, 0x81,
-	0x91, 0xdf, 0x9d, 0x66, 0x1b, 0x2c, 0xbb, 0x2c, 0xfe, 0x13, 0x31, 0xbe, 0x84, 0x8d, 0xa5, 0x07,
-	0x87, 0xb8, 0x74, 0x24, 0x8e, 0xc3, 0x38, 0x39, 0x1f, 0x45, 0x18, 0xef, 0xa0, 0x95, 0x8d, 0x8d,
-	0xa2, 0x03, 0x15, 0x9a, 0x85, 0xfc, 0x16, 0x31, 0x6e, 0x49, 0xcc, 0xc4, 0x01, 0xa9, 0xf3, 0x4b,
-	0xc9, 0xf7, 0x4d, 0x4e, 0xbf, 0xf9, 0x13, 0xb4, 0x0b, 0x9d, 0x78, 0xf9, 0x71, 0xd0, 0x85, 0xd6,
-	0xd1, 0x9b, 0xb7, 0xc3, 0xef, 0xad, 0xd9, 0xe9, 0x71, 0x5f, 0x13, 0x6f, 0x80, 0xe9, 0x68, 0x3c,
-	0x3f, 0x9b, 0x9e, 0x9d, 0x4b, 0xce, 0xda, 0xc1, 0x3f, 0xa0, 0xae, 0x26, 0x21, 0xf4, 0x0d, 0x74,
-	0xd4, 0xd7, 0x29, 0x8f, 0x09, 0x0e, 0xd0, 0xca, 0xc5, 0xde, 0x59, 0xe1, 0x18, 0x95, 0x17, 0xda,
-	0x2b, 0x0d, 0x7d, 0x01, 0xd5, 0x13, 0x8f, 0xba, 0xa8, 0xfc, 0x48, 0xdf, 0x29, 0x93, 0x46, 0xe5,
-	0xe8, 0xab, 0xbf, 0xef, 0xb9, 0x1e, 0x5f, 0xdc, 0x5c, 0x88, 0x4e, 0xb3, 0xbf, 0xb8, 0x8f, 0x48,
-	0xac, 0xa6, 0xf2, 0xfd, 0x4b, 0x7c, 0x11, 0x7b, 0xf6, 0xbe, 0xfc, 0x2f, 0xc6, 0xf6, 0x95, 0xd9,
-	0x45, 0x5d, 0x92, 0x5f, 0xff, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x1d, 0xb3, 0x7e, 0x5f, 0x13,
-	0x00, 0x00,
+func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_cacb879a4502ff63) }
+
+var fileDescriptor_message_cacb879a4502ff63 = []byte{
+	// 2163 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x38, 0x5b, 0x6f, 0xdc, 0xc6,
+	0xd5, 0x4b, 0xed, 0x45, 0xbb, 0x67, 0x2f, 0x5a, 0x8d, 0x24, 0x9b, 0x91, 0x9d, 0x44, 0x1f, 0xbf,
+	0x3a, 0x71, 0x2a, 0x47, 0x72, 0x95, 0x06, 0x0d, 0x90, 0x36, 0x86, 0xb4, 0x5a, 0x6b, 0x17, 0xb6,
+	0x64, 0x95, 0x2b, 0xa3, 0x55, 0x5f, 0x08, 0x8a, 0x1c, 0x71, 0x59, 0xf1, 0x26, 0xce, 0x48, 0x91,
+	0xf2, 0xd6, 0xa7, 0x02, 0x6d, 0x81, 0x3e, 0xf6, 0xb9, 0x4f, 0xfd, 0x9b, 0xc5, 0x5c, 0x48, 0x0e,
+	0x77, 0x57, 0x06, 0x1c, 0xa0, 0x6f, 0x3c, 0xd7, 0x99, 0x73, 0xe6, 0x5c, 0x09, 0xeb, 0x5e, 0x4c,
+	0x88, 0x9f, 0xec, 0x86, 0x98, 0x10, 0xdb, 0xc3, 0x3b, 0x49, 0x1a, 0xd3, 0x18, 0x35, 0x04, 0x76,
+	0xf3, 0xb1, 0x13, 0x87, 0x61, 0x1c, 0xed, 0x3a, 0x71, 0x10, 0x60, 0x87, 0xfa, 0x71, 0x24, 0x18,
+	0x8c, 0x7f, 0x69, 0xd0, 0x1c, 0x46, 0xb7, 0x38, 0x88, 0x13, 0x8c, 0x74, 0x58, 0x4e, 0xec, 0xfb,
+	0x20, 0xb6, 0x5d, 0x5d, 0xdb, 0xd2, 0x9e, 0x77, 0xcc, 0x0c, 0x44, 0x4f, 0xa1, 0x45, 0x7c, 0x2f,
+	0xb2, 0xe9, 0x4d, 0x8a, 0xf5, 0x25, 0x4e, 0x2b, 0x10, 0xe8, 0x15, 0xac, 0x10, 0xec, 0xa4, 0x98,
+	0x5a, 0x58, 0xaa, 0xd2, 0xab, 0x5b, 0xda, 0xf3, 0xf6, 0xde, 0xa3, 0x1d, 0x71, 0xfe, 0xce, 0x84,
+	0x93, 0xb3, 0x83, 0xcc, 0x1e, 0x29, 0xc1, 0x08, 0x41, 0x6d, 0x1a, 0xda, 0x8e, 0x5e, 0xe3, 0x9a,
+	0xf9, 0xb7, 0x31, 0x82, 0x5e, 0x59, 0xea, 0xe7, 0x5e, 0xcf, 0xd8, 0x87, 0x86, 0xd0, 0x84, 0x5e,
+	0x40, 0xdf, 0x8f, 0x28, 0x4e, 0x23, 0x3b, 0x18, 0x46, 0x6e, 0x12, 0xfb, 0x11, 0xe5, 0xaa, 0x5a,
+	0xa3, 0x8a, 0x39, 0x47, 0x39, 0x68, 0xc1, 0xb2, 0x13, 0x47, 0x14, 0x47, 0xd4, 0xf8, 0x7b, 0x17,
+	0xba, 0x47, 0xdc, 0x94, 0x63, 0xe1, 0x5f, 0xb4, 0x0e, 0xf5, 0x28, 0x8e, 0x1c, 0xcc, 0xe5, 0x6b,
+	0xa6, 0x00, 0xd8, 0x15, 0x9d, 0xa9, 0x1d, 0x45, 0x38, 0x90, 0xd7, 0xc8, 0x40, 0xb4, 0x0d, 0x55,
+	0x6a, 0x7b, 0xdc, 0x2f, 0xbd, 0xbd, 0x4f, 0x32, 0xbf, 0x94, 0x74, 0xee, 0x9c, 0xd9, 0x9e, 0xc9,
+	0xb8, 0xd0, 0x37, 0xd0, 0xb2, 0x03, 0xff, 0x16, 0x5b, 0x21, 0xf1, 0xf4, 0x3a, 0x77, 0xe5, 0x7a,
+	0x26, 0xb2, 0xcf, 0x08, 0x52, 0x62, 0x54, 0x31, 0x9b, 0x9c, 0xf1, 0x98, 0x78, 0xe8, 0xd7, 0xb0,
+	0x1c, 0xe2, 0xd0, 0x4a, 0xf1, 0xb5, 0xde, 0xe0, 0x22, 0xf9, 0x29, 0xc7, 0x38, 0xbc, 0xc0, 0x29,
+	0x99, 0xfa, 0x89, 0x89, 0xaf, 0x6f, 0x30, 0xa1, 0xa3, 0x8a, 0xd9, 0x08, 0x71, 0x68, 0xe2, 0x6b,
+	0xf4, 0x6d, 0x26, 0x45, 0xf4, 0x65, 0x2e, 0xb5, 0xb9, 0x48, 0x8a, 0x24, 0x71, 0x44, 0x70, 0x2e,
+	0x46, 0xd0, 0x4b, 0x68, 0xba, 0x36, 0xb5, 0xf9, 0x05, 0x9b, 0x5c, 0x6e, 0x2d, 0x93, 0x3b, 0xb4,
+	0xa9, 0x5d, 0xdc, 0x6f, 0x99, 0xb1, 0xb1, 0xeb, 0x6d, 0x43, 0x7d, 0x8a, 0x83, 0x20, 0xd6, 0x5b,
+	0x65, 0x76, 0xe1, 0x82, 0x11, 0x23, 0x8d, 0x2a, 0xa6, 0xe0, 0x41, 0xbb, 0x52, 0xbd, 0xeb, 0x7b,
+	0x3a, 0x70, 0x7e, 0xa4, 0xaa, 0x3f, 0xf4, 0x3d, 0x61, 0x05, 0xd7, 0x7e, 0xe8, 0x7b, 0xf9, 0x7d,
+	0x98, 0xf5, 0xed, 0xf9, 0xfb, 0x14, 0x76, 0x73, 0x09, 0x61, 0x78, 0x9b, 0x4b, 0xdc, 0x24, 0xae,
+	0x4d, 0xb1, 0xde, 0x99, 0x3f, 0xe5, 0x3d, 0xa7, 0x8c, 0x2a, 0x26, 0xb8, 0x39, 0x84, 0x9e, 0x41,
+	0x1d, 0x87, 0x09, 0xbd, 0xd7, 0xbb, 0x5c, 0xa0, 0x9b, 0x09, 0x0c, 0x19, 0x92, 0x19, 0xc0, 0xa9,
+	0x68, 0x1b, 0x6a, 0x4e, 0x1c, 0x45, 0x7a, 0x8f, 0x73, 0x6d, 0x64, 0x5c, 0x83, 0x38, 0x8a, 0x86,
+	0x84, 0xda, 0x17, 0x81, 0x4f, 0xa6, 0xa3, 0x8a, 0xc9, 0x99, 0xd0, 0x1e, 0x00, 0xa1, 0x36, 0xc5,
+	0x96, 0x1f, 0x5d, 0xc6, 0xfa, 0x0a, 0x17, 0x59, 0xcd, 0x53, 0x87, 0x51, 0xc6, 0xd1, 0x25, 0xf3,
+	0x4e, 0x8b, 0x64, 0x00, 0x3a, 0x80, 0x9e, 0x90, 0x21, 0x91, 0x9d, 0x90, 0x69, 0x4c, 0xf5, 0x7e,
+	0xf9, 0xd1, 0x73, 0xb9, 0x89, 0x64, 0x18, 0x55, 0xcc, 0x2e, 0x17, 0xc9, 0x10, 0xe8, 0x18, 0xd6,
+	0x8a, 0x73, 0xad, 0xe4, 0x26, 0x08, 0xb8, 0xff, 0x56, 0xb9, 0xa2, 0xa7, 0x73, 0x8a, 0x4e, 0x6f,
+	0x82, 0xa0, 0x70, 0x64, 0x9f, 0xcc, 0xe0, 0xd1, 0x3e, 0x08, 0xfd, 0x56, 0x2a, 0x98, 0x74, 0x54,
+	0x0e, 0x28, 0x13, 0x87, 0x31, 0xc5, 0x5c, 0x5d, 0xa1, 0xa6, 0x43, 0x14, 0x18, 0x1d, 0x66, 0x56,
+	0xa5, 0x32, 0xe4, 0xf4, 0x35, 0xae, 0xe3, 0xc9, 0x42, 0x1d, 0x79, 0x54, 0x76, 0x89, 0x8a, 0x60,
+	0xbe, 0x09, 0xb0, 0xed, 0x8a, 0xe0, 0xe5, 0x21, 0xba, 0x5e, 0xf6, 0xcd, 0xdb, 0x9c, 0x5a, 0x04,
+	0x6a, 0xb7, 0x10, 0x61, 0xe1, 0xfa, 0x3d, 0x74, 0x13, 0x8c, 0x53, 0xcb, 0x77, 0x71, 0x44, 0x7d,
+	0x7a, 0xaf, 0x6f, 0x94, 0xd3, 0xf0, 0x14, 0xe3, 0x74, 0x2c, 0x69, 0xcc, 0x8c, 0x44, 0x81, 0x59,
+	0xb2, 0xdb, 0xce, 0x95, 0xfe, 0x88, 0x8b, 0x3c, 0xce, 0x33, 0xd7, 0xb9, 0x8a, 0xe2, 0x1f, 0x03,
+	0xec, 0x7a, 0x38, 0xc4, 0x11, 0x33, 0x9e, 0x71, 0xa1, 0x1f, 0x00, 0x92, 0xd4, 0xbf, 0x15, 0x5e,
+	0xd0, 0x1f, 0x97, 0x9d, 0x2f, 0xec, 0x3d, 0xbd, 0xa5, 0xe5, 0x28, 0x56, 0x24, 0xd0, 0x2b, 0x45,
+	0x9e, 0xe8, 0x3a, 0x97, 0xff, 0xf4, 0x01, 0xf9, 0xdc, 0x63, 0x8a, 0x08, 0x7a, 0x05, 0x1d, 0x09,
+	0x59, 0x2c, 0xd0, 0xf5, 0x4f, 0xca, 0xcf, 0x76, 0x2a, 0x68, 0xe5, 0xb4, 0x6e, 0x27, 0x05, 0x96,
+	0xbd, 0x5a, 0x72, 0x4b, 0xad, 0xa9, 0x4d, 0xa6, 0x98, 0xf0, 0x10, 0xda, 0x2c, 0x5b, 0x21, 0xcf,
+	0x1f, 0x71, 0x06, 0xe5, 0xed, 0x93, 0x5b, 0x9a, 0xe3, 0xd0, 0x70, 0x46, 0x0b, 0xd1, 0x9f, 0x94,
+	0x6d, 0x99, 0xd1, 0x92, 0xdb, 0xa2, 0xaa, 0x21, 0x2c, 0xaf, 0x09, 0x26, 0xc4, 0x8f, 0x23, 0xeb,
+	0x0a, 0xdf, 0xeb, 0x4f, 0xcb, 0x79, 0x3d, 0x11, 0xa4, 0x37, 0x98, 0x3d, 0x1a, 0x90, 0x1c, 0x42,
+	0x3f, 0x40, 0xef, 0x22, 0x88, 0x9d, 0x2b, 0xeb, 0x32, 0xb5, 0x3d, 0xf6, 0x3c, 0xfa, 0xa7, 0xe5,
+	0xd4, 0x3d, 0x60, 0xd4, 0xd7, 0x92, 0xc8, 0xe2, 0xe5, 0x42, 0x45, 0x18, 0x16, 0x54, 0xcf, 0x6c,
+	0x0f, 0x75, 0xa1, 0xf5, 0xfe, 0xe4, 0x70, 0xf8, 0x7a, 0x7c, 0x32, 0x3c, 0xec, 0x57, 0x50, 0x0b,
+	0xea, 0xc3, 0xe3, 0xd3, 0xb3, 0xf3, 0xbe, 0x86, 0x3a, 0xd0, 0x7c, 0x67, 0x1e, 0x59, 0xef, 0x4e,
+	0xde, 0x9e, 0xf7, 0x97, 0x18, 0xdf, 0x60, 0xb4, 0x7f, 0x22, 0xc0, 0x2a, 0xea, 0x43, 0x87, 0x83,
+	0xfb, 0x27, 0x87, 0xd6, 0x3b, 0xf3, 0xa8, 0x5f, 0x43, 0x2b, 0xd0, 0x16, 0x0c, 0x26, 0x47, 0xd4,
+	0xd5, 0x6e, 0xf4, 0x1f, 0x0d, 0x5a, 0x79, 0x56, 0xa2, 0x1d, 0x68, 0x51, 0x3f, 0xc4, 0x84, 0xda,
+	0x61, 0xc2, 0xbb, 0x4e, 0x7b, 0xaf, 0xaf, 0x46, 0xe9, 0x99, 0x1f, 0x62, 0xb3, 0x60, 0x41, 0x1b,
+	0xd0, 0x48, 0xae, 0x7c, 0xcb, 0x77, 0x79, 0x33, 0xea, 0x98, 0xf5, 0xe4, 0xca, 0x1f, 0xbb, 0xe8,
+	0x73, 0x68, 0xcb, 0x5e, 0x65, 0x1d, 0xef, 0x0f, 0x64, 0x2b, 0x06, 0x89, 0x3a, 0xde, 0x1f, 0xb0,
+	0x2a, 0x95, 0xa4, 0x71, 0x82, 0x53, 0xea, 0x63, 0xa2, 0xd7, 0xcb, 0x7e, 0x3d, 0xcd, 0x29, 0xa6,
+	0xc2, 0x65, 0xfc, 0x55, 0x03, 0x28, 0x48, 0xe8, 0xff, 0xa1, 0xcb, 0xc3, 0x3f, 0xb5, 0xa6, 0xd8,
+	0xf7, 0xa6, 0x54, 0x36, 0xcf, 0x8e, 0x40, 0x8e, 0x38, 0x0e, 0xfd, 0x1f, 0x74, 0x02, 0x7c, 0x49,
+	0x2d, 0xb5, 0x91, 0x36, 0xcd, 0x36, 0xc3, 0x0d, 0x04, 0x0a, 0xfd, 0x0a, 0xd8, 0xc5, 0xfc, 0xc8,
+	0x89, 0x5d, 0x4c, 0xf4, 0xea, 0x56, 0x55, 0x2d, 0x98, 0x83, 0x8c, 0x62, 0x2a, 0x4c, 0xc6, 0x3e,
+	0xac, 0xce, 0x55, 0x44, 0xf4, 0x02, 0x9a, 0x38, 0xe0, 0xc9, 0x48, 0x74, 0x6d, 0xab, 0xaa, 0x7a,
+	0x2e, 0x9f, 0x55, 0x72, 0x0e, 0xe3, 0x37, 0xb0, 0xbe, 0xa8, 0x16, 0xce, 0x7a, 0x4e, 0x9b, 0xf5,
+	0x9c, 0x71, 0x09, 0xdd, 0x52, 0xe1, 0x57, 0x9e, 0x40, 0x53, 0x9f, 0x60, 0x13, 0x9a, 0x79, 0xb9,
+	0x11, 0xe3, 0x43, 0x0e, 0x23, 0x03, 0xba, 0x34, 0x20, 0x96, 0x83, 0x53, 0x91, 0x22, 0xf2, 0xf1,
+	0xda, 0x34, 0x20, 0x03, 0x9c, 0xf2, 0xf8, 0x37, 0xde, 0x43, 0x47, 0x2d, 0x4b, 0x0f, 0x1d, 0x83,
+	0xa0, 0xc6, 0xd4, 0xc8, 0x23, 0xf8, 0x37, 0x3b, 0x3a, 0xc4, 0xd4, 0xe6, 0xf9, 0x2f, 0x34, 0xe7,
+	0xb0, 0x11, 0x42, 0x5b, 0xa9, 0x3e, 0x0f, 0x4f, 0x3e, 0x2e, 0xef, 0xca, 0x44, 0x5f, 0xda, 0xaa,
+	0xb2, 0xc9, 0x47, 0x82, 0x68, 0x07, 0x9a, 0x21, 0xf1, 0x2c, 0x7a, 0x2f, 0xc7, 0xc2, 0x5e, 0xd1,
+	0x9a, 0x99, 0x17, 0x8f, 0x89, 0x77, 0x76, 0x9f, 0x60, 0x73, 0x39, 0x14, 0x1f, 0x46, 0x0c, 0x6d,
+	0x65, 0x26, 0x78, 0xe0, 0x38, 0xf5, 0xbe, 0x4b, 0xe5, 0xfb, 0x7e, 0xf4, 0x81, 0x77, 0x00, 0x45,
+	0xbb, 0x7f, 0xe0, 0xbc, 0x5f, 0x40, 0x4d, 0x9e, 0xb5, 0x38, 0x4a, 0x6a, 0x3f, 0xeb, 0xe4, 0x00,
+	0xa0, 0x18, 0x67, 0xfe, 0xe7, 0x8e, 0xfd, 0x0e, 0xda, 0x4a, 0x11, 0x47, 0x5f, 0x95, 0xc7, 0xe9,
+	0xf6, 0xde, 0x4a, 0x2e, 0x2d, 0xd0, 0xf9, 0x7c, 0x6d, 0xfc, 0x63, 0x09, 0xba, 0xa5, 0xfa, 0x87,
+	0x1e, 0xc3, 0x32, 0xc1, 0xd7, 0x56, 0x74, 0x13, 0xca, 0xdb, 0x36, 0x08, 0xbe, 0x3e, 0xb9, 0x09,
+	0x59, 0xf6, 0x4a, 0x29, 0x11, 0xa6, 0xe2, 0x71, 0xda, 0x12, 0xc7, 0xc2, 0x54, 0x65, 0x21, 0xfe,
+	0x4f, 0xe2, 0xee, 0xb5, 0x9c, 0x65, 0xe2, 0xff, 0xc4, 0xa6, 0xac, 0x1e, 0x1f, 0xce, 0xb2, 0x62,
+	0x4c, 0x78, 0x3d, 0xea, 0x9a, 0x5d, 0x86, 0xcd, 0x2e, 0x41, 0xd0, 0x57, 0xd0, 0x4f, 0xec, 0xd4,
+	0xa7, 0xf7, 0x0a, 0x63, 0x9d, 0x33, 0xae, 0x08, 0x7c, 0xc1, 0xba, 0x0e, 0x75, 0x3f, 0x72, 0xf1,
+	0x1d, 0x9f, 0x8d, 0xbb, 0xa6, 0x00, 0x58, 0x18, 0x65, 0x92, 0x7c, 0xfc, 0xed, 0x98, 0x39, 0xcc,
+	0x1c, 0x9f, 0xe2, 0xc0, 0xbe, 0xc7, 0x2e, 0x9f, 0x70, 0x9b, 0x66, 0x06, 0x1a, 0xaf, 0x01, 0xcd,
+	0x37, 0x45, 0xf4, 0x72, 0xd6, 0x9f, 0x8f, 0x66, 0x3a, 0xe8, 0x9c, 0x5b, 0xcf, 0x61, 0x59, 0xe2,
+	0x1e, 0xf6, 0x27, 0xca, 0x03, 0x8f, 0x27, 0x2b, 0xfb, 0xe6, 0x0e, 0x54, 0x1b, 0x76, 0x95, 0xc7,
+	0x85, 0xda, 0x92, 0x8d, 0x7f, 0x2e, 0x41, 0xaf, 0x7c, 0x2c, 0xfa, 0x12, 0x56, 0x8a, 0xf5, 0xcf,
+	0x8a, 0xec, 0x50, 0x04, 0x5a, 0xcb, 0xec, 0x15, 0xe8, 0x13, 0x3b, 0xc4, 0x6c, 0x9b, 0x62, 0x54,
+	0x92, 0xd8, 0x8e, 0xd8, 0xa6, 0x5a, 0x66, 0x81, 0x40, 0x6b, 0x50, 0xa7, 0x77, 0x59, 0xf7, 0x68,
+	0x99, 0x35, 0x7a, 0x37, 0x76, 0x59, 0x61, 0xcf, 0x6e, 0x94, 0xfe, 0x48, 0x30, 0x95, 0xed, 0x23,
+	0xbb, 0xa6, 0xc9, 0x70, 0xe8, 0x05, 0xa0, 0x8c, 0x89, 0xf8, 0x61, 0xd6, 0x02, 0xea, 0xdc, 0xdc,
+	0xbe, 0xa4, 0x4c, 0xfc, 0x50, 0xb6, 0x81, 0x13, 0x40, 0xca, 0x75, 0x9d, 0x38, 0xba, 0xf4, 0x3d,
+	0x22, 0x37, 0x9b, 0xcf, 0x77, 0xc4, 0x3e, 0xbb, 0x33, 0xc8, 0x39, 0x06, 0x9c, 0xe1, 0xd4, 0x76,
+	0xae, 0x6c, 0x0f, 0x9b, 0xab, 0xce, 0x0c, 0x81, 0x18, 0x7f, 0xd3, 0xa0, 0xa3, 0xee, 0x4e, 0x68,
+	0x07, 0x20, 0xcc, 0x57, 0x1c, 0xf9, 0x64, 0xbd, 0xf2, 0xf2, 0x63, 0x2a, 0x1c, 0x1f, 0xdd, 0x67,
+	0xd5, 0x6a, 0x5e, 0x2b, 0x57, 0x73, 0xe3, 0x2f, 0x1a, 0xac, 0xce, 0x0d, 0xa1, 0x0f, 0xd5, 0xeb,
+	0x8f, 0x3d, 0xf8, 0x19, 0xf4, 0x7c, 0x62, 0xb9, 0xd8, 0x09, 0xec, 0xd4, 0x66, 0x2e, 0xe0, 0x4f,
+	0xd5, 0x34, 0xbb, 0x3e, 0x39, 0x2c, 0x90, 0xc6, 0x6f, 0xa1, 0x99, 0x49, 0xb3, 0xf0, 0xf3, 0x23,
+	0x47, 0x0d, 0x3f, 0x3f, 0x72, 0x58, 0xf8, 0x29, 0x71, 0xb9, 0xa4, 0xc6, 0xa5, 0x71, 0x09, 0xab,
+	0x73, 0x6b, 0x25, 0xfa, 0x1e, 0xfa, 0x04, 0x07, 0x97, 0x7c, 0x9f, 0x48, 0x43, 0x71, 0xb6, 0xb6,
+	0xa5, 0x2d, 0xac, 0x98, 0x2b, 0x8c, 0x73, 0x5c, 0x30, 0xb2, 0x0c, 0x65, 0xf3, 0x71, 0x24, 0xcb,
+	0x9c, 0x00, 0x8c, 0x0b, 0x40, 0xf3, 0x8b, 0x28, 0xfa, 0x02, 0xea, 0x7c, 0xef, 0x7d, 0xb0, 0x6b,
+	0x0b, 0x32, 0x2f, 0xdb, 0xd8, 0x76, 0x3f, 0x50, 0xb6, 0xb1, 0xed, 0x1a, 0x7f, 0x80, 0x86, 0x38,
+	0x83, 0xbd, 0x19, 0x2e, 0xfd, 0x18, 0x30, 0x73, 0xf8, 0x83, 0x2d, 0x67, 0xf1, 0x4c, 0x65, 0x2c,
+	0x43, 0x9d, 0xef, 0x85, 0xc6, 0x1f, 0x01, 0xcd, 0x6f, 0x3f, 0xac, 0xa7, 0x13, 0x6a, 0xa7, 0xd4,
+	0x2a, 0xa7, 0x7e, 0x9b, 0x23, 0x27, 0x22, 0xff, 0x3f, 0x83, 0x36, 0x8e, 0x5c, 0xab, 0xfc, 0x08,
+	0x2d, 0x1c, 0xb9, 0x82, 0x6e, 0x1c, 0xc0, 0xda, 0x82, 0x9d, 0x08, 0x6d, 0x43, 0x53, 0x56, 0x99,
+	0x6c, 0xb2, 0x99, 0xab, 0xee, 0x39, 0x83, 0x71, 0x04, 0xeb, 0x8b, 0xf6, 0x0c, 0xb4, 0x5b, 0xb4,
+	0x1e, 0xa1, 0x63, 0x63, 0x66, 0x14, 0x17, 0x8d, 0x2b, 0xef, 0x48, 0xc6, 0xbf, 0x35, 0xe8, 0x96,
+	0x48, 0x45, 0xb5, 0xd0, 0x94, 0x6a, 0xf1, 0xe1, 0x02, 0xf3, 0x19, 0x40, 0x91, 0xbd, 0xb2, 0xca,
+	0x28, 0x18, 0xf4, 0x04, 0x5a, 0x62, 0x52, 0x27, 0xf8, 0x9a, 0x27, 0x56, 0xcd, 0x6c, 0x72, 0xc4,
+	0x04, 0x5f, 0xa3, 0x2d, 0xe8, 0x30, 0x57, 0xf9, 0x91, 0xc5, 0x51, 0xb2, 0xba, 0x00, 0xc1, 0xd7,
+	0xe3, 0x88, 0x77, 0x30, 0xe3, 0x0d, 0x6c, 0x2c, 0x5c, 0x8a, 0xd0, 0xde, 0xdc, 0x30, 0xf8, 0x68,
+	0xc6, 0xdc, 0xa1, 0x20, 0x2b, 0x23, 0xe1, 0x39, 0xf4, 0xca, 0x34, 0xf4, 0x35, 0x34, 0x84, 0x37,
+	0x64, 0xe0, 0x3f, 0xe0, 0x32, 0xc9, 0xa4, 0xfe, 0xd3, 0x92, 0xdd, 0x5d, 0x82, 0xc6, 0x19, 0xac,
+	0x2f, 0x5a, 0x9b, 0xd8, 0xb4, 0x29, 0x82, 0x46, 0x18, 0xa8, 0x49, 0x03, 0x19, 0x8a, 0x1b, 0xc8,
+	0xfc, 0xc3, 0x22, 0x46, 0x90, 0x45, 0xbc, 0xb0, 0x20, 0x16, 0xd6, 0x8f, 0x61, 0x63, 0xe1, 0x1a,
+	0x85, 0x5e, 0x42, 0x43, 0x6c, 0x5e, 0xd2, 0x76, 0xbd, 0xb4, 0xf7, 0x28, 0x32, 0xa6, 0xe4, 0x33,
+	0x06, 0xd0, 0x9f, 0xa5, 0x15, 0x6f, 0x53, 0x44, 0xb3, 0x78, 0x1b, 0xd9, 0xca, 0x94, 0x91, 0x80,
+	0x7f, 0x1b, 0xdf, 0x02, 0x14, 0x2b, 0x19, 0xea, 0x43, 0x95, 0xed, 0x6c, 0xa2, 0xfa, 0xb1, 0x4f,
+	0x25, 0xb1, 0x96, 0xd4, 0xc4, 0xfa, 0x7d, 0xee, 0xf7, 0xac, 0xbb, 0x3d, 0x83, 0x15, 0x7a, 0x67,
+	0x95, 0xde, 0x5e, 0x2e, 0x17, 0xf4, 0x6e, 0x92, 0xbf, 0x7e, 0xd9, 0xdf, 0xea, 0x3f, 0x44, 0xe3,
+	0x4b, 0x58, 0x99, 0x59, 0xd0, 0x59, 0x45, 0xc2, 0x69, 0x1a, 0xa7, 0x32, 0x78, 0x05, 0x60, 0xbc,
+	0x87, 0x56, 0xbe, 0x62, 0x30, 0x9b, 0x94, 0x4e, 0xca, 0xbf, 0xd9, 0x19, 0xb7, 0x38, 0x65, 0x36,
+	0xc9, 0xe0, 0xce, 0xc0, 0x0f, 0x4d, 0xd9, 0xbf, 0xfc, 0x1d, 0xb4, 0x95, 0xa9, 0x6d, 0x76, 0x91,
+	0xec, 0x42, 0xeb, 0xe0, 0xed, 0xbb, 0xc1, 0x1b, 0xeb, 0x78, 0x72, 0xd4, 0xd7, 0xd8, 0xbe, 0x38,
+	0x3e, 0x1c, 0x9e, 0x9c, 0x8d, 0xcf, 0xce, 0x39, 0x66, 0x69, 0xef, 0xcf, 0xd0, 0x10, 0x53, 0x33,
+	0xfa, 0x0e, 0x3a, 0xe2, 0x6b, 0x42, 0x53, 0x6c, 0x87, 0x68, 0xae, 0xea, 0x6d, 0xce, 0x61, 0x8c,
+	0xca, 0x73, 0xed, 0xa5, 0x86, 0xbe, 0x80, 0xda, 0xa9, 0x1f, 0x79, 0xa8, 0xfc, 0x53, 0x6b, 0xb3,
+	0x0c, 0x1a, 0x95, 0x83, 0xaf, 0xff, 0xb4, 0xed, 0xf9, 0x74, 0x7a, 0x73, 0xc1, 0xda, 0xf0, 0xee,
+	0xf4, 0x3e, 0xc1, 0xa9, 0xd8, 0xe0, 0x76, 0x2f, 0xed, 0x8b, 0xd4, 0x77, 0x76, 0xf9, 0xbf, 0x65,
+	0xb2, 0x2b, 0xc4, 0x2e, 0x1a, 0x1c, 0xfc, 0xe6, 0xbf, 0x03, 0x00, 0x59, 0x6a, 0x66, 0xa0, 0xa3,
+	0x16, 0x00, 0x00,
 }
@@ -48,7 +48,7 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 0}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{5, 0}
 }
 
 // SeekErrorTolerance indicates to the server how block provider errors should be tolerated.  By default,
@@ -79,7 +79,7 @@ func (x SeekInfo_SeekErrorResponse) String() string {
 	return proto.EnumName(SeekInfo_SeekErrorResponse_name, int32(x))
 }
 func (SeekInfo_SeekErrorResponse) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 1}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{5, 1}
 }
 
 type BroadcastResponse struct {
@@ -96,7 +96,7 @@ func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{0}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -140,7 +140,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{1}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -170,7 +170,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{2}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{3}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -243,7 +243,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{4}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -415,6 +415,7 @@ type SeekInfo struct {
 	Stop                 *SeekPosition              `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
 	Behavior             SeekInfo_SeekBehavior      `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
 	ErrorResponse        SeekInfo_SeekErrorResponse `protobuf:"varint,4,opt,name=error_response,json=errorResponse,proto3,enum=orderer.SeekInfo_SeekErrorResponse" json:"error_response,omitempty"`
+	ChaincodeEventFilter *ChaincodeEventFilter      `protobuf:"bytes,5,opt,name=chaincode_event_filter,json=chaincodeEventFilter,proto3" json:"chaincode_event_filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -424,7 +425,7 @@ func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -472,6 +473,62 @@ func (m *SeekInfo) GetErrorResponse() SeekInfo_SeekErrorResponse {
 	return SeekInfo_STRICT
 }
 
+func (m *SeekInfo) GetChaincodeEventFilter() *ChaincodeEventFilter {
+	if m != nil {
+		return m.ChaincodeEventFilter
+	}
+	return nil
+}
+
+// ChaincodeEventFilter restricts the blocks returned by the deliver service of a peer to
+// the ones containing a valid transaction which set the given event of the given chaincode.
+// The other blocks in the requested range are skipped. The ordering service ignores it.
+type ChaincodeEventFilter struct {
+	ChaincodeId          string   `protobuf:"bytes,1,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
+	EventName            string   `protobuf:"bytes,2,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventFilter) Reset()         { *m = ChaincodeEventFilter{} }
+func (m *ChaincodeEventFilter) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventFilter) ProtoMessage()    {}
+func (*ChaincodeEventFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{6}
+}
+func (m *ChaincodeEventFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventFilter.Unmarshal(m, b)
+}
+func (m *ChaincodeEventFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventFilter.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventFilter.Merge(dst, src)
+}
+func (m *ChaincodeEventFilter) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventFilter.Size(m)
+}
+func (m *ChaincodeEventFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventFilter proto.InternalMessageInfo
+
+func (m *ChaincodeEventFilter) GetChaincodeId() string {
+	if m != nil {
+		return m.ChaincodeId
+	}
+	return ""
+}
+
+func (m *ChaincodeEventFilter) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
@@ -486,7 +543,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{6}
+	return fileDescriptor_ab_4664ee1bb1f37ecf, []int{7}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -619,6 +676,7 @@ func init() {
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*ChaincodeEventFilter)(nil), "orderer.ChaincodeEventFilter")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 	proto.RegisterEnum("orderer.SeekInfo_SeekErrorResponse", SeekInfo_SeekErrorResponse_name, SeekInfo_SeekErrorResponse_value)
@@ -797,43 +855,48 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_237e854d47750248) }
-
-var fileDescriptor_ab_237e854d47750248 = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0xc7, 0x93, 0xd1, 0x65, 0xdb, 0xd9, 0xfa, 0xe5, 0x69, 0x53, 0xd5, 0x0b, 0x34, 0x05, 0x0d,
-	0x8a, 0x80, 0x64, 0x2a, 0x12, 0x17, 0x80, 0x84, 0x9a, 0xad, 0x55, 0x0b, 0x55, 0x8b, 0xdc, 0xec,
-	0x02, 0x6e, 0xa2, 0x24, 0x75, 0xdb, 0xb0, 0x36, 0x8e, 0x9c, 0xb4, 0x68, 0x4f, 0xc1, 0x8b, 0xf0,
-	0x44, 0x3c, 0x0d, 0xf2, 0x47, 0xda, 0x75, 0x1b, 0x5c, 0x25, 0xe7, 0xf8, 0x77, 0xce, 0xff, 0x6f,
-	0x1f, 0x1b, 0x2a, 0x94, 0x8d, 0x09, 0x23, 0xcc, 0xf6, 0x03, 0x2b, 0x61, 0x34, 0xa3, 0x68, 0x4f,
-	0x65, 0xea, 0xc7, 0x21, 0x5d, 0x2c, 0x68, 0x6c, 0xcb, 0x8f, 0x5c, 0x35, 0x87, 0x50, 0x75, 0x18,
-	0xf5, 0xc7, 0xa1, 0x9f, 0x66, 0x98, 0xa4, 0x09, 0x8d, 0x53, 0x82, 0x9e, 0x83, 0x91, 0x66, 0x7e,
-	0xb6, 0x4c, 0x6b, 0xfa, 0x99, 0xde, 0x28, 0x35, 0x4b, 0x96, 0xaa, 0x19, 0x89, 0x2c, 0x56, 0xab,
-	0x08, 0x41, 0x21, 0x8a, 0x27, 0xb4, 0xb6, 0x73, 0xa6, 0x37, 0x0e, 0xb0, 0xf8, 0x37, 0x8f, 0x00,
-	0x46, 0x84, 0xdc, 0x0c, 0xc8, 0x4f, 0x92, 0x66, 0x79, 0x34, 0x9c, 0x8f, 0x79, 0xf4, 0x02, 0x8a,
-	0x3c, 0x1a, 0x25, 0x24, 0x8c, 0x26, 0x11, 0x19, 0xa3, 0x53, 0x30, 0xe2, 0xe5, 0x22, 0x20, 0x4c,
-	0x08, 0x15, 0xb0, 0x8a, 0xcc, 0xdf, 0x3a, 0x1c, 0x71, 0xf2, 0x2b, 0x4d, 0xa3, 0x2c, 0xa2, 0x31,
-	0x7a, 0x03, 0x46, 0x2c, 0x3a, 0x0a, 0xf0, 0xb0, 0x79, 0x6c, 0xa9, 0x5d, 0x59, 0x1b, 0xb1, 0xae,
-	0x86, 0x15, 0xc4, 0x71, 0x2a, 0x24, 0x85, 0xb5, 0xfb, 0xb8, 0x74, 0xc3, 0x71, 0x09, 0xa1, 0x77,
-	0x70, 0x90, 0xe6, 0x9e, 0x6a, 0x4f, 0x44, 0xc5, 0xe9, 0x56, 0xc5, 0xda, 0x71, 0x57, 0xc3, 0x1b,
-	0xd4, 0x31, 0xa0, 0xe0, 0xde, 0x26, 0xc4, 0xfc, 0xb3, 0x03, 0xfb, 0x1c, 0xeb, 0xc5, 0x13, 0x8a,
-	0x5e, 0xc1, 0x6e, 0x9a, 0xf9, 0x2c, 0x77, 0x7a, 0xb2, 0xd5, 0x28, 0xdf, 0x10, 0x96, 0x0c, 0x7a,
-	0x09, 0x85, 0x34, 0xa3, 0x89, 0xb2, 0xf9, 0x0f, 0x56, 0x20, 0xe8, 0x3d, 0xec, 0x07, 0x64, 0xe6,
-	0xaf, 0x22, 0xca, 0x84, 0xc7, 0x52, 0xf3, 0xe9, 0x16, 0xce, 0xc5, 0xc5, 0x8f, 0xa3, 0x28, 0xbc,
-	0xe6, 0xd1, 0x67, 0x28, 0x11, 0xc6, 0x28, 0xf3, 0x98, 0x1a, 0x71, 0xad, 0x20, 0x3a, 0x3c, 0x7b,
-	0xbc, 0x43, 0x9b, 0xb3, 0xf9, 0x6d, 0xc0, 0x45, 0x72, 0x37, 0x34, 0x3f, 0xca, 0xd1, 0xe4, 0x2a,
-	0xe8, 0x04, 0xaa, 0x4e, 0x7f, 0x78, 0xf9, 0xc5, 0xbb, 0x1e, 0xb8, 0xbd, 0xbe, 0x87, 0xdb, 0xad,
-	0xab, 0x6f, 0x15, 0x8d, 0xa7, 0x3b, 0xad, 0x5e, 0xdf, 0xeb, 0x75, 0xbc, 0xc1, 0xd0, 0x55, 0x69,
-	0xdd, 0xbc, 0x80, 0xea, 0x03, 0x05, 0x04, 0x60, 0x8c, 0x5c, 0xdc, 0xbb, 0x74, 0x2b, 0x1a, 0x2a,
-	0xc3, 0xa1, 0xd3, 0x1e, 0xb9, 0x5e, 0xbb, 0xd3, 0x19, 0x62, 0xb7, 0xa2, 0x9b, 0x3f, 0xa0, 0x7c,
-	0x45, 0xe6, 0xd1, 0x8a, 0x6c, 0xf8, 0xc6, 0xff, 0xef, 0x27, 0x9f, 0xac, 0xba, 0xa1, 0xe7, 0xb0,
-	0x1b, 0xcc, 0x69, 0x78, 0xa3, 0x0e, 0xb8, 0x98, 0x83, 0x0e, 0x4f, 0x76, 0x35, 0x2c, 0x57, 0xf3,
-	0x41, 0x36, 0x7f, 0xe9, 0x50, 0x6e, 0x65, 0x74, 0x11, 0x85, 0xeb, 0x47, 0x81, 0x3e, 0xc1, 0xc1,
-	0x26, 0xa8, 0xe4, 0x0d, 0xda, 0xf1, 0x8a, 0xcc, 0x69, 0x42, 0xea, 0xf5, 0xf5, 0x11, 0x3e, 0x78,
-	0x47, 0xa6, 0xd6, 0xd0, 0x2f, 0x74, 0xf4, 0x01, 0xf6, 0xd4, 0x06, 0x1e, 0x29, 0xaf, 0xad, 0xcb,
-	0xef, 0x6d, 0x52, 0x16, 0x3b, 0xd7, 0x70, 0x4e, 0xd9, 0xd4, 0x9a, 0xdd, 0x26, 0x84, 0xcd, 0xc9,
-	0x78, 0x4a, 0x98, 0x35, 0xf1, 0x03, 0x16, 0x85, 0xf2, 0xfd, 0xa6, 0x79, 0xf9, 0xf7, 0xd7, 0xd3,
-	0x28, 0x9b, 0x2d, 0x03, 0x2e, 0x60, 0xdf, 0xa1, 0x6d, 0x49, 0xdb, 0x92, 0xb6, 0x15, 0x1d, 0x18,
-	0x22, 0x7e, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0x48, 0x6c, 0xc9, 0x45, 0x2f, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_4664ee1bb1f37ecf) }
+
+var fileDescriptor_ab_4664ee1bb1f37ecf = []byte{
+	// 631 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0x6d, 0x4f, 0x13, 0x41,
+	0x10, 0xc7, 0x7b, 0x50, 0x0a, 0x1d, 0x4a, 0x29, 0xcb, 0x43, 0x2e, 0x24, 0x18, 0x3c, 0x83, 0xd6,
+	0xa8, 0x2d, 0xa9, 0x89, 0x2f, 0xd4, 0xc4, 0x50, 0x68, 0x43, 0x95, 0xb4, 0x66, 0x7b, 0x24, 0xea,
+	0x9b, 0xcb, 0x3d, 0x4c, 0xe9, 0x4a, 0x7b, 0x7b, 0xd9, 0x3b, 0x6a, 0xf8, 0x14, 0x7e, 0x11, 0x13,
+	0xbf, 0xa2, 0xd9, 0xbd, 0xbd, 0x2b, 0x48, 0xc3, 0xab, 0xde, 0xfc, 0xf7, 0x37, 0x33, 0xff, 0xc9,
+	0xce, 0x16, 0x6a, 0x5c, 0x04, 0x28, 0x50, 0x34, 0x5d, 0xaf, 0x11, 0x09, 0x9e, 0x70, 0xb2, 0xaa,
+	0x95, 0xfd, 0x6d, 0x9f, 0x4f, 0xa7, 0x3c, 0x6c, 0xa6, 0x3f, 0xe9, 0xa9, 0x35, 0x80, 0xad, 0xb6,
+	0xe0, 0x6e, 0xe0, 0xbb, 0x71, 0x42, 0x31, 0x8e, 0x78, 0x18, 0x23, 0x79, 0x0e, 0xa5, 0x38, 0x71,
+	0x93, 0x9b, 0xd8, 0x34, 0x0e, 0x8d, 0x7a, 0xb5, 0x55, 0x6d, 0xe8, 0x9c, 0xa1, 0x52, 0xa9, 0x3e,
+	0x25, 0x04, 0x8a, 0x2c, 0x1c, 0x71, 0x73, 0xe9, 0xd0, 0xa8, 0x97, 0xa9, 0xfa, 0xb6, 0x2a, 0x00,
+	0x43, 0xc4, 0xeb, 0x3e, 0xfe, 0xc2, 0x38, 0xc9, 0xa2, 0xc1, 0x24, 0x90, 0xd1, 0x0b, 0xd8, 0x90,
+	0xd1, 0x30, 0x42, 0x9f, 0x8d, 0x18, 0x06, 0x64, 0x0f, 0x4a, 0xe1, 0xcd, 0xd4, 0x43, 0xa1, 0x1a,
+	0x15, 0xa9, 0x8e, 0xac, 0x3f, 0x06, 0x54, 0x24, 0xf9, 0x95, 0xc7, 0x2c, 0x61, 0x3c, 0x24, 0x6f,
+	0xa0, 0x14, 0xaa, 0x8a, 0x0a, 0x5c, 0x6f, 0x6d, 0x37, 0xf4, 0x54, 0x8d, 0x79, 0xb3, 0xf3, 0x02,
+	0xd5, 0x90, 0xc4, 0xb9, 0x6a, 0x69, 0x2e, 0x2d, 0xc0, 0x53, 0x37, 0x12, 0x4f, 0x21, 0xf2, 0x0e,
+	0xca, 0x71, 0xe6, 0xc9, 0x5c, 0x56, 0x19, 0x7b, 0xf7, 0x32, 0x72, 0xc7, 0xe7, 0x05, 0x3a, 0x47,
+	0xdb, 0x25, 0x28, 0xda, 0xb7, 0x11, 0x5a, 0x7f, 0x97, 0x61, 0x4d, 0x62, 0xbd, 0x70, 0xc4, 0xc9,
+	0x2b, 0x58, 0x89, 0x13, 0x57, 0x64, 0x4e, 0x77, 0xef, 0x15, 0xca, 0x06, 0xa2, 0x29, 0x43, 0x5e,
+	0x42, 0x31, 0x4e, 0x78, 0x64, 0x2e, 0x3d, 0xc6, 0x2a, 0x84, 0xbc, 0x87, 0x35, 0x0f, 0xc7, 0xee,
+	0x8c, 0x71, 0xa1, 0x3c, 0x56, 0x5b, 0x4f, 0xee, 0xe1, 0xb2, 0xb9, 0xfa, 0x68, 0x6b, 0x8a, 0xe6,
+	0x3c, 0xf9, 0x0c, 0x55, 0x14, 0x82, 0x0b, 0x47, 0xe8, 0x2b, 0x36, 0x8b, 0xaa, 0xc2, 0xb3, 0xc5,
+	0x15, 0x3a, 0x92, 0xcd, 0xb6, 0x81, 0x6e, 0xe0, 0xdd, 0x90, 0x0c, 0x61, 0xcf, 0x1f, 0xbb, 0x2c,
+	0xf4, 0x79, 0x80, 0x0e, 0xce, 0x30, 0x4c, 0x9c, 0x11, 0x9b, 0x24, 0x28, 0xcc, 0x15, 0x35, 0xc4,
+	0x41, 0x5e, 0xf3, 0x34, 0xc3, 0x3a, 0x92, 0xea, 0x2a, 0x88, 0xee, 0xf8, 0x0b, 0x54, 0xeb, 0x23,
+	0x54, 0xee, 0x5a, 0x27, 0xbb, 0xb0, 0xd5, 0xbe, 0x18, 0x9c, 0x7e, 0x71, 0x2e, 0xfb, 0x76, 0xef,
+	0xc2, 0xa1, 0x9d, 0x93, 0xb3, 0xef, 0xb5, 0x82, 0x94, 0xbb, 0x27, 0xbd, 0x0b, 0xa7, 0xd7, 0x75,
+	0xfa, 0x03, 0x5b, 0xcb, 0x86, 0x75, 0x0c, 0x5b, 0x0f, 0x6c, 0x13, 0x80, 0xd2, 0xd0, 0xa6, 0xbd,
+	0x53, 0xbb, 0x56, 0x20, 0x9b, 0xb0, 0xde, 0xee, 0x0c, 0x6d, 0xa7, 0xd3, 0xed, 0x0e, 0xa8, 0x5d,
+	0x33, 0xac, 0x6f, 0xb0, 0xb3, 0xc8, 0x1d, 0x79, 0x0a, 0x95, 0xf9, 0x70, 0x2c, 0x50, 0x77, 0x58,
+	0xa6, 0xeb, 0xb9, 0xd6, 0x0b, 0xc8, 0x01, 0x40, 0x3a, 0x75, 0xe8, 0x4e, 0x51, 0xaf, 0x7e, 0x59,
+	0x29, 0x7d, 0x77, 0x8a, 0xd6, 0x4f, 0xd8, 0x3c, 0xc3, 0x09, 0x9b, 0xe1, 0xdc, 0x49, 0xfd, 0xf1,
+	0xe7, 0x24, 0x17, 0x51, 0x3f, 0xa8, 0x23, 0x58, 0xf1, 0x26, 0xdc, 0xbf, 0xd6, 0xfb, 0xb0, 0x91,
+	0x81, 0x6d, 0x29, 0x9e, 0x17, 0x68, 0x7a, 0x9a, 0xed, 0x5d, 0xeb, 0xb7, 0x01, 0x9b, 0x27, 0x09,
+	0x9f, 0x32, 0x3f, 0x7f, 0xc3, 0xe4, 0x13, 0x94, 0xe7, 0x41, 0x2d, 0x2b, 0xd0, 0x09, 0x67, 0x38,
+	0xe1, 0x11, 0xee, 0xef, 0xe7, 0xb7, 0xf3, 0xe0, 0xd9, 0x5b, 0x85, 0xba, 0x71, 0x6c, 0x90, 0x0f,
+	0xb0, 0xaa, 0x07, 0x58, 0x90, 0x6e, 0xe6, 0xe9, 0xff, 0x0d, 0x99, 0x26, 0xb7, 0x2f, 0xe1, 0x88,
+	0x8b, 0xab, 0xc6, 0xf8, 0x36, 0x42, 0x31, 0xc1, 0xe0, 0x0a, 0x45, 0x63, 0xe4, 0x7a, 0x82, 0xf9,
+	0xe9, 0xdf, 0x4d, 0x9c, 0xa5, 0xff, 0x78, 0x7d, 0xc5, 0x92, 0xf1, 0x8d, 0x27, 0x1b, 0x34, 0xef,
+	0xd0, 0xcd, 0x94, 0x6e, 0xa6, 0x74, 0x53, 0xd3, 0x5e, 0x49, 0xc5, 0x6f, 0xff, 0x0d, 0x00, 0xc7,
+	0xd6, 0x7b, 0x59, 0xde, 0x04, 0x00, 0x00,
 }
@@ -57,7 +57,7 @@ func (x ServerStatus_StatusCode) String() string {
 	return proto.EnumName(ServerStatus_StatusCode_name, int32(x))
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0, 0}
+	return fileDescriptor_admin_21addb49bc657c99, []int{0, 0}
 }
 
 type ServerStatus struct {
@@ -71,7 +71,7 @@ func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
 func (m *ServerStatus) String() string { return proto.CompactTextString(m) }
 func (*ServerStatus) ProtoMessage()    {}
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0}
+	return fileDescriptor_admin_21addb49bc657c99, []int{0}
 }
 func (m *ServerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerStatus.Unmarshal(m, b)
@@ -110,7 +110,7 @@ func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
 func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
 func (*LogLevelRequest) ProtoMessage()    {}
 func (*LogLevelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{1}
+	return fileDescriptor_admin_21addb49bc657c99, []int{1}
 }
 func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelRequest.Unmarshal(m, b)
@@ -156,7 +156,7 @@ func (m *LogLevelResponse) Reset()         { *m = LogLevelResponse{} }
 func (m *LogLevelResponse) String() string { return proto.CompactTextString(m) }
 func (*LogLevelResponse) ProtoMessage()    {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{2}
+	return fileDescriptor_admin_21addb49bc657c99, []int{2}
 }
 func (m *LogLevelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelResponse.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *LogSpecRequest) Reset()         { *m = LogSpecRequest{} }
 func (m *LogSpecRequest) String() string { return proto.CompactTextString(m) }
 func (*LogSpecRequest) ProtoMessage()    {}
 func (*LogSpecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{3}
+	return fileDescriptor_admin_21addb49bc657c99, []int{3}
 }
 func (m *LogSpecRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecRequest.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *LogSpecResponse) Reset()         { *m = LogSpecResponse{} }
 func (m *LogSpecResponse) String() string { return proto.CompactTextString(m) }
 func (*LogSpecResponse) ProtoMessage()    {}
 func (*LogSpecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{4}
+	return fileDescriptor_admin_21addb49bc657c99, []int{4}
 }
 func (m *LogSpecResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecResponse.Unmarshal(m, b)
@@ -274,10 +274,51 @@ func (m *LogSpecResponse) GetError() string {
 	return ""
 }
 
+// UnjoinChannelRequest requests the removal of a channel, along with all
+// its data, from the peer
+type UnjoinChannelRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnjoinChannelRequest) Reset()         { *m = UnjoinChannelRequest{} }
+func (m *UnjoinChannelRequest) String() string { return proto.CompactTextString(m) }
+func (*UnjoinChannelRequest) ProtoMessage()    {}
+func (*UnjoinChannelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_21addb49bc657c99, []int{5}
+}
+func (m *UnjoinChannelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnjoinChannelRequest.Unmarshal(m, b)
+}
+func (m *UnjoinChannelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnjoinChannelRequest.Marshal(b, m, deterministic)
+}
+func (dst *UnjoinChannelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnjoinChannelRequest.Merge(dst, src)
+}
+func (m *UnjoinChannelRequest) XXX_Size() int {
+	return xxx_messageInfo_UnjoinChannelRequest.Size(m)
+}
+func (m *UnjoinChannelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnjoinChannelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnjoinChannelRequest proto.InternalMessageInfo
+
+func (m *UnjoinChannelRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
 	//	*AdminOperation_LogSpecReq
+	//	*AdminOperation_UnjoinChannelReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -288,7 +329,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+	return fileDescriptor_admin_21addb49bc657c99, []int{6}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -320,10 +361,16 @@ type AdminOperation_LogSpecReq struct {
 	LogSpecReq *LogSpecRequest `protobuf:"bytes,2,opt,name=logSpecReq,proto3,oneof"`
 }
 
+type AdminOperation_UnjoinChannelReq struct {
+	UnjoinChannelReq *UnjoinChannelRequest `protobuf:"bytes,3,opt,name=unjoinChannelReq,proto3,oneof"`
+}
+
 func (*AdminOperation_LogReq) isAdminOperation_Content() {}
 
 func (*AdminOperation_LogSpecReq) isAdminOperation_Content() {}
 
+func (*AdminOperation_UnjoinChannelReq) isAdminOperation_Content() {}
+
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
 		return m.Content
@@ -345,11 +392,19 @@ func (m *AdminOperation) GetLogSpecReq() *LogSpecRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetUnjoinChannelReq() *UnjoinChannelRequest {
+	if x, ok := m.GetContent().(*AdminOperation_UnjoinChannelReq); ok {
+		return x.UnjoinChannelReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
 		(*AdminOperation_LogSpecReq)(nil),
+		(*AdminOperation_UnjoinChannelReq)(nil),
 	}
 }
 
@@ -367,6 +422,11 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogSpecReq); err != nil {
 			return err
 		}
+	case *AdminOperation_UnjoinChannelReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UnjoinChannelReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -393,6 +453,14 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogSpecReq{msg}
 		return true, err
+	case 3: // content.unjoinChannelReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(UnjoinChannelRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_UnjoinChannelReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -412,6 +480,11 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_UnjoinChannelReq:
+		s := proto.Size(x.UnjoinChannelReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,6 +498,7 @@ func init() {
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*LogSpecRequest)(nil), "protos.LogSpecRequest")
 	proto.RegisterType((*LogSpecResponse)(nil), "protos.LogSpecResponse")
+	proto.RegisterType((*UnjoinChannelRequest)(nil), "protos.UnjoinChannelRequest")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
@@ -448,6 +522,7 @@ type AdminClient interface {
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
 	SetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
+	UnjoinChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type adminClient struct {
@@ -521,6 +596,15 @@ func (c *adminClient) SetLogSpec(ctx context.Context, in *common.Envelope, opts
 	return out, nil
 }
 
+func (c *adminClient) UnjoinChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.Admin/UnjoinChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 type AdminServer interface {
 	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
@@ -530,6 +614,7 @@ type AdminServer interface {
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
 	GetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
 	SetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
+	UnjoinChannel(context.Context, *common.Envelope) (*empty.Empty, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -662,6 +747,24 @@ func _Admin_SetLogSpec_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_UnjoinChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UnjoinChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/UnjoinChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UnjoinChannel(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -694,48 +797,55 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogSpec",
 			Handler:    _Admin_SetLogSpec_Handler,
 		},
+		{
+			MethodName: "UnjoinChannel",
+			Handler:    _Admin_UnjoinChannel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
-func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_b2904393863b6bc5) }
-
-var fileDescriptor_admin_b2904393863b6bc5 = []byte{
-	// 555 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x37, 0xda, 0x92, 0xd3, 0xd1, 0x05, 0x33, 0x6d, 0xa5, 0x13, 0x02, 0xe5, 0x0a, 0x84,
-	0x94, 0x88, 0x22, 0x34, 0x76, 0xc1, 0x45, 0x4b, 0x43, 0x87, 0xe8, 0xd2, 0x2a, 0x59, 0x85, 0x40,
-	0x42, 0x55, 0x9a, 0x9e, 0x79, 0x15, 0x6e, 0x9c, 0x39, 0x6e, 0xa5, 0xbd, 0x01, 0xcf, 0xc1, 0x23,
-	0xf0, 0x84, 0x28, 0x76, 0xc2, 0x2a, 0x56, 0x2e, 0xd8, 0xae, 0xec, 0x73, 0xfc, 0x7d, 0xdf, 0xf9,
-	0xb3, 0x0d, 0x66, 0x82, 0x28, 0x9c, 0x70, 0xb6, 0x98, 0xc7, 0x76, 0x22, 0xb8, 0xe4, 0xa4, 0xaa,
-	0x96, 0xb4, 0x75, 0x48, 0x39, 0xa7, 0x0c, 0x1d, 0x65, 0x4e, 0x97, 0xe7, 0x0e, 0x2e, 0x12, 0x79,
-	0xa5, 0x41, 0xad, 0x47, 0x11, 0x5f, 0x2c, 0x78, 0xec, 0xe8, 0x45, 0x3b, 0xad, 0x9f, 0x65, 0xd8,
-	0x09, 0x50, 0xac, 0x50, 0x04, 0x32, 0x94, 0xcb, 0x94, 0x1c, 0x41, 0x35, 0x55, 0xbb, 0x66, 0xf9,
-	0x59, 0xf9, 0x79, 0xa3, 0xfd, 0x54, 0x03, 0x53, 0x7b, 0x1d, 0x65, 0xeb, 0xe5, 0x3d, 0x9f, 0xa1,
-	0x9f, 0xc3, 0xad, 0x2f, 0x00, 0xd7, 0x5e, 0xf2, 0x00, 0x8c, 0xb1, 0xd7, 0x73, 0x3f, 0x7c, 0xf4,
-	0xdc, 0x9e, 0x59, 0x22, 0x75, 0xa8, 0x05, 0x67, 0x1d, 0xff, 0xcc, 0xed, 0x99, 0x65, 0x6d, 0x0c,
-	0x47, 0x23, 0xb7, 0x67, 0x6e, 0x11, 0x80, 0xea, 0xa8, 0x33, 0x0e, 0xdc, 0x9e, 0xb9, 0x4d, 0x0c,
-	0xa8, 0xb8, 0xbe, 0x3f, 0xf4, 0xcd, 0x7b, 0x19, 0x66, 0xec, 0x7d, 0xf2, 0x86, 0x9f, 0x3d, 0xb3,
-	0x62, 0x9d, 0xc2, 0xee, 0x80, 0xd3, 0x01, 0xae, 0x90, 0xf9, 0x78, 0xb9, 0xc4, 0x54, 0x92, 0x27,
-	0x00, 0x8c, 0xd3, 0xc9, 0x82, 0xcf, 0x96, 0x0c, 0x55, 0xaa, 0x86, 0x6f, 0x30, 0x4e, 0x4f, 0x95,
-	0x83, 0x1c, 0x42, 0x66, 0x4c, 0x58, 0x46, 0x69, 0x6e, 0xa9, 0xd3, 0xfb, 0x2c, 0x97, 0xb0, 0x3c,
-	0x30, 0xaf, 0xe5, 0xd2, 0x84, 0xc7, 0x29, 0xde, 0x49, 0xef, 0x25, 0x34, 0x06, 0x9c, 0x06, 0x09,
-	0x46, 0x45, 0x76, 0x8f, 0x21, 0x3b, 0x9d, 0xa4, 0x09, 0x46, 0xb9, 0x56, 0x8d, 0x69, 0x84, 0xd5,
-	0x55, 0xb5, 0x68, 0x70, 0x1e, 0xfb, 0xdf, 0x68, 0xb2, 0x07, 0x15, 0x14, 0x82, 0x8b, 0x3c, 0xa6,
-	0x36, 0xac, 0x1f, 0x65, 0x68, 0x74, 0xb2, 0xf1, 0x0f, 0x13, 0x14, 0xa1, 0x9c, 0xf3, 0x98, 0xbc,
-	0x82, 0x2a, 0xe3, 0xd4, 0xc7, 0x4b, 0xa5, 0x50, 0x6f, 0x1f, 0x14, 0x63, 0xfb, 0xab, 0x71, 0x27,
-	0x25, 0x3f, 0x07, 0x92, 0xb7, 0xaa, 0xe4, 0x3c, 0x6d, 0x15, 0xa0, 0xde, 0xde, 0x5f, 0xa3, 0xad,
-	0x15, 0x74, 0x52, 0xf2, 0xd7, 0xb0, 0x5d, 0x03, 0x6a, 0x11, 0x8f, 0x25, 0xc6, 0xb2, 0xfd, 0x6b,
-	0x1b, 0x2a, 0x2a, 0x15, 0xf2, 0x06, 0x8c, 0x3e, 0xca, 0xfc, 0x16, 0x99, 0x76, 0x7e, 0xcb, 0xdc,
-	0x78, 0x85, 0x8c, 0x27, 0xd8, 0xda, 0xdb, 0x74, 0x8f, 0xac, 0x12, 0x39, 0x82, 0x7a, 0x20, 0x43,
-	0x21, 0xb5, 0xfb, 0x3f, 0x88, 0x1d, 0x78, 0xd8, 0x47, 0xa9, 0xe7, 0x53, 0x14, 0xb9, 0x81, 0xde,
-	0xbc, 0xd9, 0x08, 0xdd, 0x76, 0x2d, 0x11, 0xdc, 0x51, 0xe2, 0x1d, 0xec, 0xfa, 0xb8, 0x42, 0x21,
-	0x8b, 0xb3, 0x4d, 0xb5, 0xef, 0xdb, 0xfa, 0x5d, 0xda, 0xc5, 0xbb, 0xb4, 0xdd, 0xec, 0x5d, 0x5a,
-	0x25, 0x72, 0x0c, 0xd0, 0x47, 0x99, 0x37, 0x7b, 0x03, 0xf3, 0xe0, 0xc6, 0x3c, 0xfe, 0x44, 0x3e,
-	0x06, 0x08, 0x6e, 0x47, 0xed, 0x7e, 0x03, 0x8b, 0x0b, 0x6a, 0x5f, 0x5c, 0x25, 0x28, 0x18, 0xce,
-	0x28, 0x0a, 0xfb, 0x3c, 0x9c, 0x8a, 0x79, 0x54, 0x50, 0xb2, 0x0f, 0xa6, 0xbb, 0xa3, 0xe6, 0x3a,
-	0x0a, 0xa3, 0xef, 0x21, 0xc5, 0xaf, 0x2f, 0xe8, 0x5c, 0x5e, 0x2c, 0xa7, 0x59, 0x18, 0x67, 0x8d,
-	0xe8, 0x68, 0xa2, 0xfe, 0x71, 0x52, 0x27, 0x23, 0x4e, 0xf5, 0x6f, 0xf4, 0xfa, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x77, 0x16, 0x8f, 0xa8, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_21addb49bc657c99) }
+
+var fileDescriptor_admin_21addb49bc657c99 = []byte{
+	// 605 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x6d, 0x4f, 0xd3, 0x5e,
+	0x14, 0xdf, 0xe0, 0xbf, 0xf1, 0xef, 0xe1, 0xa9, 0x5e, 0x09, 0x4c, 0xd0, 0x68, 0xfa, 0x4a, 0x63,
+	0xd2, 0x46, 0x0c, 0x41, 0x62, 0x7c, 0xb1, 0xb1, 0x0a, 0x28, 0x74, 0xcb, 0x2d, 0x8b, 0xd1, 0xc4,
+	0x2c, 0x5d, 0x7b, 0x28, 0xd5, 0xae, 0xb7, 0xdc, 0xde, 0x2e, 0xe1, 0xeb, 0xf8, 0x99, 0xfc, 0x1e,
+	0x7e, 0x05, 0xd3, 0x7b, 0x5b, 0x19, 0x30, 0x5f, 0x20, 0xaf, 0xee, 0x3d, 0xe7, 0xfc, 0x7e, 0xe7,
+	0x39, 0x07, 0xf4, 0x14, 0x91, 0x5b, 0x5e, 0x30, 0x8e, 0x12, 0x33, 0xe5, 0x4c, 0x30, 0xd2, 0x94,
+	0x4f, 0xb6, 0xb9, 0x15, 0x32, 0x16, 0xc6, 0x68, 0x49, 0x71, 0x94, 0x9f, 0x59, 0x38, 0x4e, 0xc5,
+	0xa5, 0x02, 0x6d, 0x3e, 0xf4, 0xd9, 0x78, 0xcc, 0x12, 0x4b, 0x3d, 0x4a, 0x69, 0xfc, 0xa8, 0xc3,
+	0x92, 0x8b, 0x7c, 0x82, 0xdc, 0x15, 0x9e, 0xc8, 0x33, 0xb2, 0x0b, 0xcd, 0x4c, 0xfe, 0x5a, 0xf5,
+	0x67, 0xf5, 0xe7, 0x2b, 0xdb, 0x4f, 0x15, 0x30, 0x33, 0xa7, 0x51, 0xa6, 0x7a, 0xf6, 0x59, 0x80,
+	0xb4, 0x84, 0x1b, 0x9f, 0x01, 0xae, 0xb4, 0x64, 0x19, 0xb4, 0x81, 0xd3, 0xb5, 0xdf, 0x1f, 0x39,
+	0x76, 0x57, 0xaf, 0x91, 0x45, 0x58, 0x70, 0x4f, 0xdb, 0xf4, 0xd4, 0xee, 0xea, 0x75, 0x25, 0xf4,
+	0xfa, 0x7d, 0xbb, 0xab, 0xcf, 0x11, 0x80, 0x66, 0xbf, 0x3d, 0x70, 0xed, 0xae, 0x3e, 0x4f, 0x34,
+	0x68, 0xd8, 0x94, 0xf6, 0xa8, 0xfe, 0x5f, 0x81, 0x19, 0x38, 0x1f, 0x9d, 0xde, 0x27, 0x47, 0x6f,
+	0x18, 0x27, 0xb0, 0x7a, 0xcc, 0xc2, 0x63, 0x9c, 0x60, 0x4c, 0xf1, 0x22, 0xc7, 0x4c, 0x90, 0x27,
+	0x00, 0x31, 0x0b, 0x87, 0x63, 0x16, 0xe4, 0x31, 0xca, 0x54, 0x35, 0xaa, 0xc5, 0x2c, 0x3c, 0x91,
+	0x0a, 0xb2, 0x05, 0x85, 0x30, 0x8c, 0x0b, 0x4a, 0x6b, 0x4e, 0x5a, 0xff, 0x8f, 0x4b, 0x17, 0x86,
+	0x03, 0xfa, 0x95, 0xbb, 0x2c, 0x65, 0x49, 0x86, 0xf7, 0xf2, 0xf7, 0x12, 0x56, 0x8e, 0x59, 0xe8,
+	0xa6, 0xe8, 0x57, 0xd9, 0x3d, 0x82, 0xc2, 0x3a, 0xcc, 0x52, 0xf4, 0x4b, 0x5f, 0x0b, 0xb1, 0x42,
+	0x18, 0x1d, 0x59, 0x8b, 0x02, 0x97, 0xb1, 0xff, 0x8e, 0x26, 0x6b, 0xd0, 0x40, 0xce, 0x19, 0x2f,
+	0x63, 0x2a, 0xc1, 0xd8, 0x81, 0xb5, 0x41, 0xf2, 0x8d, 0x45, 0xc9, 0xfe, 0xb9, 0x97, 0x24, 0xd7,
+	0x9a, 0xe2, 0x2b, 0xcd, 0x30, 0x0a, 0xaa, 0x22, 0x4a, 0xcd, 0x51, 0x60, 0xfc, 0xac, 0xc3, 0x4a,
+	0xbb, 0xd8, 0x9a, 0x5e, 0x8a, 0xdc, 0x13, 0x11, 0x4b, 0xc8, 0x2b, 0x68, 0xc6, 0x2c, 0xa4, 0x78,
+	0x21, 0xd1, 0x8b, 0xdb, 0x1b, 0xd5, 0xb4, 0x6f, 0xf4, 0xfb, 0xb0, 0x46, 0x4b, 0x20, 0x79, 0x03,
+	0x50, 0x66, 0x57, 0xd0, 0xe6, 0x24, 0x6d, 0x7d, 0x8a, 0x36, 0xd5, 0x87, 0xc3, 0x1a, 0x9d, 0xc2,
+	0x92, 0x0f, 0xa0, 0xe7, 0x37, 0xd2, 0x6e, 0xcd, 0x4b, 0xfe, 0xe3, 0x8a, 0x3f, 0xab, 0xac, 0xc3,
+	0x1a, 0xbd, 0xc5, 0xeb, 0x68, 0xb0, 0xe0, 0xb3, 0x44, 0x60, 0x22, 0xb6, 0x7f, 0xcd, 0x43, 0x43,
+	0x96, 0x45, 0x76, 0x40, 0x3b, 0x40, 0x51, 0x2e, 0xb2, 0x6e, 0x96, 0x8b, 0x6e, 0x27, 0x13, 0x8c,
+	0x59, 0x8a, 0x9b, 0x6b, 0xb3, 0x56, 0xd9, 0xa8, 0x91, 0x5d, 0x58, 0x74, 0x85, 0xc7, 0x85, 0x52,
+	0xdf, 0x81, 0xd8, 0x86, 0x07, 0x07, 0x28, 0xd4, 0x8a, 0x54, 0x0d, 0x9b, 0x41, 0x6f, 0xdd, 0x6e,
+	0xaa, 0x9a, 0xbc, 0x72, 0xe1, 0xde, 0xd3, 0xc5, 0x3b, 0x58, 0xa5, 0x38, 0x41, 0x2e, 0x2a, 0xdb,
+	0xac, 0xda, 0xd7, 0x4d, 0x75, 0x1a, 0xcc, 0xea, 0x34, 0x98, 0x76, 0x71, 0x1a, 0x8c, 0x1a, 0xd9,
+	0x03, 0x38, 0x40, 0x51, 0x0e, 0x6e, 0x06, 0x73, 0xe3, 0xd6, 0x6c, 0xff, 0x44, 0xde, 0x03, 0x70,
+	0xff, 0x91, 0xfa, 0x16, 0x96, 0xaf, 0xcd, 0xfa, 0x2e, 0x29, 0x77, 0xbe, 0x82, 0xc1, 0x78, 0x68,
+	0x9e, 0x5f, 0xa6, 0xc8, 0x63, 0x0c, 0x42, 0xe4, 0xe6, 0x99, 0x37, 0xe2, 0x91, 0x5f, 0xc5, 0x4b,
+	0x11, 0x79, 0x67, 0x49, 0x2e, 0x45, 0xdf, 0xf3, 0xbf, 0x7b, 0x21, 0x7e, 0x79, 0x11, 0x46, 0xe2,
+	0x3c, 0x1f, 0x15, 0x51, 0xac, 0x29, 0xa2, 0xa5, 0x88, 0xea, 0x62, 0x66, 0x56, 0x41, 0x1c, 0xa9,
+	0x6b, 0xfa, 0xfa, 0xf7, 0x00, 0xf0, 0x9f, 0xdb, 0x0c, 0x68, 0x05, 0x00, 0x00,
 }
@@ -39,7 +39,7 @@ func (x ConfidentialityLevel) String() string {
 	return proto.EnumName(ConfidentialityLevel_name, int32(x))
 }
 func (ConfidentialityLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{0}
 }
 
 type ChaincodeSpec_Type int32
@@ -50,6 +50,7 @@ const (
 	ChaincodeSpec_NODE      ChaincodeSpec_Type = 2
 	ChaincodeSpec_CAR       ChaincodeSpec_Type = 3
 	ChaincodeSpec_JAVA      ChaincodeSpec_Type = 4
+	ChaincodeSpec_WASM      ChaincodeSpec_Type = 5
 )
 
 var ChaincodeSpec_Type_name = map[int32]string{
@@ -58,6 +59,7 @@ var ChaincodeSpec_Type_name = map[int32]string{
 	2: "NODE",
 	3: "CAR",
 	4: "JAVA",
+	5: "WASM",
 }
 var ChaincodeSpec_Type_value = map[string]int32{
 	"UNDEFINED": 0,
@@ -65,13 +67,14 @@ var ChaincodeSpec_Type_value = map[string]int32{
 	"NODE":      2,
 	"CAR":       3,
 	"JAVA":      4,
+	"WASM":      5,
 }
 
 func (x ChaincodeSpec_Type) String() string {
 	return proto.EnumName(ChaincodeSpec_Type_name, int32(x))
 }
 func (ChaincodeSpec_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2, 0}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{2, 0}
 }
 
 type ChaincodeDeploymentSpec_ExecutionEnvironment int32
@@ -79,22 +82,27 @@ type ChaincodeDeploymentSpec_ExecutionEnvironment int32
 const (
 	ChaincodeDeploymentSpec_DOCKER ChaincodeDeploymentSpec_ExecutionEnvironment = 0
 	ChaincodeDeploymentSpec_SYSTEM ChaincodeDeploymentSpec_ExecutionEnvironment = 1
+	// The chaincode runs as an external service, which the peer connects to
+	// using the connection.json file of the code package
+	ChaincodeDeploymentSpec_EXTERNAL ChaincodeDeploymentSpec_ExecutionEnvironment = 2
 )
 
 var ChaincodeDeploymentSpec_ExecutionEnvironment_name = map[int32]string{
 	0: "DOCKER",
 	1: "SYSTEM",
+	2: "EXTERNAL",
 }
 var ChaincodeDeploymentSpec_ExecutionEnvironment_value = map[string]int32{
-	"DOCKER": 0,
-	"SYSTEM": 1,
+	"DOCKER":   0,
+	"SYSTEM":   1,
+	"EXTERNAL": 2,
 }
 
 func (x ChaincodeDeploymentSpec_ExecutionEnvironment) String() string {
 	return proto.EnumName(ChaincodeDeploymentSpec_ExecutionEnvironment_name, int32(x))
 }
 func (ChaincodeDeploymentSpec_ExecutionEnvironment) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3, 0}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{3, 0}
 }
 
 // ChaincodeID contains the path as specified by the deploy transaction
@@ -121,7 +129,7 @@ func (m *ChaincodeID) Reset()         { *m = ChaincodeID{} }
 func (m *ChaincodeID) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeID) ProtoMessage()    {}
 func (*ChaincodeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{0}
 }
 func (m *ChaincodeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeID.Unmarshal(m, b)
@@ -177,7 +185,7 @@ func (m *ChaincodeInput) Reset()         { *m = ChaincodeInput{} }
 func (m *ChaincodeInput) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInput) ProtoMessage()    {}
 func (*ChaincodeInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{1}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{1}
 }
 func (m *ChaincodeInput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInput.Unmarshal(m, b)
@@ -227,7 +235,7 @@ func (m *ChaincodeSpec) Reset()         { *m = ChaincodeSpec{} }
 func (m *ChaincodeSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeSpec) ProtoMessage()    {}
 func (*ChaincodeSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{2}
 }
 func (m *ChaincodeSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeSpec.Unmarshal(m, b)
@@ -290,7 +298,7 @@ func (m *ChaincodeDeploymentSpec) Reset()         { *m = ChaincodeDeploymentSpec
 func (m *ChaincodeDeploymentSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeDeploymentSpec) ProtoMessage()    {}
 func (*ChaincodeDeploymentSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{3}
 }
 func (m *ChaincodeDeploymentSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeDeploymentSpec.Unmarshal(m, b)
@@ -343,7 +351,7 @@ func (m *ChaincodeInvocationSpec) Reset()         { *m = ChaincodeInvocationSpec
 func (m *ChaincodeInvocationSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInvocationSpec) ProtoMessage()    {}
 func (*ChaincodeInvocationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{4}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{4}
 }
 func (m *ChaincodeInvocationSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInvocationSpec.Unmarshal(m, b)
@@ -382,7 +390,7 @@ func (m *LifecycleEvent) Reset()         { *m = LifecycleEvent{} }
 func (m *LifecycleEvent) String() string { return proto.CompactTextString(m) }
 func (*LifecycleEvent) ProtoMessage()    {}
 func (*LifecycleEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{5}
+	return fileDescriptor_chaincode_da5ae258dab26727, []int{5}
 }
 func (m *LifecycleEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LifecycleEvent.Unmarshal(m, b)
@@ -422,48 +430,49 @@ func init() {
 	proto.RegisterEnum("protos.ChaincodeDeploymentSpec_ExecutionEnvironment", ChaincodeDeploymentSpec_ExecutionEnvironment_name, ChaincodeDeploymentSpec_ExecutionEnvironment_value)
 }
 
-func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_d56d9efe15650cad) }
-
-var fileDescriptor_chaincode_d56d9efe15650cad = []byte{
-	// 632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x6d, 0x6f, 0xda, 0x3c,
-	0x14, 0x6d, 0x80, 0xbe, 0xdd, 0x50, 0x94, 0xc7, 0x0f, 0xcf, 0x33, 0xd4, 0x4f, 0x2c, 0xd2, 0x34,
-	0x36, 0x4d, 0x41, 0x62, 0xd5, 0x36, 0x4d, 0x53, 0x25, 0x4a, 0xd2, 0x2a, 0x1d, 0x83, 0xca, 0x6d,
-	0x27, 0x6d, 0x5f, 0x50, 0xea, 0x5c, 0xc0, 0x6a, 0x70, 0xa2, 0x60, 0xa2, 0xe6, 0x67, 0xec, 0x97,
-	0xec, 0x27, 0x6e, 0xb2, 0x53, 0x5e, 0xba, 0xf6, 0xdb, 0x3e, 0x71, 0x7d, 0x7c, 0x7c, 0xee, 0x3d,
-	0x07, 0xc7, 0x50, 0x4f, 0x10, 0xd3, 0x36, 0x9b, 0x06, 0x5c, 0xb0, 0x38, 0x44, 0x27, 0x49, 0x63,
-	0x19, 0x93, 0x1d, 0xfd, 0x33, 0xb7, 0x87, 0x60, 0xf6, 0x96, 0x5b, 0xbe, 0x4b, 0x08, 0x54, 0x92,
-	0x40, 0x4e, 0x1b, 0x46, 0xd3, 0x68, 0xed, 0x53, 0x5d, 0x2b, 0x4c, 0x04, 0x33, 0x6c, 0x94, 0x0a,
-	0x4c, 0xd5, 0xa4, 0x01, 0xbb, 0x19, 0xa6, 0x73, 0x1e, 0x8b, 0x46, 0x59, 0xc3, 0xcb, 0xa5, 0xfd,
-	0xd3, 0x80, 0xda, 0x5a, 0x51, 0x24, 0x0b, 0xa9, 0x04, 0x82, 0x74, 0x32, 0x6f, 0x18, 0xcd, 0x72,
-	0xab, 0x4a, 0x75, 0x4d, 0x7c, 0x30, 0x43, 0x64, 0x71, 0x1a, 0x48, 0x1e, 0x8b, 0x79, 0xa3, 0xd4,
-	0x2c, 0xb7, 0xcc, 0xce, 0xcb, 0x62, 0xb8, 0xb9, 0xf3, 0x50, 0xc0, 0x71, 0xd7, 0x4c, 0x4f, 0xc8,
-	0x34, 0xa7, 0x9b, 0x67, 0x0f, 0x8f, 0xc1, 0xfa, 0x93, 0x40, 0x2c, 0x28, 0xdf, 0x62, 0x7e, 0x6f,
-	0x43, 0x95, 0xa4, 0x0e, 0xdb, 0x59, 0x10, 0x2d, 0x0a, 0x1b, 0x55, 0x5a, 0x2c, 0x3e, 0x96, 0x3e,
-	0x18, 0xf6, 0x2f, 0x03, 0x0e, 0x56, 0x0d, 0x2f, 0x13, 0x64, 0xc4, 0x81, 0x8a, 0xcc, 0x13, 0xd4,
-	0xc7, 0x6b, 0x9d, 0xc3, 0x47, 0x53, 0x29, 0x92, 0x73, 0x95, 0x27, 0x48, 0x35, 0x8f, 0xbc, 0x83,
-	0xea, 0x2a, 0xdf, 0x11, 0x0f, 0x75, 0x0b, 0xb3, 0xf3, 0xef, 0x63, 0x37, 0x2e, 0x35, 0x57, 0x44,
-	0x3f, 0x24, 0x6f, 0x60, 0x9b, 0x2b, 0x83, 0x3a, 0x43, 0xb3, 0xf3, 0xff, 0xd3, 0xf6, 0x69, 0x41,
-	0x52, 0x99, 0x4b, 0x3e, 0xc3, 0x78, 0x21, 0x1b, 0x95, 0xa6, 0xd1, 0xda, 0xa6, 0xcb, 0xa5, 0x7d,
-	0x0c, 0x15, 0x35, 0x0d, 0x39, 0x80, 0xfd, 0xeb, 0x81, 0xeb, 0x9d, 0xfa, 0x03, 0xcf, 0xb5, 0xb6,
-	0x08, 0xc0, 0xce, 0xd9, 0xb0, 0xdf, 0x1d, 0x9c, 0x59, 0x06, 0xd9, 0x83, 0xca, 0x60, 0xe8, 0x7a,
-	0x56, 0x89, 0xec, 0x42, 0xb9, 0xd7, 0xa5, 0x56, 0x59, 0x41, 0xe7, 0xdd, 0xaf, 0x5d, 0xab, 0x62,
-	0xff, 0x28, 0xc1, 0xb3, 0x55, 0x4f, 0x17, 0x93, 0x28, 0xce, 0x67, 0x28, 0xa4, 0xce, 0xe2, 0x13,
-	0xd4, 0xd6, 0xde, 0xe6, 0x09, 0x32, 0x9d, 0x8a, 0xd9, 0xf9, 0xef, 0xc9, 0x54, 0xe8, 0x01, 0x7b,
-	0x90, 0xe4, 0x73, 0xa8, 0xea, 0x83, 0x49, 0xc0, 0x6e, 0x83, 0x09, 0x6a, 0xa3, 0x55, 0x6a, 0x2a,
-	0xec, 0xa2, 0x80, 0xc8, 0x10, 0xf6, 0xf0, 0x0e, 0xd9, 0x08, 0x45, 0xa6, 0x7d, 0xd5, 0x3a, 0x47,
-	0x8f, 0xa4, 0x1f, 0xce, 0xe4, 0x78, 0x77, 0xc8, 0x16, 0xea, 0xdf, 0xf6, 0x44, 0xc6, 0xd3, 0x58,
-	0xa8, 0x0d, 0xba, 0xab, 0x54, 0x3c, 0x91, 0xd9, 0x0e, 0xd4, 0x9f, 0x22, 0xa8, 0x38, 0xdc, 0x61,
-	0xef, 0xb3, 0x47, 0x8b, 0x68, 0x2e, 0xbf, 0x5d, 0x5e, 0x79, 0x5f, 0x2c, 0xe3, 0xbc, 0xb2, 0x57,
-	0xb2, 0xca, 0xb4, 0x86, 0xe3, 0x31, 0x32, 0xc9, 0x33, 0x1c, 0x85, 0x81, 0x44, 0x3b, 0xd9, 0x88,
-	0xc4, 0x17, 0x59, 0xcc, 0xf4, 0xf5, 0xfa, 0xfb, 0x48, 0xee, 0xdb, 0xfd, 0xc3, 0xc3, 0xd1, 0x04,
-	0x05, 0x16, 0xb7, 0x76, 0x14, 0x44, 0x13, 0xfb, 0x3d, 0xd4, 0xfa, 0x7c, 0x8c, 0x2c, 0x67, 0x11,
-	0x7a, 0x99, 0x9a, 0xf8, 0xc5, 0x66, 0x23, 0xfd, 0x0d, 0x16, 0x17, 0x7a, 0xad, 0x38, 0x08, 0x66,
-	0xf8, 0xfa, 0x08, 0xea, 0xbd, 0x58, 0x8c, 0x79, 0x88, 0x42, 0xf2, 0x20, 0xe2, 0x32, 0xef, 0x63,
-	0x86, 0x91, 0x32, 0x79, 0x71, 0x7d, 0xd2, 0xf7, 0x7b, 0xd6, 0x16, 0xb1, 0xa0, 0xda, 0x1b, 0x0e,
-	0x4e, 0x7d, 0xd7, 0x1b, 0x5c, 0xf9, 0xdd, 0xbe, 0x65, 0x9c, 0x0c, 0xc1, 0x8e, 0xd3, 0x89, 0x33,
-	0xcd, 0x13, 0x4c, 0x23, 0x0c, 0x27, 0x98, 0x3a, 0xe3, 0xe0, 0x26, 0xe5, 0x6c, 0xe9, 0x42, 0xbd,
-	0x1b, 0xdf, 0x5f, 0x4d, 0xb8, 0x9c, 0x2e, 0x6e, 0x1c, 0x16, 0xcf, 0xda, 0x1b, 0xd4, 0x76, 0x41,
-	0x6d, 0x17, 0xd4, 0xb6, 0xa2, 0xde, 0x14, 0x4f, 0xca, 0xdb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xea, 0x1f, 0x3b, 0xe4, 0x71, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_da5ae258dab26727) }
+
+var fileDescriptor_chaincode_da5ae258dab26727 = []byte{
+	// 649 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xda, 0x4a,
+	0x10, 0x8e, 0x0d, 0x24, 0x64, 0x4c, 0x90, 0xcf, 0x1e, 0xce, 0x29, 0xca, 0x15, 0xb5, 0x54, 0x95,
+	0x56, 0x95, 0x91, 0x68, 0xd4, 0x56, 0x55, 0x54, 0x89, 0x60, 0x27, 0x75, 0x4a, 0x4c, 0x64, 0x48,
+	0xff, 0x6e, 0x90, 0xb3, 0x1e, 0x60, 0x15, 0x58, 0x5b, 0xc6, 0x58, 0xf1, 0x23, 0xf4, 0x29, 0xfa,
+	0x08, 0x7d, 0xc5, 0x6a, 0xd7, 0xe1, 0x27, 0x4d, 0xee, 0x7a, 0xc5, 0xcc, 0xec, 0xb7, 0xdf, 0xcc,
+	0xf7, 0x31, 0x5e, 0xa8, 0x45, 0x88, 0x71, 0x8b, 0x4e, 0x7d, 0xc6, 0x69, 0x18, 0xa0, 0x19, 0xc5,
+	0x61, 0x12, 0x92, 0x5d, 0xf9, 0xb3, 0x30, 0xfa, 0xa0, 0x75, 0x57, 0x47, 0x8e, 0x45, 0x08, 0x14,
+	0x23, 0x3f, 0x99, 0xd6, 0x95, 0x86, 0xd2, 0xdc, 0xf7, 0x64, 0x2c, 0x6a, 0xdc, 0x9f, 0x63, 0x5d,
+	0xcd, 0x6b, 0x22, 0x26, 0x75, 0xd8, 0x4b, 0x31, 0x5e, 0xb0, 0x90, 0xd7, 0x0b, 0xb2, 0xbc, 0x4a,
+	0x8d, 0x5f, 0x0a, 0x54, 0x37, 0x8c, 0x3c, 0x5a, 0x26, 0x82, 0xc0, 0x8f, 0x27, 0x8b, 0xba, 0xd2,
+	0x28, 0x34, 0x2b, 0x9e, 0x8c, 0x89, 0x03, 0x5a, 0x80, 0x34, 0x8c, 0xfd, 0x84, 0x85, 0x7c, 0x51,
+	0x57, 0x1b, 0x85, 0xa6, 0xd6, 0x7e, 0x9e, 0x0f, 0xb7, 0x30, 0xef, 0x13, 0x98, 0xd6, 0x06, 0x69,
+	0xf3, 0x24, 0xce, 0xbc, 0xed, 0xbb, 0x87, 0x1f, 0x40, 0xff, 0x13, 0x40, 0x74, 0x28, 0xdc, 0x60,
+	0x76, 0x27, 0x43, 0x84, 0xa4, 0x06, 0xa5, 0xd4, 0x9f, 0x2d, 0x73, 0x19, 0x15, 0x2f, 0x4f, 0xde,
+	0xab, 0xef, 0x14, 0xe3, 0x87, 0x0a, 0x07, 0xeb, 0x86, 0x83, 0x08, 0x29, 0x31, 0xa1, 0x98, 0x64,
+	0x11, 0xca, 0xeb, 0xd5, 0xf6, 0xe1, 0x83, 0xa9, 0x04, 0xc8, 0x1c, 0x66, 0x11, 0x7a, 0x12, 0x47,
+	0xde, 0x40, 0x65, 0xed, 0xef, 0x88, 0x05, 0xb2, 0x85, 0xd6, 0xfe, 0xf7, 0xa1, 0x1a, 0xcb, 0xd3,
+	0xd6, 0x40, 0x27, 0x20, 0xaf, 0xa0, 0xc4, 0x84, 0x40, 0xe9, 0xa1, 0xd6, 0xfe, 0xff, 0x71, 0xf9,
+	0x5e, 0x0e, 0x12, 0x9e, 0x27, 0x6c, 0x8e, 0xe1, 0x32, 0xa9, 0x17, 0x1b, 0x4a, 0xb3, 0xe4, 0xad,
+	0x52, 0xe3, 0x23, 0x14, 0xc5, 0x34, 0xe4, 0x00, 0xf6, 0xaf, 0x5c, 0xcb, 0x3e, 0x75, 0x5c, 0xdb,
+	0xd2, 0x77, 0x08, 0xc0, 0xee, 0x59, 0xbf, 0xd7, 0x71, 0xcf, 0x74, 0x85, 0x94, 0xa1, 0xe8, 0xf6,
+	0x2d, 0x5b, 0x57, 0xc9, 0x1e, 0x14, 0xba, 0x1d, 0x4f, 0x2f, 0x88, 0xd2, 0x79, 0xe7, 0x73, 0x47,
+	0x2f, 0x8a, 0xe8, 0x4b, 0x67, 0x70, 0xa1, 0x97, 0x8c, 0x9f, 0x2a, 0x3c, 0x59, 0x77, 0xb7, 0x30,
+	0x9a, 0x85, 0xd9, 0x1c, 0x79, 0x22, 0x5d, 0x39, 0x86, 0xea, 0x46, 0xe5, 0x22, 0x42, 0x2a, 0xfd,
+	0xd1, 0xda, 0xff, 0x3d, 0xea, 0x8f, 0x77, 0x40, 0xb7, 0x53, 0xf2, 0x14, 0x2a, 0xf2, 0x62, 0xe4,
+	0xd3, 0x1b, 0x7f, 0x82, 0x52, 0x72, 0xc5, 0xd3, 0x44, 0xed, 0x32, 0x2f, 0x91, 0x3e, 0x94, 0xf1,
+	0x16, 0xe9, 0x08, 0x79, 0x2a, 0x15, 0x56, 0xdb, 0x47, 0x0f, 0xa8, 0xef, 0xcf, 0x64, 0xda, 0xb7,
+	0x48, 0x97, 0xe2, 0x7f, 0xb7, 0x79, 0xca, 0xe2, 0x90, 0x8b, 0x03, 0x6f, 0x4f, 0xb0, 0xd8, 0x3c,
+	0x35, 0x8e, 0xa1, 0xf6, 0x18, 0x40, 0x18, 0x63, 0xf5, 0xbb, 0x9f, 0x6c, 0x2f, 0x37, 0x69, 0xf0,
+	0x6d, 0x30, 0xb4, 0x2f, 0x74, 0x85, 0x54, 0xa0, 0x6c, 0x7f, 0x1d, 0xda, 0x9e, 0xdb, 0xe9, 0xe9,
+	0xea, 0x79, 0xb1, 0xac, 0xea, 0x05, 0xaf, 0x8a, 0xe3, 0x31, 0xd2, 0x84, 0xa5, 0x38, 0x0a, 0xfc,
+	0x04, 0x8d, 0x68, 0xcb, 0x20, 0x87, 0xa7, 0x21, 0x95, 0x6b, 0xf7, 0xf7, 0x06, 0xdd, 0xb5, 0xfb,
+	0x87, 0x05, 0xa3, 0x09, 0x72, 0xcc, 0xb7, 0x79, 0xe4, 0xcf, 0x26, 0xc6, 0x5b, 0xa8, 0xf6, 0xd8,
+	0x18, 0x69, 0x46, 0x67, 0x68, 0xa7, 0x62, 0xfe, 0x67, 0xdb, 0x8d, 0xe4, 0xb7, 0x99, 0x2f, 0xfa,
+	0x86, 0xd1, 0xf5, 0xe7, 0xf8, 0xf2, 0x08, 0x6a, 0xdd, 0x90, 0x8f, 0x59, 0x80, 0x3c, 0x61, 0xfe,
+	0x8c, 0x25, 0x59, 0x0f, 0x53, 0x9c, 0x09, 0xc9, 0x97, 0x57, 0x27, 0x3d, 0xa7, 0xab, 0xef, 0x10,
+	0x1d, 0x2a, 0xdd, 0xbe, 0x7b, 0xea, 0x58, 0xb6, 0x3b, 0x74, 0x3a, 0x3d, 0x5d, 0x39, 0xe9, 0x83,
+	0x11, 0xc6, 0x13, 0x73, 0x9a, 0x45, 0x18, 0xcf, 0x30, 0x98, 0x60, 0x6c, 0x8e, 0xfd, 0xeb, 0x98,
+	0xd1, 0x95, 0x0a, 0xf1, 0x9e, 0x7c, 0x7f, 0x31, 0x61, 0xc9, 0x74, 0x79, 0x6d, 0xd2, 0x70, 0xde,
+	0xda, 0x82, 0xb6, 0x72, 0x68, 0x2b, 0x87, 0xb6, 0x04, 0xf4, 0x3a, 0x7f, 0x6a, 0x5e, 0xff, 0x1e,
+	0x00, 0xe8, 0xd0, 0x0c, 0xbd, 0x89, 0x04, 0x00, 0x00,
 }
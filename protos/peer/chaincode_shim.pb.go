@@ -27,28 +27,32 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
-	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
-	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
-	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_UNDEFINED                    ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER                     ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED                   ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                         ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                        ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION                  ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED                    ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                        ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE                    ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE                    ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE                    ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE             ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE                     ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE           ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT             ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT             ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE            ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE                    ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY          ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_METADATA           ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_METADATA           ChaincodeMessage_Type = 21
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH        ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_STATE_AT_HEIGHT          ChaincodeMessage_Type = 23
+	ChaincodeMessage_GET_STATE_BY_RANGE_AT_HEIGHT ChaincodeMessage_Type = 24
+	ChaincodeMessage_GET_STATE_MULTIPLE           ChaincodeMessage_Type = 25
+	ChaincodeMessage_PUT_STATE_BATCH              ChaincodeMessage_Type = 26
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -74,37 +78,45 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "GET_STATE_AT_HEIGHT",
+	24: "GET_STATE_BY_RANGE_AT_HEIGHT",
+	25: "GET_STATE_MULTIPLE",
+	26: "PUT_STATE_BATCH",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":             0,
-	"REGISTER":              1,
-	"REGISTERED":            2,
-	"INIT":                  3,
-	"READY":                 4,
-	"TRANSACTION":           5,
-	"COMPLETED":             6,
-	"ERROR":                 7,
-	"GET_STATE":             8,
-	"PUT_STATE":             9,
-	"DEL_STATE":             10,
-	"INVOKE_CHAINCODE":      11,
-	"RESPONSE":              13,
-	"GET_STATE_BY_RANGE":    14,
-	"GET_QUERY_RESULT":      15,
-	"QUERY_STATE_NEXT":      16,
-	"QUERY_STATE_CLOSE":     17,
-	"KEEPALIVE":             18,
-	"GET_HISTORY_FOR_KEY":   19,
-	"GET_STATE_METADATA":    20,
-	"PUT_STATE_METADATA":    21,
-	"GET_PRIVATE_DATA_HASH": 22,
+	"UNDEFINED":                    0,
+	"REGISTER":                     1,
+	"REGISTERED":                   2,
+	"INIT":                         3,
+	"READY":                        4,
+	"TRANSACTION":                  5,
+	"COMPLETED":                    6,
+	"ERROR":                        7,
+	"GET_STATE":                    8,
+	"PUT_STATE":                    9,
+	"DEL_STATE":                    10,
+	"INVOKE_CHAINCODE":             11,
+	"RESPONSE":                     13,
+	"GET_STATE_BY_RANGE":           14,
+	"GET_QUERY_RESULT":             15,
+	"QUERY_STATE_NEXT":             16,
+	"QUERY_STATE_CLOSE":            17,
+	"KEEPALIVE":                    18,
+	"GET_HISTORY_FOR_KEY":          19,
+	"GET_STATE_METADATA":           20,
+	"PUT_STATE_METADATA":           21,
+	"GET_PRIVATE_DATA_HASH":        22,
+	"GET_STATE_AT_HEIGHT":          23,
+	"GET_STATE_BY_RANGE_AT_HEIGHT": 24,
+	"GET_STATE_MULTIPLE":           25,
+	"PUT_STATE_BATCH":              26,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -118,17 +130,21 @@ type ChaincodeMessage struct {
 	// with Block.NonHashData.TransactionResult
 	ChaincodeEvent *ChaincodeEvent `protobuf:"bytes,6,opt,name=chaincode_event,json=chaincodeEvent,proto3" json:"chaincode_event,omitempty"`
 	// channel id
-	ChannelId            string   `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ChannelId string `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// all the events emitted by chaincode, in the order they were set or
+	// added. Used only with Init or Invoke. When set, chaincode_event holds
+	// the event set with SetEvent for the peers not knowing of this field
+	ChaincodeEvents      []*ChaincodeEvent `protobuf:"bytes,8,rep,name=chaincode_events,json=chaincodeEvents,proto3" json:"chaincode_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -197,6 +213,13 @@ func (m *ChaincodeMessage) GetChannelId() string {
 	return ""
 }
 
+func (m *ChaincodeMessage) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
 // GetState is the payload of a ChaincodeMessage. It contains a key which
 // is to be fetched from the ledger. If the collection is specified, the key
 // would be fetched from the collection (i.e., private state)
@@ -212,7 +235,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +281,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +332,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +386,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +443,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -472,7 +495,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +560,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -593,7 +616,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -628,19 +651,23 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved. The options restrict
+// the history to a range of blocks and select the order of the results, and
+// the metadata, if set, carries the page size and the bookmark.
 type GetHistoryForKey struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Key                  string               `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Options              *HistoryQueryOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Metadata             []byte               `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +694,445 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetOptions() *HistoryQueryOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// HistoryQueryOptions restricts a history query to the modifications made in
+// the blocks from start_block to end_block, both included, an end_block of zero
+// meaning no upper bound, and returns them from the oldest to the newest, or
+// from the newest to the oldest if descending is set.
+type HistoryQueryOptions struct {
+	StartBlock           uint64   `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	Descending           bool     `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryQueryOptions) Reset()         { *m = HistoryQueryOptions{} }
+func (m *HistoryQueryOptions) String() string { return proto.CompactTextString(m) }
+func (*HistoryQueryOptions) ProtoMessage()    {}
+func (*HistoryQueryOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{10}
+}
+func (m *HistoryQueryOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryQueryOptions.Unmarshal(m, b)
+}
+func (m *HistoryQueryOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryQueryOptions.Marshal(b, m, deterministic)
+}
+func (dst *HistoryQueryOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryQueryOptions.Merge(dst, src)
+}
+func (m *HistoryQueryOptions) XXX_Size() int {
+	return xxx_messageInfo_HistoryQueryOptions.Size(m)
+}
+func (m *HistoryQueryOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryQueryOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryQueryOptions proto.InternalMessageInfo
+
+func (m *HistoryQueryOptions) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+// GetStateAtHeight is the payload of a ChaincodeMessage. It contains a key which
+// is to be fetched from the ledger as it was right after the block of the given
+// number was committed.
+type GetStateAtHeight struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BlockNum             uint64   `protobuf:"varint,2,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateAtHeight) Reset()         { *m = GetStateAtHeight{} }
+func (m *GetStateAtHeight) String() string { return proto.CompactTextString(m) }
+func (*GetStateAtHeight) ProtoMessage()    {}
+func (*GetStateAtHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{11}
+}
+func (m *GetStateAtHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateAtHeight.Unmarshal(m, b)
+}
+func (m *GetStateAtHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateAtHeight.Marshal(b, m, deterministic)
+}
+func (dst *GetStateAtHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateAtHeight.Merge(dst, src)
+}
+func (m *GetStateAtHeight) XXX_Size() int {
+	return xxx_messageInfo_GetStateAtHeight.Size(m)
+}
+func (m *GetStateAtHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateAtHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateAtHeight proto.InternalMessageInfo
+
+func (m *GetStateAtHeight) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetStateAtHeight) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+// GetStateByRangeAtHeight is the payload of a ChaincodeMessage. It contains a start
+// key and an end key required to execute a range query against the ledger as it was
+// right after the block of the given number was committed.
+type GetStateByRangeAtHeight struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	BlockNum             uint64   `protobuf:"varint,3,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateByRangeAtHeight) Reset()         { *m = GetStateByRangeAtHeight{} }
+func (m *GetStateByRangeAtHeight) String() string { return proto.CompactTextString(m) }
+func (*GetStateByRangeAtHeight) ProtoMessage()    {}
+func (*GetStateByRangeAtHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{12}
+}
+func (m *GetStateByRangeAtHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Unmarshal(m, b)
+}
+func (m *GetStateByRangeAtHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Marshal(b, m, deterministic)
+}
+func (dst *GetStateByRangeAtHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateByRangeAtHeight.Merge(dst, src)
+}
+func (m *GetStateByRangeAtHeight) XXX_Size() int {
+	return xxx_messageInfo_GetStateByRangeAtHeight.Size(m)
+}
+func (m *GetStateByRangeAtHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateByRangeAtHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateByRangeAtHeight proto.InternalMessageInfo
+
+func (m *GetStateByRangeAtHeight) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAtHeight) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAtHeight) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+// GetStateMultiple is the payload of a ChaincodeMessage. It contains the keys
+// which are to be fetched from the ledger in a single round trip. If the
+// collection is specified, the keys would be fetched from the collection.
+type GetStateMultiple struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultiple) Reset()         { *m = GetStateMultiple{} }
+func (m *GetStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultiple) ProtoMessage()    {}
+func (*GetStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{13}
+}
+func (m *GetStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultiple.Unmarshal(m, b)
+}
+func (m *GetStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultiple.Marshal(b, m, deterministic)
+}
+func (dst *GetStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultiple.Merge(dst, src)
+}
+func (m *GetStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultiple.Size(m)
+}
+func (m *GetStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultiple proto.InternalMessageInfo
+
+func (m *GetStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// GetStateMultipleResult is returned by the peer as a result of a GetStateMultiple.
+// It holds the values of the keys in the order they were requested, an empty
+// value denoting a key which does not exist.
+type GetStateMultipleResult struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultipleResult) Reset()         { *m = GetStateMultipleResult{} }
+func (m *GetStateMultipleResult) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultipleResult) ProtoMessage()    {}
+func (*GetStateMultipleResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{14}
+}
+func (m *GetStateMultipleResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultipleResult.Unmarshal(m, b)
+}
+func (m *GetStateMultipleResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultipleResult.Marshal(b, m, deterministic)
+}
+func (dst *GetStateMultipleResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultipleResult.Merge(dst, src)
+}
+func (m *GetStateMultipleResult) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultipleResult.Size(m)
+}
+func (m *GetStateMultipleResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultipleResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultipleResult proto.InternalMessageInfo
+
+func (m *GetStateMultipleResult) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// WriteRecord is a write of a PutStateBatch. It is the equivalent of a PutState,
+// or of a DelState when is_delete is set.
+type WriteRecord struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Collection           string   `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	IsDelete             bool     `protobuf:"varint,4,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteRecord) Reset()         { *m = WriteRecord{} }
+func (m *WriteRecord) String() string { return proto.CompactTextString(m) }
+func (*WriteRecord) ProtoMessage()    {}
+func (*WriteRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{15}
+}
+func (m *WriteRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteRecord.Unmarshal(m, b)
+}
+func (m *WriteRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteRecord.Marshal(b, m, deterministic)
+}
+func (dst *WriteRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteRecord.Merge(dst, src)
+}
+func (m *WriteRecord) XXX_Size() int {
+	return xxx_messageInfo_WriteRecord.Size(m)
+}
+func (m *WriteRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteRecord proto.InternalMessageInfo
+
+func (m *WriteRecord) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WriteRecord) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
+// PutStateBatch is the payload of a ChaincodeMessage. It contains the writes
+// buffered by the chaincode during a transaction, which are recorded in the
+// transaction's write sets in order.
+type PutStateBatch struct {
+	Records              []*WriteRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PutStateBatch) Reset()         { *m = PutStateBatch{} }
+func (m *PutStateBatch) String() string { return proto.CompactTextString(m) }
+func (*PutStateBatch) ProtoMessage()    {}
+func (*PutStateBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{16}
+}
+func (m *PutStateBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutStateBatch.Unmarshal(m, b)
+}
+func (m *PutStateBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutStateBatch.Marshal(b, m, deterministic)
+}
+func (dst *PutStateBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutStateBatch.Merge(dst, src)
+}
+func (m *PutStateBatch) XXX_Size() int {
+	return xxx_messageInfo_PutStateBatch.Size(m)
+}
+func (m *PutStateBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutStateBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutStateBatch proto.InternalMessageInfo
+
+func (m *PutStateBatch) GetRecords() []*WriteRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// ChaincodeAdditionalParams is the payload of the REGISTERED message. It tells
+// the chaincode whether the peer accepts GET_STATE_MULTIPLE and PUT_STATE_BATCH
+// messages and how many keys or writes at most each of them may carry, zero
+// meaning no limit. A peer which does not send it supports neither.
+type ChaincodeAdditionalParams struct {
+	UseWriteBatch          bool     `protobuf:"varint,1,opt,name=use_write_batch,json=useWriteBatch,proto3" json:"use_write_batch,omitempty"`
+	MaxSizeWriteBatch      uint32   `protobuf:"varint,2,opt,name=max_size_write_batch,json=maxSizeWriteBatch,proto3" json:"max_size_write_batch,omitempty"`
+	UseGetMultipleKeys     bool     `protobuf:"varint,3,opt,name=use_get_multiple_keys,json=useGetMultipleKeys,proto3" json:"use_get_multiple_keys,omitempty"`
+	MaxSizeGetMultipleKeys uint32   `protobuf:"varint,4,opt,name=max_size_get_multiple_keys,json=maxSizeGetMultipleKeys,proto3" json:"max_size_get_multiple_keys,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ChaincodeAdditionalParams) Reset()         { *m = ChaincodeAdditionalParams{} }
+func (m *ChaincodeAdditionalParams) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeAdditionalParams) ProtoMessage()    {}
+func (*ChaincodeAdditionalParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{17}
+}
+func (m *ChaincodeAdditionalParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Unmarshal(m, b)
+}
+func (m *ChaincodeAdditionalParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeAdditionalParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeAdditionalParams.Merge(dst, src)
+}
+func (m *ChaincodeAdditionalParams) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Size(m)
+}
+func (m *ChaincodeAdditionalParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeAdditionalParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeAdditionalParams proto.InternalMessageInfo
+
+func (m *ChaincodeAdditionalParams) GetUseWriteBatch() bool {
+	if m != nil {
+		return m.UseWriteBatch
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeWriteBatch() uint32 {
+	if m != nil {
+		return m.MaxSizeWriteBatch
+	}
+	return 0
+}
+
+func (m *ChaincodeAdditionalParams) GetUseGetMultipleKeys() bool {
+	if m != nil {
+		return m.UseGetMultipleKeys
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeGetMultipleKeys() uint32 {
+	if m != nil {
+		return m.MaxSizeGetMultipleKeys
+	}
+	return 0
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +1144,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{18}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +1182,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{19}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +1221,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{20}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +1267,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{21}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +1329,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{22}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +1375,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{23}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +1420,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_7a92373e88f30ff6, []int{24}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -992,6 +1458,14 @@ func init() {
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*HistoryQueryOptions)(nil), "protos.HistoryQueryOptions")
+	proto.RegisterType((*GetStateAtHeight)(nil), "protos.GetStateAtHeight")
+	proto.RegisterType((*GetStateByRangeAtHeight)(nil), "protos.GetStateByRangeAtHeight")
+	proto.RegisterType((*GetStateMultiple)(nil), "protos.GetStateMultiple")
+	proto.RegisterType((*GetStateMultipleResult)(nil), "protos.GetStateMultipleResult")
+	proto.RegisterType((*WriteRecord)(nil), "protos.WriteRecord")
+	proto.RegisterType((*PutStateBatch)(nil), "protos.PutStateBatch")
+	proto.RegisterType((*ChaincodeAdditionalParams)(nil), "protos.ChaincodeAdditionalParams")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1106,75 +1580,195 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// ChaincodeClient is the client API for Chaincode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[0], "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeServer is the server API for Chaincode service.
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_7a92373e88f30ff6)
+}
+
+var fileDescriptor_chaincode_shim_7a92373e88f30ff6 = []byte{
+	// 1423 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdf, 0x73, 0xda, 0xc6,
+	0x16, 0x0e, 0x06, 0x1b, 0x71, 0xb0, 0xcd, 0x66, 0xb1, 0x1d, 0x4c, 0x6e, 0x12, 0x2e, 0x0f, 0x77,
+	0x7c, 0x1f, 0x0a, 0x09, 0x6d, 0x67, 0x3a, 0x9d, 0x4e, 0x33, 0x32, 0xc8, 0xc0, 0xd8, 0x06, 0xb2,
+	0xc8, 0x69, 0xdc, 0x17, 0x8d, 0x40, 0x1b, 0x50, 0x2d, 0x24, 0xaa, 0x5d, 0x25, 0x26, 0x6f, 0x79,
+	0xed, 0xbf, 0xd9, 0xff, 0xa2, 0x4f, 0x9d, 0x5d, 0xfd, 0x30, 0xe0, 0xd8, 0x99, 0x7a, 0xfa, 0x64,
+	0xce, 0x39, 0xdf, 0xf9, 0xbe, 0x73, 0xf6, 0xc7, 0x59, 0x0b, 0x0e, 0xe7, 0x94, 0xfa, 0xf5, 0xf1,
+	0xd4, 0xb4, 0xdd, 0xb1, 0x67, 0x51, 0x83, 0x4d, 0xed, 0x59, 0x6d, 0xee, 0x7b, 0xdc, 0xc3, 0x5b,
+	0xf2, 0x0f, 0x2b, 0x97, 0xd7, 0x20, 0xf4, 0x03, 0x75, 0x79, 0x88, 0x29, 0x17, 0x65, 0x6c, 0xee,
+	0x7b, 0x73, 0x8f, 0x99, 0x4e, 0xe4, 0x7c, 0x31, 0xf1, 0xbc, 0x89, 0x43, 0xeb, 0xd2, 0x1a, 0x05,
+	0xef, 0xeb, 0xdc, 0x9e, 0x51, 0xc6, 0xcd, 0xd9, 0x3c, 0x04, 0x54, 0x3f, 0x67, 0x01, 0x35, 0x63,
+	0xbe, 0x73, 0xca, 0x98, 0x39, 0xa1, 0xf8, 0x15, 0x64, 0xf8, 0x62, 0x4e, 0x4b, 0xa9, 0x4a, 0xea,
+	0x68, 0xb7, 0xf1, 0x2c, 0x84, 0xb2, 0xda, 0x3a, 0xae, 0xa6, 0x2f, 0xe6, 0x94, 0x48, 0x28, 0xfe,
+	0x01, 0x72, 0x09, 0x75, 0x69, 0xa3, 0x92, 0x3a, 0xca, 0x37, 0xca, 0xb5, 0x50, 0xbc, 0x16, 0x8b,
+	0xd7, 0xf4, 0x18, 0x41, 0x6e, 0xc0, 0xb8, 0x04, 0xd9, 0xb9, 0xb9, 0x70, 0x3c, 0xd3, 0x2a, 0xa5,
+	0x2b, 0xa9, 0xa3, 0x6d, 0x12, 0x9b, 0x18, 0x43, 0x86, 0x5f, 0xdb, 0x56, 0x29, 0x53, 0x49, 0x1d,
+	0xe5, 0x88, 0xfc, 0x8d, 0x1b, 0xa0, 0xc4, 0x2d, 0x96, 0x36, 0xa5, 0xcc, 0x41, 0x5c, 0xde, 0xd0,
+	0x9e, 0xb8, 0xd4, 0x1a, 0x44, 0x51, 0x92, 0xe0, 0xf0, 0x6b, 0x28, 0xac, 0x2d, 0x59, 0x69, 0x6b,
+	0x35, 0x35, 0xe9, 0x4c, 0x13, 0x51, 0xb2, 0x3b, 0x5e, 0xb1, 0xf1, 0x33, 0x80, 0xf1, 0xd4, 0x74,
+	0x5d, 0xea, 0x18, 0xb6, 0x55, 0xca, 0xca, 0x72, 0x72, 0x91, 0xa7, 0x6b, 0x61, 0x15, 0xd0, 0x1a,
+	0x3f, 0x2b, 0x29, 0x95, 0xf4, 0x3d, 0x02, 0x85, 0x55, 0x01, 0x56, 0xfd, 0x2b, 0x0d, 0x19, 0xb1,
+	0x9a, 0x78, 0x07, 0x72, 0x17, 0xbd, 0x96, 0x76, 0xd2, 0xed, 0x69, 0x2d, 0xf4, 0x08, 0x6f, 0x83,
+	0x42, 0xb4, 0x76, 0x77, 0xa8, 0x6b, 0x04, 0xa5, 0xf0, 0x2e, 0x40, 0x6c, 0x69, 0x2d, 0xb4, 0x81,
+	0x15, 0xc8, 0x74, 0x7b, 0x5d, 0x1d, 0xa5, 0x71, 0x0e, 0x36, 0x89, 0xa6, 0xb6, 0x2e, 0x51, 0x06,
+	0x17, 0x20, 0xaf, 0x13, 0xb5, 0x37, 0x54, 0x9b, 0x7a, 0xb7, 0xdf, 0x43, 0x9b, 0x82, 0xb2, 0xd9,
+	0x3f, 0x1f, 0x9c, 0x69, 0xba, 0xd6, 0x42, 0x5b, 0x02, 0xaa, 0x11, 0xd2, 0x27, 0x28, 0x2b, 0x22,
+	0x6d, 0x4d, 0x37, 0x86, 0xba, 0xaa, 0x6b, 0x48, 0x11, 0xe6, 0xe0, 0x22, 0x36, 0x73, 0xc2, 0x6c,
+	0x69, 0x67, 0x91, 0x09, 0x78, 0x0f, 0x50, 0xb7, 0xf7, 0xb6, 0x7f, 0xaa, 0x19, 0xcd, 0x8e, 0xda,
+	0xed, 0x35, 0xfb, 0x2d, 0x0d, 0xe5, 0xc3, 0x02, 0x87, 0x83, 0x7e, 0x6f, 0xa8, 0xa1, 0x1d, 0x7c,
+	0x00, 0x38, 0x21, 0x34, 0x8e, 0x2f, 0x0d, 0xa2, 0xf6, 0xda, 0x1a, 0xda, 0x15, 0xb9, 0xc2, 0xff,
+	0xe6, 0x42, 0x23, 0x97, 0x06, 0xd1, 0x86, 0x17, 0x67, 0x3a, 0x2a, 0x08, 0x6f, 0xe8, 0x09, 0xf1,
+	0x3d, 0xed, 0x9d, 0x8e, 0x10, 0xde, 0x87, 0xc7, 0xcb, 0xde, 0xe6, 0x59, 0x7f, 0xa8, 0xa1, 0xc7,
+	0xa2, 0x9a, 0x53, 0x4d, 0x1b, 0xa8, 0x67, 0xdd, 0xb7, 0x1a, 0xc2, 0xf8, 0x09, 0x14, 0x05, 0x63,
+	0xa7, 0x3b, 0xd4, 0xfb, 0xe4, 0xd2, 0x38, 0xe9, 0x13, 0xe3, 0x54, 0xbb, 0x44, 0xc5, 0xd5, 0x12,
+	0xce, 0x35, 0x5d, 0x6d, 0xa9, 0xba, 0x8a, 0xf6, 0x84, 0x7f, 0x70, 0x71, 0xcb, 0xbf, 0x8f, 0x0f,
+	0x61, 0x5f, 0xe0, 0x07, 0xa4, 0xfb, 0x56, 0x44, 0x84, 0xd7, 0xe8, 0xa8, 0xc3, 0x0e, 0x3a, 0x88,
+	0x35, 0xc2, 0x14, 0x55, 0x37, 0x3a, 0x5a, 0xb7, 0xdd, 0xd1, 0xd1, 0x13, 0x5c, 0x81, 0xff, 0xdc,
+	0x6e, 0x73, 0x09, 0x51, 0x5a, 0xab, 0xe2, 0xe2, 0x4c, 0xef, 0x0e, 0xce, 0x34, 0x74, 0x88, 0x8b,
+	0x50, 0xb8, 0xa9, 0xe2, 0x58, 0xd5, 0x9b, 0x1d, 0x54, 0xae, 0xfe, 0x04, 0x4a, 0x9b, 0xf2, 0x21,
+	0x37, 0x39, 0xc5, 0x08, 0xd2, 0x57, 0x74, 0x21, 0x6f, 0x5e, 0x8e, 0x88, 0x9f, 0xf8, 0x39, 0xc0,
+	0xd8, 0x73, 0x1c, 0x3a, 0xe6, 0xb6, 0xe7, 0xca, 0xab, 0x95, 0x23, 0x4b, 0x9e, 0x6a, 0x0b, 0x50,
+	0x9c, 0x7d, 0x4e, 0xb9, 0x69, 0x99, 0xdc, 0x7c, 0x00, 0x0b, 0x01, 0x65, 0x10, 0xdc, 0x59, 0xc3,
+	0x1e, 0x6c, 0x7e, 0x30, 0x9d, 0x80, 0xca, 0xc4, 0x6d, 0x12, 0x1a, 0x6b, 0x9c, 0xe9, 0x5b, 0x9c,
+	0x1f, 0x01, 0x0d, 0x82, 0x7f, 0x58, 0xd9, 0x2d, 0x16, 0xfc, 0x0a, 0x94, 0x59, 0x94, 0x2d, 0x27,
+	0x41, 0xbe, 0xb1, 0x9f, 0xdc, 0xf8, 0x65, 0x6a, 0x92, 0xc0, 0xc4, 0x82, 0xb6, 0xa8, 0xf3, 0xd0,
+	0x05, 0xfd, 0x9c, 0x82, 0x42, 0xbc, 0xa2, 0xc7, 0x0b, 0x62, 0xba, 0x13, 0x8a, 0xcb, 0xa0, 0x30,
+	0x6e, 0xfa, 0xfc, 0x34, 0xa1, 0x4a, 0x6c, 0x7c, 0x00, 0x5b, 0xd4, 0xb5, 0x44, 0x24, 0xe4, 0x8a,
+	0xac, 0xaf, 0x36, 0x56, 0x5e, 0x6b, 0x6c, 0x7b, 0xa9, 0x83, 0x11, 0xec, 0xb6, 0x29, 0x7f, 0x13,
+	0x50, 0x7f, 0x41, 0x28, 0x0b, 0x1c, 0x2e, 0xb6, 0xe0, 0x77, 0x61, 0x46, 0xf2, 0xa1, 0xf1, 0xb5,
+	0x5e, 0x56, 0x34, 0xd2, 0x6b, 0x1a, 0x6d, 0xd8, 0x91, 0x02, 0xc9, 0xde, 0x94, 0x41, 0x99, 0x9b,
+	0x13, 0x3a, 0xb4, 0x3f, 0x85, 0xa3, 0x7f, 0x93, 0x24, 0xb6, 0x88, 0x8d, 0x3c, 0xef, 0x6a, 0x66,
+	0xfa, 0x57, 0x91, 0x4c, 0x62, 0x8b, 0x7d, 0x6e, 0x53, 0xde, 0xb1, 0x19, 0xf7, 0xfc, 0xc5, 0x89,
+	0xe7, 0x8b, 0xe6, 0x6f, 0x2f, 0xfb, 0xf7, 0x90, 0xf5, 0xe6, 0xa2, 0x28, 0x16, 0xbd, 0x0f, 0x4f,
+	0xe3, 0x6d, 0x8c, 0x32, 0x65, 0x31, 0xfd, 0x10, 0x42, 0x62, 0xec, 0xbd, 0x1d, 0x30, 0x28, 0x7e,
+	0x21, 0x17, 0xbf, 0x80, 0xbc, 0xdc, 0x1c, 0x63, 0xe4, 0x78, 0xe3, 0x2b, 0x59, 0x43, 0x86, 0x80,
+	0x74, 0x1d, 0x0b, 0x0f, 0x7e, 0x0a, 0x39, 0xea, 0x5a, 0x51, 0x78, 0x43, 0x86, 0x15, 0xea, 0x5a,
+	0x61, 0xf0, 0x39, 0x80, 0x45, 0xd9, 0x98, 0xba, 0x96, 0xed, 0x4e, 0xa4, 0xa4, 0x42, 0x96, 0x3c,
+	0x55, 0xf5, 0xe6, 0xbe, 0xa9, 0xbc, 0x43, 0xed, 0xc9, 0x94, 0x7f, 0xa1, 0xdb, 0xa7, 0x90, 0x93,
+	0xf4, 0x86, 0x1b, 0xcc, 0x62, 0x09, 0xe9, 0xe8, 0x05, 0xb3, 0xea, 0x6f, 0xf0, 0x64, 0xed, 0x80,
+	0x25, 0x4c, 0x0f, 0x39, 0x68, 0x2b, 0x5a, 0xe9, 0x35, 0xad, 0x93, 0xa5, 0xf1, 0x10, 0x38, 0xdc,
+	0x9e, 0x3b, 0x54, 0x3c, 0xac, 0x57, 0x74, 0xc1, 0x4a, 0xa9, 0x4a, 0x5a, 0x3c, 0xac, 0xe2, 0xf7,
+	0x57, 0x6f, 0xc5, 0x4b, 0x38, 0x58, 0xe7, 0x89, 0x4e, 0xe6, 0x01, 0x6c, 0xc9, 0x79, 0x10, 0xf2,
+	0x6d, 0x93, 0xc8, 0xaa, 0xfa, 0x90, 0xff, 0xc5, 0xb7, 0x39, 0x25, 0x74, 0xec, 0xf9, 0xd6, 0xbf,
+	0x35, 0x55, 0x44, 0xb7, 0x36, 0x33, 0x2c, 0xea, 0x50, 0x4e, 0xe5, 0xbd, 0x51, 0x88, 0x62, 0xb3,
+	0x96, 0xb4, 0xab, 0x3f, 0xc3, 0x4e, 0x3c, 0x72, 0x8e, 0x4d, 0x3e, 0x9e, 0xe2, 0x6f, 0x20, 0xeb,
+	0x4b, 0xfd, 0xb0, 0xba, 0x7c, 0xa3, 0x18, 0x9f, 0xba, 0xa5, 0xda, 0x48, 0x8c, 0xa9, 0xfe, 0x99,
+	0x82, 0xc3, 0xe4, 0xad, 0x56, 0x2d, 0xcb, 0x16, 0x92, 0xa6, 0x33, 0x30, 0x7d, 0x73, 0xc6, 0xf0,
+	0xff, 0xa0, 0x10, 0x30, 0x6a, 0x7c, 0x14, 0x99, 0xc6, 0x48, 0xf0, 0xcb, 0x76, 0x14, 0xb2, 0x13,
+	0x30, 0x2a, 0xf9, 0x42, 0xd1, 0x3a, 0xec, 0xcd, 0xcc, 0x6b, 0x83, 0xd9, 0x9f, 0x56, 0xc1, 0xa2,
+	0xcf, 0x1d, 0xf2, 0x78, 0x66, 0x5e, 0x8b, 0x3b, 0xb5, 0x94, 0xf0, 0x0a, 0xf6, 0x05, 0xf1, 0x84,
+	0x72, 0x63, 0x16, 0x2d, 0xae, 0x21, 0x77, 0x28, 0x3c, 0x7e, 0x38, 0x60, 0xb4, 0x4d, 0x79, 0xbc,
+	0xee, 0xa7, 0x62, 0xbf, 0x7e, 0x84, 0x72, 0xa2, 0x71, 0x3b, 0x2f, 0x23, 0x95, 0x0e, 0x22, 0xa5,
+	0xb5, 0xdc, 0x6a, 0x05, 0x76, 0xe5, 0x85, 0x91, 0xeb, 0xd4, 0xa3, 0xd7, 0x1c, 0xef, 0xc2, 0x86,
+	0x6d, 0x45, 0x7b, 0xb3, 0x61, 0x5b, 0xd5, 0xff, 0x42, 0xe1, 0x06, 0xd1, 0x74, 0x3c, 0x46, 0x6f,
+	0x41, 0xbe, 0x03, 0xb4, 0x34, 0x9f, 0x8e, 0x17, 0x9c, 0x32, 0x5c, 0x81, 0xbc, 0x7f, 0x63, 0x4a,
+	0xf0, 0x36, 0x59, 0x76, 0x55, 0xff, 0x48, 0x45, 0x53, 0x87, 0x50, 0x36, 0xf7, 0x5c, 0x46, 0x71,
+	0x03, 0xb2, 0x21, 0x20, 0xde, 0xa1, 0x52, 0xbc, 0x43, 0xeb, 0xf4, 0x24, 0x06, 0xe2, 0x43, 0x50,
+	0xa6, 0x26, 0x33, 0x66, 0x9e, 0x1f, 0x1e, 0x1e, 0x85, 0x64, 0xa7, 0x26, 0x3b, 0xf7, 0xfc, 0xb8,
+	0xcc, 0x74, 0x5c, 0xe6, 0xbd, 0x53, 0x76, 0x02, 0xfb, 0x2b, 0xb5, 0x24, 0x93, 0xb0, 0x01, 0xfb,
+	0xef, 0x29, 0x1f, 0x4f, 0xa9, 0x65, 0x44, 0x27, 0xc3, 0x18, 0x7b, 0x81, 0xcb, 0xa3, 0xb1, 0x58,
+	0x8c, 0x82, 0xe1, 0xe9, 0x61, 0x4d, 0x11, 0xba, 0x77, 0x42, 0xbe, 0x86, 0x9d, 0xd5, 0x67, 0xb0,
+	0x04, 0x59, 0x51, 0xc5, 0xcd, 0x85, 0x88, 0xcd, 0x2f, 0x5f, 0x8a, 0xea, 0x09, 0x14, 0x57, 0x1f,
+	0xbb, 0xf0, 0xea, 0xd5, 0x21, 0x4b, 0x5d, 0xee, 0xdb, 0x34, 0x5e, 0xbb, 0x3b, 0x9e, 0xc6, 0x18,
+	0xd5, 0x78, 0xb7, 0xf4, 0xdf, 0xfe, 0x30, 0x98, 0xcf, 0x3d, 0x9f, 0xe3, 0x16, 0x28, 0x84, 0x4e,
+	0x6c, 0xc6, 0xa9, 0x8f, 0x4b, 0x77, 0xfd, 0xaf, 0x5f, 0xbe, 0x33, 0x52, 0x7d, 0x74, 0x94, 0x7a,
+	0x99, 0x6a, 0x0c, 0x20, 0x97, 0x44, 0x70, 0x13, 0xb2, 0x4d, 0xcf, 0x75, 0xe9, 0x98, 0x3f, 0x9c,
+	0xf1, 0xb8, 0x0f, 0x55, 0xcf, 0x9f, 0xd4, 0xa6, 0x8b, 0x39, 0xf5, 0x1d, 0x6a, 0x4d, 0xa8, 0x5f,
+	0x7b, 0x6f, 0x8e, 0x7c, 0x7b, 0x1c, 0xe7, 0x89, 0x0f, 0x9e, 0x5f, 0xff, 0x3f, 0xb1, 0xf9, 0x34,
+	0x18, 0xd5, 0xc6, 0xde, 0xac, 0xbe, 0x04, 0xad, 0x87, 0xd0, 0xf0, 0xc3, 0x87, 0xd5, 0x05, 0x74,
+	0x14, 0x7e, 0x45, 0x7d, 0xfb, 0xf7, 0x00, 0x12, 0x4b, 0x94, 0x70, 0x69, 0x0d, 0x00, 0x00,
 }
@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/crosschannel.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type CrossChannelIntent_Status int32
+
+const (
+	CrossChannelIntent_PREPARED  CrossChannelIntent_Status = 0
+	CrossChannelIntent_COMMITTED CrossChannelIntent_Status = 1
+	CrossChannelIntent_ABORTED   CrossChannelIntent_Status = 2
+)
+
+var CrossChannelIntent_Status_name = map[int32]string{
+	0: "PREPARED",
+	1: "COMMITTED",
+	2: "ABORTED",
+}
+var CrossChannelIntent_Status_value = map[string]int32{
+	"PREPARED":  0,
+	"COMMITTED": 1,
+	"ABORTED":   2,
+}
+
+func (x CrossChannelIntent_Status) String() string {
+	return proto.EnumName(CrossChannelIntent_Status_name, int32(x))
+}
+func (CrossChannelIntent_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crosschannel_9add322b082eb02d, []int{0, 0}
+}
+
+// CrossChannelIntent is the write intent of a transaction of a source channel
+// whose chaincode invoked a chaincode of a target channel hosted by the same
+// peer. It holds the writes of the transaction on both channels, which are
+// applied on each of them once the source channel, acting as the coordinator,
+// decides to commit the intent, or discarded if it decides to abort it. The
+// intent is recorded on both channels by the cross-channel system chaincode.
+type CrossChannelIntent struct {
+	// the id of the transaction which recorded the intent on the source channel
+	TxId          string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	SourceChannel string `protobuf:"bytes,2,opt,name=source_channel,json=sourceChannel,proto3" json:"source_channel,omitempty"`
+	TargetChannel string `protobuf:"bytes,3,opt,name=target_channel,json=targetChannel,proto3" json:"target_channel,omitempty"`
+	// the serialized TxReadWriteSet holding the writes deferred on the source
+	// channel
+	SourceResults []byte `protobuf:"bytes,4,opt,name=source_results,json=sourceResults,proto3" json:"source_results,omitempty"`
+	// the serialized TxReadWriteSet of the simulation of the chaincode invoked
+	// on the target channel
+	TargetResults        []byte                    `protobuf:"bytes,5,opt,name=target_results,json=targetResults,proto3" json:"target_results,omitempty"`
+	Status               CrossChannelIntent_Status `protobuf:"varint,6,opt,name=status,proto3,enum=protos.CrossChannelIntent_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *CrossChannelIntent) Reset()         { *m = CrossChannelIntent{} }
+func (m *CrossChannelIntent) String() string { return proto.CompactTextString(m) }
+func (*CrossChannelIntent) ProtoMessage()    {}
+func (*CrossChannelIntent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crosschannel_9add322b082eb02d, []int{0}
+}
+func (m *CrossChannelIntent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossChannelIntent.Unmarshal(m, b)
+}
+func (m *CrossChannelIntent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossChannelIntent.Marshal(b, m, deterministic)
+}
+func (dst *CrossChannelIntent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossChannelIntent.Merge(dst, src)
+}
+func (m *CrossChannelIntent) XXX_Size() int {
+	return xxx_messageInfo_CrossChannelIntent.Size(m)
+}
+func (m *CrossChannelIntent) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossChannelIntent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossChannelIntent proto.InternalMessageInfo
+
+func (m *CrossChannelIntent) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *CrossChannelIntent) GetSourceChannel() string {
+	if m != nil {
+		return m.SourceChannel
+	}
+	return ""
+}
+
+func (m *CrossChannelIntent) GetTargetChannel() string {
+	if m != nil {
+		return m.TargetChannel
+	}
+	return ""
+}
+
+func (m *CrossChannelIntent) GetSourceResults() []byte {
+	if m != nil {
+		return m.SourceResults
+	}
+	return nil
+}
+
+func (m *CrossChannelIntent) GetTargetResults() []byte {
+	if m != nil {
+		return m.TargetResults
+	}
+	return nil
+}
+
+func (m *CrossChannelIntent) GetStatus() CrossChannelIntent_Status {
+	if m != nil {
+		return m.Status
+	}
+	return CrossChannelIntent_PREPARED
+}
+
+func init() {
+	proto.RegisterType((*CrossChannelIntent)(nil), "protos.CrossChannelIntent")
+	proto.RegisterEnum("protos.CrossChannelIntent_Status", CrossChannelIntent_Status_name, CrossChannelIntent_Status_value)
+}
+
+func init() {
+	proto.RegisterFile("peer/crosschannel.proto", fileDescriptor_crosschannel_9add322b082eb02d)
+}
+
+var fileDescriptor_crosschannel_9add322b082eb02d = []byte{
+	// 295 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xb1, 0x6e, 0xb3, 0x30,
+	0x14, 0x85, 0x7f, 0xf2, 0x27, 0xb4, 0x71, 0x93, 0x28, 0x72, 0x86, 0x32, 0xa6, 0x91, 0x2a, 0xa5,
+	0x8b, 0x91, 0xd2, 0xa9, 0x63, 0x20, 0x0c, 0x0c, 0x51, 0x90, 0x9b, 0xa9, 0x4b, 0x64, 0x8c, 0x0b,
+	0xa8, 0x14, 0x23, 0xdb, 0x48, 0xe9, 0x7b, 0xf5, 0x01, 0x2b, 0xb8, 0x50, 0x21, 0x75, 0xb2, 0x7c,
+	0xce, 0x77, 0xce, 0xd5, 0xb5, 0xd1, 0x7d, 0x25, 0x84, 0x72, 0xb9, 0x92, 0x5a, 0xf3, 0x8c, 0x95,
+	0xa5, 0x28, 0x48, 0xa5, 0xa4, 0x91, 0xd8, 0x6e, 0x0f, 0xbd, 0xf9, 0x1e, 0x21, 0xec, 0x37, 0xb6,
+	0x0f, 0x76, 0x58, 0x1a, 0x51, 0x1a, 0xbc, 0x42, 0x13, 0x73, 0xbd, 0xe4, 0x89, 0x63, 0xad, 0xad,
+	0xed, 0x94, 0x8e, 0xcd, 0x35, 0x4c, 0xf0, 0x23, 0x5a, 0x68, 0x59, 0x2b, 0x2e, 0x2e, 0x5d, 0x97,
+	0x33, 0x6a, 0xdd, 0x39, 0xa8, 0x5d, 0x43, 0x83, 0x19, 0xa6, 0x52, 0x61, 0x7e, 0xb1, 0xff, 0x80,
+	0x81, 0x3a, 0xc0, 0xba, 0x36, 0x25, 0x74, 0x5d, 0x18, 0xed, 0x8c, 0xd7, 0xd6, 0x76, 0xd6, 0xb7,
+	0x51, 0x10, 0x07, 0x6d, 0x3d, 0x36, 0x01, 0x0c, 0xd4, 0x1e, 0x7b, 0x41, 0xb6, 0x36, 0xcc, 0xd4,
+	0xda, 0xb1, 0xd7, 0xd6, 0x76, 0xb1, 0x7b, 0x80, 0x3d, 0x35, 0xf9, 0xbb, 0x1c, 0x79, 0x6d, 0x41,
+	0xda, 0x05, 0x36, 0x3b, 0x64, 0x83, 0x82, 0x67, 0xe8, 0x36, 0xa2, 0x41, 0xb4, 0xa7, 0xc1, 0x61,
+	0xf9, 0x0f, 0xcf, 0xd1, 0xd4, 0x3f, 0x1d, 0x8f, 0xe1, 0xf9, 0x1c, 0x1c, 0x96, 0x16, 0xbe, 0x43,
+	0x37, 0x7b, 0xef, 0x44, 0x9b, 0xcb, 0xc8, 0x4b, 0xd0, 0x46, 0xaa, 0x94, 0x64, 0x5f, 0x95, 0x50,
+	0x85, 0x48, 0x52, 0xa1, 0xc8, 0x3b, 0x8b, 0x55, 0xce, 0xfb, 0xb1, 0xcd, 0xbb, 0x7b, 0xab, 0xe1,
+	0xf0, 0x88, 0xf1, 0x0f, 0x96, 0x8a, 0xb7, 0xa7, 0x34, 0x37, 0x59, 0x1d, 0x13, 0x2e, 0x3f, 0xdd,
+	0x41, 0xde, 0x85, 0xbc, 0x0b, 0x79, 0xb7, 0xc9, 0xc7, 0xf0, 0x49, 0xcf, 0x3f, 0x03, 0x00, 0x38,
+	0x52, 0x8f, 0x87, 0xc6, 0x01, 0x00, 0x00,
+}
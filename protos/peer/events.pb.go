@@ -39,7 +39,7 @@ func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -98,7 +98,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -231,7 +231,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +271,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -298,6 +298,120 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// ChaincodeEventInfo is a chaincode event set by a valid transaction, along with
+// the position of the transaction in the blockchain
+type ChaincodeEventInfo struct {
+	BlockNumber    uint64          `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxNumber       uint64          `protobuf:"varint,2,opt,name=tx_number,json=txNumber,proto3" json:"tx_number,omitempty"`
+	ChaincodeEvent *ChaincodeEvent `protobuf:"bytes,3,opt,name=chaincode_event,json=chaincodeEvent,proto3" json:"chaincode_event,omitempty"`
+	// position of the event among the events of the transaction
+	EventNumber          uint64   `protobuf:"varint,4,opt,name=event_number,json=eventNumber,proto3" json:"event_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventInfo) Reset()         { *m = ChaincodeEventInfo{} }
+func (m *ChaincodeEventInfo) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventInfo) ProtoMessage()    {}
+func (*ChaincodeEventInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{4}
+}
+func (m *ChaincodeEventInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventInfo.Unmarshal(m, b)
+}
+func (m *ChaincodeEventInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventInfo.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventInfo.Merge(dst, src)
+}
+func (m *ChaincodeEventInfo) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventInfo.Size(m)
+}
+func (m *ChaincodeEventInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventInfo proto.InternalMessageInfo
+
+func (m *ChaincodeEventInfo) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventInfo) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventInfo) GetChaincodeEvent() *ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvent
+	}
+	return nil
+}
+
+func (m *ChaincodeEventInfo) GetEventNumber() uint64 {
+	if m != nil {
+		return m.EventNumber
+	}
+	return 0
+}
+
+// ChaincodeEventsPage is a page of the chaincode events returned by a query of
+// the chaincode event index. If more events match the query, the bookmark is set
+// and can be passed to the query to retrieve the next page.
+type ChaincodeEventsPage struct {
+	Events               []*ChaincodeEventInfo `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Bookmark             string                `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ChaincodeEventsPage) Reset()         { *m = ChaincodeEventsPage{} }
+func (m *ChaincodeEventsPage) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsPage) ProtoMessage()    {}
+func (*ChaincodeEventsPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{5}
+}
+func (m *ChaincodeEventsPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsPage.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsPage.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsPage.Merge(dst, src)
+}
+func (m *ChaincodeEventsPage) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsPage.Size(m)
+}
+func (m *ChaincodeEventsPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsPage proto.InternalMessageInfo
+
+func (m *ChaincodeEventsPage) GetEvents() []*ChaincodeEventInfo {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsPage) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
@@ -314,7 +428,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_71c8e68e426b3bcb, []int{6}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -477,6 +591,8 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*ChaincodeEventInfo)(nil), "protos.ChaincodeEventInfo")
+	proto.RegisterType((*ChaincodeEventsPage)(nil), "protos.ChaincodeEventsPage")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 }
 
@@ -661,43 +777,49 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_71c8e68e426b3bcb) }
+
+var fileDescriptor_events_71c8e68e426b3bcb = []byte{
+	// 653 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6e, 0xd3, 0x4a,
+	0x10, 0x8e, 0x4f, 0x73, 0x72, 0x9a, 0xc9, 0x49, 0xda, 0x6e, 0x68, 0x1b, 0xb9, 0x42, 0x2d, 0x96,
+	0x40, 0xe1, 0x26, 0x46, 0xe6, 0x8e, 0x0b, 0x10, 0xe9, 0x8f, 0x52, 0x09, 0xa1, 0xca, 0x14, 0x2e,
+	0x7a, 0x81, 0xb5, 0xb6, 0x27, 0x8e, 0x89, 0xe3, 0xb5, 0xbc, 0x9b, 0x28, 0x7d, 0x04, 0xde, 0x80,
+	0x67, 0xe0, 0x09, 0x78, 0x34, 0x2e, 0x91, 0x77, 0xbd, 0xf9, 0x6b, 0x8b, 0xd4, 0x2b, 0x7b, 0x67,
+	0xbe, 0x6f, 0xbe, 0x99, 0xf1, 0xb7, 0x86, 0xbd, 0x0c, 0x31, 0xb7, 0x71, 0x86, 0xa9, 0xe0, 0xbd,
+	0x2c, 0x67, 0x82, 0x91, 0x9a, 0x7c, 0x70, 0xb3, 0x1d, 0xb0, 0xc9, 0x84, 0xa5, 0xb6, 0x7a, 0xa8,
+	0xa4, 0x79, 0x1c, 0x31, 0x16, 0x25, 0x68, 0xcb, 0x93, 0x3f, 0x1d, 0xda, 0x22, 0x9e, 0x20, 0x17,
+	0x74, 0x92, 0x95, 0x00, 0x53, 0x16, 0x0c, 0x46, 0x34, 0x4e, 0x03, 0x16, 0xa2, 0x27, 0x4b, 0x97,
+	0xb9, 0x03, 0x99, 0x13, 0x39, 0x4d, 0x39, 0x0d, 0x44, 0xac, 0x8b, 0x5a, 0x3f, 0x0c, 0x68, 0x5e,
+	0xc4, 0x89, 0xc0, 0x1c, 0xc3, 0x7e, 0xc2, 0x82, 0x31, 0x79, 0x0a, 0x10, 0x8c, 0x68, 0x9a, 0x62,
+	0xe2, 0xc5, 0x61, 0xc7, 0x38, 0x31, 0xba, 0x75, 0xb7, 0x5e, 0x46, 0x2e, 0x43, 0x72, 0x00, 0xb5,
+	0x74, 0x3a, 0xf1, 0x31, 0xef, 0xfc, 0x73, 0x62, 0x74, 0xab, 0x6e, 0x79, 0x22, 0x57, 0xb0, 0x3f,
+	0x2c, 0xeb, 0x78, 0x2b, 0x32, 0xbc, 0x53, 0x3d, 0xd9, 0xea, 0x36, 0x9c, 0x23, 0xa5, 0xc7, 0x7b,
+	0x5a, 0xec, 0x7a, 0x89, 0x71, 0x9f, 0x0c, 0xef, 0x06, 0xb9, 0xf5, 0xdb, 0x80, 0xf6, 0x3d, 0x68,
+	0x42, 0xa0, 0x2a, 0xe6, 0x8b, 0xd6, 0xe4, 0x3b, 0x79, 0x01, 0x55, 0x71, 0x9b, 0xa1, 0xec, 0xa9,
+	0xe5, 0x90, 0x5e, 0xb9, 0xb8, 0x01, 0xd2, 0x10, 0xf3, 0xeb, 0xdb, 0x0c, 0x5d, 0x99, 0x27, 0x17,
+	0x40, 0xc4, 0xdc, 0x9b, 0xd1, 0x24, 0x0e, 0x69, 0x51, 0xcc, 0x2b, 0x16, 0xd5, 0xd9, 0x92, 0xac,
+	0x8e, 0x6e, 0xf1, 0x7a, 0xfe, 0x65, 0x01, 0x38, 0x65, 0x21, 0xba, 0xbb, 0x62, 0x23, 0x42, 0x3e,
+	0x43, 0x7b, 0x65, 0x48, 0x6f, 0x39, 0xab, 0xd1, 0x6d, 0x38, 0xd6, 0x5f, 0x66, 0x7d, 0xaf, 0x90,
+	0x83, 0x8a, 0x4b, 0xc4, 0x9d, 0x68, 0xbf, 0x06, 0xd5, 0x33, 0x2a, 0xa8, 0xf5, 0x0d, 0xcc, 0x87,
+	0xb9, 0xe4, 0x03, 0xec, 0x2d, 0x3f, 0xb2, 0x96, 0x36, 0xe4, 0x9a, 0x8f, 0x37, 0xa5, 0x4f, 0x35,
+	0x50, 0x91, 0xdd, 0xdd, 0x60, 0x3d, 0xc0, 0xad, 0x1b, 0x38, 0x7c, 0x00, 0x4c, 0xde, 0xc1, 0xce,
+	0x86, 0x9b, 0xe4, 0xd2, 0x1b, 0xce, 0x81, 0x96, 0x59, 0x30, 0xce, 0x8b, 0xac, 0xdb, 0x0a, 0xd6,
+	0xce, 0xd6, 0x2f, 0x03, 0xc8, 0x3a, 0xe4, 0x32, 0x1d, 0x32, 0xf2, 0x0c, 0xfe, 0xf7, 0x0b, 0xaf,
+	0x79, 0xa5, 0x93, 0x0c, 0xe9, 0xa4, 0x86, 0x8c, 0x7d, 0x54, 0x76, 0x3a, 0x82, 0xba, 0x98, 0x7b,
+	0x6b, 0x4e, 0xdb, 0x16, 0xf3, 0x32, 0x79, 0x4f, 0x5f, 0x5b, 0x8f, 0xe9, 0xab, 0x68, 0x40, 0xd2,
+	0xb4, 0x40, 0x55, 0x35, 0x20, 0x63, 0x4a, 0xc3, 0x42, 0x68, 0xaf, 0x17, 0xe1, 0x57, 0x34, 0x42,
+	0xe2, 0x40, 0x4d, 0xdd, 0xd8, 0x72, 0xe1, 0xe6, 0xfd, 0x8a, 0xc5, 0x98, 0x6e, 0x89, 0x24, 0x26,
+	0x6c, 0xfb, 0x8c, 0x8d, 0x27, 0x34, 0x1f, 0xcb, 0x51, 0xea, 0xee, 0xe2, 0x6c, 0xfd, 0x34, 0x60,
+	0xe7, 0x0c, 0x93, 0x78, 0x86, 0xb9, 0x8b, 0x3c, 0x63, 0x29, 0x47, 0xd2, 0x85, 0x1a, 0x17, 0x54,
+	0x4c, 0xb9, 0x5c, 0x4c, 0xcb, 0x69, 0x69, 0x3b, 0x7f, 0x92, 0xd1, 0x41, 0xc5, 0x2d, 0xf3, 0xe4,
+	0x39, 0xfc, 0x2b, 0x97, 0x26, 0xcb, 0x36, 0x9c, 0xa6, 0x06, 0xca, 0x9b, 0x3c, 0xa8, 0xb8, 0x2a,
+	0x4b, 0xde, 0x42, 0x6b, 0x71, 0x37, 0x15, 0x5e, 0xad, 0x6b, 0x7f, 0xd3, 0x2d, 0x9a, 0xd7, 0x1c,
+	0xae, 0x06, 0x0a, 0x5b, 0x16, 0x77, 0xc8, 0xf9, 0x6e, 0xc0, 0x7f, 0x65, 0xb3, 0xe4, 0xcd, 0xf2,
+	0x75, 0x57, 0xcb, 0x9e, 0xa7, 0x33, 0x4c, 0x58, 0x86, 0xe6, 0xa1, 0x2e, 0xbc, 0x31, 0x9a, 0x55,
+	0xe9, 0x1a, 0xaf, 0x0c, 0xd2, 0x5f, 0xcc, 0xac, 0x85, 0x1f, 0x5d, 0xa3, 0xff, 0x15, 0x2c, 0x96,
+	0x47, 0xbd, 0xd1, 0x6d, 0x86, 0x79, 0x82, 0x61, 0x84, 0x79, 0x6f, 0x48, 0xfd, 0x3c, 0x0e, 0x34,
+	0xad, 0xf8, 0xe1, 0xf5, 0x9b, 0xfa, 0xd3, 0x05, 0x63, 0x1a, 0xe1, 0xcd, 0xcb, 0x28, 0x16, 0xa3,
+	0xa9, 0x5f, 0x68, 0xd9, 0x2b, 0x4c, 0x5b, 0x31, 0xd5, 0x9f, 0x95, 0xdb, 0x05, 0xd3, 0x57, 0xbf,
+	0xe2, 0xd7, 0x7f, 0x06, 0x00, 0x3a, 0xaf, 0xae, 0xcf, 0xa6, 0x05, 0x00, 0x00,
 }
@@ -34,7 +34,7 @@ func (m *PeerID) Reset()         { *m = PeerID{} }
 func (m *PeerID) String() string { return proto.CompactTextString(m) }
 func (*PeerID) ProtoMessage()    {}
 func (*PeerID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_peer_f225b6e437338532, []int{0}
+	return fileDescriptor_peer_28293db25e9609a4, []int{0}
 }
 func (m *PeerID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerID.Unmarshal(m, b)
@@ -73,7 +73,7 @@ func (m *PeerEndpoint) Reset()         { *m = PeerEndpoint{} }
 func (m *PeerEndpoint) String() string { return proto.CompactTextString(m) }
 func (*PeerEndpoint) ProtoMessage()    {}
 func (*PeerEndpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_peer_f225b6e437338532, []int{1}
+	return fileDescriptor_peer_28293db25e9609a4, []int{1}
 }
 func (m *PeerEndpoint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerEndpoint.Unmarshal(m, b)
@@ -107,9 +107,61 @@ func (m *PeerEndpoint) GetAddress() string {
 	return ""
 }
 
+// EvaluateResponse is the result of the evaluation of a proposal, which only
+// reads the state of the ledger and isn't endorsed
+type EvaluateResponse struct {
+	// A response message indicating whether the evaluation was successful
+	Response *Response `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// The height of the ledger when the proposal was evaluated. The state read
+	// by the chaincode includes at least all the blocks below this height.
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_peer_28293db25e9609a4, []int{2}
+}
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (dst *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(dst, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *EvaluateResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*PeerID)(nil), "protos.PeerID")
 	proto.RegisterType((*PeerEndpoint)(nil), "protos.PeerEndpoint")
+	proto.RegisterType((*EvaluateResponse)(nil), "protos.EvaluateResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -184,24 +236,91 @@ var _Endorser_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/peer.proto",
 }
 
-func init() { proto.RegisterFile("peer/peer.proto", fileDescriptor_peer_f225b6e437338532) }
-
-var fileDescriptor_peer_f225b6e437338532 = []byte{
-	// 246 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x4f, 0x4b, 0xc4, 0x30,
-	0x10, 0xc5, 0xdd, 0x22, 0xab, 0x8e, 0xe2, 0x42, 0x04, 0x29, 0x65, 0x11, 0xe9, 0x49, 0x2f, 0x29,
-	0xac, 0xdf, 0x40, 0x2c, 0xe8, 0xc9, 0x5a, 0x6f, 0x5e, 0xa4, 0x6d, 0xc6, 0x6e, 0x60, 0x37, 0x13,
-	0x66, 0xea, 0xc1, 0x6f, 0x2f, 0x4d, 0x5a, 0x71, 0x2f, 0xf9, 0xf3, 0xde, 0x6f, 0x5e, 0x26, 0x03,
-	0x2b, 0x8f, 0xc8, 0xc5, 0xb8, 0x68, 0xcf, 0x34, 0x90, 0x5a, 0x86, 0x4d, 0xb2, 0xab, 0x68, 0x30,
-	0x79, 0x92, 0x66, 0x17, 0xcd, 0x6c, 0x7d, 0x20, 0x7e, 0x32, 0x8a, 0x27, 0x27, 0x18, 0xdd, 0x7c,
-	0x0d, 0xcb, 0x0a, 0x91, 0x5f, 0x9e, 0x94, 0x82, 0x63, 0xd7, 0xec, 0x31, 0x5d, 0xdc, 0x2e, 0xee,
-	0xce, 0xea, 0x70, 0xce, 0x9f, 0xe1, 0x62, 0x74, 0x4b, 0x67, 0x3c, 0x59, 0x37, 0xa8, 0x1b, 0x48,
-	0xac, 0x09, 0xc4, 0xf9, 0xe6, 0x32, 0x26, 0x88, 0x8e, 0xf5, 0x75, 0x62, 0x8d, 0x4a, 0xe1, 0xa4,
-	0x31, 0x86, 0x51, 0x24, 0x4d, 0x42, 0xcc, 0x7c, 0xdd, 0xbc, 0xc1, 0x69, 0xe9, 0x0c, 0xb1, 0x20,
-	0xab, 0x12, 0x56, 0x15, 0x53, 0x87, 0x22, 0xd5, 0xd4, 0x95, 0xba, 0x9e, 0xc3, 0xde, 0x6d, 0xef,
-	0xd0, 0xcc, 0x7a, 0x96, 0xfe, 0x3d, 0x32, 0x29, 0xf5, 0xd4, 0x7e, 0x7e, 0xf4, 0xf8, 0x0a, 0x39,
-	0x71, 0xaf, 0xb7, 0x3f, 0x1e, 0x79, 0x87, 0xa6, 0x47, 0xd6, 0x5f, 0x4d, 0xcb, 0xb6, 0x9b, 0x6b,
-	0xc6, 0x8f, 0x7f, 0xdc, 0xf7, 0x76, 0xd8, 0x7e, 0xb7, 0xba, 0xa3, 0x7d, 0xf1, 0x0f, 0x2d, 0x22,
-	0x5a, 0x44, 0x34, 0x0c, 0xb3, 0x8d, 0x63, 0x7c, 0xf8, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xef, 0x32,
-	0xf2, 0x1f, 0x60, 0x01, 0x00, 0x00,
+// EvaluatorClient is the client API for Evaluator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EvaluatorClient interface {
+	Evaluate(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*EvaluateResponse, error)
+}
+
+type evaluatorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEvaluatorClient(cc *grpc.ClientConn) EvaluatorClient {
+	return &evaluatorClient{cc}
+}
+
+func (c *evaluatorClient) Evaluate(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/protos.Evaluator/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvaluatorServer is the server API for Evaluator service.
+type EvaluatorServer interface {
+	Evaluate(context.Context, *SignedProposal) (*EvaluateResponse, error)
+}
+
+func RegisterEvaluatorServer(s *grpc.Server, srv EvaluatorServer) {
+	s.RegisterService(&_Evaluator_serviceDesc, srv)
+}
+
+func _Evaluator_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluatorServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Evaluator/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluatorServer).Evaluate(ctx, req.(*SignedProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Evaluator_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Evaluator",
+	HandlerType: (*EvaluatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _Evaluator_Evaluate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "peer/peer.proto",
+}
+
+func init() { proto.RegisterFile("peer/peer.proto", fileDescriptor_peer_28293db25e9609a4) }
+
+var fileDescriptor_peer_28293db25e9609a4 = []byte{
+	// 304 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xcf, 0x4b, 0xfb, 0x40,
+	0x10, 0xc5, 0xdb, 0x52, 0xfa, 0x6d, 0xe7, 0x2b, 0xb6, 0xac, 0x50, 0x42, 0x29, 0x22, 0x7b, 0x52,
+	0x90, 0x04, 0xea, 0xdd, 0x83, 0x18, 0x50, 0x3c, 0x58, 0xe3, 0x45, 0xbc, 0xc8, 0xb6, 0x3b, 0x26,
+	0x0b, 0xed, 0xee, 0x32, 0x9b, 0x0a, 0xfe, 0xf7, 0xd2, 0xfd, 0x51, 0xd4, 0x8b, 0x97, 0x24, 0x33,
+	0xef, 0xcd, 0x27, 0x6f, 0x18, 0x18, 0x5b, 0x44, 0x2a, 0xf6, 0x8f, 0xdc, 0x92, 0x69, 0x0d, 0x1b,
+	0xf8, 0x97, 0x9b, 0x9d, 0x04, 0x81, 0x8c, 0x35, 0x4e, 0x6c, 0x82, 0x38, 0x9b, 0xff, 0x68, 0xbe,
+	0x11, 0x3a, 0x6b, 0xb4, 0xc3, 0xa0, 0xf2, 0x39, 0x0c, 0x96, 0x88, 0x74, 0x7f, 0xcb, 0x18, 0xf4,
+	0xb5, 0xd8, 0x62, 0xd6, 0x3d, 0xeb, 0x9e, 0x8f, 0x2a, 0xff, 0xcd, 0xef, 0xe0, 0x68, 0xaf, 0x96,
+	0x5a, 0x5a, 0xa3, 0x74, 0xcb, 0x4e, 0xa1, 0xa7, 0xa4, 0x77, 0xfc, 0x5f, 0x1c, 0x07, 0x82, 0xcb,
+	0xc3, 0x7c, 0xd5, 0x53, 0x92, 0x65, 0xf0, 0x4f, 0x48, 0x49, 0xe8, 0x5c, 0xd6, 0xf3, 0x98, 0x54,
+	0xf2, 0x17, 0x98, 0x94, 0x1f, 0x62, 0xb3, 0x13, 0x2d, 0x56, 0x31, 0x01, 0xbb, 0x84, 0x61, 0x4a,
+	0x13, 0x99, 0x93, 0xc4, 0x4c, 0x9e, 0xea, 0xe0, 0x60, 0x53, 0x18, 0x34, 0xa8, 0xea, 0xa6, 0xf5,
+	0xe8, 0x7e, 0x15, 0xab, 0xc5, 0x13, 0x0c, 0x4b, 0x2d, 0x0d, 0x39, 0x24, 0x56, 0xc2, 0x78, 0x49,
+	0x66, 0x8d, 0xce, 0x2d, 0xe3, 0xbe, 0x6c, 0x9a, 0x90, 0xcf, 0xaa, 0xd6, 0x28, 0x53, 0x7f, 0x96,
+	0x1d, 0xe2, 0xc7, 0x4e, 0xfa, 0x25, 0xef, 0x2c, 0x1e, 0x60, 0x14, 0xc3, 0x1a, 0x62, 0xd7, 0x30,
+	0x8c, 0x05, 0xfe, 0x0d, 0xfb, 0xbd, 0x23, 0xef, 0xdc, 0x3c, 0x02, 0x37, 0x54, 0xe7, 0xcd, 0xa7,
+	0x45, 0xda, 0xa0, 0xac, 0x91, 0xf2, 0x77, 0xb1, 0x22, 0xb5, 0x4e, 0x33, 0x16, 0x91, 0x5e, 0x2f,
+	0x6a, 0xd5, 0x36, 0xbb, 0x55, 0xbe, 0x36, 0xdb, 0xe2, 0x9b, 0xb5, 0x08, 0xd6, 0x22, 0x58, 0xfd,
+	0xcd, 0x57, 0xe1, 0xda, 0x57, 0x5f, 0x03, 0x00, 0x2d, 0xe6, 0x2e, 0xe8, 0x07, 0x02, 0x00, 0x00,
 }
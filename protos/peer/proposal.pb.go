@@ -27,22 +27,22 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // When an endorser receives a SignedProposal message, it should verify the
 // signature over the proposal bytes. This verification requires the following
 // steps:
-// 1. Verification of the validity of the certificate that was used to produce
-//    the signature.  The certificate will be available once proposalBytes has
-//    been unmarshalled to a Proposal message, and Proposal.header has been
-//    unmarshalled to a Header message. While this unmarshalling-before-verifying
-//    might not be ideal, it is unavoidable because i) the signature needs to also
-//    protect the signing certificate; ii) it is desirable that Header is created
-//    once by the client and never changed (for the sake of accountability and
-//    non-repudiation). Note also that it is actually impossible to conclusively
-//    verify the validity of the certificate included in a Proposal, because the
-//    proposal needs to first be endorsed and ordered with respect to certificate
-//    expiration transactions. Still, it is useful to pre-filter expired
-//    certificates at this stage.
-// 2. Verification that the certificate is trusted (signed by a trusted CA) and
-//    that it is allowed to transact with us (with respect to some ACLs);
-// 3. Verification that the signature on proposalBytes is valid;
-// 4. Detect replay attacks;
+//  1. Verification of the validity of the certificate that was used to produce
+//     the signature.  The certificate will be available once proposalBytes has
+//     been unmarshalled to a Proposal message, and Proposal.header has been
+//     unmarshalled to a Header message. While this unmarshalling-before-verifying
+//     might not be ideal, it is unavoidable because i) the signature needs to also
+//     protect the signing certificate; ii) it is desirable that Header is created
+//     once by the client and never changed (for the sake of accountability and
+//     non-repudiation). Note also that it is actually impossible to conclusively
+//     verify the validity of the certificate included in a Proposal, because the
+//     proposal needs to first be endorsed and ordered with respect to certificate
+//     expiration transactions. Still, it is useful to pre-filter expired
+//     certificates at this stage.
+//  2. Verification that the certificate is trusted (signed by a trusted CA) and
+//     that it is allowed to transact with us (with respect to some ACLs);
+//  3. Verification that the signature on proposalBytes is valid;
+//  4. Detect replay attacks;
 type SignedProposal struct {
 	// The bytes of Proposal
 	ProposalBytes []byte `protobuf:"bytes,1,opt,name=proposal_bytes,json=proposalBytes,proto3" json:"proposal_bytes,omitempty"`
@@ -59,7 +59,7 @@ func (m *SignedProposal) Reset()         { *m = SignedProposal{} }
 func (m *SignedProposal) String() string { return proto.CompactTextString(m) }
 func (*SignedProposal) ProtoMessage()    {}
 func (*SignedProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{0}
+	return fileDescriptor_proposal_54ff840fc68d8d65, []int{0}
 }
 func (m *SignedProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedProposal.Unmarshal(m, b)
@@ -94,24 +94,24 @@ func (m *SignedProposal) GetSignature() []byte {
 }
 
 // A Proposal is sent to an endorser for endorsement.  The proposal contains:
-// 1. A header which should be unmarshaled to a Header message.  Note that
-//    Header is both the header of a Proposal and of a Transaction, in that i)
-//    both headers should be unmarshaled to this message; and ii) it is used to
-//    compute cryptographic hashes and signatures.  The header has fields common
-//    to all proposals/transactions.  In addition it has a type field for
-//    additional customization. An example of this is the ChaincodeHeaderExtension
-//    message used to extend the Header for type CHAINCODE.
-// 2. A payload whose type depends on the header's type field.
-// 3. An extension whose type depends on the header's type field.
+//  1. A header which should be unmarshaled to a Header message.  Note that
+//     Header is both the header of a Proposal and of a Transaction, in that i)
+//     both headers should be unmarshaled to this message; and ii) it is used to
+//     compute cryptographic hashes and signatures.  The header has fields common
+//     to all proposals/transactions.  In addition it has a type field for
+//     additional customization. An example of this is the ChaincodeHeaderExtension
+//     message used to extend the Header for type CHAINCODE.
+//  2. A payload whose type depends on the header's type field.
+//  3. An extension whose type depends on the header's type field.
 //
 // Let us see an example. For type CHAINCODE (see the Header message),
 // we have the following:
-// 1. The header is a Header message whose extensions field is a
-//    ChaincodeHeaderExtension message.
-// 2. The payload is a ChaincodeProposalPayload message.
-// 3. The extension is a ChaincodeAction that might be used to ask the
-//    endorsers to endorse a specific ChaincodeAction, thus emulating the
-//    submitting peer model.
+//  1. The header is a Header message whose extensions field is a
+//     ChaincodeHeaderExtension message.
+//  2. The payload is a ChaincodeProposalPayload message.
+//  3. The extension is a ChaincodeAction that might be used to ask the
+//     endorsers to endorse a specific ChaincodeAction, thus emulating the
+//     submitting peer model.
 type Proposal struct {
 	// The header of the proposal. It is the bytes of the Header
 	Header []byte `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -131,7 +131,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{1}
+	return fileDescriptor_proposal_54ff840fc68d8d65, []int{1}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proposal.Unmarshal(m, b)
@@ -199,7 +199,7 @@ func (m *ChaincodeHeaderExtension) Reset()         { *m = ChaincodeHeaderExtensi
 func (m *ChaincodeHeaderExtension) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeHeaderExtension) ProtoMessage()    {}
 func (*ChaincodeHeaderExtension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{2}
+	return fileDescriptor_proposal_54ff840fc68d8d65, []int{2}
 }
 func (m *ChaincodeHeaderExtension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeHeaderExtension.Unmarshal(m, b)
@@ -255,7 +255,7 @@ func (m *ChaincodeProposalPayload) Reset()         { *m = ChaincodeProposalPaylo
 func (m *ChaincodeProposalPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()    {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{3}
+	return fileDescriptor_proposal_54ff840fc68d8d65, []int{3}
 }
 func (m *ChaincodeProposalPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeProposalPayload.Unmarshal(m, b)
@@ -308,17 +308,21 @@ type ChaincodeAction struct {
 	ChaincodeId *ChaincodeID `protobuf:"bytes,4,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
 	// This field contains the token expectation generated by the chaincode
 	// executing this invocation
-	TokenExpectation     *token.TokenExpectation `protobuf:"bytes,5,opt,name=token_expectation,json=tokenExpectation,proto3" json:"token_expectation,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	TokenExpectation *token.TokenExpectation `protobuf:"bytes,5,opt,name=token_expectation,json=tokenExpectation,proto3" json:"token_expectation,omitempty"`
+	// This field contains all the events generated by the chaincode executing
+	// this invocation, when there is more than one of them. The events field
+	// then holds the first one, for the consumers not knowing of this field.
+	ChaincodeEvents      []*ChaincodeEvent `protobuf:"bytes,6,rep,name=chaincode_events,json=chaincodeEvents,proto3" json:"chaincode_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ChaincodeAction) Reset()         { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()    {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{4}
+	return fileDescriptor_proposal_54ff840fc68d8d65, []int{4}
 }
 func (m *ChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeAction.Unmarshal(m, b)
@@ -373,6 +377,13 @@ func (m *ChaincodeAction) GetTokenExpectation() *token.TokenExpectation {
 	return nil
 }
 
+func (m *ChaincodeAction) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignedProposal)(nil), "protos.SignedProposal")
 	proto.RegisterType((*Proposal)(nil), "protos.Proposal")
@@ -382,39 +393,40 @@ func init() {
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
 }
 
-func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_2be65988745cf952) }
-
-var fileDescriptor_proposal_2be65988745cf952 = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x57, 0x5a, 0x36, 0x36, 0xb7, 0x6c, 0xad, 0x37, 0xa1, 0xa8, 0xda, 0x61, 0x8a, 0x84, 0x34,
-	0x24, 0x48, 0xa4, 0x22, 0x21, 0xc4, 0x05, 0x51, 0xa8, 0xc4, 0x0e, 0x48, 0x53, 0x18, 0x3b, 0xec,
-	0x52, 0x9c, 0xe4, 0x91, 0x5a, 0x0d, 0xb6, 0x65, 0x3b, 0xd5, 0x72, 0xe4, 0xe3, 0xf1, 0x6d, 0xf8,
-	0x08, 0xc8, 0xb1, 0x9d, 0x76, 0xed, 0x85, 0x53, 0xf2, 0xde, 0xef, 0xfd, 0x7e, 0xef, 0xaf, 0xd1,
-	0x99, 0x00, 0x90, 0x89, 0x90, 0x5c, 0x70, 0x45, 0xaa, 0x58, 0x48, 0xae, 0x39, 0x3e, 0x6c, 0x3f,
-	0x6a, 0x72, 0xde, 0x82, 0xf9, 0x92, 0x50, 0x96, 0xf3, 0x02, 0x2c, 0x3a, 0xb9, 0x78, 0x44, 0x59,
-	0x48, 0x50, 0x82, 0x33, 0xe5, 0xd1, 0x50, 0xf3, 0x15, 0xb0, 0x04, 0x1e, 0x04, 0xe4, 0x9a, 0x68,
-	0xca, 0x99, 0xb2, 0x48, 0xf4, 0x1d, 0x9d, 0x7c, 0xa3, 0x25, 0x83, 0xe2, 0xc6, 0x51, 0xf1, 0x0b,
-	0x74, 0xd2, 0xc9, 0x64, 0x8d, 0x06, 0x15, 0x06, 0x97, 0xc1, 0xd5, 0x30, 0x7d, 0xe6, 0xbd, 0x33,
-	0xe3, 0xc4, 0x17, 0xe8, 0x58, 0xd1, 0x92, 0x11, 0x5d, 0x4b, 0x08, 0x7b, 0x6d, 0xc4, 0xc6, 0x11,
-	0xdd, 0xa3, 0xa3, 0x4e, 0xf0, 0x39, 0x3a, 0x5c, 0x02, 0x29, 0x40, 0x3a, 0x21, 0x67, 0xe1, 0x10,
-	0x3d, 0x15, 0xa4, 0xa9, 0x38, 0x29, 0x1c, 0xdf, 0x9b, 0x46, 0x1b, 0x1e, 0x34, 0x30, 0x45, 0x39,
-	0x0b, 0xfb, 0x56, 0xbb, 0x73, 0x44, 0xbf, 0x03, 0x14, 0x7e, 0xf2, 0xed, 0x7f, 0x69, 0xb5, 0xe6,
-	0x1e, 0xc4, 0xaf, 0x11, 0x76, 0x2a, 0x8b, 0x35, 0x55, 0x34, 0xa3, 0x15, 0xd5, 0x8d, 0x4b, 0x3c,
-	0x76, 0xc8, 0x5d, 0x07, 0xe0, 0xb7, 0x68, 0xd8, 0x4d, 0x72, 0x41, 0x6d, 0x21, 0x83, 0xe9, 0x99,
-	0x1d, 0x8e, 0x8a, 0xbb, 0x34, 0xd7, 0x9f, 0xd3, 0x41, 0x17, 0x78, 0x5d, 0x44, 0x7f, 0xb6, 0x6b,
-	0xf0, 0x9d, 0xde, 0xb8, 0xf2, 0xcf, 0xd1, 0x01, 0x65, 0xa2, 0xd6, 0x2e, 0xad, 0x35, 0xf0, 0x1d,
-	0x1a, 0xde, 0x4a, 0xc2, 0x14, 0x05, 0xa6, 0xbf, 0x12, 0x11, 0xf6, 0x2e, 0xfb, 0x57, 0x83, 0xe9,
-	0x74, 0x2f, 0xd5, 0x8e, 0x5a, 0xbc, 0x4d, 0x9a, 0x33, 0x2d, 0x9b, 0xf4, 0x91, 0xce, 0xe4, 0x03,
-	0x1a, 0xef, 0x85, 0xe0, 0x11, 0xea, 0xaf, 0xc0, 0xf6, 0x7d, 0x9c, 0x9a, 0x5f, 0x53, 0xd4, 0x9a,
-	0x54, 0xb5, 0xdf, 0x95, 0x35, 0xde, 0xf7, 0xde, 0x05, 0xd1, 0xdf, 0x00, 0x9d, 0x76, 0xd9, 0x3f,
-	0xe6, 0xe6, 0x3a, 0xcc, 0x6e, 0x24, 0xa8, 0xba, 0xd2, 0x7e, 0xfb, 0xde, 0x34, 0xdb, 0x84, 0x35,
-	0x30, 0xad, 0x9c, 0x90, 0xb3, 0xf0, 0x2b, 0x74, 0xe4, 0x8f, 0xae, 0x5d, 0xd9, 0x60, 0x3a, 0xf2,
-	0xad, 0xa5, 0xce, 0x9f, 0x76, 0x11, 0x7b, 0x73, 0x7f, 0xf2, 0x7f, 0x73, 0xc7, 0x73, 0x34, 0x6e,
-	0x4f, 0x79, 0xb1, 0x75, 0xca, 0xe1, 0x41, 0x4b, 0x0e, 0x3d, 0xf9, 0xd6, 0x04, 0xcc, 0x37, 0x78,
-	0x3a, 0xd2, 0x3b, 0x9e, 0xd9, 0x0f, 0x14, 0x71, 0x59, 0xc6, 0xcb, 0x46, 0x80, 0xac, 0xa0, 0x28,
-	0x41, 0xc6, 0x3f, 0x49, 0x26, 0x69, 0xee, 0x35, 0xcc, 0x6b, 0x9a, 0x9d, 0x6e, 0x56, 0x91, 0xaf,
-	0x48, 0x09, 0xf7, 0x2f, 0x4b, 0xaa, 0x97, 0x75, 0x16, 0xe7, 0xfc, 0x57, 0xb2, 0xc5, 0x4d, 0x2c,
-	0x37, 0xb1, 0xdc, 0xc4, 0x70, 0x33, 0xfb, 0x5a, 0xdf, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xaa,
-	0xf2, 0x0a, 0xf6, 0xcb, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_54ff840fc68d8d65) }
+
+var fileDescriptor_proposal_54ff840fc68d8d65 = []byte{
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6a, 0x1b, 0x31,
+	0x10, 0xc6, 0x76, 0xe3, 0x26, 0xb2, 0x1b, 0xdb, 0x4a, 0x08, 0xc2, 0xe4, 0x10, 0x16, 0x0a, 0x29,
+	0xb4, 0xbb, 0xe0, 0x42, 0x29, 0xbd, 0x94, 0xb8, 0x35, 0x34, 0x87, 0x42, 0xd8, 0xa6, 0x39, 0xe4,
+	0xe2, 0xca, 0xbb, 0xd3, 0xb5, 0xf0, 0x56, 0x12, 0x92, 0x6c, 0xe2, 0x63, 0x9f, 0xa9, 0x4f, 0xd1,
+	0xb7, 0x2a, 0x5a, 0x49, 0xeb, 0xbf, 0x4b, 0x4f, 0xf6, 0xcc, 0x37, 0xdf, 0x37, 0xbf, 0x5a, 0x74,
+	0x26, 0x01, 0x54, 0x22, 0x95, 0x90, 0x42, 0xd3, 0x32, 0x96, 0x4a, 0x18, 0x81, 0xdb, 0xd5, 0x8f,
+	0x1e, 0x9e, 0x57, 0x60, 0x36, 0xa7, 0x8c, 0x67, 0x22, 0x07, 0x87, 0x0e, 0x87, 0xbb, 0xde, 0x29,
+	0xac, 0x80, 0x1b, 0x8f, 0x5d, 0xee, 0xc8, 0x4d, 0x15, 0x68, 0x29, 0xb8, 0x0e, 0x4c, 0x62, 0xc4,
+	0x02, 0x78, 0x02, 0x4f, 0x12, 0x32, 0x43, 0x0d, 0x13, 0x5c, 0x3b, 0x24, 0xfa, 0x8e, 0x4e, 0xbf,
+	0xb1, 0x82, 0x43, 0x7e, 0xe7, 0xa9, 0xf8, 0x25, 0x3a, 0xad, 0x65, 0x66, 0x6b, 0x03, 0x9a, 0x34,
+	0xae, 0x1a, 0xd7, 0xdd, 0xf4, 0x45, 0xf0, 0x8e, 0xad, 0x13, 0x5f, 0xa2, 0x13, 0xcd, 0x0a, 0x4e,
+	0xcd, 0x52, 0x01, 0x69, 0x56, 0x11, 0x1b, 0x47, 0xf4, 0x88, 0x8e, 0x6b, 0xc1, 0x0b, 0xd4, 0x9e,
+	0x03, 0xcd, 0x41, 0x79, 0x21, 0x6f, 0x61, 0x82, 0x9e, 0x4b, 0xba, 0x2e, 0x05, 0xcd, 0x3d, 0x3f,
+	0x98, 0x56, 0x1b, 0x9e, 0x0c, 0x70, 0xcd, 0x04, 0x27, 0x2d, 0xa7, 0x5d, 0x3b, 0xa2, 0xdf, 0x0d,
+	0x44, 0x3e, 0x85, 0x21, 0x7c, 0xa9, 0xb4, 0x26, 0x01, 0xc4, 0x6f, 0x10, 0xf6, 0x2a, 0xd3, 0x15,
+	0xd3, 0x6c, 0xc6, 0x4a, 0x66, 0xd6, 0x3e, 0xf1, 0xc0, 0x23, 0x0f, 0x35, 0x80, 0xdf, 0xa1, 0xee,
+	0x66, 0x9e, 0xcc, 0x15, 0xd2, 0x19, 0x9d, 0xb9, 0xe1, 0xe8, 0xb8, 0x4e, 0x73, 0xfb, 0x39, 0xed,
+	0xd4, 0x81, 0xb7, 0x79, 0xf4, 0x77, 0xbb, 0x86, 0xd0, 0xe9, 0x9d, 0x2f, 0xff, 0x1c, 0x1d, 0x31,
+	0x2e, 0x97, 0xc6, 0xa7, 0x75, 0x06, 0x7e, 0x40, 0xdd, 0x7b, 0x45, 0xb9, 0x66, 0xc0, 0xcd, 0x57,
+	0x2a, 0x49, 0xf3, 0xaa, 0x75, 0xdd, 0x19, 0x8d, 0x0e, 0x52, 0xed, 0xa9, 0xc5, 0xdb, 0xa4, 0x09,
+	0x37, 0x6a, 0x9d, 0xee, 0xe8, 0x0c, 0x3f, 0xa2, 0xc1, 0x41, 0x08, 0xee, 0xa3, 0xd6, 0x02, 0x5c,
+	0xdf, 0x27, 0xa9, 0xfd, 0x6b, 0x8b, 0x5a, 0xd1, 0x72, 0x19, 0x76, 0xe5, 0x8c, 0x0f, 0xcd, 0xf7,
+	0x8d, 0xe8, 0x4f, 0x13, 0xf5, 0xea, 0xec, 0x37, 0x99, 0xbd, 0x0e, 0xbb, 0x1b, 0x05, 0x7a, 0x59,
+	0x9a, 0xb0, 0xfd, 0x60, 0xda, 0x6d, 0x56, 0x77, 0xa7, 0xbd, 0x90, 0xb7, 0xf0, 0x6b, 0x74, 0x1c,
+	0x8e, 0xae, 0x5a, 0x59, 0x67, 0xd4, 0x0f, 0xad, 0xa5, 0xde, 0x9f, 0xd6, 0x11, 0x07, 0x73, 0x7f,
+	0xf6, 0x7f, 0x73, 0xc7, 0x13, 0x34, 0xa8, 0x4e, 0x79, 0xba, 0x75, 0xca, 0xe4, 0xa8, 0x22, 0x93,
+	0x40, 0xbe, 0xb7, 0x01, 0x93, 0x0d, 0x9e, 0xf6, 0xcd, 0x9e, 0x07, 0xdf, 0xa0, 0xfe, 0xde, 0x33,
+	0xd2, 0xa4, 0x5d, 0xed, 0xe3, 0xe2, 0xa0, 0x84, 0x89, 0x85, 0xd3, 0x5e, 0xb6, 0x63, 0xeb, 0xf1,
+	0x0f, 0x14, 0x09, 0x55, 0xc4, 0xf3, 0xb5, 0x04, 0x55, 0x42, 0x5e, 0x80, 0x8a, 0x7f, 0xd2, 0x99,
+	0x62, 0x59, 0x10, 0xb0, 0x0f, 0x72, 0xdc, 0xdb, 0x6c, 0x33, 0x5b, 0xd0, 0x02, 0x1e, 0x5f, 0x15,
+	0xcc, 0xcc, 0x97, 0xb3, 0x38, 0x13, 0xbf, 0x92, 0x2d, 0x6e, 0xe2, 0xb8, 0x89, 0xe3, 0x26, 0x96,
+	0x3b, 0x73, 0x1f, 0x83, 0xb7, 0xff, 0x06, 0x00, 0xa3, 0x97, 0x2b, 0xcb, 0x2a, 0x04, 0x00, 0x00,
 }
@@ -48,6 +48,7 @@ const (
 	TxValidationCode_BAD_RWSET                    TxValidationCode = 22
 	TxValidationCode_ILLEGAL_WRITESET             TxValidationCode = 23
 	TxValidationCode_INVALID_WRITESET             TxValidationCode = 24
+	TxValidationCode_CROSS_CHANNEL_LOCK_CONFLICT  TxValidationCode = 25
 	TxValidationCode_NOT_VALIDATED                TxValidationCode = 254
 	TxValidationCode_INVALID_OTHER_REASON         TxValidationCode = 255
 )
@@ -78,6 +79,7 @@ var TxValidationCode_name = map[int32]string{
 	22:  "BAD_RWSET",
 	23:  "ILLEGAL_WRITESET",
 	24:  "INVALID_WRITESET",
+	25:  "CROSS_CHANNEL_LOCK_CONFLICT",
 	254: "NOT_VALIDATED",
 	255: "INVALID_OTHER_REASON",
 }
@@ -107,6 +109,7 @@ var TxValidationCode_value = map[string]int32{
 	"BAD_RWSET":                    22,
 	"ILLEGAL_WRITESET":             23,
 	"INVALID_WRITESET":             24,
+	"CROSS_CHANNEL_LOCK_CONFLICT":  25,
 	"NOT_VALIDATED":                254,
 	"INVALID_OTHER_REASON":         255,
 }
@@ -115,7 +118,7 @@ func (x TxValidationCode) String() string {
 	return proto.EnumName(TxValidationCode_name, int32(x))
 }
 func (TxValidationCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{0}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{0}
 }
 
 // Reserved entries in the key-level metadata map
@@ -136,7 +139,7 @@ func (x MetaDataKeys) String() string {
 	return proto.EnumName(MetaDataKeys_name, int32(x))
 }
 func (MetaDataKeys) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{1}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{1}
 }
 
 // This message is necessary to facilitate the verification of the signature
@@ -159,7 +162,7 @@ func (m *SignedTransaction) Reset()         { *m = SignedTransaction{} }
 func (m *SignedTransaction) String() string { return proto.CompactTextString(m) }
 func (*SignedTransaction) ProtoMessage()    {}
 func (*SignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{0}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{0}
 }
 func (m *SignedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedTransaction.Unmarshal(m, b)
@@ -213,7 +216,7 @@ func (m *ProcessedTransaction) Reset()         { *m = ProcessedTransaction{} }
 func (m *ProcessedTransaction) String() string { return proto.CompactTextString(m) }
 func (*ProcessedTransaction) ProtoMessage()    {}
 func (*ProcessedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{1}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{1}
 }
 func (m *ProcessedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProcessedTransaction.Unmarshal(m, b)
@@ -272,7 +275,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{2}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{2}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -316,7 +319,7 @@ func (m *TransactionAction) Reset()         { *m = TransactionAction{} }
 func (m *TransactionAction) String() string { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()    {}
 func (*TransactionAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{3}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{3}
 }
 func (m *TransactionAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionAction.Unmarshal(m, b)
@@ -375,7 +378,7 @@ func (m *ChaincodeActionPayload) Reset()         { *m = ChaincodeActionPayload{}
 func (m *ChaincodeActionPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()    {}
 func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{4}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{4}
 }
 func (m *ChaincodeActionPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeActionPayload.Unmarshal(m, b)
@@ -428,7 +431,7 @@ func (m *ChaincodeEndorsedAction) Reset()         { *m = ChaincodeEndorsedAction
 func (m *ChaincodeEndorsedAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()    {}
 func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{5}
+	return fileDescriptor_transaction_099bbeef2700c35c, []int{5}
 }
 func (m *ChaincodeEndorsedAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeEndorsedAction.Unmarshal(m, b)
@@ -473,63 +476,66 @@ func init() {
 	proto.RegisterEnum("protos.MetaDataKeys", MetaDataKeys_name, MetaDataKeys_value)
 }
 
-func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_4fbd1a0e1a50cfab) }
-
-var fileDescriptor_transaction_4fbd1a0e1a50cfab = []byte{
-	// 877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x41, 0x6f, 0xe2, 0x46,
-	0x14, 0x5e, 0xb2, 0x4d, 0xd2, 0x0c, 0x24, 0x19, 0x06, 0x42, 0x08, 0x8a, 0xba, 0x2b, 0x0e, 0x55,
-	0xba, 0x95, 0x40, 0xca, 0x1e, 0x2a, 0x55, 0xbd, 0x0c, 0xf6, 0x24, 0x58, 0x6b, 0x66, 0xac, 0xf1,
-	0x40, 0x48, 0x0f, 0x1d, 0x19, 0x98, 0x25, 0xa8, 0x60, 0x23, 0xdb, 0x59, 0x35, 0xd7, 0xfe, 0x80,
-	0xf6, 0xd2, 0xdf, 0xdb, 0x56, 0xe3, 0xb1, 0x81, 0x24, 0xed, 0x05, 0x33, 0xdf, 0xfb, 0xde, 0x7b,
-	0xdf, 0xf7, 0x9e, 0x35, 0x06, 0x8d, 0xb5, 0x52, 0x71, 0x37, 0x8d, 0x83, 0x30, 0x09, 0xa6, 0xe9,
-	0x22, 0x0a, 0x3b, 0xeb, 0x38, 0x4a, 0x23, 0x74, 0x90, 0x3d, 0x92, 0xd6, 0xbb, 0x79, 0x14, 0xcd,
-	0x97, 0xaa, 0x9b, 0x1d, 0x27, 0x8f, 0x9f, 0xbb, 0xe9, 0x62, 0xa5, 0x92, 0x34, 0x58, 0xad, 0x0d,
-	0xb1, 0x75, 0x99, 0x15, 0x58, 0xc7, 0xd1, 0x3a, 0x4a, 0x82, 0xa5, 0x8c, 0x55, 0xb2, 0x8e, 0xc2,
-	0x44, 0xe5, 0xd1, 0xda, 0x34, 0x5a, 0xad, 0xa2, 0xb0, 0x6b, 0x1e, 0x06, 0x6c, 0xff, 0x02, 0xaa,
-	0xfe, 0x62, 0x1e, 0xaa, 0x99, 0xd8, 0xb6, 0x45, 0xdf, 0x83, 0xea, 0x8e, 0x0a, 0x39, 0x79, 0x4a,
-	0x55, 0xd2, 0x2c, 0xbd, 0x2f, 0x5d, 0x55, 0x38, 0xdc, 0x09, 0xf4, 0x34, 0x8e, 0x2e, 0xc1, 0x51,
-	0xb2, 0x98, 0x87, 0x41, 0xfa, 0x18, 0xab, 0xe6, 0x5e, 0x46, 0xda, 0x02, 0xed, 0xdf, 0x4b, 0xa0,
-	0xee, 0xc5, 0xd1, 0x54, 0x25, 0xc9, 0xf3, 0x1e, 0x3d, 0x50, 0xdb, 0x29, 0x45, 0xc2, 0x2f, 0x6a,
-	0x19, 0xad, 0x55, 0xd6, 0xa5, 0x7c, 0x0d, 0x3b, 0xb9, 0xc8, 0x02, 0xe7, 0xff, 0x45, 0x46, 0xdf,
-	0x82, 0x93, 0x2f, 0xc1, 0x72, 0x31, 0x0b, 0x34, 0x6a, 0x45, 0x33, 0xd3, 0x7f, 0x9f, 0xbf, 0x40,
-	0xdb, 0x3d, 0x50, 0xde, 0x6d, 0xfd, 0x11, 0x1c, 0x9a, 0x7f, 0xda, 0xd4, 0xdb, 0xab, 0xf2, 0xf5,
-	0x85, 0x19, 0x46, 0xd2, 0xd9, 0x61, 0xe1, 0xec, 0x97, 0x17, 0xcc, 0x36, 0x01, 0xd5, 0x57, 0x51,
-	0xd4, 0x00, 0x07, 0x0f, 0x2a, 0x98, 0xa9, 0x38, 0x9f, 0x4e, 0x7e, 0x42, 0x4d, 0x70, 0xb8, 0x0e,
-	0x9e, 0x96, 0x51, 0x30, 0xcb, 0x27, 0x52, 0x1c, 0xdb, 0x7f, 0x96, 0x40, 0xc3, 0x7a, 0x08, 0x16,
-	0xe1, 0x34, 0x9a, 0x29, 0x53, 0xc5, 0x33, 0x21, 0xf4, 0x13, 0x68, 0x4d, 0x8b, 0x88, 0xdc, 0x2c,
-	0xb1, 0xa8, 0x63, 0x1a, 0x34, 0x37, 0x0c, 0x2f, 0x27, 0x14, 0xd9, 0x3f, 0x80, 0x03, 0x23, 0x2d,
-	0xeb, 0x58, 0xbe, 0x7e, 0x57, 0x78, 0xda, 0x74, 0x23, 0xe1, 0x2c, 0x8a, 0x13, 0x35, 0xcb, 0x9d,
-	0xe5, 0xf4, 0xf6, 0x1f, 0x25, 0x70, 0xfe, 0x3f, 0x1c, 0xf4, 0x23, 0xb8, 0x78, 0xf5, 0x36, 0xbd,
-	0x50, 0x74, 0x5e, 0x10, 0x78, 0x1e, 0xdf, 0x0a, 0xaa, 0x28, 0x53, 0x6d, 0xa5, 0xc2, 0x34, 0x69,
-	0xee, 0x65, 0xa3, 0xae, 0x15, 0xb2, 0xc8, 0x36, 0xc6, 0x9f, 0x11, 0x3f, 0xfc, 0xb5, 0x0f, 0xa0,
-	0xf8, 0x6d, 0xf4, 0x6c, 0x85, 0xe8, 0x08, 0xec, 0x8f, 0xb0, 0xeb, 0xd8, 0xf0, 0x0d, 0x82, 0xa0,
-	0x42, 0x1d, 0x57, 0x12, 0x3a, 0x22, 0x2e, 0xf3, 0x08, 0x2c, 0xa1, 0x53, 0x50, 0xee, 0x61, 0x5b,
-	0x7a, 0xf8, 0xde, 0x65, 0xd8, 0x86, 0x7b, 0xe8, 0x0c, 0x54, 0x35, 0x60, 0xb1, 0xc1, 0x80, 0x51,
-	0xd9, 0x27, 0xd8, 0x26, 0x1c, 0xbe, 0x45, 0x17, 0xe0, 0x2c, 0x83, 0x39, 0xc1, 0x82, 0x71, 0xe9,
-	0x3b, 0xb7, 0x14, 0x8b, 0x21, 0x27, 0xf0, 0x2b, 0xf4, 0x1e, 0x5c, 0x3a, 0x34, 0xeb, 0x20, 0x09,
-	0xb5, 0x19, 0xf7, 0x09, 0x97, 0x82, 0x63, 0xea, 0x63, 0x4b, 0x38, 0x8c, 0xc2, 0x7d, 0xf4, 0x0d,
-	0x68, 0x15, 0x0c, 0x8b, 0xd1, 0x1b, 0xe7, 0xf6, 0x59, 0xfc, 0x00, 0xb5, 0x40, 0x63, 0x48, 0xfd,
-	0xa1, 0xe7, 0x31, 0x2e, 0x88, 0x2d, 0xc5, 0x78, 0xa3, 0xe7, 0xb0, 0xd0, 0xe3, 0x71, 0xe6, 0x31,
-	0x1f, 0xbb, 0x52, 0x8c, 0x1d, 0x1b, 0x7e, 0x8d, 0x10, 0x38, 0xb1, 0x87, 0x9e, 0xeb, 0x58, 0x58,
-	0x10, 0x83, 0x1d, 0xe9, 0x36, 0xb9, 0x80, 0x01, 0xa1, 0x42, 0x7a, 0xcc, 0x75, 0xac, 0x7b, 0x79,
-	0x83, 0x1d, 0x57, 0x0b, 0x05, 0xa8, 0x01, 0xd0, 0x60, 0x64, 0x59, 0x92, 0x13, 0x6c, 0x84, 0xb8,
-	0x8e, 0x25, 0x60, 0x59, 0x7b, 0xf3, 0xfa, 0x98, 0x0a, 0x36, 0x78, 0x11, 0xaa, 0xa0, 0x1a, 0x38,
-	0x1d, 0xd2, 0x4f, 0x94, 0xdd, 0x51, 0xad, 0x4a, 0xdc, 0x7b, 0x04, 0x1e, 0x6b, 0xb9, 0x02, 0xf3,
-	0x5b, 0x22, 0xa4, 0xd5, 0xc7, 0x0e, 0x95, 0x94, 0x09, 0x79, 0xc3, 0x86, 0xd4, 0x86, 0x27, 0xa8,
-	0x0e, 0xe0, 0x00, 0x73, 0xbf, 0x9f, 0x29, 0x95, 0x84, 0x73, 0xc6, 0xe1, 0x69, 0x31, 0x77, 0x31,
-	0xce, 0x2d, 0x43, 0x6d, 0x8b, 0x8c, 0x3d, 0x87, 0x13, 0xdb, 0x14, 0xb1, 0x98, 0x4d, 0x60, 0x55,
-	0x5b, 0xd8, 0x1c, 0xe5, 0x88, 0x70, 0xdf, 0x61, 0x74, 0xab, 0x07, 0xa1, 0x26, 0xa8, 0xeb, 0x69,
-	0x98, 0xb5, 0x48, 0x32, 0x16, 0x84, 0x6a, 0x0a, 0xac, 0x69, 0x73, 0xd9, 0x82, 0xfa, 0x98, 0x52,
-	0xe2, 0x16, 0x8b, 0xab, 0x17, 0x19, 0x9c, 0xf8, 0x1e, 0xa3, 0x3e, 0xd9, 0x4c, 0xf6, 0x0c, 0x1d,
-	0x83, 0xa3, 0x2c, 0x72, 0xe7, 0x13, 0x01, 0x1b, 0x5a, 0xb9, 0xe3, 0xba, 0xe4, 0x16, 0xbb, 0xf2,
-	0x8e, 0x3b, 0x82, 0x68, 0xf4, 0x3c, 0x43, 0xf3, 0xd5, 0x6d, 0xd0, 0x26, 0x42, 0xe0, 0x58, 0x9b,
-	0xce, 0x70, 0x2c, 0x88, 0x0d, 0xff, 0x2e, 0xa1, 0x0b, 0x50, 0x2f, 0x98, 0x4c, 0xf4, 0x09, 0xd7,
-	0xb3, 0xf4, 0x19, 0x85, 0xff, 0x94, 0x3e, 0x5c, 0x81, 0xca, 0x40, 0xa5, 0x81, 0x1d, 0xa4, 0xc1,
-	0x27, 0xf5, 0x94, 0x68, 0x4d, 0x79, 0xaa, 0xb6, 0xe7, 0x61, 0x8e, 0x07, 0x44, 0x10, 0x0e, 0xdf,
-	0xf4, 0xa6, 0xa0, 0x1d, 0xc5, 0xf3, 0xce, 0xc3, 0xd3, 0x5a, 0xc5, 0x4b, 0x35, 0x9b, 0xab, 0xb8,
-	0xf3, 0x39, 0x98, 0xc4, 0x8b, 0x69, 0xf1, 0xee, 0xeb, 0x6b, 0xba, 0x87, 0x76, 0xae, 0x13, 0x2f,
-	0x98, 0xfe, 0x1a, 0xcc, 0xd5, 0xcf, 0xdf, 0xcd, 0x17, 0xe9, 0xc3, 0xe3, 0x44, 0xdf, 0x7e, 0xdd,
-	0x9d, 0xf4, 0xae, 0x49, 0x37, 0x17, 0x7f, 0xd2, 0xd5, 0xe9, 0x13, 0xf3, 0x51, 0xf8, 0xf8, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xba, 0xd3, 0xb2, 0x32, 0x35, 0x06, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_099bbeef2700c35c)
+}
+
+var fileDescriptor_transaction_099bbeef2700c35c = []byte{
+	// 890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x5d, 0x6f, 0xe2, 0x46,
+	0x14, 0x5d, 0xb2, 0x4d, 0xd2, 0x0c, 0x24, 0x19, 0x06, 0x42, 0x80, 0x46, 0xcd, 0x8a, 0x87, 0x2a,
+	0xdd, 0x4a, 0x20, 0x65, 0x1f, 0x2a, 0x55, 0x7d, 0x19, 0xec, 0x49, 0xb0, 0x62, 0x66, 0xac, 0xf1,
+	0x40, 0x48, 0x1f, 0x3a, 0x32, 0x30, 0x4b, 0x50, 0xc1, 0xb6, 0x6c, 0x67, 0xd5, 0xbc, 0xf6, 0x07,
+	0xb4, 0xbf, 0xa6, 0x7f, 0xaf, 0xad, 0xc6, 0x1f, 0x40, 0xb2, 0xdd, 0x17, 0xcc, 0x9c, 0x7b, 0xee,
+	0x3d, 0xe7, 0xde, 0x0b, 0x63, 0xd0, 0x08, 0x95, 0x8a, 0x7a, 0x49, 0xe4, 0xf9, 0xb1, 0x37, 0x4b,
+	0x96, 0x81, 0xdf, 0x0d, 0xa3, 0x20, 0x09, 0xd0, 0x41, 0xfa, 0x88, 0xdb, 0x97, 0x8b, 0x20, 0x58,
+	0xac, 0x54, 0x2f, 0x3d, 0x4e, 0x9f, 0x3e, 0xf6, 0x92, 0xe5, 0x5a, 0xc5, 0x89, 0xb7, 0x0e, 0x33,
+	0x62, 0xfb, 0x22, 0x2d, 0x10, 0x46, 0x41, 0x18, 0xc4, 0xde, 0x4a, 0x46, 0x2a, 0x0e, 0x03, 0x3f,
+	0x56, 0x79, 0xb4, 0x36, 0x0b, 0xd6, 0xeb, 0xc0, 0xef, 0x65, 0x8f, 0x0c, 0xec, 0xfc, 0x0a, 0xaa,
+	0xee, 0x72, 0xe1, 0xab, 0xb9, 0xd8, 0xca, 0xa2, 0x1f, 0x40, 0x75, 0xc7, 0x85, 0x9c, 0x3e, 0x27,
+	0x2a, 0x6e, 0x96, 0xde, 0x95, 0xae, 0x2a, 0x1c, 0xee, 0x04, 0xfa, 0x1a, 0x47, 0x17, 0xe0, 0x28,
+	0x5e, 0x2e, 0x7c, 0x2f, 0x79, 0x8a, 0x54, 0x73, 0x2f, 0x25, 0x6d, 0x81, 0xce, 0x1f, 0x25, 0x50,
+	0x77, 0xa2, 0x60, 0xa6, 0xe2, 0xf8, 0xa5, 0x46, 0x1f, 0xd4, 0x76, 0x4a, 0x11, 0xff, 0x93, 0x5a,
+	0x05, 0xa1, 0x4a, 0x55, 0xca, 0xd7, 0xb0, 0x9b, 0x9b, 0x2c, 0x70, 0xfe, 0x7f, 0x64, 0xf4, 0x1d,
+	0x38, 0xf9, 0xe4, 0xad, 0x96, 0x73, 0x4f, 0xa3, 0x46, 0x30, 0xcf, 0xf4, 0xf7, 0xf9, 0x2b, 0xb4,
+	0xd3, 0x07, 0xe5, 0x5d, 0xe9, 0x0f, 0xe0, 0x30, 0xfb, 0xa6, 0x9b, 0x7a, 0x7b, 0x55, 0xbe, 0x6e,
+	0x65, 0xc3, 0x88, 0xbb, 0x3b, 0x2c, 0x9c, 0x7e, 0xf2, 0x82, 0xd9, 0x21, 0xa0, 0xfa, 0x59, 0x14,
+	0x35, 0xc0, 0xc1, 0xa3, 0xf2, 0xe6, 0x2a, 0xca, 0xa7, 0x93, 0x9f, 0x50, 0x13, 0x1c, 0x86, 0xde,
+	0xf3, 0x2a, 0xf0, 0xe6, 0xf9, 0x44, 0x8a, 0x63, 0xe7, 0xaf, 0x12, 0x68, 0x18, 0x8f, 0xde, 0xd2,
+	0x9f, 0x05, 0x73, 0x95, 0x55, 0x71, 0xb2, 0x10, 0xfa, 0x19, 0xb4, 0x67, 0x45, 0x44, 0x6e, 0x96,
+	0x58, 0xd4, 0xc9, 0x04, 0x9a, 0x1b, 0x86, 0x93, 0x13, 0x8a, 0xec, 0x1f, 0xc1, 0x41, 0x66, 0x2d,
+	0x55, 0x2c, 0x5f, 0x5f, 0x16, 0x3d, 0x6d, 0xd4, 0x88, 0x3f, 0x0f, 0xa2, 0x58, 0xcd, 0xf3, 0xce,
+	0x72, 0x7a, 0xe7, 0xcf, 0x12, 0x38, 0xff, 0x02, 0x07, 0xfd, 0x04, 0x5a, 0x9f, 0xfd, 0x9a, 0x5e,
+	0x39, 0x3a, 0x2f, 0x08, 0x3c, 0x8f, 0x6f, 0x0d, 0x55, 0x54, 0x56, 0x6d, 0xad, 0xfc, 0x24, 0x6e,
+	0xee, 0xa5, 0xa3, 0xae, 0x15, 0xb6, 0xc8, 0x36, 0xc6, 0x5f, 0x10, 0xdf, 0xff, 0xbd, 0x0f, 0xa0,
+	0xf8, 0x7d, 0xfc, 0x62, 0x85, 0xe8, 0x08, 0xec, 0x8f, 0xb1, 0x6d, 0x99, 0xf0, 0x0d, 0x82, 0xa0,
+	0x42, 0x2d, 0x5b, 0x12, 0x3a, 0x26, 0x36, 0x73, 0x08, 0x2c, 0xa1, 0x53, 0x50, 0xee, 0x63, 0x53,
+	0x3a, 0xf8, 0xc1, 0x66, 0xd8, 0x84, 0x7b, 0xe8, 0x0c, 0x54, 0x35, 0x60, 0xb0, 0xe1, 0x90, 0x51,
+	0x39, 0x20, 0xd8, 0x24, 0x1c, 0xbe, 0x45, 0x2d, 0x70, 0x96, 0xc2, 0x9c, 0x60, 0xc1, 0xb8, 0x74,
+	0xad, 0x5b, 0x8a, 0xc5, 0x88, 0x13, 0xf8, 0x15, 0x7a, 0x07, 0x2e, 0x2c, 0x9a, 0x2a, 0x48, 0x42,
+	0x4d, 0xc6, 0x5d, 0xc2, 0xa5, 0xe0, 0x98, 0xba, 0xd8, 0x10, 0x16, 0xa3, 0x70, 0x1f, 0x7d, 0x0b,
+	0xda, 0x05, 0xc3, 0x60, 0xf4, 0xc6, 0xba, 0x7d, 0x11, 0x3f, 0x40, 0x6d, 0xd0, 0x18, 0x51, 0x77,
+	0xe4, 0x38, 0x8c, 0x0b, 0x62, 0x4a, 0x31, 0xd9, 0xf8, 0x39, 0x2c, 0xfc, 0x38, 0x9c, 0x39, 0xcc,
+	0xc5, 0xb6, 0x14, 0x13, 0xcb, 0x84, 0x5f, 0x23, 0x04, 0x4e, 0xcc, 0x91, 0x63, 0x5b, 0x06, 0x16,
+	0x24, 0xc3, 0x8e, 0xb4, 0x4c, 0x6e, 0x60, 0x48, 0xa8, 0x90, 0x0e, 0xb3, 0x2d, 0xe3, 0x41, 0xde,
+	0x60, 0xcb, 0xd6, 0x46, 0x01, 0x6a, 0x00, 0x34, 0x1c, 0x1b, 0x86, 0xe4, 0x04, 0x67, 0x46, 0x6c,
+	0xcb, 0x10, 0xb0, 0xac, 0x7b, 0x73, 0x06, 0x98, 0x0a, 0x36, 0x7c, 0x15, 0xaa, 0xa0, 0x1a, 0x38,
+	0x1d, 0xd1, 0x3b, 0xca, 0xee, 0xa9, 0x76, 0x25, 0x1e, 0x1c, 0x02, 0x8f, 0xb5, 0x5d, 0x81, 0xf9,
+	0x2d, 0x11, 0xd2, 0x18, 0x60, 0x8b, 0x4a, 0xca, 0x84, 0xbc, 0x61, 0x23, 0x6a, 0xc2, 0x13, 0x54,
+	0x07, 0x70, 0x88, 0xb9, 0x3b, 0x48, 0x9d, 0x4a, 0xc2, 0x39, 0xe3, 0xf0, 0xb4, 0x98, 0xbb, 0x98,
+	0xe4, 0x2d, 0x43, 0xdd, 0x16, 0x99, 0x38, 0x16, 0x27, 0x66, 0x56, 0xc4, 0x60, 0x26, 0x81, 0x55,
+	0xdd, 0xc2, 0xe6, 0x28, 0xc7, 0x84, 0xbb, 0x16, 0xa3, 0x5b, 0x3f, 0x08, 0x35, 0x41, 0x5d, 0x4f,
+	0x23, 0x5b, 0x8b, 0x24, 0x13, 0x41, 0xa8, 0xa6, 0xc0, 0x9a, 0x6e, 0x2e, 0x5d, 0xd0, 0x00, 0x53,
+	0x4a, 0xec, 0x62, 0x71, 0xf5, 0x22, 0x83, 0x13, 0xd7, 0x61, 0xd4, 0x25, 0x9b, 0xc9, 0x9e, 0xa1,
+	0x63, 0x70, 0x94, 0x46, 0xee, 0x5d, 0x22, 0x60, 0x43, 0x3b, 0xb7, 0x6c, 0x9b, 0xdc, 0x62, 0x5b,
+	0xde, 0x73, 0x4b, 0x10, 0x8d, 0x9e, 0xa7, 0x68, 0xbe, 0xba, 0x0d, 0xda, 0x44, 0x97, 0xe0, 0x1b,
+	0x83, 0x33, 0xd7, 0xdd, 0xc8, 0xd9, 0xcc, 0xb8, 0xdb, 0xfa, 0x6c, 0x21, 0x04, 0x8e, 0xf5, 0x54,
+	0xd2, 0x44, 0x2c, 0x88, 0x09, 0xff, 0x29, 0xa1, 0x16, 0xa8, 0x17, 0xa5, 0x98, 0x18, 0x10, 0xae,
+	0x87, 0xed, 0x32, 0x0a, 0xff, 0x2d, 0xbd, 0xbf, 0x02, 0x95, 0xa1, 0x4a, 0x3c, 0xd3, 0x4b, 0xbc,
+	0x3b, 0xf5, 0x1c, 0x6b, 0xd3, 0x79, 0xaa, 0xee, 0xdf, 0xc1, 0x1c, 0x0f, 0x89, 0x20, 0x1c, 0xbe,
+	0xe9, 0xcf, 0x40, 0x27, 0x88, 0x16, 0xdd, 0xc7, 0xe7, 0x50, 0x45, 0x2b, 0x35, 0x5f, 0xa8, 0xa8,
+	0xfb, 0xd1, 0x9b, 0x46, 0xcb, 0x59, 0xf1, 0xe7, 0xd0, 0xf7, 0x78, 0x1f, 0xed, 0xdc, 0x37, 0x8e,
+	0x37, 0xfb, 0xcd, 0x5b, 0xa8, 0x5f, 0xbe, 0x5f, 0x2c, 0x93, 0xc7, 0xa7, 0xa9, 0xbe, 0x1e, 0x7b,
+	0x3b, 0xe9, 0xbd, 0x2c, 0x3d, 0x7b, 0x33, 0xc4, 0x3d, 0x9d, 0x3e, 0xcd, 0xde, 0x1a, 0x1f, 0xfe,
+	0x1b, 0x00, 0x44, 0x7d, 0x1b, 0x86, 0x56, 0x06, 0x00, 0x00,
 }
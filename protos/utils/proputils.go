@@ -157,6 +157,12 @@ func GetChaincodeDeploymentSpec(code []byte, pr *platforms.Registry) (*peer.Chai
 		return nil, errors.Wrap(err, "error unmarshaling ChaincodeDeploymentSpec")
 	}
 
+	// the code package of a chaincode running as an external service only holds
+	// the information needed to connect to it, hence it has no platform to abide by
+	if cds.ExecEnv == peer.ChaincodeDeploymentSpec_EXTERNAL {
+		return cds, nil
+	}
+
 	// FAB-2122: Validate the CDS according to platform specific requirements
 	return cds, pr.ValidateDeploymentSpec(cds.CCType(), cds.Bytes())
 }
@@ -182,6 +188,23 @@ func GetChaincodeEvents(eBytes []byte) (*peer.ChaincodeEvent, error) {
 	return chaincodeEvent, errors.Wrap(err, "error unmarshaling ChaicnodeEvent")
 }
 
+// GetChaincodeActionEvents gets all the events of a ChaincodeAction, which
+// are either recorded as a list or, for the actions emitting a single event,
+// as the serialized event only. An action without events has none
+func GetChaincodeActionEvents(cAct *peer.ChaincodeAction) ([]*peer.ChaincodeEvent, error) {
+	if len(cAct.ChaincodeEvents) > 0 {
+		return cAct.ChaincodeEvents, nil
+	}
+	if len(cAct.Events) == 0 {
+		return nil, nil
+	}
+	event, err := GetChaincodeEvents(cAct.Events)
+	if err != nil {
+		return nil, err
+	}
+	return []*peer.ChaincodeEvent{event}, nil
+}
+
 // GetProposalResponsePayload gets the proposal response payload
 func GetProposalResponsePayload(prpBytes []byte) (*peer.ProposalResponsePayload, error) {
 	prp := &peer.ProposalResponsePayload{}
@@ -334,10 +357,18 @@ func CreateChaincodeProposalWithTxIDNonceAndTransient(txid string, typ common.He
 
 // GetBytesProposalResponsePayload gets proposal response payload
 func GetBytesProposalResponsePayload(hash []byte, response *peer.Response, result []byte, event []byte, ccid *peer.ChaincodeID) ([]byte, error) {
+	return GetBytesProposalResponsePayloadWithEvents(hash, response, result, event, nil, ccid)
+}
+
+// GetBytesProposalResponsePayloadWithEvents gets proposal response payload
+// whose chaincode action records all the events of a transaction emitting
+// more than one of them, in addition to the first one as event
+func GetBytesProposalResponsePayloadWithEvents(hash []byte, response *peer.Response, result []byte, event []byte, events []*peer.ChaincodeEvent, ccid *peer.ChaincodeID) ([]byte, error) {
 	cAct := &peer.ChaincodeAction{
 		Events: event, Results: result,
-		Response:    response,
-		ChaincodeId: ccid,
+		Response:        response,
+		ChaincodeId:     ccid,
+		ChaincodeEvents: events,
 	}
 	cActBytes, err := proto.Marshal(cAct)
 	if err != nil {
@@ -449,6 +480,52 @@ func GetActionFromEnvelopeMsg(env *common.Envelope) (*peer.ChaincodeAction, erro
 	return respPayload, err
 }
 
+// GetChaincodeEventsFromEnvelope extracts the chaincode events set by the
+// actions of the transaction in a serialized Envelope. An envelope which does
+// not carry an endorser transaction has no chaincode events.
+func GetChaincodeEventsFromEnvelope(envBytes []byte) ([]*peer.ChaincodeEvent, error) {
+	env, err := GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	payl, err := GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	if payl.Header == nil {
+		return nil, errors.New("missing header in Payload")
+	}
+	chdr, err := UnmarshalChannelHeader(payl.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+
+	tx, err := GetTransaction(payl.Data)
+	if err != nil {
+		return nil, err
+	}
+	var events []*peer.ChaincodeEvent
+	for _, action := range tx.Actions {
+		_, respPayload, err := GetPayloads(action)
+		if err != nil {
+			return nil, err
+		}
+		actionEvents, err := GetChaincodeActionEvents(respPayload)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range actionEvents {
+			if event.ChaincodeId != "" {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
 // CreateProposalFromCISAndTxid returns a proposal given a serialized identity
 // and a ChaincodeInvocationSpec
 func CreateProposalFromCISAndTxid(txid string, typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte) (*peer.Proposal, string, error) {
@@ -187,6 +187,15 @@ func TestGetChaincodeDeploymentSpec(t *testing.T) {
 	_, err = utils.GetChaincodeDeploymentSpec(cds, pr)
 	assert.Error(t, err, "Expected error with invalid spec type")
 
+	// the code package of an external chaincode isn't validated by the platform
+	cds, _ = proto.Marshal(&pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type: pb.ChaincodeSpec_UNDEFINED,
+		},
+		ExecEnv: pb.ChaincodeDeploymentSpec_EXTERNAL,
+	})
+	_, err = utils.GetChaincodeDeploymentSpec(cds, pr)
+	assert.NoError(t, err, "Unexpected error getting external deployment spec")
 }
 
 func TestCDSProposals(t *testing.T) {
@@ -602,6 +611,81 @@ func TestEnvelope(t *testing.T) {
 	}
 }
 
+func TestGetChaincodeEventsFromEnvelope(t *testing.T) {
+	prop, txid, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), signerSerialized)
+	assert.NoError(t, err)
+	response := &pb.Response{Status: 200}
+	ccid := &pb.ChaincodeID{Name: "foo", Version: "v1"}
+	event := &pb.ChaincodeEvent{ChaincodeId: "foo", TxId: txid, EventName: "bar", Payload: []byte("payload")}
+	eventBytes, err := proto.Marshal(event)
+	assert.NoError(t, err)
+
+	for _, testCase := range []struct {
+		eventBytes     []byte
+		expectedEvents []*pb.ChaincodeEvent
+	}{
+		{eventBytes: eventBytes, expectedEvents: []*pb.ChaincodeEvent{event}},
+		{eventBytes: nil, expectedEvents: nil},
+	} {
+		presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, response, []byte("res"), testCase.eventBytes, ccid, nil, signer)
+		assert.NoError(t, err)
+		tx, err := utils.CreateSignedTx(prop, signer, presp)
+		assert.NoError(t, err)
+		envBytes, err := utils.GetBytesEnvelope(tx)
+		assert.NoError(t, err)
+
+		events, err := utils.GetChaincodeEventsFromEnvelope(envBytes)
+		assert.NoError(t, err)
+		assert.Len(t, events, len(testCase.expectedEvents))
+		for i, expectedEvent := range testCase.expectedEvents {
+			assert.True(t, proto.Equal(expectedEvent, events[i]))
+		}
+	}
+
+	configEnv, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, util.GetTestChainID(), nil, &common.ConfigEnvelope{}, 0, 0)
+	assert.NoError(t, err)
+	envBytes, err := utils.GetBytesEnvelope(configEnv)
+	assert.NoError(t, err)
+	events, err := utils.GetChaincodeEventsFromEnvelope(envBytes)
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+
+	_, err = utils.GetChaincodeEventsFromEnvelope([]byte("garbage"))
+	assert.Error(t, err)
+}
+
+func TestGetChaincodeActionEvents(t *testing.T) {
+	event1 := &pb.ChaincodeEvent{ChaincodeId: "foo", TxId: "txid", EventName: "event1"}
+	event2 := &pb.ChaincodeEvent{ChaincodeId: "foo", TxId: "txid", EventName: "event2"}
+	event1Bytes, err := proto.Marshal(event1)
+	assert.NoError(t, err)
+
+	prpBytes, err := utils.GetBytesProposalResponsePayloadWithEvents([]byte("hash"), &pb.Response{Status: 200}, []byte("res"), event1Bytes, []*pb.ChaincodeEvent{event1, event2}, &pb.ChaincodeID{Name: "foo"})
+	assert.NoError(t, err)
+	prp, err := utils.GetProposalResponsePayload(prpBytes)
+	assert.NoError(t, err)
+	act, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+	events, err := utils.GetChaincodeActionEvents(act)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.True(t, proto.Equal(event1, events[0]))
+	assert.True(t, proto.Equal(event2, events[1]))
+
+	// the actions emitting a single event only carry it serialized
+	events, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{Events: event1Bytes})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.True(t, proto.Equal(event1, events[0]))
+
+	events, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{})
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+
+	_, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{Events: []byte("garbage")})
+	assert.Error(t, err)
+}
+
 func TestProposalTxID(t *testing.T) {
 	nonce := []byte{1}
 	creator := []byte{2}
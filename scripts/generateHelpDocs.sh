@@ -60,7 +60,7 @@ cat docs/wrappers/peer_logging_postscript.md >> $DOC
 DOC=docs/source/commands/peernode.md
 cat docs/wrappers/peer_node_preamble.md > $DOC
 
-for x in "peer node start" "peer node status" "peer node reset" "peer node rollback"; do
+for x in "peer node start" "peer node status" "peer node reset" "peer node rollback" "peer node verify" "peer node rebuild-dbs" "peer node unjoin"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC